@@ -11,6 +11,7 @@ type OidcAuthMethodAttributes struct {
 	SigningAlgorithms                 []string `json:"signing_algorithms,omitempty"`
 	ApiUrlPrefix                      string   `json:"api_url_prefix,omitempty"`
 	CallbackUrl                       string   `json:"callback_url,omitempty"`
+	BackchannelLogoutUrl              string   `json:"backchannel_logout_url,omitempty"`
 	IdpCaCerts                        []string `json:"idp_ca_certs,omitempty"`
 	AllowedAudiences                  []string `json:"allowed_audiences,omitempty"`
 	ClaimsScopes                      []string `json:"claims_scopes,omitempty"`
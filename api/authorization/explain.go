@@ -0,0 +1,72 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type ExplanationResult struct {
+	Item     *Explanation
+	response *api.Response
+}
+
+func (n ExplanationResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n ExplanationResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// Explain simulates the authorization check performed when a user requests an
+// action on a resource in the given scope, without performing the action. The
+// user is given with WithUserId or WithAuthTokenId, and the resource with
+// WithResourceId, WithResourceType and WithPin.
+func (c *Client) Explain(ctx context.Context, scopeId, action string, opt ...Option) (*ExplanationResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into Explain request")
+	}
+	if action == "" {
+		return nil, fmt.Errorf("empty action value passed into Explain request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.postMap["scope_id"] = scopeId
+	opts.postMap["action"] = action
+
+	req, err := c.client.NewRequest(ctx, "POST", "authorization:explain", opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Explain request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Explain call: %w", err)
+	}
+
+	target := new(ExplanationResult)
+	target.Item = new(Explanation)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Explain response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -0,0 +1,36 @@
+// Code generated by "make api"; DO NOT EDIT.
+package authorization
+
+import (
+	"github.com/hashicorp/boundary/api"
+)
+
+type Explanation struct {
+	UserId         string           `json:"user_id,omitempty"`
+	ScopeId        string           `json:"scope_id,omitempty"`
+	ResourceId     string           `json:"resource_id,omitempty"`
+	ResourceType   string           `json:"resource_type,omitempty"`
+	Pin            string           `json:"pin,omitempty"`
+	Action         string           `json:"action,omitempty"`
+	Authorized     bool             `json:"authorized,omitempty"`
+	MatchingGrants []*MatchingGrant `json:"matching_grants,omitempty"`
+	OutputFields   []string         `json:"output_fields,omitempty"`
+}
+
+// Client is a client for this collection
+type Client struct {
+	client *api.Client
+}
+
+// Creates a new client for this collection. The submitted API client is cloned;
+// modifications to it after generating this client will not have effect. If you
+// need to make changes to the underlying API client, use ApiClient() to access
+// it.
+func NewClient(c *api.Client) *Client {
+	return &Client{client: c.Clone()}
+}
+
+// ApiClient returns the underlying API client
+func (c *Client) ApiClient() *api.Client {
+	return c.client
+}
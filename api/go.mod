@@ -11,7 +11,11 @@ require (
 	github.com/hashicorp/go-kms-wrapping v0.6.1
 	github.com/hashicorp/go-retryablehttp v0.6.8
 	github.com/hashicorp/go-rootcerts v1.0.2
+	github.com/hashicorp/yamux v0.0.0-20181012175058-2f1d1f20f75d
+	github.com/mr-tron/base58 v1.2.0
 	github.com/stretchr/testify v1.7.0
 	golang.org/x/time v0.0.0-20200630173020-3af7569d3a1e
 	google.golang.org/grpc v1.35.0
+	google.golang.org/protobuf v1.25.0
+	nhooyr.io/websocket v1.8.7
 )
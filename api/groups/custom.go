@@ -0,0 +1,65 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type ResolvedMembersResult struct {
+	Item     *ResolvedMembers
+	response *api.Response
+}
+
+func (n ResolvedMembersResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n ResolvedMembersResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ReadResolvedMembers returns the users that are members of the group, either
+// directly or through the groups nested in it.
+func (c *Client) ReadResolvedMembers(ctx context.Context, groupId string, opt ...Option) (*ResolvedMembersResult, error) {
+	if groupId == "" {
+		return nil, fmt.Errorf("empty groupId value passed into ReadResolvedMembers request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("groups/%s:read-resolved-members", groupId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ReadResolvedMembers request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ReadResolvedMembers call: %w", err)
+	}
+
+	target := new(ResolvedMembersResult)
+	target.Item = new(ResolvedMembers)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ReadResolvedMembers response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
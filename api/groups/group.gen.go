@@ -23,6 +23,7 @@ type Group struct {
 	Version           uint32            `json:"version,omitempty"`
 	MemberIds         []string          `json:"member_ids,omitempty"`
 	Members           []*Member         `json:"members,omitempty"`
+	OwnerId           string            `json:"owner_id,omitempty"`
 	AuthorizedActions []string          `json:"authorized_actions,omitempty"`
 
 	response *api.Response
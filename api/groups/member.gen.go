@@ -4,4 +4,5 @@ package groups
 type Member struct {
 	Id      string `json:"id,omitempty"`
 	ScopeId string `json:"scope_id,omitempty"`
+	Type    string `json:"type,omitempty"`
 }
@@ -0,0 +1,49 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+)
+
+const ownerIdPostBodyKey = "owner_id"
+
+// TransferOwnership makes the given user the owner of the group. Grants
+// using the "{{owner}}" template match the resources the user owns.
+func (c *Client) TransferOwnership(ctx context.Context, groupId string, ownerId string, opt ...Option) (*GroupUpdateResult, error) {
+	if groupId == "" {
+		return nil, fmt.Errorf("empty groupId value passed into TransferOwnership request")
+	}
+	if ownerId == "" {
+		return nil, fmt.Errorf("empty ownerId value passed into TransferOwnership request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in TransferOwnership request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	reqBody := opts.postMap
+	reqBody[ownerIdPostBodyKey] = ownerId
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("groups/%s:transfer-ownership", groupId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating TransferOwnership request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during TransferOwnership call: %w", err)
+	}
+
+	g := new(GroupUpdateResult)
+	g.Item = new(Group)
+	apiErr, err := resp.Decode(g.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding TransferOwnership response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	g.response = resp
+	return g, nil
+}
@@ -0,0 +1,8 @@
+// Code generated by "make api"; DO NOT EDIT.
+package groups
+
+type ResolvedMember struct {
+	Id       string   `json:"id,omitempty"`
+	ScopeId  string   `json:"scope_id,omitempty"`
+	GroupIds []string `json:"group_ids,omitempty"`
+}
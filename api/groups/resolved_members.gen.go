@@ -0,0 +1,7 @@
+// Code generated by "make api"; DO NOT EDIT.
+package groups
+
+type ResolvedMembers struct {
+	GroupId string            `json:"group_id,omitempty"`
+	Members []*ResolvedMember `json:"members,omitempty"`
+}
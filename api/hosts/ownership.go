@@ -0,0 +1,49 @@
+package hosts
+
+import (
+	"context"
+	"fmt"
+)
+
+const ownerIdPostBodyKey = "owner_id"
+
+// TransferOwnership makes the given user the owner of the host. Grants
+// using the "{{owner}}" template match the resources the user owns.
+func (c *Client) TransferOwnership(ctx context.Context, hostId string, ownerId string, opt ...Option) (*HostUpdateResult, error) {
+	if hostId == "" {
+		return nil, fmt.Errorf("empty hostId value passed into TransferOwnership request")
+	}
+	if ownerId == "" {
+		return nil, fmt.Errorf("empty ownerId value passed into TransferOwnership request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in TransferOwnership request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	reqBody := opts.postMap
+	reqBody[ownerIdPostBodyKey] = ownerId
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("hosts/%s:transfer-ownership", hostId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating TransferOwnership request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during TransferOwnership call: %w", err)
+	}
+
+	h := new(HostUpdateResult)
+	h.Item = new(Host)
+	apiErr, err := resp.Decode(h.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding TransferOwnership response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	h.response = resp
+	return h, nil
+}
@@ -0,0 +1,49 @@
+package hosts
+
+import (
+	"context"
+	"fmt"
+)
+
+const fingerprintPostBodyKey = "fingerprint"
+
+// RotateSshHostKey trusts the pending SSH host key of the host, which has the
+// given fingerprint, in place of the key pinned for it.
+func (c *Client) RotateSshHostKey(ctx context.Context, hostId string, fingerprint string, opt ...Option) (*HostUpdateResult, error) {
+	if hostId == "" {
+		return nil, fmt.Errorf("empty hostId value passed into RotateSshHostKey request")
+	}
+	if fingerprint == "" {
+		return nil, fmt.Errorf("empty fingerprint value passed into RotateSshHostKey request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RotateSshHostKey request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	reqBody := opts.postMap
+	reqBody[fingerprintPostBodyKey] = fingerprint
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("hosts/%s:rotate-ssh-host-key", hostId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RotateSshHostKey request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RotateSshHostKey call: %w", err)
+	}
+
+	target := new(HostUpdateResult)
+	target.Item = new(Host)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RotateSshHostKey response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
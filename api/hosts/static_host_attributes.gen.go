@@ -2,5 +2,7 @@
 package hosts
 
 type StaticHostAttributes struct {
-	Address string `json:"address,omitempty"`
+	Address                      string `json:"address,omitempty"`
+	SshHostKeyFingerprint        string `json:"ssh_host_key_fingerprint,omitempty"`
+	PendingSshHostKeyFingerprint string `json:"pending_ssh_host_key_fingerprint,omitempty"`
 }
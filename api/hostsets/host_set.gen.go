@@ -24,6 +24,8 @@ type HostSet struct {
 	Type              string                 `json:"type,omitempty"`
 	HostIds           []string               `json:"host_ids,omitempty"`
 	Attributes        map[string]interface{} `json:"attributes,omitempty"`
+	Labels            map[string]string      `json:"labels,omitempty"`
+	OwnerId           string                 `json:"owner_id,omitempty"`
 	AuthorizedActions []string               `json:"authorized_actions,omitempty"`
 
 	response *api.Response
@@ -0,0 +1,49 @@
+package hostsets
+
+import (
+	"context"
+	"fmt"
+)
+
+const ownerIdPostBodyKey = "owner_id"
+
+// TransferOwnership makes the given user the owner of the host set. Grants
+// using the "{{owner}}" template match the resources the user owns.
+func (c *Client) TransferOwnership(ctx context.Context, hostSetId string, ownerId string, opt ...Option) (*HostSetUpdateResult, error) {
+	if hostSetId == "" {
+		return nil, fmt.Errorf("empty hostSetId value passed into TransferOwnership request")
+	}
+	if ownerId == "" {
+		return nil, fmt.Errorf("empty ownerId value passed into TransferOwnership request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in TransferOwnership request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	reqBody := opts.postMap
+	reqBody[ownerIdPostBodyKey] = ownerId
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("host-sets/%s:transfer-ownership", hostSetId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating TransferOwnership request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during TransferOwnership call: %w", err)
+	}
+
+	hs := new(HostSetUpdateResult)
+	hs.Item = new(HostSet)
+	apiErr, err := resp.Decode(hs.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding TransferOwnership response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	hs.response = resp
+	return hs, nil
+}
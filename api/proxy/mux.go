@@ -0,0 +1,164 @@
+package proxy
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+
+	"github.com/hashicorp/yamux"
+	"nhooyr.io/websocket"
+)
+
+// Each stream of a multiplexed session begins with a result the worker sends
+// once it has authorized the stream's connection: a status byte, then the
+// uvarint length of a payload which is an encoded HandshakeResult if the
+// status is streamStatusConnected, and otherwise the reason the connection
+// was refused.
+const (
+	streamStatusConnected byte = 0
+	streamStatusRefused   byte = 1
+
+	maxStreamResultLength = 64 * 1024
+)
+
+var (
+	// errMuxUnsupported is returned by dialMux if the worker doesn't support
+	// multiplexing.
+	errMuxUnsupported = errors.New("worker doesn't support multiplexing")
+	// errStreamRefused is returned by readStreamResult if the worker
+	// refused the stream's connection.
+	errStreamRefused = errors.New("connection refused by worker")
+)
+
+// dialMux opens a stream of the multiplexed session, starting the session if
+// needed.
+func (p *ClientProxy) dialMux(ctx context.Context) (net.Conn, error) {
+	muxSession, err := p.getMuxSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := muxSession.Open()
+	if err != nil {
+		return nil, fmt.Errorf("error opening stream to worker: %w", err)
+	}
+
+	// Don't wait on the worker for longer than ctx allows
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			stream.Close()
+		case <-stopped:
+		}
+	}()
+
+	result, err := readStreamResult(stream)
+	if err != nil {
+		stream.Close()
+		switch {
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		case errors.Is(err, errStreamRefused):
+			// As with separate websockets, there's no reason to think we'd be
+			// able to authorize any more connections after one has failed
+			p.setConnsLeft(0)
+		}
+		return nil, err
+	}
+	if result.connectionsLeft != -1 {
+		p.setConnsLeft(result.connectionsLeft)
+	}
+	return stream, nil
+}
+
+// getMuxSession returns the multiplexed session, starting it if it isn't
+// running.
+func (p *ClientProxy) getMuxSession(ctx context.Context) (*yamux.Session, error) {
+	p.muxLock.Lock()
+	defer p.muxLock.Unlock()
+	if p.muxUnsupported {
+		return nil, errMuxUnsupported
+	}
+	if p.muxSession != nil && !p.muxSession.IsClosed() {
+		return p.muxSession, nil
+	}
+
+	wsConn, subprotocol, err := p.dialWorker(ctx, tcpProxyMuxV1, tcpProxyV1)
+	if err != nil {
+		return nil, err
+	}
+	if subprotocol != tcpProxyMuxV1 {
+		wsConn.Close(websocket.StatusNormalClosure, "")
+		p.muxUnsupported = true
+		return nil, errMuxUnsupported
+	}
+	if _, err := p.handshake(ctx, wsConn); err != nil {
+		return nil, err
+	}
+
+	config := yamux.DefaultConfig()
+	config.LogOutput = ioutil.Discard
+	muxSession, err := yamux.Client(websocket.NetConn(p.ctx, wsConn, websocket.MessageBinary), config)
+	if err != nil {
+		wsConn.Close(websocket.StatusInternalError, "")
+		return nil, fmt.Errorf("error starting multiplexed session: %w", err)
+	}
+	p.muxSession = muxSession
+	return muxSession, nil
+}
+
+func (p *ClientProxy) closeMux() {
+	p.muxLock.Lock()
+	defer p.muxLock.Unlock()
+	if p.muxSession != nil {
+		p.muxSession.Close()
+	}
+}
+
+// readStreamResult reads the result beginning a stream, returning an error
+// if the worker refused the connection.
+func readStreamResult(stream net.Conn) (*handshakeResult, error) {
+	// The reader mustn't consume more than the result
+	r := &byteReader{r: stream}
+	status, err := r.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("error reading stream result: %w", err)
+	}
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading stream result: %w", err)
+	}
+	if n > maxStreamResultLength {
+		return nil, errors.New("stream result is too long")
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(stream, payload); err != nil {
+		return nil, fmt.Errorf("error reading stream result: %w", err)
+	}
+	switch status {
+	case streamStatusConnected:
+		return decodeHandshakeResult(payload)
+	case streamStatusRefused:
+		return nil, fmt.Errorf("%w: %s", errStreamRefused, payload)
+	default:
+		return nil, fmt.Errorf("unknown stream status %d", status)
+	}
+}
+
+// byteReader reads single bytes without buffering.
+type byteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (b *byteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(b.r, b.buf[:]); err != nil {
+		return 0, err
+	}
+	return b.buf[0], nil
+}
@@ -0,0 +1,69 @@
+package proxy
+
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withWorkerAddress       string
+	withConnectionsLeftFunc func(int32)
+	withErrorFunc           func(error)
+	withSkipSessionTeardown bool
+	withMultiplexing        bool
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithWorkerAddress sets the address of the worker first used to proxy the
+// session, ahead of the workers in the authorization data.  By default the
+// first worker in the authorization data is used.
+func WithWorkerAddress(addr string) Option {
+	return func(o *options) {
+		o.withWorkerAddress = addr
+	}
+}
+
+// WithConnectionsLeftFunc provides a function called with the number of
+// connections left in the session each time a worker reports it.  It isn't
+// called for sessions with unlimited connections.
+func WithConnectionsLeftFunc(fn func(int32)) Option {
+	return func(o *options) {
+		o.withConnectionsLeftFunc = fn
+	}
+}
+
+// WithErrorFunc provides a function called with errors proxying connections
+// accepted by Serve, which otherwise can't be reported.
+func WithErrorFunc(fn func(error)) Option {
+	return func(o *options) {
+		o.withErrorFunc = fn
+	}
+}
+
+// WithSkipSessionTeardown prevents Close from asking the worker to cancel the
+// session, leaving it to end when it expires.
+func WithSkipSessionTeardown(skip bool) Option {
+	return func(o *options) {
+		o.withSkipSessionTeardown = skip
+	}
+}
+
+// WithMultiplexing makes connections streams multiplexed over a single
+// websocket to the worker, rather than each having its own websocket.  If
+// the worker doesn't support multiplexing, each connection has its own
+// websocket as usual.
+func WithMultiplexing(mux bool) Option {
+	return func(o *options) {
+		o.withMultiplexing = mux
+	}
+}
@@ -0,0 +1,530 @@
+// Package proxy proxies connections to a Boundary session through a worker,
+// so programs can connect to targets without running "boundary connect".
+//
+// A ClientProxy is created from the result of a target's authorize-session
+// action.  Dial returns a net.Conn to the target, and Serve proxies the
+// connections accepted by a net.Listener:
+//
+//	sar, err := targets.NewClient(client).AuthorizeSession(ctx, "ttcp_1234567890")
+//	...
+//	p, err := proxy.New(ctx, sar.Item)
+//	...
+//	defer p.Close()
+//	l, err := net.Listen("tcp", "127.0.0.1:0")
+//	...
+//	err = p.Serve(l)
+//
+// For sessions to udp targets, connections returned by Dial read and write
+// single datagrams, and ServeUDP proxies the datagrams received on a
+// net.PacketConn.
+//
+// Done is closed when no more connections can be made, and Err then reports
+// why, e.g. ErrSessionExpired.
+//
+// If a worker can't be reached, e.g. because it's being restarted, new
+// connections are made through the next of the session's workers, which
+// takes the session over.  Connections proxied by the unreachable worker
+// are lost.
+package proxy
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/yamux"
+	"github.com/mr-tron/base58"
+	"nhooyr.io/websocket"
+)
+
+const (
+	// tcpProxyV1 is the websocket subprotocol spoken with workers.
+	tcpProxyV1 = "boundary-tcp-proxy-v1"
+	// tcpProxyMuxV1 is the websocket subprotocol spoken with workers when
+	// connections are multiplexed.
+	tcpProxyMuxV1 = "boundary-tcp-proxy-mux-v1"
+	// udpProxyV1 is the websocket subprotocol spoken with workers for
+	// connections to udp targets.
+	udpProxyV1 = "boundary-udp-proxy-v1"
+
+	sessionCancelTimeout = 10 * time.Second
+)
+
+var (
+	// ErrSessionExpired is reported by Err once the session has expired.
+	ErrSessionExpired = errors.New("session has expired")
+	// ErrNoConnectionsLeft is reported by Err once the session's connection
+	// limit has been reached.
+	ErrNoConnectionsLeft = errors.New("no connections left in session")
+	// ErrSessionInUse is reported by Err if the session is being used by
+	// another client.
+	ErrSessionInUse = errors.New("session is already in use")
+	// ErrClosed is reported by Err once Close has been called.
+	ErrClosed = errors.New("proxy closed")
+)
+
+// ClientProxy proxies connections to a session through a worker.
+type ClientProxy struct {
+	data       *SessionAuthorizationData
+	tofuToken  string
+	transport  *http.Transport
+	expiration time.Time
+	opts       options
+
+	// ctx bounds the session's connections.  It's canceled when the session
+	// expires, its parent is canceled or the proxy is closed.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	connsLeft int32
+
+	// workerAddrs are the addresses of the workers which can proxy the
+	// session, and workerIdx the index of the one in use.  workerLock
+	// guards workerIdx.
+	workerLock  sync.Mutex
+	workerAddrs []string
+	workerIdx   int
+
+	// muxLock guards the multiplexed session, which is started by the first
+	// Dial when WithMultiplexing is given.  muxUnsupported is set if the
+	// worker doesn't support multiplexing.
+	muxLock        sync.Mutex
+	muxSession     *yamux.Session
+	muxUnsupported bool
+
+	// done is closed when no more connections can be made, after err is set.
+	done     chan struct{}
+	doneOnce sync.Once
+	err      error
+
+	closeOnce sync.Once
+}
+
+// New returns a ClientProxy for the session authorized by authz, the result
+// of a target's authorize-session action.  The session's connections are
+// closed when ctx is canceled.
+func New(ctx context.Context, authz *targets.SessionAuthorization, opt ...Option) (*ClientProxy, error) {
+	if authz == nil {
+		return nil, errors.New("nil session authorization")
+	}
+	return NewFromToken(ctx, authz.AuthorizationToken, opt...)
+}
+
+// NewFromToken returns a ClientProxy for the session authorized by the
+// authorization token returned by a target's authorize-session action.  The
+// session's connections are closed when ctx is canceled.
+func NewFromToken(ctx context.Context, authzToken string, opt ...Option) (*ClientProxy, error) {
+	opts := getOpts(opt...)
+	data, err := DecodeAuthorizationToken(authzToken)
+	if err != nil {
+		return nil, err
+	}
+
+	workerAddrs := data.WorkerAddresses
+	if opts.withWorkerAddress != "" {
+		workerAddrs = []string{opts.withWorkerAddress}
+		for _, addr := range data.WorkerAddresses {
+			if addr != opts.withWorkerAddress {
+				workerAddrs = append(workerAddrs, addr)
+			}
+		}
+	}
+	if len(workerAddrs) == 0 {
+		return nil, errors.New("no workers found in authorization data")
+	}
+
+	cert, err := x509.ParseCertificate(data.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode mTLS certificate: %w", err)
+	}
+	if len(cert.DNSNames) != 1 {
+		return nil, errors.New("mTLS certificate has invalid parameters")
+	}
+	certPool := x509.NewCertPool()
+	certPool.AddCert(cert)
+
+	transport := cleanhttp.DefaultTransport()
+	transport.DisableKeepAlives = false
+	transport.TLSClientConfig = &tls.Config{
+		Certificates: []tls.Certificate{
+			{
+				Certificate: [][]byte{data.Certificate},
+				PrivateKey:  ed25519.PrivateKey(data.PrivateKey),
+				Leaf:        cert,
+			},
+		},
+		RootCAs:    certPool,
+		ServerName: cert.DNSNames[0],
+		MinVersion: tls.VersionTLS13,
+	}
+	// This isn't/shouldn't used anyways really because the connection is
+	// hijacked, just setting for completeness
+	transport.IdleConnTimeout = 0
+
+	tofu := make([]byte, 20)
+	if _, err := rand.Read(tofu); err != nil {
+		return nil, fmt.Errorf("could not derive random bytes for tofu token: %w", err)
+	}
+
+	p := &ClientProxy{
+		data:        data,
+		workerAddrs: workerAddrs,
+		tofuToken:   base58.FastBase58Encoding(tofu),
+		transport:   transport,
+		expiration:  cert.NotAfter,
+		opts:        opts,
+		connsLeft:   data.ConnectionLimit,
+		done:        make(chan struct{}),
+	}
+	// We don't _rely_ on client-side timeout verification but this prevents
+	// us seeming to be ready for a connection that will immediately fail
+	// when we try to actually make it
+	p.ctx, p.cancel = context.WithDeadline(ctx, p.expiration)
+	go func() {
+		<-p.ctx.Done()
+		err := ErrSessionExpired
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		p.stop(err)
+	}()
+	return p, nil
+}
+
+// SessionAuthorizationData returns the decoded authorization data of the
+// session.
+func (p *ClientProxy) SessionAuthorizationData() *SessionAuthorizationData {
+	return p.data
+}
+
+// SessionExpiration returns the time the session expires.
+func (p *ClientProxy) SessionExpiration() time.Time {
+	return p.expiration
+}
+
+// ConnectionsLeft returns the number of connections left in the session as
+// last reported by the worker, or -1 if they are unlimited.
+func (p *ClientProxy) ConnectionsLeft() int32 {
+	return atomic.LoadInt32(&p.connsLeft)
+}
+
+// Done returns a channel which is closed when no more connections can be
+// made through the proxy.  Connections already made may remain open until
+// the session expires or the proxy is closed.
+func (p *ClientProxy) Done() <-chan struct{} {
+	return p.done
+}
+
+// Err returns nil if Done is not yet closed, and otherwise the reason no more
+// connections can be made: ErrSessionExpired, ErrNoConnectionsLeft,
+// ErrSessionInUse, ErrClosed, or the error of the context the proxy was
+// created with.
+func (p *ClientProxy) Err() error {
+	select {
+	case <-p.done:
+		return p.err
+	default:
+		return nil
+	}
+}
+
+// stop prevents any more connections from being made, for the reason err.
+func (p *ClientProxy) stop(err error) {
+	p.doneOnce.Do(func() {
+		p.err = err
+		close(p.done)
+	})
+}
+
+// Close closes the proxy and its connections.  Unless the session has
+// already ended, or WithSkipSessionTeardown was given, the worker is asked
+// to cancel the session.
+func (p *ClientProxy) Close() error {
+	var err error
+	p.closeOnce.Do(func() {
+		p.stop(ErrClosed)
+		p.cancel()
+		p.closeMux()
+		switch p.err {
+		case ErrSessionExpired, ErrNoConnectionsLeft, ErrSessionInUse:
+			return
+		}
+		if p.opts.withSkipSessionTeardown {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), sessionCancelTimeout)
+		defer cancel()
+		err = p.sendSessionTeardown(ctx)
+	})
+	return err
+}
+
+// Dial returns a connection to the session's target through the worker.
+// The connection is closed when the session expires or the proxy is closed.
+func (p *ClientProxy) Dial(ctx context.Context) (net.Conn, error) {
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if p.data.Type == udpType {
+		return p.dialUDP(ctx)
+	}
+	if p.opts.withMultiplexing {
+		conn, err := p.dialMux(ctx)
+		if err != errMuxUnsupported {
+			return conn, err
+		}
+	}
+	wsConn, _, err := p.dialWorker(ctx, tcpProxyV1)
+	if err != nil {
+		return nil, err
+	}
+	result, err := p.handshake(ctx, wsConn)
+	if err != nil {
+		return nil, err
+	}
+	if result.connectionsLeft != -1 {
+		p.setConnsLeft(result.connectionsLeft)
+	}
+
+	// Get a wrapped net.Conn so we can use io.Copy
+	return websocket.NetConn(p.ctx, wsConn, websocket.MessageBinary), nil
+}
+
+// handshake sends the worker a handshake requesting a connection and reads
+// the result.  wsConn is closed if it fails.
+func (p *ClientProxy) handshake(ctx context.Context, wsConn *websocket.Conn) (*handshakeResult, error) {
+	if err := wsConn.Write(ctx, websocket.MessageBinary, encodeClientHandshake(p.tofuToken, handshakeCommandConnect)); err != nil {
+		wsConn.Close(websocket.StatusInternalError, "")
+		return nil, fmt.Errorf("error sending handshake to worker: %w", err)
+	}
+	_, b, err := wsConn.Read(ctx)
+	if err != nil {
+		wsConn.Close(websocket.StatusInternalError, "")
+		switch {
+		case strings.Contains(err.Error(), "unable to authorize connection"):
+			// There's no reason to think we'd be able to authorize any more
+			// connections after the first has failed
+			p.setConnsLeft(0)
+			return nil, errors.New("unable to authorize connection")
+		case strings.Contains(err.Error(), "tofu token not allowed"):
+			p.stop(ErrSessionInUse)
+			return nil, ErrSessionInUse
+		default:
+			return nil, fmt.Errorf("error reading handshake result: %w", err)
+		}
+	}
+	result, err := decodeHandshakeResult(b)
+	if err != nil {
+		wsConn.Close(websocket.StatusInternalError, "")
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p *ClientProxy) setConnsLeft(n int32) {
+	atomic.StoreInt32(&p.connsLeft, n)
+	if p.opts.withConnectionsLeftFunc != nil {
+		p.opts.withConnectionsLeftFunc(n)
+	}
+	if n == 0 {
+		p.stop(ErrNoConnectionsLeft)
+	}
+}
+
+// Serve accepts connections on l and proxies each to the session's target
+// until no more connections can be made, then closes l and waits for the
+// proxied connections to end.  It returns the reason reported by Err, or the
+// error accepting a connection.  Sessions to udp targets are served with
+// ServeUDP instead.
+func (p *ClientProxy) Serve(l net.Listener) error {
+	if p.data.Type == udpType {
+		return errors.New("sessions to udp targets must be served with ServeUDP")
+	}
+	go func() {
+		<-p.done
+		l.Close()
+	}()
+
+	wg := new(sync.WaitGroup)
+	defer wg.Wait()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if pErr := p.Err(); pErr != nil {
+				return pErr
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Temporary() {
+				p.reportError(fmt.Errorf("error accepting connection: %w", err))
+				continue
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+			remote, err := p.Dial(p.ctx)
+			if err != nil {
+				p.reportError(err)
+				return
+			}
+			defer remote.Close()
+			copyConns(conn, remote)
+		}()
+	}
+}
+
+func (p *ClientProxy) reportError(err error) {
+	if p.opts.withErrorFunc != nil {
+		p.opts.withErrorFunc(err)
+	}
+}
+
+// copyConns copies between a and b until either is closed.
+func copyConns(a, b net.Conn) {
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(a, b)
+		a.Close()
+		b.Close()
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(b, a)
+		b.Close()
+		a.Close()
+	}()
+	wg.Wait()
+}
+
+// WorkerAddress returns the address of the worker currently used to proxy
+// the session.
+func (p *ClientProxy) WorkerAddress() string {
+	p.workerLock.Lock()
+	defer p.workerLock.Unlock()
+	return p.workerAddrs[p.workerIdx]
+}
+
+// dialWorker opens a websocket to the worker offering the given subprotocols
+// in order of preference, and returns the one negotiated.  If the worker in
+// use can't be dialed, the session's other workers are tried in turn, and the
+// first which can be becomes the one in use.
+func (p *ClientProxy) dialWorker(ctx context.Context, subprotocols ...string) (*websocket.Conn, string, error) {
+	p.workerLock.Lock()
+	idx := p.workerIdx
+	p.workerLock.Unlock()
+
+	var err error
+	for i := range p.workerAddrs {
+		n := (idx + i) % len(p.workerAddrs)
+		var conn *websocket.Conn
+		var subprotocol string
+		conn, subprotocol, err = p.dialWorkerAt(ctx, p.workerAddrs[n], subprotocols...)
+		if err == nil {
+			if n != idx {
+				p.workerLock.Lock()
+				p.workerIdx = n
+				p.workerLock.Unlock()
+			}
+			return conn, subprotocol, nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, "", err
+}
+
+// dialWorkerAt opens a websocket to the worker at addr.
+func (p *ClientProxy) dialWorkerAt(ctx context.Context, addr string, subprotocols ...string) (*websocket.Conn, string, error) {
+	conn, resp, err := websocket.Dial(
+		ctx,
+		fmt.Sprintf("wss://%s/v1/proxy", addr),
+		&websocket.DialOptions{
+			HTTPClient: &http.Client{
+				Transport: p.transport,
+			},
+			Subprotocols: subprotocols,
+		},
+	)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "tls: internal error"):
+			return nil, "", errors.New("session credentials were not accepted, or session is unauthorized")
+		case strings.Contains(err.Error(), "connect: connection refused"):
+			return nil, "", fmt.Errorf("unable to connect to worker at %s", addr)
+		default:
+			return nil, "", fmt.Errorf("error dialing the worker: %w", err)
+		}
+	}
+
+	if resp == nil {
+		return nil, "", errors.New("response from worker is nil")
+	}
+	if resp.Header == nil {
+		return nil, "", errors.New("response header is nil")
+	}
+	negProto := resp.Header.Get("Sec-WebSocket-Protocol")
+	for _, sp := range subprotocols {
+		if negProto == sp {
+			return conn, negProto, nil
+		}
+	}
+	conn.Close(websocket.StatusProtocolError, "")
+	return nil, "", fmt.Errorf("unexpected negotiated protocol: %s", negProto)
+}
+
+// SshHostKey asks the worker for the SSH host key of the session's host.  The
+// worker fetches the key the host presents and reports it to the controller,
+// which pins it if no key is pinned for the host yet.  It doesn't use one of
+// the session's connections.
+func (p *ClientProxy) SshHostKey(ctx context.Context) (*SshHostKey, error) {
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	wsConn, _, err := p.dialWorker(ctx, tcpProxyV1)
+	if err != nil {
+		return nil, err
+	}
+	defer wsConn.Close(websocket.StatusNormalClosure, "")
+	if err := wsConn.Write(ctx, websocket.MessageBinary, encodeClientHandshake(p.tofuToken, handshakeCommandSshHostKey)); err != nil {
+		return nil, fmt.Errorf("error sending ssh host key request to worker: %w", err)
+	}
+	_, b, err := wsConn.Read(ctx)
+	if err != nil {
+		if strings.Contains(err.Error(), "tofu token not allowed") {
+			p.stop(ErrSessionInUse)
+			return nil, ErrSessionInUse
+		}
+		return nil, fmt.Errorf("error reading ssh host key from worker: %w", err)
+	}
+	return decodeSshHostKeyResult(b)
+}
+
+func (p *ClientProxy) sendSessionTeardown(ctx context.Context) error {
+	wsConn, _, err := p.dialWorker(ctx, tcpProxyV1)
+	if err != nil {
+		return fmt.Errorf("error fetching connection to send session teardown request to worker: %w", err)
+	}
+	defer wsConn.Close(websocket.StatusNormalClosure, "")
+	if err := wsConn.Write(ctx, websocket.MessageBinary, encodeClientHandshake(p.tofuToken, handshakeCommandSessionCancel)); err != nil {
+		return fmt.Errorf("error sending teardown handshake to worker: %w", err)
+	}
+	return nil
+}
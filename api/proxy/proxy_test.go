@@ -0,0 +1,556 @@
+package proxy
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/yamux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"nhooyr.io/websocket"
+)
+
+// testWorker is a worker which echoes the data of the connections it
+// proxies.
+type testWorker struct {
+	*httptest.Server
+	token string
+
+	mu        sync.Mutex
+	connsLeft int32
+	tofuToken string
+	canceled  bool
+	// mux is whether the worker supports multiplexing, and websockets counts
+	// the websockets opened for connections.
+	mux        bool
+	websockets int
+	// hostKey is the ssh host key pinned for the session's host, and
+	// presentedHostKey the key the host presents.
+	hostKey, presentedHostKey []byte
+}
+
+// newTestWorker returns a worker for a session allowing connLimit
+// connections, and the session's authorization token.
+func newTestWorker(t *testing.T, connLimit int32, expiration time.Time) *testWorker {
+	t.Helper()
+	return newTestWorkerOfType(t, "tcp", connLimit, expiration)
+}
+
+// newTestWorkerOfType returns a worker for a session of type typ.
+func newTestWorkerOfType(t *testing.T, typ string, connLimit int32, expiration time.Time) *testWorker {
+	t.Helper()
+	require := require.New(t)
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "s_1234567890"},
+		DNSNames:              []string{"s_1234567890"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              expiration,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDer, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+	require.NoError(err)
+
+	w := &testWorker{connsLeft: connLimit}
+	w.Server = httptest.NewUnstartedServer(http.HandlerFunc(w.handleProxy))
+	w.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{certDer}, PrivateKey: priv}},
+		ClientAuth:   tls.RequireAnyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}
+	w.StartTLS()
+	t.Cleanup(w.Close)
+
+	w.token = testAuthorizationToken(t, &SessionAuthorizationData{
+		SessionId:       "s_1234567890",
+		Type:            typ,
+		ConnectionLimit: connLimit,
+		Certificate:     certDer,
+		PrivateKey:      priv,
+		WorkerAddresses: []string{strings.TrimPrefix(w.URL, "https://")},
+	})
+	return w
+}
+
+func (w *testWorker) handleProxy(rw http.ResponseWriter, r *http.Request) {
+	subprotocols := []string{tcpProxyV1, udpProxyV1}
+	w.mu.Lock()
+	if w.mux {
+		subprotocols = []string{tcpProxyMuxV1, tcpProxyV1, udpProxyV1}
+	}
+	w.mu.Unlock()
+	conn, err := websocket.Accept(rw, r, &websocket.AcceptOptions{
+		Subprotocols: subprotocols,
+	})
+	if err != nil {
+		return
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
+	ctx := r.Context()
+	_, b, err := conn.Read(ctx)
+	if err != nil {
+		return
+	}
+	var tofuToken string
+	var cmd uint64
+	_ = consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 10:
+			v, n := protowire.ConsumeString(b)
+			tofuToken = v
+			return n, nil
+		case 20:
+			v, n := protowire.ConsumeVarint(b)
+			cmd = v
+			return n, nil
+		}
+		return protowire.ConsumeFieldValue(num, typ, b), nil
+	})
+
+	w.mu.Lock()
+	switch {
+	case w.tofuToken != "" && w.tofuToken != tofuToken:
+		w.mu.Unlock()
+		conn.Close(websocket.StatusPolicyViolation, "tofu token not allowed")
+		return
+	case cmd == uint64(handshakeCommandSessionCancel):
+		w.canceled = true
+		w.mu.Unlock()
+		return
+	case cmd == uint64(handshakeCommandSshHostKey):
+		w.tofuToken = tofuToken
+		var result []byte
+		result = protowire.AppendTag(result, 10, protowire.BytesType)
+		result = protowire.AppendBytes(result, w.hostKey)
+		result = protowire.AppendTag(result, 30, protowire.BytesType)
+		result = protowire.AppendBytes(result, w.presentedHostKey)
+		w.mu.Unlock()
+		_ = conn.Write(ctx, websocket.MessageBinary, result)
+		return
+	case conn.Subprotocol() == tcpProxyMuxV1:
+		w.tofuToken = tofuToken
+		w.websockets++
+		w.mu.Unlock()
+		w.handleMux(ctx, conn)
+		return
+	case w.connsLeft == 0:
+		w.mu.Unlock()
+		conn.Close(websocket.StatusInternalError, "unable to authorize connection")
+		return
+	}
+	w.tofuToken = tofuToken
+	w.websockets++
+	if w.connsLeft > 0 {
+		w.connsLeft--
+	}
+	var result []byte
+	result = protowire.AppendTag(result, 30, protowire.VarintType)
+	result = protowire.AppendVarint(result, uint64(w.connsLeft))
+	w.mu.Unlock()
+
+	if err := conn.Write(ctx, websocket.MessageBinary, result); err != nil {
+		return
+	}
+	netConn := websocket.NetConn(ctx, conn, websocket.MessageBinary)
+	_, _ = io.Copy(netConn, netConn)
+}
+
+// handleMux echoes the data of each stream the client opens over conn.
+func (w *testWorker) handleMux(ctx context.Context, conn *websocket.Conn) {
+	// Connections left aren't known until a stream is opened
+	unknown := int32(-1)
+	var result []byte
+	result = protowire.AppendTag(result, 30, protowire.VarintType)
+	result = protowire.AppendVarint(result, uint64(unknown))
+	if err := conn.Write(ctx, websocket.MessageBinary, result); err != nil {
+		return
+	}
+	muxSession, err := yamux.Server(websocket.NetConn(ctx, conn, websocket.MessageBinary), nil)
+	if err != nil {
+		return
+	}
+	defer muxSession.Close()
+	for {
+		stream, err := muxSession.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer stream.Close()
+			w.mu.Lock()
+			if w.connsLeft == 0 {
+				w.mu.Unlock()
+				_, _ = stream.Write(testStreamResult(streamStatusRefused, []byte("unable to authorize connection")))
+				return
+			}
+			if w.connsLeft > 0 {
+				w.connsLeft--
+			}
+			var result []byte
+			result = protowire.AppendTag(result, 30, protowire.VarintType)
+			result = protowire.AppendVarint(result, uint64(w.connsLeft))
+			w.mu.Unlock()
+			if _, err := stream.Write(testStreamResult(streamStatusConnected, result)); err != nil {
+				return
+			}
+			_, _ = io.Copy(stream, stream)
+		}()
+	}
+}
+
+func testStreamResult(status byte, payload []byte) []byte {
+	b := []byte{status}
+	b = protowire.AppendVarint(b, uint64(len(payload)))
+	return append(b, payload...)
+}
+
+func (w *testWorker) websocketCount() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.websockets
+}
+
+func (w *testWorker) sessionCanceled() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.canceled
+}
+
+func testEcho(t *testing.T, conn net.Conn, msg string) {
+	t.Helper()
+	_, err := conn.Write([]byte(msg))
+	require.NoError(t, err)
+	buf := make([]byte, len(msg))
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, msg, string(buf))
+}
+
+func TestClientProxy_Dial(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	w := newTestWorker(t, 2, time.Now().Add(time.Hour))
+
+	var reported []int32
+	p, err := NewFromToken(ctx, w.token, WithConnectionsLeftFunc(func(n int32) {
+		reported = append(reported, n)
+	}))
+	require.NoError(err)
+	assert.Equal("s_1234567890", p.SessionAuthorizationData().SessionId)
+	assert.Equal(int32(2), p.ConnectionsLeft())
+
+	conn, err := p.Dial(ctx)
+	require.NoError(err)
+	testEcho(t, conn, "hello")
+	assert.Equal(int32(1), p.ConnectionsLeft())
+	assert.NoError(p.Err())
+
+	conn2, err := p.Dial(ctx)
+	require.NoError(err)
+	assert.Equal([]int32{1, 0}, reported)
+	<-p.Done()
+	assert.Equal(ErrNoConnectionsLeft, p.Err())
+
+	// Connections remain usable once no more can be made.
+	testEcho(t, conn2, "still here")
+	_, err = p.Dial(ctx)
+	assert.Equal(ErrNoConnectionsLeft, err)
+
+	// The session has ended so isn't torn down.
+	require.NoError(p.Close())
+	assert.False(w.sessionCanceled())
+	_, err = io.ReadFull(conn, make([]byte, 1))
+	assert.Error(err)
+}
+
+func TestClientProxy_Serve(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	w := newTestWorker(t, -1, time.Now().Add(time.Hour))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p, err := New(ctx, nil)
+	require.Error(err)
+	assert.Nil(p)
+
+	p, err = NewFromToken(ctx, w.token, WithErrorFunc(func(err error) {
+		t.Errorf("unexpected proxy error: %v", err)
+	}))
+	require.NoError(err)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	served := make(chan error)
+	go func() {
+		served <- p.Serve(l)
+	}()
+
+	for i := 0; i < 3; i++ {
+		conn, err := net.Dial("tcp", l.Addr().String())
+		require.NoError(err)
+		testEcho(t, conn, "hello")
+		require.NoError(conn.Close())
+	}
+	assert.Equal(int32(-1), p.ConnectionsLeft())
+
+	require.NoError(p.Close())
+	assert.Equal(ErrClosed, <-served)
+	assert.True(w.sessionCanceled())
+}
+
+func TestClientProxy_WorkerFailover(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	w := newTestWorker(t, -1, time.Now().Add(time.Hour))
+	workerAddr := strings.TrimPrefix(w.URL, "https://")
+
+	// An address no worker listens on, as when a worker is restarting
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	deadAddr := l.Addr().String()
+	require.NoError(l.Close())
+
+	data, err := DecodeAuthorizationToken(w.token)
+	require.NoError(err)
+	data.WorkerAddresses = []string{deadAddr, workerAddr}
+	token := testAuthorizationToken(t, data)
+
+	p, err := NewFromToken(ctx, token)
+	require.NoError(err)
+	assert.Equal(deadAddr, p.WorkerAddress())
+	conn, err := p.Dial(ctx)
+	require.NoError(err)
+	testEcho(t, conn, "hello")
+	require.NoError(conn.Close())
+	assert.Equal(workerAddr, p.WorkerAddress())
+	require.NoError(p.Close())
+	assert.True(w.sessionCanceled())
+
+	// A worker given explicitly is tried first.
+	w2 := newTestWorker(t, -1, time.Now().Add(time.Hour))
+	p, err = NewFromToken(ctx, w2.token, WithWorkerAddress(deadAddr), WithSkipSessionTeardown(true))
+	require.NoError(err)
+	assert.Equal(deadAddr, p.WorkerAddress())
+	conn, err = p.Dial(ctx)
+	require.NoError(err)
+	testEcho(t, conn, "hello")
+	require.NoError(conn.Close())
+	assert.Equal(strings.TrimPrefix(w2.URL, "https://"), p.WorkerAddress())
+	require.NoError(p.Close())
+
+	// The error of the last worker is returned if none can be dialed.
+	data.WorkerAddresses = []string{deadAddr}
+	p, err = NewFromToken(ctx, testAuthorizationToken(t, data), WithSkipSessionTeardown(true))
+	require.NoError(err)
+	_, err = p.Dial(ctx)
+	require.Error(err)
+	assert.Contains(err.Error(), deadAddr)
+	require.NoError(p.Close())
+}
+
+func TestClientProxy_SessionInUse(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	w := newTestWorker(t, -1, time.Now().Add(time.Hour))
+
+	p1, err := NewFromToken(ctx, w.token, WithSkipSessionTeardown(true))
+	require.NoError(err)
+	conn, err := p1.Dial(ctx)
+	require.NoError(err)
+	defer conn.Close()
+
+	p2, err := NewFromToken(ctx, w.token)
+	require.NoError(err)
+	_, err = p2.Dial(ctx)
+	assert.True(errors.Is(err, ErrSessionInUse))
+	assert.Equal(ErrSessionInUse, p2.Err())
+
+	require.NoError(p2.Close())
+	require.NoError(p1.Close())
+	assert.False(w.sessionCanceled())
+}
+
+func TestClientProxy_SshHostKey(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	w := newTestWorker(t, 1, time.Now().Add(time.Hour))
+	w.hostKey, w.presentedHostKey = []byte("pinned"), []byte("pinned")
+
+	p, err := NewFromToken(ctx, w.token)
+	require.NoError(err)
+	got, err := p.SshHostKey(ctx)
+	require.NoError(err)
+	assert.Equal([]byte("pinned"), got.HostKey)
+	assert.False(got.Mismatch())
+
+	w.mu.Lock()
+	w.presentedHostKey = []byte("changed")
+	w.mu.Unlock()
+	got, err = p.SshHostKey(ctx)
+	require.NoError(err)
+	assert.Equal([]byte("changed"), got.PresentedHostKey)
+	assert.True(got.Mismatch())
+
+	// Fetching the host key doesn't use one of the session's connections.
+	assert.Equal(int32(1), p.ConnectionsLeft())
+	conn, err := p.Dial(ctx)
+	require.NoError(err)
+	testEcho(t, conn, "hello")
+	require.NoError(p.Close())
+}
+
+func TestClientProxy_Expiration(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	w := newTestWorker(t, -1, time.Now().Add(time.Second))
+
+	p, err := NewFromToken(ctx, w.token)
+	require.NoError(err)
+	assert.WithinDuration(time.Now().Add(time.Second), p.SessionExpiration(), time.Second)
+	select {
+	case <-p.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("proxy didn't end when the session expired")
+	}
+	assert.Equal(ErrSessionExpired, p.Err())
+	require.NoError(p.Close())
+	assert.False(w.sessionCanceled())
+}
+
+func TestClientProxy_Multiplexing(t *testing.T) {
+	ctx := context.Background()
+	for _, mux := range []bool{true, false} {
+		t.Run(fmt.Sprintf("worker mux %v", mux), func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			w := newTestWorker(t, 3, time.Now().Add(time.Hour))
+			w.mux = mux
+
+			p, err := NewFromToken(ctx, w.token, WithMultiplexing(true))
+			require.NoError(err)
+
+			var conns []net.Conn
+			for i := 0; i < 3; i++ {
+				conn, err := p.Dial(ctx)
+				require.NoError(err)
+				defer conn.Close()
+				conns = append(conns, conn)
+				assert.Equal(int32(2-i), p.ConnectionsLeft())
+			}
+			for i, conn := range conns {
+				testEcho(t, conn, fmt.Sprintf("hello %d", i))
+			}
+			if mux {
+				assert.Equal(1, w.websocketCount())
+			} else {
+				assert.Equal(3, w.websocketCount())
+			}
+			assert.Equal(ErrNoConnectionsLeft, p.Err())
+
+			require.NoError(p.Close())
+			_, err = io.ReadFull(conns[0], make([]byte, 1))
+			assert.Error(err)
+		})
+	}
+}
+
+func TestClientProxy_MultiplexingRefused(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	w := newTestWorker(t, 1, time.Now().Add(time.Hour))
+	w.mux = true
+
+	p, err := NewFromToken(ctx, w.token, WithMultiplexing(true))
+	require.NoError(err)
+	defer p.Close()
+	// Another client uses up the session's connection without the proxy
+	// hearing about it
+	w.mu.Lock()
+	w.connsLeft = 0
+	w.mu.Unlock()
+
+	_, err = p.Dial(ctx)
+	require.Error(err)
+	assert.True(errors.Is(err, errStreamRefused))
+	assert.Equal(ErrNoConnectionsLeft, p.Err())
+}
+
+func TestClientProxy_UDP(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	w := newTestWorkerOfType(t, "udp", -1, time.Now().Add(time.Hour))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p, err := NewFromToken(ctx, w.token, WithErrorFunc(func(err error) {
+		t.Errorf("unexpected proxy error: %v", err)
+	}))
+	require.NoError(err)
+
+	// The worker echoes frames, so each datagram comes back whole.
+	conn, err := p.Dial(ctx)
+	require.NoError(err)
+	_, err = conn.Write([]byte("a"))
+	require.NoError(err)
+	_, err = conn.Write([]byte("bcd"))
+	require.NoError(err)
+	buf := make([]byte, 2)
+	n, err := conn.Read(buf)
+	require.NoError(err)
+	assert.Equal("a", string(buf[:n]))
+	n, err = conn.Read(buf)
+	require.NoError(err)
+	assert.Equal("bc", string(buf[:n]), "datagrams are truncated to the buffer")
+	require.NoError(conn.Close())
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	assert.Error(p.Serve(l))
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+	served := make(chan error)
+	go func() {
+		served <- p.ServeUDP(pc)
+	}()
+
+	for i := 0; i < 2; i++ {
+		src, err := net.Dial("udp", pc.LocalAddr().String())
+		require.NoError(err)
+		for _, msg := range []string{"hello", "again"} {
+			_, err = src.Write([]byte(msg))
+			require.NoError(err)
+			require.NoError(src.SetReadDeadline(time.Now().Add(5 * time.Second)))
+			buf := make([]byte, 64)
+			n, err := src.Read(buf)
+			require.NoError(err)
+			assert.Equal(msg, string(buf[:n]))
+		}
+		require.NoError(src.Close())
+	}
+	// One connection for Dial and one for each source address
+	w.mu.Lock()
+	assert.Equal(3, w.websockets)
+	w.mu.Unlock()
+
+	require.NoError(p.Close())
+	assert.Equal(ErrClosed, <-served)
+}
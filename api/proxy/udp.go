@@ -0,0 +1,203 @@
+package proxy
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sync"
+	"time"
+
+	"nhooyr.io/websocket"
+)
+
+const (
+	// udpType is the type of sessions to udp targets.
+	udpType = "udp"
+
+	// udpIdleTimeout is how long ServeUDP keeps a connection open for a
+	// source address without a datagram in either direction.
+	udpIdleTimeout = 2 * time.Minute
+	// udpQueueLength is the number of datagrams ServeUDP queues for a source
+	// address while its connection is being made.  Datagrams are dropped
+	// once the queue is full, as they would be by the network.
+	udpQueueLength = 64
+
+	// maxDatagramSize is the largest datagram that can be framed.
+	maxDatagramSize = 64*1024 - 1
+)
+
+// dialUDP returns a connection to the session's udp target, whose Read and
+// Write each transfer one datagram.
+func (p *ClientProxy) dialUDP(ctx context.Context) (net.Conn, error) {
+	wsConn, _, err := p.dialWorker(ctx, udpProxyV1)
+	if err != nil {
+		return nil, err
+	}
+	result, err := p.handshake(ctx, wsConn)
+	if err != nil {
+		return nil, err
+	}
+	if result.connectionsLeft != -1 {
+		p.setConnsLeft(result.connectionsLeft)
+	}
+	return &datagramConn{Conn: websocket.NetConn(p.ctx, wsConn, websocket.MessageBinary)}, nil
+}
+
+// ServeUDP proxies the datagrams received on pc to the session's udp target,
+// using a connection of the session for each source address, and sends the
+// target's replies back to the source.  A source's connection is closed once
+// it's idle for two minutes.  Once no more connections can be made ServeUDP
+// closes pc and waits for the connections to end.  It returns the reason
+// reported by Err, or the error reading from pc.
+func (p *ClientProxy) ServeUDP(pc net.PacketConn) error {
+	if p.data.Type != udpType {
+		return fmt.Errorf("session is of type %q, not %q", p.data.Type, udpType)
+	}
+	go func() {
+		<-p.done
+		pc.Close()
+	}()
+
+	ctx, cancel := context.WithCancel(p.ctx)
+	wg := new(sync.WaitGroup)
+	defer wg.Wait()
+	defer cancel()
+
+	var assocsLock sync.Mutex
+	assocs := make(map[string]chan []byte)
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			if pErr := p.Err(); pErr != nil {
+				return pErr
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Temporary() {
+				p.reportError(fmt.Errorf("error reading datagram: %w", err))
+				continue
+			}
+			return err
+		}
+		d := append([]byte(nil), buf[:n]...)
+
+		assocsLock.Lock()
+		datagrams, ok := assocs[addr.String()]
+		if !ok {
+			datagrams = make(chan []byte, udpQueueLength)
+			assocs[addr.String()] = datagrams
+			wg.Add(1)
+			go func(addr net.Addr) {
+				defer wg.Done()
+				p.serveUDPSource(ctx, pc, addr, datagrams)
+				assocsLock.Lock()
+				delete(assocs, addr.String())
+				assocsLock.Unlock()
+			}(addr)
+		}
+		assocsLock.Unlock()
+
+		select {
+		case datagrams <- d:
+		default:
+		}
+	}
+}
+
+// serveUDPSource proxies the datagrams from a source address over a
+// connection of the session until it's idle or ctx is canceled.
+func (p *ClientProxy) serveUDPSource(ctx context.Context, pc net.PacketConn, addr net.Addr, datagrams <-chan []byte) {
+	conn, err := p.Dial(ctx)
+	if err != nil {
+		p.reportError(err)
+		return
+	}
+	idle := time.AfterFunc(udpIdleTimeout, func() { conn.Close() })
+	defer idle.Stop()
+
+	replies := make(chan struct{})
+	defer func() {
+		conn.Close()
+		<-replies
+	}()
+	go func() {
+		defer close(replies)
+		buf := make([]byte, maxDatagramSize)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			idle.Reset(udpIdleTimeout)
+			if _, err := pc.WriteTo(buf[:n], addr); err != nil {
+				p.reportError(fmt.Errorf("error sending datagram to %s: %w", addr, err))
+			}
+		}
+	}()
+
+	for {
+		select {
+		case d := <-datagrams:
+			idle.Reset(udpIdleTimeout)
+			if _, err := conn.Write(d); err != nil {
+				return
+			}
+		case <-replies:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// datagramConn is a connection to a udp target.  Each datagram is framed by
+// its length as a big-endian uint16.
+type datagramConn struct {
+	net.Conn
+
+	readLock  sync.Mutex
+	writeLock sync.Mutex
+}
+
+// Read reads a single datagram into b.  As with a udp socket, the datagram
+// is truncated if b is too small.
+func (c *datagramConn) Read(b []byte) (int, error) {
+	c.readLock.Lock()
+	defer c.readLock.Unlock()
+	var hdr [2]byte
+	if _, err := io.ReadFull(c.Conn, hdr[:]); err != nil {
+		return 0, err
+	}
+	size := int(binary.BigEndian.Uint16(hdr[:]))
+	n := size
+	if n > len(b) {
+		n = len(b)
+	}
+	if _, err := io.ReadFull(c.Conn, b[:n]); err != nil {
+		return 0, err
+	}
+	if _, err := io.CopyN(ioutil.Discard, c.Conn, int64(size-n)); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// Write writes b as a single datagram.
+func (c *datagramConn) Write(b []byte) (int, error) {
+	if len(b) > maxDatagramSize {
+		return 0, errors.New("datagram too large")
+	}
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	frame := make([]byte, 2+len(b))
+	binary.BigEndian.PutUint16(frame, uint16(len(b)))
+	copy(frame[2:], b)
+	if _, err := c.Conn.Write(frame); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
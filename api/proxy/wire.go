@@ -0,0 +1,289 @@
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mr-tron/base58"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages exchanged with the controller and workers are protobuf
+// encoded.  They're encoded and decoded here by their field numbers, which
+// must match the controller's SessionAuthorizationData message in
+// controller/api/resources/targets/v1/target.proto and the worker's messages
+// in worker/proxy/v1/proxy.proto, so this module doesn't depend on Boundary's
+// generated code.
+
+// SessionAuthorizationData is the information a client needs to connect to a
+// session through a worker.  It's decoded from the authorization token
+// returned by a target's authorize-session action.
+type SessionAuthorizationData struct {
+	SessionId   string
+	TargetId    string
+	CreatedTime time.Time
+	// Type is the type of the session, e.g. "tcp".
+	Type string
+	// ConnectionLimit is the number of connections the session allows, or -1
+	// if it's unlimited.
+	ConnectionLimit int32
+	// Certificate is the DER encoded certificate used to connect to workers.
+	Certificate []byte
+	// PrivateKey is the raw Ed25519 private key of the certificate.
+	PrivateKey []byte
+	HostId     string
+	// Endpoint is the address of the host the session connects to.
+	Endpoint string
+	// WorkerAddresses are the addresses of the workers which can proxy the
+	// session, in order of preference.
+	WorkerAddresses []string
+}
+
+// DecodeAuthorizationToken decodes the authorization token returned by a
+// target's authorize-session action.
+func DecodeAuthorizationToken(token string) (*SessionAuthorizationData, error) {
+	if token == "" {
+		return nil, errors.New("empty authorization token")
+	}
+	marshaled, err := base58.FastBase58Decoding(token)
+	if err != nil {
+		return nil, fmt.Errorf("unable to base58-decode authorization token: %w", err)
+	}
+	if len(marshaled) == 0 {
+		return nil, errors.New("zero length authorization data after decoding")
+	}
+	data := &SessionAuthorizationData{}
+	err = consumeFields(marshaled, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 10 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			data.SessionId = v
+			return n, nil
+		case num == 20 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			data.TargetId = v
+			return n, nil
+		case num == 40 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			t, err := decodeTimestamp(v)
+			if err != nil {
+				return 0, err
+			}
+			data.CreatedTime = t
+			return n, nil
+		case num == 80 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			data.Type = v
+			return n, nil
+		case num == 90 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			data.ConnectionLimit = int32(v)
+			return n, nil
+		case num == 120 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			data.Certificate = append([]byte(nil), v...)
+			return n, nil
+		case num == 130 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			data.PrivateKey = append([]byte(nil), v...)
+			return n, nil
+		case num == 140 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			data.HostId = v
+			return n, nil
+		case num == 141 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			data.Endpoint = v
+			return n, nil
+		case num == 150 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			var addr string
+			err := consumeFields(v, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+				if num == 10 && typ == protowire.BytesType {
+					v, n := protowire.ConsumeString(b)
+					addr = v
+					return n, nil
+				}
+				return protowire.ConsumeFieldValue(num, typ, b), nil
+			})
+			if err != nil {
+				return 0, err
+			}
+			data.WorkerAddresses = append(data.WorkerAddresses, addr)
+			return n, nil
+		default:
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode authorization data: %w", err)
+	}
+	return data, nil
+}
+
+// consumeFields calls fn with the number, type and remaining bytes of each
+// field of the message in b.  fn returns the length of the field's value or a
+// negative protowire error code.
+func consumeFields(b []byte, fn func(protowire.Number, protowire.Type, []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		n, err := fn(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func decodeTimestamp(b []byte) (time.Time, error) {
+	var secs, nanos int64
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if typ != protowire.VarintType || (num != 1 && num != 2) {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		v, n := protowire.ConsumeVarint(b)
+		if num == 1 {
+			secs = int64(v)
+		} else {
+			nanos = int64(int32(v))
+		}
+		return n, nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(secs, nanos).UTC(), nil
+}
+
+// handshakeCommand is the command a client sends a worker in its handshake.
+type handshakeCommand uint64
+
+const (
+	// handshakeCommandConnect requests a new connection.
+	handshakeCommandConnect handshakeCommand = 0
+	// handshakeCommandSessionCancel requests the session be canceled.
+	handshakeCommandSessionCancel handshakeCommand = 1
+	// handshakeCommandSshHostKey requests the SSH host key of the session's
+	// host.
+	handshakeCommandSshHostKey handshakeCommand = 2
+)
+
+// encodeClientHandshake returns the encoded ClientHandshake message.
+func encodeClientHandshake(tofuToken string, cmd handshakeCommand) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, 10, protowire.BytesType)
+	b = protowire.AppendString(b, tofuToken)
+	if cmd != handshakeCommandConnect {
+		b = protowire.AppendTag(b, 20, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(cmd))
+	}
+	return b
+}
+
+// handshakeResult is the worker's response to a ClientHandshake.
+type handshakeResult struct {
+	expiration      time.Time
+	connectionLimit int32
+	connectionsLeft int32
+}
+
+// decodeHandshakeResult decodes a HandshakeResult message.
+func decodeHandshakeResult(b []byte) (*handshakeResult, error) {
+	ret := &handshakeResult{}
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 10 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n, nil
+			}
+			t, err := decodeTimestamp(v)
+			if err != nil {
+				return 0, err
+			}
+			ret.expiration = t
+			return n, nil
+		case num == 20 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			ret.connectionLimit = int32(v)
+			return n, nil
+		case num == 30 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			ret.connectionsLeft = int32(v)
+			return n, nil
+		default:
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode handshake result: %w", err)
+	}
+	return ret, nil
+}
+
+// SshHostKey is the SSH host key of a session's host, as fetched by the
+// worker.  Keys are in the SSH wire format.
+type SshHostKey struct {
+	// HostKey is the key pinned for the host, which clients should trust.
+	HostKey     []byte
+	Fingerprint string
+	// PresentedHostKey is the key the host presented to the worker.
+	PresentedHostKey     []byte
+	PresentedFingerprint string
+}
+
+// Mismatch returns whether the key the host presented isn't the key pinned
+// for it.
+func (k *SshHostKey) Mismatch() bool {
+	return !bytes.Equal(k.HostKey, k.PresentedHostKey)
+}
+
+// decodeSshHostKeyResult decodes a SshHostKeyResult message.
+func decodeSshHostKeyResult(b []byte) (*SshHostKey, error) {
+	ret := &SshHostKey{}
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if typ != protowire.BytesType {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		switch num {
+		case 10:
+			v, n := protowire.ConsumeBytes(b)
+			ret.HostKey = append([]byte(nil), v...)
+			return n, nil
+		case 20:
+			v, n := protowire.ConsumeString(b)
+			ret.Fingerprint = v
+			return n, nil
+		case 30:
+			v, n := protowire.ConsumeBytes(b)
+			ret.PresentedHostKey = append([]byte(nil), v...)
+			return n, nil
+		case 40:
+			v, n := protowire.ConsumeString(b)
+			ret.PresentedFingerprint = v
+			return n, nil
+		default:
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode ssh host key result: %w", err)
+	}
+	return ret, nil
+}
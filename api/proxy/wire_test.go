@@ -0,0 +1,120 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// testAuthorizationToken encodes data the way the controller does.
+func testAuthorizationToken(t *testing.T, data *SessionAuthorizationData) string {
+	t.Helper()
+	var b []byte
+	appendString := func(num protowire.Number, v string) {
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		b = protowire.AppendString(b, v)
+	}
+	appendString(10, data.SessionId)
+	appendString(20, data.TargetId)
+	appendString(30, "scope info is ignored")
+
+	var ts []byte
+	ts = protowire.AppendTag(ts, 1, protowire.VarintType)
+	ts = protowire.AppendVarint(ts, uint64(data.CreatedTime.Unix()))
+	ts = protowire.AppendTag(ts, 2, protowire.VarintType)
+	ts = protowire.AppendVarint(ts, uint64(data.CreatedTime.Nanosecond()))
+	b = protowire.AppendTag(b, 40, protowire.BytesType)
+	b = protowire.AppendBytes(b, ts)
+
+	appendString(80, data.Type)
+	b = protowire.AppendTag(b, 90, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(data.ConnectionLimit))
+	b = protowire.AppendTag(b, 120, protowire.BytesType)
+	b = protowire.AppendBytes(b, data.Certificate)
+	b = protowire.AppendTag(b, 130, protowire.BytesType)
+	b = protowire.AppendBytes(b, data.PrivateKey)
+	appendString(140, data.HostId)
+	appendString(141, data.Endpoint)
+	for _, addr := range data.WorkerAddresses {
+		var w []byte
+		w = protowire.AppendTag(w, 10, protowire.BytesType)
+		w = protowire.AppendString(w, addr)
+		b = protowire.AppendTag(b, 150, protowire.BytesType)
+		b = protowire.AppendBytes(b, w)
+	}
+	return base58.FastBase58Encoding(b)
+}
+
+func TestDecodeAuthorizationToken(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	want := &SessionAuthorizationData{
+		SessionId:       "s_1234567890",
+		TargetId:        "ttcp_1234567890",
+		CreatedTime:     time.Unix(1612345678, 123456789).UTC(),
+		Type:            "tcp",
+		ConnectionLimit: -1,
+		Certificate:     []byte("certificate"),
+		PrivateKey:      []byte("private key"),
+		HostId:          "hst_1234567890",
+		Endpoint:        "tcp://10.0.0.1:22",
+		WorkerAddresses: []string{"127.0.0.1:9202", "127.0.0.2:9202"},
+	}
+	got, err := DecodeAuthorizationToken(testAuthorizationToken(t, want))
+	require.NoError(err)
+	assert.Equal(want, got)
+
+	_, err = DecodeAuthorizationToken("")
+	assert.Error(err)
+	_, err = DecodeAuthorizationToken("0OIl")
+	assert.Error(err)
+	// A truncated field
+	_, err = DecodeAuthorizationToken(base58.FastBase58Encoding([]byte{0x52, 0x05, 0x61}))
+	assert.Error(err)
+}
+
+func TestEncodeClientHandshake(t *testing.T) {
+	assert.Equal(t, []byte{0x52, 0x03, 'a', 'b', 'c'}, encodeClientHandshake("abc", handshakeCommandConnect))
+	assert.Equal(t, []byte{0x52, 0x03, 'a', 'b', 'c', 0xa0, 0x01, 0x01}, encodeClientHandshake("abc", handshakeCommandSessionCancel))
+}
+
+func TestDecodeHandshakeResult(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	got, err := decodeHandshakeResult([]byte{
+		0xa0, 0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01,
+		0xf0, 0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01,
+	})
+	require.NoError(err)
+	assert.Equal(&handshakeResult{connectionLimit: -1, connectionsLeft: -1}, got)
+
+	got, err = decodeHandshakeResult([]byte{0xa0, 0x01, 0x05, 0xf0, 0x01, 0x04})
+	require.NoError(err)
+	assert.Equal(&handshakeResult{connectionLimit: 5, connectionsLeft: 4}, got)
+
+	_, err = decodeHandshakeResult([]byte{0xa0})
+	assert.Error(err)
+}
+
+func TestDecodeSshHostKeyResult(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	got, err := decodeSshHostKeyResult([]byte{
+		0x52, 0x01, 'a',
+		0xa2, 0x01, 0x02, 'f', 'a',
+		0xf2, 0x01, 0x01, 'b',
+		0xc2, 0x02, 0x02, 'f', 'b',
+	})
+	require.NoError(err)
+	assert.Equal(&SshHostKey{
+		HostKey:              []byte("a"),
+		Fingerprint:          "fa",
+		PresentedHostKey:     []byte("b"),
+		PresentedFingerprint: "fb",
+	}, got)
+	assert.True(got.Mismatch())
+
+	_, err = decodeSshHostKeyResult([]byte{0x52, 0x05})
+	assert.Error(err)
+}
@@ -0,0 +1,8 @@
+// Code generated by "make api"; DO NOT EDIT.
+package roletemplates
+
+type Grant struct {
+	Raw       string     `json:"raw,omitempty"`
+	Canonical string     `json:"canonical,omitempty"`
+	Json      *GrantJson `json:"json,omitempty"`
+}
@@ -0,0 +1,8 @@
+// Code generated by "make api"; DO NOT EDIT.
+package roletemplates
+
+type GrantJson struct {
+	Id      string   `json:"id,omitempty"`
+	Type    string   `json:"type,omitempty"`
+	Actions []string `json:"actions,omitempty"`
+}
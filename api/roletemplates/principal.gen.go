@@ -0,0 +1,8 @@
+// Code generated by "make api"; DO NOT EDIT.
+package roletemplates
+
+type Principal struct {
+	Id      string `json:"id,omitempty"`
+	Type    string `json:"type,omitempty"`
+	ScopeId string `json:"scope_id,omitempty"`
+}
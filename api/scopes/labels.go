@@ -0,0 +1,49 @@
+package scopes
+
+import (
+	"context"
+	"fmt"
+)
+
+const labelsPostBodyKey = "labels"
+
+// SetLabels sets the labels on the scope, which grants can match on,
+// removing any labels not given. Passing no labels removes all of them.
+func (c *Client) SetLabels(ctx context.Context, scopeId string, labels map[string]string, opt ...Option) (*ScopeUpdateResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into SetLabels request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in SetLabels request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	reqBody := opts.postMap
+	reqBody[labelsPostBodyKey] = labels
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("scopes/%s:set-labels", scopeId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating SetLabels request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during SetLabels call: %w", err)
+	}
+
+	target := new(ScopeUpdateResult)
+	target.Item = new(Scope)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding SetLabels response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
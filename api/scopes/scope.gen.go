@@ -22,6 +22,7 @@ type Scope struct {
 	Version                     uint32              `json:"version,omitempty"`
 	Type                        string              `json:"type,omitempty"`
 	PrimaryAuthMethodId         string              `json:"primary_auth_method_id,omitempty"`
+	Labels                      map[string]string   `json:"labels,omitempty"`
 	AuthorizedActions           []string            `json:"authorized_actions,omitempty"`
 	AuthorizedCollectionActions map[string][]string `json:"authorized_collection_actions,omitempty"`
 
@@ -0,0 +1,49 @@
+package targets
+
+import (
+	"context"
+	"fmt"
+)
+
+const ownerIdPostBodyKey = "owner_id"
+
+// TransferOwnership makes the given user the owner of the target. Grants
+// using the "{{owner}}" template match the resources the user owns.
+func (c *Client) TransferOwnership(ctx context.Context, targetId string, ownerId string, opt ...Option) (*TargetUpdateResult, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("empty targetId value passed into TransferOwnership request")
+	}
+	if ownerId == "" {
+		return nil, fmt.Errorf("empty ownerId value passed into TransferOwnership request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in TransferOwnership request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	reqBody := opts.postMap
+	reqBody[ownerIdPostBodyKey] = ownerId
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("targets/%s:transfer-ownership", targetId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating TransferOwnership request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during TransferOwnership call: %w", err)
+	}
+
+	target := new(TargetUpdateResult)
+	target.Item = new(Target)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding TransferOwnership response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -28,6 +28,8 @@ type Target struct {
 	SessionConnectionLimit int32                  `json:"session_connection_limit,omitempty"`
 	WorkerFilter           string                 `json:"worker_filter,omitempty"`
 	Attributes             map[string]interface{} `json:"attributes,omitempty"`
+	Labels                 map[string]string      `json:"labels,omitempty"`
+	OwnerId                string                 `json:"owner_id,omitempty"`
 	AuthorizedActions      []string               `json:"authorized_actions,omitempty"`
 
 	response *api.Response
@@ -0,0 +1,65 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type EffectivePermissionsResult struct {
+	Item     *EffectivePermissions
+	response *api.Response
+}
+
+func (n EffectivePermissionsResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n EffectivePermissionsResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ReadEffectivePermissions returns the permissions the grants of the user
+// amount to across all scopes, including grants received through groups.
+func (c *Client) ReadEffectivePermissions(ctx context.Context, userId string, opt ...Option) (*EffectivePermissionsResult, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("empty userId value passed into ReadEffectivePermissions request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("users/%s:read-effective-permissions", userId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ReadEffectivePermissions request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ReadEffectivePermissions call: %w", err)
+	}
+
+	target := new(EffectivePermissionsResult)
+	target.Item = new(EffectivePermissions)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ReadEffectivePermissions response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
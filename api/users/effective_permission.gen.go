@@ -0,0 +1,12 @@
+// Code generated by "make api"; DO NOT EDIT.
+package users
+
+type EffectivePermission struct {
+	ScopeId       string            `json:"scope_id,omitempty"`
+	ResourceType  string            `json:"resource_type,omitempty"`
+	ResourceId    string            `json:"resource_id,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Actions       []string          `json:"actions,omitempty"`
+	DeniedActions []string          `json:"denied_actions,omitempty"`
+	RoleIds       []string          `json:"role_ids,omitempty"`
+}
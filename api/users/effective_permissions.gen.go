@@ -0,0 +1,7 @@
+// Code generated by "make api"; DO NOT EDIT.
+package users
+
+type EffectivePermissions struct {
+	UserId      string                 `json:"user_id,omitempty"`
+	Permissions []*EffectivePermission `json:"permissions,omitempty"`
+}
@@ -31,6 +31,7 @@ const (
 	PrincipalIdsField                = "principal_ids"
 	PrincipalsField                  = "principals"
 	GrantScopeIdField                = "grant_scope_id"
+	GrantScopeIdsField               = "grant_scope_ids"
 	GrantsField                      = "grants"
 	GrantStringsField                = "grant_strings"
 	PrimaryAuthMethodId              = "primary_auth_method_id"
@@ -53,4 +54,7 @@ const (
 	FullNameField                    = "full_name"
 	PrimaryAccountIdField            = "primary_account_id"
 	EmailField                       = "email"
+	LabelsField                      = "labels"
+	RoleIdsField                     = "role_ids"
+	OwnerIdField                     = "owner_id"
 )
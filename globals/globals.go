@@ -12,6 +12,14 @@ import "time"
 const (
 	TcpProxyV1     = "boundary-tcp-proxy-v1"
 	ServiceTokenV1 = "s1"
+
+	// TcpProxyMuxV1 multiplexes a session's connections as yamux streams
+	// over a single websocket.
+	TcpProxyMuxV1 = "boundary-tcp-proxy-mux-v1"
+
+	// UdpProxyV1 proxies a connection's datagrams, each framed by its
+	// length, to a udp target.
+	UdpProxyV1 = "boundary-udp-proxy-v1"
 )
 
 type (
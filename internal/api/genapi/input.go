@@ -6,12 +6,14 @@ import (
 	"github.com/hashicorp/boundary/internal/gen/controller/api"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/accounts"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/authmethods"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/authorization"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/authtokens"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/groups"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostcatalogs"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hosts"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostsets"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/roles"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/roletemplates"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/sessions"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
@@ -158,6 +160,16 @@ var inputStructs = []*structInfo{
 		outFile:    "users/account.gen.go",
 		outputOnly: true,
 	},
+	{
+		inProto:    &users.EffectivePermission{},
+		outFile:    "users/effective_permission.gen.go",
+		outputOnly: true,
+	},
+	{
+		inProto:    &users.EffectivePermissions{},
+		outFile:    "users/effective_permissions.gen.go",
+		outputOnly: true,
+	},
 	{
 		inProto: &users.User{},
 		outFile: "users/user.gen.go",
@@ -183,6 +195,16 @@ var inputStructs = []*structInfo{
 		outFile:    "groups/member.gen.go",
 		outputOnly: true,
 	},
+	{
+		inProto:    &groups.ResolvedMember{},
+		outFile:    "groups/resolved_member.gen.go",
+		outputOnly: true,
+	},
+	{
+		inProto:    &groups.ResolvedMembers{},
+		outFile:    "groups/resolved_members.gen.go",
+		outputOnly: true,
+	},
 	{
 		inProto: &groups.Group{},
 		outFile: "groups/group.gen.go",
@@ -229,11 +251,48 @@ var inputStructs = []*structInfo{
 			deleteTemplate,
 			listTemplate,
 		},
+		sliceSubTypes: map[string]string{
+			"Principals":  "principalIds",
+			"Grants":      "grantStrings",
+			"GrantScopes": "grantScopeIds",
+		},
+		pathArgs:            []string{"role"},
+		versionEnabled:      true,
+		createResponseTypes: true,
+		recursiveListing:    true,
+	},
+	// Role Template related resources
+	{
+		inProto:    &roletemplates.Grant{},
+		outFile:    "roletemplates/grant.gen.go",
+		outputOnly: true,
+	},
+	{
+		inProto:    &roletemplates.Principal{},
+		outFile:    "roletemplates/principal.gen.go",
+		outputOnly: true,
+	},
+	{
+		inProto:    &roletemplates.GrantJson{},
+		outFile:    "roletemplates/grant_json.gen.go",
+		outputOnly: true,
+	},
+	{
+		inProto: &roletemplates.RoleTemplate{},
+		outFile: "roletemplates/role_template.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
 		sliceSubTypes: map[string]string{
 			"Principals": "principalIds",
 			"Grants":     "grantStrings",
 		},
-		pathArgs:            []string{"role"},
+		pathArgs:            []string{"role-template"},
 		versionEnabled:      true,
 		createResponseTypes: true,
 		recursiveListing:    true,
@@ -389,6 +448,11 @@ var inputStructs = []*structInfo{
 		outFile:     "targets/tcp_target_attributes.gen.go",
 		subtypeName: "TcpTarget",
 	},
+	{
+		inProto:     &targets.UdpTargetAttributes{},
+		outFile:     "targets/udp_target_attributes.gen.go",
+		subtypeName: "UdpTarget",
+	},
 	{
 		inProto: &targets.Target{},
 		outFile: "targets/target.gen.go",
@@ -429,6 +493,52 @@ var inputStructs = []*structInfo{
 		createResponseTypes: true,
 		recursiveListing:    true,
 	},
+	// Authorization related resources
+	{
+		inProto:    &authorization.MatchingGrant{},
+		outFile:    "authorization/matching_grant.gen.go",
+		outputOnly: true,
+	},
+	{
+		inProto: &authorization.Explanation{},
+		outFile: "authorization/explanation.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+		},
+		outputOnly: true,
+		extraOptions: []fieldInfo{
+			{
+				Name:        "UserId",
+				ProtoName:   "user_id",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
+			{
+				Name:        "AuthTokenId",
+				ProtoName:   "auth_token_id",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
+			{
+				Name:        "ResourceId",
+				ProtoName:   "resource_id",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
+			{
+				Name:        "ResourceType",
+				ProtoName:   "resource_type",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
+			{
+				Name:        "Pin",
+				ProtoName:   "pin",
+				FieldType:   "string",
+				SkipDefault: true,
+			},
+		},
+	},
 	{
 		inProto: &sessions.SessionState{},
 		outFile: "sessions/state.gen.go",
@@ -68,6 +68,8 @@ func parsePBs() {
 				switch name {
 				case "v1.AuthorizedCollectionActionsEntry":
 					fi.FieldType = "map[string][]string"
+				case "v1.LabelsEntry":
+					fi.FieldType = "map[string]string"
 				default:
 					fi.FieldType = sliceText + ptr + name
 				}
@@ -94,13 +94,14 @@ func fillTemplates() {
 		// We want to generate options per-package, not per-struct, so we
 		// collate them all here for writing later. The map argument of the
 		// package map is to prevent duplicates since we may have multiple e.g.
-		// Name or Description fields.
+		// Name or Description fields. Subtype options are keyed by subtype as
+		// well, since subtypes may share attribute names.
 		if !in.outputOnly {
 			pkgOptionMap := map[string]fieldInfo{}
 			for _, val := range input.Fields {
 				if val.GenerateSdkOption {
 					val.SubtypeName = in.subtypeName
-					pkgOptionMap[val.Name] = val
+					pkgOptionMap[val.SubtypeName+val.Name] = val
 				}
 			}
 			optionMap := optionsMap[input.Package]
@@ -143,16 +144,16 @@ func fillTemplates() {
 	for pkg, options := range optionsMap {
 		outBuf := new(bytes.Buffer)
 
-		var fieldNames []string
-		for _, v := range options {
-			fieldNames = append(fieldNames, v.Name)
-		}
-		sort.Strings(fieldNames)
-
 		var fields []fieldInfo
-		for _, v := range fieldNames {
-			fields = append(fields, options[v])
+		for _, v := range options {
+			fields = append(fields, v)
 		}
+		sort.Slice(fields, func(i, j int) bool {
+			if fields[i].Name != fields[j].Name {
+				return fields[i].Name < fields[j].Name
+			}
+			return fields[i].SubtypeName < fields[j].SubtypeName
+		})
 
 		input := templateInput{
 			Package:          pkg,
@@ -48,6 +48,8 @@ func TestFetchActionSetForId(t *testing.T) {
 	iam.TestRoleGrant(t, conn, orgRole.PublicId, "id=foo;actions=read,update")
 	iam.TestRoleGrant(t, conn, orgRole.PublicId, "id=bar;actions=read,update,delete,authorize-session")
 	iam.TestRoleGrant(t, conn, orgRole.PublicId, "id=*;type=role;actions=add-grants,remove-grants")
+	iam.TestRoleGrant(t, conn, orgRole.PublicId, "id=baz;actions=*")
+	iam.TestRoleGrant(t, conn, orgRole.PublicId, "id=baz;actions=delete;effect=deny")
 
 	cases := []struct {
 		name         string
@@ -70,6 +72,12 @@ func TestFetchActionSetForId(t *testing.T) {
 			avail:   action.ActionSet{action.Delete, action.AddGrants, action.Read, action.RemoveHostSets},
 			allowed: action.ActionSet{action.Delete, action.Read},
 		},
+		{
+			name:    "deny match",
+			id:      "baz",
+			avail:   action.ActionSet{action.Delete, action.Read, action.Update},
+			allowed: action.ActionSet{action.Read, action.Update},
+		},
 		{
 			name:         "different type",
 			id:           "anything",
@@ -212,7 +220,8 @@ func TestSelfReadingDifferentOutputFields(t *testing.T) {
 		tc.Controller().PasswordAuthRepoFn,
 		tc.Controller().OidcRepoFn,
 		tc.Controller().IamRepoFn,
-		tc.Controller().AuthTokenRepoFn)
+		tc.Controller().AuthTokenRepoFn,
+		tc.Controller().SessionRepoFn)
 	require.NoError(t, err)
 
 	// Create two auth tokens belonging to different users in the org. Each will
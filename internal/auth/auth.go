@@ -275,6 +275,35 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 	return
 }
 
+// DecryptTokenFromRequest returns the public ID of the auth token in the
+// request if it can be decrypted, which proves it was issued by this
+// controller, or an empty string otherwise. Unlike Verify it checks neither
+// whether the token has expired nor what it's authorized to do.
+func DecryptTokenFromRequest(ctx context.Context, logger hclog.Logger, kmsCache *kms.Kms, authTokenRepoFn common.AuthTokenRepoFactory, req *http.Request) string {
+	publicId, encryptedToken, format := GetTokenFromRequest(logger, kmsCache, req)
+	switch {
+	case format != AuthTokenTypeBearer && format != AuthTokenTypeSplitCookie,
+		len(encryptedToken) <= len(globals.ServiceTokenV1):
+		return ""
+	}
+	v := &verifier{
+		logger:          logger,
+		authTokenRepoFn: authTokenRepoFn,
+		kms:             kmsCache,
+		ctx:             ctx,
+		requestInfo: RequestInfo{
+			PublicId:       publicId,
+			EncryptedToken: encryptedToken,
+			TokenFormat:    format,
+		},
+	}
+	v.decryptToken()
+	if v.requestInfo.TokenFormat == AuthTokenTypeUnknown || v.requestInfo.Token == "" {
+		return ""
+	}
+	return publicId
+}
+
 func (v *verifier) decryptToken() {
 	switch v.requestInfo.TokenFormat {
 	case AuthTokenTypeUnknown:
@@ -493,33 +522,35 @@ func (v verifier) performAuthCheck() (aclResults perms.ACLResults, userId string
 		return
 	}
 
-	var parsedGrants []perms.Grant
-	var grantPairs []perms.GrantPair
+	// Grants can match on the labels of the resource, so look them up if it
+	// can have any
+	if v.res.Id != "" && v.res.Labels == nil && perms.LabeledType(v.res.Type) {
+		labels, err := iamRepo.ListResourceLabels(v.ctx, []string{v.res.Id})
+		if err != nil {
+			retErr = errors.Wrap(err, op)
+			return
+		}
+		v.res.Labels = labels[v.res.Id]
+	}
+
+	// Grants can match on the owner of the resource, so look it up if it can
+	// have one
+	if v.res.Id != "" && v.res.OwnerId == "" && perms.OwnedType(v.res.Type) {
+		owners, err := iamRepo.ListResourceOwners(v.ctx, []string{v.res.Id})
+		if err != nil {
+			retErr = errors.Wrap(err, op)
+			return
+		}
+		v.res.OwnerId = owners[v.res.Id]
+	}
 
 	// Fetch and parse grants for this user ID (which may include grants for
 	// u_anon and u_auth)
-	grantPairs, err = iamRepo.GrantsForUser(v.ctx, userId)
+	_, parsedGrants, err := parsedGrantsForUser(v.ctx, iamRepo, userId, accountId)
 	if err != nil {
 		retErr = errors.Wrap(err, op)
 		return
 	}
-	parsedGrants = make([]perms.Grant, 0, len(grantPairs))
-	// Note: Below, we always skip validation so that we don't error on formats
-	// that we've since restricted, e.g. "id=foo;actions=create,read". These
-	// will simply not have an effect.
-	for _, pair := range grantPairs {
-		parsed, err := perms.Parse(
-			pair.ScopeId,
-			pair.Grant,
-			perms.WithUserId(userId),
-			perms.WithAccountId(accountId),
-			perms.WithSkipFinalValidation(true))
-		if err != nil {
-			retErr = errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed to parse grant %#v", pair.Grant)))
-			return
-		}
-		parsedGrants = append(parsedGrants, parsed)
-	}
 
 	retAcl = perms.NewACL(parsedGrants...)
 	aclResults = retAcl.Allowed(*v.res, v.act)
@@ -564,6 +595,12 @@ func (r *VerifyResults) fetchActions(id string, typ resource.Type, availableActi
 		res = new(perms.Resource)
 	}
 	if id != "" {
+		// The labels and owner looked up during verification belong to
+		// another resource
+		if id != res.Id && opts.withResource == nil {
+			res.Labels = nil
+			res.OwnerId = ""
+		}
 		res.Id = id
 	}
 	if typ != resource.Unknown {
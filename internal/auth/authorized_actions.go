@@ -11,7 +11,8 @@ import (
 )
 
 // CalculateAuthorizedCollectionActions returns authorized actions for the given
-// inputs.
+// inputs. Actions matched by a deny grant are left out even when another grant
+// allows them.
 //
 // NOTE: Eventually we should unit test this, but for now every service handler
 // is validating the results of this (as it was pulled out of the service
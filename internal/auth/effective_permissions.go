@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+// EffectivePermission is the set of actions a user's grants allow and deny on
+// a resource, or on a collection or all resources of a type, within a scope.
+type EffectivePermission struct {
+	// ScopeId is the scope the grants apply in
+	ScopeId string
+
+	// ResourceType is the type the grants apply to. It is "*" for all types
+	// and empty when the grants only name an ID.
+	ResourceType string
+
+	// ResourceId is the ID the grants apply to. It is "*" for all IDs and
+	// empty when the grants apply to the collection of the type.
+	ResourceId string
+
+	// Labels are the labels a resource must have for the grants to apply to
+	// it, if any
+	Labels map[string]string
+
+	// Actions are the actions allowed, sorted
+	Actions []string
+
+	// DeniedActions are the actions denied, sorted. A denied action takes
+	// precedence over the same action in Actions.
+	DeniedActions []string
+
+	// RoleIds are the roles the grants come from, sorted
+	RoleIds []string
+}
+
+// EffectivePermissions returns the permissions the grants of the user amount
+// to across all scopes. Grants the user receives through groups, and through
+// the u_anon and u_auth users, are included. Grants with the same scope, type,
+// ID and labels are merged into a single permission, and templated IDs are
+// filled in for the user and each of the accounts. Grants that only set output
+// fields aren't included. The returned permissions are sorted by scope, type,
+// ID and labels.
+func EffectivePermissions(ctx context.Context, iamRepo *iam.Repository, userId string, accountIds []string) ([]*EffectivePermission, error) {
+	const op = "auth.EffectivePermissions"
+	switch {
+	case iamRepo == nil:
+		return nil, errors.New(errors.InvalidParameter, op, "missing iam repository")
+	case userId == "":
+		return nil, errors.New(errors.InvalidParameter, op, "missing user id")
+	}
+
+	pairs, grants, err := parsedGrantsForUser(ctx, iamRepo, userId, "")
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	type key struct {
+		scopeId, typ, id, labels string
+	}
+	type entry struct {
+		labels  map[string]string
+		allowed map[string]bool
+		denied  map[string]bool
+		roles   map[string]bool
+	}
+	entries := make(map[key]*entry)
+	add := func(pair perms.GrantPair, g perms.Grant) {
+		_, acts := g.Actions()
+		if len(acts) == 0 {
+			return
+		}
+		var typ string
+		if g.Type() != resource.Unknown {
+			typ = g.Type().String()
+		}
+		k := key{scopeId: pair.ScopeId, typ: typ, id: g.Id(), labels: labelsKey(g.Labels())}
+		e, ok := entries[k]
+		if !ok {
+			e = &entry{
+				labels:  g.Labels(),
+				allowed: make(map[string]bool),
+				denied:  make(map[string]bool),
+				roles:   make(map[string]bool),
+			}
+			entries[k] = e
+		}
+		for _, a := range acts {
+			if g.Deny() {
+				e.denied[a] = true
+			} else {
+				e.allowed[a] = true
+			}
+		}
+		if pair.RoleId != "" {
+			e.roles[pair.RoleId] = true
+		}
+	}
+
+	for i, g := range grants {
+		// An ID that is still templated refers to the account, which the
+		// user may have several of
+		if !strings.HasPrefix(g.Id(), "{{") || len(accountIds) == 0 {
+			add(pairs[i], g)
+			continue
+		}
+		for _, accountId := range accountIds {
+			parsed, err := perms.Parse(
+				pairs[i].ScopeId,
+				pairs[i].Grant,
+				perms.WithUserId(userId),
+				perms.WithAccountId(accountId),
+				perms.WithSkipFinalValidation(true))
+			if err != nil {
+				return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed to parse grant %#v", pairs[i].Grant)))
+			}
+			add(pairs[i], parsed)
+		}
+	}
+
+	ret := make([]*EffectivePermission, 0, len(entries))
+	for k, e := range entries {
+		ret = append(ret, &EffectivePermission{
+			ScopeId:       k.scopeId,
+			ResourceType:  k.typ,
+			ResourceId:    k.id,
+			Labels:        e.labels,
+			Actions:       sortedKeys(e.allowed),
+			DeniedActions: sortedKeys(e.denied),
+			RoleIds:       sortedKeys(e.roles),
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		switch {
+		case ret[i].ScopeId != ret[j].ScopeId:
+			return ret[i].ScopeId < ret[j].ScopeId
+		case ret[i].ResourceType != ret[j].ResourceType:
+			return ret[i].ResourceType < ret[j].ResourceType
+		case ret[i].ResourceId != ret[j].ResourceId:
+			return ret[i].ResourceId < ret[j].ResourceId
+		default:
+			return labelsKey(ret[i].Labels) < labelsKey(ret[j].Labels)
+		}
+	})
+	return ret, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// labelsKey returns the labels as a string that's the same for equal labels
+func labelsKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	ret := make([]string, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, k+":"+labels[k])
+	}
+	return strings.Join(ret, ",")
+}
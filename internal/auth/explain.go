@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// ExplainResults describes the outcome of an authorization check simulated by
+// Explain.
+type ExplainResults struct {
+	// Authorized is whether the action is allowed on the resource
+	Authorized bool
+
+	// OutputFields are the fields that would be returned for the resource. It
+	// is empty if the action isn't authorized.
+	OutputFields perms.OutputFieldsMap
+
+	// MatchingGrants are the grants that allowed or denied the action, or
+	// contributed output fields to it
+	MatchingGrants []MatchingGrant
+}
+
+// MatchingGrant is a grant that applied to an explained authorization check,
+// along with where it came from.
+type MatchingGrant struct {
+	// RoleId is the role the grant belongs to
+	RoleId string
+
+	// ScopeId is the scope the grant applied in
+	ScopeId string
+
+	// Grant is the parsed grant, with templates filled in for the user
+	Grant perms.Grant
+}
+
+// Explain simulates the authorization check Verify performs when the user,
+// authenticated through the account if not empty, requests the action on the
+// resource. The grants are looked up and evaluated the same way, but nothing
+// is performed. As with Verify, the resource's scope and pin must be the ones
+// its handler looks up for it rather than ones given by the caller, since the
+// grants that apply depend on them.
+func Explain(ctx context.Context, iamRepo *iam.Repository, userId, accountId string, res perms.Resource, act action.Type) (*ExplainResults, error) {
+	const op = "auth.Explain"
+	switch {
+	case iamRepo == nil:
+		return nil, errors.New(errors.InvalidParameter, op, "missing iam repository")
+	case userId == "":
+		return nil, errors.New(errors.InvalidParameter, op, "missing user id")
+	case res.ScopeId == "":
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	case act == action.Unknown:
+		return nil, errors.New(errors.InvalidParameter, op, "missing action")
+	}
+	// Global scope has no parent ID; account for this the same way Verify
+	// does
+	if res.Id == scope.Global.String() && res.Type == resource.Scope {
+		res.ScopeId = scope.Global.String()
+	}
+
+	// Grants can match on the labels of the resource, the same as when
+	// verifying
+	if res.Id != "" && res.Labels == nil && perms.LabeledType(res.Type) {
+		labels, err := iamRepo.ListResourceLabels(ctx, []string{res.Id})
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		res.Labels = labels[res.Id]
+	}
+
+	// Grants can match on the owner of the resource, the same as when
+	// verifying
+	if res.Id != "" && res.OwnerId == "" && perms.OwnedType(res.Type) {
+		owners, err := iamRepo.ListResourceOwners(ctx, []string{res.Id})
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		res.OwnerId = owners[res.Id]
+	}
+
+	pairs, grants, err := parsedGrantsForUser(ctx, iamRepo, userId, accountId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	aclResults := perms.NewACL(grants...).Allowed(res, act)
+	ret := &ExplainResults{
+		Authorized: aclResults.Authorized,
+	}
+	if ret.Authorized {
+		ret.OutputFields = aclResults.OutputFields.SelfOrDefaults(userId)
+	}
+	for i, g := range grants {
+		if g.Matches(res, act) {
+			ret.MatchingGrants = append(ret.MatchingGrants, MatchingGrant{
+				RoleId:  pairs[i].RoleId,
+				ScopeId: pairs[i].ScopeId,
+				Grant:   g,
+			})
+		}
+	}
+	return ret, nil
+}
+
+// parsedGrantsForUser fetches the grants for the user ID (which may include
+// grants for u_anon and u_auth) and parses them, filling in templates for the
+// user and account. The returned grants are in the same order as the pairs
+// they were parsed from.
+func parsedGrantsForUser(ctx context.Context, iamRepo *iam.Repository, userId, accountId string) ([]perms.GrantPair, []perms.Grant, error) {
+	const op = "auth.parsedGrantsForUser"
+	grantPairs, err := iamRepo.GrantsForUser(ctx, userId)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	parsedGrants := make([]perms.Grant, 0, len(grantPairs))
+	// Note: Below, we always skip validation so that we don't error on formats
+	// that we've since restricted, e.g. "id=foo;actions=create,read". These
+	// will simply not have an effect.
+	for _, pair := range grantPairs {
+		parsed, err := perms.Parse(
+			pair.ScopeId,
+			pair.Grant,
+			perms.WithUserId(userId),
+			perms.WithAccountId(accountId),
+			perms.WithSkipFinalValidation(true))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed to parse grant %#v", pair.Grant)))
+		}
+		parsedGrants = append(parsedGrants, parsed)
+	}
+	return grantPairs, parsedGrants, nil
+}
@@ -3,6 +3,7 @@ package oidc
 import (
 	"crypto/x509"
 	"net/url"
+	"time"
 )
 
 // getOpts - iterate the inbound Options and return a struct.
@@ -42,6 +43,11 @@ type options struct {
 	withIssuer              *url.URL
 	withOperationalState    AuthMethodState
 	withAccountClaimMap     map[string]AccountToClaim
+
+	withProviderSessionId     string
+	withRefreshToken          string
+	withAccessToken           string
+	withAccessTokenExpiration time.Time
 }
 
 func getDefaultOptions() options {
@@ -218,3 +224,28 @@ func WithAccountClaimMap(acm map[string]AccountToClaim) Option {
 		o.withAccountClaimMap = acm
 	}
 }
+
+// WithProviderSessionId provides an option for specifying the provider's
+// session id (the id_token's sid claim).
+func WithProviderSessionId(sid string) Option {
+	return func(o *options) {
+		o.withProviderSessionId = sid
+	}
+}
+
+// WithRefreshToken provides an option for specifying a refresh_token.
+func WithRefreshToken(t string) Option {
+	return func(o *options) {
+		o.withRefreshToken = t
+	}
+}
+
+// WithAccessToken provides an option for specifying an access_token and its
+// expiration.  A zero expiration indicates that the access_token doesn't
+// expire.
+func WithAccessToken(t string, expiration time.Time) Option {
+	return func(o *options) {
+		o.withAccessToken = t
+		o.withAccessTokenExpiration = expiration
+	}
+}
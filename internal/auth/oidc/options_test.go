@@ -4,6 +4,7 @@ import (
 	"crypto/x509"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -194,4 +195,27 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withAccountClaimMap = acm
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithProviderSessionId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithProviderSessionId("sid"))
+		testOpts := getDefaultOptions()
+		testOpts.withProviderSessionId = "sid"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithRefreshToken", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithRefreshToken("refresh"))
+		testOpts := getDefaultOptions()
+		testOpts.withRefreshToken = "refresh"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAccessToken", func(t *testing.T) {
+		assert := assert.New(t)
+		exp := time.Now().Add(time.Hour)
+		opts := getOpts(WithAccessToken("access", exp))
+		testOpts := getDefaultOptions()
+		testOpts.withAccessToken = "access"
+		testOpts.withAccessTokenExpiration = exp
+		assert.Equal(opts, testOpts)
+	})
 }
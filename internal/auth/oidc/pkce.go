@@ -0,0 +1,46 @@
+package oidc
+
+import (
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/cap/oidc"
+)
+
+// codeVerifier is a PKCE code verifier rebuilt from the verifier stored in an
+// encrypted request.State.  It implements the oidc.CodeVerifier interface, so
+// it can be used to exchange an authorization code that was requested with
+// the verifier's S256 code challenge.
+type codeVerifier struct {
+	verifier  string
+	challenge string
+}
+
+// newCodeVerifier rebuilds a S256 code verifier from the verifier v.
+func newCodeVerifier(v string) (*codeVerifier, error) {
+	const op = "oidc.newCodeVerifier"
+	if v == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing verifier")
+	}
+	cv := &codeVerifier{verifier: v}
+	var err error
+	if cv.challenge, err = oidc.CreateCodeChallenge(cv); err != nil {
+		return nil, errors.New(errors.Unknown, op, "unable to create code challenge", errors.WithWrap(err))
+	}
+	return cv, nil
+}
+
+// Verifier implements the oidc.CodeVerifier.Verifier() interface function.
+func (v *codeVerifier) Verifier() string { return v.verifier }
+
+// Challenge implements the oidc.CodeVerifier.Challenge() interface function.
+func (v *codeVerifier) Challenge() string { return v.challenge }
+
+// Method implements the oidc.CodeVerifier.Method() interface function.
+func (v *codeVerifier) Method() oidc.ChallengeMethod { return oidc.S256 }
+
+// Copy implements the oidc.CodeVerifier.Copy() interface function.
+func (v *codeVerifier) Copy() oidc.CodeVerifier {
+	return &codeVerifier{
+		verifier:  v.verifier,
+		challenge: v.challenge,
+	}
+}
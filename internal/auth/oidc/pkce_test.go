@@ -0,0 +1,31 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/cap/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newCodeVerifier(t *testing.T) {
+	t.Parallel()
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		orig, err := oidc.NewCodeVerifier()
+		require.NoError(err)
+
+		got, err := newCodeVerifier(orig.Verifier())
+		require.NoError(err)
+		assert.Equal(orig.Verifier(), got.Verifier())
+		assert.Equal(orig.Challenge(), got.Challenge())
+		assert.Equal(orig.Method(), got.Method())
+		assert.Equal(got, got.Copy())
+	})
+	t.Run("missing-verifier", func(t *testing.T) {
+		assert := assert.New(t)
+		got, err := newCodeVerifier("")
+		assert.Error(err)
+		assert.Nil(got)
+	})
+}
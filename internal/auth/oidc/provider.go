@@ -6,6 +6,7 @@ import (
 	"strings"
 	"sync"
 
+	gooidc "github.com/coreos/go-oidc"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/cap/oidc"
 )
@@ -30,15 +31,25 @@ func providerCache() *providers {
 // make requests to the IdP, verify ID tokens, etc.  For more info on
 // oidc.Provider capabilities see: https://github.com/hashicorp/cap
 type providers struct {
-	cache map[string]*oidc.Provider
-	mu    *sync.RWMutex
+	cache           map[string]*oidc.Provider
+	logoutVerifiers map[string]*logoutVerifier
+	mu              *sync.RWMutex
+}
+
+// logoutVerifier verifies the logout tokens of back-channel logout requests
+// for an auth method.  The configHash is the hash of the oidc.Provider it was
+// created for.
+type logoutVerifier struct {
+	configHash uint64
+	verifier   *gooidc.IDTokenVerifier
 }
 
 // newProviderCache make a new cache
 func newProviderCache() *providers {
 	return &providers{
-		cache: map[string]*oidc.Provider{},
-		mu:    &sync.RWMutex{},
+		cache:           map[string]*oidc.Provider{},
+		logoutVerifiers: map[string]*logoutVerifier{},
+		mu:              &sync.RWMutex{},
 	}
 }
 
@@ -83,6 +94,54 @@ func (c *providers) get(ctx context.Context, currentFromDb *AuthMethod) (*oidc.P
 	return storedProvider, nil
 }
 
+// getLogoutVerifier returns a verifier for the logout tokens of back-channel
+// logout requests for the current AuthMethod from the DB.  The verifier is
+// cached along with the auth method's oidc.Provider, so the provider's
+// discovery document and JWKs aren't fetched for every request.  It's
+// replaced when the provider's configuration has been updated in the DB.
+func (c *providers) getLogoutVerifier(ctx context.Context, currentFromDb *AuthMethod) (*gooidc.IDTokenVerifier, error) {
+	const op = "oidc.(providers).getLogoutVerifier"
+	p, err := c.get(ctx, currentFromDb)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	hash, err := p.ConfigHash()
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to hash provider"))
+	}
+	c.mu.RLock()
+	v, ok := c.logoutVerifiers[currentFromDb.PublicId]
+	c.mu.RUnlock()
+	if ok && v.configHash == hash {
+		return v.verifier, nil
+	}
+
+	// the go-oidc provider keeps the context it's created with to fetch the
+	// JWKs later, so it mustn't be the request's context.
+	oidcCtx, err := p.HTTPClientContext(context.Background())
+	if err != nil {
+		return nil, errors.New(errors.Unknown, op, "unable to create http client", errors.WithWrap(err))
+	}
+	goProvider, err := gooidc.NewProvider(oidcCtx, currentFromDb.Issuer)
+	if err != nil {
+		return nil, errors.New(errors.Unavailable, op, "unable to discover provider", errors.WithWrap(err))
+	}
+	// logout tokens are not required to have an exp claim, so the expiry is
+	// checked by BackChannelLogout along with the iat and jti claims.
+	v = &logoutVerifier{
+		configHash: hash,
+		verifier: goProvider.Verifier(&gooidc.Config{
+			ClientID:             currentFromDb.ClientId,
+			SupportedSigningAlgs: currentFromDb.SigningAlgs,
+			SkipExpiryCheck:      true,
+		}),
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logoutVerifiers[currentFromDb.PublicId] = v
+	return v.verifier, nil
+}
+
 // set will set an entry in the cache.
 func (c *providers) set(ctx context.Context, authMethodId string, p *oidc.Provider) {
 	c.mu.Lock()
@@ -95,6 +154,7 @@ func (c *providers) delete(ctx context.Context, authMethodId string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	delete(c.cache, authMethodId)
+	delete(c.logoutVerifiers, authMethodId)
 }
 
 func convertToProvider(ctx context.Context, am *AuthMethod) (*oidc.Provider, error) {
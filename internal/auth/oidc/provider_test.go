@@ -78,6 +78,42 @@ func Test_ProviderCaching(t *testing.T) {
 		cache.delete(ctx, authMethodId)
 		assert.Equal(0, len(cache.cache))
 	})
+	t.Run("logout-verifier", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		cache := newProviderCache()
+		// the verifier fetches the JWKs when it's first used, so it must
+		// outlive the request it was created for.
+		reqCtx, cancel := context.WithCancel(ctx)
+		v1, err := cache.getLogoutVerifier(reqCtx, testAm)
+		cancel()
+		require.NoError(err)
+		require.Equal(1, len(cache.logoutVerifiers))
+
+		priv, _, alg, keyId := tp.SigningKeys()
+		tk := oidc.TestSignJWT(t, priv, string(alg), map[string]interface{}{
+			"iss": tp.Addr(),
+			"aud": id,
+			"sub": "alice",
+		}, []byte(keyId))
+		got, err := v1.Verify(ctx, tk)
+		require.NoError(err)
+		assert.Equal("alice", got.Subject)
+
+		v2, err := cache.getLogoutVerifier(ctx, testAm)
+		require.NoError(err)
+		assert.Equal(v1, v2)
+
+		newAm := testAm.Clone()
+		newAm.ClientId = "new-client-id"
+		v3, err := cache.getLogoutVerifier(ctx, newAm)
+		require.NoError(err)
+		assert.NotEqual(v1, v3)
+		_, err = v3.Verify(ctx, tk)
+		assert.Error(err)
+
+		cache.delete(ctx, authMethodId)
+		assert.Equal(0, len(cache.logoutVerifiers))
+	})
 }
 
 func Test_convertToProvider(t *testing.T) {
@@ -0,0 +1,136 @@
+package oidc
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultProviderTokenTableName defines the default table name for a ProviderToken
+const defaultProviderTokenTableName = "auth_oidc_provider_token"
+
+// ProviderToken contains the tokens returned by an OIDC provider for a
+// successful authentication.  It's owned by the Boundary auth token issued for
+// the authentication and deletes of that auth token are cascaded to its
+// ProviderToken.
+//
+// The refresh_token and access_token are used to periodically re-validate the
+// user with the provider and the ProviderSessionId (the id_token's sid claim)
+// is used to find the auth token when the provider sends a back-channel logout
+// request for a specific session.
+type ProviderToken struct {
+	*store.ProviderToken
+	tableName string
+}
+
+// NewProviderToken creates a new in memory ProviderToken for the auth token
+// issued to the account. WithProviderSessionId, WithRefreshToken and
+// WithAccessToken are the only valid options. All other options are ignored.
+func NewProviderToken(authTokenId, authMethodId, accountId string, opt ...Option) (*ProviderToken, error) {
+	const op = "oidc.NewProviderToken"
+	opts := getOpts(opt...)
+	t := &ProviderToken{
+		ProviderToken: &store.ProviderToken{
+			AuthTokenId:       authTokenId,
+			OidcMethodId:      authMethodId,
+			AccountId:         accountId,
+			ProviderSessionId: opts.withProviderSessionId,
+			RefreshToken:      opts.withRefreshToken,
+			AccessToken:       opts.withAccessToken,
+		},
+	}
+	if !opts.withAccessTokenExpiration.IsZero() {
+		t.AccessTokenExpirationTime = &timestamp.Timestamp{Timestamp: timestamppb.New(opts.withAccessTokenExpiration)}
+	}
+	if err := t.validate(op); err != nil {
+		return nil, err // intentionally not wrapped.
+	}
+	return t, nil
+}
+
+// validate the ProviderToken.  On success, it will return nil.
+func (t *ProviderToken) validate(caller errors.Op) error {
+	if t.AuthTokenId == "" {
+		return errors.New(errors.InvalidParameter, caller, "missing auth token id")
+	}
+	if t.OidcMethodId == "" {
+		return errors.New(errors.InvalidParameter, caller, "missing auth method id")
+	}
+	if t.AccountId == "" {
+		return errors.New(errors.InvalidParameter, caller, "missing account id")
+	}
+	return nil
+}
+
+// AllocProviderToken makes an empty one in memory
+func AllocProviderToken() *ProviderToken {
+	return &ProviderToken{
+		ProviderToken: &store.ProviderToken{},
+	}
+}
+
+// Clone a ProviderToken.
+func (t *ProviderToken) Clone() *ProviderToken {
+	cp := proto.Clone(t.ProviderToken)
+	return &ProviderToken{
+		ProviderToken: cp.(*store.ProviderToken),
+	}
+}
+
+// TableName returns the table name.
+func (t *ProviderToken) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return defaultProviderTokenTableName
+}
+
+// SetTableName sets the table name.
+func (t *ProviderToken) SetTableName(n string) {
+	t.tableName = n
+}
+
+// accessTokenValid returns true if the ProviderToken has an access_token
+// which has not expired.  An access_token without an expiration is considered
+// valid.
+func (t *ProviderToken) accessTokenValid(now time.Time) bool {
+	if t.AccessToken == "" {
+		return false
+	}
+	if t.AccessTokenExpirationTime.GetTimestamp() == nil {
+		return true
+	}
+	return now.Before(t.AccessTokenExpirationTime.GetTimestamp().AsTime())
+}
+
+// encrypt the provider token before writing it to the db
+func (t *ProviderToken) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "oidc.(ProviderToken).encrypt"
+	if cipher == nil {
+		return errors.New(errors.InvalidParameter, op, "missing cipher")
+	}
+	if err := structwrapping.WrapStruct(ctx, cipher, t.ProviderToken, nil); err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Encrypt))
+	}
+	t.KeyId = cipher.KeyID()
+	return nil
+}
+
+// decrypt the provider token after reading it from the db
+func (t *ProviderToken) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "oidc.(ProviderToken).decrypt"
+	if cipher == nil {
+		return errors.New(errors.InvalidParameter, op, "missing cipher")
+	}
+	if err := structwrapping.UnwrapStruct(ctx, cipher, t.ProviderToken, nil); err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
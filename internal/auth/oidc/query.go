@@ -12,4 +12,17 @@ const (
 			%s
 		returning public_id, version
 	`
+
+	authMethodsToRevalidateQuery = `
+		select distinct oidc_method_id
+		from auth_oidc_provider_token
+		where last_validation_time < ?
+	`
+
+	claimProviderTokenQuery = `
+		update auth_oidc_provider_token
+		set last_validation_time = now()
+		where auth_token_id = ?
+			and last_validation_time < ?
+	`
 )
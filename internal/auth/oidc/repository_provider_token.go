@@ -0,0 +1,249 @@
+package oidc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// createProviderToken inserts a ProviderToken, t, for the auth method, am,
+// into the repository and returns a new ProviderToken. t is not changed. The
+// tokens are encrypted with the database key of the auth method's scope.
+//
+// Provider tokens are not replicated, so they don't need oplog entries.
+func (r *Repository) createProviderToken(ctx context.Context, am *AuthMethod, t *ProviderToken) (*ProviderToken, error) {
+	const op = "oidc.(Repository).createProviderToken"
+	if am == nil || am.AuthMethod == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing auth method")
+	}
+	if t == nil || t.ProviderToken == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing provider token")
+	}
+	if err := t.validate(op); err != nil {
+		return nil, err // intentionally not wrapped.
+	}
+	if t.OidcMethodId != am.PublicId {
+		return nil, errors.New(errors.InvalidParameter, op, "provider token auth method id does not match auth method")
+	}
+	t = t.Clone()
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, am.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := t.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	t.LastValidationTime = &timestamp.Timestamp{Timestamp: timestamppb.Now()}
+
+	var returnedToken *ProviderToken
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedToken = t.Clone()
+			if err := w.Create(ctx, returnedToken); err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(t.AuthTokenId))
+	}
+	if err := returnedToken.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return returnedToken, nil
+}
+
+// updateProviderToken updates the refresh_token, access_token and
+// last_validation_time of the ProviderToken, t, after it has been re-validated
+// with the provider.  The tokens are re-encrypted with the current database
+// key of the auth method's scope.
+//
+// Provider tokens are not replicated, so they don't need oplog entries.
+func (r *Repository) updateProviderToken(ctx context.Context, am *AuthMethod, t *ProviderToken) (*ProviderToken, error) {
+	const op = "oidc.(Repository).updateProviderToken"
+	if am == nil || am.AuthMethod == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing auth method")
+	}
+	if t == nil || t.ProviderToken == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing provider token")
+	}
+	if t.AuthTokenId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing auth token id")
+	}
+	t = t.Clone()
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, am.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := t.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	t.LastValidationTime = &timestamp.Timestamp{Timestamp: timestamppb.Now()}
+
+	fieldMasks := []string{"CtRefreshToken", "CtAccessToken", "KeyId", "LastValidationTime"}
+	var nullFields []string
+	if t.AccessTokenExpirationTime.GetTimestamp() != nil {
+		fieldMasks = append(fieldMasks, "AccessTokenExpirationTime")
+	} else {
+		nullFields = append(nullFields, "AccessTokenExpirationTime")
+	}
+
+	var returnedToken *ProviderToken
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedToken = t.Clone()
+			rowsUpdated, err := w.Update(ctx, returnedToken, fieldMasks, nullFields)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsUpdated != 1 {
+				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(t.AuthTokenId))
+	}
+	if err := returnedToken.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return returnedToken, nil
+}
+
+// claimProviderToken claims the ProviderToken, t, for re-validation by setting
+// its last_validation_time, provided it was last validated before
+// validatedBefore.  It returns false when it wasn't, which means another
+// controller has claimed it since it was listed.
+//
+// Provider tokens are not replicated, so they don't need oplog entries.
+func (r *Repository) claimProviderToken(ctx context.Context, t *ProviderToken, validatedBefore time.Time) (bool, error) {
+	const op = "oidc.(Repository).claimProviderToken"
+	if t == nil || t.ProviderToken == nil {
+		return false, errors.New(errors.InvalidParameter, op, "missing provider token")
+	}
+	if t.AuthTokenId == "" {
+		return false, errors.New(errors.InvalidParameter, op, "missing auth token id")
+	}
+	var rowsUpdated int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			rowsUpdated, err = w.Exec(ctx, claimProviderTokenQuery, []interface{}{t.AuthTokenId, validatedBefore})
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return false, errors.Wrap(err, op, errors.WithMsg(t.AuthTokenId))
+	}
+	return rowsUpdated == 1, nil
+}
+
+// listProviderTokensForLogout returns the decrypted ProviderTokens for the
+// auth method, am, which match a back-channel logout request.  When subject is
+// not empty, only tokens issued to the account with that subject are
+// returned.  When providerSessionId is not empty, only tokens issued for that
+// provider session are returned.  At least one of them is required.
+func (r *Repository) listProviderTokensForLogout(ctx context.Context, am *AuthMethod, subject, providerSessionId string) ([]*ProviderToken, error) {
+	const op = "oidc.(Repository).listProviderTokensForLogout"
+	if am == nil || am.AuthMethod == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing auth method")
+	}
+	if subject == "" && providerSessionId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing both subject and provider session id")
+	}
+	where, args := []string{"oidc_method_id = ?"}, []interface{}{am.PublicId}
+	if subject != "" {
+		where = append(where, "account_id in (select public_id from auth_oidc_account where auth_method_id = ? and subject = ?)")
+		args = append(args, am.PublicId, subject)
+	}
+	if providerSessionId != "" {
+		where, args = append(where, "provider_session_id = ?"), append(args, providerSessionId)
+	}
+	tokens, err := r.searchProviderTokens(ctx, am, strings.Join(where, " and "), args)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return tokens, nil
+}
+
+// listProviderTokensToRevalidate returns the decrypted ProviderTokens for the
+// auth method, am, which were last validated before validatedBefore.
+func (r *Repository) listProviderTokensToRevalidate(ctx context.Context, am *AuthMethod, validatedBefore time.Time) ([]*ProviderToken, error) {
+	const op = "oidc.(Repository).listProviderTokensToRevalidate"
+	if am == nil || am.AuthMethod == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing auth method")
+	}
+	tokens, err := r.searchProviderTokens(ctx, am, "oidc_method_id = ? and last_validation_time < ?", []interface{}{am.PublicId, validatedBefore})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return tokens, nil
+}
+
+// listAuthMethodIdsToRevalidate returns the ids of the auth methods which have
+// ProviderTokens which were last validated before validatedBefore.
+func (r *Repository) listAuthMethodIdsToRevalidate(ctx context.Context, validatedBefore time.Time) ([]string, error) {
+	const op = "oidc.(Repository).listAuthMethodIdsToRevalidate"
+	rows, err := r.reader.Query(ctx, authMethodsToRevalidateQuery, []interface{}{validatedBefore})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return ids, nil
+}
+
+// searchProviderTokens returns the decrypted ProviderTokens of the auth
+// method, am, which match the where clause.
+func (r *Repository) searchProviderTokens(ctx context.Context, am *AuthMethod, where string, args []interface{}) ([]*ProviderToken, error) {
+	const op = "oidc.(Repository).searchProviderTokens"
+	var tokens []*ProviderToken
+	if err := r.reader.SearchWhere(ctx, &tokens, where, args, db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	for _, t := range tokens {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, am.ScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(t.KeyId))
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := t.decrypt(ctx, databaseWrapper); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+	return tokens, nil
+}
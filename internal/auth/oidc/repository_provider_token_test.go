@@ -0,0 +1,341 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_createProviderToken(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	rootWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	iamRepo := iam.TestRepo(t, conn, rootWrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	am := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, InactiveState, "alice-rp", "fido",
+		WithIssuer(TestConvertToUrls(t, "https://alice.com")[0]), WithApiUrl(TestConvertToUrls(t, "https://api.com")[0]))
+	acct := TestAccount(t, conn, am, "alice")
+	user := iam.TestUser(t, iamRepo, org.PublicId, iam.WithAccountIds(acct.PublicId))
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	atRepo, err := authtoken.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		am        *AuthMethod
+		token     func() *ProviderToken
+		wantErr   bool
+		wantIsErr errors.Code
+	}{
+		{
+			name: "valid",
+			am:   am,
+			token: func() *ProviderToken {
+				at, err := atRepo.CreateAuthToken(ctx, user, acct.PublicId)
+				require.NoError(t, err)
+				pt, err := NewProviderToken(at.PublicId, am.PublicId, acct.PublicId,
+					WithProviderSessionId("sid"),
+					WithRefreshToken("refresh"),
+					WithAccessToken("access", time.Now().Add(time.Hour)))
+				require.NoError(t, err)
+				return pt
+			},
+		},
+		{
+			name: "valid-without-tokens",
+			am:   am,
+			token: func() *ProviderToken {
+				at, err := atRepo.CreateAuthToken(ctx, user, acct.PublicId)
+				require.NoError(t, err)
+				pt, err := NewProviderToken(at.PublicId, am.PublicId, acct.PublicId)
+				require.NoError(t, err)
+				return pt
+			},
+		},
+		{
+			name: "missing-auth-method",
+			token: func() *ProviderToken {
+				pt, err := NewProviderToken("at_1234567890", am.PublicId, acct.PublicId)
+				require.NoError(t, err)
+				return pt
+			},
+			wantErr:   true,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-provider-token",
+			am:        am,
+			token:     func() *ProviderToken { return nil },
+			wantErr:   true,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "auth-method-mismatch",
+			am:   am,
+			token: func() *ProviderToken {
+				pt, err := NewProviderToken("at_1234567890", "amoidc_1234567890", acct.PublicId)
+				require.NoError(t, err)
+				return pt
+			},
+			wantErr:   true,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "unknown-auth-token",
+			am:   am,
+			token: func() *ProviderToken {
+				pt, err := NewProviderToken("at_1234567890", am.PublicId, acct.PublicId)
+				require.NoError(t, err)
+				return pt
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			pt := tt.token()
+			got, err := repo.createProviderToken(ctx, tt.am, pt)
+			if tt.wantErr {
+				require.Error(err)
+				if tt.wantIsErr != 0 {
+					assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "unexpected error %s", err.Error())
+				}
+				return
+			}
+			require.NoError(err)
+			assert.Equal(pt.RefreshToken, got.RefreshToken)
+			assert.Equal(pt.AccessToken, got.AccessToken)
+			assert.Equal(pt.ProviderSessionId, got.ProviderSessionId)
+			assert.NotNil(got.LastValidationTime)
+
+			found, err := repo.listProviderTokensToRevalidate(ctx, am, time.Now().Add(time.Minute))
+			require.NoError(err)
+			var foundToken *ProviderToken
+			for _, f := range found {
+				if f.AuthTokenId == pt.AuthTokenId {
+					foundToken = f
+				}
+			}
+			require.NotNil(foundToken)
+			assert.Equal(pt.RefreshToken, foundToken.RefreshToken)
+			assert.Equal(pt.AccessToken, foundToken.AccessToken)
+		})
+	}
+}
+
+func TestRepository_updateProviderToken(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	rootWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	iamRepo := iam.TestRepo(t, conn, rootWrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	am := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, InactiveState, "alice-rp", "fido",
+		WithIssuer(TestConvertToUrls(t, "https://alice.com")[0]), WithApiUrl(TestConvertToUrls(t, "https://api.com")[0]))
+	acct := TestAccount(t, conn, am, "alice")
+	user := iam.TestUser(t, iamRepo, org.PublicId, iam.WithAccountIds(acct.PublicId))
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		pt := TestProviderToken(t, conn, kmsCache, am, user, acct,
+			WithRefreshToken("refresh"),
+			WithAccessToken("access", time.Now().Add(time.Hour)))
+		pt.RefreshToken = "rotated-refresh"
+		pt.AccessToken = "new-access"
+		pt.AccessTokenExpirationTime = nil
+		got, err := repo.updateProviderToken(ctx, am, pt)
+		require.NoError(err)
+		assert.Equal("rotated-refresh", got.RefreshToken)
+		assert.Equal("new-access", got.AccessToken)
+		assert.Nil(got.AccessTokenExpirationTime)
+		assert.True(got.LastValidationTime.GetTimestamp().AsTime().After(pt.LastValidationTime.GetTimestamp().AsTime()))
+
+		found, err := repo.listProviderTokensForLogout(ctx, am, "alice", "")
+		require.NoError(err)
+		require.Len(found, 1)
+		assert.Equal("rotated-refresh", found[0].RefreshToken)
+		assert.Equal("new-access", found[0].AccessToken)
+		assert.Nil(found[0].AccessTokenExpirationTime)
+	})
+	t.Run("missing-auth-method", func(t *testing.T) {
+		_, err := repo.updateProviderToken(ctx, nil, AllocProviderToken())
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+	})
+	t.Run("missing-auth-token-id", func(t *testing.T) {
+		_, err := repo.updateProviderToken(ctx, am, AllocProviderToken())
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+	})
+	t.Run("unknown-auth-token", func(t *testing.T) {
+		pt, err := NewProviderToken("at_1234567890", am.PublicId, acct.PublicId)
+		require.NoError(t, err)
+		_, err = repo.updateProviderToken(ctx, am, pt)
+		assert.Error(t, err)
+	})
+}
+
+func TestRepository_claimProviderToken(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	rootWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	iamRepo := iam.TestRepo(t, conn, rootWrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	am := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, InactiveState, "alice-rp", "fido",
+		WithIssuer(TestConvertToUrls(t, "https://alice.com")[0]), WithApiUrl(TestConvertToUrls(t, "https://api.com")[0]))
+	acct := TestAccount(t, conn, am, "alice")
+	user := iam.TestUser(t, iamRepo, org.PublicId, iam.WithAccountIds(acct.PublicId))
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	assert, require := assert.New(t), require.New(t)
+	pt := TestProviderToken(t, conn, kmsCache, am, user, acct)
+	testSetLastValidationTime(t, conn, pt.AuthTokenId, time.Now().Add(-time.Hour))
+
+	validatedBefore := time.Now().Add(-time.Minute)
+	claimed, err := repo.claimProviderToken(ctx, pt, validatedBefore)
+	require.NoError(err)
+	assert.True(claimed)
+
+	// a second claim, e.g. by another controller, fails
+	claimed, err = repo.claimProviderToken(ctx, pt, validatedBefore)
+	require.NoError(err)
+	assert.False(claimed)
+
+	found, err := repo.listProviderTokensToRevalidate(ctx, am, validatedBefore)
+	require.NoError(err)
+	assert.Empty(found)
+
+	claimed, err = repo.claimProviderToken(ctx, nil, validatedBefore)
+	assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+	assert.False(claimed)
+}
+
+func TestRepository_listProviderTokens(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	rootWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	iamRepo := iam.TestRepo(t, conn, rootWrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	am := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, InactiveState, "alice-rp", "fido",
+		WithIssuer(TestConvertToUrls(t, "https://alice.com")[0]), WithApiUrl(TestConvertToUrls(t, "https://api.com")[0]))
+	am2 := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, InactiveState, "bob-rp", "fido",
+		WithIssuer(TestConvertToUrls(t, "https://bob.com")[0]), WithApiUrl(TestConvertToUrls(t, "https://api.com")[0]))
+	alice := TestAccount(t, conn, am, "alice")
+	aliceUser := iam.TestUser(t, iamRepo, org.PublicId, iam.WithAccountIds(alice.PublicId))
+	bob := TestAccount(t, conn, am, "bob")
+	bobUser := iam.TestUser(t, iamRepo, org.PublicId, iam.WithAccountIds(bob.PublicId))
+	carol := TestAccount(t, conn, am2, "carol")
+	carolUser := iam.TestUser(t, iamRepo, org.PublicId, iam.WithAccountIds(carol.PublicId))
+
+	alice1 := TestProviderToken(t, conn, kmsCache, am, aliceUser, alice, WithProviderSessionId("sid1"))
+	alice2 := TestProviderToken(t, conn, kmsCache, am, aliceUser, alice, WithProviderSessionId("sid2"))
+	bob1 := TestProviderToken(t, conn, kmsCache, am, bobUser, bob, WithProviderSessionId("sid1"))
+	carol1 := TestProviderToken(t, conn, kmsCache, am2, carolUser, carol)
+	testSetLastValidationTime(t, conn, alice1.AuthTokenId, time.Now().Add(-time.Hour))
+	testSetLastValidationTime(t, conn, carol1.AuthTokenId, time.Now().Add(-time.Hour))
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	tokenIds := func(tokens []*ProviderToken) []string {
+		var ids []string
+		for _, t := range tokens {
+			ids = append(ids, t.AuthTokenId)
+		}
+		return ids
+	}
+
+	t.Run("logout", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			am        *AuthMethod
+			subject   string
+			sid       string
+			want      []string
+			wantIsErr errors.Code
+		}{
+			{name: "subject", am: am, subject: "alice", want: []string{alice1.AuthTokenId, alice2.AuthTokenId}},
+			{name: "sid", am: am, sid: "sid1", want: []string{alice1.AuthTokenId, bob1.AuthTokenId}},
+			{name: "subject-and-sid", am: am, subject: "alice", sid: "sid1", want: []string{alice1.AuthTokenId}},
+			{name: "other-auth-method", am: am2, subject: "alice"},
+			{name: "unknown-subject", am: am, subject: "dave"},
+			{name: "missing-subject-and-sid", am: am, wantIsErr: errors.InvalidParameter},
+			{name: "missing-auth-method", subject: "alice", wantIsErr: errors.InvalidParameter},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert, require := assert.New(t), require.New(t)
+				got, err := repo.listProviderTokensForLogout(ctx, tt.am, tt.subject, tt.sid)
+				if tt.wantIsErr != 0 {
+					assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "unexpected error %v", err)
+					return
+				}
+				require.NoError(err)
+				assert.ElementsMatch(tt.want, tokenIds(got))
+			})
+		}
+	})
+
+	t.Run("revalidate", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		validatedBefore := time.Now().Add(-time.Minute)
+		got, err := repo.listProviderTokensToRevalidate(ctx, am, validatedBefore)
+		require.NoError(err)
+		assert.ElementsMatch([]string{alice1.AuthTokenId}, tokenIds(got))
+
+		amIds, err := repo.listAuthMethodIdsToRevalidate(ctx, validatedBefore)
+		require.NoError(err)
+		assert.ElementsMatch([]string{am.PublicId, am2.PublicId}, amIds)
+
+		amIds, err = repo.listAuthMethodIdsToRevalidate(ctx, time.Now().Add(-2*time.Hour))
+		require.NoError(err)
+		assert.Empty(amIds)
+
+		_, err = repo.listProviderTokensToRevalidate(ctx, nil, validatedBefore)
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+	})
+}
+
+// testSetLastValidationTime sets the last_validation_time of the auth token's
+// ProviderToken, so it's re-validated.
+func testSetLastValidationTime(t *testing.T, conn *gorm.DB, authTokenId string, lastValidated time.Time) {
+	t.Helper()
+	rw := db.New(conn)
+	rowsUpdated, err := rw.Exec(context.Background(), "update auth_oidc_provider_token set last_validation_time = ? where auth_token_id = ?", []interface{}{lastValidated, authTokenId})
+	require.NoError(t, err)
+	require.Equal(t, 1, rowsUpdated)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/session"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
 	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
 
@@ -33,6 +34,10 @@ const (
 	// CallbackEndpoint is the endpoint for the oidc callback which will be
 	// included in the auth URL returned when an authen attempted is kicked off.
 	CallbackEndpoint = "%s/v1/auth-methods/oidc:authenticate:callback"
+
+	// LogoutEndpoint is the endpoint for the oidc back-channel logout, which
+	// must be registered with the provider for the auth method.
+	LogoutEndpoint = "%s/v1/auth-methods/%s:authenticate:logout"
 )
 
 type (
@@ -44,6 +49,9 @@ type (
 
 	// AuthTokenRepoFactory is used by "service functions" to create a new auth token repo
 	AuthTokenRepoFactory func() (*authtoken.Repository, error)
+
+	// SessionRepoFactory is used by "service functions" to create a new session repo
+	SessionRepoFactory func() (*session.Repository, error)
 )
 
 // validator defines an optional interface that proto messages can implement
@@ -27,7 +27,7 @@ import (
 //
 // * Decrypt the state which has been encrypted with the OIDC DEK. If decryption
 // fails, and error is returned. Decrypted state payload includes the
-// token_request_id, nonce and final_redirect_url.
+// token_request_id, nonce, PKCE code verifier and final_redirect_url.
 //
 // * Exchange the callbackCodeParameter for provider tokens and validate the
 // tokens.  Call UserInfo endpoint using access token.
@@ -41,6 +41,9 @@ import (
 //
 // * Use the authtoken.(Repository).CreateAuthToken(...) to create a pending
 // auth token for the authenticated user.
+//
+// * Save the provider's refresh and access tokens for the pending auth token,
+// so it can be re-validated and revoked by a back-channel logout.
 func Callback(
 	ctx context.Context,
 	oidcRepoFn OidcRepoFactory,
@@ -148,6 +151,14 @@ func Callback(
 	if len(am.AudClaims) > 0 {
 		opts = append(opts, oidc.WithAudiences(am.AudClaims...))
 	}
+	// requests started before PKCE was supported won't have a verifier.
+	if reqState.CodeVerifier != "" {
+		verifier, err := newCodeVerifier(reqState.CodeVerifier)
+		if err != nil {
+			return "", errors.Wrap(err, op)
+		}
+		opts = append(opts, oidc.WithPKCE(verifier))
+	}
 	if strings.TrimSpace(am.ApiUrl) == "" {
 		return "", errors.New(errors.InvalidParameter, op, "empty api URL")
 	}
@@ -216,6 +227,28 @@ func Callback(
 		}
 		return "", errors.Wrap(err, op)
 	}
+
+	// keep the provider's tokens for the pending token, so the user can be
+	// re-validated with the provider and the token can be revoked by a
+	// back-channel logout.  If they can't be saved, the pending token is
+	// deleted since it could never be revoked by the provider.
+	ptOpts := []Option{
+		WithRefreshToken(string(tk.RefreshToken())),
+		WithAccessToken(string(tk.AccessToken()), tk.Expiry()),
+	}
+	if sid, ok := idTkClaims["sid"].(string); ok {
+		ptOpts = append(ptOpts, WithProviderSessionId(sid))
+	}
+	pt, err := NewProviderToken(reqState.TokenRequestId, am.PublicId, acct.PublicId, ptOpts...)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+	if _, err := r.createProviderToken(ctx, am, pt); err != nil {
+		if _, delErr := tokenRepo.DeleteAuthToken(ctx, reqState.TokenRequestId); delErr != nil {
+			return "", errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to delete pending token: %s", delErr)))
+		}
+		return "", errors.Wrap(err, op)
+	}
 	// tada!  we can return a final redirect URL for the successful authentication.
 	return reqState.FinalRedirectUrl, nil
 }
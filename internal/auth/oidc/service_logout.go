@@ -0,0 +1,175 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+const (
+	// backChannelLogoutEvent is the required member of a logout token's
+	// events claim.
+	backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+	// logoutTokenMaxAge is how long after its iat a logout token is accepted.
+	// It's also how far in the future the iat may be, to allow for clock
+	// skew between Boundary and the IdP.
+	logoutTokenMaxAge = 5 * time.Minute
+)
+
+// usedLogoutTokens holds the logout tokens which have been accepted, so that
+// they can't be replayed.
+var usedLogoutTokens = newLogoutTokenCache()
+
+// logoutTokenCache tracks used logout tokens by auth method and jti.  Tokens
+// are only kept until their iat is too old for them to be accepted anyway.
+type logoutTokenCache struct {
+	mu          sync.Mutex
+	expirations map[string]time.Time
+}
+
+func newLogoutTokenCache() *logoutTokenCache {
+	return &logoutTokenCache{expirations: map[string]time.Time{}}
+}
+
+// add records the token with the key until expiration, and returns false if
+// it was already recorded.  Expired tokens are removed as a side effect.
+func (c *logoutTokenCache) add(key string, expiration, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, exp := range c.expirations {
+		if !now.Before(exp) {
+			delete(c.expirations, k)
+		}
+	}
+	if _, ok := c.expirations[key]; ok {
+		return false
+	}
+	c.expirations[key] = expiration
+	return true
+}
+
+// remove forgets the token with the key, so that it can be retried.
+func (c *logoutTokenCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.expirations, key)
+}
+
+// BackChannelLogout is an oidc domain service function for processing an
+// OIDC Back-Channel Logout Request from an IdP.  On success, it returns the
+// number of Boundary auth tokens revoked.
+//
+// For more info on Back-Channel Logout see:
+// https://openid.net/specs/openid-connect-backchannel-1_0.html
+//
+// The service operation includes:
+//
+// * Verify the logout token's signature, issuer and audience using the auth
+// method's provider.  The logout token must have an events claim containing
+// the back-channel logout event, a sub or sid claim, and no nonce claim.
+//
+// * Verify the logout token is fresh: it must not have expired if it has an
+// exp claim, its iat claim must be within logoutTokenMaxAge of now, and its
+// jti claim must not have been used before.
+//
+// * Find the auth tokens whose ProviderTokens match the logout token's sub
+// and/or sid claims.
+//
+// * Cancel the pending and active sessions of those auth tokens and then
+// delete the auth tokens.
+func BackChannelLogout(
+	ctx context.Context,
+	oidcRepoFn OidcRepoFactory,
+	atRepoFn AuthTokenRepoFactory,
+	sessionRepoFn SessionRepoFactory,
+	am *AuthMethod,
+	logoutToken string) (revoked int, e error) {
+	const op = "oidc.BackChannelLogout"
+	if oidcRepoFn == nil {
+		return 0, errors.New(errors.InvalidParameter, op, "missing oidc repository function")
+	}
+	if atRepoFn == nil {
+		return 0, errors.New(errors.InvalidParameter, op, "missing auth token repository function")
+	}
+	if sessionRepoFn == nil {
+		return 0, errors.New(errors.InvalidParameter, op, "missing session repository function")
+	}
+	if am == nil || am.AuthMethod == nil {
+		return 0, errors.New(errors.InvalidParameter, op, "missing auth method")
+	}
+	if logoutToken == "" {
+		return 0, errors.New(errors.InvalidParameter, op, "missing logout token")
+	}
+
+	r, err := oidcRepoFn()
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	// get the verifier from the cache (if possible), so the logout token is
+	// verified with the same http client (and CA certs) as the callback and
+	// the provider isn't discovered for every request.
+	verifier, err := providerCache().getLogoutVerifier(ctx, am)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+	tk, err := verifier.Verify(ctx, logoutToken)
+	if err != nil {
+		return 0, errors.New(errors.InvalidParameter, op, "unable to verify logout token", errors.WithWrap(err))
+	}
+	var claims struct {
+		Sid    string                     `json:"sid"`
+		Jti    string                     `json:"jti"`
+		Nonce  string                     `json:"nonce"`
+		Events map[string]json.RawMessage `json:"events"`
+	}
+	if err := tk.Claims(&claims); err != nil {
+		return 0, errors.New(errors.InvalidParameter, op, "unable to parse logout token claims", errors.WithWrap(err))
+	}
+	if _, ok := claims.Events[backChannelLogoutEvent]; !ok {
+		return 0, errors.New(errors.InvalidParameter, op, fmt.Sprintf("logout token events claim is missing %q", backChannelLogoutEvent))
+	}
+	if claims.Nonce != "" {
+		return 0, errors.New(errors.InvalidParameter, op, "logout token must not contain a nonce claim")
+	}
+	if tk.Subject == "" && claims.Sid == "" {
+		return 0, errors.New(errors.InvalidParameter, op, "logout token must contain a sub or sid claim")
+	}
+
+	// the verifier skips the expiry check, since logout tokens aren't
+	// required to have an exp claim, so freshness is checked here.
+	now := time.Now()
+	if !tk.Expiry.IsZero() && now.After(tk.Expiry) {
+		return 0, errors.New(errors.InvalidParameter, op, "logout token is expired")
+	}
+	if tk.IssuedAt.IsZero() {
+		return 0, errors.New(errors.InvalidParameter, op, "logout token must contain an iat claim")
+	}
+	if now.Sub(tk.IssuedAt) > logoutTokenMaxAge || tk.IssuedAt.Sub(now) > logoutTokenMaxAge {
+		return 0, errors.New(errors.InvalidParameter, op, fmt.Sprintf("logout token iat must be within %s of now", logoutTokenMaxAge))
+	}
+	if claims.Jti == "" {
+		return 0, errors.New(errors.InvalidParameter, op, "logout token must contain a jti claim")
+	}
+	usedKey := am.PublicId + ":" + claims.Jti
+	if !usedLogoutTokens.add(usedKey, tk.IssuedAt.Add(logoutTokenMaxAge), now) {
+		return 0, errors.New(errors.InvalidParameter, op, "logout token has already been used")
+	}
+
+	tokens, err := r.listProviderTokensForLogout(ctx, am, tk.Subject, claims.Sid)
+	if err != nil {
+		usedLogoutTokens.remove(usedKey)
+		return 0, errors.Wrap(err, op)
+	}
+	revoked, err = revokeProviderTokens(ctx, atRepoFn, sessionRepoFn, tokens)
+	if err != nil {
+		usedLogoutTokens.remove(usedKey)
+		return revoked, errors.Wrap(err, op)
+	}
+	return revoked, nil
+}
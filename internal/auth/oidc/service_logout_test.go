@@ -0,0 +1,280 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/cap/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BackChannelLogout(t *testing.T) {
+	// DO NOT run these tests under t.Parallel(), there be dragons because of dependencies on the
+	// Database and TestProvider state
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	rootWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	iamRepo := iam.TestRepo(t, conn, rootWrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	repoFn := func() (*Repository, error) {
+		return NewRepository(rw, rw, kmsCache)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kmsCache)
+	}
+	atRepo, err := atRepoFn()
+	require.NoError(t, err)
+
+	// test provider for the tests (see the oidc package docs for more info)
+	// it will provide discovery and JWKs for these tests.
+	tp := oidc.StartTestProvider(t)
+	tpCert, err := ParseCertificates(tp.CACert())
+	require.NoError(t, err)
+	tpPriv, _, tpAlg, tpKeyId := tp.SigningKeys()
+
+	am := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, ActivePublicState,
+		"alice-rp", "fido",
+		WithCertificates(tpCert...),
+		WithSigningAlgs(Alg(tpAlg)),
+		WithIssuer(TestConvertToUrls(t, tp.Addr())[0]),
+		WithApiUrl(TestConvertToUrls(t, "https://api.com")[0]))
+
+	alice := TestAccount(t, conn, am, "alice")
+	aliceUser := iam.TestUser(t, iamRepo, org.PublicId, iam.WithAccountIds(alice.PublicId))
+	bob := TestAccount(t, conn, am, "bob")
+	bobUser := iam.TestUser(t, iamRepo, org.PublicId, iam.WithAccountIds(bob.PublicId))
+
+	// logoutToken returns a logout token with a unique jti signed by the test
+	// provider, with the claims modified by fn.
+	var jtis int
+	logoutToken := func(fn func(claims map[string]interface{})) string {
+		jtis++
+		claims := map[string]interface{}{
+			"iss": tp.Addr(),
+			"aud": "alice-rp",
+			"iat": time.Now().Unix(),
+			"jti": fmt.Sprintf("logout-jti-%d", jtis),
+			"events": map[string]interface{}{
+				backChannelLogoutEvent: map[string]interface{}{},
+			},
+		}
+		if fn != nil {
+			fn(claims)
+		}
+		return oidc.TestSignJWT(t, tpPriv, string(tpAlg), claims, []byte(tpKeyId))
+	}
+	exists := func(authTokenId string) bool {
+		at, err := atRepo.LookupAuthToken(ctx, authTokenId)
+		require.NoError(t, err)
+		return at != nil
+	}
+
+	t.Run("subject", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		alice1 := TestProviderToken(t, conn, kmsCache, am, aliceUser, alice, WithProviderSessionId("sid1"))
+		alice2 := TestProviderToken(t, conn, kmsCache, am, aliceUser, alice, WithProviderSessionId("sid2"))
+		bob1 := TestProviderToken(t, conn, kmsCache, am, bobUser, bob, WithProviderSessionId("sid1"))
+
+		revoked, err := BackChannelLogout(ctx, repoFn, atRepoFn, sessionRepoFn, am, logoutToken(func(c map[string]interface{}) {
+			c["sub"] = "alice"
+		}))
+		require.NoError(err)
+		assert.Equal(2, revoked)
+		assert.False(exists(alice1.AuthTokenId))
+		assert.False(exists(alice2.AuthTokenId))
+		assert.True(exists(bob1.AuthTokenId))
+
+		// the provider isn't discovered again for the next request
+		providerCache().mu.RLock()
+		_, ok := providerCache().logoutVerifiers[am.PublicId]
+		providerCache().mu.RUnlock()
+		assert.True(ok)
+	})
+	t.Run("sid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		alice1 := TestProviderToken(t, conn, kmsCache, am, aliceUser, alice, WithProviderSessionId("sid3"))
+		alice2 := TestProviderToken(t, conn, kmsCache, am, aliceUser, alice, WithProviderSessionId("sid4"))
+
+		revoked, err := BackChannelLogout(ctx, repoFn, atRepoFn, sessionRepoFn, am, logoutToken(func(c map[string]interface{}) {
+			c["sid"] = "sid3"
+		}))
+		require.NoError(err)
+		assert.Equal(1, revoked)
+		assert.False(exists(alice1.AuthTokenId))
+		assert.True(exists(alice2.AuthTokenId))
+	})
+
+	t.Run("replayed-jti", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		token := logoutToken(func(c map[string]interface{}) {
+			c["sub"] = "alice"
+		})
+		alice1 := TestProviderToken(t, conn, kmsCache, am, aliceUser, alice)
+		revoked, err := BackChannelLogout(ctx, repoFn, atRepoFn, sessionRepoFn, am, token)
+		require.NoError(err)
+		assert.Equal(1, revoked)
+		assert.False(exists(alice1.AuthTokenId))
+
+		alice2 := TestProviderToken(t, conn, kmsCache, am, aliceUser, alice)
+		revoked, err = BackChannelLogout(ctx, repoFn, atRepoFn, sessionRepoFn, am, token)
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+		assert.Equal(0, revoked)
+		assert.True(exists(alice2.AuthTokenId), "a replayed logout token doesn't end new sessions")
+	})
+
+	tests := []struct {
+		name      string
+		am        *AuthMethod
+		token     string
+		wantIsErr errors.Code
+	}{
+		{
+			name: "stale-iat",
+			am:   am,
+			token: logoutToken(func(c map[string]interface{}) {
+				c["sub"] = "alice"
+				c["iat"] = time.Now().Add(-logoutTokenMaxAge - time.Minute).Unix()
+			}),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "future-iat",
+			am:   am,
+			token: logoutToken(func(c map[string]interface{}) {
+				c["sub"] = "alice"
+				c["iat"] = time.Now().Add(logoutTokenMaxAge + time.Minute).Unix()
+			}),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "missing-iat",
+			am:   am,
+			token: logoutToken(func(c map[string]interface{}) {
+				c["sub"] = "alice"
+				delete(c, "iat")
+			}),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "expired",
+			am:   am,
+			token: logoutToken(func(c map[string]interface{}) {
+				c["sub"] = "alice"
+				c["exp"] = time.Now().Add(-time.Minute).Unix()
+			}),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "missing-jti",
+			am:   am,
+			token: logoutToken(func(c map[string]interface{}) {
+				c["sub"] = "alice"
+				delete(c, "jti")
+			}),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "missing-events",
+			am:   am,
+			token: logoutToken(func(c map[string]interface{}) {
+				c["sub"] = "alice"
+				delete(c, "events")
+			}),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "nonce",
+			am:   am,
+			token: logoutToken(func(c map[string]interface{}) {
+				c["sub"] = "alice"
+				c["nonce"] = "nonce"
+			}),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-sub-and-sid",
+			am:        am,
+			token:     logoutToken(nil),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "other-audience",
+			am:   am,
+			token: logoutToken(func(c map[string]interface{}) {
+				c["sub"] = "alice"
+				c["aud"] = "bob-rp"
+			}),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "other-signing-key",
+			am:   am,
+			token: func() string {
+				_, priv := oidc.TestGenerateKeys(t)
+				return oidc.TestSignJWT(t, priv, string(oidc.ES256), map[string]interface{}{
+					"iss":    tp.Addr(),
+					"aud":    "alice-rp",
+					"sub":    "alice",
+					"events": map[string]interface{}{backChannelLogoutEvent: map[string]interface{}{}},
+				}, []byte(tpKeyId))
+			}(),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-token",
+			am:        am,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-auth-method",
+			token:     logoutToken(func(c map[string]interface{}) { c["sub"] = "alice" }),
+			wantIsErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			pt := TestProviderToken(t, conn, kmsCache, am, aliceUser, alice)
+			revoked, err := BackChannelLogout(ctx, repoFn, atRepoFn, sessionRepoFn, tt.am, tt.token)
+			assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "unexpected error %v", err)
+			assert.Equal(0, revoked)
+			assert.True(exists(pt.AuthTokenId))
+		})
+	}
+}
+
+func Test_logoutTokenCache(t *testing.T) {
+	assert := assert.New(t)
+	c := newLogoutTokenCache()
+	now := time.Now()
+
+	assert.True(c.add("am:jti1", now.Add(time.Minute), now))
+	assert.False(c.add("am:jti1", now.Add(time.Minute), now), "a used token is rejected")
+	assert.True(c.add("am:jti2", now.Add(2*time.Minute), now))
+
+	// once expired, the token is removed from the cache
+	later := now.Add(90 * time.Second)
+	assert.True(c.add("am:jti3", later.Add(time.Minute), later))
+	assert.NotContains(c.expirations, "am:jti1")
+	assert.Contains(c.expirations, "am:jti2")
+
+	c.remove("am:jti2")
+	assert.True(c.add("am:jti2", later.Add(time.Minute), later), "a removed token can be retried")
+}
@@ -0,0 +1,255 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/cap/oidc"
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/oauth2"
+)
+
+// RevalidateProviderTokens is an oidc domain service function which
+// re-validates, with their provider, the users who authenticated using an
+// oidc auth method.  Only ProviderTokens which haven't been validated within
+// the interval are re-validated.  On success, it returns the number of
+// Boundary auth tokens revoked.
+//
+// A ProviderToken is re-validated by:
+//
+// * Using its refresh_token (when the provider returned one) to get new tokens
+// from the provider's token endpoint.  If the provider rejects the refresh
+// with an invalid_grant error, the user is no longer valid.
+//
+// * Using its access_token (when it hasn't expired) to get the user's info
+// from the provider's userinfo endpoint.  If the provider rejects the
+// access_token or the subject doesn't match the account, the user is no
+// longer valid.
+//
+// Each ProviderToken is claimed before it's re-validated, so when several
+// controllers re-validate at the same time only one of them uses a
+// refresh_token, which the provider may rotate.
+//
+// The auth tokens of users who are no longer valid are revoked: their pending
+// and active sessions are canceled and then the auth tokens are deleted.
+// Errors reaching the provider, and any other errors it returns, don't revoke
+// auth tokens; they're returned after every auth method has been processed and
+// the ProviderTokens are re-validated again by the next run.
+func RevalidateProviderTokens(
+	ctx context.Context,
+	oidcRepoFn OidcRepoFactory,
+	atRepoFn AuthTokenRepoFactory,
+	sessionRepoFn SessionRepoFactory,
+	interval time.Duration) (revoked int, e error) {
+	const op = "oidc.RevalidateProviderTokens"
+	if oidcRepoFn == nil {
+		return 0, errors.New(errors.InvalidParameter, op, "missing oidc repository function")
+	}
+	if atRepoFn == nil {
+		return 0, errors.New(errors.InvalidParameter, op, "missing auth token repository function")
+	}
+	if sessionRepoFn == nil {
+		return 0, errors.New(errors.InvalidParameter, op, "missing session repository function")
+	}
+	if interval <= 0 {
+		return 0, errors.New(errors.InvalidParameter, op, "interval must be greater than zero")
+	}
+	r, err := oidcRepoFn()
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	validatedBefore := time.Now().Add(-interval)
+	amIds, err := r.listAuthMethodIdsToRevalidate(ctx, validatedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	var errs *multierror.Error
+	for _, amId := range amIds {
+		n, err := revalidateAuthMethod(ctx, r, atRepoFn, sessionRepoFn, amId, validatedBefore)
+		revoked += n
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrap(err, op, errors.WithMsg(amId)))
+		}
+	}
+	return revoked, errs.ErrorOrNil()
+}
+
+// revalidateAuthMethod re-validates the ProviderTokens of a single auth method
+// and revokes the ones which are no longer valid.
+func revalidateAuthMethod(
+	ctx context.Context,
+	r *Repository,
+	atRepoFn AuthTokenRepoFactory,
+	sessionRepoFn SessionRepoFactory,
+	authMethodId string,
+	validatedBefore time.Time) (int, error) {
+	const op = "oidc.revalidateAuthMethod"
+	am, err := r.lookupAuthMethod(ctx, authMethodId)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+	if am == nil {
+		return 0, nil // it was deleted, along with its provider tokens
+	}
+	provider, err := providerCache().get(ctx, am)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+	tokens, err := r.listProviderTokensToRevalidate(ctx, am, validatedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	var errs *multierror.Error
+	var invalid []*ProviderToken
+	for _, t := range tokens {
+		claimed, err := r.claimProviderToken(ctx, t, validatedBefore)
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrap(err, op))
+			continue
+		}
+		if !claimed {
+			continue // another controller is re-validating it
+		}
+		acct, err := r.LookupAccount(ctx, t.AccountId)
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrap(err, op))
+			continue
+		}
+		if acct == nil {
+			invalid = append(invalid, t)
+			continue
+		}
+		valid, err := revalidateProviderToken(ctx, provider, am, acct.Subject, t)
+		if err != nil {
+			errs = multierror.Append(errs, errors.Wrap(err, op))
+			continue
+		}
+		if !valid {
+			invalid = append(invalid, t)
+			continue
+		}
+		if _, err := r.updateProviderToken(ctx, am, t); err != nil {
+			errs = multierror.Append(errs, errors.Wrap(err, op))
+		}
+	}
+	revoked, err := revokeProviderTokens(ctx, atRepoFn, sessionRepoFn, invalid)
+	if err != nil {
+		errs = multierror.Append(errs, errors.Wrap(err, op))
+	}
+	return revoked, errs.ErrorOrNil()
+}
+
+// revalidateProviderToken re-validates the user with the provider using the
+// ProviderToken's refresh_token and access_token.  The ProviderToken, t, is
+// updated with any new tokens returned by the provider.  It returns false
+// when the provider reports the user is no longer valid.  An error is returned
+// when the user's validity can't be determined.
+func revalidateProviderToken(ctx context.Context, p *oidc.Provider, am *AuthMethod, subject string, t *ProviderToken) (bool, error) {
+	const op = "oidc.revalidateProviderToken"
+	oidcCtx, err := p.HTTPClientContext(ctx)
+	if err != nil {
+		return false, errors.New(errors.Unknown, op, "unable to create http client", errors.WithWrap(err))
+	}
+
+	if t.RefreshToken != "" {
+		info, err := p.DiscoveryInfo(ctx)
+		if err != nil {
+			return false, errors.New(errors.Unavailable, op, "unable to get provider discovery info", errors.WithWrap(err))
+		}
+		cfg := oauth2.Config{
+			ClientID:     am.ClientId,
+			ClientSecret: am.ClientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  info.AuthURL,
+				TokenURL: info.TokenURL,
+			},
+		}
+		// an oauth2.Token with only a refresh_token is never valid, so the
+		// token source always uses the refresh_token grant.
+		tk, err := cfg.TokenSource(oidcCtx, &oauth2.Token{RefreshToken: t.RefreshToken}).Token()
+		if err != nil {
+			var retrieveErr *oauth2.RetrieveError
+			if stderrors.As(err, &retrieveErr) && refreshRejected(retrieveErr) {
+				return false, nil
+			}
+			return false, errors.New(errors.Unavailable, op, "unable to refresh provider tokens", errors.WithWrap(err))
+		}
+		if tk.RefreshToken != "" {
+			t.RefreshToken = tk.RefreshToken // the provider rotated it.
+		}
+		t.AccessToken = tk.AccessToken
+		t.AccessTokenExpirationTime = nil
+		if !tk.Expiry.IsZero() {
+			pt, err := NewProviderToken(t.AuthTokenId, t.OidcMethodId, t.AccountId, WithAccessToken(tk.AccessToken, tk.Expiry))
+			if err != nil {
+				return false, errors.Wrap(err, op)
+			}
+			t.AccessTokenExpirationTime = pt.AccessTokenExpirationTime
+		}
+	}
+
+	if !t.accessTokenValid(time.Now()) {
+		// without an unexpired access_token there's nothing left to
+		// re-validate the user with, so the auth token is left to expire.
+		return true, nil
+	}
+	var claims map[string]interface{}
+	err = p.UserInfo(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: t.AccessToken}), subject, &claims)
+	switch {
+	case err == nil:
+		return true, nil
+	case stderrors.Is(err, oidc.ErrNotFound):
+		// the provider doesn't support the userinfo endpoint, so the refresh
+		// (if any) is all we've got.
+		return true, nil
+	case stderrors.Is(err, oidc.ErrInvalidSubject):
+		return false, nil
+	case userInfoRejected(err):
+		return false, nil
+	default:
+		return false, errors.New(errors.Unavailable, op, "unable to get user info from provider", errors.WithWrap(err))
+	}
+}
+
+// refreshRejected returns true when the provider's token endpoint rejected the
+// refresh_token itself, with an invalid_grant error.  Other errors, like an
+// invalid_client error caused by a wrong client secret, say nothing about the
+// user's validity.  See: https://tools.ietf.org/html/rfc6749#section-5.2
+func refreshRejected(e *oauth2.RetrieveError) bool {
+	if e == nil || e.Response == nil {
+		return false
+	}
+	switch e.Response.StatusCode {
+	case http.StatusBadRequest, http.StatusUnauthorized:
+	default:
+		return false
+	}
+	var body struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(e.Body, &body); err != nil {
+		return false
+	}
+	return body.Error == "invalid_grant"
+}
+
+// userInfoRejected returns true when the error returned by the provider's
+// userinfo endpoint means the access_token was rejected. The provider's
+// UserInfo only reports the response's status in the error's message.
+func userInfoRejected(err error) bool {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		if strings.Contains(err.Error(), fmt.Sprintf("%d %s", status, http.StatusText(status))) {
+			return true
+		}
+	}
+	return false
+}
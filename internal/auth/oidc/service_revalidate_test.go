@@ -0,0 +1,224 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/cap/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func Test_refreshRejected(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		err  *oauth2.RetrieveError
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "nil-response", err: &oauth2.RetrieveError{}, want: false},
+		{
+			name: "invalid-grant",
+			err:  &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusBadRequest}, Body: []byte(`{"error":"invalid_grant"}`)},
+			want: true,
+		},
+		{
+			name: "invalid-grant-unauthorized",
+			err:  &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusUnauthorized}, Body: []byte(`{"error":"invalid_grant","error_description":"token revoked"}`)},
+			want: true,
+		},
+		{
+			name: "invalid-client",
+			err:  &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusUnauthorized}, Body: []byte(`{"error":"invalid_client"}`)},
+			want: false,
+		},
+		{
+			name: "invalid-request",
+			err:  &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusBadRequest}, Body: []byte(`{"error":"invalid_request"}`)},
+			want: false,
+		},
+		{
+			name: "forbidden",
+			err:  &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusForbidden}, Body: []byte(`{"error":"invalid_grant"}`)},
+			want: false,
+		},
+		{
+			name: "not-json",
+			err:  &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusBadRequest}, Body: []byte(`invalid_grant`)},
+			want: false,
+		},
+		{
+			name: "internal-error",
+			err:  &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusInternalServerError}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, refreshRejected(tt.err))
+		})
+	}
+}
+
+func Test_userInfoRejected(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "unauthorized", err: fmt.Errorf("Provider.UserInfo: provider UserInfo request failed: 401 Unauthorized: invalid_token"), want: true},
+		{name: "forbidden", err: fmt.Errorf("Provider.UserInfo: provider UserInfo request failed: 403 Forbidden: "), want: true},
+		{name: "internal-error", err: fmt.Errorf("Provider.UserInfo: provider UserInfo request failed: 500 Internal Server Error: "), want: false},
+		{name: "network", err: fmt.Errorf("dial tcp: connection refused"), want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, userInfoRejected(tt.err))
+		})
+	}
+}
+
+func Test_RevalidateProviderTokens(t *testing.T) {
+	// DO NOT run these tests under t.Parallel(), there be dragons because of dependencies on the
+	// Database and TestProvider state
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	rootWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	iamRepo := iam.TestRepo(t, conn, rootWrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	repoFn := func() (*Repository, error) {
+		return NewRepository(rw, rw, kmsCache)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kmsCache)
+	}
+	atRepo, err := atRepoFn()
+	require.NoError(t, err)
+	repo, err := repoFn()
+	require.NoError(t, err)
+
+	// test provider for the tests (see the oidc package docs for more info)
+	// it will provide discovery, a token endpoint and a userinfo endpoint,
+	// which replies with the alice@example.com subject, for these tests.
+	tp := oidc.StartTestProvider(t)
+	tpCert, err := ParseCertificates(tp.CACert())
+	require.NoError(t, err)
+	_, _, tpAlg, _ := tp.SigningKeys()
+
+	am := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, ActivePublicState,
+		"alice-rp", "fido",
+		WithCertificates(tpCert...),
+		WithSigningAlgs(Alg(tpAlg)),
+		WithIssuer(TestConvertToUrls(t, tp.Addr())[0]),
+		WithApiUrl(TestConvertToUrls(t, "https://api.com")[0]))
+
+	alice := TestAccount(t, conn, am, "alice@example.com")
+	aliceUser := iam.TestUser(t, iamRepo, org.PublicId, iam.WithAccountIds(alice.PublicId))
+	bob := TestAccount(t, conn, am, "bob@example.com")
+	bobUser := iam.TestUser(t, iamRepo, org.PublicId, iam.WithAccountIds(bob.PublicId))
+
+	const interval = time.Minute
+	// testToken creates a ProviderToken which is due to be re-validated.
+	testToken := func(user *iam.User, acct *Account, opt ...Option) *ProviderToken {
+		pt := TestProviderToken(t, conn, kmsCache, am, user, acct, opt...)
+		testSetLastValidationTime(t, conn, pt.AuthTokenId, time.Now().Add(-2*interval))
+		return pt
+	}
+	exists := func(authTokenId string) bool {
+		at, err := atRepo.LookupAuthToken(ctx, authTokenId)
+		require.NoError(t, err)
+		return at != nil
+	}
+	due := func() []*ProviderToken {
+		tokens, err := repo.listProviderTokensToRevalidate(ctx, am, time.Now().Add(-interval))
+		require.NoError(t, err)
+		return tokens
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		pt := testToken(aliceUser, alice, WithAccessToken("access", time.Now().Add(time.Hour)))
+		revoked, err := RevalidateProviderTokens(ctx, repoFn, atRepoFn, sessionRepoFn, interval)
+		require.NoError(err)
+		assert.Equal(0, revoked)
+		assert.True(exists(pt.AuthTokenId))
+		assert.Empty(due())
+	})
+	t.Run("invalid-subject", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		pt := testToken(bobUser, bob, WithAccessToken("access", time.Now().Add(time.Hour)))
+		revoked, err := RevalidateProviderTokens(ctx, repoFn, atRepoFn, sessionRepoFn, interval)
+		require.NoError(err)
+		assert.Equal(1, revoked)
+		assert.False(exists(pt.AuthTokenId))
+	})
+	t.Run("expired-access-token", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		pt := testToken(bobUser, bob, WithAccessToken("access", time.Now().Add(-time.Hour)))
+		revoked, err := RevalidateProviderTokens(ctx, repoFn, atRepoFn, sessionRepoFn, interval)
+		require.NoError(err)
+		assert.Equal(0, revoked)
+		assert.True(exists(pt.AuthTokenId))
+		assert.Empty(due())
+	})
+	t.Run("refresh-error-not-revoked", func(t *testing.T) {
+		// the test provider only supports the authorization_code grant, so
+		// it replies to the refresh with an invalid_request error, which
+		// says nothing about the user's validity.
+		assert, require := assert.New(t), require.New(t)
+		pt := testToken(aliceUser, alice, WithRefreshToken("refresh"))
+		revoked, err := RevalidateProviderTokens(ctx, repoFn, atRepoFn, sessionRepoFn, interval)
+		require.Error(err)
+		assert.Equal(0, revoked)
+		assert.True(exists(pt.AuthTokenId))
+
+		// it's re-validated again by the next run
+		testSetLastValidationTime(t, conn, pt.AuthTokenId, time.Now().Add(-2*interval))
+		assert.Len(due(), 1)
+		testSetLastValidationTime(t, conn, pt.AuthTokenId, time.Now())
+	})
+	t.Run("claimed-by-another-controller", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		pt := testToken(bobUser, bob, WithAccessToken("access", time.Now().Add(time.Hour)))
+		claimed, err := repo.claimProviderToken(ctx, pt, time.Now().Add(-interval))
+		require.NoError(err)
+		require.True(claimed)
+		revoked, err := RevalidateProviderTokens(ctx, repoFn, atRepoFn, sessionRepoFn, interval)
+		require.NoError(err)
+		assert.Equal(0, revoked)
+		assert.True(exists(pt.AuthTokenId))
+	})
+	t.Run("missing-params", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := RevalidateProviderTokens(ctx, nil, atRepoFn, sessionRepoFn, interval)
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+		_, err = RevalidateProviderTokens(ctx, repoFn, nil, sessionRepoFn, interval)
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+		_, err = RevalidateProviderTokens(ctx, repoFn, atRepoFn, nil, interval)
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+		_, err = RevalidateProviderTokens(ctx, repoFn, atRepoFn, sessionRepoFn, 0)
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+	})
+}
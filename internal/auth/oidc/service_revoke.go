@@ -0,0 +1,69 @@
+package oidc
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/session"
+)
+
+// revokeProviderTokens revokes the Boundary auth tokens which own the
+// ProviderTokens.  Before the auth tokens are deleted, the pending and active
+// sessions created with them are canceled.  Deleting an auth token cascades to
+// its ProviderToken.  It returns the number of auth tokens revoked.
+func revokeProviderTokens(
+	ctx context.Context,
+	atRepoFn AuthTokenRepoFactory,
+	sessionRepoFn SessionRepoFactory,
+	tokens []*ProviderToken) (int, error) {
+	const op = "oidc.revokeProviderTokens"
+	if atRepoFn == nil {
+		return 0, errors.New(errors.InvalidParameter, op, "missing auth token repository function")
+	}
+	if sessionRepoFn == nil {
+		return 0, errors.New(errors.InvalidParameter, op, "missing session repository function")
+	}
+	if len(tokens) == 0 {
+		return 0, nil
+	}
+	atRepo, err := atRepoFn()
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+	sessionRepo, err := sessionRepoFn()
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	var revoked int
+	for _, t := range tokens {
+		at, err := atRepo.LookupAuthToken(ctx, t.AuthTokenId)
+		if err != nil {
+			return revoked, errors.Wrap(err, op)
+		}
+		if at == nil {
+			continue // it's already been deleted
+		}
+		sessions, err := sessionRepo.ListSessions(ctx, session.WithUserId(at.IamUserId), session.WithLimit(-1))
+		if err != nil {
+			return revoked, errors.Wrap(err, op)
+		}
+		for _, s := range sessions {
+			if s.AuthTokenId != at.PublicId || len(s.States) == 0 {
+				continue
+			}
+			switch s.States[0].Status {
+			case session.StatusPending, session.StatusActive:
+				if _, err := sessionRepo.CancelSession(ctx, s.PublicId, s.Version); err != nil {
+					return revoked, errors.Wrap(err, op, errors.WithMsg("unable to cancel session "+s.PublicId))
+				}
+			}
+		}
+		rowsDeleted, err := atRepo.DeleteAuthToken(ctx, at.PublicId)
+		if err != nil {
+			return revoked, errors.Wrap(err, op)
+		}
+		revoked += rowsDeleted
+	}
+	return revoked, nil
+}
@@ -0,0 +1,99 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_revokeProviderTokens(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	rootWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+	iamRepo := iam.TestRepo(t, conn, rootWrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	am := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, InactiveState, "alice-rp", "fido",
+		WithIssuer(TestConvertToUrls(t, "https://alice.com")[0]), WithApiUrl(TestConvertToUrls(t, "https://api.com")[0]))
+	acct := TestAccount(t, conn, am, "alice")
+	user := iam.TestUser(t, iamRepo, org.PublicId, iam.WithAccountIds(acct.PublicId))
+
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kmsCache)
+	}
+	atRepo, err := atRepoFn()
+	require.NoError(t, err)
+	sessionRepo, err := sessionRepoFn()
+	require.NoError(t, err)
+
+	// testSession creates a pending session for the provider token's auth
+	// token.
+	testSession := func(pt *ProviderToken) *session.Session {
+		composedOf := session.TestSessionParams(t, conn, rootWrapper, iamRepo)
+		composedOf.UserId = user.PublicId
+		composedOf.AuthTokenId = pt.AuthTokenId
+		return session.TestSession(t, conn, rootWrapper, composedOf)
+	}
+	sessionStatus := func(id string) session.Status {
+		s, _, err := sessionRepo.LookupSession(ctx, id)
+		require.NoError(t, err)
+		require.NotEmpty(t, s.States)
+		return s.States[0].Status
+	}
+
+	revokedToken := TestProviderToken(t, conn, kmsCache, am, user, acct)
+	revokedSession := testSession(revokedToken)
+	keptToken := TestProviderToken(t, conn, kmsCache, am, user, acct)
+	keptSession := testSession(keptToken)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		revoked, err := revokeProviderTokens(ctx, atRepoFn, sessionRepoFn, []*ProviderToken{revokedToken})
+		require.NoError(err)
+		assert.Equal(1, revoked)
+
+		at, err := atRepo.LookupAuthToken(ctx, revokedToken.AuthTokenId)
+		require.NoError(err)
+		assert.Nil(at)
+		assert.Equal(session.StatusCanceling, sessionStatus(revokedSession.PublicId))
+
+		// the user's other auth token and its session are untouched
+		at, err = atRepo.LookupAuthToken(ctx, keptToken.AuthTokenId)
+		require.NoError(err)
+		assert.NotNil(at)
+		assert.Equal(session.StatusPending, sessionStatus(keptSession.PublicId))
+	})
+	t.Run("already-revoked", func(t *testing.T) {
+		revoked, err := revokeProviderTokens(ctx, atRepoFn, sessionRepoFn, []*ProviderToken{revokedToken})
+		require.NoError(t, err)
+		assert.Equal(t, 0, revoked)
+	})
+	t.Run("no-tokens", func(t *testing.T) {
+		revoked, err := revokeProviderTokens(ctx, atRepoFn, sessionRepoFn, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, revoked)
+	})
+	t.Run("missing-auth-token-repo", func(t *testing.T) {
+		_, err := revokeProviderTokens(ctx, nil, sessionRepoFn, []*ProviderToken{keptToken})
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+	})
+	t.Run("missing-session-repo", func(t *testing.T) {
+		_, err := revokeProviderTokens(ctx, atRepoFn, nil, []*ProviderToken{keptToken})
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+	})
+}
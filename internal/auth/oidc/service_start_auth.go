@@ -82,6 +82,13 @@ func StartAuth(ctx context.Context, oidcRepoFn OidcRepoFactory, authMethodId str
 	if err != nil {
 		return nil, "", errors.New(errors.Unknown, op, "unable to get provider config hash", errors.WithWrap(err))
 	}
+	// PKCE is always used, so a stolen authorization code can't be exchanged
+	// for tokens without the verifier, which never leaves the controller
+	// unencrypted.
+	verifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return nil, "", errors.New(errors.Unknown, op, "unable to generate pkce code verifier", errors.WithWrap(err))
+	}
 	st := &request.State{
 		TokenRequestId:     tokenRequestId,
 		CreateTime:         &timestamp.Timestamp{Timestamp: createTime},
@@ -89,6 +96,7 @@ func StartAuth(ctx context.Context, oidcRepoFn OidcRepoFactory, authMethodId str
 		FinalRedirectUrl:   finalRedirect,
 		Nonce:              nonce,
 		ProviderConfigHash: hash,
+		CodeVerifier:       verifier.Verifier(),
 	}
 
 	requestWrapper, err := requestWrappingWrapper(ctx, r.kms, am.ScopeId, authMethodId)
@@ -102,6 +110,7 @@ func StartAuth(ctx context.Context, oidcRepoFn OidcRepoFactory, authMethodId str
 	oidcOpts := []oidc.Option{
 		oidc.WithState(string(encodedEncryptedSt)),
 		oidc.WithNonce(nonce),
+		oidc.WithPKCE(verifier),
 	}
 	switch {
 	case am.MaxAge == -1:
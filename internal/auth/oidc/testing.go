@@ -351,6 +351,36 @@ func TestPendingToken(
 	return tk
 }
 
+// TestProviderToken creates a test auth token for the user of the account and
+// a ProviderToken for it.  WithProviderSessionId, WithRefreshToken and
+// WithAccessToken options are supported.
+func TestProviderToken(
+	t *testing.T,
+	conn *gorm.DB,
+	kmsCache *kms.Kms,
+	am *AuthMethod,
+	user *iam.User,
+	acct *Account,
+	opt ...Option,
+) *ProviderToken {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+	rw := db.New(conn)
+	tokenRepo, err := authtoken.NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	tk, err := tokenRepo.CreateAuthToken(ctx, user, acct.PublicId)
+	require.NoError(err)
+
+	pt, err := NewProviderToken(tk.PublicId, am.PublicId, acct.PublicId, opt...)
+	require.NoError(err)
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	pt, err = repo.createProviderToken(ctx, am, pt)
+	require.NoError(err)
+	return pt
+}
+
 // testControllerSrv is a test http server that supports the following
 // endpoints:
 //
@@ -0,0 +1,330 @@
+// Package bundle exports and imports the configuration of a Boundary
+// database as a signed, versioned bundle, so it can be moved between
+// environments (e.g. from staging to production).
+//
+// A bundle contains the scopes, users, groups, roles, auth methods, accounts,
+// host catalogs, hosts, host sets and targets of a scope and its descendants.
+// It does not contain runtime state: auth tokens, sessions, workers and KMS
+// keys are never exported, and password accounts are exported without their
+// passwords.
+//
+// A bundle is signed with a KMS wrapper supplied by the operator, and its
+// secrets (OIDC client secrets) are encrypted with the same wrapper, so the
+// bundle can only be imported by someone holding that KMS.
+package bundle
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// Version is the version of the bundle format written by this binary.
+const Version = 1
+
+// signatureAad is the additional authenticated data used when signing a
+// bundle, so a bundle signature can't be mistaken for a wrapped secret.
+var signatureAad = []byte("boundary-bundle-signature")
+
+// Bundle is an exported Boundary configuration.
+type Bundle struct {
+	// Version is the version of the bundle format.
+	Version int `json:"version"`
+
+	// SchemaVersion is the database schema version of the exporting
+	// database.  A bundle can only be imported into a database with the same
+	// schema version.
+	SchemaVersion int `json:"schema_version"`
+
+	// CreateTime is when the bundle was exported.
+	CreateTime time.Time `json:"create_time"`
+
+	// ScopeId is the id of the scope which was exported, along with its
+	// descendants.
+	ScopeId string `json:"scope_id"`
+
+	// KeyId is the id of the key used to sign the bundle and wrap its
+	// secrets.
+	KeyId string `json:"key_id"`
+
+	// Data is the exported configuration.
+	Data *Data `json:"data"`
+
+	// Signature is the signature of the bundle's header and Data.
+	Signature string `json:"signature"`
+}
+
+// Data is the configuration contained in a Bundle.  Resources refer to each
+// other by the public ids they had in the exporting database.
+type Data struct {
+	Scopes       []*Scope       `json:"scopes,omitempty"`
+	Users        []*User        `json:"users,omitempty"`
+	Groups       []*Group       `json:"groups,omitempty"`
+	AuthMethods  []*AuthMethod  `json:"auth_methods,omitempty"`
+	Accounts     []*Account     `json:"accounts,omitempty"`
+	Roles        []*Role        `json:"roles,omitempty"`
+	HostCatalogs []*HostCatalog `json:"host_catalogs,omitempty"`
+	Hosts        []*Host        `json:"hosts,omitempty"`
+	HostSets     []*HostSet     `json:"host_sets,omitempty"`
+	Targets      []*Target      `json:"targets,omitempty"`
+}
+
+// Scope is an exported org or project scope.
+type Scope struct {
+	Id                  string `json:"id"`
+	ParentId            string `json:"parent_id"`
+	Type                string `json:"type"`
+	Name                string `json:"name,omitempty"`
+	Description         string `json:"description,omitempty"`
+	PrimaryAuthMethodId string `json:"primary_auth_method_id,omitempty"`
+}
+
+// User is an exported user.  AccountIds are the accounts associated with the
+// user.
+type User struct {
+	Id          string   `json:"id"`
+	ScopeId     string   `json:"scope_id"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	AccountIds  []string `json:"account_ids,omitempty"`
+}
+
+// Group is an exported group.  MemberIds are the ids of its user and group
+// members, which may be groups that come later in the bundle.
+type Group struct {
+	Id          string   `json:"id"`
+	ScopeId     string   `json:"scope_id"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	MemberIds   []string `json:"member_ids,omitempty"`
+}
+
+// Role is an exported role.  PrincipalIds are the ids of its user and group
+// principals.
+type Role struct {
+	Id           string   `json:"id"`
+	ScopeId      string   `json:"scope_id"`
+	Name         string   `json:"name,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	GrantScopeId string   `json:"grant_scope_id,omitempty"`
+	GrantScopes  []string `json:"grant_scopes,omitempty"`
+	Grants       []string `json:"grants,omitempty"`
+	PrincipalIds []string `json:"principal_ids,omitempty"`
+}
+
+// Auth method types
+const (
+	PasswordAuthMethodType = "password"
+	OidcAuthMethodType     = "oidc"
+)
+
+// AuthMethod is an exported auth method.  Only the attributes of its Type are
+// set.
+type AuthMethod struct {
+	Id          string `json:"id"`
+	ScopeId     string `json:"scope_id"`
+	Type        string `json:"type"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// password attributes
+	MinLoginNameLength uint32 `json:"min_login_name_length,omitempty"`
+	MinPasswordLength  uint32 `json:"min_password_length,omitempty"`
+
+	// oidc attributes
+	OperationalState                  string   `json:"operational_state,omitempty"`
+	Issuer                            string   `json:"issuer,omitempty"`
+	ClientId                          string   `json:"client_id,omitempty"`
+	ClientSecret                      *Secret  `json:"client_secret,omitempty"`
+	MaxAge                            int32    `json:"max_age,omitempty"`
+	ApiUrl                            string   `json:"api_url,omitempty"`
+	SigningAlgs                       []string `json:"signing_algorithms,omitempty"`
+	AudClaims                         []string `json:"audience_claims,omitempty"`
+	Certificates                      []string `json:"certificates,omitempty"`
+	ClaimsScopes                      []string `json:"claims_scopes,omitempty"`
+	AccountClaimMaps                  []string `json:"account_claim_maps,omitempty"`
+	DisableDiscoveredConfigValidation bool     `json:"disable_discovered_config_validation,omitempty"`
+}
+
+// Account is an exported account.  Only the attributes of its auth method's
+// type are set.
+type Account struct {
+	Id           string `json:"id"`
+	AuthMethodId string `json:"auth_method_id"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+
+	// password attributes
+	LoginName string `json:"login_name,omitempty"`
+
+	// oidc attributes
+	Subject string `json:"subject,omitempty"`
+}
+
+// HostCatalog is an exported static host catalog.
+type HostCatalog struct {
+	Id          string `json:"id"`
+	ScopeId     string `json:"scope_id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Host is an exported static host.
+type Host struct {
+	Id          string `json:"id"`
+	CatalogId   string `json:"catalog_id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Address     string `json:"address"`
+}
+
+// HostSet is an exported static host set.  HostIds are the ids of its
+// members.
+type HostSet struct {
+	Id          string   `json:"id"`
+	CatalogId   string   `json:"catalog_id"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	HostIds     []string `json:"host_ids,omitempty"`
+}
+
+// Target is an exported target.  Bundles written before udp targets were
+// added have no type, which is read as tcp.
+type Target struct {
+	Id                     string   `json:"id"`
+	Type                   string   `json:"type,omitempty"`
+	ScopeId                string   `json:"scope_id"`
+	Name                   string   `json:"name,omitempty"`
+	Description            string   `json:"description,omitempty"`
+	DefaultPort            uint32   `json:"default_port,omitempty"`
+	SessionMaxSeconds      uint32   `json:"session_max_seconds,omitempty"`
+	SessionConnectionLimit int32    `json:"session_connection_limit,omitempty"`
+	WorkerFilter           string   `json:"worker_filter,omitempty"`
+	HostSetIds             []string `json:"host_set_ids,omitempty"`
+}
+
+// Secret is a value encrypted with the bundle's KMS wrapper.  The ciphertext
+// is the base64 encoding of the marshaled wrapping.EncryptedBlobInfo.
+type Secret struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+// wrapSecret encrypts the plaintext with the wrapper.
+func wrapSecret(ctx context.Context, wrapper wrapping.Wrapper, plaintext string) (*Secret, error) {
+	const op = "bundle.wrapSecret"
+	blob, err := wrapper.Encrypt(ctx, []byte(plaintext), nil)
+	if err != nil {
+		return nil, errors.New(errors.Encrypt, op, "unable to encrypt secret", errors.WithWrap(err))
+	}
+	marshaled, err := proto.Marshal(blob)
+	if err != nil {
+		return nil, errors.New(errors.Encode, op, "unable to marshal secret", errors.WithWrap(err))
+	}
+	return &Secret{Ciphertext: base64.StdEncoding.EncodeToString(marshaled)}, nil
+}
+
+// unwrapSecret decrypts the secret with the wrapper.
+func unwrapSecret(ctx context.Context, wrapper wrapping.Wrapper, s *Secret) (string, error) {
+	const op = "bundle.unwrapSecret"
+	if s == nil {
+		return "", nil
+	}
+	marshaled, err := base64.StdEncoding.DecodeString(s.Ciphertext)
+	if err != nil {
+		return "", errors.New(errors.Decode, op, "unable to decode secret", errors.WithWrap(err))
+	}
+	blob := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(marshaled, blob); err != nil {
+		return "", errors.New(errors.Decode, op, "unable to unmarshal secret", errors.WithWrap(err))
+	}
+	pt, err := wrapper.Decrypt(ctx, blob, nil)
+	if err != nil {
+		return "", errors.New(errors.Decrypt, op, "unable to decrypt secret", errors.WithWrap(err))
+	}
+	return string(pt), nil
+}
+
+// digest returns the sha256 digest of everything in the bundle except its
+// signature.
+func (b *Bundle) digest() ([]byte, error) {
+	const op = "bundle.(Bundle).digest"
+	unsigned := *b
+	unsigned.Signature = ""
+	marshaled, err := json.Marshal(&unsigned)
+	if err != nil {
+		return nil, errors.New(errors.Encode, op, "unable to marshal bundle", errors.WithWrap(err))
+	}
+	sum := sha256.Sum256(marshaled)
+	return sum[:], nil
+}
+
+// Sign signs the bundle with the wrapper.  The signature is the wrapper's
+// authenticated encryption of the bundle's digest, so only a holder of the
+// wrapper's key can produce or verify it.
+func (b *Bundle) Sign(ctx context.Context, wrapper wrapping.Wrapper) error {
+	const op = "bundle.(Bundle).Sign"
+	if wrapper == nil {
+		return errors.New(errors.InvalidParameter, op, "missing wrapper")
+	}
+	b.KeyId = wrapper.KeyID()
+	d, err := b.digest()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	blob, err := wrapper.Encrypt(ctx, d, signatureAad)
+	if err != nil {
+		return errors.New(errors.Encrypt, op, "unable to sign bundle", errors.WithWrap(err))
+	}
+	marshaled, err := proto.Marshal(blob)
+	if err != nil {
+		return errors.New(errors.Encode, op, "unable to marshal signature", errors.WithWrap(err))
+	}
+	b.Signature = base64.StdEncoding.EncodeToString(marshaled)
+	return nil
+}
+
+// Verify verifies the bundle's signature with the wrapper and that the
+// bundle's format is supported.
+func (b *Bundle) Verify(ctx context.Context, wrapper wrapping.Wrapper) error {
+	const op = "bundle.(Bundle).Verify"
+	if wrapper == nil {
+		return errors.New(errors.InvalidParameter, op, "missing wrapper")
+	}
+	if b.Version != Version {
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unsupported bundle version %d", b.Version))
+	}
+	if b.Data == nil {
+		return errors.New(errors.InvalidParameter, op, "missing data")
+	}
+	if b.Signature == "" {
+		return errors.New(errors.InvalidParameter, op, "bundle is not signed")
+	}
+	marshaled, err := base64.StdEncoding.DecodeString(b.Signature)
+	if err != nil {
+		return errors.New(errors.InvalidParameter, op, "unable to decode signature", errors.WithWrap(err))
+	}
+	blob := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(marshaled, blob); err != nil {
+		return errors.New(errors.InvalidParameter, op, "unable to unmarshal signature", errors.WithWrap(err))
+	}
+	signed, err := wrapper.Decrypt(ctx, blob, signatureAad)
+	if err != nil {
+		return errors.New(errors.InvalidParameter, op, "invalid signature", errors.WithWrap(err))
+	}
+	d, err := b.digest()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if subtle.ConstantTimeCompare(signed, d) != 1 {
+		return errors.New(errors.InvalidParameter, op, "invalid signature: bundle has been modified")
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBundle(t *testing.T) *Bundle {
+	t.Helper()
+	return &Bundle{
+		Version:       Version,
+		SchemaVersion: 42,
+		CreateTime:    time.Now().UTC().Truncate(time.Second),
+		ScopeId:       "global",
+		Data: &Data{
+			Scopes: []*Scope{
+				{Id: "o_1234567890", ParentId: "global", Type: "org", Name: "org"},
+				{Id: "p_1234567890", ParentId: "o_1234567890", Type: "project", Name: "project"},
+			},
+			Users: []*User{
+				{Id: "u_1234567890", ScopeId: "o_1234567890", Name: "alice", AccountIds: []string{"acctpw_1234567890"}},
+			},
+			AuthMethods: []*AuthMethod{
+				{Id: "ampw_1234567890", ScopeId: "o_1234567890", Type: PasswordAuthMethodType, MinLoginNameLength: 3, MinPasswordLength: 8},
+			},
+			Accounts: []*Account{
+				{Id: "acctpw_1234567890", AuthMethodId: "ampw_1234567890", LoginName: "alice"},
+			},
+		},
+	}
+}
+
+func TestBundle_SignVerify(t *testing.T) {
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		b := testBundle(t)
+		require.NoError(b.Sign(ctx, wrapper))
+		assert.NotEmpty(b.Signature)
+		assert.Equal(wrapper.KeyID(), b.KeyId)
+		require.NoError(b.Verify(ctx, wrapper))
+
+		// the signature survives a round trip through json.
+		marshaled, err := json.Marshal(b)
+		require.NoError(err)
+		var got Bundle
+		require.NoError(json.Unmarshal(marshaled, &got))
+		assert.NoError(got.Verify(ctx, wrapper))
+	})
+	t.Run("tampered", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		b := testBundle(t)
+		require.NoError(b.Sign(ctx, wrapper))
+		b.Data.Users[0].Name = "mallory"
+		err := b.Verify(ctx, wrapper)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		assert.Contains(err.Error(), "bundle has been modified")
+	})
+	t.Run("tampered-schema-version", func(t *testing.T) {
+		require := require.New(t)
+		b := testBundle(t)
+		require.NoError(b.Sign(ctx, wrapper))
+		b.SchemaVersion++
+		require.Error(b.Verify(ctx, wrapper))
+	})
+	t.Run("wrong-wrapper", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		b := testBundle(t)
+		require.NoError(b.Sign(ctx, wrapper))
+		err := b.Verify(ctx, db.TestWrapper(t))
+		require.Error(err)
+		assert.Contains(err.Error(), "invalid signature")
+	})
+	t.Run("unsigned", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		err := testBundle(t).Verify(ctx, wrapper)
+		require.Error(err)
+		assert.Contains(err.Error(), "bundle is not signed")
+	})
+	t.Run("unsupported-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		b := testBundle(t)
+		require.NoError(b.Sign(ctx, wrapper))
+		b.Version = Version + 1
+		err := b.Verify(ctx, wrapper)
+		require.Error(err)
+		assert.Contains(err.Error(), "unsupported bundle version")
+	})
+	t.Run("missing-wrapper", func(t *testing.T) {
+		require := require.New(t)
+		b := testBundle(t)
+		require.Error(b.Sign(ctx, nil))
+		require.Error(b.Verify(ctx, nil))
+	})
+}
+
+func TestSecret(t *testing.T) {
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	assert, require := assert.New(t), require.New(t)
+
+	s, err := wrapSecret(ctx, wrapper, "client-secret")
+	require.NoError(err)
+	assert.NotContains(s.Ciphertext, "client-secret")
+
+	got, err := unwrapSecret(ctx, wrapper, s)
+	require.NoError(err)
+	assert.Equal("client-secret", got)
+
+	_, err = unwrapSecret(ctx, db.TestWrapper(t), s)
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.Decrypt), err))
+
+	_, err = unwrapSecret(ctx, wrapper, &Secret{Ciphertext: "not base64!"})
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.Decode), err))
+
+	got, err = unwrapSecret(ctx, wrapper, nil)
+	require.NoError(err)
+	assert.Empty(got)
+}
@@ -0,0 +1,373 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// Repositories are the repositories used to export and import a Bundle.
+type Repositories struct {
+	Iam      *iam.Repository
+	Password *password.Repository
+	Oidc     *oidc.Repository
+	Static   *static.Repository
+	Target   *target.Repository
+}
+
+func (r *Repositories) validate(op errors.Op) error {
+	switch {
+	case r == nil:
+		return errors.New(errors.InvalidParameter, op, "missing repositories")
+	case r.Iam == nil:
+		return errors.New(errors.InvalidParameter, op, "missing iam repository")
+	case r.Password == nil:
+		return errors.New(errors.InvalidParameter, op, "missing password repository")
+	case r.Oidc == nil:
+		return errors.New(errors.InvalidParameter, op, "missing oidc repository")
+	case r.Static == nil:
+		return errors.New(errors.InvalidParameter, op, "missing static host repository")
+	case r.Target == nil:
+		return errors.New(errors.InvalidParameter, op, "missing target repository")
+	}
+	return nil
+}
+
+// builtinIds are the ids of resources which exist in every Boundary
+// database, so they're never exported and always imported as themselves.
+var builtinIds = map[string]bool{
+	scope.Global.String(): true,
+	"u_anon":              true,
+	"u_auth":              true,
+	"u_recovery":          true,
+}
+
+// Export exports the configuration of a scope and its descendants into a
+// Bundle signed with the wrapper.  Secrets in the bundle are encrypted with
+// the wrapper.  schemaVersion is the schema version of the database being
+// exported.  The WithScopeId option is supported; by default every scope is
+// exported.
+func Export(ctx context.Context, repos *Repositories, wrapper wrapping.Wrapper, schemaVersion int, opt ...Option) (*Bundle, error) {
+	const op = "bundle.Export"
+	if err := repos.validate(op); err != nil {
+		return nil, err
+	}
+	if wrapper == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing wrapper")
+	}
+	opts := getOpts(opt...)
+
+	scopes, err := repos.Iam.ListScopesRecursively(ctx, opts.withScopeId, iam.WithLimit(-1))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New(errors.RecordNotFound, op, fmt.Sprintf("scope %s not found", opts.withScopeId))
+	}
+	scopeIds := make([]string, 0, len(scopes))
+	data := &Data{}
+	for _, s := range scopes {
+		scopeIds = append(scopeIds, s.GetPublicId())
+		if builtinIds[s.GetPublicId()] {
+			continue
+		}
+		data.Scopes = append(data.Scopes, &Scope{
+			Id:                  s.GetPublicId(),
+			ParentId:            s.GetParentId(),
+			Type:                s.GetType(),
+			Name:                s.GetName(),
+			Description:         s.GetDescription(),
+			PrimaryAuthMethodId: s.GetPrimaryAuthMethodId(),
+		})
+	}
+
+	e := &exporter{repos: repos, wrapper: wrapper, scopeIds: scopeIds, data: data}
+	for _, fn := range []func(context.Context) error{
+		e.users,
+		e.groups,
+		e.roles,
+		e.authMethods,
+		e.hosts,
+		e.targets,
+	} {
+		if err := fn(ctx); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	b := &Bundle{
+		Version:       Version,
+		SchemaVersion: schemaVersion,
+		CreateTime:    time.Now().UTC().Truncate(time.Second),
+		ScopeId:       opts.withScopeId,
+		Data:          data,
+	}
+	if err := b.Sign(ctx, wrapper); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return b, nil
+}
+
+type exporter struct {
+	repos    *Repositories
+	wrapper  wrapping.Wrapper
+	scopeIds []string
+	data     *Data
+}
+
+func (e *exporter) users(ctx context.Context) error {
+	const op = "bundle.(exporter).users"
+	users, err := e.repos.Iam.ListUsers(ctx, e.scopeIds, iam.WithLimit(-1))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	for _, u := range users {
+		if builtinIds[u.GetPublicId()] {
+			continue
+		}
+		accountIds, err := e.repos.Iam.ListUserAccounts(ctx, u.GetPublicId())
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		e.data.Users = append(e.data.Users, &User{
+			Id:          u.GetPublicId(),
+			ScopeId:     u.GetScopeId(),
+			Name:        u.GetName(),
+			Description: u.GetDescription(),
+			AccountIds:  accountIds,
+		})
+	}
+	return nil
+}
+
+func (e *exporter) groups(ctx context.Context) error {
+	const op = "bundle.(exporter).groups"
+	groups, err := e.repos.Iam.ListGroups(ctx, e.scopeIds, iam.WithLimit(-1))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	for _, g := range groups {
+		members, err := e.repos.Iam.ListGroupMembers(ctx, g.GetPublicId(), iam.WithLimit(-1))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		out := &Group{
+			Id:          g.GetPublicId(),
+			ScopeId:     g.GetScopeId(),
+			Name:        g.GetName(),
+			Description: g.GetDescription(),
+		}
+		for _, m := range members {
+			out.MemberIds = append(out.MemberIds, m.GetMemberId())
+		}
+		e.data.Groups = append(e.data.Groups, out)
+	}
+	return nil
+}
+
+func (e *exporter) roles(ctx context.Context) error {
+	const op = "bundle.(exporter).roles"
+	roles, err := e.repos.Iam.ListRoles(ctx, e.scopeIds, iam.WithLimit(-1))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	for _, r := range roles {
+		_, principals, grants, err := e.repos.Iam.LookupRole(ctx, r.GetPublicId())
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		out := &Role{
+			Id:           r.GetPublicId(),
+			ScopeId:      r.GetScopeId(),
+			Name:         r.GetName(),
+			Description:  r.GetDescription(),
+			GrantScopeId: r.GetGrantScopeId(),
+		}
+		grantScopes, err := e.repos.Iam.ListRoleGrantScopes(ctx, r.GetPublicId(), iam.WithLimit(-1))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		for _, gs := range grantScopes {
+			out.GrantScopes = append(out.GrantScopes, gs.GetScopeIdOrSpecial())
+		}
+		for _, g := range grants {
+			out.Grants = append(out.Grants, g.GetCanonicalGrant())
+		}
+		for _, p := range principals {
+			out.PrincipalIds = append(out.PrincipalIds, p.GetPrincipalId())
+		}
+		e.data.Roles = append(e.data.Roles, out)
+	}
+	return nil
+}
+
+func (e *exporter) authMethods(ctx context.Context) error {
+	const op = "bundle.(exporter).authMethods"
+	pwMethods, err := e.repos.Password.ListAuthMethods(ctx, e.scopeIds, password.WithLimit(-1))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	for _, am := range pwMethods {
+		e.data.AuthMethods = append(e.data.AuthMethods, &AuthMethod{
+			Id:                 am.GetPublicId(),
+			ScopeId:            am.GetScopeId(),
+			Type:               PasswordAuthMethodType,
+			Name:               am.GetName(),
+			Description:        am.GetDescription(),
+			MinLoginNameLength: am.GetMinLoginNameLength(),
+			MinPasswordLength:  am.GetMinPasswordLength(),
+		})
+		accts, err := e.repos.Password.ListAccounts(ctx, am.GetPublicId(), password.WithLimit(-1))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		for _, a := range accts {
+			e.data.Accounts = append(e.data.Accounts, &Account{
+				Id:           a.GetPublicId(),
+				AuthMethodId: a.GetAuthMethodId(),
+				Name:         a.GetName(),
+				Description:  a.GetDescription(),
+				LoginName:    a.GetLoginName(),
+			})
+		}
+	}
+
+	oidcMethods, err := e.repos.Oidc.ListAuthMethods(ctx, e.scopeIds, oidc.WithLimit(-1))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	for _, am := range oidcMethods {
+		out := &AuthMethod{
+			Id:                                am.GetPublicId(),
+			ScopeId:                           am.GetScopeId(),
+			Type:                              OidcAuthMethodType,
+			Name:                              am.GetName(),
+			Description:                       am.GetDescription(),
+			OperationalState:                  am.GetOperationalState(),
+			Issuer:                            am.GetIssuer(),
+			ClientId:                          am.GetClientId(),
+			MaxAge:                            am.GetMaxAge(),
+			ApiUrl:                            am.GetApiUrl(),
+			SigningAlgs:                       am.GetSigningAlgs(),
+			AudClaims:                         am.GetAudClaims(),
+			Certificates:                      am.GetCertificates(),
+			ClaimsScopes:                      am.GetClaimsScopes(),
+			AccountClaimMaps:                  am.GetAccountClaimMaps(),
+			DisableDiscoveredConfigValidation: am.GetDisableDiscoveredConfigValidation(),
+		}
+		if am.GetClientSecret() != "" {
+			if out.ClientSecret, err = wrapSecret(ctx, e.wrapper, am.GetClientSecret()); err != nil {
+				return errors.Wrap(err, op)
+			}
+		}
+		e.data.AuthMethods = append(e.data.AuthMethods, out)
+		accts, err := e.repos.Oidc.ListAccounts(ctx, am.GetPublicId(), oidc.WithLimit(-1))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		for _, a := range accts {
+			e.data.Accounts = append(e.data.Accounts, &Account{
+				Id:           a.GetPublicId(),
+				AuthMethodId: a.GetAuthMethodId(),
+				Name:         a.GetName(),
+				Description:  a.GetDescription(),
+				Subject:      a.GetSubject(),
+			})
+		}
+	}
+	return nil
+}
+
+func (e *exporter) hosts(ctx context.Context) error {
+	const op = "bundle.(exporter).hosts"
+	catalogs, err := e.repos.Static.ListCatalogs(ctx, e.scopeIds, static.WithLimit(-1))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	for _, c := range catalogs {
+		e.data.HostCatalogs = append(e.data.HostCatalogs, &HostCatalog{
+			Id:          c.GetPublicId(),
+			ScopeId:     c.GetScopeId(),
+			Name:        c.GetName(),
+			Description: c.GetDescription(),
+		})
+		hosts, err := e.repos.Static.ListHosts(ctx, c.GetPublicId(), static.WithLimit(-1))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		for _, h := range hosts {
+			e.data.Hosts = append(e.data.Hosts, &Host{
+				Id:          h.GetPublicId(),
+				CatalogId:   h.GetCatalogId(),
+				Name:        h.GetName(),
+				Description: h.GetDescription(),
+				Address:     h.GetAddress(),
+			})
+		}
+		sets, err := e.repos.Static.ListSets(ctx, c.GetPublicId(), static.WithLimit(-1))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		for _, s := range sets {
+			_, members, err := e.repos.Static.LookupSet(ctx, s.GetPublicId())
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			out := &HostSet{
+				Id:          s.GetPublicId(),
+				CatalogId:   s.GetCatalogId(),
+				Name:        s.GetName(),
+				Description: s.GetDescription(),
+			}
+			for _, h := range members {
+				out.HostIds = append(out.HostIds, h.GetPublicId())
+			}
+			e.data.HostSets = append(e.data.HostSets, out)
+		}
+	}
+	return nil
+}
+
+func (e *exporter) targets(ctx context.Context) error {
+	const op = "bundle.(exporter).targets"
+	targets, err := e.repos.Target.ListTargets(ctx, target.WithScopeIds(e.scopeIds), target.WithLimit(-1))
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	for _, t := range targets {
+		switch t.GetType() {
+		case target.TcpTargetType.String(), target.UdpTargetType.String():
+		default:
+			return errors.New(errors.Internal, op, fmt.Sprintf("unsupported target type %q for %s", t.GetType(), t.GetPublicId()))
+		}
+		_, sets, err := e.repos.Target.LookupTarget(ctx, t.GetPublicId())
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		out := &Target{
+			Id:                     t.GetPublicId(),
+			Type:                   t.GetType(),
+			ScopeId:                t.GetScopeId(),
+			Name:                   t.GetName(),
+			Description:            t.GetDescription(),
+			DefaultPort:            t.GetDefaultPort(),
+			SessionMaxSeconds:      t.GetSessionMaxSeconds(),
+			SessionConnectionLimit: t.GetSessionConnectionLimit(),
+			WorkerFilter:           t.GetWorkerFilter(),
+		}
+		for _, s := range sets {
+			out.HostSetIds = append(out.HostSetIds, s.GetPublicId())
+		}
+		e.data.Targets = append(e.data.Targets, out)
+	}
+	return nil
+}
@@ -0,0 +1,738 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	oidcstore "github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// Result is the outcome of an import.
+type Result struct {
+	// IdMap maps the id of every resource in the bundle to its id in the
+	// importing database.  For a dry run, only the ids given by WithIdMap
+	// and built-in ids are mapped.
+	IdMap map[string]string
+
+	// Created is the number of resources of each kind which were (or for a
+	// dry run, would be) created, keyed by kind, e.g. "scopes".
+	Created map[string]int
+}
+
+// Import imports the bundle into the database.  The bundle's signature is
+// verified with the wrapper, which must be the one it was exported with, and
+// the bundle's schema version must equal schemaVersion, the schema version of
+// the importing database.
+//
+// Every imported resource is created with a new id.  Options WithIdMap and
+// WithDryRun are supported.  An import is not atomic: if it fails part way
+// through, the resources created before the failure remain and the returned
+// error is accompanied by a Result describing them.
+//
+// OIDC auth methods are imported in the inactive state, and password accounts
+// are imported without passwords.
+func Import(ctx context.Context, repos *Repositories, wrapper wrapping.Wrapper, b *Bundle, schemaVersion int, opt ...Option) (*Result, error) {
+	const op = "bundle.Import"
+	if err := repos.validate(op); err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing bundle")
+	}
+	if err := b.Verify(ctx, wrapper); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if b.SchemaVersion != schemaVersion {
+		return nil, errors.New(errors.VersionMismatch, op, fmt.Sprintf("bundle was exported from schema version %d but the database is at schema version %d", b.SchemaVersion, schemaVersion))
+	}
+	opts := getOpts(opt...)
+
+	res, err := plan(b.Data, opts.withIdMap)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if opts.withDryRun {
+		return res, nil
+	}
+
+	im := &importer{
+		repos:    repos,
+		wrapper:  wrapper,
+		data:     b.Data,
+		idMap:    res.IdMap,
+		versions: map[string]uint32{},
+		scopeIds: map[string]string{},
+		created:  map[string]int{},
+	}
+	for _, fn := range []func(context.Context) error{
+		im.scopes,
+		im.users,
+		im.groups,
+		im.authMethods,
+		im.accounts,
+		im.primaryAuthMethods,
+		im.userAccounts,
+		im.hosts,
+		im.targets,
+		im.roles,
+	} {
+		if err := fn(ctx); err != nil {
+			return &Result{IdMap: im.idMap, Created: im.created}, errors.Wrap(err, op)
+		}
+	}
+	return &Result{IdMap: im.idMap, Created: im.created}, nil
+}
+
+// specialGrantScopes are the grant scopes of a role which aren't scope ids, so
+// they're imported as themselves.
+var specialGrantScopes = map[string]bool{
+	iam.GrantScopeThis:        true,
+	iam.GrantScopeChildren:    true,
+	iam.GrantScopeDescendants: true,
+}
+
+// plan validates that every reference in the data is to a resource in the
+// data, a built-in resource or a resource mapped by idMap, and returns the
+// Result of importing the data.
+func plan(data *Data, idMap map[string]string) (*Result, error) {
+	const op = "bundle.plan"
+	if data == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing data")
+	}
+	res := &Result{
+		IdMap:   make(map[string]string, len(idMap)+len(builtinIds)),
+		Created: map[string]int{},
+	}
+	for id := range builtinIds {
+		res.IdMap[id] = id
+	}
+	for from, to := range idMap {
+		if from == "" || to == "" {
+			return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("invalid id mapping %q=%q", from, to))
+		}
+		res.IdMap[from] = to
+	}
+
+	known := map[string]string{}
+	add := func(kind, id string) error {
+		if id == "" {
+			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is missing an id", kind))
+		}
+		if _, ok := known[id]; ok {
+			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("duplicate id %s", id))
+		}
+		known[id] = kind
+		if _, ok := res.IdMap[id]; !ok {
+			res.Created[kind]++
+		}
+		return nil
+	}
+	ref := func(from, to string) error {
+		if _, ok := known[to]; ok {
+			return nil
+		}
+		if _, ok := res.IdMap[to]; ok {
+			return nil
+		}
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s refers to %s which is not in the bundle or the id map", from, to))
+	}
+	// parent checks that the parent of an account, host or host set is
+	// imported, since they can't be added to an existing resource.
+	parent := func(from, to string) error {
+		_, ok := known[to]
+		_, mapped := res.IdMap[to]
+		if ok && !mapped {
+			return nil
+		}
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s belongs to %s which is not imported", from, to))
+	}
+
+	// Resources are added in the order they're imported, so a reference to a
+	// resource which is imported later is caught.
+	for _, s := range sortScopes(data.Scopes) {
+		if s.Type != scope.Org.String() && s.Type != scope.Project.String() {
+			return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("scope %s has unsupported type %q", s.Id, s.Type))
+		}
+		if err := ref(s.Id, s.ParentId); err != nil {
+			return nil, err
+		}
+		if err := add("scopes", s.Id); err != nil {
+			return nil, err
+		}
+	}
+	for _, u := range data.Users {
+		if err := ref(u.Id, u.ScopeId); err != nil {
+			return nil, err
+		}
+		if err := add("users", u.Id); err != nil {
+			return nil, err
+		}
+	}
+	for _, g := range data.Groups {
+		if err := ref(g.Id, g.ScopeId); err != nil {
+			return nil, err
+		}
+		if err := add("groups", g.Id); err != nil {
+			return nil, err
+		}
+	}
+	// A group's members are added once all the groups are created, so they
+	// may include groups which come after it.
+	for _, g := range data.Groups {
+		for _, m := range g.MemberIds {
+			if err := ref(g.Id, m); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, am := range data.AuthMethods {
+		if am.Type != PasswordAuthMethodType && am.Type != OidcAuthMethodType {
+			return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("auth method %s has unsupported type %q", am.Id, am.Type))
+		}
+		if err := ref(am.Id, am.ScopeId); err != nil {
+			return nil, err
+		}
+		if err := add("auth_methods", am.Id); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range data.Accounts {
+		if err := parent(a.Id, a.AuthMethodId); err != nil {
+			return nil, err
+		}
+		if err := add("accounts", a.Id); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range data.Scopes {
+		if s.PrimaryAuthMethodId != "" {
+			if err := ref(s.Id, s.PrimaryAuthMethodId); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, u := range data.Users {
+		for _, a := range u.AccountIds {
+			if err := ref(u.Id, a); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, c := range data.HostCatalogs {
+		if err := ref(c.Id, c.ScopeId); err != nil {
+			return nil, err
+		}
+		if err := add("host_catalogs", c.Id); err != nil {
+			return nil, err
+		}
+	}
+	for _, h := range data.Hosts {
+		if err := parent(h.Id, h.CatalogId); err != nil {
+			return nil, err
+		}
+		if err := add("hosts", h.Id); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range data.HostSets {
+		if err := parent(s.Id, s.CatalogId); err != nil {
+			return nil, err
+		}
+		if err := add("host_sets", s.Id); err != nil {
+			return nil, err
+		}
+		for _, h := range s.HostIds {
+			if err := ref(s.Id, h); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, t := range data.Targets {
+		if err := ref(t.Id, t.ScopeId); err != nil {
+			return nil, err
+		}
+		if err := add("targets", t.Id); err != nil {
+			return nil, err
+		}
+		for _, s := range t.HostSetIds {
+			if err := ref(t.Id, s); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, r := range data.Roles {
+		if err := ref(r.Id, r.ScopeId); err != nil {
+			return nil, err
+		}
+		if r.GrantScopeId != "" {
+			if err := ref(r.Id, r.GrantScopeId); err != nil {
+				return nil, err
+			}
+		}
+		for _, gs := range r.GrantScopes {
+			if specialGrantScopes[gs] {
+				continue
+			}
+			if err := ref(r.Id, gs); err != nil {
+				return nil, err
+			}
+		}
+		if err := add("roles", r.Id); err != nil {
+			return nil, err
+		}
+		for _, p := range r.PrincipalIds {
+			if err := ref(r.Id, p); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// sortScopes returns the scopes with every org before every project, so a
+// project's parent is imported before it.
+func sortScopes(scopes []*Scope) []*Scope {
+	sorted := make([]*Scope, 0, len(scopes))
+	for _, s := range scopes {
+		if s.Type == scope.Org.String() {
+			sorted = append(sorted, s)
+		}
+	}
+	for _, s := range scopes {
+		if s.Type != scope.Org.String() {
+			sorted = append(sorted, s)
+		}
+	}
+	return sorted
+}
+
+// mapGrant returns the grant with the value of its id field mapped with
+// idMap.  Grants in JSON format are returned unchanged, since exported grants
+// are always canonical strings.
+func mapGrant(grant string, idMap map[string]string) string {
+	segments := strings.Split(grant, ";")
+	for i, seg := range segments {
+		kv := strings.SplitN(seg, "=", 2)
+		if len(kv) != 2 || kv[0] != "id" {
+			continue
+		}
+		if to, ok := idMap[kv[1]]; ok {
+			segments[i] = "id=" + to
+		}
+	}
+	return strings.Join(segments, ";")
+}
+
+type importer struct {
+	repos   *Repositories
+	wrapper wrapping.Wrapper
+	data    *Data
+
+	// idMap maps bundle ids to imported ids.
+	idMap map[string]string
+	// versions are the current versions of imported resources, keyed by
+	// imported id.
+	versions map[string]uint32
+	// scopeIds are the scope ids of imported auth methods and host catalogs,
+	// keyed by imported id.
+	scopeIds map[string]string
+	created  map[string]int
+}
+
+// imported reports whether the bundle resource with the id is created by
+// this import rather than mapped to an existing resource.
+func (im *importer) imported(id string) bool {
+	_, ok := im.versions[im.idMap[id]]
+	return ok
+}
+
+func (im *importer) record(kind, from, to string, version uint32) {
+	im.idMap[from] = to
+	im.versions[to] = version
+	im.created[kind]++
+}
+
+func (im *importer) mapIds(ids []string) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, im.idMap[id])
+	}
+	return out
+}
+
+func (im *importer) scopes(ctx context.Context) error {
+	const op = "bundle.(importer).scopes"
+	for _, s := range sortScopes(im.data.Scopes) {
+		if _, ok := im.idMap[s.Id]; ok {
+			continue
+		}
+		var in *iam.Scope
+		var err error
+		if s.Type == scope.Org.String() {
+			in, err = iam.NewOrg(iam.WithName(s.Name), iam.WithDescription(s.Description))
+		} else {
+			in, err = iam.NewProject(im.idMap[s.ParentId], iam.WithName(s.Name), iam.WithDescription(s.Description))
+		}
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		out, err := im.repos.Iam.CreateScope(ctx, in, "", iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import scope %s", s.Id)))
+		}
+		im.record("scopes", s.Id, out.GetPublicId(), out.GetVersion())
+	}
+	return nil
+}
+
+func (im *importer) users(ctx context.Context) error {
+	const op = "bundle.(importer).users"
+	for _, u := range im.data.Users {
+		if _, ok := im.idMap[u.Id]; ok {
+			continue
+		}
+		in, err := iam.NewUser(im.idMap[u.ScopeId], iam.WithName(u.Name), iam.WithDescription(u.Description))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		out, err := im.repos.Iam.CreateUser(ctx, in)
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import user %s", u.Id)))
+		}
+		im.record("users", u.Id, out.GetPublicId(), out.GetVersion())
+	}
+	return nil
+}
+
+func (im *importer) groups(ctx context.Context) error {
+	const op = "bundle.(importer).groups"
+	for _, g := range im.data.Groups {
+		if _, ok := im.idMap[g.Id]; ok {
+			continue
+		}
+		in, err := iam.NewGroup(im.idMap[g.ScopeId], iam.WithName(g.Name), iam.WithDescription(g.Description))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		out, err := im.repos.Iam.CreateGroup(ctx, in)
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import group %s", g.Id)))
+		}
+		im.record("groups", g.Id, out.GetPublicId(), out.GetVersion())
+	}
+	// Members are added once every group exists, as they may be groups which
+	// come later in the bundle.
+	for _, g := range im.data.Groups {
+		if len(g.MemberIds) == 0 || !im.imported(g.Id) {
+			continue
+		}
+		groupId := im.idMap[g.Id]
+		if _, err := im.repos.Iam.AddGroupMembers(ctx, groupId, im.versions[groupId], im.mapIds(g.MemberIds)); err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import members of group %s", g.Id)))
+		}
+		im.versions[groupId]++
+	}
+	return nil
+}
+
+func (im *importer) authMethods(ctx context.Context) error {
+	const op = "bundle.(importer).authMethods"
+	for _, am := range im.data.AuthMethods {
+		if _, ok := im.idMap[am.Id]; ok {
+			continue
+		}
+		scopeId := im.idMap[am.ScopeId]
+		var id string
+		var version uint32
+		switch am.Type {
+		case PasswordAuthMethodType:
+			in, err := password.NewAuthMethod(scopeId, password.WithName(am.Name), password.WithDescription(am.Description))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			in.MinLoginNameLength = am.MinLoginNameLength
+			in.MinPasswordLength = am.MinPasswordLength
+			out, err := im.repos.Password.CreateAuthMethod(ctx, in)
+			if err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import auth method %s", am.Id)))
+			}
+			id, version = out.GetPublicId(), out.GetVersion()
+		case OidcAuthMethodType:
+			secret, err := unwrapSecret(ctx, im.wrapper, am.ClientSecret)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			in := &oidc.AuthMethod{
+				AuthMethod: &oidcstore.AuthMethod{
+					ScopeId:                           scopeId,
+					Name:                              am.Name,
+					Description:                       am.Description,
+					OperationalState:                  string(oidc.InactiveState),
+					Issuer:                            am.Issuer,
+					ClientId:                          am.ClientId,
+					ClientSecret:                      secret,
+					MaxAge:                            am.MaxAge,
+					ApiUrl:                            am.ApiUrl,
+					SigningAlgs:                       am.SigningAlgs,
+					AudClaims:                         am.AudClaims,
+					Certificates:                      am.Certificates,
+					ClaimsScopes:                      am.ClaimsScopes,
+					AccountClaimMaps:                  am.AccountClaimMaps,
+					DisableDiscoveredConfigValidation: am.DisableDiscoveredConfigValidation,
+				},
+			}
+			out, err := im.repos.Oidc.CreateAuthMethod(ctx, in)
+			if err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import auth method %s", am.Id)))
+			}
+			id, version = out.GetPublicId(), out.GetVersion()
+		}
+		im.record("auth_methods", am.Id, id, version)
+		im.scopeIds[id] = scopeId
+	}
+	return nil
+}
+
+func (im *importer) accounts(ctx context.Context) error {
+	const op = "bundle.(importer).accounts"
+	types := make(map[string]string, len(im.data.AuthMethods))
+	for _, am := range im.data.AuthMethods {
+		types[am.Id] = am.Type
+	}
+	for _, a := range im.data.Accounts {
+		if _, ok := im.idMap[a.Id]; ok {
+			continue
+		}
+		if !im.imported(a.AuthMethodId) {
+			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("account %s belongs to auth method %s which is not imported", a.Id, a.AuthMethodId))
+		}
+		amId := im.idMap[a.AuthMethodId]
+		scopeId := im.scopeIds[amId]
+		var id string
+		var version uint32
+		switch types[a.AuthMethodId] {
+		case PasswordAuthMethodType:
+			in, err := password.NewAccount(amId, password.WithLoginName(a.LoginName), password.WithName(a.Name), password.WithDescription(a.Description))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			out, err := im.repos.Password.CreateAccount(ctx, scopeId, in)
+			if err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import account %s", a.Id)))
+			}
+			id, version = out.GetPublicId(), out.GetVersion()
+		case OidcAuthMethodType:
+			in, err := oidc.NewAccount(amId, a.Subject, oidc.WithName(a.Name), oidc.WithDescription(a.Description))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			out, err := im.repos.Oidc.CreateAccount(ctx, scopeId, in)
+			if err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import account %s", a.Id)))
+			}
+			id, version = out.GetPublicId(), out.GetVersion()
+		}
+		im.record("accounts", a.Id, id, version)
+	}
+	return nil
+}
+
+func (im *importer) primaryAuthMethods(ctx context.Context) error {
+	const op = "bundle.(importer).primaryAuthMethods"
+	for _, s := range im.data.Scopes {
+		if s.PrimaryAuthMethodId == "" || !im.imported(s.Id) {
+			continue
+		}
+		id := im.idMap[s.Id]
+		in := iam.AllocScope()
+		in.PublicId = id
+		in.PrimaryAuthMethodId = im.idMap[s.PrimaryAuthMethodId]
+		out, _, err := im.repos.Iam.UpdateScope(ctx, &in, im.versions[id], []string{"PrimaryAuthMethodId"})
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to set primary auth method of scope %s", s.Id)))
+		}
+		im.versions[id] = out.GetVersion()
+	}
+	return nil
+}
+
+func (im *importer) userAccounts(ctx context.Context) error {
+	const op = "bundle.(importer).userAccounts"
+	for _, u := range im.data.Users {
+		if len(u.AccountIds) == 0 || !im.imported(u.Id) {
+			continue
+		}
+		id := im.idMap[u.Id]
+		if _, err := im.repos.Iam.AddUserAccounts(ctx, id, im.versions[id], im.mapIds(u.AccountIds)); err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import accounts of user %s", u.Id)))
+		}
+	}
+	return nil
+}
+
+func (im *importer) hosts(ctx context.Context) error {
+	const op = "bundle.(importer).hosts"
+	for _, c := range im.data.HostCatalogs {
+		if _, ok := im.idMap[c.Id]; ok {
+			continue
+		}
+		scopeId := im.idMap[c.ScopeId]
+		in, err := static.NewHostCatalog(scopeId, static.WithName(c.Name), static.WithDescription(c.Description))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		out, err := im.repos.Static.CreateCatalog(ctx, in)
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import host catalog %s", c.Id)))
+		}
+		im.record("host_catalogs", c.Id, out.GetPublicId(), out.GetVersion())
+		im.scopeIds[out.GetPublicId()] = scopeId
+	}
+	for _, h := range im.data.Hosts {
+		if _, ok := im.idMap[h.Id]; ok {
+			continue
+		}
+		if !im.imported(h.CatalogId) {
+			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("host %s belongs to host catalog %s which is not imported", h.Id, h.CatalogId))
+		}
+		catalogId := im.idMap[h.CatalogId]
+		in, err := static.NewHost(catalogId, static.WithName(h.Name), static.WithDescription(h.Description), static.WithAddress(h.Address))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		out, err := im.repos.Static.CreateHost(ctx, im.scopeIds[catalogId], in)
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import host %s", h.Id)))
+		}
+		im.record("hosts", h.Id, out.GetPublicId(), out.GetVersion())
+	}
+	for _, s := range im.data.HostSets {
+		if _, ok := im.idMap[s.Id]; ok {
+			continue
+		}
+		if !im.imported(s.CatalogId) {
+			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("host set %s belongs to host catalog %s which is not imported", s.Id, s.CatalogId))
+		}
+		catalogId := im.idMap[s.CatalogId]
+		scopeId := im.scopeIds[catalogId]
+		in, err := static.NewHostSet(catalogId, static.WithName(s.Name), static.WithDescription(s.Description))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		out, err := im.repos.Static.CreateSet(ctx, scopeId, in)
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import host set %s", s.Id)))
+		}
+		im.record("host_sets", s.Id, out.GetPublicId(), out.GetVersion())
+		if len(s.HostIds) == 0 {
+			continue
+		}
+		if _, err := im.repos.Static.AddSetMembers(ctx, scopeId, out.GetPublicId(), out.GetVersion(), im.mapIds(s.HostIds)); err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import members of host set %s", s.Id)))
+		}
+	}
+	return nil
+}
+
+func (im *importer) targets(ctx context.Context) error {
+	const op = "bundle.(importer).targets"
+	for _, t := range im.data.Targets {
+		if _, ok := im.idMap[t.Id]; ok {
+			continue
+		}
+		opts := []target.Option{
+			target.WithName(t.Name),
+			target.WithDescription(t.Description),
+			target.WithDefaultPort(t.DefaultPort),
+			target.WithSessionMaxSeconds(t.SessionMaxSeconds),
+			target.WithSessionConnectionLimit(t.SessionConnectionLimit),
+			target.WithWorkerFilter(t.WorkerFilter),
+		}
+		var out target.Target
+		var err error
+		switch t.Type {
+		case "", target.TcpTargetType.String():
+			var in *target.TcpTarget
+			if in, err = target.NewTcpTarget(im.idMap[t.ScopeId], opts...); err != nil {
+				return errors.Wrap(err, op)
+			}
+			out, _, err = im.repos.Target.CreateTcpTarget(ctx, in, target.WithHostSets(im.mapIds(t.HostSetIds)))
+		case target.UdpTargetType.String():
+			var in *target.UdpTarget
+			if in, err = target.NewUdpTarget(im.idMap[t.ScopeId], opts...); err != nil {
+				return errors.Wrap(err, op)
+			}
+			out, _, err = im.repos.Target.CreateUdpTarget(ctx, in, target.WithHostSets(im.mapIds(t.HostSetIds)))
+		default:
+			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unsupported target type %q for %s", t.Type, t.Id))
+		}
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import target %s", t.Id)))
+		}
+		im.record("targets", t.Id, out.GetPublicId(), out.GetVersion())
+	}
+	return nil
+}
+
+// roles are imported last, so the ids in their grants and principals can be
+// mapped to imported ids.
+func (im *importer) roles(ctx context.Context) error {
+	const op = "bundle.(importer).roles"
+	for _, r := range im.data.Roles {
+		if _, ok := im.idMap[r.Id]; ok {
+			continue
+		}
+		opts := []iam.Option{iam.WithName(r.Name), iam.WithDescription(r.Description)}
+		if r.GrantScopeId != "" {
+			opts = append(opts, iam.WithGrantScopeId(im.idMap[r.GrantScopeId]))
+		}
+		in, err := iam.NewRole(im.idMap[r.ScopeId], opts...)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		out, err := im.repos.Iam.CreateRole(ctx, in)
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import role %s", r.Id)))
+		}
+		id := out.GetPublicId()
+		im.record("roles", r.Id, id, out.GetVersion())
+		if len(r.Grants) > 0 {
+			grants := make([]string, 0, len(r.Grants))
+			for _, g := range r.Grants {
+				grants = append(grants, mapGrant(g, im.idMap))
+			}
+			if _, err := im.repos.Iam.AddRoleGrants(ctx, id, im.versions[id], grants); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import grants of role %s", r.Id)))
+			}
+			im.versions[id]++
+		}
+		if len(r.GrantScopes) > 0 {
+			grantScopes := make([]string, 0, len(r.GrantScopes))
+			for _, gs := range r.GrantScopes {
+				if !specialGrantScopes[gs] {
+					gs = im.idMap[gs]
+				}
+				grantScopes = append(grantScopes, gs)
+			}
+			if _, err := im.repos.Iam.AddRoleGrantScopes(ctx, id, im.versions[id], grantScopes); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import grant scopes of role %s", r.Id)))
+			}
+			im.versions[id]++
+		}
+		if len(r.PrincipalIds) > 0 {
+			if _, err := im.repos.Iam.AddPrincipalRoles(ctx, id, im.versions[id], im.mapIds(r.PrincipalIds)); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to import principals of role %s", r.Id)))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,310 @@
+package bundle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_plan(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        func(*Data)
+		idMap       map[string]string
+		wantCreated map[string]int
+		wantErr     string
+	}{
+		{
+			name: "valid",
+			wantCreated: map[string]int{
+				"scopes":       2,
+				"users":        1,
+				"auth_methods": 1,
+				"accounts":     1,
+			},
+		},
+		{
+			name:  "mapped-org",
+			idMap: map[string]string{"o_1234567890": "o_0987654321"},
+			wantCreated: map[string]int{
+				"scopes":       1,
+				"users":        1,
+				"auth_methods": 1,
+				"accounts":     1,
+			},
+		},
+		{
+			name: "projects-before-orgs",
+			data: func(d *Data) {
+				d.Scopes[0], d.Scopes[1] = d.Scopes[1], d.Scopes[0]
+			},
+			wantCreated: map[string]int{
+				"scopes":       2,
+				"users":        1,
+				"auth_methods": 1,
+				"accounts":     1,
+			},
+		},
+		{
+			name: "missing-parent",
+			data: func(d *Data) {
+				d.Scopes = d.Scopes[1:]
+			},
+			wantErr: "p_1234567890 refers to o_1234567890 which is not in the bundle or the id map",
+		},
+		{
+			name: "missing-account",
+			data: func(d *Data) {
+				d.Accounts = nil
+			},
+			wantErr: "u_1234567890 refers to acctpw_1234567890",
+		},
+		{
+			name:    "account-of-mapped-auth-method",
+			idMap:   map[string]string{"ampw_1234567890": "ampw_0987654321"},
+			wantErr: "acctpw_1234567890 belongs to ampw_1234567890 which is not imported",
+		},
+		{
+			name: "duplicate-id",
+			data: func(d *Data) {
+				d.Users = append(d.Users, &User{Id: "u_1234567890", ScopeId: "global"})
+			},
+			wantErr: "duplicate id u_1234567890",
+		},
+		{
+			name: "global-scope",
+			data: func(d *Data) {
+				d.Scopes = append(d.Scopes, &Scope{Id: "global", Type: "global"})
+			},
+			wantErr: `scope global has unsupported type "global"`,
+		},
+		{
+			name: "unsupported-auth-method",
+			data: func(d *Data) {
+				d.AuthMethods[0].Type = "ldap"
+			},
+			wantErr: `auth method ampw_1234567890 has unsupported type "ldap"`,
+		},
+		{
+			name: "role-referring-to-builtin-user",
+			data: func(d *Data) {
+				d.Roles = append(d.Roles, &Role{Id: "r_1234567890", ScopeId: "p_1234567890", PrincipalIds: []string{"u_anon", "u_1234567890"}})
+			},
+			wantCreated: map[string]int{
+				"scopes":       2,
+				"users":        1,
+				"auth_methods": 1,
+				"accounts":     1,
+				"roles":        1,
+			},
+		},
+		{
+			name: "role-grant-scopes",
+			data: func(d *Data) {
+				d.Roles = append(d.Roles, &Role{Id: "r_1234567890", ScopeId: "o_1234567890", GrantScopes: []string{"this", "children", "p_1234567890"}})
+			},
+			wantCreated: map[string]int{
+				"scopes":       2,
+				"users":        1,
+				"auth_methods": 1,
+				"accounts":     1,
+				"roles":        1,
+			},
+		},
+		{
+			name: "missing-grant-scope",
+			data: func(d *Data) {
+				d.Roles = append(d.Roles, &Role{Id: "r_1234567890", ScopeId: "o_1234567890", GrantScopes: []string{"this", "p_0987654321"}})
+			},
+			wantErr: "r_1234567890 refers to p_0987654321 which is not in the bundle or the id map",
+		},
+		{
+			name: "nested-groups-out-of-order",
+			data: func(d *Data) {
+				d.Groups = append(d.Groups,
+					&Group{Id: "g_1234567890", ScopeId: "o_1234567890", MemberIds: []string{"u_1234567890", "g_0987654321"}},
+					&Group{Id: "g_0987654321", ScopeId: "o_1234567890", MemberIds: []string{"u_1234567890"}},
+				)
+			},
+			wantCreated: map[string]int{
+				"scopes":       2,
+				"users":        1,
+				"groups":       2,
+				"auth_methods": 1,
+				"accounts":     1,
+			},
+		},
+		{
+			name: "missing-member-group",
+			data: func(d *Data) {
+				d.Groups = append(d.Groups, &Group{Id: "g_1234567890", ScopeId: "o_1234567890", MemberIds: []string{"g_0987654321"}})
+			},
+			wantErr: "g_1234567890 refers to g_0987654321 which is not in the bundle or the id map",
+		},
+		{
+			name:    "invalid-id-map",
+			idMap:   map[string]string{"o_1234567890": ""},
+			wantErr: "invalid id mapping",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			data := testBundle(t).Data
+			if tt.data != nil {
+				tt.data(data)
+			}
+			got, err := plan(data, tt.idMap)
+			if tt.wantErr != "" {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+				assert.Contains(err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantCreated, got.Created)
+			assert.Equal("global", got.IdMap["global"])
+			for from, to := range tt.idMap {
+				assert.Equal(to, got.IdMap[from])
+			}
+		})
+	}
+}
+
+func TestImport_DryRun(t *testing.T) {
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	// A dry run never uses the repositories, so they can be zero values.
+	repos := &Repositories{
+		Iam:      &iam.Repository{},
+		Password: &password.Repository{},
+		Oidc:     &oidc.Repository{},
+		Static:   &static.Repository{},
+		Target:   &target.Repository{},
+	}
+	b := testBundle(t)
+	require.NoError(t, b.Sign(ctx, wrapper))
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := Import(ctx, repos, wrapper, b, b.SchemaVersion, WithDryRun(true))
+		require.NoError(err)
+		assert.Equal(2, got.Created["scopes"])
+		assert.Equal(1, got.Created["accounts"])
+	})
+	t.Run("schema-version-mismatch", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := Import(ctx, repos, wrapper, b, b.SchemaVersion+1, WithDryRun(true))
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.VersionMismatch), err))
+	})
+	t.Run("wrong-wrapper", func(t *testing.T) {
+		require := require.New(t)
+		_, err := Import(ctx, repos, db.TestWrapper(t), b, b.SchemaVersion, WithDryRun(true))
+		require.Error(err)
+	})
+	t.Run("missing-repositories", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := Import(ctx, &Repositories{}, wrapper, b, b.SchemaVersion, WithDryRun(true))
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func Test_mapGrant(t *testing.T) {
+	idMap := map[string]string{
+		"ttcp_1234567890": "ttcp_0987654321",
+		"u_1234567890":    "u_0987654321",
+	}
+	tests := []struct {
+		grant string
+		want  string
+	}{
+		{grant: "id=ttcp_1234567890;actions=authorize-session", want: "id=ttcp_0987654321;actions=authorize-session"},
+		{grant: "id=*;type=target;actions=list", want: "id=*;type=target;actions=list"},
+		{grant: "id={{account.id}};actions=read,change-password", want: "id={{account.id}};actions=read,change-password"},
+		{grant: "type=host-catalog;actions=create", want: "type=host-catalog;actions=create"},
+		{grant: "id=ttcp_unknown;actions=read", want: "id=ttcp_unknown;actions=read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.grant, func(t *testing.T) {
+			assert.Equal(t, tt.want, mapGrant(tt.grant, idMap))
+		})
+	}
+}
+
+func testRepositories(t *testing.T) *Repositories {
+	t.Helper()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, db.TestWrapper(t))
+	repos := &Repositories{}
+	var err error
+	repos.Iam, err = iam.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	repos.Password, err = password.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	repos.Oidc, err = oidc.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	repos.Static, err = static.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	repos.Target, err = target.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	return repos
+}
+
+func TestExportImport_NestedGroups(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	from, to := testRepositories(t), testRepositories(t)
+
+	org, _ := iam.TestScopes(t, from.Iam, iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+	user := iam.TestUser(t, from.Iam, org.GetPublicId())
+	child, err := iam.NewGroup(org.GetPublicId(), iam.WithName("child"))
+	require.NoError(err)
+	child, err = from.Iam.CreateGroup(ctx, child)
+	require.NoError(err)
+	_, err = from.Iam.AddGroupMembers(ctx, child.GetPublicId(), child.GetVersion(), []string{user.GetPublicId()})
+	require.NoError(err)
+	parent, err := iam.NewGroup(org.GetPublicId(), iam.WithName("parent"))
+	require.NoError(err)
+	parent, err = from.Iam.CreateGroup(ctx, parent)
+	require.NoError(err)
+	_, err = from.Iam.AddGroupMembers(ctx, parent.GetPublicId(), parent.GetVersion(), []string{user.GetPublicId(), child.GetPublicId()})
+	require.NoError(err)
+
+	b, err := Export(ctx, from, wrapper, 1, WithScopeId(org.GetPublicId()))
+	require.NoError(err)
+	require.Len(b.Data.Groups, 2)
+	// The parent group comes before its child group
+	if b.Data.Groups[0].Id != parent.GetPublicId() {
+		b.Data.Groups[0], b.Data.Groups[1] = b.Data.Groups[1], b.Data.Groups[0]
+		require.NoError(b.Sign(ctx, wrapper))
+	}
+
+	res, err := Import(ctx, to, wrapper, b, 1)
+	require.NoError(err)
+	assert.Equal(2, res.Created["groups"])
+	_, members, err := to.Iam.LookupGroup(ctx, res.IdMap[parent.GetPublicId()])
+	require.NoError(err)
+	var memberIds []string
+	for _, m := range members {
+		memberIds = append(memberIds, m.GetMemberId())
+	}
+	assert.ElementsMatch([]string{res.IdMap[user.GetPublicId()], res.IdMap[child.GetPublicId()]}, memberIds)
+	_, members, err = to.Iam.LookupGroup(ctx, res.IdMap[child.GetPublicId()])
+	require.NoError(err)
+	require.Len(members, 1)
+	assert.Equal(res.IdMap[user.GetPublicId()], members[0].GetMemberId())
+}
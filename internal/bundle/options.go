@@ -0,0 +1,56 @@
+package bundle
+
+import "github.com/hashicorp/boundary/internal/types/scope"
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withScopeId string
+	withDryRun  bool
+	withIdMap   map[string]string
+}
+
+func getDefaultOptions() options {
+	return options{
+		withScopeId: scope.Global.String(),
+	}
+}
+
+// WithScopeId provides an optional scope id to export.  The scope and its
+// descendants are exported.  The default is the global scope.
+func WithScopeId(id string) Option {
+	return func(o *options) {
+		if id != "" {
+			o.withScopeId = id
+		}
+	}
+}
+
+// WithDryRun provides an optional dry run flag.  When set, an import
+// validates the bundle and reports what it would create without writing to
+// the database.
+func WithDryRun(dryRun bool) Option {
+	return func(o *options) {
+		o.withDryRun = dryRun
+	}
+}
+
+// WithIdMap provides an optional map of bundle ids to ids in the importing
+// database.  A mapped resource is not imported; references to it use the
+// mapped id instead, e.g. to import an org's projects into an existing org.
+func WithIdMap(m map[string]string) Option {
+	return func(o *options) {
+		o.withIdMap = m
+	}
+}
@@ -0,0 +1,41 @@
+package bundle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_GetOpts provides unit tests for GetOpts and all the options
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithScopeId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal("global", opts.withScopeId)
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithScopeId("o_1234567890"))
+		testOpts.withScopeId = "o_1234567890"
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithScopeId(""))
+		assert.Equal("global", opts.withScopeId)
+	})
+	t.Run("WithDryRun", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithDryRun(true))
+		testOpts := getDefaultOptions()
+		testOpts.withDryRun = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithIdMap", func(t *testing.T) {
+		assert := assert.New(t)
+		m := map[string]string{"o_1234567890": "o_0987654321"}
+		opts := getOpts(WithIdMap(m))
+		testOpts := getDefaultOptions()
+		testOpts.withIdMap = m
+		assert.Equal(opts, testOpts)
+	})
+}
@@ -56,6 +56,7 @@ type Command struct {
 
 	flagAddr    string
 	flagVerbose bool
+	flagProfile string
 
 	flagTLSCACert     string
 	flagTLSCAPath     string
@@ -322,6 +323,15 @@ func (c *Command) FlagSet(bit FlagSetBit) *FlagSets {
 		if bit&FlagSetClient != 0 {
 			f := set.NewFlagSet("Client Options")
 
+			f.StringVar(&StringVar{
+				Name:       FlagNameProfile,
+				Target:     &c.flagProfile,
+				EnvVar:     EnvBoundaryProfile,
+				Completion: PredictProfiles,
+				Usage:      `If specified, the named profile of the CLI config file supplies defaults for the connection and client options, as well as -scope-id and -auth-method-id, which are used unless given as flags or environment variables. Defaults to the profile selected with "boundary profiles use".`,
+			})
+			set.profile = &c.flagProfile
+
 			f.StringVar(&StringVar{
 				Name:   "token-name",
 				Target: &c.FlagTokenName,
@@ -394,6 +404,11 @@ type FlagSets struct {
 	mainSet     *flag.FlagSet
 	hiddens     map[string]struct{}
 	completions complete.Flags
+	envVars     map[string]string
+
+	// profile, if set, names the profile whose values are applied once the
+	// flags are parsed.
+	profile *string
 }
 
 // NewFlagSets creates a new flag sets.
@@ -409,6 +424,7 @@ func NewFlagSets(ui cli.Ui) *FlagSets {
 		mainSet:     mainSet,
 		hiddens:     make(map[string]struct{}),
 		completions: complete.Flags{},
+		envVars:     make(map[string]string),
 	}
 }
 
@@ -417,6 +433,7 @@ func (f *FlagSets) NewFlagSet(name string) *FlagSet {
 	flagSet := NewFlagSet(name)
 	flagSet.mainSet = f.mainSet
 	flagSet.completions = f.completions
+	flagSet.envVars = f.envVars
 	f.flagSets = append(f.flagSets, flagSet)
 	return flagSet
 }
@@ -431,7 +448,13 @@ func (f *FlagSets) Completions() complete.Flags {
 
 // Parse parses the given flags, returning any errors.
 func (f *FlagSets) Parse(args []string) error {
-	return f.mainSet.Parse(args)
+	if err := f.mainSet.Parse(args); err != nil {
+		return err
+	}
+	if f.profile != nil {
+		return f.applyProfile(*f.profile)
+	}
+	return nil
 }
 
 // Parsed reports whether the command-line flags have been parsed.
@@ -444,6 +467,11 @@ func (f *FlagSets) Args() []string {
 	return f.mainSet.Args()
 }
 
+// Lookup returns the flag with the given name, or nil if there's none.
+func (f *FlagSets) Lookup(name string) *flag.Flag {
+	return f.mainSet.Lookup(name)
+}
+
 // Visit visits the flags in lexicographical order, calling fn for each. It
 // visits only those flags that have been set.
 func (f *FlagSets) Visit(fn func(*flag.Flag)) {
@@ -474,6 +502,7 @@ type FlagSet struct {
 	flagSet     *flag.FlagSet
 	mainSet     *flag.FlagSet
 	completions complete.Flags
+	envVars     map[string]string
 }
 
 // NewFlagSet creates a new flag set.
@@ -0,0 +1,153 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"text/template"
+
+	"github.com/hashicorp/hcl"
+)
+
+// connectHelperNamePattern matches the names of connect helpers and their
+// flags, which become CLI subcommands and flags.
+var connectHelperNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// CLIConfig is the configuration of the CLI itself, read from the file given
+// by BOUNDARY_CLI_CONFIG or otherwise from ~/.boundary/cli.hcl.
+type CLIConfig struct {
+	// CurrentProfile is the profile used when none is given by -profile or
+	// BOUNDARY_PROFILE.
+	CurrentProfile string `hcl:"current_profile"`
+
+	Profiles []*Profile `hcl:"profile"`
+
+	ConnectHelpers []*ConnectHelper `hcl:"connect_helper"`
+}
+
+// ConnectHelper declares a "boundary connect <name>" subcommand which
+// executes a client against a session's local proxy. Args and the values of
+// Env are Go templates; see the connect command for the data available to
+// them.
+type ConnectHelper struct {
+	Name string `hcl:",key"`
+
+	// Description is the subcommand's synopsis.
+	Description string `hcl:"description"`
+
+	// Exec is the client to execute, unless overridden by -exec.
+	Exec string `hcl:"exec"`
+
+	// Args are the arguments passed to the client. Arguments which are empty
+	// once rendered are dropped.
+	Args []string `hcl:"args"`
+
+	// Env holds environment variables set for the client.
+	Env map[string]string `hcl:"env"`
+
+	// Flags are extra string flags of the subcommand.
+	Flags []*ConnectHelperFlag `hcl:"flag"`
+}
+
+// ConnectHelperFlag is a string flag of a connect helper, available to its
+// templates under .Flags.
+type ConnectHelperFlag struct {
+	Name string `hcl:",key"`
+
+	Usage   string `hcl:"usage"`
+	Default string `hcl:"default"`
+	EnvVar  string `hcl:"env_var"`
+
+	// Values, if set, are offered when completing the flag.
+	Values []string `hcl:"values"`
+}
+
+// CLIConfigPath returns the path of the CLI's configuration file and whether
+// it was set explicitly with BOUNDARY_CLI_CONFIG.
+func CLIConfigPath() (string, bool) {
+	if path := os.Getenv(EnvBoundaryCLIConfig); path != "" {
+		return path, true
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", false
+	}
+	return filepath.Join(home, ".boundary", "cli.hcl"), false
+}
+
+// LoadCLIConfig loads the CLI's configuration file. A missing file is only an
+// error if its path was set explicitly.
+func LoadCLIConfig() (*CLIConfig, error) {
+	path, explicit := CLIConfigPath()
+	if path == "" {
+		return new(CLIConfig), nil
+	}
+	d, err := ioutil.ReadFile(path)
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist) && !explicit:
+		return new(CLIConfig), nil
+	default:
+		return nil, fmt.Errorf("error reading CLI config file: %w", err)
+	}
+	c, err := ParseCLIConfig(string(d))
+	if err != nil {
+		return nil, fmt.Errorf("error in CLI config file %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// ParseCLIConfig parses the CLI's configuration.
+func ParseCLIConfig(d string) (*CLIConfig, error) {
+	c := new(CLIConfig)
+	if err := hcl.Decode(c, d); err != nil {
+		return nil, fmt.Errorf("error parsing config: %w", err)
+	}
+	if err := c.validateProfiles(); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(c.ConnectHelpers))
+	for _, h := range c.ConnectHelpers {
+		if err := h.validate(); err != nil {
+			return nil, err
+		}
+		if names[h.Name] {
+			return nil, fmt.Errorf("connect helper %q is declared more than once", h.Name)
+		}
+		names[h.Name] = true
+	}
+	return c, nil
+}
+
+func (h *ConnectHelper) validate() error {
+	if !connectHelperNamePattern.MatchString(h.Name) {
+		return fmt.Errorf("connect helper name %q must be lowercase letters, digits, hyphens and underscores", h.Name)
+	}
+	if h.Exec == "" {
+		return fmt.Errorf("connect helper %q must have an exec", h.Name)
+	}
+	for _, arg := range h.Args {
+		if _, err := template.New("").Parse(arg); err != nil {
+			return fmt.Errorf("connect helper %q has an invalid argument template: %w", h.Name, err)
+		}
+	}
+	for k, v := range h.Env {
+		if _, err := template.New("").Parse(v); err != nil {
+			return fmt.Errorf("connect helper %q has an invalid template for env var %s: %w", h.Name, k, err)
+		}
+	}
+	flags := make(map[string]bool, len(h.Flags))
+	for _, f := range h.Flags {
+		if !connectHelperNamePattern.MatchString(f.Name) {
+			return fmt.Errorf("connect helper %q flag name %q must be lowercase letters, digits, hyphens and underscores", h.Name, f.Name)
+		}
+		if flags[f.Name] {
+			return fmt.Errorf("connect helper %q flag %q is declared more than once", h.Name, f.Name)
+		}
+		flags[f.Name] = true
+	}
+	return nil
+}
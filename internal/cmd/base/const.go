@@ -27,4 +27,7 @@ const (
 const (
 	EnvBoundaryCLINoColor = `BOUNDARY_CLI_NO_COLOR`
 	EnvBoundaryCLIFormat  = `BOUNDARY_CLI_FORMAT`
+	EnvBoundaryCLIConfig  = `BOUNDARY_CLI_CONFIG`
+
+	EnvBoundaryProfile = `BOUNDARY_PROFILE`
 )
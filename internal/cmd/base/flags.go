@@ -740,6 +740,9 @@ func (f *FlagSet) VarFlag(i *VarFlag) {
 
 	f.Var(i.Value, i.Name, usage)
 	f.completions["-"+i.Name] = i.Completion
+	if f.envVars != nil && i.EnvVar != "" {
+		f.envVars[i.Name] = i.EnvVar
+	}
 }
 
 // Var is a lower-level API for adding something to the flags. It should be used
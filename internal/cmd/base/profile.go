@@ -0,0 +1,194 @@
+package base
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/posener/complete"
+)
+
+// FlagNameProfile is the flag used in the base command to select a profile
+// from the CLI config file.
+const FlagNameProfile = "profile"
+
+// currentProfilePattern matches the current_profile assignment in the CLI
+// config file, so that it can be replaced without rewriting the rest of the
+// file.
+var currentProfilePattern = regexp.MustCompile(`(?m)^[ \t]*current_profile[ \t]*=.*$`)
+
+// Profile holds defaults for the flags of the same name. A value is only
+// used when its flag is neither given on the command line nor set through
+// its environment variable.
+type Profile struct {
+	Name string `hcl:",key" json:"name"`
+
+	Addr          string `hcl:"addr" json:"addr,omitempty"`
+	CACert        string `hcl:"ca_cert" json:"ca_cert,omitempty"`
+	CAPath        string `hcl:"ca_path" json:"ca_path,omitempty"`
+	ClientCert    string `hcl:"client_cert" json:"client_cert,omitempty"`
+	ClientKey     string `hcl:"client_key" json:"client_key,omitempty"`
+	TLSServerName string `hcl:"tls_server_name" json:"tls_server_name,omitempty"`
+	TLSInsecure   bool   `hcl:"tls_insecure" json:"tls_insecure,omitempty"`
+
+	ScopeId      string `hcl:"scope_id" json:"scope_id,omitempty"`
+	AuthMethodId string `hcl:"auth_method_id" json:"auth_method_id,omitempty"`
+	TokenName    string `hcl:"token_name" json:"token_name,omitempty"`
+	KeyringType  string `hcl:"keyring_type" json:"keyring_type,omitempty"`
+}
+
+func (c *CLIConfig) validateProfiles() error {
+	names := make(map[string]bool, len(c.Profiles))
+	for _, p := range c.Profiles {
+		if p.Name == "" {
+			return errors.New("profile names must not be empty")
+		}
+		if names[p.Name] {
+			return fmt.Errorf("profile %q is declared more than once", p.Name)
+		}
+		names[p.Name] = true
+	}
+	if c.CurrentProfile != "" && !names[c.CurrentProfile] {
+		return fmt.Errorf("current profile %q is not declared", c.CurrentProfile)
+	}
+	return nil
+}
+
+// Profile returns the profile with the given name, or nil if there's none.
+func (c *CLIConfig) Profile(name string) *Profile {
+	for _, p := range c.Profiles {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// SetCurrentProfile makes the named profile the current one in the CLI's
+// configuration file. Only the current_profile assignment of the file is
+// changed.
+func SetCurrentProfile(name string) error {
+	path, _ := CLIConfigPath()
+	if path == "" {
+		return errors.New("unable to determine the path of the CLI config file")
+	}
+	d, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading CLI config file: %w", err)
+	}
+	c, err := ParseCLIConfig(string(d))
+	if err != nil {
+		return fmt.Errorf("error in CLI config file %s: %w", path, err)
+	}
+	if c.Profile(name) == nil {
+		return fmt.Errorf("profile %q is not declared in %s", name, path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("error reading CLI config file: %w", err)
+	}
+	if err := ioutil.WriteFile(path, setCurrentProfile(d, name), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("error writing CLI config file: %w", err)
+	}
+	return nil
+}
+
+func setCurrentProfile(d []byte, name string) []byte {
+	line := []byte("current_profile = " + strconv.Quote(name))
+	if currentProfilePattern.Match(d) {
+		return currentProfilePattern.ReplaceAllLiteral(d, line)
+	}
+	return append(append(line, '\n', '\n'), d...)
+}
+
+// flagValues returns the profile's settings keyed by the names of the flags
+// they're defaults for.
+func (p *Profile) flagValues() map[string]string {
+	ret := map[string]string{
+		FlagNameAddr:       p.Addr,
+		FlagNameCACert:     p.CACert,
+		FlagNameCAPath:     p.CAPath,
+		FlagNameClientCert: p.ClientCert,
+		FlagNameClientKey:  p.ClientKey,
+		FlagTLSServerName:  p.TLSServerName,
+		"scope-id":         p.ScopeId,
+		"auth-method-id":   p.AuthMethodId,
+		"token-name":       p.TokenName,
+		"keyring-type":     p.KeyringType,
+	}
+	if p.TLSInsecure {
+		ret[FlagNameTLSInsecure] = "true"
+	}
+	for k, v := range ret {
+		if v == "" {
+			delete(ret, k)
+		}
+	}
+	return ret
+}
+
+// applyProfile sets the flags of the given flag sets from the named profile,
+// or from the current one of the CLI config file if name is empty. Flags given
+// on the command line or through their environment variable are left alone.
+func (f *FlagSets) applyProfile(name string) error {
+	cfg, err := LoadCLIConfig()
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		name = cfg.CurrentProfile
+		if name == "" {
+			return nil
+		}
+	}
+	p := cfg.Profile(name)
+	if p == nil {
+		return fmt.Errorf("profile %q is not declared in the CLI config file", name)
+	}
+
+	given := make(map[string]bool)
+	f.mainSet.Visit(func(fl *flag.Flag) {
+		given[fl.Name] = true
+	})
+	values := p.flagValues()
+	names := make([]string, 0, len(values))
+	for k := range values {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		fl := f.mainSet.Lookup(k)
+		if given[k] || fl == nil {
+			continue
+		}
+		if env := f.envVars[k]; env != "" {
+			if _, ok := os.LookupEnv(env); ok {
+				continue
+			}
+		}
+		if err := fl.Value.Set(values[k]); err != nil {
+			return fmt.Errorf("invalid value for %q in profile %q: %w", k, name, err)
+		}
+	}
+	return nil
+}
+
+// PredictProfiles completes the names of the profiles in the CLI config file.
+var PredictProfiles = complete.PredictFunc(predictProfiles)
+
+func predictProfiles(complete.Args) []string {
+	cfg, err := LoadCLIConfig()
+	if err != nil {
+		return nil
+	}
+	ret := make([]string, 0, len(cfg.Profiles))
+	for _, p := range cfg.Profiles {
+		ret = append(ret, p.Name)
+	}
+	return ret
+}
@@ -0,0 +1,249 @@
+package base
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProfilesConfig = `# Clusters
+current_profile = "dev"
+
+profile "dev" {
+  addr       = "https://dev.example.com:9200"
+  token_name = "dev"
+}
+
+profile "prod" {
+  addr         = "https://prod.example.com:9200"
+  tls_insecure = true
+}
+
+connect_helper "psql" {
+  exec = "psql"
+}
+`
+
+// setEnv sets the environment variable for the duration of the test.
+func setEnv(t *testing.T, key, value string) {
+	t.Helper()
+	old, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// testCLIConfigFile writes the CLI config file and points
+// BOUNDARY_CLI_CONFIG at it.
+func testCLIConfigFile(t *testing.T, config string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "boundary-cli-config")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "cli.hcl")
+	require.NoError(t, ioutil.WriteFile(path, []byte(config), 0o640))
+	setEnv(t, EnvBoundaryCLIConfig, path)
+	return path
+}
+
+func TestParseCLIConfig_Profiles(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c, err := ParseCLIConfig(testProfilesConfig)
+		require.NoError(err)
+		assert.Equal("dev", c.CurrentProfile)
+		require.Len(c.Profiles, 2)
+		assert.Equal(&Profile{Name: "dev", Addr: "https://dev.example.com:9200", TokenName: "dev"}, c.Profile("dev"))
+		assert.Equal(&Profile{Name: "prod", Addr: "https://prod.example.com:9200", TLSInsecure: true}, c.Profile("prod"))
+		assert.Nil(c.Profile("test"))
+		require.Len(c.ConnectHelpers, 1, "profiles and connect helpers share the file")
+		assert.Equal("psql", c.ConnectHelpers[0].Name)
+	})
+
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:    "duplicate",
+			config:  `profile "dev" {} profile "dev" {}`,
+			wantErr: `profile "dev" is declared more than once`,
+		},
+		{
+			name:    "undeclared current profile",
+			config:  `current_profile = "prod" profile "dev" {}`,
+			wantErr: `current profile "prod" is not declared`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCLIConfig(tt.config)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoadCLIConfig_Profiles(t *testing.T) {
+	t.Run("explicit", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		testCLIConfigFile(t, testProfilesConfig)
+		c, err := LoadCLIConfig()
+		require.NoError(err)
+		assert.Equal("dev", c.CurrentProfile)
+		assert.Len(c.Profiles, 2)
+	})
+
+	t.Run("missing explicit", func(t *testing.T) {
+		setEnv(t, EnvBoundaryCLIConfig, filepath.Join(os.TempDir(), "boundary-missing", "cli.hcl"))
+		_, err := LoadCLIConfig()
+		assert.Error(t, err)
+	})
+
+	t.Run("missing default", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir, err := ioutil.TempDir("", "boundary-home")
+		require.NoError(err)
+		defer os.RemoveAll(dir)
+		setEnv(t, EnvBoundaryCLIConfig, "")
+		setEnv(t, "HOME", dir)
+		c, err := LoadCLIConfig()
+		require.NoError(err)
+		assert.Empty(c.Profiles)
+	})
+}
+
+func TestSetCurrentProfile(t *testing.T) {
+	t.Run("replaced", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		path := testCLIConfigFile(t, testProfilesConfig)
+		require.NoError(SetCurrentProfile("prod"))
+
+		d, err := ioutil.ReadFile(path)
+		require.NoError(err)
+		want := strings.Replace(testProfilesConfig, `current_profile = "dev"`, `current_profile = "prod"`, 1)
+		assert.Equal(want, string(d), "only current_profile is rewritten")
+		info, err := os.Stat(path)
+		require.NoError(err)
+		assert.Equal(os.FileMode(0o640), info.Mode().Perm())
+	})
+
+	t.Run("added", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		config := `profile "dev" {}` + "\n"
+		path := testCLIConfigFile(t, config)
+		require.NoError(SetCurrentProfile("dev"))
+
+		d, err := ioutil.ReadFile(path)
+		require.NoError(err)
+		assert.Equal("current_profile = \"dev\"\n\n"+config, string(d))
+		c, err := LoadCLIConfig()
+		require.NoError(err)
+		assert.Equal("dev", c.CurrentProfile)
+	})
+
+	t.Run("undeclared", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		path := testCLIConfigFile(t, testProfilesConfig)
+		err := SetCurrentProfile("test")
+		require.Error(err)
+		assert.Contains(err.Error(), `profile "test" is not declared`)
+
+		d, err := ioutil.ReadFile(path)
+		require.NoError(err)
+		assert.Equal(testProfilesConfig, string(d))
+	})
+}
+
+func TestApplyProfile(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		env           map[string]string
+		wantAddr      string
+		wantTokenName string
+		wantInsecure  bool
+		wantErr       string
+	}{
+		{
+			name:          "current profile",
+			wantAddr:      "https://dev.example.com:9200",
+			wantTokenName: "dev",
+		},
+		{
+			name:         "profile flag",
+			args:         []string{"-profile", "prod"},
+			wantAddr:     "https://prod.example.com:9200",
+			wantInsecure: true,
+		},
+		{
+			name:         "profile env",
+			env:          map[string]string{EnvBoundaryProfile: "prod"},
+			wantAddr:     "https://prod.example.com:9200",
+			wantInsecure: true,
+		},
+		{
+			name:         "profile flag over env",
+			args:         []string{"-profile", "prod"},
+			env:          map[string]string{EnvBoundaryProfile: "dev"},
+			wantAddr:     "https://prod.example.com:9200",
+			wantInsecure: true,
+		},
+		{
+			name:          "flag over profile",
+			args:          []string{"-addr", "https://flag.example.com:9200"},
+			wantAddr:      "https://flag.example.com:9200",
+			wantTokenName: "dev",
+		},
+		{
+			name:          "env over profile",
+			env:           map[string]string{api.EnvBoundaryAddr: "https://env.example.com:9200"},
+			wantAddr:      "https://env.example.com:9200",
+			wantTokenName: "dev",
+		},
+		{
+			name:    "undeclared profile",
+			args:    []string{"-profile", "test"},
+			wantErr: `profile "test" is not declared`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			testCLIConfigFile(t, testProfilesConfig)
+			// Start without any of the settings in the environment
+			for _, k := range []string{api.EnvBoundaryAddr, api.EnvBoundaryTLSInsecure, EnvTokenName, EnvBoundaryProfile} {
+				setEnv(t, k, "")
+				os.Unsetenv(k)
+			}
+			for k, v := range tt.env {
+				setEnv(t, k, v)
+			}
+
+			c := NewCommand(cli.NewMockUi())
+			err := c.FlagSet(FlagSetHTTP | FlagSetClient).Parse(tt.args)
+			if tt.wantErr != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantAddr, c.flagAddr)
+			assert.Equal(tt.wantTokenName, c.FlagTokenName)
+			assert.Equal(tt.wantInsecure, c.flagTLSInsecure)
+		})
+	}
+}
@@ -372,7 +372,7 @@ func (b *Server) SetupKMSes(ui cli.Ui, config *config.Config) error {
 			switch purpose {
 			case "":
 				return errors.New("KMS block missing 'purpose'")
-			case "root", "worker-auth", "config":
+			case "root", "worker-auth", "config", "bundle":
 			case "recovery":
 				if config.Controller != nil && config.DevRecoveryKey != "" {
 					kms.Config["key"] = config.DevRecoveryKey
@@ -405,7 +405,7 @@ func (b *Server) SetupKMSes(ui cli.Ui, config *config.Config) error {
 				b.WorkerAuthKms = wrapper
 			case "recovery":
 				b.RecoveryKms = wrapper
-			case "config":
+			case "config", "bundle":
 				// Do nothing, can be set in same file but not needed at runtime
 			default:
 				return fmt.Errorf("KMS purpose of %q is unknown", purpose)
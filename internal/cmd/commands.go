@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/commands/accountscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/agent"
+	"github.com/hashicorp/boundary/internal/cmd/commands/apply"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authenticate"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authmethodscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/authorize"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authtokenscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/config"
 	"github.com/hashicorp/boundary/internal/cmd/commands/connect"
@@ -15,7 +20,10 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostsetscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/logout"
+	"github.com/hashicorp/boundary/internal/cmd/commands/profiles"
+	"github.com/hashicorp/boundary/internal/cmd/commands/proxy"
 	"github.com/hashicorp/boundary/internal/cmd/commands/rolescmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/roletemplatescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/scopescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/server"
 	"github.com/hashicorp/boundary/internal/cmd/commands/sessionscmd"
@@ -139,6 +147,35 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"agent": func() (cli.Command, error) {
+			return &agent.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"agent add-listener": func() (cli.Command, error) {
+			return &agent.ListenerCommand{
+				Command: base.NewCommand(ui),
+				Func:    "add-listener",
+			}, nil
+		},
+		"agent remove-listener": func() (cli.Command, error) {
+			return &agent.ListenerCommand{
+				Command: base.NewCommand(ui),
+				Func:    "remove-listener",
+			}, nil
+		},
+		"agent status": func() (cli.Command, error) {
+			return &agent.StatusCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+
+		"apply": func() (cli.Command, error) {
+			return &apply.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+
 		"auth-methods": func() (cli.Command, error) {
 			return &authmethodscmd.Command{
 				Command: base.NewCommand(ui),
@@ -307,12 +344,27 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "ssh",
 			}, nil
 		},
+		"connect stdio": func() (cli.Command, error) {
+			return &connect.StdioCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"database": func() (cli.Command, error) {
 			return &database.Command{
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"database export": func() (cli.Command, error) {
+			return &database.ExportCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database import": func() (cli.Command, error) {
+			return &database.ImportCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 		"database init": func() (cli.Command, error) {
 			return &database.InitCommand{
 				Command: base.NewCommand(ui),
@@ -323,6 +375,16 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"database status": func() (cli.Command, error) {
+			return &database.StatusCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database verify": func() (cli.Command, error) {
+			return &database.VerifyCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"groups": func() (cli.Command, error) {
 			return &groupscmd.Command{
@@ -377,6 +439,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-members",
 			}, nil
 		},
+		"groups read-resolved-members": func() (cli.Command, error) {
+			return &groupscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read-resolved-members",
+			}, nil
+		},
+		"groups transfer-ownership": func() (cli.Command, error) {
+			return &groupscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "transfer-ownership",
+			}, nil
+		},
 
 		"host-catalogs": func() (cli.Command, error) {
 			return &hostcatalogscmd.Command{
@@ -425,6 +499,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"host-catalogs set-labels": func() (cli.Command, error) {
+			return &hostcatalogscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-labels",
+			}, nil
+		},
 
 		"host-sets": func() (cli.Command, error) {
 			return &hostsetscmd.Command{
@@ -491,6 +571,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "set-hosts",
 			}, nil
 		},
+		"host-sets set-labels": func() (cli.Command, error) {
+			return &hostsetscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-labels",
+			}, nil
+		},
+		"host-sets transfer-ownership": func() (cli.Command, error) {
+			return &hostsetscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "transfer-ownership",
+			}, nil
+		},
 
 		"hosts": func() (cli.Command, error) {
 			return &hostscmd.Command{
@@ -539,6 +631,35 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"hosts rotate-ssh-host-key": func() (cli.Command, error) {
+			return &hostscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "rotate-ssh-host-key",
+			}, nil
+		},
+		"hosts set-labels": func() (cli.Command, error) {
+			return &hostscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-labels",
+			}, nil
+		},
+		"hosts transfer-ownership": func() (cli.Command, error) {
+			return &hostscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "transfer-ownership",
+			}, nil
+		},
+
+		"authorize": func() (cli.Command, error) {
+			return &authorize.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"authorize explain": func() (cli.Command, error) {
+			return &authorize.ExplainCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"logout": func() (cli.Command, error) {
 			return &logout.LogoutCommand{
@@ -546,6 +667,27 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"profiles": func() (cli.Command, error) {
+			return &profiles.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"profiles list": func() (cli.Command, error) {
+			return &profiles.ListCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"profiles use": func() (cli.Command, error) {
+			return &profiles.UseCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"proxy": func() (cli.Command, error) {
+			return &proxy.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+
 		"roles": func() (cli.Command, error) {
 			return &rolescmd.Command{
 				Command: base.NewCommand(ui),
@@ -617,6 +759,96 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-grants",
 			}, nil
 		},
+		"roles add-grant-scopes": func() (cli.Command, error) {
+			return &rolescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "add-grant-scopes",
+			}, nil
+		},
+		"roles set-grant-scopes": func() (cli.Command, error) {
+			return &rolescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-grant-scopes",
+			}, nil
+		},
+		"roles remove-grant-scopes": func() (cli.Command, error) {
+			return &rolescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-grant-scopes",
+			}, nil
+		},
+
+		"role-templates": func() (cli.Command, error) {
+			return &roletemplatescmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"role-templates create": func() (cli.Command, error) {
+			return &roletemplatescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"role-templates update": func() (cli.Command, error) {
+			return &roletemplatescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"role-templates read": func() (cli.Command, error) {
+			return &roletemplatescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"role-templates delete": func() (cli.Command, error) {
+			return &roletemplatescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"role-templates list": func() (cli.Command, error) {
+			return &roletemplatescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"role-templates add-principals": func() (cli.Command, error) {
+			return &roletemplatescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "add-principals",
+			}, nil
+		},
+		"role-templates set-principals": func() (cli.Command, error) {
+			return &roletemplatescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-principals",
+			}, nil
+		},
+		"role-templates remove-principals": func() (cli.Command, error) {
+			return &roletemplatescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-principals",
+			}, nil
+		},
+		"role-templates add-grants": func() (cli.Command, error) {
+			return &roletemplatescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "add-grants",
+			}, nil
+		},
+		"role-templates set-grants": func() (cli.Command, error) {
+			return &roletemplatescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-grants",
+			}, nil
+		},
+		"role-templates remove-grants": func() (cli.Command, error) {
+			return &roletemplatescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-grants",
+			}, nil
+		},
 
 		"scopes": func() (cli.Command, error) {
 			return &scopescmd.Command{
@@ -653,6 +885,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "list",
 			}, nil
 		},
+		"scopes set-labels": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-labels",
+			}, nil
+		},
 
 		"sessions": func() (cli.Command, error) {
 			return &sessionscmd.Command{
@@ -719,6 +957,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"targets create udp": func() (cli.Command, error) {
+			return &targetscmd.UdpCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"targets update": func() (cli.Command, error) {
 			return &targetscmd.Command{
 				Command: base.NewCommand(ui),
@@ -731,6 +975,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"targets update udp": func() (cli.Command, error) {
+			return &targetscmd.UdpCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 		"targets add-host-sets": func() (cli.Command, error) {
 			return &targetscmd.Command{
 				Command: base.NewCommand(ui),
@@ -749,6 +999,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "set-host-sets",
 			}, nil
 		},
+		"targets set-labels": func() (cli.Command, error) {
+			return &targetscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-labels",
+			}, nil
+		},
+		"targets transfer-ownership": func() (cli.Command, error) {
+			return &targetscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "transfer-ownership",
+			}, nil
+		},
 
 		"users": func() (cli.Command, error) {
 			return &userscmd.Command{
@@ -803,5 +1065,38 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-accounts",
 			}, nil
 		},
+		"users read-effective-permissions": func() (cli.Command, error) {
+			return &userscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read-effective-permissions",
+			}, nil
+		},
+	}
+
+	initConnectHelperCommands(ui)
+}
+
+// initConnectHelperCommands adds a "connect <name>" command for each connect
+// helper declared in the CLI's configuration file.
+func initConnectHelperCommands(ui cli.Ui) {
+	cfg, err := base.LoadCLIConfig()
+	if err != nil {
+		ui.Warn(fmt.Sprintf("Connect helpers are unavailable: %s", err))
+		return
+	}
+	for _, h := range cfg.ConnectHelpers {
+		name := "connect " + h.Name
+		if _, ok := Commands[name]; ok {
+			ui.Warn(fmt.Sprintf("Connect helper %q has the name of a built-in command and is ignored", h.Name))
+			continue
+		}
+		h := h
+		Commands[name] = func() (cli.Command, error) {
+			return &connect.Command{
+				Command: base.NewCommand(ui),
+				Func:    h.Name,
+				Helper:  h,
+			}, nil
+		}
 	}
 }
@@ -0,0 +1,258 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*Command)(nil)
+	_ cli.CommandAutocomplete = (*Command)(nil)
+)
+
+type Command struct {
+	*base.Command
+
+	flagConfig        string
+	flagControlSocket string
+
+	outputMu sync.Mutex
+	// refreshToken is set when the auth token is read from the keyring, so
+	// it's re-read before each authorization in case it has changed.
+	refreshToken bool
+	keyringType  string
+	tokenName    string
+}
+
+func (c *Command) Synopsis() string {
+	return "Run an agent keeping local listeners to targets"
+}
+
+func (c *Command) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary agent [options]",
+		"",
+		"  Run an agent which keeps named local listeners whose connections are proxied to targets. Each listener's session is re-authorized before it expires, or once it has no connections left, so the listener stays usable; connections made through an expiring session end with it. The auth token is re-read from the keyring before each authorization, so re-authenticating while the agent runs takes effect.",
+		"",
+		"  Listeners are configured in the file given by -config, for example:",
+		"",
+		`      listener "ssh-prod" {`,
+		`        target_id   = "ttcp_1234567890"`,
+		`        listen_port = 2222`,
+		`      }`,
+		"",
+		"  They can also be added and removed while the agent runs through its control socket, with the \"agent add-listener\" and \"agent remove-listener\" commands. The listeners' status is shown by \"agent status\".",
+		"",
+		"  Example:",
+		"",
+		`      $ boundary agent -config agent.hcl`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:       "config",
+		Target:     &c.flagConfig,
+		EnvVar:     "BOUNDARY_AGENT_CONFIG",
+		Completion: complete.PredictFiles("*.hcl"),
+		Usage:      "Path to the agent's configuration file. If not set, the agent starts without listeners.",
+	})
+
+	controlSocketFlag(f, &c.flagControlSocket, "Path of the unix socket the agent is controlled through. Overrides the configuration file's control_socket.")
+
+	return set
+}
+
+// controlSocketFlag adds the -control-socket flag to f.
+func controlSocketFlag(f *base.FlagSet, target *string, usage string) {
+	f.StringVar(&base.StringVar{
+		Name:       "control-socket",
+		Target:     target,
+		EnvVar:     "BOUNDARY_AGENT_CONTROL_SOCKET",
+		Completion: complete.PredictFiles("*"),
+		Usage:      fmt.Sprintf("%s Defaults to %s.", usage, DefaultControlSocket()),
+	})
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	config := &Config{RenewBeforeDur: defaultRenewBefore}
+	if c.flagConfig != "" {
+		var err error
+		config, err = LoadConfig(c.flagConfig)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error loading configuration: %w", err))
+			return base.CommandUserError
+		}
+	}
+	socketPath := c.flagControlSocket
+	if socketPath == "" {
+		socketPath = config.ControlSocket
+	}
+	if socketPath == "" {
+		socketPath = DefaultControlSocket()
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %s", err))
+		return base.CommandCliError
+	}
+	if c.FlagToken == "" && c.FlagRecoveryConfig == "" && os.Getenv(api.EnvBoundaryToken) == "" && strings.ToLower(c.FlagKeyringType) != base.NoneKeyring {
+		c.keyringType, c.tokenName, err = c.DiscoverKeyringTokenInfo()
+		if err != nil {
+			c.PrintCliError(err)
+			return base.CommandCliError
+		}
+		c.refreshToken = c.keyringType != ""
+	}
+
+	controlListener, err := listenControlSocket(socketPath)
+	if err != nil {
+		c.PrintCliError(err)
+		return base.CommandCliError
+	}
+	defer os.Remove(socketPath)
+
+	a := newAgent(c.Context, c.authorizeFunc(client), config.RenewBeforeDur, c.outputEvent)
+	defer a.close()
+	for _, l := range config.Listeners {
+		if _, err := a.addListener(l); err != nil {
+			c.PrintCliError(err)
+			controlListener.Close()
+			return base.CommandCliError
+		}
+	}
+
+	srv := &http.Server{Handler: a.handler()}
+	srvErr := make(chan error, 1)
+	go func() {
+		srvErr <- srv.Serve(controlListener)
+	}()
+
+	c.outputStatus(socketPath, a.status())
+
+	select {
+	case <-c.Context.Done():
+	case err := <-srvErr:
+		c.PrintCliError(fmt.Errorf("Error serving control socket: %w", err))
+		return base.CommandCliError
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		c.PrintCliError(fmt.Errorf("Error shutting down control socket: %w", err))
+	}
+	return base.CommandSuccess
+}
+
+// authorizeFunc returns a function authorizing sessions to the target of a
+// listener.
+func (c *Command) authorizeFunc(client *api.Client) authorizeFunc {
+	var tokenMu sync.Mutex
+	targetClient := targets.NewClient(client)
+	return func(ctx context.Context, l *ListenerConfig) (session, error) {
+		if c.refreshToken {
+			tokenMu.Lock()
+			if authToken := c.ReadTokenFromKeyring(c.keyringType, c.tokenName); authToken != nil {
+				client.SetToken(authToken.Token)
+			}
+			tokenMu.Unlock()
+		}
+
+		var opts []targets.Option
+		if l.HostId != "" {
+			opts = append(opts, targets.WithHostId(l.HostId))
+		}
+		if l.TargetName != "" {
+			opts = append(opts, targets.WithName(l.TargetName))
+		}
+		if l.TargetScopeId != "" {
+			opts = append(opts, targets.WithScopeId(l.TargetScopeId))
+		}
+		if l.TargetScopeName != "" {
+			opts = append(opts, targets.WithScopeName(l.TargetScopeName))
+		}
+		sar, err := targetClient.AuthorizeSession(ctx, l.TargetId, opts...)
+		if err != nil {
+			if apiErr := api.AsServerError(err); apiErr != nil {
+				return nil, fmt.Errorf("error from controller when authorizing a session: %s", apiErr.Message)
+			}
+			return nil, fmt.Errorf("error authorizing a session: %w", err)
+		}
+		sess, err := apiproxy.New(ctx, sar.GetItem().(*targets.SessionAuthorization))
+		if err != nil {
+			return nil, fmt.Errorf("error using session authorization: %w", err)
+		}
+		return sess, nil
+	}
+}
+
+func (c *Command) outputStatus(socketPath string, status *Status) {
+	c.outputMu.Lock()
+	defer c.outputMu.Unlock()
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(base.WrapForHelpText([]string{"", fmt.Sprintf("Agent control socket: %s", socketPath)}))
+		c.UI.Output(generateStatusTableOutput(status))
+	case "json":
+		out, err := json.Marshal(&struct {
+			ControlSocket string `json:"control_socket"`
+			*Status
+		}{socketPath, status})
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("error marshaling agent status: %w", err))
+			return
+		}
+		c.UI.Output(string(out))
+	}
+}
+
+func (c *Command) outputEvent(ev *Event) {
+	c.outputMu.Lock()
+	defer c.outputMu.Unlock()
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateEventTableOutput(ev))
+	case "json":
+		out, err := json.Marshal(ev)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("error marshaling agent event: %w", err))
+			return
+		}
+		c.UI.Output(string(out))
+	}
+}
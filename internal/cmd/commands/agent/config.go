@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/hcl"
+)
+
+const defaultRenewBefore = time.Minute
+
+// Config is the agent's configuration file.
+type Config struct {
+	// ControlSocket is the path of the unix socket the agent is controlled
+	// through.
+	ControlSocket string `hcl:"control_socket"`
+
+	// RenewBefore is how long before a session expires a new one is
+	// authorized for its listener, e.g. "5m".  Defaults to 1m.
+	RenewBefore    string        `hcl:"renew_before"`
+	RenewBeforeDur time.Duration `hcl:"-"`
+
+	Listeners []*ListenerConfig `hcl:"listener"`
+}
+
+// ListenerConfig describes a named local listener whose connections are
+// proxied to a target.
+type ListenerConfig struct {
+	Name string `hcl:",key" json:"name"`
+
+	// The target is given by its id or by its name and scope.
+	TargetId        string `hcl:"target_id" json:"target_id,omitempty"`
+	TargetName      string `hcl:"target_name" json:"target_name,omitempty"`
+	TargetScopeId   string `hcl:"target_scope_id" json:"target_scope_id,omitempty"`
+	TargetScopeName string `hcl:"target_scope_name" json:"target_scope_name,omitempty"`
+	HostId          string `hcl:"host_id" json:"host_id,omitempty"`
+
+	// ListenAddr is the IP address to listen on, by default 127.0.0.1.
+	ListenAddr string `hcl:"listen_addr" json:"listen_addr,omitempty"`
+	// ListenPort is the port to listen on, by default a random one.
+	ListenPort int `hcl:"listen_port" json:"listen_port,omitempty"`
+}
+
+// target returns a description of the listener's target.
+func (l *ListenerConfig) target() string {
+	if l.TargetId != "" {
+		return l.TargetId
+	}
+	return l.TargetName
+}
+
+func (l *ListenerConfig) validate() error {
+	switch {
+	case l.Name == "":
+		return errors.New("listener is missing its name")
+	case l.TargetId == "" && l.TargetName == "":
+		return fmt.Errorf("listener %q must have a target_id or target_name", l.Name)
+	case l.TargetId != "" && (l.TargetName != "" || l.TargetScopeId != "" || l.TargetScopeName != ""):
+		return fmt.Errorf("listener %q can't have a target_id and also target_name, target_scope_id or target_scope_name", l.Name)
+	case l.TargetName != "" && l.TargetScopeId == "" && l.TargetScopeName == "":
+		return fmt.Errorf("listener %q must have a target_scope_id or target_scope_name with its target_name", l.Name)
+	case l.TargetScopeId != "" && l.TargetScopeName != "":
+		return fmt.Errorf("listener %q can't have both a target_scope_id and target_scope_name", l.Name)
+	case l.ListenPort < 0 || l.ListenPort > 65535:
+		return fmt.Errorf("listener %q has invalid listen_port %d", l.Name, l.ListenPort)
+	}
+	if l.ListenAddr == "" {
+		l.ListenAddr = "127.0.0.1"
+	}
+	if net.ParseIP(l.ListenAddr) == nil {
+		return fmt.Errorf("listener %q has invalid listen_addr %q, which must be an IP address", l.Name, l.ListenAddr)
+	}
+	return nil
+}
+
+// LoadConfig loads the agent's configuration file at path.
+func LoadConfig(path string) (*Config, error) {
+	d, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+	return ParseConfig(string(d))
+}
+
+// ParseConfig parses the agent's configuration.
+func ParseConfig(d string) (*Config, error) {
+	c := new(Config)
+	if err := hcl.Decode(c, d); err != nil {
+		return nil, fmt.Errorf("error parsing config: %w", err)
+	}
+	c.RenewBeforeDur = defaultRenewBefore
+	if c.RenewBefore != "" {
+		dur, err := time.ParseDuration(c.RenewBefore)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing renew_before: %w", err)
+		}
+		if dur <= 0 {
+			return nil, errors.New("renew_before must be positive")
+		}
+		c.RenewBeforeDur = dur
+	}
+	names := make(map[string]bool, len(c.Listeners))
+	for _, l := range c.Listeners {
+		if err := l.validate(); err != nil {
+			return nil, err
+		}
+		if names[l.Name] {
+			return nil, fmt.Errorf("listener %q is declared more than once", l.Name)
+		}
+		names[l.Name] = true
+	}
+	return c, nil
+}
+
+// DefaultControlSocket returns the path of the agent's control socket when
+// it's not configured. It's in a directory of the user's rather than a shared
+// one, so other users can't connect to it or take its place: under
+// $XDG_RUNTIME_DIR if set, and otherwise under the user's home directory. It's
+// empty if neither can be found.
+func DefaultControlSocket() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "boundary", "agent.sock")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".boundary", "agent.sock")
+}
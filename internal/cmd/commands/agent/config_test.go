@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c, err := ParseConfig(`
+control_socket = "/tmp/agent.sock"
+renew_before   = "5m"
+
+listener "ssh" {
+  target_id   = "ttcp_1234567890"
+  listen_port = 2222
+}
+
+listener "db" {
+  target_name       = "postgres"
+  target_scope_name = "prod"
+  listen_addr       = "127.0.0.2"
+}
+`)
+		require.NoError(err)
+		assert.Equal("/tmp/agent.sock", c.ControlSocket)
+		assert.Equal(5*time.Minute, c.RenewBeforeDur)
+		require.Len(c.Listeners, 2)
+		assert.Equal(&ListenerConfig{Name: "ssh", TargetId: "ttcp_1234567890", ListenAddr: "127.0.0.1", ListenPort: 2222}, c.Listeners[0])
+		assert.Equal(&ListenerConfig{Name: "db", TargetName: "postgres", TargetScopeName: "prod", ListenAddr: "127.0.0.2"}, c.Listeners[1])
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		c, err := ParseConfig(``)
+		require.NoError(t, err)
+		assert.Equal(t, defaultRenewBefore, c.RenewBeforeDur)
+		assert.Empty(t, c.Listeners)
+	})
+
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:    "invalid renew_before",
+			config:  `renew_before = "soon"`,
+			wantErr: "error parsing renew_before",
+		},
+		{
+			name:    "missing target",
+			config:  `listener "a" {}`,
+			wantErr: "must have a target_id or target_name",
+		},
+		{
+			name:    "id and name",
+			config:  `listener "a" { target_id = "ttcp_1234567890" target_name = "a" }`,
+			wantErr: "can't have a target_id and also",
+		},
+		{
+			name:    "name without scope",
+			config:  `listener "a" { target_name = "a" }`,
+			wantErr: "must have a target_scope_id or target_scope_name",
+		},
+		{
+			name:    "invalid address",
+			config:  `listener "a" { target_id = "ttcp_1234567890" listen_addr = "localhost" }`,
+			wantErr: "must be an IP address",
+		},
+		{
+			name:    "duplicate",
+			config:  `listener "a" { target_id = "ttcp_1234567890" } listener "a" { target_id = "ttcp_1234567890" }`,
+			wantErr: "declared more than once",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfig(tt.config)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestDefaultControlSocket(t *testing.T) {
+	oldRuntimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	defer os.Setenv("XDG_RUNTIME_DIR", oldRuntimeDir)
+
+	require.NoError(t, os.Setenv("XDG_RUNTIME_DIR", "/run/user/1000"))
+	assert.Equal(t, filepath.Join("/run/user/1000", "boundary", "agent.sock"), DefaultControlSocket())
+
+	require.NoError(t, os.Unsetenv("XDG_RUNTIME_DIR"))
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".boundary", "agent.sock"), DefaultControlSocket())
+}
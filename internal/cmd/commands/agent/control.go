@@ -0,0 +1,311 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event is something that happened to one of the agent's listeners.
+type Event struct {
+	Event      string     `json:"event"`
+	Listener   string     `json:"listener"`
+	Target     string     `json:"target"`
+	SessionId  string     `json:"session_id,omitempty"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// ListenerStatus is the status of one of the agent's listeners.
+type ListenerStatus struct {
+	ListenerConfig
+	// Address and Port are where the listener is listening.
+	Address           string     `json:"address"`
+	Port              int        `json:"port"`
+	SessionId         string     `json:"session_id,omitempty"`
+	SessionExpiration *time.Time `json:"session_expiration,omitempty"`
+	ConnectionsLeft   *int32     `json:"connections_left,omitempty"`
+	ActiveConnections int        `json:"active_connections"`
+	LastError         string     `json:"last_error,omitempty"`
+}
+
+// Status is the status of the agent.
+type Status struct {
+	StartTime time.Time         `json:"start_time"`
+	Listeners []*ListenerStatus `json:"listeners"`
+}
+
+// agent manages the listeners of a running agent.
+type agent struct {
+	ctx         context.Context
+	authorize   authorizeFunc
+	renewBefore time.Duration
+	onEvent     func(*Event)
+	startTime   time.Time
+
+	mu        sync.Mutex
+	listeners map[string]*listener
+	closed    bool
+}
+
+func newAgent(ctx context.Context, authorize authorizeFunc, renewBefore time.Duration, onEvent func(*Event)) *agent {
+	return &agent{
+		ctx:         ctx,
+		authorize:   authorize,
+		renewBefore: renewBefore,
+		onEvent:     onEvent,
+		startTime:   time.Now(),
+		listeners:   make(map[string]*listener),
+	}
+}
+
+var (
+	errListenerExists   = errors.New("a listener with that name already exists")
+	errListenerNotFound = errors.New("listener not found")
+)
+
+// addListener starts a new listener.
+func (a *agent) addListener(config *ListenerConfig) (*ListenerStatus, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return nil, errors.New("agent is shutting down")
+	}
+	if _, ok := a.listeners[config.Name]; ok {
+		return nil, errListenerExists
+	}
+	l, err := newListener(a.ctx, config, a.authorize, a.renewBefore, a.onEvent)
+	if err != nil {
+		return nil, err
+	}
+	a.listeners[config.Name] = l
+	return l.status(), nil
+}
+
+// removeListener stops a listener, closing its sessions.
+func (a *agent) removeListener(name string) error {
+	a.mu.Lock()
+	l, ok := a.listeners[name]
+	delete(a.listeners, name)
+	a.mu.Unlock()
+	if !ok {
+		return errListenerNotFound
+	}
+	l.close()
+	return nil
+}
+
+func (a *agent) status() *Status {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	ret := &Status{
+		StartTime: a.startTime,
+		Listeners: make([]*ListenerStatus, 0, len(a.listeners)),
+	}
+	for _, l := range a.listeners {
+		ret.Listeners = append(ret.Listeners, l.status())
+	}
+	sort.Slice(ret.Listeners, func(i, j int) bool {
+		return ret.Listeners[i].Name < ret.Listeners[j].Name
+	})
+	return ret
+}
+
+// close stops all listeners.
+func (a *agent) close() {
+	a.mu.Lock()
+	a.closed = true
+	listeners := a.listeners
+	a.listeners = make(map[string]*listener)
+	a.mu.Unlock()
+	wg := new(sync.WaitGroup)
+	for _, l := range listeners {
+		wg.Add(1)
+		go func(l *listener) {
+			defer wg.Done()
+			l.close()
+		}(l)
+	}
+	wg.Wait()
+}
+
+// The agent is controlled with a small HTTP API served on a unix socket:
+//
+//	GET    /v1/status           returns the agent's Status
+//	POST   /v1/listeners        adds the listener given by a ListenerConfig
+//	DELETE /v1/listeners/<name> removes a listener
+const listenersPath = "/v1/listeners"
+
+type controlError struct {
+	Error string `json:"error"`
+}
+
+func writeControlJson(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeControlError(w http.ResponseWriter, status int, err error) {
+	writeControlJson(w, status, &controlError{Error: err.Error()})
+}
+
+func (a *agent) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeControlError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		writeControlJson(w, http.StatusOK, a.status())
+	})
+	mux.HandleFunc(listenersPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeControlError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		config := new(ListenerConfig)
+		if err := json.NewDecoder(r.Body).Decode(config); err != nil {
+			writeControlError(w, http.StatusBadRequest, fmt.Errorf("error decoding listener: %w", err))
+			return
+		}
+		status, err := a.addListener(config)
+		switch {
+		case err == errListenerExists:
+			writeControlError(w, http.StatusConflict, err)
+		case err != nil:
+			writeControlError(w, http.StatusBadRequest, err)
+		default:
+			writeControlJson(w, http.StatusCreated, status)
+		}
+	})
+	mux.HandleFunc(listenersPath+"/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			writeControlError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		switch err := a.removeListener(strings.TrimPrefix(r.URL.Path, listenersPath+"/")); {
+		case err == errListenerNotFound:
+			writeControlError(w, http.StatusNotFound, err)
+		case err != nil:
+			writeControlError(w, http.StatusInternalServerError, err)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	return mux
+}
+
+// listenControlSocket listens on the unix socket at path, replacing a stale
+// socket left by an agent which didn't exit cleanly. A missing directory for
+// the socket is created so only the user can access it.
+func listenControlSocket(path string) (net.Listener, error) {
+	if path == "" {
+		return nil, errors.New("no control socket path, set one with -control-socket")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("error creating control socket directory: %w", err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("an agent is already running with control socket %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("error removing stale control socket: %w", err)
+		}
+	}
+	l, err := listenUnix(path)
+	if err != nil {
+		return nil, fmt.Errorf("error listening on control socket: %w", err)
+	}
+	return l, nil
+}
+
+// controlClient makes requests of a running agent.
+type controlClient struct {
+	path   string
+	client *http.Client
+}
+
+func newControlClient(path string) *controlClient {
+	return &controlClient{
+		path: path,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", path)
+				},
+			},
+		},
+	}
+}
+
+// do makes a request of the agent, decoding its response into out if it's
+// not nil.
+func (c *controlClient) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, "http://agent"+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error connecting to the agent's control socket %s; is the agent running? %w", c.path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		var cerr controlError
+		if err := json.NewDecoder(resp.Body).Decode(&cerr); err != nil || cerr.Error == "" {
+			return fmt.Errorf("agent returned status %s", resp.Status)
+		}
+		return errors.New(cerr.Error)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("error decoding agent response: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *controlClient) status(ctx context.Context) (*Status, error) {
+	ret := new(Status)
+	if err := c.do(ctx, http.MethodGet, "/v1/status", nil, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (c *controlClient) addListener(ctx context.Context, config *ListenerConfig) (*ListenerStatus, error) {
+	ret := new(ListenerStatus)
+	if err := c.do(ctx, http.MethodPost, listenersPath, config, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (c *controlClient) removeListener(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, listenersPath+"/"+url.PathEscape(name), nil, nil)
+}
@@ -0,0 +1,17 @@
+// +build !windows
+
+package agent
+
+import (
+	"net"
+	"syscall"
+)
+
+// listenUnix listens on a unix socket at path which only the user can
+// connect to. The socket is created with its final mode, so there's no window
+// in which others can connect to it.
+func listenUnix(path string) (net.Listener, error) {
+	oldMask := syscall.Umask(0o177)
+	defer syscall.Umask(oldMask)
+	return net.Listen("unix", path)
+}
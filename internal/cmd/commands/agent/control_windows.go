@@ -0,0 +1,11 @@
+// +build windows
+
+package agent
+
+import "net"
+
+// listenUnix listens on a unix socket at path. Windows has no umask; the
+// socket gets the permissions inherited from its directory.
+func listenUnix(path string) (net.Listener, error) {
+	return net.Listen("unix", path)
+}
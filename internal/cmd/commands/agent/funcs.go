@@ -0,0 +1,83 @@
+package agent
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func generateStatusTableOutput(in *Status) string {
+	if len(in.Listeners) == 0 {
+		return "No listeners found"
+	}
+	ret := []string{"", "Listener information:"}
+	for i, l := range in.Listeners {
+		if i > 0 {
+			ret = append(ret, "")
+		}
+		ret = append(ret, listenerTableLines(l)...)
+	}
+	return base.WrapForHelpText(ret)
+}
+
+func generateListenerTableOutput(in *ListenerStatus) string {
+	ret := []string{"", "Listener information:"}
+	ret = append(ret, listenerTableLines(in)...)
+	return base.WrapForHelpText(ret)
+}
+
+func listenerTableLines(in *ListenerStatus) []string {
+	nonAttributeMap := map[string]interface{}{
+		"Address":            in.Address,
+		"Port":               in.Port,
+		"Target":             in.target(),
+		"Active Connections": in.ActiveConnections,
+	}
+	if in.SessionId != "" {
+		nonAttributeMap["Session ID"] = in.SessionId
+	}
+	if in.SessionExpiration != nil {
+		nonAttributeMap["Session Expiration"] = in.SessionExpiration.Local().Format(time.RFC1123)
+	}
+	if in.ConnectionsLeft != nil && *in.ConnectionsLeft >= 0 {
+		nonAttributeMap["Connections Left"] = *in.ConnectionsLeft
+	}
+	if in.LastError != "" {
+		nonAttributeMap["Last Error"] = in.LastError
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	return []string{
+		fmt.Sprintf("  Name: %s", in.Name),
+		base.WrapMap(4, maxLength+2, nonAttributeMap),
+	}
+}
+
+func generateEventTableOutput(in *Event) string {
+	nonAttributeMap := map[string]interface{}{
+		"Event":    in.Event,
+		"Listener": in.Listener,
+		"Target":   in.Target,
+	}
+	if in.SessionId != "" {
+		nonAttributeMap["Session ID"] = in.SessionId
+	}
+	if in.Expiration != nil {
+		nonAttributeMap["Expiration"] = in.Expiration.Local().Format(time.RFC1123)
+	}
+	if in.Error != "" {
+		nonAttributeMap["Error"] = in.Error
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Agent event:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	return base.WrapForHelpText(ret)
+}
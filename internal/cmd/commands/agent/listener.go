@@ -0,0 +1,357 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
+)
+
+const (
+	minRetryInterval = 5 * time.Second
+	maxRetryInterval = time.Minute
+)
+
+// session is a session proxied through a worker.  It's implemented by
+// *apiproxy.ClientProxy.
+type session interface {
+	Dial(context.Context) (net.Conn, error)
+	Done() <-chan struct{}
+	Close() error
+	SessionAuthorizationData() *apiproxy.SessionAuthorizationData
+	SessionExpiration() time.Time
+	ConnectionsLeft() int32
+}
+
+// authorizeFunc authorizes a new session for the listener.  The session's
+// connections are closed when ctx is canceled.
+type authorizeFunc func(ctx context.Context, l *ListenerConfig) (session, error)
+
+// listenerSession is a session of a listener and its use.
+type listenerSession struct {
+	sess    session
+	renewAt time.Time
+
+	// The following are guarded by the listener's mutex.
+	active int
+	// retired is set once the session has been replaced.  It's closed once
+	// it has no active connections.
+	retired bool
+}
+
+func (s *listenerSession) ended() bool {
+	select {
+	case <-s.sess.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// listener is a local listener whose connections are proxied to its target
+// through a session, which is renewed before it expires or once it has no
+// connections left.
+type listener struct {
+	config      *ListenerConfig
+	authorize   authorizeFunc
+	renewBefore time.Duration
+	onEvent     func(*Event)
+
+	l      net.Listener
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	// renew is signaled when the current session can no longer be used.
+	renew chan struct{}
+
+	mu       sync.Mutex
+	current  *listenerSession
+	sessions map[*listenerSession]struct{}
+	lastErr  error
+	conns    int
+}
+
+// newListener starts listening for the listener's connections.
+func newListener(ctx context.Context, config *ListenerConfig, authorize authorizeFunc, renewBefore time.Duration, onEvent func(*Event)) (*listener, error) {
+	l, err := net.Listen("tcp", net.JoinHostPort(config.ListenAddr, fmt.Sprint(config.ListenPort)))
+	if err != nil {
+		return nil, fmt.Errorf("error starting listener %q: %w", config.Name, err)
+	}
+	ret := &listener{
+		config:      config,
+		authorize:   authorize,
+		renewBefore: renewBefore,
+		onEvent:     onEvent,
+		l:           l,
+		renew:       make(chan struct{}, 1),
+		sessions:    make(map[*listenerSession]struct{}),
+	}
+	ret.ctx, ret.cancel = context.WithCancel(ctx)
+	ret.wg.Add(2)
+	go ret.maintain()
+	go ret.serve()
+	return ret, nil
+}
+
+// addr returns the address the listener is listening on.
+func (l *listener) addr() *net.TCPAddr {
+	return l.l.Addr().(*net.TCPAddr)
+}
+
+func (l *listener) event(ev *Event) {
+	ev.Listener = l.config.Name
+	ev.Target = l.config.target()
+	if l.onEvent != nil {
+		l.onEvent(ev)
+	}
+}
+
+// maintain keeps the listener's session current until the listener is
+// closed.
+func (l *listener) maintain() {
+	defer l.wg.Done()
+	retryInterval := minRetryInterval
+	for {
+		var wait <-chan time.Time
+		var done <-chan struct{}
+		if err := l.renewSession(); err != nil {
+			l.event(&Event{Event: "authorization_failed", Error: err.Error()})
+			wait = time.After(retryInterval)
+			retryInterval *= 2
+			if retryInterval > maxRetryInterval {
+				retryInterval = maxRetryInterval
+			}
+		} else {
+			retryInterval = minRetryInterval
+			l.mu.Lock()
+			cur := l.current
+			l.mu.Unlock()
+			wait = time.After(time.Until(cur.renewAt))
+			done = cur.sess.Done()
+		}
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-wait:
+		case <-done:
+		case <-l.renew:
+		}
+	}
+}
+
+// renewSession authorizes a new session if the current one needs renewing,
+// retiring the current one.
+func (l *listener) renewSession() error {
+	l.mu.Lock()
+	cur := l.current
+	l.mu.Unlock()
+	if cur != nil && !cur.ended() && time.Now().Before(cur.renewAt) {
+		return nil
+	}
+
+	sess, err := l.authorize(l.ctx, l.config)
+	if err != nil {
+		l.mu.Lock()
+		l.lastErr = err
+		l.mu.Unlock()
+		return err
+	}
+	now := time.Now()
+	margin := l.renewBefore
+	if lifetime := sess.SessionExpiration().Sub(now); margin > lifetime/2 {
+		margin = lifetime / 2
+	}
+	next := &listenerSession{
+		sess:    sess,
+		renewAt: sess.SessionExpiration().Add(-margin),
+	}
+
+	l.mu.Lock()
+	if l.ctx.Err() != nil {
+		l.mu.Unlock()
+		sess.Close()
+		return l.ctx.Err()
+	}
+	l.current = next
+	l.sessions[next] = struct{}{}
+	l.lastErr = nil
+	var closeOld bool
+	if cur != nil {
+		cur.retired = true
+		closeOld = l.closableLocked(cur)
+	}
+	l.mu.Unlock()
+
+	exp := sess.SessionExpiration()
+	l.event(&Event{
+		Event:      "session_authorized",
+		SessionId:  sess.SessionAuthorizationData().SessionId,
+		Expiration: &exp,
+	})
+	if closeOld {
+		l.closeSession(cur)
+	}
+	return nil
+}
+
+// closableLocked reports whether s should now be closed, and if so forgets
+// it.  The listener's mutex must be held.
+func (l *listener) closableLocked(s *listenerSession) bool {
+	if !s.retired || s.active > 0 {
+		return false
+	}
+	if _, ok := l.sessions[s]; !ok {
+		return false
+	}
+	delete(l.sessions, s)
+	return true
+}
+
+func (l *listener) closeSession(s *listenerSession) {
+	err := s.sess.Close()
+	ev := &Event{
+		Event:     "session_closed",
+		SessionId: s.sess.SessionAuthorizationData().SessionId,
+	}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	l.event(ev)
+}
+
+// acquire returns the current session with its use counted.
+func (l *listener) acquire() (*listenerSession, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.current == nil {
+		if l.lastErr != nil {
+			return nil, fmt.Errorf("no session is available: %w", l.lastErr)
+		}
+		return nil, errors.New("no session is available yet")
+	}
+	l.current.active++
+	l.conns++
+	return l.current, nil
+}
+
+// release marks a use of s as finished.
+func (l *listener) release(s *listenerSession) {
+	l.mu.Lock()
+	s.active--
+	l.conns--
+	closeSess := l.closableLocked(s)
+	l.mu.Unlock()
+	if closeSess {
+		l.closeSession(s)
+	}
+}
+
+func (l *listener) serve() {
+	defer l.wg.Done()
+	for {
+		conn, err := l.l.Accept()
+		if err != nil {
+			if l.ctx.Err() != nil {
+				return
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Temporary() {
+				continue
+			}
+			l.event(&Event{Event: "listener_failed", Error: err.Error()})
+			return
+		}
+		l.wg.Add(1)
+		go func() {
+			defer l.wg.Done()
+			if err := l.handleConn(conn); err != nil {
+				l.event(&Event{Event: "connection_failed", Error: err.Error()})
+			}
+		}()
+	}
+}
+
+func (l *listener) handleConn(conn net.Conn) error {
+	defer conn.Close()
+	s, err := l.acquire()
+	if err != nil {
+		return err
+	}
+	defer l.release(s)
+	remote, err := s.sess.Dial(l.ctx)
+	if err != nil {
+		if s.ended() {
+			select {
+			case l.renew <- struct{}{}:
+			default:
+			}
+		}
+		return err
+	}
+	defer remote.Close()
+
+	copyWg := new(sync.WaitGroup)
+	copyWg.Add(2)
+	go func() {
+		defer copyWg.Done()
+		_, _ = io.Copy(remote, conn)
+		remote.Close()
+		conn.Close()
+	}()
+	go func() {
+		defer copyWg.Done()
+		_, _ = io.Copy(conn, remote)
+		conn.Close()
+		remote.Close()
+	}()
+	copyWg.Wait()
+	return nil
+}
+
+// status returns the listener's status.
+func (l *listener) status() *ListenerStatus {
+	addr := l.addr()
+	ret := &ListenerStatus{
+		ListenerConfig: *l.config,
+		Address:        addr.IP.String(),
+		Port:           addr.Port,
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ret.ActiveConnections = l.conns
+	if l.lastErr != nil {
+		ret.LastError = l.lastErr.Error()
+	}
+	if l.current != nil {
+		exp := l.current.sess.SessionExpiration()
+		ret.SessionId = l.current.sess.SessionAuthorizationData().SessionId
+		ret.SessionExpiration = &exp
+		left := l.current.sess.ConnectionsLeft()
+		ret.ConnectionsLeft = &left
+	}
+	return ret
+}
+
+// close stops the listener and closes its sessions, including their
+// connections.
+func (l *listener) close() {
+	l.cancel()
+	l.l.Close()
+	l.mu.Lock()
+	var sessions []*listenerSession
+	for s := range l.sessions {
+		sessions = append(sessions, s)
+	}
+	l.sessions = make(map[*listenerSession]struct{})
+	l.current = nil
+	l.mu.Unlock()
+	for _, s := range sessions {
+		l.closeSession(s)
+	}
+	l.wg.Wait()
+}
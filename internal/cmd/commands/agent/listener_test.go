@@ -0,0 +1,198 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession is a session whose connections echo what's written to them.
+type testSession struct {
+	id         string
+	expiration time.Time
+	done       chan struct{}
+	doneOnce   sync.Once
+	closed     int32
+}
+
+func (s *testSession) Dial(context.Context) (net.Conn, error) {
+	select {
+	case <-s.done:
+		return nil, apiproxy.ErrSessionExpired
+	default:
+	}
+	local, remote := net.Pipe()
+	go func() {
+		_, _ = io.Copy(remote, remote)
+		remote.Close()
+	}()
+	return local, nil
+}
+
+func (s *testSession) Done() <-chan struct{} { return s.done }
+
+func (s *testSession) Close() error {
+	atomic.AddInt32(&s.closed, 1)
+	s.doneOnce.Do(func() { close(s.done) })
+	return nil
+}
+
+func (s *testSession) SessionAuthorizationData() *apiproxy.SessionAuthorizationData {
+	return &apiproxy.SessionAuthorizationData{SessionId: s.id}
+}
+
+func (s *testSession) SessionExpiration() time.Time { return s.expiration }
+
+func (s *testSession) ConnectionsLeft() int32 { return -1 }
+
+func (s *testSession) isClosed() bool { return atomic.LoadInt32(&s.closed) > 0 }
+
+// testAuthorizer authorizes testSessions lasting lifetime.
+type testAuthorizer struct {
+	lifetime time.Duration
+
+	mu       sync.Mutex
+	sessions []*testSession
+}
+
+func (a *testAuthorizer) authorize(_ context.Context, l *ListenerConfig) (session, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s := &testSession{
+		id:         fmt.Sprintf("s_%d", len(a.sessions)),
+		expiration: time.Now().Add(a.lifetime),
+		done:       make(chan struct{}),
+	}
+	a.sessions = append(a.sessions, s)
+	return s, nil
+}
+
+func (a *testAuthorizer) authorized() []*testSession {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]*testSession(nil), a.sessions...)
+}
+
+func testEcho(t *testing.T, conn net.Conn, msg string) {
+	t.Helper()
+	_, err := conn.Write([]byte(msg))
+	require.NoError(t, err)
+	buf := make([]byte, len(msg))
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, msg, string(buf))
+}
+
+func TestListener(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	a := &testAuthorizer{lifetime: 2 * time.Second}
+	config := &ListenerConfig{Name: "ssh", TargetId: "ttcp_1234567890", ListenAddr: "127.0.0.1"}
+	l, err := newListener(context.Background(), config, a.authorize, time.Minute, nil)
+	require.NoError(err)
+	defer l.close()
+
+	require.Eventually(func() bool { return l.status().SessionId == "s_0" }, time.Second, 10*time.Millisecond)
+	conn, err := net.Dial("tcp", l.addr().String())
+	require.NoError(err)
+	defer conn.Close()
+	testEcho(t, conn, "hello")
+	assert.Equal(1, l.status().ActiveConnections)
+
+	// As the renewal margin is more than half the session's lifetime, the
+	// session is renewed halfway through it.
+	require.Eventually(func() bool { return l.status().SessionId == "s_1" }, 3*time.Second, 10*time.Millisecond)
+	sessions := a.authorized()
+	// The retired session is kept until its connection is closed.
+	assert.False(sessions[0].isClosed())
+	testEcho(t, conn, "still here")
+
+	conn2, err := net.Dial("tcp", l.addr().String())
+	require.NoError(err)
+	testEcho(t, conn2, "new session")
+	conn2.Close()
+
+	conn.Close()
+	assert.Eventually(sessions[0].isClosed, time.Second, 10*time.Millisecond)
+	assert.False(sessions[1].isClosed())
+
+	l.close()
+	for _, s := range a.authorized() {
+		assert.True(s.isClosed())
+	}
+}
+
+func TestControl(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "boundary-agent")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "run", "agent.sock")
+
+	a := newAgent(ctx, (&testAuthorizer{lifetime: time.Hour}).authorize, time.Minute, nil)
+	defer a.close()
+	cl, err := listenControlSocket(socketPath)
+	require.NoError(err)
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(filepath.Dir(socketPath))
+		require.NoError(err)
+		assert.Equal(os.FileMode(0o700), fi.Mode().Perm(), "the socket's directory is private")
+		fi, err = os.Stat(socketPath)
+		require.NoError(err)
+		assert.Equal(os.FileMode(0o600), fi.Mode().Perm(), "only the user can connect to the socket")
+	}
+	srv := &http.Server{Handler: a.handler()}
+	go srv.Serve(cl)
+	defer srv.Close()
+
+	_, err = listenControlSocket(socketPath)
+	assert.Error(err, "a second agent can't use the socket")
+
+	client := newControlClient(socketPath)
+	status, err := client.status(ctx)
+	require.NoError(err)
+	assert.Empty(status.Listeners)
+
+	ls, err := client.addListener(ctx, &ListenerConfig{Name: "ssh", TargetId: "ttcp_1234567890"})
+	require.NoError(err)
+	assert.Equal("127.0.0.1", ls.Address)
+	assert.NotZero(ls.Port)
+
+	_, err = client.addListener(ctx, &ListenerConfig{Name: "ssh", TargetId: "ttcp_1234567890"})
+	require.Error(err)
+	assert.Equal(errListenerExists.Error(), err.Error())
+	_, err = client.addListener(ctx, &ListenerConfig{Name: "db"})
+	assert.Error(err)
+
+	require.Eventually(func() bool {
+		status, err := client.status(ctx)
+		require.NoError(err)
+		require.Len(status.Listeners, 1)
+		return status.Listeners[0].SessionId != ""
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(client.removeListener(ctx, "ssh"))
+	err = client.removeListener(ctx, "ssh")
+	require.Error(err)
+	assert.Equal(errListenerNotFound.Error(), err.Error())
+	status, err = client.status(ctx)
+	require.NoError(err)
+	assert.Empty(status.Listeners)
+
+	_, err = newControlClient(filepath.Join(dir, "missing.sock")).status(ctx)
+	assert.Error(err)
+}
@@ -0,0 +1,178 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ListenerCommand)(nil)
+	_ cli.CommandAutocomplete = (*ListenerCommand)(nil)
+)
+
+// ListenerCommand adds listeners to, and removes them from, a running agent.
+type ListenerCommand struct {
+	*base.Command
+
+	Func string
+
+	flagControlSocket string
+	flagListener      ListenerConfig
+}
+
+func (c *ListenerCommand) Synopsis() string {
+	switch c.Func {
+	case "add-listener":
+		return "Add a listener to a running agent"
+	case "remove-listener":
+		return "Remove a listener from a running agent"
+	}
+	return ""
+}
+
+func (c *ListenerCommand) Help() string {
+	switch c.Func {
+	case "add-listener":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary agent add-listener [options]",
+			"",
+			"  Add a listener to a running agent. The listener isn't added to the agent's configuration file, so lasts until the agent exits.",
+			"",
+			"  Example:",
+			"",
+			`      $ boundary agent add-listener -name ssh-prod -target-id ttcp_1234567890 -listen-port 2222`,
+			"",
+			"",
+		}) + c.Flags().Help()
+	case "remove-listener":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary agent remove-listener [options]",
+			"",
+			"  Remove a listener from a running agent, canceling its sessions and closing their connections.",
+			"",
+			"  Example:",
+			"",
+			`      $ boundary agent remove-listener -name ssh-prod`,
+			"",
+			"",
+		}) + c.Flags().Help()
+	}
+	return ""
+}
+
+func (c *ListenerCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	controlSocketFlag(f, &c.flagControlSocket, "Path of the running agent's control socket.")
+
+	f.StringVar(&base.StringVar{
+		Name:   "name",
+		Target: &c.flagListener.Name,
+		Usage:  "The name of the listener.",
+	})
+
+	if c.Func == "add-listener" {
+		f.StringVar(&base.StringVar{
+			Name:   "target-id",
+			Target: &c.flagListener.TargetId,
+			Usage:  "The ID of the target to proxy connections to.",
+		})
+		f.StringVar(&base.StringVar{
+			Name:   "target-name",
+			Target: &c.flagListener.TargetName,
+			Usage:  "The name of the target to proxy connections to, with -target-scope-id or -target-scope-name.",
+		})
+		f.StringVar(&base.StringVar{
+			Name:   "target-scope-id",
+			Target: &c.flagListener.TargetScopeId,
+			Usage:  "The ID of the scope of the target given by -target-name.",
+		})
+		f.StringVar(&base.StringVar{
+			Name:   "target-scope-name",
+			Target: &c.flagListener.TargetScopeName,
+			Usage:  "The name of the scope of the target given by -target-name.",
+		})
+		f.StringVar(&base.StringVar{
+			Name:   "host-id",
+			Target: &c.flagListener.HostId,
+			Usage:  "The ID of a specific host to connect to out of the hosts from the target's host sets. If not specified, one is chosen at random.",
+		})
+		f.StringVar(&base.StringVar{
+			Name:   "listen-addr",
+			Target: &c.flagListener.ListenAddr,
+			Usage:  "The IP address to listen on. Defaults to 127.0.0.1.",
+		})
+		f.IntVar(&base.IntVar{
+			Name:   "listen-port",
+			Target: &c.flagListener.ListenPort,
+			Usage:  "The port to listen on. If not set, a random port is chosen.",
+		})
+	}
+
+	return set
+}
+
+func (c *ListenerCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ListenerCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ListenerCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if c.flagListener.Name == "" {
+		c.PrintCliError(errors.New("Listener name must be provided via -name"))
+		return base.CommandUserError
+	}
+	if c.flagControlSocket == "" {
+		c.flagControlSocket = DefaultControlSocket()
+	}
+	client := newControlClient(c.flagControlSocket)
+
+	switch c.Func {
+	case "add-listener":
+		if err := c.flagListener.validate(); err != nil {
+			c.PrintCliError(err)
+			return base.CommandUserError
+		}
+		status, err := client.addListener(c.Context, &c.flagListener)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error adding listener: %w", err))
+			return base.CommandCliError
+		}
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(generateListenerTableOutput(status))
+		case "json":
+			out, err := json.Marshal(status)
+			if err != nil {
+				c.PrintCliError(fmt.Errorf("error marshaling listener status: %w", err))
+				return base.CommandCliError
+			}
+			c.UI.Output(string(out))
+		}
+
+	case "remove-listener":
+		if err := client.removeListener(c.Context, c.flagListener.Name); err != nil {
+			c.PrintCliError(fmt.Errorf("Error removing listener: %w", err))
+			return base.CommandCliError
+		}
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output("The remove operation completed successfully.")
+		}
+	}
+	return base.CommandSuccess
+}
@@ -0,0 +1,85 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*StatusCommand)(nil)
+	_ cli.CommandAutocomplete = (*StatusCommand)(nil)
+)
+
+type StatusCommand struct {
+	*base.Command
+
+	flagControlSocket string
+}
+
+func (c *StatusCommand) Synopsis() string {
+	return "Show the status of a running agent's listeners"
+}
+
+func (c *StatusCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary agent status [options]",
+		"",
+		"  Show the status of a running agent's listeners: where each is listening, its current session and its active connections.",
+		"",
+		"  Example:",
+		"",
+		`      $ boundary agent status`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *StatusCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	controlSocketFlag(f, &c.flagControlSocket, "Path of the running agent's control socket.")
+	return set
+}
+
+func (c *StatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *StatusCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *StatusCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if c.flagControlSocket == "" {
+		c.flagControlSocket = DefaultControlSocket()
+	}
+
+	status, err := newControlClient(c.flagControlSocket).status(c.Context)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error reading agent status: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateStatusTableOutput(status))
+	case "json":
+		out, err := json.Marshal(status)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("error marshaling agent status: %w", err))
+			return base.CommandCliError
+		}
+		c.UI.Output(string(out))
+	}
+	return base.CommandSuccess
+}
@@ -0,0 +1,240 @@
+package apply
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*Command)(nil)
+	_ cli.CommandAutocomplete = (*Command)(nil)
+)
+
+type Command struct {
+	*base.Command
+
+	flagFiles      []string
+	flagPlan       bool
+	flagPrune      bool
+	flagPruneTypes []string
+}
+
+func (c *Command) Synopsis() string {
+	return "Converge Boundary's resources to their description in configuration files"
+}
+
+func (c *Command) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary apply [options]",
+		"",
+		"  Read descriptions of scopes, auth methods, users, groups, roles, host catalogs, hosts, host sets and targets from HCL, JSON or YAML files, and create or update the resources to match them:",
+		"",
+		"    $ boundary apply -f config/",
+		"",
+		"  Each resource is declared in a block whose label is its key, and refers to other resources by their keys or by ids:",
+		"",
+		`    scope "engineering" {`,
+		`      scope = "global"`,
+		`    }`,
+		"",
+		`    role "engineers" {`,
+		`      scope      = "engineering"`,
+		`      principals = ["u_1234567890"]`,
+		`      grants     = ["id=*;type=*;actions=read,list"]`,
+		`    }`,
+		"",
+		"  A resource's name defaults to its key, and it's matched to an existing resource by name within its scope or host catalog. Attributes the controller doesn't return, such as secrets, are only set when a resource is created.",
+		"",
+		"  To show the changes which would be made without making them:",
+		"",
+		"    $ boundary apply -f config/ -plan",
+		"",
+		"  Updates are made against the versions of the resources read when planning, so a resource modified by someone else in the meantime is not overwritten. With -prune, resources in the declared scopes and host catalogs which aren't themselves declared are deleted.",
+		"",
+		"  For a full list of examples, please see the documentation.",
+	}) + c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:    "file",
+		Aliases: []string{"f"},
+		Target:  &c.flagFiles,
+		Completion: complete.PredictOr(
+			complete.PredictDirs("*"),
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+			complete.PredictFiles("*.yaml"),
+			complete.PredictFiles("*.yml"),
+		),
+		Usage: "A file, or a directory of .hcl, .json, .yaml and .yml files, describing resources. May be specified multiple times.",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "plan",
+		Target: &c.flagPlan,
+		Usage:  "If set, show the changes which would be made without making them.",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "prune",
+		Target: &c.flagPrune,
+		Usage:  "If set, delete resources in the declared scopes and host catalogs which are not themselves declared.",
+	})
+
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:       "prune-type",
+		Target:     &c.flagPruneTypes,
+		Completion: complete.PredictSet(kindNames()...),
+		Usage:      `Restricts -prune to resources of the type, e.g. "role". May be specified multiple times. If not set, resources of all types are pruned.`,
+	})
+
+	return set
+}
+
+func kindNames() []string {
+	ret := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		ret = append(ret, string(k))
+	}
+	return ret
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	prune, err := pruneKinds(c.flagPrune, c.flagPruneTypes)
+	if err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	conf, err := Load(c.flagFiles...)
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error reading configuration: %w", err))
+		return base.CommandUserError
+	}
+	declared, err := conf.resources()
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error in configuration: %w", err))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	p, err := plan(c.Context, declared, &apiSource{client: client}, prune)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when planning changes")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error planning changes: %w", err))
+		return base.CommandCliError
+	}
+
+	applied := 0
+	var applyErr error
+	if !c.flagPlan && len(p.Changes) > 0 {
+		applied, applyErr = newExecutor(client, p).execute(c.Context, p.Changes)
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(struct {
+			*Plan
+			Applied int `json:"applied"`
+		}{Plan: p, Applied: applied})
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error formatting as JSON: %w", err))
+			return base.CommandCliError
+		}
+		c.UI.Output(string(b))
+	default:
+		c.UI.Output(generatePlanTableOutput(p))
+		if !c.flagPlan && applyErr == nil && len(p.Changes) > 0 {
+			c.UI.Output(fmt.Sprintf("\nApplied %d changes.", applied))
+		}
+	}
+
+	if applyErr != nil {
+		if apiErr := api.AsServerError(applyErr); apiErr != nil {
+			c.PrintApiError(apiErr, fmt.Sprintf("Error from controller after applying %d of %d changes: %s", applied, len(p.Changes), applyErr))
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error after applying %d of %d changes: %w", applied, len(p.Changes), applyErr))
+		return base.CommandCliError
+	}
+	return base.CommandSuccess
+}
+
+// pruneKinds returns the kinds of resources to prune, given the -prune and
+// -prune-type flags.
+func pruneKinds(prune bool, types []string) (map[kind]bool, error) {
+	if !prune {
+		if len(types) > 0 {
+			return nil, fmt.Errorf("-prune-type can only be used with -prune")
+		}
+		return nil, nil
+	}
+	ret := make(map[kind]bool, len(kinds))
+	if len(types) == 0 {
+		for _, k := range kinds {
+			ret[k] = true
+		}
+		return ret, nil
+	}
+	valid := make(map[string]bool, len(kinds))
+	for _, k := range kinds {
+		valid[string(k)] = true
+	}
+	for _, t := range types {
+		if !valid[t] {
+			return nil, fmt.Errorf("unknown resource type %q passed to -prune-type; valid types are %s", t, strings.Join(kindNames(), ", "))
+		}
+		ret[kind(t)] = true
+	}
+	return ret, nil
+}
+
+func generatePlanTableOutput(p *Plan) string {
+	if len(p.Changes) == 0 {
+		return "No changes. Resources match their configuration."
+	}
+	counts := map[action]int{}
+	ret := []string{"", "Changes:"}
+	for _, c := range p.Changes {
+		counts[c.Action]++
+		ret = append(ret, "  "+c.String())
+	}
+	var summary []string
+	for _, a := range []action{actionCreate, actionUpdate, actionDelete} {
+		summary = append(summary, fmt.Sprintf("%d to %s", counts[a], a))
+	}
+	ret = append(ret, "", fmt.Sprintf("Plan: %s.", strings.Join(summary, ", ")))
+	return base.WrapForHelpText(ret)
+}
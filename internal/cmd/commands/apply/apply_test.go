@@ -0,0 +1,28 @@
+package apply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneKinds(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	got, err := pruneKinds(false, nil)
+	require.NoError(err)
+	assert.Nil(got)
+
+	got, err = pruneKinds(true, nil)
+	require.NoError(err)
+	assert.Len(got, len(kinds))
+
+	got, err = pruneKinds(true, []string{"role", "user"})
+	require.NoError(err)
+	assert.Equal(map[kind]bool{kindRole: true, kindUser: true}, got)
+
+	_, err = pruneKinds(false, []string{"role"})
+	assert.Error(err)
+	_, err = pruneKinds(true, []string{"roles"})
+	assert.Error(err)
+}
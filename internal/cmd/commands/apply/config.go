@@ -0,0 +1,444 @@
+package apply
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/hashicorp/hcl"
+)
+
+// Config is the desired state of the resources described by a set of files.
+// Each resource is declared in a block whose label is its key, which other
+// resources use to refer to it, e.g.
+//
+//	scope "engineering" {
+//	  scope = "global"
+//	}
+//
+//	user "alice" {
+//	  scope = "engineering"
+//	}
+//
+// References which are not the key of a declared resource are used as ids of
+// existing resources.  A resource's name defaults to its key, and it's
+// matched to a live resource by name within its scope or host catalog.
+type Config struct {
+	Scopes       []*Scope       `hcl:"scope"`
+	AuthMethods  []*AuthMethod  `hcl:"auth_method"`
+	Users        []*User        `hcl:"user"`
+	Groups       []*Group       `hcl:"group"`
+	Roles        []*Role        `hcl:"role"`
+	HostCatalogs []*HostCatalog `hcl:"host_catalog"`
+	Hosts        []*Host        `hcl:"host"`
+	HostSets     []*HostSet     `hcl:"host_set"`
+	Targets      []*Target      `hcl:"target"`
+}
+
+type Scope struct {
+	Key                     string `hcl:",key"`
+	Name                    string `hcl:"name"`
+	Description             string `hcl:"description"`
+	Scope                   string `hcl:"scope"`
+	SkipAdminRoleCreation   bool   `hcl:"skip_admin_role_creation"`
+	SkipDefaultRoleCreation bool   `hcl:"skip_default_role_creation"`
+}
+
+type AuthMethod struct {
+	Key         string                 `hcl:",key"`
+	Name        string                 `hcl:"name"`
+	Description string                 `hcl:"description"`
+	Scope       string                 `hcl:"scope"`
+	Type        string                 `hcl:"type"`
+	Attributes  map[string]interface{} `hcl:"attributes"`
+}
+
+type User struct {
+	Key         string `hcl:",key"`
+	Name        string `hcl:"name"`
+	Description string `hcl:"description"`
+	Scope       string `hcl:"scope"`
+}
+
+type Group struct {
+	Key         string   `hcl:",key"`
+	Name        string   `hcl:"name"`
+	Description string   `hcl:"description"`
+	Scope       string   `hcl:"scope"`
+	Members     []string `hcl:"members"`
+}
+
+type Role struct {
+	Key         string   `hcl:",key"`
+	Name        string   `hcl:"name"`
+	Description string   `hcl:"description"`
+	Scope       string   `hcl:"scope"`
+	GrantScope  string   `hcl:"grant_scope"`
+	Principals  []string `hcl:"principals"`
+	Grants      []string `hcl:"grants"`
+}
+
+type HostCatalog struct {
+	Key         string                 `hcl:",key"`
+	Name        string                 `hcl:"name"`
+	Description string                 `hcl:"description"`
+	Scope       string                 `hcl:"scope"`
+	Type        string                 `hcl:"type"`
+	Attributes  map[string]interface{} `hcl:"attributes"`
+}
+
+type Host struct {
+	Key         string                 `hcl:",key"`
+	Name        string                 `hcl:"name"`
+	Description string                 `hcl:"description"`
+	HostCatalog string                 `hcl:"host_catalog"`
+	Attributes  map[string]interface{} `hcl:"attributes"`
+}
+
+type HostSet struct {
+	Key         string   `hcl:",key"`
+	Name        string   `hcl:"name"`
+	Description string   `hcl:"description"`
+	HostCatalog string   `hcl:"host_catalog"`
+	Hosts       []string `hcl:"hosts"`
+}
+
+type Target struct {
+	Key                    string                 `hcl:",key"`
+	Name                   string                 `hcl:"name"`
+	Description            string                 `hcl:"description"`
+	Scope                  string                 `hcl:"scope"`
+	Type                   string                 `hcl:"type"`
+	Attributes             map[string]interface{} `hcl:"attributes"`
+	HostSets               []string               `hcl:"host_sets"`
+	SessionMaxSeconds      int                    `hcl:"session_max_seconds"`
+	SessionConnectionLimit int                    `hcl:"session_connection_limit"`
+	WorkerFilter           string                 `hcl:"worker_filter"`
+}
+
+// configExtensions are the extensions of the files read from directories.
+var configExtensions = map[string]bool{
+	".hcl":  true,
+	".json": true,
+	".yaml": true,
+	".yml":  true,
+}
+
+// Load reads the files at paths, and the files with a .hcl, .json, .yaml or
+// .yml extension in the directories at paths, and returns the Config they
+// describe together.
+func Load(paths ...string) (*Config, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no configuration files specified; use -f")
+	}
+	var files []string
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		entries, err := ioutil.ReadDir(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() && configExtensions[strings.ToLower(filepath.Ext(e.Name()))] {
+				files = append(files, filepath.Join(p, e.Name()))
+			}
+		}
+	}
+
+	ret := &Config{}
+	for _, f := range files {
+		d, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		c, err := Parse(f, d)
+		if err != nil {
+			return nil, err
+		}
+		ret.merge(c)
+	}
+	return ret, nil
+}
+
+// Parse parses the contents of the named file.  Files with a .yaml or .yml
+// extension are parsed as YAML, and others as HCL or JSON.
+func Parse(name string, d []byte) (*Config, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		j, err := yaml.YAMLToJSON(d)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", name, err)
+		}
+		d = j
+	}
+	c := &Config{}
+	if err := hcl.Decode(c, string(d)); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", name, err)
+	}
+	return c, nil
+}
+
+func (c *Config) merge(o *Config) {
+	c.Scopes = append(c.Scopes, o.Scopes...)
+	c.AuthMethods = append(c.AuthMethods, o.AuthMethods...)
+	c.Users = append(c.Users, o.Users...)
+	c.Groups = append(c.Groups, o.Groups...)
+	c.Roles = append(c.Roles, o.Roles...)
+	c.HostCatalogs = append(c.HostCatalogs, o.HostCatalogs...)
+	c.Hosts = append(c.Hosts, o.Hosts...)
+	c.HostSets = append(c.HostSets, o.HostSets...)
+	c.Targets = append(c.Targets, o.Targets...)
+}
+
+// kind is the type of a resource, as used for its blocks in the config.
+type kind string
+
+const (
+	kindScope       kind = "scope"
+	kindAuthMethod  kind = "auth_method"
+	kindUser        kind = "user"
+	kindGroup       kind = "group"
+	kindHostCatalog kind = "host_catalog"
+	kindHost        kind = "host"
+	kindHostSet     kind = "host_set"
+	kindTarget      kind = "target"
+	kindRole        kind = "role"
+)
+
+// kinds are the kinds of resources in the order they are created or updated,
+// so a resource is always applied after the resources it refers to.
+// Resources are deleted in the reverse order.
+var kinds = []kind{
+	kindScope,
+	kindAuthMethod,
+	kindUser,
+	kindGroup,
+	kindHostCatalog,
+	kindHost,
+	kindHostSet,
+	kindTarget,
+	kindRole,
+}
+
+// parentKind returns the kind of the resource a resource of kind k is
+// created in.
+func (k kind) parentKind() kind {
+	switch k {
+	case kindHost, kindHostSet:
+		return kindHostCatalog
+	default:
+		return kindScope
+	}
+}
+
+// resource is a declared resource, in the form used to plan changes.
+type resource struct {
+	kind        kind
+	key         string
+	name        string
+	description string
+	// parent refers to the scope or host catalog the resource is in.
+	parent string
+	typ    string
+	// attributes are the type specific attributes of the resource.
+	attributes map[string]interface{}
+	// fields are other fields set on the resource, e.g. a target's
+	// "session_max_seconds".
+	fields map[string]interface{}
+	// refs refer to the resources associated with the resource: a group's
+	// members, a role's principals, a host set's hosts or a target's host
+	// sets.
+	refs []string
+	// refKinds are the kinds refs may refer to.
+	refKinds []kind
+	// grantScope refers to a role's grant scope.
+	grantScope string
+	grants     []string
+}
+
+func (r *resource) String() string {
+	return fmt.Sprintf("%s %q", r.kind, r.key)
+}
+
+// idPattern matches the ids of Boundary's resources.
+var idPattern = regexp.MustCompile(`^(global|[a-z]+_[0-9A-Za-z]+)$`)
+
+// resources validates the config and returns its resources by kind.  Scopes
+// are ordered so each comes after the scope it's in.
+func (c *Config) resources() (map[kind][]*resource, error) {
+	ret := make(map[kind][]*resource, len(kinds))
+	add := func(r *resource) {
+		if r.name == "" {
+			r.name = r.key
+		}
+		ret[r.kind] = append(ret[r.kind], r)
+	}
+	for _, s := range c.Scopes {
+		fields := map[string]interface{}{}
+		if s.SkipAdminRoleCreation {
+			fields["skip_admin_role_creation"] = true
+		}
+		if s.SkipDefaultRoleCreation {
+			fields["skip_default_role_creation"] = true
+		}
+		add(&resource{kind: kindScope, key: s.Key, name: s.Name, description: s.Description, parent: s.Scope, fields: fields})
+	}
+	for _, a := range c.AuthMethods {
+		add(&resource{kind: kindAuthMethod, key: a.Key, name: a.Name, description: a.Description, parent: a.Scope, typ: a.Type, attributes: a.Attributes})
+	}
+	for _, u := range c.Users {
+		add(&resource{kind: kindUser, key: u.Key, name: u.Name, description: u.Description, parent: u.Scope})
+	}
+	for _, g := range c.Groups {
+		add(&resource{kind: kindGroup, key: g.Key, name: g.Name, description: g.Description, parent: g.Scope, refs: g.Members, refKinds: []kind{kindUser}})
+	}
+	for _, hc := range c.HostCatalogs {
+		typ := hc.Type
+		if typ == "" {
+			typ = "static"
+		}
+		add(&resource{kind: kindHostCatalog, key: hc.Key, name: hc.Name, description: hc.Description, parent: hc.Scope, typ: typ, attributes: hc.Attributes})
+	}
+	for _, h := range c.Hosts {
+		add(&resource{kind: kindHost, key: h.Key, name: h.Name, description: h.Description, parent: h.HostCatalog, attributes: h.Attributes})
+	}
+	for _, hs := range c.HostSets {
+		add(&resource{kind: kindHostSet, key: hs.Key, name: hs.Name, description: hs.Description, parent: hs.HostCatalog, refs: hs.Hosts, refKinds: []kind{kindHost}})
+	}
+	for _, t := range c.Targets {
+		typ := t.Type
+		if typ == "" {
+			typ = "tcp"
+		}
+		fields := map[string]interface{}{}
+		if t.SessionMaxSeconds != 0 {
+			fields["session_max_seconds"] = t.SessionMaxSeconds
+		}
+		if t.SessionConnectionLimit != 0 {
+			fields["session_connection_limit"] = t.SessionConnectionLimit
+		}
+		if t.WorkerFilter != "" {
+			fields["worker_filter"] = t.WorkerFilter
+		}
+		add(&resource{kind: kindTarget, key: t.Key, name: t.Name, description: t.Description, parent: t.Scope, typ: typ, attributes: t.Attributes, fields: fields, refs: t.HostSets, refKinds: []kind{kindHostSet}})
+	}
+	for _, r := range c.Roles {
+		add(&resource{kind: kindRole, key: r.Key, name: r.Name, description: r.Description, parent: r.Scope, grantScope: r.GrantScope, grants: r.Grants, refs: r.Principals, refKinds: []kind{kindUser, kindGroup}})
+	}
+
+	keys := make(map[kind]map[string]*resource, len(kinds))
+	names := map[string]*resource{}
+	for _, k := range kinds {
+		keys[k] = map[string]*resource{}
+		for _, r := range ret[k] {
+			if r.key == "" {
+				return nil, fmt.Errorf("%s block is missing its key", k)
+			}
+			if _, ok := keys[k][r.key]; ok {
+				return nil, fmt.Errorf("%s is declared more than once", r)
+			}
+			keys[k][r.key] = r
+			if r.parent == "" {
+				return nil, fmt.Errorf("%s is missing its %s", r, k.parentKind())
+			}
+			if k == kindAuthMethod && r.typ == "" {
+				return nil, fmt.Errorf("%s is missing its type", r)
+			}
+		}
+	}
+	// Keys are unique per kind, but names need only be unique per kind
+	// within the same parent, so they are checked once parents are known to
+	// be valid references.
+	check := func(r *resource, ref string, refKinds ...kind) error {
+		var found []kind
+		for _, k := range refKinds {
+			if _, ok := keys[k][ref]; ok {
+				found = append(found, k)
+			}
+		}
+		switch {
+		case len(found) > 1:
+			return fmt.Errorf("%s refers to %q, which is the key of more than one kind of resource; use its id instead", r, ref)
+		case len(found) == 0 && !idPattern.MatchString(ref):
+			return fmt.Errorf("%s refers to %q, which is neither the key of a declared resource nor an id", r, ref)
+		}
+		return nil
+	}
+	for _, k := range kinds {
+		for _, r := range ret[k] {
+			if err := check(r, r.parent, k.parentKind()); err != nil {
+				return nil, err
+			}
+			n := fmt.Sprintf("%s/%s/%s", k, r.parent, r.name)
+			if o, ok := names[n]; ok {
+				return nil, fmt.Errorf("%s and %s have the same name %q in the same %s", o, r, r.name, k.parentKind())
+			}
+			names[n] = r
+			for _, ref := range r.refs {
+				if err := check(r, ref, r.refKinds...); err != nil {
+					return nil, err
+				}
+			}
+			if r.grantScope != "" {
+				if err := check(r, r.grantScope, kindScope); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	sorted, err := sortScopes(ret[kindScope], keys[kindScope])
+	if err != nil {
+		return nil, err
+	}
+	ret[kindScope] = sorted
+	return ret, nil
+}
+
+// sortScopes orders scopes so each comes after the declared scope it's in,
+// and otherwise by key.
+func sortScopes(scopes []*resource, byKey map[string]*resource) ([]*resource, error) {
+	sort.Slice(scopes, func(i, j int) bool { return scopes[i].key < scopes[j].key })
+	ret := make([]*resource, 0, len(scopes))
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(scopes))
+	var visit func(r *resource) error
+	visit = func(r *resource) error {
+		switch state[r.key] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("%s is within itself", r)
+		}
+		state[r.key] = visiting
+		if p, ok := byKey[r.parent]; ok {
+			if err := visit(p); err != nil {
+				return err
+			}
+		}
+		state[r.key] = done
+		ret = append(ret, r)
+		return nil
+	}
+	for _, s := range scopes {
+		if err := visit(s); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
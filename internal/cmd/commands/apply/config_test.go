@@ -0,0 +1,183 @@
+package apply
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHcl = `
+scope "org" {
+  scope       = "global"
+  description = "The org"
+}
+
+scope "proj" {
+  scope = "org"
+}
+
+user "alice" {
+  scope = "org"
+}
+
+role "readers" {
+  scope      = "proj"
+  principals = ["alice"]
+  grants     = ["id=*;type=*;actions=read"]
+}
+
+host_catalog "static" {
+  scope = "proj"
+}
+
+host "web" {
+  host_catalog = "static"
+  attributes {
+    address = "10.0.0.1"
+  }
+}
+
+target "ssh" {
+  scope               = "proj"
+  session_max_seconds = 3600
+  attributes {
+    default_port = 22
+  }
+}
+`
+
+const testYaml = `
+scope:
+  org:
+    scope: global
+    description: The org
+user:
+  alice:
+    scope: org
+`
+
+func TestParse(t *testing.T) {
+	t.Run("hcl", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c, err := Parse("config.hcl", []byte(testHcl))
+		require.NoError(err)
+		require.Len(c.Scopes, 2)
+		assert.Equal(&Scope{Key: "org", Scope: "global", Description: "The org"}, c.Scopes[0])
+		require.Len(c.Roles, 1)
+		assert.Equal([]string{"alice"}, c.Roles[0].Principals)
+		require.Len(c.Hosts, 1)
+		assert.Equal(map[string]interface{}{"address": "10.0.0.1"}, c.Hosts[0].Attributes)
+		require.Len(c.Targets, 1)
+		assert.Equal(3600, c.Targets[0].SessionMaxSeconds)
+	})
+	t.Run("yaml", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c, err := Parse("config.yaml", []byte(testYaml))
+		require.NoError(err)
+		require.Len(c.Scopes, 1)
+		assert.Equal(&Scope{Key: "org", Scope: "global", Description: "The org"}, c.Scopes[0])
+		require.Len(c.Users, 1)
+		assert.Equal(&User{Key: "alice", Scope: "org"}, c.Users[0])
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Parse("config.hcl", []byte(`scope "org" {`))
+		assert.Error(t, err)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	dir, err := ioutil.TempDir("", "boundary-apply")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "scopes.hcl"), []byte(`scope "org" { scope = "global" }`), 0o600))
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "users.yml"), []byte("user:\n  alice:\n    scope: org\n"), 0o600))
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("not config"), 0o600))
+
+	c, err := Load(dir)
+	require.NoError(err)
+	assert.Len(c.Scopes, 1)
+	assert.Len(c.Users, 1)
+
+	_, err = Load()
+	assert.Error(err)
+	_, err = Load(filepath.Join(dir, "missing.hcl"))
+	assert.Error(err)
+}
+
+func TestConfig_resources(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		c, err := Parse("config.hcl", []byte(testHcl))
+		require.NoError(err)
+		// Declare the project before the org it's in.
+		c.Scopes[0], c.Scopes[1] = c.Scopes[1], c.Scopes[0]
+		res, err := c.resources()
+		require.NoError(err)
+		require.Len(res[kindScope], 2)
+		assert.Equal("org", res[kindScope][0].key)
+		assert.Equal("proj", res[kindScope][1].key)
+		assert.Equal("org", res[kindScope][0].name)
+		require.Len(res[kindHostCatalog], 1)
+		assert.Equal("static", res[kindHostCatalog][0].typ)
+		require.Len(res[kindTarget], 1)
+		assert.Equal("tcp", res[kindTarget][0].typ)
+		assert.Equal(map[string]interface{}{"session_max_seconds": 3600}, res[kindTarget][0].fields)
+	})
+
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:    "duplicate key",
+			config:  `user "a" { scope = "global" } user "a" { scope = "global" }`,
+			wantErr: `user "a" is declared more than once`,
+		},
+		{
+			name:    "duplicate name",
+			config:  `user "a" { scope = "global" } user "b" { scope = "global" name = "a" }`,
+			wantErr: `same name "a"`,
+		},
+		{
+			name:    "missing scope",
+			config:  `user "a" {}`,
+			wantErr: `user "a" is missing its scope`,
+		},
+		{
+			name:    "missing auth method type",
+			config:  `auth_method "pw" { scope = "global" }`,
+			wantErr: `auth_method "pw" is missing its type`,
+		},
+		{
+			name:    "unknown reference",
+			config:  `user "a" { scope = "org" }`,
+			wantErr: `neither the key of a declared resource nor an id`,
+		},
+		{
+			name:    "ambiguous principal",
+			config:  `user "a" { scope = "global" } group "a" { scope = "global" } role "r" { scope = "global" principals = ["a"] }`,
+			wantErr: `key of more than one kind`,
+		},
+		{
+			name:    "scope cycle",
+			config:  `scope "a" { scope = "b" } scope "b" { scope = "a" }`,
+			wantErr: `is within itself`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			c, err := Parse("config.hcl", []byte(tt.config))
+			require.NoError(err)
+			_, err = c.resources()
+			require.Error(err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,473 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/api/groups"
+	"github.com/hashicorp/boundary/api/hostcatalogs"
+	"github.com/hashicorp/boundary/api/hosts"
+	"github.com/hashicorp/boundary/api/hostsets"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/api/users"
+)
+
+// executor applies planned changes through the controller's API.
+type executor struct {
+	client *api.Client
+	// ids are the ids of the declared resources which exist, by kind and
+	// key, including those created by the executor.
+	ids map[kind]map[string]string
+}
+
+// newExecutor returns an executor for the plan.  The ids of the declared
+// resources which exist are taken from the plan, as unchanged resources may
+// still be referred to by the changes.
+func newExecutor(client *api.Client, p *Plan) *executor {
+	e := &executor{
+		client: client,
+		ids:    make(map[kind]map[string]string, len(kinds)),
+	}
+	for _, k := range kinds {
+		e.ids[k] = make(map[string]string, len(p.ids[k]))
+		for key, id := range p.ids[k] {
+			e.ids[k][key] = id
+		}
+	}
+	return e
+}
+
+// resolve returns the id of the resource of one of the kinds ref refers to.
+func (e *executor) resolve(ref string, refKinds ...kind) (string, error) {
+	for _, k := range refKinds {
+		if id, ok := e.ids[k][ref]; ok {
+			return id, nil
+		}
+	}
+	if idPattern.MatchString(ref) {
+		return ref, nil
+	}
+	return "", fmt.Errorf("%q has not been created", ref)
+}
+
+func (e *executor) resolveAll(refs []string, refKinds ...kind) ([]string, error) {
+	ret := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		id, err := e.resolve(ref, refKinds...)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, id)
+	}
+	return ret, nil
+}
+
+// execute applies the changes in order, stopping at the first which fails.
+// It returns the number of changes applied.
+func (e *executor) execute(ctx context.Context, changes []*Change) (int, error) {
+	for i, c := range changes {
+		var err error
+		switch c.Action {
+		case actionCreate:
+			err = e.create(ctx, c)
+		case actionUpdate:
+			err = e.update(ctx, c)
+		case actionDelete:
+			err = e.delete(ctx, c)
+		}
+		if err != nil {
+			return i, fmt.Errorf("error applying change %q: %w", c.String(), err)
+		}
+	}
+	return len(changes), nil
+}
+
+func (e *executor) create(ctx context.Context, c *Change) error {
+	r := c.res
+	parentId, err := e.resolve(r.parent, r.kind.parentKind())
+	if err != nil {
+		return err
+	}
+	var id string
+	var version uint32
+	switch r.kind {
+	case kindScope:
+		opts := []scopes.Option{scopes.WithName(r.name)}
+		if r.description != "" {
+			opts = append(opts, scopes.WithDescription(r.description))
+		}
+		if _, ok := r.fields["skip_admin_role_creation"]; ok {
+			opts = append(opts, scopes.WithSkipAdminRoleCreation(true))
+		}
+		if _, ok := r.fields["skip_default_role_creation"]; ok {
+			opts = append(opts, scopes.WithSkipDefaultRoleCreation(true))
+		}
+		res, err := scopes.NewClient(e.client).Create(ctx, parentId, opts...)
+		if err != nil {
+			return err
+		}
+		id, version = res.Item.Id, res.Item.Version
+	case kindAuthMethod:
+		opts := []authmethods.Option{authmethods.WithName(r.name)}
+		if r.description != "" {
+			opts = append(opts, authmethods.WithDescription(r.description))
+		}
+		if len(r.attributes) > 0 {
+			opts = append(opts, authmethods.WithAttributes(r.attributes))
+		}
+		res, err := authmethods.NewClient(e.client).Create(ctx, r.typ, parentId, opts...)
+		if err != nil {
+			return err
+		}
+		id, version = res.Item.Id, res.Item.Version
+	case kindUser:
+		opts := []users.Option{users.WithName(r.name)}
+		if r.description != "" {
+			opts = append(opts, users.WithDescription(r.description))
+		}
+		res, err := users.NewClient(e.client).Create(ctx, parentId, opts...)
+		if err != nil {
+			return err
+		}
+		id, version = res.Item.Id, res.Item.Version
+	case kindGroup:
+		opts := []groups.Option{groups.WithName(r.name)}
+		if r.description != "" {
+			opts = append(opts, groups.WithDescription(r.description))
+		}
+		res, err := groups.NewClient(e.client).Create(ctx, parentId, opts...)
+		if err != nil {
+			return err
+		}
+		id, version = res.Item.Id, res.Item.Version
+	case kindHostCatalog:
+		opts := []hostcatalogs.Option{hostcatalogs.WithName(r.name)}
+		if r.description != "" {
+			opts = append(opts, hostcatalogs.WithDescription(r.description))
+		}
+		if len(r.attributes) > 0 {
+			opts = append(opts, hostcatalogs.WithAttributes(r.attributes))
+		}
+		res, err := hostcatalogs.NewClient(e.client).Create(ctx, r.typ, parentId, opts...)
+		if err != nil {
+			return err
+		}
+		id, version = res.Item.Id, res.Item.Version
+	case kindHost:
+		opts := []hosts.Option{hosts.WithName(r.name)}
+		if r.description != "" {
+			opts = append(opts, hosts.WithDescription(r.description))
+		}
+		if len(r.attributes) > 0 {
+			opts = append(opts, hosts.WithAttributes(r.attributes))
+		}
+		res, err := hosts.NewClient(e.client).Create(ctx, parentId, opts...)
+		if err != nil {
+			return err
+		}
+		id, version = res.Item.Id, res.Item.Version
+	case kindHostSet:
+		opts := []hostsets.Option{hostsets.WithName(r.name)}
+		if r.description != "" {
+			opts = append(opts, hostsets.WithDescription(r.description))
+		}
+		res, err := hostsets.NewClient(e.client).Create(ctx, parentId, opts...)
+		if err != nil {
+			return err
+		}
+		id, version = res.Item.Id, res.Item.Version
+	case kindTarget:
+		opts := append([]targets.Option{targets.WithName(r.name)}, targetOptions(r, createdFields(r))...)
+		if r.description != "" {
+			opts = append(opts, targets.WithDescription(r.description))
+		}
+		res, err := targets.NewClient(e.client).Create(ctx, r.typ, parentId, opts...)
+		if err != nil {
+			return err
+		}
+		id, version = res.Item.Id, res.Item.Version
+	case kindRole:
+		opts := []roles.Option{roles.WithName(r.name)}
+		if r.description != "" {
+			opts = append(opts, roles.WithDescription(r.description))
+		}
+		if r.grantScope != "" {
+			gs, err := e.resolve(r.grantScope, kindScope)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, roles.WithGrantScopeId(gs))
+		}
+		res, err := roles.NewClient(e.client).Create(ctx, parentId, opts...)
+		if err != nil {
+			return err
+		}
+		id, version = res.Item.Id, res.Item.Version
+	}
+	e.ids[r.kind][r.key] = id
+	if len(r.refs) == 0 && len(r.grants) == 0 {
+		return nil
+	}
+	_, err = e.setAssociations(ctx, r, id, version)
+	return err
+}
+
+func (e *executor) update(ctx context.Context, c *Change) error {
+	r := c.res
+	version := c.Version
+	changed := make(map[string]bool, len(c.Fields))
+	for _, f := range c.Fields {
+		changed[f] = true
+	}
+	attrs := map[string]interface{}{}
+	for k, v := range r.attributes {
+		if changed["attributes."+k] {
+			attrs[k] = v
+		}
+	}
+
+	var fieldsChanged bool
+	for _, f := range c.Fields {
+		if f != refsField(r.kind) && f != "grants" {
+			fieldsChanged = true
+			break
+		}
+	}
+	if fieldsChanged {
+		var err error
+		desc := changed["description"]
+		switch r.kind {
+		case kindScope:
+			var opts []scopes.Option
+			switch {
+			case desc && r.description == "":
+				opts = append(opts, scopes.DefaultDescription())
+			case desc:
+				opts = append(opts, scopes.WithDescription(r.description))
+			}
+			res, uErr := scopes.NewClient(e.client).Update(ctx, c.Id, version, opts...)
+			if err = uErr; err == nil {
+				version = res.Item.Version
+			}
+		case kindAuthMethod:
+			var opts []authmethods.Option
+			switch {
+			case desc && r.description == "":
+				opts = append(opts, authmethods.DefaultDescription())
+			case desc:
+				opts = append(opts, authmethods.WithDescription(r.description))
+			}
+			if len(attrs) > 0 {
+				opts = append(opts, authmethods.WithAttributes(attrs))
+			}
+			res, uErr := authmethods.NewClient(e.client).Update(ctx, c.Id, version, opts...)
+			if err = uErr; err == nil {
+				version = res.Item.Version
+			}
+		case kindUser:
+			var opts []users.Option
+			switch {
+			case desc && r.description == "":
+				opts = append(opts, users.DefaultDescription())
+			case desc:
+				opts = append(opts, users.WithDescription(r.description))
+			}
+			res, uErr := users.NewClient(e.client).Update(ctx, c.Id, version, opts...)
+			if err = uErr; err == nil {
+				version = res.Item.Version
+			}
+		case kindGroup:
+			var opts []groups.Option
+			switch {
+			case desc && r.description == "":
+				opts = append(opts, groups.DefaultDescription())
+			case desc:
+				opts = append(opts, groups.WithDescription(r.description))
+			}
+			res, uErr := groups.NewClient(e.client).Update(ctx, c.Id, version, opts...)
+			if err = uErr; err == nil {
+				version = res.Item.Version
+			}
+		case kindHostCatalog:
+			var opts []hostcatalogs.Option
+			switch {
+			case desc && r.description == "":
+				opts = append(opts, hostcatalogs.DefaultDescription())
+			case desc:
+				opts = append(opts, hostcatalogs.WithDescription(r.description))
+			}
+			if len(attrs) > 0 {
+				opts = append(opts, hostcatalogs.WithAttributes(attrs))
+			}
+			res, uErr := hostcatalogs.NewClient(e.client).Update(ctx, c.Id, version, opts...)
+			if err = uErr; err == nil {
+				version = res.Item.Version
+			}
+		case kindHost:
+			var opts []hosts.Option
+			switch {
+			case desc && r.description == "":
+				opts = append(opts, hosts.DefaultDescription())
+			case desc:
+				opts = append(opts, hosts.WithDescription(r.description))
+			}
+			if len(attrs) > 0 {
+				opts = append(opts, hosts.WithAttributes(attrs))
+			}
+			res, uErr := hosts.NewClient(e.client).Update(ctx, c.Id, version, opts...)
+			if err = uErr; err == nil {
+				version = res.Item.Version
+			}
+		case kindHostSet:
+			var opts []hostsets.Option
+			switch {
+			case desc && r.description == "":
+				opts = append(opts, hostsets.DefaultDescription())
+			case desc:
+				opts = append(opts, hostsets.WithDescription(r.description))
+			}
+			res, uErr := hostsets.NewClient(e.client).Update(ctx, c.Id, version, opts...)
+			if err = uErr; err == nil {
+				version = res.Item.Version
+			}
+		case kindTarget:
+			opts := targetOptions(r, c.Fields)
+			switch {
+			case desc && r.description == "":
+				opts = append(opts, targets.DefaultDescription())
+			case desc:
+				opts = append(opts, targets.WithDescription(r.description))
+			}
+			res, uErr := targets.NewClient(e.client).Update(ctx, c.Id, version, opts...)
+			if err = uErr; err == nil {
+				version = res.Item.Version
+			}
+		case kindRole:
+			var opts []roles.Option
+			switch {
+			case desc && r.description == "":
+				opts = append(opts, roles.DefaultDescription())
+			case desc:
+				opts = append(opts, roles.WithDescription(r.description))
+			}
+			if changed["grant_scope_id"] {
+				gs, rErr := e.resolve(r.grantScope, kindScope)
+				if rErr != nil {
+					return rErr
+				}
+				opts = append(opts, roles.WithGrantScopeId(gs))
+			}
+			res, uErr := roles.NewClient(e.client).Update(ctx, c.Id, version, opts...)
+			if err = uErr; err == nil {
+				version = res.Item.Version
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if !changed[refsField(r.kind)] && !changed["grants"] {
+		return nil
+	}
+	_, err := e.setAssociations(ctx, r, c.Id, version)
+	return err
+}
+
+// targetOptions returns the options setting the attributes and fields of the
+// target r named in names.
+func targetOptions(r *resource, names []string) []targets.Option {
+	set := make(map[string]bool, len(names))
+	for _, n := range names {
+		set[n] = true
+	}
+	var opts []targets.Option
+	attrs := map[string]interface{}{}
+	for k, v := range r.attributes {
+		if set["attributes."+k] {
+			attrs[k] = v
+		}
+	}
+	if len(attrs) > 0 {
+		opts = append(opts, targets.WithAttributes(attrs))
+	}
+	if set["session_max_seconds"] {
+		opts = append(opts, targets.WithSessionMaxSeconds(uint32(r.fields["session_max_seconds"].(int))))
+	}
+	if set["session_connection_limit"] {
+		opts = append(opts, targets.WithSessionConnectionLimit(int32(r.fields["session_connection_limit"].(int))))
+	}
+	if set["worker_filter"] {
+		opts = append(opts, targets.WithWorkerFilter(r.fields["worker_filter"].(string)))
+	}
+	return opts
+}
+
+// setAssociations sets the associations and grants of r, which has the id and
+// version, and returns its new version.
+func (e *executor) setAssociations(ctx context.Context, r *resource, id string, version uint32) (uint32, error) {
+	ids, err := e.resolveAll(r.refs, r.refKinds...)
+	if err != nil {
+		return 0, err
+	}
+	switch r.kind {
+	case kindGroup:
+		res, err := groups.NewClient(e.client).SetMembers(ctx, id, version, ids)
+		if err != nil {
+			return 0, err
+		}
+		return res.Item.Version, nil
+	case kindHostSet:
+		res, err := hostsets.NewClient(e.client).SetHosts(ctx, id, version, ids)
+		if err != nil {
+			return 0, err
+		}
+		return res.Item.Version, nil
+	case kindTarget:
+		res, err := targets.NewClient(e.client).SetHostSets(ctx, id, version, ids)
+		if err != nil {
+			return 0, err
+		}
+		return res.Item.Version, nil
+	case kindRole:
+		rc := roles.NewClient(e.client)
+		res, err := rc.SetPrincipals(ctx, id, version, ids)
+		if err != nil {
+			return 0, err
+		}
+		res, err = rc.SetGrants(ctx, id, res.Item.Version, r.grants)
+		if err != nil {
+			return 0, err
+		}
+		return res.Item.Version, nil
+	}
+	return version, nil
+}
+
+func (e *executor) delete(ctx context.Context, c *Change) error {
+	var err error
+	switch c.Kind {
+	case kindScope:
+		_, err = scopes.NewClient(e.client).Delete(ctx, c.Id)
+	case kindAuthMethod:
+		_, err = authmethods.NewClient(e.client).Delete(ctx, c.Id)
+	case kindUser:
+		_, err = users.NewClient(e.client).Delete(ctx, c.Id)
+	case kindGroup:
+		_, err = groups.NewClient(e.client).Delete(ctx, c.Id)
+	case kindHostCatalog:
+		_, err = hostcatalogs.NewClient(e.client).Delete(ctx, c.Id)
+	case kindHost:
+		_, err = hosts.NewClient(e.client).Delete(ctx, c.Id)
+	case kindHostSet:
+		_, err = hostsets.NewClient(e.client).Delete(ctx, c.Id)
+	case kindTarget:
+		_, err = targets.NewClient(e.client).Delete(ctx, c.Id)
+	case kindRole:
+		_, err = roles.NewClient(e.client).Delete(ctx, c.Id)
+	}
+	return err
+}
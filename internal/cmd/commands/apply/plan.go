@@ -0,0 +1,371 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type action string
+
+const (
+	actionCreate action = "create"
+	actionUpdate action = "update"
+	actionDelete action = "delete"
+)
+
+// liveResource is a resource read from the controller, reduced to the fields
+// apply manages.
+type liveResource struct {
+	id          string
+	version     uint32
+	name        string
+	description string
+	typ         string
+	attributes  map[string]interface{}
+	fields      map[string]interface{}
+	// refs are the ids of the resources associated with the resource.
+	refs         []string
+	grantScopeId string
+	// grants are the raw and canonical forms of a role's grants.
+	grants          []string
+	canonicalGrants []string
+}
+
+// source reads the live resources of a kind in a scope or host catalog.
+type source interface {
+	list(ctx context.Context, parentId string, k kind) ([]*liveResource, error)
+}
+
+// Change is a change to converge a live resource to its declared state.
+type Change struct {
+	Action action `json:"action"`
+	Kind   kind   `json:"type"`
+	// Key is the key of the declared resource, and is empty for deletes.
+	Key  string `json:"key,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Id is the id of the live resource, and is empty for creates.
+	Id string `json:"id,omitempty"`
+	// Parent refers to the scope or host catalog the resource is in.
+	Parent string `json:"parent"`
+	// Version is the version of the live resource the change was planned
+	// against.  The change fails if the resource has been modified since.
+	Version uint32 `json:"version,omitempty"`
+	// Fields are the fields set by a create or changed by an update.
+	Fields []string `json:"fields,omitempty"`
+
+	res *resource
+}
+
+func (c *Change) String() string {
+	switch c.Action {
+	case actionCreate:
+		return fmt.Sprintf("+ %s %q in %s", c.Kind, c.Key, c.Parent)
+	case actionUpdate:
+		return fmt.Sprintf("~ %s %q (%s): %s", c.Kind, c.Key, c.Id, strings.Join(c.Fields, ", "))
+	default:
+		return fmt.Sprintf("- %s %q (%s) in %s", c.Kind, c.Name, c.Id, c.Parent)
+	}
+}
+
+// Plan is the changes to converge the live resources to the declared
+// resources.
+type Plan struct {
+	Changes []*Change `json:"changes"`
+
+	// ids are the ids of the declared resources which exist, by kind and
+	// key.
+	ids map[kind]map[string]string
+}
+
+// planner computes the changes to converge the live resources read from a
+// source to the declared resources.
+type planner struct {
+	src source
+	// ids are the ids of the declared resources which exist, by kind and
+	// key.
+	ids map[kind]map[string]string
+	// matched are the ids of the live resources which are declared.
+	matched map[string]bool
+	// lists caches the live resources by parent and kind.
+	lists map[string]map[kind][]*liveResource
+}
+
+// plan returns the Plan to converge the live resources read from src to the
+// declared resources.  Changes are ordered so each can be applied after
+// the ones before it.  Live resources which are in a declared scope or host
+// catalog but aren't themselves declared are deleted if their kind is in
+// prune.
+func plan(ctx context.Context, declared map[kind][]*resource, src source, prune map[kind]bool) (*Plan, error) {
+	p := &planner{
+		src:     src,
+		ids:     make(map[kind]map[string]string, len(kinds)),
+		matched: map[string]bool{},
+		lists:   map[string]map[kind][]*liveResource{},
+	}
+	for _, k := range kinds {
+		p.ids[k] = map[string]string{}
+	}
+
+	var changes []*Change
+	for _, k := range kinds {
+		for _, r := range declared[k] {
+			c, err := p.planResource(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			if c != nil {
+				changes = append(changes, c)
+			}
+		}
+	}
+
+	var deletes []*Change
+	for _, pk := range []kind{kindScope, kindHostCatalog} {
+		for _, r := range declared[pk] {
+			parentId, ok := p.ids[pk][r.key]
+			if !ok {
+				continue
+			}
+			for _, k := range childKinds(pk, parentId) {
+				if !prune[k] {
+					continue
+				}
+				live, err := p.list(ctx, parentId, k)
+				if err != nil {
+					return nil, err
+				}
+				for _, l := range live {
+					if p.matched[l.id] {
+						continue
+					}
+					deletes = append(deletes, &Change{Action: actionDelete, Kind: k, Name: l.name, Id: l.id, Parent: parentId, Version: l.version})
+				}
+			}
+		}
+	}
+	order := make(map[kind]int, len(kinds))
+	for i, k := range kinds {
+		order[k] = i
+	}
+	sort.SliceStable(deletes, func(i, j int) bool {
+		return order[deletes[i].Kind] > order[deletes[j].Kind]
+	})
+	return &Plan{Changes: append(changes, deletes...), ids: p.ids}, nil
+}
+
+// childKinds returns the kinds of resources which can be in the scope or
+// host catalog with the id.
+func childKinds(parentKind kind, parentId string) []kind {
+	switch {
+	case parentKind == kindHostCatalog:
+		return []kind{kindHost, kindHostSet}
+	case strings.HasPrefix(parentId, "p_"):
+		return []kind{kindGroup, kindHostCatalog, kindTarget, kindRole}
+	default:
+		return []kind{kindScope, kindAuthMethod, kindUser, kindGroup, kindRole}
+	}
+}
+
+func (p *planner) list(ctx context.Context, parentId string, k kind) ([]*liveResource, error) {
+	if l, ok := p.lists[parentId][k]; ok {
+		return l, nil
+	}
+	l, err := p.src.list(ctx, parentId, k)
+	if err != nil {
+		return nil, fmt.Errorf("error listing %ss in %s: %w", k, parentId, err)
+	}
+	if p.lists[parentId] == nil {
+		p.lists[parentId] = map[kind][]*liveResource{}
+	}
+	p.lists[parentId][k] = l
+	return l, nil
+}
+
+// resolve returns the id of the resource of one of the kinds ref refers to.
+// It returns false if ref refers to a declared resource which doesn't exist
+// yet.
+func (p *planner) resolve(ref string, refKinds ...kind) (string, bool) {
+	for _, k := range refKinds {
+		if id, ok := p.ids[k][ref]; ok {
+			return id, true
+		}
+	}
+	if idPattern.MatchString(ref) {
+		return ref, true
+	}
+	return "", false
+}
+
+func (p *planner) planResource(ctx context.Context, r *resource) (*Change, error) {
+	create := &Change{Action: actionCreate, Kind: r.kind, Key: r.key, Name: r.name, Parent: r.parent, Fields: createdFields(r), res: r}
+	parentId, ok := p.resolve(r.parent, r.kind.parentKind())
+	if !ok {
+		// The parent will be created, so the resource must be too.
+		return create, nil
+	}
+	create.Parent = parentId
+	live, err := p.list(ctx, parentId, r.kind)
+	if err != nil {
+		return nil, err
+	}
+	var l *liveResource
+	for _, c := range live {
+		if c.name == r.name {
+			l = c
+			break
+		}
+	}
+	if l == nil {
+		return create, nil
+	}
+	p.ids[r.kind][r.key] = l.id
+	p.matched[l.id] = true
+
+	if r.typ != "" && l.typ != "" && r.typ != l.typ {
+		return nil, fmt.Errorf("%s has type %q but %s has type %q, and the type of a resource can't be changed", r, r.typ, l.id, l.typ)
+	}
+	fields := p.changedFields(r, l)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return &Change{Action: actionUpdate, Kind: r.kind, Key: r.key, Name: r.name, Id: l.id, Parent: parentId, Version: l.version, Fields: fields, res: r}, nil
+}
+
+// createdFields returns the fields set when creating r.
+func createdFields(r *resource) []string {
+	var ret []string
+	if r.description != "" {
+		ret = append(ret, "description")
+	}
+	for k := range r.attributes {
+		ret = append(ret, "attributes."+k)
+	}
+	for k := range r.fields {
+		ret = append(ret, k)
+	}
+	if r.grantScope != "" {
+		ret = append(ret, "grant_scope_id")
+	}
+	if len(r.refs) > 0 {
+		ret = append(ret, refsField(r.kind))
+	}
+	if len(r.grants) > 0 {
+		ret = append(ret, "grants")
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// changedFields returns the fields of l which differ from r.  Attributes and
+// fields which aren't read back from the controller, such as secrets, are
+// only set when a resource is created.
+func (p *planner) changedFields(r *resource, l *liveResource) []string {
+	var ret []string
+	if r.description != l.description {
+		ret = append(ret, "description")
+	}
+	for k, v := range r.attributes {
+		if lv, ok := l.attributes[k]; ok && !jsonEqual(v, lv) {
+			ret = append(ret, "attributes."+k)
+		}
+	}
+	for k, v := range r.fields {
+		if lv, ok := l.fields[k]; ok && !jsonEqual(v, lv) {
+			ret = append(ret, k)
+		}
+	}
+	if r.grantScope != "" {
+		if id, ok := p.resolve(r.grantScope, kindScope); !ok || id != l.grantScopeId {
+			ret = append(ret, "grant_scope_id")
+		}
+	}
+	if r.refKinds != nil {
+		want := make([]string, 0, len(r.refs))
+		pending := false
+		for _, ref := range r.refs {
+			id, ok := p.resolve(ref, r.refKinds...)
+			if !ok {
+				pending = true
+				break
+			}
+			want = append(want, id)
+		}
+		if pending || !sameSet(want, l.refs) {
+			ret = append(ret, refsField(r.kind))
+		}
+	}
+	if r.kind == kindRole && !sameGrants(r.grants, l) {
+		ret = append(ret, "grants")
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// refsField returns the name of the field holding the associations of a
+// resource of kind k.
+func refsField(k kind) string {
+	switch k {
+	case kindGroup:
+		return "members"
+	case kindRole:
+		return "principals"
+	case kindHostSet:
+		return "hosts"
+	default:
+		return "host_sets"
+	}
+}
+
+// jsonEqual compares values by their JSON encoding, so numbers decoded from a
+// config compare equal to the same numbers decoded from the API.
+func jsonEqual(a, b interface{}) bool {
+	ja, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	jb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(ja) == string(jb)
+}
+
+func sameSet(a, b []string) bool {
+	set := make(map[string]bool, len(a))
+	for _, v := range a {
+		set[v] = true
+	}
+	if len(set) != len(b) {
+		return false
+	}
+	for _, v := range b {
+		if !set[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameGrants reports whether the declared grants are those of the live role.
+// A declared grant may be in either its raw or canonical form.
+func sameGrants(want []string, l *liveResource) bool {
+	if len(want) != len(l.grants) {
+		return false
+	}
+	have := make(map[string]bool, 2*len(l.grants))
+	for _, g := range l.grants {
+		have[g] = true
+	}
+	for _, g := range l.canonicalGrants {
+		have[g] = true
+	}
+	for _, g := range want {
+		if !have[g] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,208 @@
+package apply
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSource is a source of live resources by parent id and kind.
+type testSource map[string]map[kind][]*liveResource
+
+func (s testSource) list(_ context.Context, parentId string, k kind) ([]*liveResource, error) {
+	return s[parentId][k], nil
+}
+
+func testResources(t *testing.T, config string) map[kind][]*resource {
+	t.Helper()
+	c, err := Parse("config.hcl", []byte(config))
+	require.NoError(t, err)
+	res, err := c.resources()
+	require.NoError(t, err)
+	return res
+}
+
+func TestPlan(t *testing.T) {
+	ctx := context.Background()
+	const config = `
+scope "org" {
+  scope       = "global"
+  description = "The org"
+}
+
+user "alice" {
+  scope = "org"
+}
+
+user "bob" {
+  scope = "org"
+}
+
+role "readers" {
+  scope      = "org"
+  principals = ["alice", "bob"]
+  grants     = ["id=*;type=*;actions=read"]
+}
+`
+	declared := testResources(t, config)
+
+	t.Run("create everything", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		p, err := plan(ctx, declared, testSource{}, nil)
+		require.NoError(err)
+		require.Len(p.Changes, 4)
+		for _, c := range p.Changes {
+			assert.Equal(actionCreate, c.Action)
+		}
+		assert.Equal("global", p.Changes[0].Parent)
+		assert.Equal([]string{"description"}, p.Changes[0].Fields)
+		// The org doesn't exist, so the parent of its contents is its key.
+		assert.Equal("org", p.Changes[1].Parent)
+		assert.Equal(kindRole, p.Changes[3].Kind)
+		assert.Equal([]string{"grants", "principals"}, p.Changes[3].Fields)
+	})
+
+	src := testSource{
+		"global": {
+			kindScope: {{id: "o_1", version: 2, name: "org", description: "The org"}},
+		},
+		"o_1": {
+			kindUser: {
+				{id: "u_1", version: 1, name: "alice"},
+				{id: "u_2", version: 1, name: "bob"},
+				{id: "u_3", version: 4, name: "carol"},
+			},
+			kindRole: {
+				{id: "r_1", version: 3, name: "readers", refs: []string{"u_2", "u_1"}, grants: []string{"id=*;type=*;actions=read"}},
+				{id: "r_2", version: 1, name: "admins"},
+			},
+		},
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		p, err := plan(ctx, declared, src, nil)
+		require.NoError(err)
+		assert.Empty(p.Changes)
+		assert.Equal("o_1", p.ids[kindScope]["org"])
+		assert.Equal("r_1", p.ids[kindRole]["readers"])
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		p, err := plan(ctx, declared, src, map[kind]bool{kindUser: true, kindRole: true})
+		require.NoError(err)
+		require.Len(p.Changes, 2)
+		// Roles are deleted before users.
+		assert.Equal(&Change{Action: actionDelete, Kind: kindRole, Name: "admins", Id: "r_2", Parent: "o_1", Version: 1}, p.Changes[0])
+		assert.Equal(&Change{Action: actionDelete, Kind: kindUser, Name: "carol", Id: "u_3", Parent: "o_1", Version: 4}, p.Changes[1])
+
+		p, err = plan(ctx, declared, src, map[kind]bool{kindUser: true})
+		require.NoError(err)
+		require.Len(p.Changes, 1)
+		assert.Equal("u_3", p.Changes[0].Id)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		declared := testResources(t, `
+scope "org" {
+  scope = "global"
+}
+
+user "alice" {
+  scope = "org"
+}
+
+user "dave" {
+  scope = "org"
+}
+
+role "readers" {
+  scope      = "org"
+  principals = ["alice", "dave"]
+  grants     = ["id=*;type=*;actions=read"]
+}
+`)
+		p, err := plan(ctx, declared, src, nil)
+		require.NoError(err)
+		require.Len(p.Changes, 3)
+		assert.Equal(&Change{Action: actionUpdate, Kind: kindScope, Key: "org", Name: "org", Id: "o_1", Parent: "global", Version: 2, Fields: []string{"description"}, res: declared[kindScope][0]}, p.Changes[0])
+		assert.Equal(actionCreate, p.Changes[1].Action)
+		assert.Equal("dave", p.Changes[1].Key)
+		assert.Equal("o_1", p.Changes[1].Parent)
+		// The role's principals change as dave doesn't exist yet.
+		assert.Equal(actionUpdate, p.Changes[2].Action)
+		assert.Equal(uint32(3), p.Changes[2].Version)
+		assert.Equal([]string{"principals"}, p.Changes[2].Fields)
+	})
+
+	t.Run("type change", func(t *testing.T) {
+		declared := testResources(t, `
+target "ssh" {
+  scope = "p_1"
+}
+`)
+		src := testSource{"p_1": {kindTarget: {{id: "tssh_1", name: "ssh", typ: "ssh"}}}}
+		_, err := plan(ctx, declared, src, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "can't be changed")
+	})
+}
+
+func TestChangedFields(t *testing.T) {
+	p := &planner{ids: map[kind]map[string]string{kindScope: {}, kindHost: {}}}
+	tests := []struct {
+		name string
+		res  *resource
+		live *liveResource
+		want []string
+	}{
+		{
+			name: "attributes compare by value",
+			res:  &resource{kind: kindHost, attributes: map[string]interface{}{"address": "10.0.0.1", "port": 22}},
+			live: &liveResource{attributes: map[string]interface{}{"address": "10.0.0.1", "port": float64(22)}},
+		},
+		{
+			name: "unreturned attributes are ignored",
+			res:  &resource{kind: kindAuthMethod, attributes: map[string]interface{}{"client_secret": "secret"}},
+			live: &liveResource{attributes: map[string]interface{}{"client_secret_hmac": "hmac"}},
+		},
+		{
+			name: "changed attribute",
+			res:  &resource{kind: kindHost, attributes: map[string]interface{}{"address": "10.0.0.2"}},
+			live: &liveResource{attributes: map[string]interface{}{"address": "10.0.0.1"}},
+			want: []string{"attributes.address"},
+		},
+		{
+			name: "changed fields",
+			res:  &resource{kind: kindTarget, description: "d", fields: map[string]interface{}{"session_max_seconds": 60}},
+			live: &liveResource{fields: map[string]interface{}{"session_max_seconds": uint32(30)}},
+			want: []string{"description", "session_max_seconds"},
+		},
+		{
+			name: "canonical grants",
+			res:  &resource{kind: kindRole, grants: []string{"id=*;type=*;actions=read"}, grantScope: "o_1"},
+			live: &liveResource{grants: []string{"type=*;id=*;actions=read"}, canonicalGrants: []string{"id=*;type=*;actions=read"}, grantScopeId: "o_1"},
+		},
+		{
+			name: "changed grant scope",
+			res:  &resource{kind: kindRole, grantScope: "o_2"},
+			live: &liveResource{grantScopeId: "o_1"},
+			want: []string{"grant_scope_id"},
+		},
+		{
+			name: "removed host",
+			res:  &resource{kind: kindHostSet, refs: []string{"hst_1"}, refKinds: []kind{kindHost}},
+			live: &liveResource{refs: []string{"hst_1", "hst_2"}},
+			want: []string{"hosts"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, p.changedFields(tt.res, tt.live))
+		})
+	}
+}
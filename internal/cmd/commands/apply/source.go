@@ -0,0 +1,123 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/api/groups"
+	"github.com/hashicorp/boundary/api/hostcatalogs"
+	"github.com/hashicorp/boundary/api/hosts"
+	"github.com/hashicorp/boundary/api/hostsets"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/api/users"
+)
+
+// apiSource reads live resources from the controller.
+type apiSource struct {
+	client *api.Client
+}
+
+var _ source = (*apiSource)(nil)
+
+func (s *apiSource) list(ctx context.Context, parentId string, k kind) ([]*liveResource, error) {
+	var ret []*liveResource
+	switch k {
+	case kindScope:
+		res, err := scopes.NewClient(s.client).List(ctx, parentId)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range res.Items {
+			ret = append(ret, &liveResource{id: i.Id, version: i.Version, name: i.Name, description: i.Description})
+		}
+	case kindAuthMethod:
+		res, err := authmethods.NewClient(s.client).List(ctx, parentId)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range res.Items {
+			ret = append(ret, &liveResource{id: i.Id, version: i.Version, name: i.Name, description: i.Description, typ: i.Type, attributes: i.Attributes})
+		}
+	case kindUser:
+		res, err := users.NewClient(s.client).List(ctx, parentId)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range res.Items {
+			ret = append(ret, &liveResource{id: i.Id, version: i.Version, name: i.Name, description: i.Description})
+		}
+	case kindGroup:
+		res, err := groups.NewClient(s.client).List(ctx, parentId)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range res.Items {
+			ret = append(ret, &liveResource{id: i.Id, version: i.Version, name: i.Name, description: i.Description, refs: i.MemberIds})
+		}
+	case kindHostCatalog:
+		res, err := hostcatalogs.NewClient(s.client).List(ctx, parentId)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range res.Items {
+			ret = append(ret, &liveResource{id: i.Id, version: i.Version, name: i.Name, description: i.Description, typ: i.Type, attributes: i.Attributes})
+		}
+	case kindHost:
+		res, err := hosts.NewClient(s.client).List(ctx, parentId)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range res.Items {
+			ret = append(ret, &liveResource{id: i.Id, version: i.Version, name: i.Name, description: i.Description, typ: i.Type, attributes: i.Attributes})
+		}
+	case kindHostSet:
+		res, err := hostsets.NewClient(s.client).List(ctx, parentId)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range res.Items {
+			ret = append(ret, &liveResource{id: i.Id, version: i.Version, name: i.Name, description: i.Description, typ: i.Type, refs: i.HostIds})
+		}
+	case kindTarget:
+		res, err := targets.NewClient(s.client).List(ctx, parentId)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range res.Items {
+			ret = append(ret, &liveResource{
+				id:          i.Id,
+				version:     i.Version,
+				name:        i.Name,
+				description: i.Description,
+				typ:         i.Type,
+				attributes:  i.Attributes,
+				fields: map[string]interface{}{
+					"session_max_seconds":      i.SessionMaxSeconds,
+					"session_connection_limit": i.SessionConnectionLimit,
+					"worker_filter":            i.WorkerFilter,
+				},
+				refs: i.HostSetIds,
+			})
+		}
+	case kindRole:
+		res, err := roles.NewClient(s.client).List(ctx, parentId)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range res.Items {
+			l := &liveResource{id: i.Id, version: i.Version, name: i.Name, description: i.Description, refs: i.PrincipalIds, grantScopeId: i.GrantScopeId}
+			for _, g := range i.Grants {
+				l.grants = append(l.grants, g.Raw)
+				l.canonicalGrants = append(l.canonicalGrants, g.Canonical)
+			}
+			ret = append(ret, l)
+		}
+	default:
+		return nil, fmt.Errorf("unknown resource type %q", k)
+	}
+	return ret, nil
+}
@@ -0,0 +1,34 @@
+package authorize
+
+import (
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+)
+
+var _ cli.Command = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return "Inspect authorization decisions"
+}
+
+func (c *Command) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary authorize <subcommand> [options] [args]",
+		"",
+		"  This command groups subcommands for inspecting authorization decisions. Example:",
+		"",
+		"    Explain whether a user can read a target:",
+		"",
+		`      $ boundary authorize explain -scope-id p_1234567890 -user-id u_1234567890 -resource-id ttcp_1234567890 -resource-type target -action read`,
+		"",
+		"  Please see the individual subcommand help for detailed usage information.",
+	})
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
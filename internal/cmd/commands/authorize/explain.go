@@ -0,0 +1,222 @@
+package authorize
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/authorization"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ExplainCommand)(nil)
+	_ cli.CommandAutocomplete = (*ExplainCommand)(nil)
+)
+
+type ExplainCommand struct {
+	*base.Command
+
+	flagUserId       string
+	flagAuthTokenId  string
+	flagResourceId   string
+	flagResourceType string
+	flagPin          string
+	flagAction       string
+}
+
+func (c *ExplainCommand) Synopsis() string {
+	return "Explain whether a user is authorized to perform an action"
+}
+
+func (c *ExplainCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary authorize explain [options]",
+		"",
+		"  Simulate the authorization check performed when a user, given directly or through one of their auth tokens, requests an action on a resource, without performing the action. The result shows whether the action is authorized, the grants that allowed or denied it along with their roles, and the output fields that would apply. This requires the explain-authorization action on the scope containing the resource. Example:",
+		"",
+		`    $ boundary authorize explain -scope-id p_1234567890 -user-id u_1234567890 -resource-id ttcp_1234567890 -resource-type target -action delete`,
+		"",
+		"  For collection actions such as list and create, give only the resource type:",
+		"",
+		`    $ boundary authorize explain -scope-id o_1234567890 -auth-token-id at_1234567890 -resource-type user -action list`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ExplainCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:       "scope-id",
+		Target:     &c.FlagScopeId,
+		EnvVar:     "BOUNDARY_SCOPE_ID",
+		Completion: complete.PredictAnything,
+		Usage:      "The scope containing the resource. It must match the scope of the resource or pin, if given.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "user-id",
+		Target: &c.flagUserId,
+		Usage:  "The user to perform the check for. Exactly one of this and -auth-token-id must be given.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "auth-token-id",
+		Target: &c.flagAuthTokenId,
+		Usage:  "The auth token whose user and account the check is performed for.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "resource-id",
+		Target: &c.flagResourceId,
+		Usage:  "The ID of the resource. Leave empty for collection actions such as list and create.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "resource-type",
+		Target: &c.flagResourceType,
+		Usage:  `The type of the resource, e.g. "target". It must match the type of the resource, if given.`,
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "pin",
+		Target: &c.flagPin,
+		Usage:  "The ID of the collection the resource is in, e.g. the host catalog of a host. It must match the collection of the resource, if given.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "action",
+		Target: &c.flagAction,
+		Usage:  `The action to check, e.g. "read".`,
+	})
+
+	return set
+}
+
+func (c *ExplainCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ExplainCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ExplainCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	switch {
+	case c.FlagScopeId == "":
+		c.PrintCliError(fmt.Errorf("Scope ID must be provided via -scope-id"))
+		return base.CommandUserError
+	case c.flagAction == "":
+		c.PrintCliError(fmt.Errorf("Action must be provided via -action"))
+		return base.CommandUserError
+	case c.flagUserId == "" && c.flagAuthTokenId == "":
+		c.PrintCliError(fmt.Errorf("One of -user-id or -auth-token-id must be provided"))
+		return base.CommandUserError
+	case c.flagUserId != "" && c.flagAuthTokenId != "":
+		c.PrintCliError(fmt.Errorf("Only one of -user-id or -auth-token-id can be provided"))
+		return base.CommandUserError
+	case c.flagResourceId == "" && c.flagResourceType == "":
+		c.PrintCliError(fmt.Errorf("One of -resource-id or -resource-type must be provided"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	var opts []authorization.Option
+	if c.flagUserId != "" {
+		opts = append(opts, authorization.WithUserId(c.flagUserId))
+	}
+	if c.flagAuthTokenId != "" {
+		opts = append(opts, authorization.WithAuthTokenId(c.flagAuthTokenId))
+	}
+	if c.flagResourceId != "" {
+		opts = append(opts, authorization.WithResourceId(c.flagResourceId))
+	}
+	if c.flagResourceType != "" {
+		opts = append(opts, authorization.WithResourceType(c.flagResourceType))
+	}
+	if c.flagPin != "" {
+		opts = append(opts, authorization.WithPin(c.flagPin))
+	}
+
+	result, err := authorization.NewClient(client).Explain(c.Context, c.FlagScopeId, c.flagAction, opts...)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when explaining authorization")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to explain authorization: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(printExplanationTable(result.Item))
+	case "json":
+		if ok := c.PrintJsonItem(result); !ok {
+			return base.CommandCliError
+		}
+	}
+	return base.CommandSuccess
+}
+
+func printExplanationTable(item *authorization.Explanation) string {
+	nonAttributeMap := map[string]interface{}{
+		"User ID":    item.UserId,
+		"Scope ID":   item.ScopeId,
+		"Action":     item.Action,
+		"Authorized": item.Authorized,
+	}
+	if item.ResourceId != "" {
+		nonAttributeMap["Resource ID"] = item.ResourceId
+	}
+	if item.ResourceType != "" {
+		nonAttributeMap["Resource Type"] = item.ResourceType
+	}
+	if item.Pin != "" {
+		nonAttributeMap["Pin"] = item.Pin
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Authorization explanation:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if len(item.MatchingGrants) > 0 {
+		ret = append(ret,
+			"",
+			fmt.Sprintf("  Matching Grants:  %s", ""),
+		)
+	}
+	for _, g := range item.MatchingGrants {
+		ret = append(ret,
+			fmt.Sprintf("    Grant:          %s", g.Grant),
+			fmt.Sprintf("      Effect:       %s", g.Effect),
+			fmt.Sprintf("      Role ID:      %s", g.RoleId),
+			fmt.Sprintf("      Scope ID:     %s", g.ScopeId),
+		)
+	}
+
+	if len(item.OutputFields) > 0 {
+		ret = append(ret,
+			"",
+			"  Output Fields:",
+			base.WrapSlice(4, item.OutputFields),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
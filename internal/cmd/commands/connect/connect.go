@@ -2,16 +2,11 @@ package connect
 
 import (
 	"context"
-	"crypto/ed25519"
-	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"net"
-	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -20,28 +15,19 @@ import (
 	"time"
 
 	"github.com/hashicorp/boundary/api"
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
 	"github.com/hashicorp/boundary/api/targets"
-	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/cmd/base"
-	targetspb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
-	"github.com/hashicorp/boundary/internal/proxy"
-	"github.com/hashicorp/go-cleanhttp"
-	"github.com/hashicorp/vault/sdk/helper/base62"
 	"github.com/mitchellh/cli"
-	"github.com/mr-tron/base58"
 	"github.com/posener/complete"
 	"go.uber.org/atomic"
 	exec "golang.org/x/sys/execabs"
-	"google.golang.org/protobuf/proto"
-	"nhooyr.io/websocket"
-	"nhooyr.io/websocket/wspb"
 )
 
-const sessionCancelTimeout = 10 * time.Second
-
 type SessionInfo struct {
 	Address         string    `json:"address"`
 	Port            int       `json:"port"`
+	Socket          string    `json:"socket,omitempty"`
 	Protocol        string    `json:"protocol"`
 	Expiration      time.Time `json:"expiration"`
 	ConnectionLimit int32     `json:"connection_limit"`
@@ -64,14 +50,18 @@ var (
 type Command struct {
 	*base.Command
 
-	flagAuthzToken string
-	flagListenAddr string
-	flagListenPort int
-	flagTargetId   string
-	flagTargetName string
-	flagHostId     string
-	flagExec       string
-	flagUsername   string
+	flagAuthzToken        string
+	flagListenAddr        string
+	flagListenPort        int
+	flagListenSocket      string
+	flagListenSocketMode  string
+	flagListenSocketOwner string
+	flagTargetId          string
+	flagTargetName        string
+	flagHostId            string
+	flagExec              string
+	flagUsername          string
+	flagMultiplex         bool
 
 	// HTTP
 	httpFlags
@@ -90,17 +80,20 @@ type Command struct {
 
 	Func string
 
-	sessionAuthzData *targetspb.SessionAuthorizationData
+	// Helper, if set, is the user-defined connect helper this command runs,
+	// named by Func.
+	Helper *base.ConnectHelper
+
+	helperFlagValues map[string]*string
+	helperFlagErr    error
+
+	sessionAuthzData *apiproxy.SessionAuthorizationData
 
 	connWg             *sync.WaitGroup
-	listenerCloseOnce  sync.Once
-	listener           *net.TCPListener
-	listenerAddr       *net.TCPAddr
-	connsLeftCh        chan int32
-	connectionsLeft    *atomic.Int32
-	expiration         time.Time
+	listenerIp         net.IP
+	listenerPort       int
+	listenerSocket     string
 	execCmdReturnValue *atomic.Int32
-	proxyCtx           context.Context
 	proxyCancel        context.CancelFunc
 	outputJsonErrors   bool
 }
@@ -120,6 +113,9 @@ func (c *Command) Synopsis() string {
 	case "kube":
 		return kubeSynopsis
 	default:
+		if c.Helper != nil {
+			return helperSynopsis(c.Helper)
+		}
 		return ""
 	}
 }
@@ -186,6 +182,13 @@ func (c *Command) Flags() *base.FlagSets {
 		Usage:      `If set, after connecting to the worker, the given binary will be executed. This should be a binary on your path, or an absolute path. If all command flags are followed by " -- " (space, two hyphens, space), then any arguments after that will be sent directly to the binary.`,
 	})
 
+	f.BoolVar(&base.BoolVar{
+		Name:   "multiplex",
+		Target: &c.flagMultiplex,
+		EnvVar: "BOUNDARY_CONNECT_MULTIPLEX",
+		Usage:  "If set, connections are multiplexed over a single connection to the worker rather than each making its own, if the worker supports it.",
+	})
+
 	f.StringVar(&base.StringVar{
 		Name:   "target-name",
 		Target: &c.flagTargetName,
@@ -208,6 +211,31 @@ func (c *Command) Flags() *base.FlagSets {
 		Usage:      "Target scope name, if authorizing the session via scope parameters and target name. Mutually exclusive with -scope-id.",
 	})
 
+	f.StringVar(&base.StringVar{
+		Name:       "listen-socket",
+		Target:     &c.flagListenSocket,
+		EnvVar:     "BOUNDARY_CONNECT_LISTEN_SOCKET",
+		Completion: complete.PredictFiles("*"),
+		Usage:      `If set, the CLI will listen on a Unix domain socket at the given path rather than on a TCP port, so that access to the session can be restricted with the socket's file mode and owner. The socket must not already exist. For "connect postgres" the socket's file name must be .s.PGSQL.<port>, as psql expects, and "connect ssh" reaches the socket through a ProxyCommand running "boundary connect stdio". Not supported by "connect kube", "connect rdp" or sessions to udp targets.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "listen-socket-mode",
+		Target:     &c.flagListenSocketMode,
+		Default:    "0600",
+		EnvVar:     "BOUNDARY_CONNECT_LISTEN_SOCKET_MODE",
+		Completion: complete.PredictAnything,
+		Usage:      `The octal file mode of the socket given by -listen-socket.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "listen-socket-owner",
+		Target:     &c.flagListenSocketOwner,
+		EnvVar:     "BOUNDARY_CONNECT_LISTEN_SOCKET_OWNER",
+		Completion: complete.PredictAnything,
+		Usage:      `The owner of the socket given by -listen-socket, as "user", "user:group" or ":group", by name or numeric ID. If not set, the socket is owned by the invoking user. Changing the owner usually requires elevated privileges.`,
+	})
+
 	switch c.Func {
 	case "connect":
 		f.StringVar(&base.StringVar{
@@ -240,6 +268,11 @@ func (c *Command) Flags() *base.FlagSets {
 
 	case "kube":
 		kubeOptions(c, set)
+
+	default:
+		if c.Helper != nil {
+			helperOptions(c, set)
+		}
 	}
 
 	return set
@@ -268,6 +301,10 @@ func (c *Command) Run(args []string) (retCode int) {
 		c.PrintCliError(err)
 		return base.CommandUserError
 	}
+	if c.helperFlagErr != nil {
+		c.PrintCliError(c.helperFlagErr)
+		return base.CommandUserError
+	}
 
 	switch {
 	case c.flagAuthzToken != "":
@@ -305,18 +342,40 @@ func (c *Command) Run(args []string) (retCode int) {
 			c.flagExec = c.rdpFlags.defaultExec()
 		case "kube":
 			c.flagExec = c.kubeFlags.defaultExec()
+		default:
+			if c.Helper != nil {
+				c.flagExec = c.Helper.Exec
+			}
 		}
 	}
 
-	tofuToken, err := base62.Random(20)
-	if err != nil {
-		c.PrintCliError(fmt.Errorf("Could not derive random bytes for tofu token: %w", err))
-		return base.CommandCliError
+	var socketMode os.FileMode
+	socketUid, socketGid := -1, -1
+	if c.flagListenSocket != "" {
+		switch {
+		case c.flagListenAddr != "" || c.flagListenPort != 0:
+			c.PrintCliError(errors.New("-listen-socket cannot be used with -listen-addr or -listen-port"))
+			return base.CommandUserError
+		case c.Func == "kube", c.Func == "rdp":
+			c.PrintCliError(fmt.Errorf("-listen-socket is not supported by connect %s", c.Func))
+			return base.CommandUserError
+		case c.Func == "postgres":
+			if _, _, err := postgresSocketDirAndPort(c.flagListenSocket); err != nil {
+				c.PrintCliError(err)
+				return base.CommandUserError
+			}
+		}
+		var err error
+		if socketMode, err = parseSocketMode(c.flagListenSocketMode); err != nil {
+			c.PrintCliError(err)
+			return base.CommandUserError
+		}
+		if socketUid, socketGid, err = parseSocketOwner(c.flagListenSocketOwner); err != nil {
+			c.PrintCliError(err)
+			return base.CommandUserError
+		}
 	}
 
-	c.connectionsLeft = atomic.NewInt32(0)
-	c.connsLeftCh = make(chan int32)
-
 	if c.flagListenAddr == "" {
 		c.flagListenAddr = "127.0.0.1"
 	}
@@ -390,105 +449,94 @@ func (c *Command) Run(args []string) (retCode int) {
 		authzString = sar.GetItem().(*targets.SessionAuthorization).AuthorizationToken
 	}
 
-	marshaled, err := base58.FastBase58Decoding(authzString)
-	if err != nil {
-		c.PrintCliError(fmt.Errorf("Unable to base58-decode authorization data: %w", err))
-		return base.CommandUserError
-	}
-	if len(marshaled) == 0 {
-		c.PrintCliError(errors.New("Zero length authorization information after decoding"))
-		return base.CommandUserError
-	}
-
-	c.sessionAuthzData = new(targetspb.SessionAuthorizationData)
-	if err := proto.Unmarshal(marshaled, c.sessionAuthzData); err != nil {
-		c.PrintCliError(fmt.Errorf("Unable to proto-decode authorization data: %w", err))
-		return base.CommandUserError
-	}
-
-	if len(c.sessionAuthzData.GetWorkerInfo()) == 0 {
-		c.PrintCliError(errors.New("No workers found in authorization string"))
-		return base.CommandUserError
-	}
-
-	c.connectionsLeft.Store(c.sessionAuthzData.ConnectionLimit)
-	workerAddr := c.sessionAuthzData.GetWorkerInfo()[0].GetAddress()
-
-	parsedCert, err := x509.ParseCertificate(c.sessionAuthzData.Certificate)
-	if err != nil {
-		c.PrintCliError(fmt.Errorf("Unable to decode mTLS certificate: %w", err))
-		return base.CommandUserError
-	}
-
-	if len(parsedCert.DNSNames) != 1 {
-		c.PrintCliError(fmt.Errorf("mTLS certificate has invalid parameters: %w", err))
-		return base.CommandUserError
-	}
-
-	c.expiration = parsedCert.NotAfter
-
-	// We don't _rely_ on client-side timeout verification but this prevents us
-	// seeming to be ready for a connection that will immediately fail when we
-	// try to actually make it
-	c.proxyCtx, c.proxyCancel = context.WithDeadline(c.Context, c.expiration)
+	// The proxy's context is canceled when an executed command exits, which
+	// closes its connections
+	var proxyCtx context.Context
+	proxyCtx, c.proxyCancel = context.WithCancel(c.Context)
 	defer c.proxyCancel()
 
-	certPool := x509.NewCertPool()
-	certPool.AddCert(parsedCert)
-
-	tlsConf := &tls.Config{
-		Certificates: []tls.Certificate{
-			{
-				Certificate: [][]byte{c.sessionAuthzData.Certificate},
-				PrivateKey:  ed25519.PrivateKey(c.sessionAuthzData.PrivateKey),
-				Leaf:        parsedCert,
-			},
-		},
-		RootCAs:    certPool,
-		ServerName: parsedCert.DNSNames[0],
-		MinVersion: tls.VersionTLS13,
-	}
-
-	transport := cleanhttp.DefaultTransport()
-	transport.DisableKeepAlives = false
-	transport.TLSClientConfig = tlsConf
-	// This isn't/shouldn't used anyways really because the connection is
-	// hijacked, just setting for completeness
-	transport.IdleConnTimeout = 0
-
-	c.listener, err = net.ListenTCP("tcp", &net.TCPAddr{
-		IP:   listenAddr,
-		Port: c.flagListenPort,
-	})
+	clientProxy, err := apiproxy.NewFromToken(
+		proxyCtx,
+		authzString,
+		apiproxy.WithConnectionsLeftFunc(c.updateConnsLeft),
+		apiproxy.WithErrorFunc(c.PrintCliError),
+		apiproxy.WithMultiplexing(c.flagMultiplex),
+	)
 	if err != nil {
-		c.PrintCliError(fmt.Errorf("Error starting listening port: %w", err))
-		return base.CommandCliError
+		c.PrintCliError(fmt.Errorf("Unable to use authorization data: %w", err))
+		return base.CommandUserError
 	}
+	c.sessionAuthzData = clientProxy.SessionAuthorizationData()
 
-	listenerCloseFunc := func() {
-		// Forces the for loop to exist instead of spinning on errors
-		c.connectionsLeft.Store(0)
-		if err := c.listener.Close(); err != nil {
-			c.PrintCliError(fmt.Errorf("Error closing listener on shutdown: %w", err))
-			retCode = 2
+	// Sessions to udp targets proxy the datagrams received on a udp socket
+	protocol := "tcp"
+	var serve func() error
+	switch {
+	case c.sessionAuthzData.Type == "udp":
+		if c.flagListenSocket != "" {
+			c.PrintCliError(errors.New("-listen-socket cannot be used for sessions to udp targets"))
+			if err := clientProxy.Close(); err != nil {
+				c.PrintCliError(fmt.Errorf("error sending session teardown request to worker: %w", err))
+			}
+			return base.CommandUserError
+		}
+		protocol = "udp"
+		pc, err := net.ListenUDP("udp", &net.UDPAddr{
+			IP:   listenAddr,
+			Port: c.flagListenPort,
+		})
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error starting listening port: %w", err))
+			return base.CommandCliError
+		}
+		// Ensure it's closed on any other return condition; it's otherwise
+		// closed once the proxy stops accepting datagrams
+		defer pc.Close()
+		addr := pc.LocalAddr().(*net.UDPAddr)
+		c.listenerIp, c.listenerPort = addr.IP, addr.Port
+		serve = func() error { return clientProxy.ServeUDP(pc) }
+	case c.flagListenSocket != "":
+		protocol = "unix"
+		listener, err := listenSocket(c.flagListenSocket, socketMode, socketUid, socketGid)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error starting listening socket: %w", err))
+			return base.CommandCliError
+		}
+		// Ensure it's closed, removing the socket, on any other return
+		// condition; it's otherwise closed once the proxy stops accepting
+		// connections
+		defer listener.Close()
+		c.listenerSocket = c.flagListenSocket
+		serve = func() error { return clientProxy.Serve(listener) }
+	default:
+		listener, err := net.ListenTCP("tcp", &net.TCPAddr{
+			IP:   listenAddr,
+			Port: c.flagListenPort,
+		})
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error starting listening port: %w", err))
+			return base.CommandCliError
 		}
+		// Ensure it's closed on any other return condition; it's otherwise
+		// closed once the proxy stops accepting connections
+		defer listener.Close()
+		addr := listener.Addr().(*net.TCPAddr)
+		c.listenerIp, c.listenerPort = addr.IP, addr.Port
+		serve = func() error { return clientProxy.Serve(listener) }
 	}
 
-	// Ensure it runs on any other return condition
-	defer func() {
-		c.listenerCloseOnce.Do(listenerCloseFunc)
-	}()
-
-	c.listenerAddr = c.listener.Addr().(*net.TCPAddr)
-
 	if c.flagExec == "" {
 		sessInfo := SessionInfo{
-			Protocol:        "tcp",
-			Address:         c.listenerAddr.IP.String(),
-			Port:            c.listenerAddr.Port,
-			Expiration:      c.expiration,
-			ConnectionLimit: c.sessionAuthzData.GetConnectionLimit(),
-			SessionId:       c.sessionAuthzData.GetSessionId(),
+			Protocol:        protocol,
+			Expiration:      clientProxy.SessionExpiration(),
+			ConnectionLimit: c.sessionAuthzData.ConnectionLimit,
+			SessionId:       c.sessionAuthzData.SessionId,
+		}
+		if c.listenerSocket != "" {
+			sessInfo.Socket = c.listenerSocket
+		} else {
+			sessInfo.Address = c.listenerIp.String()
+			sessInfo.Port = c.listenerPort
 		}
 
 		switch base.Format(c.UI) {
@@ -504,70 +552,28 @@ func (c *Command) Run(args []string) (retCode int) {
 		}
 	}
 
-	c.connWg = new(sync.WaitGroup)
-
-	c.connWg.Add(1)
-	go func() {
-		defer c.connWg.Done()
-		for {
-			listeningConn, err := c.listener.AcceptTCP()
-			if err != nil {
-				select {
-				case <-c.proxyCtx.Done():
-					return
-				case <-c.Context.Done():
-					return
-				default:
-					// When this hits zero we trigger listener close so this
-					// isn't actually an error condition
-					if c.connectionsLeft.Load() == 0 {
-						return
-					}
-					c.PrintCliError(fmt.Errorf("Error accepting connection: %w", err))
-					continue
-				}
+	// The host key is verified before the client is run so a host
+	// presenting an unexpected key is never connected to
+	if c.flagExec != "" && c.Func == "ssh" {
+		cleanup, err := c.sshFlags.verifyHostKey(c, clientProxy)
+		if err != nil {
+			c.PrintCliError(err)
+			if err := clientProxy.Close(); err != nil {
+				c.PrintCliError(fmt.Errorf("error sending session teardown request to worker: %w", err))
 			}
-			c.connWg.Add(1)
-			go func() {
-				defer listeningConn.Close()
-				defer c.connWg.Done()
-				wsConn, err := c.getWsConn(
-					c.proxyCtx,
-					workerAddr,
-					transport)
-				if err != nil {
-					c.PrintCliError(err)
-				} else {
-					if err := c.runTcpProxyV1(wsConn, listeningConn, tofuToken); err != nil {
-						c.PrintCliError(err)
-					}
-				}
-			}()
+			return base.CommandCliError
 		}
-	}()
+		defer cleanup()
+	}
+
+	c.connWg = new(sync.WaitGroup)
 
-	timer := time.NewTimer(time.Until(c.expiration))
 	c.connWg.Add(1)
 	go func() {
 		defer c.connWg.Done()
-		defer c.listenerCloseOnce.Do(listenerCloseFunc)
-
-		for {
-			select {
-			case <-c.proxyCtx.Done():
-				timer.Stop()
-				return
-			case <-c.Context.Done():
-				timer.Stop()
-				return
-			case <-timer.C:
-				return
-			case connsLeft := <-c.connsLeftCh:
-				c.updateConnsLeft(connsLeft)
-				if connsLeft == 0 {
-					return
-				}
-			}
+		if err := serve(); err != nil && clientProxy.Err() == nil {
+			c.PrintCliError(fmt.Errorf("Error accepting connection: %w", err))
+			c.proxyCancel()
 		}
 	}()
 
@@ -584,36 +590,24 @@ func (c *Command) Run(args []string) (retCode int) {
 	}
 
 	termInfo := TerminationInfo{Reason: "Unknown"}
-	sendSessionCancel := false
 	select {
 	case <-c.Context.Done():
 		termInfo.Reason = "Received shutdown signal"
-		sendSessionCancel = true
-	case <-timer.C:
-		termInfo.Reason = "Session has expired"
 	default:
-		if c.execCmdReturnValue != nil {
+		switch {
+		case clientProxy.Err() == apiproxy.ErrSessionExpired:
+			termInfo.Reason = "Session has expired"
+		case c.execCmdReturnValue != nil:
 			// Don't print out in this case, so ensure we clear it
 			termInfo.Reason = ""
-			sendSessionCancel = true
-		} else {
-			if c.connectionsLeft.Load() == 0 {
-				termInfo.Reason = "No connections left in session"
-			}
+		case clientProxy.Err() == apiproxy.ErrNoConnectionsLeft:
+			termInfo.Reason = "No connections left in session"
 		}
 	}
 
-	if sendSessionCancel {
-		ctx, cancel := context.WithTimeout(context.Background(), sessionCancelTimeout)
-		wsConn, err := c.getWsConn(ctx, workerAddr, transport)
-		if err != nil {
-			c.PrintCliError(fmt.Errorf("error fetching connection to send session teardown request to worker: %w", err))
-		} else {
-			if err := c.sendSessionTeardown(ctx, wsConn, tofuToken); err != nil {
-				c.PrintCliError(fmt.Errorf("error sending session teardown request to worker: %w", err))
-			}
-		}
-		cancel()
+	// This cancels the session unless it has already ended
+	if err := clientProxy.Close(); err != nil {
+		c.PrintCliError(fmt.Errorf("error sending session teardown request to worker: %w", err))
 	}
 
 	if termInfo.Reason != "" {
@@ -633,116 +627,7 @@ func (c *Command) Run(args []string) (retCode int) {
 	return
 }
 
-func (c *Command) getWsConn(
-	ctx context.Context,
-	workerAddr string,
-	transport *http.Transport) (*websocket.Conn, error) {
-	conn, resp, err := websocket.Dial(
-		ctx,
-		fmt.Sprintf("wss://%s/v1/proxy", workerAddr),
-		&websocket.DialOptions{
-			HTTPClient: &http.Client{
-				Transport: transport,
-			},
-			Subprotocols: []string{globals.TcpProxyV1},
-		},
-	)
-	if err != nil {
-		switch {
-		case strings.Contains(err.Error(), "tls: internal error"):
-			return nil, errors.New("Session credentials were not accepted, or session is unauthorized")
-		case strings.Contains(err.Error(), "connect: connection refused"):
-			return nil, fmt.Errorf("Unable to connect to worker at %s", workerAddr)
-		default:
-			return nil, fmt.Errorf("Error dialing the worker: %w", err)
-		}
-	}
-
-	if resp == nil {
-		return nil, errors.New("Response from worker is nil")
-	}
-	if resp.Header == nil {
-		return nil, errors.New("Response header is nil")
-	}
-	negProto := resp.Header.Get("Sec-WebSocket-Protocol")
-	if negProto != globals.TcpProxyV1 {
-		return nil, fmt.Errorf("Unexpected negotiated protocol: %s", negProto)
-	}
-	return conn, nil
-}
-
-func (c *Command) sendSessionTeardown(
-	ctx context.Context,
-	wsConn *websocket.Conn,
-	tofuToken string) error {
-	handshake := proxy.ClientHandshake{
-		TofuToken: tofuToken,
-		Command:   proxy.HANDSHAKECOMMAND_HANDSHAKECOMMAND_SESSION_CANCEL,
-	}
-	if err := wspb.Write(ctx, wsConn, &handshake); err != nil {
-		return fmt.Errorf("error sending teardown handshake to worker: %w", err)
-	}
-
-	return nil
-}
-
-func (c *Command) runTcpProxyV1(
-	wsConn *websocket.Conn,
-	listeningConn *net.TCPConn,
-	tofuToken string) error {
-	handshake := proxy.ClientHandshake{TofuToken: tofuToken}
-	if err := wspb.Write(c.proxyCtx, wsConn, &handshake); err != nil {
-		return fmt.Errorf("error sending handshake to worker: %w", err)
-	}
-	var handshakeResult proxy.HandshakeResult
-	if err := wspb.Read(c.proxyCtx, wsConn, &handshakeResult); err != nil {
-		switch {
-		case strings.Contains(err.Error(), "unable to authorize connection"):
-			// There's no reason to think we'd be able to authorize any more
-			// connections after the first has failed
-			c.connsLeftCh <- 0
-			return errors.New("Unable to authorize connection")
-		}
-		switch {
-		case strings.Contains(err.Error(), "tofu token not allowed"):
-			// Nothing will be able to be done here, so cancel the context too
-			c.proxyCancel()
-			return errors.New("Session is already in use")
-		default:
-			return fmt.Errorf("error reading handshake result: %w", err)
-		}
-	}
-
-	if handshakeResult.GetConnectionsLeft() != -1 {
-		c.connsLeftCh <- handshakeResult.GetConnectionsLeft()
-	}
-
-	// Get a wrapped net.Conn so we can use io.Copy
-	netConn := websocket.NetConn(c.proxyCtx, wsConn, websocket.MessageBinary)
-
-	localWg := new(sync.WaitGroup)
-	localWg.Add(2)
-
-	go func() {
-		defer localWg.Done()
-		io.Copy(netConn, listeningConn)
-		netConn.Close()
-		listeningConn.Close()
-	}()
-	go func() {
-		defer localWg.Done()
-		io.Copy(listeningConn, netConn)
-		listeningConn.Close()
-		netConn.Close()
-	}()
-	localWg.Wait()
-
-	return nil
-}
-
 func (c *Command) updateConnsLeft(connsLeft int32) {
-	c.connectionsLeft.Store(connsLeft)
-
 	connInfo := ConnectionInfo{
 		ConnectionsLeft: connsLeft,
 	}
@@ -765,11 +650,21 @@ func (c *Command) handleExec(passthroughArgs []string) {
 	defer c.connWg.Done()
 	defer c.proxyCancel()
 
-	port := strconv.Itoa(c.listenerAddr.Port)
-	ip := c.listenerAddr.IP.String()
-	addr := c.listenerAddr.String()
+	var port, ip, addr string
+	switch {
+	case c.listenerSocket != "" && c.Func == "postgres":
+		// psql is given the socket's directory and port
+		ip, port, _ = postgresSocketDirAndPort(c.listenerSocket)
+		addr = c.listenerSocket
+	case c.listenerSocket != "":
+		addr = c.listenerSocket
+	default:
+		port = strconv.Itoa(c.listenerPort)
+		ip = c.listenerIp.String()
+		addr = net.JoinHostPort(ip, port)
+	}
 
-	var args []string
+	var args, env []string
 
 	switch c.Func {
 	case "http":
@@ -788,6 +683,15 @@ func (c *Command) handleExec(passthroughArgs []string) {
 		args = append(args, c.rdpFlags.buildArgs(c, port, ip, addr)...)
 
 	case "ssh":
+		if c.listenerSocket != "" {
+			proxyCommand, err := socketProxyCommand(c.listenerSocket)
+			if err != nil {
+				c.PrintCliError(fmt.Errorf("Error parsing session args: %w", err))
+				c.execCmdReturnValue.Store(int32(3))
+				return
+			}
+			c.sshFlags.proxyCommand = proxyCommand
+		}
 		args = append(args, c.sshFlags.buildArgs(c, port, ip, addr)...)
 
 	case "kube":
@@ -798,6 +702,20 @@ func (c *Command) handleExec(passthroughArgs []string) {
 			return
 		}
 		args = append(args, kubeArgs...)
+
+	default:
+		if c.Helper != nil {
+			helperArgs, err := c.buildHelperArgs(port, ip, addr)
+			if err == nil {
+				env, err = c.buildHelperEnv(port, ip, addr)
+			}
+			if err != nil {
+				c.PrintCliError(fmt.Errorf("Error parsing session args: %w", err))
+				c.execCmdReturnValue.Store(int32(3))
+				return
+			}
+			args = append(args, helperArgs...)
+		}
 	}
 
 	args = append(passthroughArgs, args...)
@@ -818,6 +736,7 @@ func (c *Command) handleExec(passthroughArgs []string) {
 		args[i] = stringReplacer(args[i], "port", port)
 		args[i] = stringReplacer(args[i], "ip", ip)
 		args[i] = stringReplacer(args[i], "addr", addr)
+		args[i] = stringReplacer(args[i], "socket", c.listenerSocket)
 	}
 
 	// NOTE: exec.CommandContext is a hard kill, so if used it leaves the
@@ -829,6 +748,10 @@ func (c *Command) handleExec(passthroughArgs []string) {
 		fmt.Sprintf("BOUNDARY_PROXIED_IP=%s", ip),
 		fmt.Sprintf("BOUNDARY_PROXIED_ADDR=%s", addr),
 	)
+	if c.listenerSocket != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("BOUNDARY_PROXIED_SOCKET=%s", c.listenerSocket))
+	}
+	cmd.Env = append(cmd.Env, env...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
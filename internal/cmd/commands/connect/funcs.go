@@ -10,11 +10,15 @@ func generateSessionInfoTableOutput(in SessionInfo) string {
 	nonAttributeMap := map[string]interface{}{
 		"Session ID":       in.SessionId,
 		"Protocol":         in.Protocol,
-		"Address":          in.Address,
-		"Port":             in.Port,
 		"Expiration":       in.Expiration.Local().Format(time.RFC1123),
 		"Connection Limit": in.ConnectionLimit,
 	}
+	if in.Socket != "" {
+		nonAttributeMap["Socket"] = in.Socket
+	} else {
+		nonAttributeMap["Address"] = in.Address
+		nonAttributeMap["Port"] = in.Port
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
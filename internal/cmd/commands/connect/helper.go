@@ -0,0 +1,144 @@
+package connect
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/template"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/posener/complete"
+)
+
+func helperSynopsis(h *base.ConnectHelper) string {
+	if h.Description != "" {
+		return h.Description
+	}
+	return fmt.Sprintf("Authorize a session against a target and invoke %s to connect", h.Exec)
+}
+
+func helperOptions(c *Command, set *base.FlagSets) {
+	f := set.NewFlagSet(fmt.Sprintf("%s Options", c.Helper.Name))
+
+	f.StringVar(&base.StringVar{
+		Name:       "username",
+		Target:     &c.flagUsername,
+		EnvVar:     "BOUNDARY_CONNECT_USERNAME",
+		Completion: complete.PredictNothing,
+		Usage:      `Specifies the username to pass through to the client`,
+	})
+
+	c.helperFlagValues = make(map[string]*string, len(c.Helper.Flags))
+	c.helperFlagErr = nil
+	for _, hf := range c.Helper.Flags {
+		if set.Lookup(hf.Name) != nil {
+			c.helperFlagErr = fmt.Errorf("Connect helper %q flag %q conflicts with a built-in flag", c.Helper.Name, hf.Name)
+			continue
+		}
+		var completion complete.Predictor = complete.PredictAnything
+		if len(hf.Values) > 0 {
+			completion = complete.PredictSet(hf.Values...)
+		}
+		target := new(string)
+		c.helperFlagValues[hf.Name] = target
+		f.StringVar(&base.StringVar{
+			Name:       hf.Name,
+			Target:     target,
+			Default:    hf.Default,
+			EnvVar:     hf.EnvVar,
+			Completion: completion,
+			Usage:      hf.Usage,
+		})
+	}
+}
+
+// helperTemplateData is the data available to a connect helper's argument
+// and environment templates.
+type helperTemplateData struct {
+	// Ip, Port and Addr are those of the local proxy. When it listens on a
+	// Unix domain socket, Socket and Addr are the socket's path.
+	Ip     string
+	Port   string
+	Addr   string
+	Socket string
+
+	SessionId string
+	TargetId  string
+	HostId    string
+	// Endpoint is the address of the host the session connects to.
+	Endpoint string
+
+	Username string
+
+	// Flags holds the values of the helper's own flags by name.
+	Flags map[string]string
+}
+
+func (c *Command) helperTemplateData(port, ip, addr string) *helperTemplateData {
+	data := &helperTemplateData{
+		Ip:        ip,
+		Port:      port,
+		Addr:      addr,
+		Socket:    c.listenerSocket,
+		SessionId: c.sessionAuthzData.SessionId,
+		TargetId:  c.sessionAuthzData.TargetId,
+		HostId:    c.sessionAuthzData.HostId,
+		Endpoint:  c.sessionAuthzData.Endpoint,
+		Username:  c.flagUsername,
+		Flags:     make(map[string]string, len(c.helperFlagValues)),
+	}
+	for name, v := range c.helperFlagValues {
+		data.Flags[name] = *v
+	}
+	return data
+}
+
+func renderHelperTemplate(text string, data *helperTemplateData) (string, error) {
+	// Unset flags render as empty strings rather than "<no value>"
+	tmpl, err := template.New("").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// buildHelperArgs renders the helper's arguments, dropping any which are
+// empty so that an argument can be made conditional on a flag.
+func (c *Command) buildHelperArgs(port, ip, addr string) ([]string, error) {
+	data := c.helperTemplateData(port, ip, addr)
+	var args []string
+	for _, text := range c.Helper.Args {
+		arg, err := renderHelperTemplate(text, data)
+		if err != nil {
+			return nil, fmt.Errorf("error rendering argument %q: %w", text, err)
+		}
+		if arg != "" {
+			args = append(args, arg)
+		}
+	}
+	return args, nil
+}
+
+// buildHelperEnv renders the helper's environment variables as KEY=value
+// pairs, sorted by key.
+func (c *Command) buildHelperEnv(port, ip, addr string) ([]string, error) {
+	data := c.helperTemplateData(port, ip, addr)
+	keys := make([]string, 0, len(c.Helper.Env))
+	for k := range c.Helper.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	env := make([]string, 0, len(keys))
+	for _, k := range keys {
+		v, err := renderHelperTemplate(c.Helper.Env[k], data)
+		if err != nil {
+			return nil, fmt.Errorf("error rendering env var %s: %w", k, err)
+		}
+		env = append(env, fmt.Sprintf("%s=%s", k, v))
+	}
+	return env, nil
+}
@@ -0,0 +1,97 @@
+package connect
+
+import (
+	"testing"
+
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHelperConfig = `
+connect_helper "mysql" {
+  description = "Invoke the MySQL client"
+  exec        = "mysql"
+  args = [
+    "-h", "{{.Ip}}",
+    "-P", "{{.Port}}",
+    "{{if .Username}}--user={{.Username}}{{end}}",
+    "{{.Flags.database}}",
+  ]
+  env = {
+    MYSQL_HISTFILE = "/dev/null"
+    BOUNDARY_HOST  = "{{.HostId}}"
+  }
+
+  flag "database" {
+    usage   = "The database to use."
+    default = "app"
+    values  = ["app", "audit"]
+  }
+}
+`
+
+func TestConnectHelper(t *testing.T) {
+	cfg, err := base.ParseCLIConfig(testHelperConfig)
+	require.NoError(t, err)
+	require.Len(t, cfg.ConnectHelpers, 1)
+	h := cfg.ConnectHelpers[0]
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantArgs []string
+	}{
+		{
+			name:     "defaults",
+			wantArgs: []string{"-h", "127.0.0.1", "-P", "1234", "app"},
+		},
+		{
+			name:     "flags",
+			args:     []string{"-username", "dba", "-database", "audit"},
+			wantArgs: []string{"-h", "127.0.0.1", "-P", "1234", "--user=dba", "audit"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			c := &Command{Command: base.NewCommand(nil), Func: h.Name, Helper: h}
+			require.NoError(c.Flags().Parse(tt.args))
+			require.NoError(c.helperFlagErr)
+			c.sessionAuthzData = &apiproxy.SessionAuthorizationData{HostId: "hst_1234567890"}
+
+			args, err := c.buildHelperArgs("1234", "127.0.0.1", "127.0.0.1:1234")
+			require.NoError(err)
+			assert.Equal(tt.wantArgs, args)
+
+			env, err := c.buildHelperEnv("1234", "127.0.0.1", "127.0.0.1:1234")
+			require.NoError(err)
+			assert.Equal([]string{"BOUNDARY_HOST=hst_1234567890", "MYSQL_HISTFILE=/dev/null"}, env)
+		})
+	}
+
+	t.Run("conflicting flag", func(t *testing.T) {
+		cfg, err := base.ParseCLIConfig(`connect_helper "a" {
+  exec = "a"
+  flag "target-id" {}
+}`)
+		require.NoError(t, err)
+		c := &Command{Command: base.NewCommand(nil), Func: "a", Helper: cfg.ConnectHelpers[0]}
+		c.Flags()
+		assert.Error(t, c.helperFlagErr)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		for _, d := range []string{
+			`connect_helper "MySQL" { exec = "mysql" }`,
+			`connect_helper "mysql" {}`,
+			`connect_helper "mysql" { exec = "mysql" args = ["{{.Ip"] }`,
+			`connect_helper "mysql" { exec = "mysql" } connect_helper "mysql" { exec = "mysql" }`,
+			`connect_helper "mysql" { exec = "mysql" flag "-db" {} }`,
+		} {
+			_, err := base.ParseCLIConfig(d)
+			assert.Error(t, err, d)
+		}
+	})
+}
@@ -74,8 +74,8 @@ func (h *httpFlags) defaultExec() string {
 func (h *httpFlags) buildArgs(c *Command, port, ip, addr string) ([]string, error) {
 	var args []string
 	host := h.flagHttpHost
-	if host == "" && c.sessionAuthzData.GetEndpoint() != "" {
-		hostUrl := c.sessionAuthzData.GetEndpoint()
+	if host == "" && c.sessionAuthzData.Endpoint != "" {
+		hostUrl := c.sessionAuthzData.Endpoint
 		u, err := url.Parse(hostUrl)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing endpoint URL: %w", err)
@@ -88,12 +88,20 @@ func (h *httpFlags) buildArgs(c *Command, port, ip, addr string) ([]string, erro
 			args = append(args, "-X", h.flagHttpMethod)
 		}
 		var uri string
-		if host != "" {
+		switch {
+		case c.listenerSocket != "":
+			// curl connects to the socket whatever the URL's host
+			args = append(args, "--unix-socket", c.listenerSocket)
+			if host == "" {
+				host = "localhost"
+			}
+			uri = fmt.Sprintf("%s://%s", h.flagHttpScheme, strings.TrimSuffix(host, "/"))
+		case host != "":
 			host = strings.TrimSuffix(host, "/")
 			args = append(args, "-H", fmt.Sprintf("Host: %s", host))
 			args = append(args, "--resolve", fmt.Sprintf("%s:%s:%s", host, port, ip))
 			uri = fmt.Sprintf("%s://%s:%s", h.flagHttpScheme, host, port)
-		} else {
+		default:
 			uri = fmt.Sprintf("%s://%s", h.flagHttpScheme, addr)
 		}
 		if h.flagHttpPath != "" {
@@ -0,0 +1,109 @@
+package connect
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// postgresSocketPrefix is the prefix of the file names of Postgres sockets;
+// the rest of the name is the port. psql is given the socket's directory and
+// port rather than its path.
+const postgresSocketPrefix = ".s.PGSQL."
+
+// parseSocketMode parses the octal file mode of a listen socket.
+func parseSocketMode(s string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("Invalid socket mode %q, which must be an octal permission mode such as 0600", s)
+	}
+	return os.FileMode(mode), nil
+}
+
+// parseSocketOwner parses the "user[:group]" owner of a listen socket, where
+// either may be a name or a numeric ID. An ID of -1 leaves the socket's
+// owner or group unchanged.
+func parseSocketOwner(s string) (uid, gid int, err error) {
+	uid, gid = -1, -1
+	if s == "" {
+		return uid, gid, nil
+	}
+	userName, groupName := s, ""
+	if i := strings.Index(s, ":"); i >= 0 {
+		userName, groupName = s[:i], s[i+1:]
+	}
+	if userName != "" {
+		if uid, err = strconv.Atoi(userName); err != nil {
+			u, err := user.Lookup(userName)
+			if err != nil {
+				return -1, -1, fmt.Errorf("Unable to find socket owner %q: %w", userName, err)
+			}
+			if uid, err = strconv.Atoi(u.Uid); err != nil {
+				return -1, -1, fmt.Errorf("Socket owner %q has no numeric user ID", userName)
+			}
+		}
+	}
+	if groupName != "" {
+		if gid, err = strconv.Atoi(groupName); err != nil {
+			g, err := user.LookupGroup(groupName)
+			if err != nil {
+				return -1, -1, fmt.Errorf("Unable to find socket group %q: %w", groupName, err)
+			}
+			if gid, err = strconv.Atoi(g.Gid); err != nil {
+				return -1, -1, fmt.Errorf("Socket group %q has no numeric group ID", groupName)
+			}
+		}
+	}
+	return uid, gid, nil
+}
+
+// postgresSocketDirAndPort returns the directory and port psql is given to
+// connect to the socket at path, whose file name must be .s.PGSQL.<port>.
+func postgresSocketDirAndPort(path string) (string, string, error) {
+	dir, name := filepath.Split(path)
+	port := strings.TrimPrefix(name, postgresSocketPrefix)
+	if port == name {
+		return "", "", fmt.Errorf("The file name of the socket must be %s<port>, e.g. %s5432, for psql to connect to it", postgresSocketPrefix, postgresSocketPrefix)
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", "", fmt.Errorf("The socket's file name %q doesn't end with a valid port", name)
+	}
+	if dir == "" {
+		dir = "."
+	}
+	return filepath.Clean(dir), port, nil
+}
+
+// socketProxyCommand returns a command line, for use as an ssh ProxyCommand,
+// which connects its standard input and output to the socket at path with
+// "boundary connect stdio".
+func socketProxyCommand(path string) (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("Unable to find the path of the boundary executable: %w", err)
+	}
+	if path == "" {
+		return "", errors.New("No socket path given")
+	}
+	cmd := strings.Join([]string{shellQuote(exe), "connect", "stdio", shellQuote(path)}, " ")
+	// ssh expands % tokens in the command
+	return strings.ReplaceAll(cmd, "%", "%%"), nil
+}
+
+// socketHostName returns the host name given to clients which reach the
+// proxy's socket through a command, and so don't connect to the name.
+func socketHostName(c *Command) string {
+	if c.sessionAuthzData.HostId != "" {
+		return c.sessionAuthzData.HostId
+	}
+	return "localhost"
+}
+
+// shellQuote quotes s as a single word for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,33 @@
+// +build !windows
+
+package connect
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+)
+
+// listenSocket listens on a Unix domain socket at path with the given mode
+// and owner. The socket is created without any permissions, so that nobody
+// can connect to it before its mode and owner are set.
+func listenSocket(path string, mode os.FileMode, uid, gid int) (*net.UnixListener, error) {
+	oldMask := syscall.Umask(0777)
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	syscall.Umask(oldMask)
+	if err != nil {
+		return nil, err
+	}
+	if uid != -1 || gid != -1 {
+		if err := os.Chown(path, uid, gid); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("error setting socket owner: %w", err)
+		}
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("error setting socket mode: %w", err)
+	}
+	return listener, nil
+}
@@ -0,0 +1,131 @@
+package connect
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSocketMode(t *testing.T) {
+	assert := assert.New(t)
+	mode, err := parseSocketMode("0600")
+	assert.NoError(err)
+	assert.Equal(os.FileMode(0600), mode)
+	mode, err = parseSocketMode("660")
+	assert.NoError(err)
+	assert.Equal(os.FileMode(0660), mode)
+
+	for _, s := range []string{"", "0800", "rw-------", "01777"} {
+		_, err := parseSocketMode(s)
+		assert.Error(err, s)
+	}
+}
+
+func TestParseSocketOwner(t *testing.T) {
+	assert := assert.New(t)
+	uid, gid, err := parseSocketOwner("")
+	assert.NoError(err)
+	assert.Equal(-1, uid)
+	assert.Equal(-1, gid)
+
+	uid, gid, err = parseSocketOwner("1000:100")
+	assert.NoError(err)
+	assert.Equal(1000, uid)
+	assert.Equal(100, gid)
+
+	uid, gid, err = parseSocketOwner(":100")
+	assert.NoError(err)
+	assert.Equal(-1, uid)
+	assert.Equal(100, gid)
+
+	_, _, err = parseSocketOwner("no-such-boundary-user")
+	assert.Error(err)
+	_, _, err = parseSocketOwner("0:no-such-boundary-group")
+	assert.Error(err)
+}
+
+func TestPostgresSocketDirAndPort(t *testing.T) {
+	assert := assert.New(t)
+	dir, port, err := postgresSocketDirAndPort("/run/user/1000/.s.PGSQL.5432")
+	assert.NoError(err)
+	assert.Equal("/run/user/1000", dir)
+	assert.Equal("5432", port)
+
+	dir, port, err = postgresSocketDirAndPort(".s.PGSQL.15432")
+	assert.NoError(err)
+	assert.Equal(".", dir)
+	assert.Equal("15432", port)
+
+	for _, path := range []string{"/tmp/postgres.sock", "/tmp/.s.PGSQL.", "/tmp/.s.PGSQL.99999"} {
+		_, _, err := postgresSocketDirAndPort(path)
+		assert.Error(err, path)
+	}
+}
+
+func TestSocketProxyCommand(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	assert.Equal(`'it'\''s'`, shellQuote("it's"))
+
+	cmd, err := socketProxyCommand("/tmp/100% boundary.sock")
+	require.NoError(err)
+	exe, err := os.Executable()
+	require.NoError(err)
+	assert.Equal(shellQuote(exe)+` connect stdio '/tmp/100%% boundary.sock'`, cmd)
+
+	c := &Command{sessionAuthzData: &apiproxy.SessionAuthorizationData{HostId: "hst_1234567890"}}
+	c.flagSshStyle = "ssh"
+	c.proxyCommand = "boundary connect stdio /tmp/boundary.sock"
+	assert.Equal([]string{
+		"-o", "ProxyCommand=boundary connect stdio /tmp/boundary.sock", "hst_1234567890",
+		"-o", "HostKeyAlias=hst_1234567890",
+	}, c.sshFlags.buildArgs(c, "", "", "/tmp/boundary.sock"))
+}
+
+func TestListenSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix domain sockets aren't supported on windows")
+	}
+	assert, require := assert.New(t), require.New(t)
+	dir, err := ioutil.TempDir("", "boundary-connect-")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "boundary.sock")
+
+	listener, err := listenSocket(path, 0640, -1, os.Getgid())
+	require.NoError(err)
+	info, err := os.Stat(path)
+	require.NoError(err)
+	assert.Equal(os.FileMode(0640), info.Mode().Perm())
+	assert.True(info.Mode()&os.ModeSocket != 0)
+
+	// The socket can't be created twice
+	_, err = listenSocket(path, 0600, -1, -1)
+	assert.Error(err)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			conn.Write([]byte(strconv.Itoa(42)))
+			conn.Close()
+		}
+	}()
+	conn, err := net.Dial("unix", path)
+	require.NoError(err)
+	b, err := ioutil.ReadAll(conn)
+	require.NoError(err)
+	assert.Equal("42", string(b))
+	conn.Close()
+
+	// Closing the listener removes the socket
+	require.NoError(listener.Close())
+	_, err = os.Stat(path)
+	assert.True(os.IsNotExist(err))
+}
@@ -0,0 +1,13 @@
+// +build windows
+
+package connect
+
+import (
+	"errors"
+	"net"
+	"os"
+)
+
+func listenSocket(string, os.FileMode, int, int) (*net.UnixListener, error) {
+	return nil, errors.New("listening on a Unix domain socket is not supported on Windows")
+}
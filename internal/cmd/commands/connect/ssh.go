@@ -1,15 +1,25 @@
 package connect
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"strings"
+	"time"
 
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/posener/complete"
+	"golang.org/x/crypto/ssh"
 )
 
 const (
 	sshSynopsis = "Authorize a session against a target and invoke an SSH client to connect"
+
+	// sshHostKeyTimeout bounds fetching the host key through the worker.
+	sshHostKeyTimeout = 30 * time.Second
 )
 
 func sshOptions(c *Command, set *base.FlagSets) {
@@ -31,10 +41,27 @@ func sshOptions(c *Command, set *base.FlagSets) {
 		Completion: complete.PredictNothing,
 		Usage:      `Specifies the username to pass through to the client`,
 	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:    "verify-host-key",
+		Target:  &c.flagSshVerifyHostKey,
+		EnvVar:  "BOUNDARY_CONNECT_SSH_VERIFY_HOST_KEY",
+		Default: true,
+		Usage:   `If set, the host's SSH host key is fetched by the worker and checked against the key pinned for the host, and the "ssh" style client is told to only trust that key. The first key fetched for a host is pinned. If the host presents a different key the connection is refused until the key is trusted with "boundary hosts rotate-ssh-host-key".`,
+	})
 }
 
 type sshFlags struct {
-	flagSshStyle string
+	flagSshStyle         string
+	flagSshVerifyHostKey bool
+
+	// knownHostsFile is the temporary known_hosts file holding the host's
+	// pinned key, if it was fetched.
+	knownHostsFile string
+
+	// proxyCommand, if set, is the command the client runs to reach the
+	// proxy's socket.
+	proxyCommand string
 }
 
 func (s *sshFlags) defaultExec() string {
@@ -46,13 +73,84 @@ func (s *sshFlags) buildArgs(c *Command, port, ip, addr string) []string {
 	var args []string
 	switch s.flagSshStyle {
 	case "ssh":
-		args = append(args, "-p", port, ip)
+		if s.proxyCommand != "" {
+			args = append(args, "-o", fmt.Sprintf("ProxyCommand=%s", s.proxyCommand), socketHostName(c))
+		} else {
+			args = append(args, "-p", port, ip)
+		}
 		args = append(args, "-o", fmt.Sprintf("HostKeyAlias=%s", c.sessionAuthzData.HostId))
+		if s.knownHostsFile != "" {
+			args = append(args, "-o", fmt.Sprintf("UserKnownHostsFile=%s", s.knownHostsFile))
+			args = append(args, "-o", "StrictHostKeyChecking=yes")
+		}
 	case "putty":
-		args = append(args, "-P", port, ip)
+		if s.proxyCommand != "" {
+			args = append(args, "-proxycmd", s.proxyCommand, socketHostName(c))
+		} else {
+			args = append(args, "-P", port, ip)
+		}
 	}
 	if c.flagUsername != "" {
 		args = append(args, "-l", c.flagUsername)
 	}
 	return args
 }
+
+// verifyHostKey fetches the SSH host key of the session's host through the
+// worker, and writes the key pinned for the host to a temporary known_hosts
+// file for the client to verify the host against. The returned function
+// removes the file. If the key can't be fetched the client falls back to
+// its own host key checking; a key which doesn't match the pinned key is an
+// error.
+func (s *sshFlags) verifyHostKey(c *Command, p *apiproxy.ClientProxy) (func(), error) {
+	noop := func() {}
+	if !s.flagSshVerifyHostKey || s.flagSshStyle != "ssh" {
+		return noop, nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, sshHostKeyTimeout)
+	defer cancel()
+	hostKey, err := p.SshHostKey(ctx)
+	if err != nil {
+		c.UI.Warn(fmt.Sprintf("Unable to verify the SSH host key of host %s: %s", c.sessionAuthzData.HostId, err))
+		return noop, nil
+	}
+	if hostKey.Mismatch() {
+		return noop, fmt.Errorf("The SSH host key of host %s has changed: it presented a key with fingerprint %s, but the key pinned for it has fingerprint %s. "+
+			"If the change is expected, trust the new key with \"boundary hosts rotate-ssh-host-key -id %s -fingerprint %s\".",
+			c.sessionAuthzData.HostId, hostKey.PresentedFingerprint, hostKey.Fingerprint, c.sessionAuthzData.HostId, hostKey.PresentedFingerprint)
+	}
+	line, err := knownHostsLine(c.sessionAuthzData.HostId, hostKey.HostKey)
+	if err != nil {
+		return noop, err
+	}
+
+	f, err := ioutil.TempFile("", "boundary-known-hosts-")
+	if err != nil {
+		return noop, fmt.Errorf("Error creating known hosts file: %w", err)
+	}
+	cleanup := func() { os.Remove(f.Name()) }
+	_, err = f.WriteString(line)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return noop, fmt.Errorf("Error writing known hosts file: %w", err)
+	}
+	s.knownHostsFile = f.Name()
+	return cleanup, nil
+}
+
+// knownHostsLine returns the known_hosts line trusting hostKey, in the SSH
+// wire format, for connections using hostId as their HostKeyAlias.
+func knownHostsLine(hostId string, hostKey []byte) (string, error) {
+	if hostId == "" {
+		return "", errors.New("No host ID in the session authorization data")
+	}
+	pk, err := ssh.ParsePublicKey(hostKey)
+	if err != nil {
+		return "", fmt.Errorf("Error parsing SSH host key: %w", err)
+	}
+	return fmt.Sprintf("%s %s", hostId, ssh.MarshalAuthorizedKey(pk)), nil
+}
@@ -0,0 +1,45 @@
+package connect
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSshKnownHosts(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	pk, err := ssh.NewPublicKey(pub)
+	require.NoError(err)
+
+	line, err := knownHostsLine("hst_1234567890", pk.Marshal())
+	require.NoError(err)
+	assert.True(strings.HasPrefix(line, "hst_1234567890 ssh-ed25519 "))
+	_, hosts, got, _, _, err := ssh.ParseKnownHosts([]byte(line))
+	require.NoError(err)
+	assert.Equal([]string{"hst_1234567890"}, hosts)
+	assert.Equal(pk.Marshal(), got.Marshal())
+
+	_, err = knownHostsLine("hst_1234567890", []byte("not a key"))
+	assert.Error(err)
+	_, err = knownHostsLine("", pk.Marshal())
+	assert.Error(err)
+
+	c := &Command{sessionAuthzData: &apiproxy.SessionAuthorizationData{HostId: "hst_1234567890"}}
+	c.flagSshStyle = "ssh"
+	assert.Equal([]string{"-p", "22", "127.0.0.1", "-o", "HostKeyAlias=hst_1234567890"}, c.sshFlags.buildArgs(c, "22", "127.0.0.1", "127.0.0.1:22"))
+	c.knownHostsFile = "/tmp/known_hosts"
+	assert.Equal([]string{
+		"-p", "22", "127.0.0.1",
+		"-o", "HostKeyAlias=hst_1234567890",
+		"-o", "UserKnownHostsFile=/tmp/known_hosts",
+		"-o", "StrictHostKeyChecking=yes",
+	}, c.sshFlags.buildArgs(c, "22", "127.0.0.1", "127.0.0.1:22"))
+}
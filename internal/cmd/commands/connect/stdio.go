@@ -0,0 +1,95 @@
+package connect
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*StdioCommand)(nil)
+	_ cli.CommandAutocomplete = (*StdioCommand)(nil)
+)
+
+// StdioCommand connects its standard input and output to the Unix domain
+// socket of a "boundary connect -listen-socket" proxy, for clients such as
+// ssh which can run a command to reach a host but can't connect to a socket.
+type StdioCommand struct {
+	*base.Command
+}
+
+func (c *StdioCommand) Synopsis() string {
+	return "Connect standard input and output to a connect proxy's socket"
+}
+
+func (c *StdioCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary connect stdio [options] <socket path>",
+		"",
+		`  Connect standard input and output to the Unix domain socket of a proxy started with "boundary connect -listen-socket". It's intended for use as an ssh ProxyCommand, which "boundary connect ssh -listen-socket" sets up.`,
+		"",
+		"  Example:",
+		"",
+		`      $ ssh -o ProxyCommand="boundary connect stdio /run/user/1000/boundary.sock" host`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *StdioCommand) Flags() *base.FlagSets {
+	return c.FlagSet(base.FlagSetNone)
+}
+
+func (c *StdioCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFiles("*")
+}
+
+func (c *StdioCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *StdioCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	args = f.Args()
+	switch len(args) {
+	case 1:
+	case 0:
+		c.PrintCliError(errors.New("A socket path must be provided"))
+		return base.CommandUserError
+	default:
+		c.PrintCliError(fmt.Errorf("Too many arguments (expected 1, got %d)", len(args)))
+		return base.CommandUserError
+	}
+
+	conn, err := net.Dial("unix", args[0])
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error connecting to socket: %w", err))
+		return base.CommandCliError
+	}
+	defer conn.Close()
+
+	go func() {
+		_, _ = io.Copy(conn, os.Stdin)
+		// Let the other end see the end of the input while still reading
+		// its output
+		if uc, ok := conn.(*net.UnixConn); ok {
+			uc.CloseWrite()
+		}
+	}()
+	if _, err := io.Copy(os.Stdout, conn); err != nil {
+		c.PrintCliError(fmt.Errorf("Error reading from socket: %w", err))
+		return base.CommandCliError
+	}
+	return base.CommandSuccess
+}
@@ -0,0 +1,122 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/bundle"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/schema"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+)
+
+// bundleKmsPurpose is the purpose of the kms block used to sign and verify
+// bundles and to wrap their secrets.
+const bundleKmsPurpose = "bundle"
+
+// getBundleWrapper returns the initialized wrapper of the kms block marked
+// for the "bundle" purpose in the file at path.
+func getBundleWrapper(ctx context.Context, path string) (wrapping.Wrapper, error) {
+	w, err := wrapper.GetWrapperFromPath(path, bundleKmsPurpose)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, fmt.Errorf("No %q block marked for %q purpose found in %s", "kms", bundleKmsPurpose, path)
+	}
+	if err := w.Init(ctx); err != nil {
+		return nil, fmt.Errorf("Could not initialize bundle kms: %w", err)
+	}
+	return w, nil
+}
+
+// connectForBundle sets up the server's KMSes and connects it to the
+// database named by the config.  It returns the database's schema version,
+// which must be the one supported by this binary.
+func connectForBundle(ctx context.Context, ui cli.Ui, srv *base.Server, conf *config.Config, dialect string) (int, error) {
+	if err := srv.SetupKMSes(ui, conf); err != nil {
+		return 0, err
+	}
+	if srv.RootKms == nil {
+		return 0, fmt.Errorf("Root KMS not found after parsing KMS blocks")
+	}
+	if conf.Controller == nil {
+		return 0, fmt.Errorf(`"controller" config block not found`)
+	}
+	if conf.Controller.Database == nil || conf.Controller.Database.Url == "" {
+		return 0, fmt.Errorf(`"url" not specified in "controller.database" config block`)
+	}
+	var err error
+	srv.DatabaseUrl, err = config.ParseAddress(conf.Controller.Database.Url)
+	if err != nil && err != config.ErrNotAUrl {
+		return 0, fmt.Errorf("Error parsing database url: %w", err)
+	}
+	if err := srv.ConnectToDatabase(dialect); err != nil {
+		return 0, fmt.Errorf("Error connecting to database: %w", err)
+	}
+
+	man, err := schema.NewManager(ctx, dialect, srv.Database.DB())
+	if err != nil {
+		return 0, fmt.Errorf("Error setting up schema manager: %w", err)
+	}
+	st, err := man.CurrentState(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Error getting database state: %w", err)
+	}
+	switch {
+	case !st.InitializationStarted:
+		return 0, fmt.Errorf("Database has not been initialized. Please use 'boundary database init' to initialize the boundary database.")
+	case st.Dirty:
+		return 0, fmt.Errorf("Database is in a bad state.  Please revert back to the last known good state.")
+	case st.DatabaseSchemaVersion != st.BinarySchemaVersion:
+		return 0, fmt.Errorf("Database schema version %d does not match the version %d supported by this binary. Please use 'boundary database migrate' first.", st.DatabaseSchemaVersion, st.BinarySchemaVersion)
+	}
+	return st.DatabaseSchemaVersion, nil
+}
+
+// bundleRepositories returns the repositories used to export and import
+// bundles.
+func bundleRepositories(srv *base.Server) (*bundle.Repositories, error) {
+	rw := db.New(srv.Database)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kms repository: %w", err)
+	}
+	kmsCache, err := kms.NewKms(kmsRepo, kms.WithLogger(srv.Logger.Named("kms")))
+	if err != nil {
+		return nil, fmt.Errorf("error creating kms cache: %w", err)
+	}
+	if err := kmsCache.AddExternalWrappers(
+		kms.WithRootWrapper(srv.RootKms),
+	); err != nil {
+		return nil, fmt.Errorf("error adding config keys to kms: %w", err)
+	}
+
+	repos := &bundle.Repositories{}
+	if repos.Iam, err = iam.NewRepository(rw, rw, kmsCache, iam.WithRandomReader(srv.SecureRandomReader)); err != nil {
+		return nil, fmt.Errorf("error creating iam repository: %w", err)
+	}
+	if repos.Password, err = password.NewRepository(rw, rw, kmsCache); err != nil {
+		return nil, fmt.Errorf("error creating password auth repository: %w", err)
+	}
+	if repos.Oidc, err = oidc.NewRepository(rw, rw, kmsCache); err != nil {
+		return nil, fmt.Errorf("error creating oidc auth repository: %w", err)
+	}
+	if repos.Static, err = static.NewRepository(rw, rw, kmsCache); err != nil {
+		return nil, fmt.Errorf("error creating static host repository: %w", err)
+	}
+	if repos.Target, err = target.NewRepository(rw, rw, kmsCache); err != nil {
+		return nil, fmt.Errorf("error creating target repository: %w", err)
+	}
+	return repos, nil
+}
@@ -0,0 +1,236 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/hashicorp/boundary/internal/bundle"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ExportCommand)(nil)
+	_ cli.CommandAutocomplete = (*ExportCommand)(nil)
+)
+
+type ExportCommand struct {
+	*base.Command
+	srv *base.Server
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+	bundleWrapper wrapping.Wrapper
+
+	flagConfig    string
+	flagConfigKms string
+	flagBundleKms string
+	flagLogLevel  string
+	flagLogFormat string
+	flagScopeId   string
+	flagOutput    string
+}
+
+func (c *ExportCommand) Synopsis() string {
+	return "Export Boundary's configuration to a signed bundle."
+}
+
+func (c *ExportCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database export [options]",
+		"",
+		"  Export the scopes, users, groups, roles, auth methods, accounts, host catalogs, hosts, host sets and targets of a scope and its descendants to a bundle which can be imported with \"boundary database import\":",
+		"",
+		"    $ boundary database export -config=/etc/boundary/controller.hcl -output=bundle.json",
+		"",
+		"  The bundle is signed, and OIDC client secrets in it are encrypted, with the kms block marked for the \"bundle\" purpose. Passwords, auth tokens, sessions and KMS keys are never exported.",
+		"",
+		"  For a full list of examples, please see the documentation.",
+	}) + c.Flags().Help()
+}
+
+func (c *ExportCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetNone)
+
+	f := set.NewFlagSet("Command options")
+	bundleCommonFlags(f, &c.flagConfig, &c.flagConfigKms, &c.flagBundleKms, &c.flagLogLevel, &c.flagLogFormat)
+
+	f = set.NewFlagSet("Export options")
+
+	f.StringVar(&base.StringVar{
+		Name:    "scope-id",
+		Target:  &c.flagScopeId,
+		Default: "global",
+		Usage:   "The scope to export, along with its descendants.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "output",
+		Target:     &c.flagOutput,
+		Completion: complete.PredictFiles("*.json"),
+		Usage:      "Path of the file to write the bundle to. If not set, the bundle is written to stdout.",
+	})
+
+	return set
+}
+
+// bundleCommonFlags adds the flags shared by the export and import commands.
+func bundleCommonFlags(f *base.FlagSet, configPath, configKms, bundleKms, logLevel, logFormat *string) {
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: configPath,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: configKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "bundle-kms",
+		Target: bundleKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "bundle" purpose, used to sign and verify the bundle and encrypt its secrets. If not set, will look for such a block in the main configuration file.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-level",
+		Target:     logLevel,
+		EnvVar:     "BOUNDARY_LOG_LEVEL",
+		Completion: complete.PredictSet("trace", "debug", "info", "warn", "err"),
+		Usage: "Log verbosity level. Supported values (in order of more detail to less) are " +
+			"\"trace\", \"debug\", \"info\", \"warn\", and \"err\".",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-format",
+		Target:     logFormat,
+		Completion: complete.PredictSet("standard", "json"),
+		Usage:      `Log format. Supported values are "standard" and "json".`,
+	})
+}
+
+func (c *ExportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ExportCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ExportCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	if c.configWrapper != nil {
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	defer func() {
+		if err := c.bundleWrapper.Finalize(c.Context); err != nil {
+			c.UI.Warn(fmt.Errorf("Error finalizing bundle kms: %w", err).Error())
+		}
+	}()
+
+	dialect := "postgres"
+
+	c.srv = base.NewServer(&base.Command{UI: c.UI})
+
+	if err := c.srv.SetupLogging(c.flagLogLevel, c.flagLogFormat, c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	schemaVersion, err := connectForBundle(c.Context, c.UI, c.srv, c.Config, dialect)
+	if err != nil {
+		c.UI.Error(base.WrapAtLength(err.Error()))
+		return base.CommandCliError
+	}
+	repos, err := bundleRepositories(c.srv)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	b, err := bundle.Export(c.Context, repos, c.bundleWrapper, schemaVersion, bundle.WithScopeId(c.flagScopeId))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error exporting configuration: %w", err).Error())
+		return base.CommandCliError
+	}
+	marshaled, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error marshaling bundle: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	if c.flagOutput == "" {
+		c.UI.Output(string(marshaled))
+		return base.CommandSuccess
+	}
+	if err := ioutil.WriteFile(c.flagOutput, marshaled, 0o600); err != nil {
+		c.UI.Error(fmt.Errorf("Error writing bundle: %w", err).Error())
+		return base.CommandCliError
+	}
+	c.UI.Info(fmt.Sprintf("Configuration of scope %s exported to %s.", b.ScopeId, c.flagOutput))
+	return base.CommandSuccess
+}
+
+func (c *ExportCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	c.Config, c.configWrapper, c.bundleWrapper, err = loadBundleConfig(c.Command, c.flagConfig, c.flagConfigKms, c.flagBundleKms)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
+
+// loadBundleConfig loads the config file and the config and bundle kms
+// wrappers for the export and import commands.
+func loadBundleConfig(c *base.Command, configPath, configKms, bundleKms string) (*config.Config, wrapping.Wrapper, wrapping.Wrapper, error) {
+	conf, configWrapper, err := loadConfig(c.Context, configPath, configKms)
+	if err != nil {
+		return nil, configWrapper, nil, err
+	}
+
+	bundlePath := configPath
+	if bundleKms != "" {
+		bundlePath = bundleKms
+	}
+	bundleWrapper, err := getBundleWrapper(c.Context, bundlePath)
+	if err != nil {
+		return nil, configWrapper, nil, err
+	}
+	return conf, configWrapper, bundleWrapper, nil
+}
@@ -0,0 +1,254 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/bundle"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ImportCommand)(nil)
+	_ cli.CommandAutocomplete = (*ImportCommand)(nil)
+)
+
+type ImportCommand struct {
+	*base.Command
+	srv *base.Server
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+	bundleWrapper wrapping.Wrapper
+
+	flagConfig    string
+	flagConfigKms string
+	flagBundleKms string
+	flagLogLevel  string
+	flagLogFormat string
+	flagBundle    string
+	flagDryRun    bool
+	flagMapIds    []string
+}
+
+func (c *ImportCommand) Synopsis() string {
+	return "Import Boundary's configuration from a signed bundle."
+}
+
+func (c *ImportCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database import [options]",
+		"",
+		"  Import a bundle created by \"boundary database export\":",
+		"",
+		"    $ boundary database import -config=/etc/boundary/controller.hcl -bundle=bundle.json",
+		"",
+		"  The bundle's signature is verified with the kms block marked for the \"bundle\" purpose, and the bundle must have been exported from a database with the same schema version. Every imported resource is created with a new id. OIDC auth methods are imported inactive and password accounts are imported without passwords.",
+		"",
+		"  Resources in the bundle can be mapped to existing resources instead of being imported, e.g. to import an org's projects into an existing org:",
+		"",
+		"    $ boundary database import -config=/etc/boundary/controller.hcl -bundle=bundle.json -map-id=o_1234567890=o_0987654321",
+		"",
+		"  An import is not atomic. Use -dry-run to validate a bundle before importing it.",
+		"",
+		"  For a full list of examples, please see the documentation.",
+	}) + c.Flags().Help()
+}
+
+func (c *ImportCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command options")
+	bundleCommonFlags(f, &c.flagConfig, &c.flagConfigKms, &c.flagBundleKms, &c.flagLogLevel, &c.flagLogFormat)
+
+	f = set.NewFlagSet("Import options")
+
+	f.StringVar(&base.StringVar{
+		Name:       "bundle",
+		Target:     &c.flagBundle,
+		Completion: complete.PredictFiles("*.json"),
+		Usage:      "Path of the bundle to import.",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "dry-run",
+		Target: &c.flagDryRun,
+		Usage:  "If set, the bundle is validated and the resources which would be imported are reported, but nothing is written to the database.",
+	})
+
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:   "map-id",
+		Target: &c.flagMapIds,
+		Usage:  `Maps the id of a resource in the bundle to the id of an existing resource, in the form "<bundle id>=<existing id>". The resource is not imported and references to it use the existing id. Can be specified multiple times.`,
+	})
+
+	return set
+}
+
+func (c *ImportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ImportCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ImportCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	if c.configWrapper != nil {
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	defer func() {
+		if err := c.bundleWrapper.Finalize(c.Context); err != nil {
+			c.UI.Warn(fmt.Errorf("Error finalizing bundle kms: %w", err).Error())
+		}
+	}()
+
+	idMap, err := parseIdMap(c.flagMapIds)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+	raw, err := ioutil.ReadFile(c.flagBundle)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error reading bundle: %w", err).Error())
+		return base.CommandUserError
+	}
+	var b bundle.Bundle
+	if err := json.Unmarshal(raw, &b); err != nil {
+		c.UI.Error(fmt.Errorf("Error parsing bundle: %w", err).Error())
+		return base.CommandUserError
+	}
+
+	dialect := "postgres"
+
+	c.srv = base.NewServer(&base.Command{UI: c.UI})
+
+	if err := c.srv.SetupLogging(c.flagLogLevel, c.flagLogFormat, c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	schemaVersion, err := connectForBundle(c.Context, c.UI, c.srv, c.Config, dialect)
+	if err != nil {
+		c.UI.Error(base.WrapAtLength(err.Error()))
+		return base.CommandCliError
+	}
+	repos, err := bundleRepositories(c.srv)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	res, err := bundle.Import(c.Context, repos, c.bundleWrapper, &b, schemaVersion,
+		bundle.WithDryRun(c.flagDryRun),
+		bundle.WithIdMap(idMap),
+	)
+	if res != nil {
+		c.printResult(res)
+	}
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error importing configuration: %w", err).Error())
+		return base.CommandCliError
+	}
+	return base.CommandSuccess
+}
+
+func (c *ImportCommand) printResult(res *bundle.Result) {
+	switch base.Format(c.UI) {
+	case "json":
+		out, err := base.JsonFormatter{}.Format(map[string]interface{}{
+			"dry_run": c.flagDryRun,
+			"created": res.Created,
+			"id_map":  res.IdMap,
+		})
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return
+		}
+		c.UI.Output(string(out))
+	default:
+		created := make(map[string]interface{}, len(res.Created))
+		for k, v := range res.Created {
+			created[k] = v
+		}
+		header := "Imported resources:"
+		if c.flagDryRun {
+			header = "Resources which would be imported (dry run):"
+		}
+		ret := []string{"", header}
+		if len(created) == 0 {
+			ret = append(ret, "  none")
+		} else {
+			ret = append(ret, base.WrapMap(2, 0, created))
+		}
+		if !c.flagDryRun && len(res.IdMap) > 0 {
+			ids := make(map[string]interface{}, len(res.IdMap))
+			for from, to := range res.IdMap {
+				if from != to {
+					ids[from] = to
+				}
+			}
+			if len(ids) > 0 {
+				ret = append(ret, "", "  Id Map:", base.WrapMap(4, 0, ids))
+			}
+		}
+		c.UI.Output(base.WrapForHelpText(ret))
+	}
+}
+
+func (c *ImportCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	// Validation
+	switch {
+	case len(c.flagBundle) == 0:
+		c.UI.Error("Must specify a bundle file using -bundle")
+		return base.CommandUserError
+	}
+
+	c.Config, c.configWrapper, c.bundleWrapper, err = loadBundleConfig(c.Command, c.flagConfig, c.flagConfigKms, c.flagBundleKms)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
+
+// parseIdMap parses -map-id values of the form "<bundle id>=<existing id>".
+func parseIdMap(values []string) (map[string]string, error) {
+	idMap := make(map[string]string, len(values))
+	for _, v := range values {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("Invalid -map-id value %q, expected <bundle id>=<existing id>", v)
+		}
+		if _, ok := idMap[kv[0]]; ok {
+			return nil, fmt.Errorf("Id %s is mapped more than once", kv[0])
+		}
+		idMap[kv[0]] = kv[1]
+	}
+	return idMap, nil
+}
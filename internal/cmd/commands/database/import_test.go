@@ -0,0 +1,58 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIdMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "empty",
+			want: map[string]string{},
+		},
+		{
+			name:   "valid",
+			values: []string{"o_1234567890=o_0987654321", "p_1234567890=p_0987654321"},
+			want: map[string]string{
+				"o_1234567890": "o_0987654321",
+				"p_1234567890": "p_0987654321",
+			},
+		},
+		{
+			name:    "missing-separator",
+			values:  []string{"o_1234567890"},
+			wantErr: `Invalid -map-id value "o_1234567890"`,
+		},
+		{
+			name:    "missing-existing-id",
+			values:  []string{"o_1234567890="},
+			wantErr: `Invalid -map-id value "o_1234567890="`,
+		},
+		{
+			name:    "duplicate",
+			values:  []string{"o_1234567890=o_0987654321", "o_1234567890=o_1111111111"},
+			wantErr: "Id o_1234567890 is mapped more than once",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := parseIdMap(tt.values)
+			if tt.wantErr != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
@@ -35,6 +35,7 @@ type MigrateCommand struct {
 	flagLogFormat          string
 	flagMigrationUrl       string
 	flagAllowDevMigrations bool
+	flagDryRun             bool
 }
 
 func (c *MigrateCommand) Synopsis() string {
@@ -49,6 +50,10 @@ func (c *MigrateCommand) Help() string {
 		"",
 		"    $ boundary database migrate -config=/etc/boundary/controller.hcl",
 		"",
+		"  Show the migrations which would be run, and their SQL, without running them:",
+		"",
+		"    $ boundary database migrate -config=/etc/boundary/controller.hcl -dry-run",
+		"",
 		"  For a full list of examples, please see the documentation.",
 	}) + c.Flags().Help()
 }
@@ -102,6 +107,12 @@ func (c *MigrateCommand) Flags() *base.FlagSets {
 		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database for migration. This can allow different permissions for the user running initialization or migration vs. normal operation. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
 	})
 
+	f.BoolVar(&base.BoolVar{
+		Name:   "dry-run",
+		Target: &c.flagDryRun,
+		Usage:  "If set, the migrations which would be run are printed along with their SQL, but are not run.",
+	})
+
 	return set
 }
 
@@ -180,6 +191,15 @@ func (c *MigrateCommand) Run(args []string) (retCode int) {
 		return base.CommandUserError
 	}
 
+	if c.flagDryRun {
+		clean, errCode := previewMigrations(c.Context, c.UI, dialect, migrationUrl)
+		defer clean()
+		if errCode > 0 {
+			return errCode
+		}
+		return base.CommandSuccess
+	}
+
 	clean, errCode := migrateDatabase(c.Context, c.UI, dialect, migrationUrl, true)
 	defer clean()
 	if errCode != 0 {
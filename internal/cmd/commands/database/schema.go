@@ -0,0 +1,201 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db/schema"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+)
+
+// loadConfig loads the config file at configPath, decrypting it with the kms
+// block marked for the "config" purpose in configKms, or in the config file
+// itself if configKms is empty.  The returned wrapper, if not nil, must be
+// finalized by the caller.
+func loadConfig(ctx context.Context, configPath, configKms string) (*config.Config, wrapping.Wrapper, error) {
+	if configPath == "" {
+		return nil, nil, fmt.Errorf("Must specify a config file using -config")
+	}
+	wrapperPath := configPath
+	if configKms != "" {
+		wrapperPath = configKms
+	}
+	configWrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		return nil, nil, err
+	}
+	if configWrapper != nil {
+		if err := configWrapper.Init(ctx); err != nil {
+			return nil, nil, fmt.Errorf("Could not initialize kms: %w", err)
+		}
+	}
+	conf, err := config.LoadFile(configPath, configWrapper)
+	if err != nil {
+		return nil, configWrapper, fmt.Errorf("Error parsing config: %w", err)
+	}
+	return conf, configWrapper, nil
+}
+
+// migrationUrl returns the URL used to connect to the database for schema
+// operations: the -migration-url flag, the config's "migration_url" or its
+// "url", in that order.
+func migrationUrl(conf *config.Config, flagMigrationUrl string) (string, error) {
+	if conf.Controller == nil {
+		return "", fmt.Errorf(`"controller" config block not found`)
+	}
+	if conf.Controller.Database == nil {
+		return "", fmt.Errorf(`"controller.database" config block not found`)
+	}
+	urlToParse := conf.Controller.Database.MigrationUrl
+	if flagMigrationUrl != "" {
+		urlToParse = flagMigrationUrl
+	}
+	// Fallback to using database URL for everything
+	if urlToParse == "" {
+		urlToParse = conf.Controller.Database.Url
+	}
+	if urlToParse == "" {
+		return "", fmt.Errorf(`neither "url" nor "migration_url" correctly set in "database" config block nor was the "migration-url" flag used`)
+	}
+	u, err := config.ParseAddress(urlToParse)
+	if err != nil && err != config.ErrNotAUrl {
+		return "", fmt.Errorf("Error parsing migration url: %w", err)
+	}
+	return u, nil
+}
+
+// openSchemaManager connects to the database and returns a schema manager
+// holding a shared lock on it.  It owns the reporting to the UI of any
+// errors.  Returns a cleanup function which must be called even if an error
+// is returned and an error code where a non-zero value indicates an error
+// happened.
+func openSchemaManager(ctx context.Context, ui cli.Ui, dialect, u string) (*schema.Manager, func(), int) {
+	noop := func() {}
+	dBase, err := sql.Open(dialect, u)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error establishing db connection: %w", err).Error())
+		return nil, noop, 2
+	}
+	if err := dBase.PingContext(ctx); err != nil {
+		ui.Error(fmt.Sprintf("Unable to connect to the database at %q", u))
+		return nil, noop, 2
+	}
+	man, err := schema.NewManager(ctx, dialect, dBase)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error setting up schema manager: %w", err).Error())
+		return nil, noop, 2
+	}
+	// A shared lock prevents a migration from running while the schema is
+	// being inspected.
+	if err := man.SharedLock(ctx); err != nil {
+		ui.Error("Unable to capture a lock on the database.")
+		return nil, noop, 2
+	}
+	unlock := func() {
+		// We don't report anything since this should resolve itself anyways.
+		_ = man.SharedUnlock(ctx)
+	}
+	return man, unlock, 0
+}
+
+// previewMigrations reports the migrations which "database migrate" would
+// run, without running them.  It owns the reporting to the UI of any errors.
+// Returns a cleanup function which must be called even if an error is
+// returned and an error code where a non-zero value indicates an error
+// happened.
+func previewMigrations(ctx context.Context, ui cli.Ui, dialect, u string) (func(), int) {
+	man, unlock, errCode := openSchemaManager(ctx, ui, dialect, u)
+	if errCode != 0 {
+		return unlock, errCode
+	}
+	st, err := man.CurrentState(ctx)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error getting database state: %w", err).Error())
+		return unlock, 2
+	}
+	if !st.InitializationStarted {
+		ui.Output(base.WrapAtLength("Database has not been initialized. Please use 'boundary database init' to initialize the boundary database."))
+		return unlock, -1
+	}
+	if st.Dirty {
+		ui.Error(base.WrapAtLength("Database is in a bad state.  Please revert back to the last known good state."))
+		return unlock, 2
+	}
+	pending, err := man.PendingMigrations(ctx)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error getting pending migrations: %w", err).Error())
+		return unlock, 2
+	}
+
+	switch base.Format(ui) {
+	case "json":
+		type migration struct {
+			Version    int    `json:"version"`
+			Statements string `json:"statements"`
+		}
+		out := map[string]interface{}{
+			"database_schema_version": st.DatabaseSchemaVersion,
+			"binary_schema_version":   st.BinarySchemaVersion,
+		}
+		migrations := make([]migration, 0, len(pending))
+		for _, m := range pending {
+			migrations = append(migrations, migration{Version: m.Version, Statements: m.Statements})
+		}
+		out["pending_migrations"] = migrations
+		b, err := base.JsonFormatter{}.Format(out)
+		if err != nil {
+			ui.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return unlock, 2
+		}
+		ui.Output(string(b))
+	default:
+		if len(pending) == 0 {
+			ui.Info(fmt.Sprintf("Database is at schema version %d; no migrations are pending.", st.DatabaseSchemaVersion))
+			return unlock, 0
+		}
+		ui.Info(fmt.Sprintf("Dry run: %d migrations would be run to migrate the database from schema version %d to %d.", len(pending), st.DatabaseSchemaVersion, st.BinarySchemaVersion))
+		for _, m := range pending {
+			ui.Output(fmt.Sprintf("\n-- Migration to version %d\n%s", m.Version, m.Statements))
+		}
+	}
+	return unlock, 0
+}
+
+// StatusInfo is the status of a database's schema.
+type StatusInfo struct {
+	Initialized           bool `json:"initialized"`
+	Dirty                 bool `json:"dirty"`
+	DatabaseSchemaVersion int  `json:"database_schema_version"`
+	BinarySchemaVersion   int  `json:"binary_schema_version"`
+	PendingMigrations     int  `json:"pending_migrations"`
+}
+
+func generateStatusTableOutput(in *StatusInfo) string {
+	nonAttributeMap := map[string]interface{}{
+		"Initialized":             in.Initialized,
+		"Dirty":                   in.Dirty,
+		"Database Schema Version": in.DatabaseSchemaVersion,
+		"Binary Schema Version":   in.BinarySchemaVersion,
+		"Pending Migrations":      in.PendingMigrations,
+	}
+
+	maxLength := 0
+	for k := range nonAttributeMap {
+		if len(k) > maxLength {
+			maxLength = len(k)
+		}
+	}
+
+	ret := []string{
+		"",
+		"Database schema status:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	return base.WrapForHelpText(ret)
+}
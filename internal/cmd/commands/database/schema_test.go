@@ -0,0 +1,80 @@
+package database
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationUrl(t *testing.T) {
+	require.NoError(t, os.Setenv("BOUNDARY_TEST_MIGRATION_URL", "postgres://env"))
+	defer os.Unsetenv("BOUNDARY_TEST_MIGRATION_URL")
+	tests := []struct {
+		name    string
+		db      *config.Database
+		flag    string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "missing-database",
+			wantErr: `"controller.database" config block not found`,
+		},
+		{
+			name:    "missing-urls",
+			db:      &config.Database{},
+			wantErr: `neither "url" nor "migration_url"`,
+		},
+		{
+			name: "url",
+			db:   &config.Database{Url: "postgres://url"},
+			want: "postgres://url",
+		},
+		{
+			name: "migration-url",
+			db:   &config.Database{Url: "postgres://url", MigrationUrl: "postgres://migration"},
+			want: "postgres://migration",
+		},
+		{
+			name: "flag",
+			db:   &config.Database{Url: "postgres://url", MigrationUrl: "postgres://migration"},
+			flag: "postgres://flag",
+			want: "postgres://flag",
+		},
+		{
+			name: "env",
+			db:   &config.Database{MigrationUrl: "env://BOUNDARY_TEST_MIGRATION_URL"},
+			want: "postgres://env",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			conf := &config.Config{Controller: &config.Controller{Database: tt.db}}
+			got, err := migrationUrl(conf, tt.flag)
+			if tt.wantErr != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestGenerateDriftTableOutput(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("Database schema matches the schema defined by this binary.", generateDriftTableOutput(&schema.Drift{}))
+
+	got := generateDriftTableOutput(&schema.Drift{
+		Missing:    []string{"trigger iam_scope.immutable_columns"},
+		Unexpected: []string{"column iam_scope.drifted text"},
+	})
+	assert.Contains(got, "Missing from the database:\n    trigger iam_scope.immutable_columns")
+	assert.Contains(got, "Not defined by this binary:\n    column iam_scope.drifted text")
+}
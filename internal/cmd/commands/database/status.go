@@ -0,0 +1,167 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*StatusCommand)(nil)
+	_ cli.CommandAutocomplete = (*StatusCommand)(nil)
+)
+
+type StatusCommand struct {
+	*base.Command
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig       string
+	flagConfigKms    string
+	flagMigrationUrl string
+}
+
+func (c *StatusCommand) Synopsis() string {
+	return "Show the status of Boundary's database schema."
+}
+
+func (c *StatusCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database status [options]",
+		"",
+		"  Show whether Boundary's database has been initialized, its current schema version, the schema version supported by this binary, and whether the schema is dirty from a failed migration:",
+		"",
+		"    $ boundary database status -config=/etc/boundary/controller.hcl",
+		"",
+		"  For a full list of examples, please see the documentation.",
+	}) + c.Flags().Help()
+}
+
+func (c *StatusCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command options")
+	schemaCommonFlags(f, &c.flagConfig, &c.flagConfigKms, &c.flagMigrationUrl)
+
+	return set
+}
+
+// schemaCommonFlags adds the flags shared by the status and verify commands.
+func schemaCommonFlags(f *base.FlagSet, configPath, configKms, migrationUrl *string) {
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: configPath,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: configKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "migration-url",
+		Target: migrationUrl,
+		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
+	})
+}
+
+func (c *StatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *StatusCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *StatusCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	if c.configWrapper != nil {
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+
+	dialect := "postgres"
+
+	u, err := migrationUrl(c.Config, c.flagMigrationUrl)
+	if err != nil {
+		c.UI.Error(base.WrapAtLength(err.Error()))
+		return base.CommandUserError
+	}
+
+	man, clean, errCode := openSchemaManager(c.Context, c.UI, dialect, u)
+	defer clean()
+	if errCode != 0 {
+		return errCode
+	}
+	st, err := man.CurrentState(c.Context)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error getting database state: %w", err).Error())
+		return base.CommandCliError
+	}
+	pending, err := man.PendingMigrations(c.Context)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error getting pending migrations: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	info := &StatusInfo{
+		Initialized:           st.InitializationStarted,
+		Dirty:                 st.Dirty,
+		DatabaseSchemaVersion: st.DatabaseSchemaVersion,
+		BinarySchemaVersion:   st.BinarySchemaVersion,
+		PendingMigrations:     len(pending),
+	}
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(info)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return base.CommandCliError
+		}
+		c.UI.Output(string(b))
+	default:
+		c.UI.Output(generateStatusTableOutput(info))
+	}
+	return base.CommandSuccess
+}
+
+func (c *StatusCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	c.Config, c.configWrapper, err = loadConfig(c.Context, c.flagConfig, c.flagConfigKms)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db/schema"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*VerifyCommand)(nil)
+	_ cli.CommandAutocomplete = (*VerifyCommand)(nil)
+)
+
+type VerifyCommand struct {
+	*base.Command
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig       string
+	flagConfigKms    string
+	flagMigrationUrl string
+}
+
+func (c *VerifyCommand) Synopsis() string {
+	return "Verify Boundary's database schema matches the schema defined by this binary."
+}
+
+func (c *VerifyCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database verify [options]",
+		"",
+		"  Verify the tables, views, sequences, columns, constraints, indexes, triggers, functions and domains of Boundary's database match those defined by this binary's migrations up to the database's schema version, to catch drift before an upgrade:",
+		"",
+		"    $ boundary database verify -config=/etc/boundary/controller.hcl",
+		"",
+		"  The expected schema is built by running the migrations in a temporary schema within a transaction which is rolled back, so the database is not modified. The command exits with a non-zero status if drift is found.",
+		"",
+		"  For a full list of examples, please see the documentation.",
+	}) + c.Flags().Help()
+}
+
+func (c *VerifyCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command options")
+	schemaCommonFlags(f, &c.flagConfig, &c.flagConfigKms, &c.flagMigrationUrl)
+
+	return set
+}
+
+func (c *VerifyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *VerifyCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *VerifyCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	if c.configWrapper != nil {
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+
+	dialect := "postgres"
+
+	u, err := migrationUrl(c.Config, c.flagMigrationUrl)
+	if err != nil {
+		c.UI.Error(base.WrapAtLength(err.Error()))
+		return base.CommandUserError
+	}
+
+	man, clean, errCode := openSchemaManager(c.Context, c.UI, dialect, u)
+	defer clean()
+	if errCode != 0 {
+		return errCode
+	}
+	drift, err := man.Verify(c.Context)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error verifying database schema: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(map[string]interface{}{
+			"verified":   drift.Empty(),
+			"missing":    drift.Missing,
+			"unexpected": drift.Unexpected,
+		})
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return base.CommandCliError
+		}
+		c.UI.Output(string(b))
+	default:
+		c.UI.Output(generateDriftTableOutput(drift))
+	}
+	if !drift.Empty() {
+		return base.CommandCliError
+	}
+	return base.CommandSuccess
+}
+
+func (c *VerifyCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	c.Config, c.configWrapper, err = loadConfig(c.Context, c.flagConfig, c.flagConfigKms)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
+
+func generateDriftTableOutput(d *schema.Drift) string {
+	if d.Empty() {
+		return "Database schema matches the schema defined by this binary."
+	}
+	ret := []string{
+		"",
+		"Database schema has drifted from the schema defined by this binary.",
+	}
+	if len(d.Missing) > 0 {
+		ret = append(ret, "", "  Missing from the database:")
+		for _, m := range d.Missing {
+			ret = append(ret, "    "+m)
+		}
+	}
+	if len(d.Unexpected) > 0 {
+		ret = append(ret, "", "  Not defined by this binary:")
+		for _, u := range d.Unexpected {
+			ret = append(ret, "    "+u)
+		}
+	}
+	return base.WrapForHelpText(ret)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/groups"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 	"github.com/mitchellh/go-wordwrap"
 )
 
@@ -17,10 +18,13 @@ func init() {
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 type extraCmdVars struct {
 	flagMembers []string
+	rmr         *groups.ResolvedMembersResult
+	flagOwner   string
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
@@ -28,6 +32,9 @@ func extraActionsFlagsMapFuncImpl() map[string][]string {
 		"add-members":    {"id", "member", "version"},
 		"remove-members": {"id", "member", "version"},
 		"set-members":    {"id", "member", "version"},
+
+		"read-resolved-members": {"id"},
+		"transfer-ownership":    {"id", "owner"},
 	}
 }
 
@@ -45,6 +52,12 @@ func extraSynopsisFuncImpl(c *Command) string {
 		}
 		return wordwrap.WrapString(fmt.Sprintf("%s a group", in), base.TermWidth)
 
+	case "read-resolved-members":
+		return wordwrap.WrapString("Read the users that are members of a group, including through nested groups", base.TermWidth)
+
+	case "transfer-ownership":
+		return "Transfer ownership of the specified group to another user"
+
 	default:
 		return ""
 	}
@@ -60,7 +73,7 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 		return base.WrapForHelpText([]string{
 			"Usage: boundary groups add-members [options] [args]",
 			"",
-			`  Adds members (users) to a group given its ID. The "user" flag can be specified multiple times. Example:`,
+			`  Adds members (users or groups) to a group given its ID. The "member" flag can be specified multiple times. The members of a group that's added are members of this group as well. Example:`,
 			"",
 			`    $ boundary groups add-members -id g_1234567890 -user u_1234567890`,
 			"",
@@ -71,7 +84,7 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 		return base.WrapForHelpText([]string{
 			"Usage: boundary groups set-members [options] [args]",
 			"",
-			`  Sets the complete set of members (users) on a group given its ID. The "user" flag can be specified multiple times. Example:`,
+			`  Sets the complete set of members (users or groups) on a group given its ID. The "member" flag can be specified multiple times. Example:`,
 			"",
 			`    $ boundary groups set-principals -id g_1234567890 -user u_anon -user u_1234567890`,
 			"",
@@ -82,12 +95,34 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 		return base.WrapForHelpText([]string{
 			"Usage: boundary groups remove-members [options] [args]",
 			"",
-			`  Removes members (users) from a group given its ID. The "user" flag can be specified multiple times. Example:`,
+			`  Removes members (users or groups) from a group given its ID. The "member" flag can be specified multiple times. Example:`,
 			"",
 			`    $ boundary groups remove-members -id g_1234567890 -user u_1234567890`,
 			"",
 			"",
 		})
+
+	case "read-resolved-members":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary groups read-resolved-members [options] [args]",
+			"",
+			`  Reads the users that are members of a group given its ID, either directly or through the groups nested in it. Each user lists the groups it's a direct member of. Example:`,
+			"",
+			`    $ boundary groups read-resolved-members -id g_1234567890`,
+			"",
+			"",
+		})
+
+	case "transfer-ownership":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary groups transfer-ownership [options] [args]",
+			"",
+			`  This command makes another user the owner of a group. Grants using the "{{owner}}" template match the resources a user owns. Example:`,
+			"",
+			`    $ boundary groups transfer-ownership -id g_1234567890 -owner u_1234567890`,
+			"",
+			"",
+		})
 	}
 	return helpStr + c.Flags().Help()
 }
@@ -99,8 +134,10 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 			f.StringSliceVar(&base.StringSliceVar{
 				Name:   "member",
 				Target: &c.flagMembers,
-				Usage:  "The members (users) to add, remove, or set. May be specified multiple times.",
+				Usage:  "The members (users or groups) to add, remove, or set. May be specified multiple times.",
 			})
+		case "owner":
+			common.PopulateOwnerFlag(f, &c.flagOwner, "group")
 		}
 	}
 }
@@ -123,6 +160,12 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]groups.Opt
 				c.flagMembers = nil
 			}
 		}
+
+	case "transfer-ownership":
+		if c.flagOwner == "" {
+			c.UI.Error("No owner supplied via -owner")
+			return false
+		}
 	}
 
 	return true
@@ -136,6 +179,13 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		return groupClient.SetMembers(c.Context, c.FlagId, version, c.flagMembers, opts...)
 	case "remove-members":
 		return groupClient.RemoveMembers(c.Context, c.FlagId, version, c.flagMembers, opts...)
+	case "read-resolved-members":
+		var err error
+		c.plural = "resolved members of group"
+		c.rmr, err = groupClient.ReadResolvedMembers(c.Context, c.FlagId, opts...)
+		return nil, err
+	case "transfer-ownership":
+		return groupClient.TransferOwnership(c.Context, c.FlagId, c.flagOwner, opts...)
 	}
 	return origResult, origError
 }
@@ -214,6 +264,9 @@ func printItemTable(result api.GenericResult) string {
 	if item.Description != "" {
 		nonAttributeMap["Description"] = item.Description
 	}
+	if item.OwnerId != "" {
+		nonAttributeMap["Owner ID"] = item.OwnerId
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -224,6 +277,9 @@ func printItemTable(result api.GenericResult) string {
 				"ID":       member.Id,
 				"Scope ID": member.ScopeId,
 			}
+			if member.Type != "" {
+				m["Type"] = member.Type
+			}
 			groupMaps = append(groupMaps, m)
 		}
 		if l := len("Scope ID"); l > maxLength {
@@ -268,3 +324,53 @@ func printItemTable(result api.GenericResult) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "read-resolved-members":
+		item := c.rmr.GetItem().(*groups.ResolvedMembers)
+
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printResolvedMembersTable(item))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.rmr); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+
+		default:
+			return false, fmt.Errorf("Unknown format %q; valid formats are \"table\" and \"json\"", base.Format(c.UI))
+		}
+	}
+
+	return false, nil
+}
+
+func printResolvedMembersTable(item *groups.ResolvedMembers) string {
+	if len(item.Members) == 0 {
+		return fmt.Sprintf("No members found for group %s", item.GroupId)
+	}
+
+	ret := []string{
+		"",
+		"Resolved members:",
+		fmt.Sprintf("  Group ID:               %s", item.GroupId),
+	}
+	for _, m := range item.Members {
+		ret = append(ret,
+			"",
+			fmt.Sprintf("  ID:                     %s", m.Id),
+			fmt.Sprintf("    Scope ID:             %s", m.ScopeId),
+		)
+		if len(m.GroupIds) > 0 {
+			ret = append(ret,
+				fmt.Sprintf("    Group IDs:            %s", strings.Join(m.GroupIds, ", ")),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -8,8 +8,37 @@ import (
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/hostcatalogs"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 )
 
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+}
+
+type extraCmdVars struct {
+	flagLabels []string
+	labels     map[string]string
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"set-labels": {"id", "label"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "set-labels":
+		return "Set the labels on the specified host catalog"
+	default:
+		return ""
+	}
+}
+
 func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 	var helpStr string
 	switch c.Func {
@@ -49,12 +78,52 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
+	case "set-labels":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary host-catalogs set-labels [options] [args]",
+			"",
+			`  This command sets the complete set of labels on a host catalog, which grants can match on. Any labels not given are removed. Setting "null" removes all labels. The "label" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary host-catalogs set-labels -id hcst_1234567890 -label env=prod -label team=db`,
+			"",
+			"",
+		})
 	default:
 		helpStr = helpMap["base"]()
 	}
 	return helpStr + c.Flags().Help()
 }
 
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "label":
+			common.PopulateLabelFlag(f, &c.flagLabels, "host catalog")
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, _ *[]hostcatalogs.Option) bool {
+	switch c.Func {
+	case "set-labels":
+		labels, err := common.ParseLabels(c.flagLabels)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return false
+		}
+		c.labels = labels
+	}
+	return true
+}
+
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, hostcatalogClient *hostcatalogs.Client, _ uint32, opts []hostcatalogs.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "set-labels":
+		return hostcatalogClient.SetLabels(c.Context, c.FlagId, c.labels, opts...)
+	}
+	return origResult, origError
+}
+
 func (c *Command) printListTable(items []*hostcatalogs.HostCatalog) string {
 	if len(items) == 0 {
 		return "No host catalogs found"
@@ -163,6 +232,14 @@ func printItemTable(result api.GenericResult) string {
 		)
 	}
 
+	if len(item.Labels) > 0 {
+		ret = append(ret,
+			"",
+			"  Labels:",
+			common.LabelsForOutput(item.Labels, maxLength),
+		)
+	}
+
 	if len(item.AuthorizedCollectionActions) > 0 {
 		keys := make([]string, 0, len(item.AuthorizedCollectionActions))
 		for k := range item.AuthorizedCollectionActions {
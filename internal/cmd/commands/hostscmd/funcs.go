@@ -7,8 +7,46 @@ import (
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/hosts"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/posener/complete"
 )
 
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+}
+
+type extraCmdVars struct {
+	flagFingerprint string
+	flagLabels      []string
+	labels          map[string]string
+	flagOwner       string
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"rotate-ssh-host-key": {"id", "fingerprint"},
+		"set-labels":          {"id", "label"},
+		"transfer-ownership":  {"id", "owner"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "rotate-ssh-host-key":
+		return "Trust the pending SSH host key of the specified host"
+	case "set-labels":
+		return "Set the labels on the specified host"
+	case "transfer-ownership":
+		return "Transfer ownership of the specified host to another user"
+	default:
+		return common.SynopsisFunc(c.Func, "host")
+	}
+}
+
 func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 	var helpStr string
 	switch c.Func {
@@ -48,12 +86,97 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
+	case "rotate-ssh-host-key":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary hosts rotate-ssh-host-key [options] [args]",
+			"",
+			"  This command trusts the SSH host key a host presented in place of its pinned key, which is shown in the host's pending_ssh_host_key_fingerprint attribute. The pending key's fingerprint must be given. Example:",
+			"",
+			"    Trust the pending SSH host key of a host:",
+			"",
+			`      $ boundary hosts rotate-ssh-host-key -id hst_1234567890 -fingerprint SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8`,
+			"",
+			"",
+		})
+	case "set-labels":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary hosts set-labels [options] [args]",
+			"",
+			`  This command sets the complete set of labels on a host, which grants can match on. Any labels not given are removed. Setting "null" removes all labels. The "label" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary hosts set-labels -id hst_1234567890 -label env=prod -label team=db`,
+			"",
+			"",
+		})
+	case "transfer-ownership":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary hosts transfer-ownership [options] [args]",
+			"",
+			`  This command makes another user the owner of a host. Grants using the "{{owner}}" template match the resources a user owns. Example:`,
+			"",
+			`    $ boundary hosts transfer-ownership -id hst_1234567890 -owner u_1234567890`,
+			"",
+			"",
+		})
 	default:
 		helpStr = helpMap[c.Func]()
 	}
 	return helpStr + c.Flags().Help()
 }
 
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "fingerprint":
+			f.StringVar(&base.StringVar{
+				Name:       "fingerprint",
+				Target:     &c.flagFingerprint,
+				Completion: complete.PredictAnything,
+				Usage:      "The SHA256 fingerprint of the pending SSH host key to trust.",
+			})
+		case "label":
+			common.PopulateLabelFlag(f, &c.flagLabels, "host")
+		case "owner":
+			common.PopulateOwnerFlag(f, &c.flagOwner, "host")
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, _ *[]hosts.Option) bool {
+	switch c.Func {
+	case "rotate-ssh-host-key":
+		if c.flagFingerprint == "" {
+			c.UI.Error("No fingerprint supplied via -fingerprint")
+			return false
+		}
+	case "set-labels":
+		labels, err := common.ParseLabels(c.flagLabels)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return false
+		}
+		c.labels = labels
+	case "transfer-ownership":
+		if c.flagOwner == "" {
+			c.UI.Error("No owner supplied via -owner")
+			return false
+		}
+	}
+	return true
+}
+
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, hostClient *hosts.Client, _ uint32, opts []hosts.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "rotate-ssh-host-key":
+		return hostClient.RotateSshHostKey(c.Context, c.FlagId, c.flagFingerprint, opts...)
+	case "set-labels":
+		return hostClient.SetLabels(c.Context, c.FlagId, c.labels, opts...)
+	case "transfer-ownership":
+		return hostClient.TransferOwnership(c.Context, c.FlagId, c.flagOwner, opts...)
+	}
+	return origResult, origError
+}
+
 func (c *Command) printListTable(items []*hosts.Host) string {
 	if len(items) == 0 {
 		return "No host sets found"
@@ -132,6 +255,9 @@ func printItemTable(result api.GenericResult) string {
 	if item.Description != "" {
 		nonAttributeMap["Description"] = item.Description
 	}
+	if item.OwnerId != "" {
+		nonAttributeMap["Owner ID"] = item.OwnerId
+	}
 	if item.HostCatalogId != "" {
 		nonAttributeMap["Host Catalog ID"] = item.HostCatalogId
 	}
@@ -160,6 +286,14 @@ func printItemTable(result api.GenericResult) string {
 		)
 	}
 
+	if len(item.Labels) > 0 {
+		ret = append(ret,
+			"",
+			"  Labels:",
+			common.LabelsForOutput(item.Labels, maxLength),
+		)
+	}
+
 	if len(item.HostSetIds) > 0 {
 		ret = append(ret,
 			"",
@@ -19,14 +19,19 @@ func init() {
 }
 
 type extraCmdVars struct {
-	flagHosts []string
+	flagHosts  []string
+	flagLabels []string
+	labels     map[string]string
+	flagOwner  string
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"add-hosts":    {"id", "host", "version"},
-		"set-hosts":    {"id", "host", "version"},
-		"remove-hosts": {"id", "host", "version"},
+		"add-hosts":          {"id", "host", "version"},
+		"set-hosts":          {"id", "host", "version"},
+		"remove-hosts":       {"id", "host", "version"},
+		"set-labels":         {"id", "label"},
+		"transfer-ownership": {"id", "owner"},
 	}
 }
 
@@ -38,6 +43,10 @@ func extraSynopsisFuncImpl(c *Command) string {
 		return "Remove hosts from the specified host set"
 	case "set-hosts":
 		return "Set the full contents of the hosts on the specified host set"
+	case "set-labels":
+		return "Set the labels on the specified host set"
+	case "transfer-ownership":
+		return "Transfer ownership of the specified host set to another user"
 	default:
 		return common.SynopsisFunc(c.Func, "host set")
 	}
@@ -118,6 +127,26 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"",
 		})
+	case "set-labels":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary host-sets set-labels [options] [args]",
+			"",
+			`  This command sets the complete set of labels on a host set, which grants can match on. Any labels not given are removed. Setting "null" removes all labels. The "label" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary host-sets set-labels -id hsst_1234567890 -label env=prod -label team=db`,
+			"",
+			"",
+		})
+	case "transfer-ownership":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary host-sets transfer-ownership [options] [args]",
+			"",
+			`  This command makes another user the owner of a host set. Grants using the "{{owner}}" template match the resources a user owns. Example:`,
+			"",
+			`    $ boundary host-sets transfer-ownership -id hsst_1234567890 -owner u_1234567890`,
+			"",
+			"",
+		})
 	default:
 		helpStr = helpMap[c.Func]()
 	}
@@ -133,6 +162,10 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagHosts,
 				Usage:  "The hosts to add, remove, or set. May be specified multiple times.",
 			})
+		case "label":
+			common.PopulateLabelFlag(f, &c.flagLabels, "host set")
+		case "owner":
+			common.PopulateOwnerFlag(f, &c.flagOwner, "host set")
 		}
 	}
 }
@@ -155,6 +188,20 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]hostsets.O
 				c.flagHosts = nil
 			}
 		}
+
+	case "set-labels":
+		labels, err := common.ParseLabels(c.flagLabels)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return false
+		}
+		c.labels = labels
+
+	case "transfer-ownership":
+		if c.flagOwner == "" {
+			c.UI.Error("No owner supplied via -owner")
+			return false
+		}
 	}
 
 	return true
@@ -168,6 +215,10 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		return hostsetClient.RemoveHosts(c.Context, c.FlagId, version, c.flagHosts, opts...)
 	case "set-hosts":
 		return hostsetClient.SetHosts(c.Context, c.FlagId, version, c.flagHosts, opts...)
+	case "set-labels":
+		return hostsetClient.SetLabels(c.Context, c.FlagId, c.labels, opts...)
+	case "transfer-ownership":
+		return hostsetClient.TransferOwnership(c.Context, c.FlagId, c.flagOwner, opts...)
 	}
 	return origResult, origError
 }
@@ -250,6 +301,9 @@ func printItemTable(result api.GenericResult) string {
 	if item.Description != "" {
 		nonAttributeMap["Description"] = item.Description
 	}
+	if item.OwnerId != "" {
+		nonAttributeMap["Owner ID"] = item.OwnerId
+	}
 	if item.HostCatalogId != "" {
 		nonAttributeMap["Host Catalog ID"] = item.HostCatalogId
 	}
@@ -278,6 +332,14 @@ func printItemTable(result api.GenericResult) string {
 		)
 	}
 
+	if len(item.Labels) > 0 {
+		ret = append(ret,
+			"",
+			"  Labels:",
+			common.LabelsForOutput(item.Labels, maxLength),
+		)
+	}
+
 	if len(item.HostIds) > 0 {
 		ret = append(ret,
 			"",
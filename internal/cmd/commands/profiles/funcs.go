@@ -0,0 +1,43 @@
+package profiles
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func generateListTableOutput(in *base.CLIConfig) string {
+	if len(in.Profiles) == 0 {
+		return "No profiles found"
+	}
+	ret := []string{"", "Profile information:"}
+	for i, p := range in.Profiles {
+		if i > 0 {
+			ret = append(ret, "")
+		}
+		nonAttributeMap := map[string]interface{}{
+			"Current": p.Name == in.CurrentProfile,
+		}
+		if p.Addr != "" {
+			nonAttributeMap["Address"] = p.Addr
+		}
+		if p.ScopeId != "" {
+			nonAttributeMap["Scope ID"] = p.ScopeId
+		}
+		if p.AuthMethodId != "" {
+			nonAttributeMap["Auth Method ID"] = p.AuthMethodId
+		}
+		if p.TokenName != "" {
+			nonAttributeMap["Token Name"] = p.TokenName
+		}
+		if p.KeyringType != "" {
+			nonAttributeMap["Keyring Type"] = p.KeyringType
+		}
+		maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+		ret = append(ret,
+			fmt.Sprintf("  Name: %s", p.Name),
+			base.WrapMap(4, maxLength+2, nonAttributeMap),
+		)
+	}
+	return base.WrapForHelpText(ret)
+}
@@ -0,0 +1,83 @@
+package profiles
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ListCommand)(nil)
+	_ cli.CommandAutocomplete = (*ListCommand)(nil)
+)
+
+type ListCommand struct {
+	*base.Command
+}
+
+func (c *ListCommand) Synopsis() string {
+	return "List the CLI's profiles"
+}
+
+func (c *ListCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary profiles list [options]",
+		"",
+		"  List the profiles of the CLI's config file, marking the current one.",
+		"",
+		"  Example:",
+		"",
+		`      $ boundary profiles list`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ListCommand) Flags() *base.FlagSets {
+	return c.FlagSet(base.FlagSetOutputFormat)
+}
+
+func (c *ListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ListCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ListCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	cfg, err := base.LoadCLIConfig()
+	if err != nil {
+		c.PrintCliError(err)
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateListTableOutput(cfg))
+	case "json":
+		out, err := json.Marshal(struct {
+			CurrentProfile string          `json:"current_profile,omitempty"`
+			Profiles       []*base.Profile `json:"profiles,omitempty"`
+		}{
+			CurrentProfile: cfg.CurrentProfile,
+			Profiles:       cfg.Profiles,
+		})
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("error marshaling profiles: %w", err))
+			return base.CommandCliError
+		}
+		c.UI.Output(string(out))
+	}
+	return base.CommandSuccess
+}
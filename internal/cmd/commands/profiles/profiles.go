@@ -0,0 +1,50 @@
+package profiles
+
+import (
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+)
+
+var _ cli.Command = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return "Manage the CLI's profiles for Boundary clusters"
+}
+
+func (c *Command) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary profiles <subcommand> [options] [args]",
+		"",
+		"  This command groups subcommands for managing the CLI's profiles. A profile bundles the address, TLS settings, default scope, auth method and token name used to talk to one Boundary cluster. Profiles are declared in the CLI's config file, given by BOUNDARY_CLI_CONFIG or otherwise ~/.boundary/cli.hcl, alongside its connect helpers, for example:",
+		"",
+		`      current_profile = "dev"`,
+		"",
+		`      profile "dev" {`,
+		`        addr           = "https://boundary.dev.example.com:9200"`,
+		`        ca_cert        = "/etc/boundary/dev-ca.pem"`,
+		`        scope_id       = "o_1234567890"`,
+		`        auth_method_id = "ampw_1234567890"`,
+		`        token_name     = "dev"`,
+		`      }`,
+		"",
+		"  The current profile, or the one given by -profile or BOUNDARY_PROFILE, supplies defaults for any flags not otherwise given on the command line or through environment variables.",
+		"",
+		"    List the profiles:",
+		"",
+		"      $ boundary profiles list",
+		"",
+		"    Make a profile the current one:",
+		"",
+		"      $ boundary profiles use prod",
+		"",
+		"  Please see the individual subcommand help for detailed usage information.",
+	})
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,149 @@
+package profiles
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `current_profile = "dev"
+
+profile "dev" {
+  addr     = "https://dev.example.com:9200"
+  scope_id = "o_1234567890"
+}
+
+profile "prod" {
+  addr = "https://prod.example.com:9200"
+}
+`
+
+// setEnv sets the environment variable for the duration of the test.
+func setEnv(t *testing.T, key, value string) {
+	t.Helper()
+	old, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// testConfigFile writes the CLI config file and points BOUNDARY_CLI_CONFIG at
+// it.
+func testConfigFile(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "boundary-profiles")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "cli.hcl")
+	require.NoError(t, ioutil.WriteFile(path, []byte(testConfig), 0o600))
+	setEnv(t, base.EnvBoundaryCLIConfig, path)
+	return path
+}
+
+func TestListCommand(t *testing.T) {
+	t.Run("table", func(t *testing.T) {
+		assert := assert.New(t)
+		testConfigFile(t)
+		setEnv(t, base.EnvBoundaryCLIFormat, "table")
+		ui := cli.NewMockUi()
+		c := &ListCommand{Command: base.NewCommand(ui)}
+		assert.Equal(base.CommandSuccess, c.Run(nil), ui.ErrorWriter.String())
+		out := ui.OutputWriter.String()
+		assert.Contains(out, "Name: dev")
+		assert.Contains(out, "https://dev.example.com:9200")
+		assert.Contains(out, "o_1234567890")
+		assert.Contains(out, "Name: prod")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		assert := assert.New(t)
+		testConfigFile(t)
+		setEnv(t, base.EnvBoundaryCLIFormat, "json")
+		ui := cli.NewMockUi()
+		c := &ListCommand{Command: base.NewCommand(ui)}
+		assert.Equal(base.CommandSuccess, c.Run(nil), ui.ErrorWriter.String())
+		assert.JSONEq(`{
+			"current_profile": "dev",
+			"profiles": [
+				{"name": "dev", "addr": "https://dev.example.com:9200", "scope_id": "o_1234567890"},
+				{"name": "prod", "addr": "https://prod.example.com:9200"}
+			]
+		}`, ui.OutputWriter.String())
+	})
+
+	t.Run("no profiles", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		path := testConfigFile(t)
+		require.NoError(ioutil.WriteFile(path, nil, 0o600))
+		setEnv(t, base.EnvBoundaryCLIFormat, "table")
+		ui := cli.NewMockUi()
+		c := &ListCommand{Command: base.NewCommand(ui)}
+		assert.Equal(base.CommandSuccess, c.Run(nil), ui.ErrorWriter.String())
+		assert.Contains(ui.OutputWriter.String(), "No profiles found")
+	})
+}
+
+func TestUseCommand(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		testConfigFile(t)
+		ui := cli.NewMockUi()
+		c := &UseCommand{Command: base.NewCommand(ui)}
+		require.Equal(base.CommandSuccess, c.Run([]string{"prod"}), ui.ErrorWriter.String())
+		assert.Contains(ui.OutputWriter.String(), `Switched to profile "prod"`)
+
+		cfg, err := base.LoadCLIConfig()
+		require.NoError(err)
+		assert.Equal("prod", cfg.CurrentProfile)
+	})
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantErr  string
+	}{
+		{
+			name:     "undeclared",
+			args:     []string{"test"},
+			wantCode: base.CommandCliError,
+			wantErr:  `profile "test" is not declared`,
+		},
+		{
+			name:     "missing name",
+			wantCode: base.CommandUserError,
+			wantErr:  "A profile name must be provided",
+		},
+		{
+			name:     "too many names",
+			args:     []string{"dev", "prod"},
+			wantCode: base.CommandUserError,
+			wantErr:  "Too many arguments",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			path := testConfigFile(t)
+			ui := cli.NewMockUi()
+			c := &UseCommand{Command: base.NewCommand(ui)}
+			assert.Equal(tt.wantCode, c.Run(tt.args))
+			assert.Contains(ui.ErrorWriter.String(), tt.wantErr)
+
+			d, err := ioutil.ReadFile(path)
+			require.NoError(err)
+			assert.Equal(testConfig, string(d), "the file is unchanged")
+		})
+	}
+}
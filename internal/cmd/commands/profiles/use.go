@@ -0,0 +1,76 @@
+package profiles
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*UseCommand)(nil)
+	_ cli.CommandAutocomplete = (*UseCommand)(nil)
+)
+
+type UseCommand struct {
+	*base.Command
+}
+
+func (c *UseCommand) Synopsis() string {
+	return "Make a profile the current one"
+}
+
+func (c *UseCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary profiles use <name>",
+		"",
+		"  Make the named profile the current one, so that it's used by commands not given -profile or BOUNDARY_PROFILE. Only the current_profile setting of the CLI's config file is changed.",
+		"",
+		"  Example:",
+		"",
+		`      $ boundary profiles use prod`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *UseCommand) Flags() *base.FlagSets {
+	return c.FlagSet(base.FlagSetNone)
+}
+
+func (c *UseCommand) AutocompleteArgs() complete.Predictor {
+	return base.PredictProfiles
+}
+
+func (c *UseCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *UseCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	args = f.Args()
+	switch len(args) {
+	case 1:
+	case 0:
+		c.PrintCliError(errors.New("A profile name must be provided"))
+		return base.CommandUserError
+	default:
+		c.PrintCliError(fmt.Errorf("Too many arguments (expected 1, got %d)", len(args)))
+		return base.CommandUserError
+	}
+
+	if err := base.SetCurrentProfile(args[0]); err != nil {
+		c.PrintCliError(err)
+		return base.CommandCliError
+	}
+	c.UI.Output(fmt.Sprintf("Switched to profile %q", args[0]))
+	return base.CommandSuccess
+}
@@ -0,0 +1,50 @@
+package proxy
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func generateProxyInfoTableOutput(in ProxyInfo) string {
+	nonAttributeMap := map[string]interface{}{
+		"Address":      in.Address,
+		"Port":         in.Port,
+		"Protocols":    strings.Join(in.Protocols, ", "),
+		"Idle Timeout": in.IdleTimeout.String(),
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Proxy listening information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	return base.WrapForHelpText(ret)
+}
+
+func generateSessionEventTableOutput(in SessionEvent) string {
+	nonAttributeMap := map[string]interface{}{
+		"Target":           in.Target,
+		"Session ID":       in.SessionId,
+		"Expiration":       in.Expiration.Local().Format(time.RFC1123),
+		"Connection Limit": in.ConnectionLimit,
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	header := "Session authorized:"
+	if in.Event == "session_closed" {
+		header = "Session closed:"
+	}
+	ret := []string{
+		"",
+		header,
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -0,0 +1,355 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+// requestTimeout bounds how long a client can take to send its request.
+const requestTimeout = 30 * time.Second
+
+// targetIdPattern matches the ids of targets, which are otherwise requested
+// by name.
+var targetIdPattern = regexp.MustCompile(`^t[a-z]+_[0-9A-Za-z]{10}$`)
+
+type ProxyInfo struct {
+	Address     string        `json:"address"`
+	Port        int           `json:"port"`
+	Protocols   []string      `json:"protocols"`
+	IdleTimeout time.Duration `json:"idle_timeout"`
+}
+
+type SessionEvent struct {
+	Event           string    `json:"event"`
+	Target          string    `json:"target"`
+	SessionId       string    `json:"session_id"`
+	Expiration      time.Time `json:"expiration"`
+	ConnectionLimit int32     `json:"connection_limit"`
+}
+
+var (
+	_ cli.Command             = (*Command)(nil)
+	_ cli.CommandAutocomplete = (*Command)(nil)
+)
+
+type Command struct {
+	*base.Command
+
+	flagListenAddr  string
+	flagListenPort  int
+	flagIdleTimeout time.Duration
+
+	outputMu sync.Mutex
+}
+
+func (c *Command) Synopsis() string {
+	return "Run a local SOCKS5 and HTTP CONNECT proxy to targets"
+}
+
+func (c *Command) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary proxy [options]",
+		"",
+		"  Run a local proxy server speaking both SOCKS5 and HTTP CONNECT. When a client connects through it to <target>:<port>, where <target> is the ID of a target or, with -target-scope-id or -target-scope-name, its name, a session is authorized to the target and the connection proxied through it. The port is ignored; the target's configured port is used.",
+		"",
+		"  Sessions are reused for later connections to the same target, and are canceled once they have had no connections for the idle timeout. SOCKS5 clients must resolve host names through the proxy (e.g. socks5h:// URLs), as targets can't be requested by IP address.",
+		"",
+		"  Example:",
+		"",
+		`      $ boundary proxy -listen-port 1080 -target-scope-name "Generated project scope"`,
+		"",
+		`      $ curl -x socks5h://127.0.0.1:1080 http://web-servers:80/`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:       "listen-addr",
+		Target:     &c.flagListenAddr,
+		EnvVar:     "BOUNDARY_PROXY_LISTEN_ADDR",
+		Completion: complete.PredictAnything,
+		Usage:      `If set, the CLI will attempt to bind its listening address to the given value, which must be an IP address. If it cannot, the command will error. If not set, defaults to the most common IPv4 loopback address (127.0.0.1).`,
+	})
+
+	f.IntVar(&base.IntVar{
+		Name:       "listen-port",
+		Target:     &c.flagListenPort,
+		EnvVar:     "BOUNDARY_PROXY_LISTEN_PORT",
+		Completion: complete.PredictAnything,
+		Usage:      `If set, the CLI will attempt to bind its listening port to the given value. If it cannot, the command will error. If not set, a random port is chosen.`,
+	})
+
+	f.DurationVar(&base.DurationVar{
+		Name:       "idle-timeout",
+		Target:     &c.flagIdleTimeout,
+		Default:    5 * time.Minute,
+		EnvVar:     "BOUNDARY_PROXY_IDLE_TIMEOUT",
+		Completion: complete.PredictAnything,
+		Usage:      `How long a session can have no connections before it is canceled.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "target-scope-id",
+		Target:     &c.FlagScopeId,
+		EnvVar:     "BOUNDARY_PROXY_TARGET_SCOPE_ID",
+		Completion: complete.PredictAnything,
+		Usage:      "The ID of the scope of targets requested by name. Mutually exclusive with -target-scope-name.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "target-scope-name",
+		Target:     &c.FlagScopeName,
+		EnvVar:     "BOUNDARY_PROXY_TARGET_SCOPE_NAME",
+		Completion: complete.PredictAnything,
+		Usage:      "The name of the scope of targets requested by name. Mutually exclusive with -target-scope-id.",
+	})
+
+	return set
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	switch {
+	case c.FlagScopeId != "" && c.FlagScopeName != "":
+		c.PrintCliError(errors.New("-target-scope-id and -target-scope-name cannot both be specified"))
+		return base.CommandUserError
+	case c.flagIdleTimeout <= 0:
+		c.PrintCliError(errors.New("-idle-timeout must be positive"))
+		return base.CommandUserError
+	}
+
+	if c.flagListenAddr == "" {
+		c.flagListenAddr = "127.0.0.1"
+	}
+	listenAddr := net.ParseIP(c.flagListenAddr)
+	if listenAddr == nil {
+		c.PrintCliError(fmt.Errorf("Could not successfully parse listen address of %s", c.flagListenAddr))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %s", err))
+		return base.CommandCliError
+	}
+
+	listener, err := net.ListenTCP("tcp", &net.TCPAddr{
+		IP:   listenAddr,
+		Port: c.flagListenPort,
+	})
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error starting listening port: %w", err))
+		return base.CommandCliError
+	}
+	defer listener.Close()
+	addr := listener.Addr().(*net.TCPAddr)
+
+	info := ProxyInfo{
+		Address:     addr.IP.String(),
+		Port:        addr.Port,
+		Protocols:   []string{"socks5", "http"},
+		IdleTimeout: c.flagIdleTimeout,
+	}
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateProxyInfoTableOutput(info))
+	case "json":
+		out, err := json.Marshal(&info)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("error marshaling proxy information: %w", err))
+			return base.CommandCliError
+		}
+		c.UI.Output(string(out))
+	}
+
+	ctx, cancel := context.WithCancel(c.Context)
+	defer cancel()
+
+	cache := newSessionCache(ctx, c.authorizeFunc(client), c.flagIdleTimeout)
+	cache.onClose = func(target string, sess session, err error) {
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error canceling session to %s: %w", target, err))
+		}
+		c.outputEvent(newSessionEvent("session_closed", target, sess))
+	}
+	go cache.run(ctx)
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	wg := new(sync.WaitGroup)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			c.PrintCliError(fmt.Errorf("Error accepting connection: %w", err))
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Temporary() {
+				continue
+			}
+			cancel()
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.handleConn(ctx, cache, conn); err != nil {
+				c.PrintCliError(err)
+			}
+		}()
+	}
+
+	// Cancel the sessions, which also closes their connections
+	cache.close()
+	wg.Wait()
+
+	if c.Context.Err() == nil {
+		return base.CommandCliError
+	}
+	return base.CommandSuccess
+}
+
+// authorizeFunc returns a function authorizing sessions to targets by id, or
+// by name within the scope given by the flags.
+func (c *Command) authorizeFunc(client *api.Client) authorizeFunc {
+	targetClient := targets.NewClient(client)
+	return func(ctx context.Context, target string) (session, error) {
+		var opts []targets.Option
+		targetId := target
+		if !targetIdPattern.MatchString(target) {
+			if c.FlagScopeId == "" && c.FlagScopeName == "" {
+				return nil, fmt.Errorf("%q is not a target ID, and -target-scope-id or -target-scope-name must be set to connect to targets by name", target)
+			}
+			targetId = ""
+			opts = append(opts, targets.WithName(target))
+			if c.FlagScopeId != "" {
+				opts = append(opts, targets.WithScopeId(c.FlagScopeId))
+			}
+			if c.FlagScopeName != "" {
+				opts = append(opts, targets.WithScopeName(c.FlagScopeName))
+			}
+		}
+
+		sar, err := targetClient.AuthorizeSession(ctx, targetId, opts...)
+		if err != nil {
+			if apiErr := api.AsServerError(err); apiErr != nil {
+				return nil, fmt.Errorf("error from controller when authorizing a session to %s: %s", target, apiErr.Message)
+			}
+			return nil, fmt.Errorf("error authorizing a session to %s: %w", target, err)
+		}
+		sess, err := apiproxy.New(ctx, sar.GetItem().(*targets.SessionAuthorization))
+		if err != nil {
+			return nil, fmt.Errorf("error using session authorization for %s: %w", target, err)
+		}
+		c.outputEvent(newSessionEvent("session_authorized", target, sess))
+		return sess, nil
+	}
+}
+
+// handleConn reads the client's request from conn, and proxies it to the
+// requested target.
+func (c *Command) handleConn(ctx context.Context, cache *sessionCache, conn net.Conn) error {
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(requestTimeout)); err != nil {
+		return fmt.Errorf("Error setting request deadline: %w", err)
+	}
+	req, err := readRequest(conn)
+	if err != nil {
+		return fmt.Errorf("Error reading request from %s: %w", conn.RemoteAddr(), err)
+	}
+	remote, release, err := cache.dial(ctx, req.host)
+	if err != nil {
+		_ = req.reply(err)
+		return fmt.Errorf("Error connecting to %s: %w", req.host, err)
+	}
+	defer release()
+	defer remote.Close()
+	if err := req.reply(nil); err != nil {
+		return fmt.Errorf("Error replying to %s request: %w", req.protocol, err)
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return fmt.Errorf("Error clearing request deadline: %w", err)
+	}
+
+	copyWg := new(sync.WaitGroup)
+	copyWg.Add(2)
+	go func() {
+		defer copyWg.Done()
+		_, _ = io.Copy(remote, req.conn)
+		remote.Close()
+		conn.Close()
+	}()
+	go func() {
+		defer copyWg.Done()
+		_, _ = io.Copy(req.conn, remote)
+		conn.Close()
+		remote.Close()
+	}()
+	copyWg.Wait()
+	return nil
+}
+
+func newSessionEvent(event, target string, sess session) SessionEvent {
+	data := sess.SessionAuthorizationData()
+	return SessionEvent{
+		Event:           event,
+		Target:          target,
+		SessionId:       data.SessionId,
+		Expiration:      sess.SessionExpiration(),
+		ConnectionLimit: data.ConnectionLimit,
+	}
+}
+
+func (c *Command) outputEvent(ev SessionEvent) {
+	c.outputMu.Lock()
+	defer c.outputMu.Unlock()
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateSessionEventTableOutput(ev))
+	case "json":
+		out, err := json.Marshal(&ev)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("error marshaling session event: %w", err))
+			return
+		}
+		c.UI.Output(string(out))
+	}
+}
@@ -0,0 +1,292 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession is a session whose connections echo what's written to them.
+type testSession struct {
+	id        string
+	connsLeft int32
+	done      chan struct{}
+	doneOnce  sync.Once
+	closed    int32
+}
+
+func (s *testSession) Dial(context.Context) (net.Conn, error) {
+	select {
+	case <-s.done:
+		return nil, apiproxy.ErrNoConnectionsLeft
+	default:
+	}
+	if atomic.AddInt32(&s.connsLeft, -1) == 0 {
+		s.doneOnce.Do(func() { close(s.done) })
+	}
+	local, remote := net.Pipe()
+	go func() {
+		_, _ = io.Copy(remote, remote)
+		remote.Close()
+	}()
+	return local, nil
+}
+
+func (s *testSession) Done() <-chan struct{} { return s.done }
+
+func (s *testSession) Close() error {
+	atomic.AddInt32(&s.closed, 1)
+	s.doneOnce.Do(func() { close(s.done) })
+	return nil
+}
+
+func (s *testSession) SessionAuthorizationData() *apiproxy.SessionAuthorizationData {
+	return &apiproxy.SessionAuthorizationData{SessionId: s.id}
+}
+
+func (s *testSession) SessionExpiration() time.Time { return time.Now().Add(time.Hour) }
+
+func (s *testSession) isClosed() bool { return atomic.LoadInt32(&s.closed) > 0 }
+
+// testAuthorizer authorizes testSessions allowing connLimit connections.
+type testAuthorizer struct {
+	connLimit int32
+
+	mu       sync.Mutex
+	sessions []*testSession
+}
+
+func (a *testAuthorizer) authorize(_ context.Context, target string) (session, error) {
+	if target == "missing" {
+		return nil, errors.New("target not found")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s := &testSession{id: target, connsLeft: a.connLimit, done: make(chan struct{})}
+	a.sessions = append(a.sessions, s)
+	return s, nil
+}
+
+func (a *testAuthorizer) authorized() []*testSession {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]*testSession(nil), a.sessions...)
+}
+
+func TestSessionCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reuse and idle", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a := &testAuthorizer{connLimit: -1}
+		cache := newSessionCache(ctx, a.authorize, time.Minute)
+
+		wg := new(sync.WaitGroup)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				conn, release, err := cache.dial(ctx, "web")
+				require.NoError(err)
+				conn.Close()
+				release()
+			}()
+		}
+		wg.Wait()
+		require.Len(a.authorized(), 1)
+
+		conn, release, err := cache.dial(ctx, "db")
+		require.NoError(err)
+		require.Len(a.authorized(), 2)
+		web, db := a.authorized()[0], a.authorized()[1]
+
+		// Only sessions without connections are closed when idle.
+		cache.closeIdle(time.Now().Add(time.Minute))
+		assert.True(web.isClosed())
+		assert.False(db.isClosed())
+		conn.Close()
+		release()
+		cache.closeIdle(time.Now().Add(-time.Minute))
+		assert.False(db.isClosed())
+
+		_, _, err = cache.dial(ctx, "missing")
+		assert.Error(err)
+		assert.Len(a.authorized(), 2)
+	})
+
+	t.Run("ended sessions", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a := &testAuthorizer{connLimit: 1}
+		cache := newSessionCache(ctx, a.authorize, time.Minute)
+
+		conn1, release1, err := cache.dial(ctx, "web")
+		require.NoError(err)
+		conn2, release2, err := cache.dial(ctx, "web")
+		require.NoError(err)
+		sessions := a.authorized()
+		require.Len(sessions, 2)
+
+		// The ended session is closed once its connection is.
+		assert.False(sessions[0].isClosed())
+		conn1.Close()
+		release1()
+		assert.True(sessions[0].isClosed())
+
+		// Closing the cache closes sessions with active connections.
+		cache.close()
+		assert.True(sessions[1].isClosed())
+		conn2.Close()
+		release2()
+		assert.Equal(int32(1), atomic.LoadInt32(&sessions[1].closed))
+
+		_, _, err = cache.dial(ctx, "web")
+		assert.Equal(errCacheClosed, err)
+	})
+}
+
+func testHandleConn(t *testing.T, cache *sessionCache) net.Conn {
+	t.Helper()
+	client, server := net.Pipe()
+	go func() {
+		_ = (&Command{}).handleConn(context.Background(), cache, server)
+	}()
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func testEcho(t *testing.T, conn io.ReadWriter, msg string) {
+	t.Helper()
+	go func() {
+		_, _ = conn.Write([]byte(msg))
+	}()
+	buf := make([]byte, len(msg))
+	_, err := io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, msg, string(buf))
+}
+
+func TestHandleConn_socks5(t *testing.T) {
+	a := &testAuthorizer{connLimit: -1}
+	cache := newSessionCache(context.Background(), a.authorize, time.Minute)
+
+	t.Run("connect", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		conn := testHandleConn(t, cache)
+		go func() {
+			_, _ = conn.Write([]byte{0x05, 0x02, 0x02, 0x00})
+		}()
+		resp := make([]byte, 2)
+		_, err := io.ReadFull(conn, resp)
+		require.NoError(err)
+		assert.Equal([]byte{0x05, 0x00}, resp)
+
+		req := append([]byte{0x05, 0x01, 0x00, 0x03, 3}, "web"...)
+		go func() {
+			_, _ = conn.Write(append(req, 0x00, 0x50))
+		}()
+		resp = make([]byte, 10)
+		_, err = io.ReadFull(conn, resp)
+		require.NoError(err)
+		assert.Equal([]byte{0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0}, resp)
+		testEcho(t, conn, "hello")
+		require.Len(a.authorized(), 1)
+		assert.Equal("web", a.authorized()[0].id)
+	})
+
+	tests := []struct {
+		name string
+		req  []byte
+		want byte
+	}{
+		{
+			name: "ip address",
+			req:  []byte{0x05, 0x01, 0x00, 0x01, 10, 0, 0, 1, 0x00, 0x50},
+			want: socks5AddressTypeNotSupported,
+		},
+		{
+			name: "bind",
+			req:  append(append([]byte{0x05, 0x02, 0x00, 0x03, 3}, "web"...), 0x00, 0x50),
+			want: socks5CommandNotSupported,
+		},
+		{
+			name: "unknown target",
+			req:  append(append([]byte{0x05, 0x01, 0x00, 0x03, 7}, "missing"...), 0x00, 0x50),
+			want: socks5HostUnreachable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			conn := testHandleConn(t, cache)
+			go func() {
+				_, _ = conn.Write(append([]byte{0x05, 0x01, 0x00}, tt.req...))
+			}()
+			resp := make([]byte, 12)
+			_, err := io.ReadFull(conn, resp)
+			require.NoError(err)
+			assert.Equal(t, tt.want, resp[3])
+		})
+	}
+}
+
+func TestHandleConn_httpConnect(t *testing.T) {
+	a := &testAuthorizer{connLimit: -1}
+	cache := newSessionCache(context.Background(), a.authorize, time.Minute)
+
+	tests := []struct {
+		name       string
+		req        string
+		wantStatus int
+	}{
+		{
+			name:       "connect",
+			req:        "CONNECT web:443 HTTP/1.1\r\nHost: web:443\r\n\r\n",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "get",
+			req:        "GET http://web/ HTTP/1.1\r\nHost: web\r\n\r\n",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "missing port",
+			req:        "CONNECT web HTTP/1.1\r\nHost: web\r\n\r\n",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown target",
+			req:        "CONNECT missing:443 HTTP/1.1\r\nHost: missing:443\r\n\r\n",
+			wantStatus: http.StatusBadGateway,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			conn := testHandleConn(t, cache)
+			go func() {
+				_, _ = io.WriteString(conn, tt.req)
+			}()
+			br := bufio.NewReader(conn)
+			resp, err := http.ReadResponse(br, &http.Request{Method: http.MethodConnect})
+			require.NoError(err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantStatus == http.StatusOK {
+				testEcho(t, struct {
+					io.Reader
+					io.Writer
+				}{br, conn}, "hello")
+			}
+		})
+	}
+}
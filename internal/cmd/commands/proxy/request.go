@@ -0,0 +1,192 @@
+package proxy
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+const socks5Version = 0x05
+
+// SOCKS5 reply codes, from RFC 1928.
+const (
+	socks5Succeeded               = 0x00
+	socks5HostUnreachable         = 0x04
+	socks5CommandNotSupported     = 0x07
+	socks5AddressTypeNotSupported = 0x08
+)
+
+// request is a client's request to connect to a target.
+type request struct {
+	// protocol is "socks5" or "http".
+	protocol string
+	// host is the requested host, the id or name of a target.
+	host string
+	port int
+	// conn is the client's connection, including any data read past the
+	// request.
+	conn net.Conn
+	// reply tells the client whether the connection to the target was made.
+	reply func(error) error
+}
+
+// bufferedConn is a net.Conn which reads through a buffer.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// readRequest reads a SOCKS5 or HTTP CONNECT request from conn, telling them
+// apart by the first byte.
+func readRequest(conn net.Conn) (*request, error) {
+	br := bufio.NewReader(conn)
+	first, err := br.Peek(1)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request: %w", err)
+	}
+	bc := &bufferedConn{Conn: conn, r: br}
+	if first[0] == socks5Version {
+		return readSocks5Request(bc)
+	}
+	return readHttpConnectRequest(bc)
+}
+
+func readSocks5Request(conn *bufferedConn) (*request, error) {
+	// The greeting is the version, the number of methods and the methods.
+	hdr := make([]byte, 2)
+	if _, err := io.ReadFull(conn, hdr); err != nil {
+		return nil, fmt.Errorf("error reading socks5 greeting: %w", err)
+	}
+	methods := make([]byte, hdr[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return nil, fmt.Errorf("error reading socks5 authentication methods: %w", err)
+	}
+	noAuth := false
+	for _, m := range methods {
+		if m == 0x00 {
+			noAuth = true
+		}
+	}
+	if !noAuth {
+		_, _ = conn.Write([]byte{socks5Version, 0xff})
+		return nil, errors.New("socks5 client doesn't support connecting without authentication")
+	}
+	if _, err := conn.Write([]byte{socks5Version, 0x00}); err != nil {
+		return nil, fmt.Errorf("error writing socks5 method selection: %w", err)
+	}
+
+	// The request is the version, command, a reserved byte, then the
+	// address's type, the address and the port.
+	hdr = make([]byte, 4)
+	if _, err := io.ReadFull(conn, hdr); err != nil {
+		return nil, fmt.Errorf("error reading socks5 request: %w", err)
+	}
+	var host string
+	switch hdr[3] {
+	case 0x01, 0x04:
+		l := net.IPv4len
+		if hdr[3] == 0x04 {
+			l = net.IPv6len
+		}
+		if _, err := io.ReadFull(conn, make([]byte, l+2)); err != nil {
+			return nil, fmt.Errorf("error reading socks5 request address: %w", err)
+		}
+		writeSocks5Reply(conn, socks5AddressTypeNotSupported)
+		return nil, errors.New("socks5 client requested an IP address rather than a target; configure it to resolve host names through the proxy")
+	case 0x03:
+		l := make([]byte, 1)
+		if _, err := io.ReadFull(conn, l); err != nil {
+			return nil, fmt.Errorf("error reading socks5 request address: %w", err)
+		}
+		name := make([]byte, l[0])
+		if _, err := io.ReadFull(conn, name); err != nil {
+			return nil, fmt.Errorf("error reading socks5 request address: %w", err)
+		}
+		host = string(name)
+	default:
+		writeSocks5Reply(conn, socks5AddressTypeNotSupported)
+		return nil, fmt.Errorf("unknown socks5 address type %d", hdr[3])
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return nil, fmt.Errorf("error reading socks5 request port: %w", err)
+	}
+	if hdr[1] != 0x01 {
+		writeSocks5Reply(conn, socks5CommandNotSupported)
+		return nil, fmt.Errorf("unsupported socks5 command %d", hdr[1])
+	}
+
+	return &request{
+		protocol: "socks5",
+		host:     host,
+		port:     int(binary.BigEndian.Uint16(port)),
+		conn:     conn,
+		reply: func(err error) error {
+			rep := byte(socks5Succeeded)
+			if err != nil {
+				rep = socks5HostUnreachable
+			}
+			return writeSocks5Reply(conn, rep)
+		},
+	}, nil
+}
+
+// writeSocks5Reply writes a reply with the given code.  The bound address
+// isn't meaningful so is always 0.0.0.0:0.
+func writeSocks5Reply(conn net.Conn, rep byte) error {
+	_, err := conn.Write([]byte{socks5Version, rep, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+	return err
+}
+
+func readHttpConnectRequest(conn *bufferedConn) (*request, error) {
+	req, err := http.ReadRequest(conn.r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading http request: %w", err)
+	}
+	if req.Method != http.MethodConnect {
+		writeHttpResponse(conn, http.StatusMethodNotAllowed, "Only CONNECT requests are supported\n", "Allow: CONNECT\r\n")
+		return nil, fmt.Errorf("unsupported http method %s", req.Method)
+	}
+	host, portStr, err := net.SplitHostPort(req.Host)
+	if err != nil {
+		writeHttpResponse(conn, http.StatusBadRequest, "The host must include a port\n", "")
+		return nil, fmt.Errorf("invalid http connect host %q: %w", req.Host, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		writeHttpResponse(conn, http.StatusBadRequest, "The port must be a number\n", "")
+		return nil, fmt.Errorf("invalid http connect port %q", portStr)
+	}
+
+	return &request{
+		protocol: "http",
+		host:     host,
+		port:     port,
+		conn:     conn,
+		reply: func(err error) error {
+			if err != nil {
+				return writeHttpResponse(conn, http.StatusBadGateway, err.Error()+"\n", "")
+			}
+			_, err = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+			return err
+		},
+	}, nil
+}
+
+// writeHttpResponse writes a response closing the connection with the given
+// status, body and any extra headers, which must end with "\r\n".
+func writeHttpResponse(conn net.Conn, status int, body, headers string) error {
+	_, err := fmt.Fprintf(conn,
+		"HTTP/1.1 %d %s\r\n%sContent-Type: text/plain; charset=utf-8\r\nContent-Length: %d\r\nConnection: close\r\n\r\n%s",
+		status, http.StatusText(status), headers, len(body), body)
+	return err
+}
@@ -0,0 +1,275 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
+)
+
+// session is a session proxied through a worker.  It's implemented by
+// *apiproxy.ClientProxy.
+type session interface {
+	Dial(context.Context) (net.Conn, error)
+	Done() <-chan struct{}
+	Close() error
+	SessionAuthorizationData() *apiproxy.SessionAuthorizationData
+	SessionExpiration() time.Time
+}
+
+// authorizeFunc authorizes a new session to the target with the given id or
+// name.  The session's connections are closed when ctx is canceled.
+type authorizeFunc func(ctx context.Context, target string) (session, error)
+
+// sessionEntry is a cached session and its use.
+type sessionEntry struct {
+	target string
+
+	// ready is closed once the session has been authorized, after sess or
+	// err is set.
+	ready chan struct{}
+	sess  session
+	err   error
+
+	// The following are guarded by the cache's mutex.
+	active   int
+	lastUsed time.Time
+	// removed is set once the entry is no longer cached.  Its session is
+	// closed once it has no active connections.
+	removed bool
+	closed  bool
+}
+
+// ended reports whether no more connections can be made with the entry's
+// session.  It must only be called once the entry is ready.
+func (e *sessionEntry) ended() bool {
+	if e.err != nil {
+		return true
+	}
+	select {
+	case <-e.sess.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// sessionCache authorizes sessions to targets on demand, reuses them for
+// later connections to the same target, and closes them once they've been
+// idle for idleTimeout.
+type sessionCache struct {
+	ctx         context.Context
+	authorize   authorizeFunc
+	idleTimeout time.Duration
+	// onClose, if set, is called after a session is closed.
+	onClose func(target string, sess session, err error)
+
+	mu      sync.Mutex
+	entries map[string]*sessionEntry
+	// live are the entries whose sessions haven't been closed, including
+	// removed entries with active connections.
+	live   map[*sessionEntry]struct{}
+	closed bool
+}
+
+// newSessionCache returns a cache whose sessions are authorized with
+// authorize.  Their connections are closed when ctx is canceled.
+func newSessionCache(ctx context.Context, authorize authorizeFunc, idleTimeout time.Duration) *sessionCache {
+	return &sessionCache{
+		ctx:         ctx,
+		authorize:   authorize,
+		idleTimeout: idleTimeout,
+		entries:     make(map[string]*sessionEntry),
+		live:        make(map[*sessionEntry]struct{}),
+	}
+}
+
+var errCacheClosed = errors.New("proxy is shutting down")
+
+// dial returns a connection to target, authorizing a session to it if there
+// isn't a usable one.  The returned function must be called once the
+// connection is closed.
+func (s *sessionCache) dial(ctx context.Context, target string) (net.Conn, func(), error) {
+	// A session can end between it being looked up and dialed, e.g. once its
+	// connection limit is reached, so retry once with a new session.
+	var err error
+	for i := 0; i < 2; i++ {
+		var e *sessionEntry
+		e, err = s.get(ctx, target)
+		if err != nil {
+			return nil, nil, err
+		}
+		var conn net.Conn
+		conn, err = e.sess.Dial(ctx)
+		if err == nil {
+			return conn, func() { s.release(e) }, nil
+		}
+		ended := e.ended()
+		s.release(e)
+		if !ended {
+			break
+		}
+	}
+	return nil, nil, err
+}
+
+// get returns the ready entry for target with its use counted, authorizing a
+// new session if there's no usable one.
+func (s *sessionCache) get(ctx context.Context, target string) (*sessionEntry, error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, errCacheClosed
+	}
+	e, ok := s.entries[target]
+	if ok {
+		select {
+		case <-e.ready:
+			if e.ended() {
+				s.removeLocked(e)
+				ok = false
+			}
+		default:
+		}
+	}
+	if !ok {
+		e = &sessionEntry{target: target, ready: make(chan struct{})}
+		s.entries[target] = e
+		s.live[e] = struct{}{}
+		go func() {
+			e.sess, e.err = s.authorize(s.ctx, target)
+			close(e.ready)
+		}()
+	}
+	e.active++
+	s.mu.Unlock()
+
+	select {
+	case <-e.ready:
+	case <-ctx.Done():
+		s.release(e)
+		return nil, ctx.Err()
+	}
+	if e.err != nil {
+		s.release(e)
+		return nil, e.err
+	}
+	return e, nil
+}
+
+// release marks a use of e as finished, and closes its session if e has been
+// removed and this was its last use.
+func (s *sessionCache) release(e *sessionEntry) {
+	s.mu.Lock()
+	e.active--
+	e.lastUsed = time.Now()
+	if e.err != nil {
+		s.removeLocked(e)
+	}
+	closeSess := s.closableLocked(e)
+	s.mu.Unlock()
+	if closeSess {
+		s.closeSession(e)
+	}
+}
+
+// removeLocked removes e from the cache.  Its session is closed once it has
+// no active connections.  The cache's mutex must be held.
+func (s *sessionCache) removeLocked(e *sessionEntry) {
+	if s.entries[e.target] == e {
+		delete(s.entries, e.target)
+	}
+	e.removed = true
+}
+
+// closableLocked reports whether e's session should now be closed, and if so
+// marks it closed.  The cache's mutex must be held.
+func (s *sessionCache) closableLocked(e *sessionEntry) bool {
+	if !e.removed || e.closed || e.active > 0 {
+		return false
+	}
+	e.closed = true
+	delete(s.live, e)
+	return e.sess != nil
+}
+
+func (s *sessionCache) closeSession(e *sessionEntry) {
+	err := e.sess.Close()
+	if s.onClose != nil {
+		s.onClose(e.target, e.sess, err)
+	}
+}
+
+// closeIdle closes the sessions with no active connections which have either
+// ended or been unused since before cutoff.
+func (s *sessionCache) closeIdle(cutoff time.Time) {
+	var idle []*sessionEntry
+	s.mu.Lock()
+	for _, e := range s.entries {
+		if e.active > 0 {
+			continue
+		}
+		select {
+		case <-e.ready:
+		default:
+			continue
+		}
+		if e.ended() || e.lastUsed.Before(cutoff) {
+			s.removeLocked(e)
+			if s.closableLocked(e) {
+				idle = append(idle, e)
+			}
+		}
+	}
+	s.mu.Unlock()
+	for _, e := range idle {
+		s.closeSession(e)
+	}
+}
+
+// run closes idle sessions until ctx is done.
+func (s *sessionCache) run(ctx context.Context) {
+	interval := s.idleTimeout / 2
+	switch {
+	case interval > time.Minute:
+		interval = time.Minute
+	case interval < time.Second:
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.closeIdle(time.Now().Add(-s.idleTimeout))
+		}
+	}
+}
+
+// close closes all sessions, including those with active connections, after
+// waiting for any being authorized.
+func (s *sessionCache) close() {
+	s.mu.Lock()
+	s.closed = true
+	var live []*sessionEntry
+	for e := range s.live {
+		live = append(live, e)
+	}
+	s.mu.Unlock()
+	for _, e := range live {
+		<-e.ready
+		s.mu.Lock()
+		s.removeLocked(e)
+		e.active = 0
+		closeSess := s.closableLocked(e)
+		s.mu.Unlock()
+		if closeSess {
+			s.closeSession(e)
+		}
+	}
+}
@@ -24,18 +24,22 @@ type extraCmdVars struct {
 	flagGrantScopeId string
 	flagPrincipals   []string
 	flagGrants       []string
+	flagGrantScopes  []string
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create":            {"grant-scope-id"},
-		"update":            {"grant-scope-id"},
-		"add-principals":    {"id", "principal", "version"},
-		"set-principals":    {"id", "principal", "version"},
-		"remove-principals": {"id", "principal", "version"},
-		"add-grants":        {"id", "grant", "version"},
-		"set-grants":        {"id", "grant", "version"},
-		"remove-grants":     {"id", "grant", "version"},
+		"create":              {"grant-scope-id"},
+		"update":              {"grant-scope-id"},
+		"add-principals":      {"id", "principal", "version"},
+		"set-principals":      {"id", "principal", "version"},
+		"remove-principals":   {"id", "principal", "version"},
+		"add-grants":          {"id", "grant", "version"},
+		"set-grants":          {"id", "grant", "version"},
+		"remove-grants":       {"id", "grant", "version"},
+		"add-grant-scopes":    {"id", "grant-scope", "version"},
+		"set-grant-scopes":    {"id", "grant-scope", "version"},
+		"remove-grant-scopes": {"id", "grant-scope", "version"},
 	}
 }
 
@@ -45,6 +49,8 @@ func extraSynopsisFuncImpl(c *Command) string {
 		return c.principalsGrantsSynopsisFunc(c.Func, true)
 	case "add-grants", "set-grants", "remove-grants":
 		return c.principalsGrantsSynopsisFunc(c.Func, false)
+	case "add-grant-scopes", "set-grant-scopes", "remove-grant-scopes":
+		return c.grantScopesSynopsisFunc(c.Func)
 	}
 
 	return ""
@@ -67,6 +73,19 @@ func (c *Command) principalsGrantsSynopsisFunc(inFunc string, principals bool) s
 	return wordwrap.WrapString(fmt.Sprintf("%s a role", in), base.TermWidth)
 }
 
+func (c *Command) grantScopesSynopsisFunc(inFunc string) string {
+	var in string
+	switch {
+	case strings.HasPrefix(inFunc, "add"):
+		in = "Add grant scopes to"
+	case strings.HasPrefix(inFunc, "set"):
+		in = "Set the full contents of the grant scopes on"
+	case strings.HasPrefix(inFunc, "remove"):
+		in = "Remove grant scopes from"
+	}
+	return wordwrap.WrapString(fmt.Sprintf("%s a role", in), base.TermWidth)
+}
+
 func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 	var helpStr string
 	switch c.Func {
@@ -136,6 +155,39 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "add-grant-scopes":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary roles add-grant-scopes [options] [args]",
+			"",
+			`  Adds grant scopes to a role given its ID. A grant scope is a scope ID, "this" for the role's own scope, "children" for the direct child scopes of the role's scope, or "descendants" for every scope below global. Once a role has grant scopes its grants apply to them instead of its grant scope ID. The "grant-scope" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary roles add-grant-scopes -id r_1234567890 -grant-scope this -grant-scope children`,
+			"",
+			"",
+		})
+
+	case "set-grant-scopes":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary roles set-grant-scopes [options] [args]",
+			"",
+			`  Sets the complete set of grant scopes on a role given its ID. Setting "null" removes all grant scopes, so that the role's grants apply to its grant scope ID again. The "grant-scope" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary roles set-grant-scopes -id r_1234567890 -grant-scope p_1234567890 -grant-scope p_0987654321`,
+			"",
+			"",
+		})
+
+	case "remove-grant-scopes":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary roles remove-grant-scopes [options] [args]",
+			"",
+			`  Removes grant scopes from a role given its ID. The "grant-scope" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary roles remove-grant-scopes -id r_1234567890 -grant-scope children`,
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -163,6 +215,12 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagGrants,
 				Usage:  "The grants to add, remove, or set. May be specified multiple times. Can be in compact string format or JSON (be sure to escape JSON properly).",
 			})
+		case "grant-scope":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "grant-scope",
+				Target: &c.flagGrantScopes,
+				Usage:  `The grant scopes to add, remove, or set: scope IDs, "this", "children" or "descendants". May be specified multiple times.`,
+			})
 		}
 	}
 }
@@ -200,6 +258,23 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]roles.Opti
 			}
 		}
 
+	case "add-grant-scopes", "remove-grant-scopes":
+		if len(c.flagGrantScopes) == 0 {
+			c.UI.Error("No grant scopes supplied via -grant-scope")
+			return false
+		}
+
+	case "set-grant-scopes":
+		switch len(c.flagGrantScopes) {
+		case 0:
+			c.UI.Error("No grant scopes supplied via -grant-scope")
+			return false
+		case 1:
+			if c.flagGrantScopes[0] == "null" {
+				c.flagGrantScopes = nil
+			}
+		}
+
 	case "set-grants":
 		switch len(c.flagGrants) {
 		case 0:
@@ -239,6 +314,12 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		return roleClient.SetGrants(c.Context, c.FlagId, version, c.flagGrants, opts...)
 	case "remove-grants":
 		return roleClient.RemoveGrants(c.Context, c.FlagId, version, c.flagGrants, opts...)
+	case "add-grant-scopes":
+		return roleClient.AddGrantScopes(c.Context, c.FlagId, version, c.flagGrantScopes, opts...)
+	case "set-grant-scopes":
+		return roleClient.SetGrantScopes(c.Context, c.FlagId, version, c.flagGrantScopes, opts...)
+	case "remove-grant-scopes":
+		return roleClient.RemoveGrantScopes(c.Context, c.FlagId, version, c.flagGrantScopes, opts...)
 	}
 	return origResult, origError
 }
@@ -370,6 +451,17 @@ func printItemTable(result api.GenericResult) string {
 			fmt.Sprintf("    %s", grant.Canonical),
 		)
 	}
+	if len(item.GrantScopeIds) > 0 {
+		ret = append(ret,
+			"",
+			fmt.Sprintf("  Grant Scopes:     %s", ""),
+		)
+	}
+	for _, gs := range item.GrantScopeIds {
+		ret = append(ret,
+			fmt.Sprintf("    %s", gs),
+		)
+	}
 
 	return base.WrapForHelpText(ret)
 }
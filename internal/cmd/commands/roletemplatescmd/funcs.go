@@ -0,0 +1,355 @@
+package roletemplatescmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/roletemplates"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+}
+
+type extraCmdVars struct {
+	flagPrincipals []string
+	flagGrants     []string
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"add-principals":    {"id", "principal", "version"},
+		"set-principals":    {"id", "principal", "version"},
+		"remove-principals": {"id", "principal", "version"},
+		"add-grants":        {"id", "grant", "version"},
+		"set-grants":        {"id", "grant", "version"},
+		"remove-grants":     {"id", "grant", "version"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "add-principals", "set-principals", "remove-principals":
+		return c.principalsGrantsSynopsisFunc(c.Func, true)
+	case "add-grants", "set-grants", "remove-grants":
+		return c.principalsGrantsSynopsisFunc(c.Func, false)
+	}
+
+	return ""
+}
+
+func (c *Command) principalsGrantsSynopsisFunc(inFunc string, principals bool) string {
+	var in string
+	switchStr := "principals (users, groups)"
+	if !principals {
+		switchStr = "grants"
+	}
+	switch {
+	case strings.HasPrefix(inFunc, "add"):
+		in = fmt.Sprintf("Add %s to", switchStr)
+	case strings.HasPrefix(inFunc, "set"):
+		in = fmt.Sprintf("Set the full contents of the %s on", switchStr)
+	case strings.HasPrefix(inFunc, "remove"):
+		in = fmt.Sprintf("Remove %s from", switchStr)
+	}
+	return wordwrap.WrapString(fmt.Sprintf("%s a role template", in), base.TermWidth)
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "add-principals":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary role-templates add-principals [options] [args]",
+			"",
+			`  Adds principals (users, groups) to a role template given its ID. The roles instantiated from the template are updated to match. The "principal" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary role-templates add-principals -id rt_1234567890 -principal u_1234567890`,
+			"",
+			"",
+		})
+
+	case "set-principals":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary role-templates set-principals [options] [args]",
+			"",
+			`  Sets the complete set of principals (users, groups) on a role template given its ID. The roles instantiated from the template are updated to match. The "principal" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary role-templates set-principals -id rt_1234567890 -principal u_anon -principal sg_1234567890`,
+			"",
+			"",
+		})
+
+	case "remove-principals":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary role-templates remove-principals [options] [args]",
+			"",
+			`  Removes principals (users, groups) from a role template given its ID. The roles instantiated from the template are updated to match. The "principal" flags can be specified multiple times. Example:`,
+			"",
+			`    $ boundary role-templates remove-principals -id rt_1234567890 -principal sg_1234567890`,
+			"",
+			"",
+		})
+
+	case "add-grants":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary role-templates add-grants [options] [args]",
+			"",
+			`  Adds grants to a role template given its ID. Grants may use the "{{scope.id}}" and "{{scope.parent_id}}" parameters, which are replaced with the IDs of the project a role is instantiated in and of its org. The "grant" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary role-templates add-grants -id rt_1234567890 -grant "id={{scope.id}};actions=read"`,
+			"",
+			"",
+		})
+
+	case "set-grants":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary role-templates set-grants [options] [args]",
+			"",
+			`  Sets the complete set of grants on a role template given its ID. The "grant" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary role-templates set-grants -id rt_1234567890 -grant "id=*;type=target;actions=read" -grant "id=*;type=target;actions=list"`,
+			"",
+			"",
+		})
+
+	case "remove-grants":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary role-templates remove-grants [options] [args]",
+			"",
+			`  Removes grants from a role template given its ID. The "grant" flags can be specified multiple times. Example:`,
+			"",
+			`    $ boundary role-templates remove-grants -id rt_1234567890 -grant "id=*;type=target;actions=read"`,
+			"",
+			"",
+		})
+
+	default:
+		helpStr = helpMap["base"]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "principal":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "principal",
+				Target: &c.flagPrincipals,
+				Usage:  "The principals (users or groups) to add, remove, or set. May be specified multiple times.",
+			})
+		case "grant":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "grant",
+				Target: &c.flagGrants,
+				Usage:  "The grants to add, remove, or set. May be specified multiple times. Can be in compact string format or JSON (be sure to escape JSON properly).",
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, _ *[]roletemplates.Option) bool {
+	switch c.Func {
+	case "add-principals", "remove-principals":
+		if len(c.flagPrincipals) == 0 {
+			c.UI.Error("No principals supplied via -principal")
+			return false
+		}
+
+	case "add-grants", "remove-grants":
+		if len(c.flagGrants) == 0 {
+			c.UI.Error("No grants supplied via -grant")
+			return false
+		}
+
+	case "set-principals":
+		switch len(c.flagPrincipals) {
+		case 0:
+			c.UI.Error("No principals supplied via -principal")
+			return false
+		case 1:
+			if c.flagPrincipals[0] == "null" {
+				c.flagPrincipals = nil
+			}
+		}
+
+	case "set-grants":
+		switch len(c.flagGrants) {
+		case 0:
+			c.UI.Error("No grants supplied via -grant")
+			return false
+		case 1:
+			if c.flagGrants[0] == "null" {
+				c.flagGrants = nil
+			}
+		}
+	}
+
+	return true
+}
+
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, roleTemplateClient *roletemplates.Client, version uint32, opts []roletemplates.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "add-principals":
+		return roleTemplateClient.AddPrincipals(c.Context, c.FlagId, version, c.flagPrincipals, opts...)
+	case "set-principals":
+		return roleTemplateClient.SetPrincipals(c.Context, c.FlagId, version, c.flagPrincipals, opts...)
+	case "remove-principals":
+		return roleTemplateClient.RemovePrincipals(c.Context, c.FlagId, version, c.flagPrincipals, opts...)
+	case "add-grants":
+		return roleTemplateClient.AddGrants(c.Context, c.FlagId, version, c.flagGrants, opts...)
+	case "set-grants":
+		return roleTemplateClient.SetGrants(c.Context, c.FlagId, version, c.flagGrants, opts...)
+	case "remove-grants":
+		return roleTemplateClient.RemoveGrants(c.Context, c.FlagId, version, c.flagGrants, opts...)
+	}
+	return origResult, origError
+}
+
+func (c *Command) printListTable(items []*roletemplates.RoleTemplate) string {
+	if len(items) == 0 {
+		return "No role templates found"
+	}
+
+	var output []string
+	output = []string{
+		"",
+		"Role template information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", "(not available)"),
+			)
+		}
+		if c.FlagRecursive && item.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
+			)
+		}
+		if item.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:             %d", item.Version),
+			)
+		}
+		if item.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                %s", item.Name),
+			)
+		}
+		if item.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:         %s", item.Description),
+			)
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(result api.GenericResult) string {
+	item := result.GetItem().(*roletemplates.RoleTemplate)
+	nonAttributeMap := map[string]interface{}{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if item.Name != "" {
+		nonAttributeMap["Name"] = item.Name
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Role template information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	if len(item.Principals) > 0 {
+		ret = append(ret,
+			"",
+			fmt.Sprintf("  Principals:       %s", ""),
+		)
+	}
+	for _, principal := range item.Principals {
+		ret = append(ret,
+			fmt.Sprintf("    ID:             %s", principal.Id),
+			fmt.Sprintf("      Type:         %s", principal.Type),
+			fmt.Sprintf("      Scope ID:     %s", principal.ScopeId),
+		)
+	}
+	if len(item.Grants) > 0 {
+		ret = append(ret,
+			"",
+			fmt.Sprintf("  Canonical Grants: %s", ""),
+		)
+	}
+	for _, grant := range item.Grants {
+		ret = append(ret,
+			fmt.Sprintf("    %s", grant.Canonical),
+		)
+	}
+	if len(item.RoleIds) > 0 {
+		ret = append(ret,
+			"",
+			fmt.Sprintf("  Roles:            %s", ""),
+		)
+	}
+	for _, id := range item.RoleIds {
+		ret = append(ret,
+			fmt.Sprintf("    %s", id),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/scopes"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 )
 
 const (
@@ -18,21 +19,54 @@ const (
 
 func init() {
 	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName},
-		"update": {flagPrimaryAuthMethodIdName},
+		"create":     {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName},
+		"update":     {flagPrimaryAuthMethodIdName},
+		"set-labels": {"id", "label"},
 	}
 }
 
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "set-labels":
+		return "Set the labels on the specified scope"
+	default:
+		return ""
+	}
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "set-labels":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes set-labels [options] [args]",
+			"",
+			`  This command sets the complete set of labels on a scope, which grants can match on. Any labels not given are removed. Setting "null" removes all labels. The "label" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary scopes set-labels -id o_1234567890 -label env=prod -label team=db`,
+			"",
+			"",
+		})
+	default:
+		return helpMap["base"]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
 type extraCmdVars struct {
 	flagSkipAdminRoleCreation   bool
 	flagSkipDefaultRoleCreation bool
 	flagPrimaryAuthMethodId     string
+	flagLabels                  []string
+	labels                      map[string]string
 }
 
 func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
@@ -56,6 +90,8 @@ func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagPrimaryAuthMethodId,
 				Usage:  "If set, the primary auth method id for the scope.  A primary auth method is allowed to create users on first login and is also used as a source for account full name and email for a scope's users",
 			})
+		case "label":
+			common.PopulateLabelFlag(f, &c.flagLabels, "scope")
 		}
 	}
 }
@@ -71,9 +107,27 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]scopes.Opt
 		*opts = append(*opts, scopes.WithPrimaryAuthMethodId(c.flagPrimaryAuthMethodId))
 	}
 
+	switch c.Func {
+	case "set-labels":
+		labels, err := common.ParseLabels(c.flagLabels)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return false
+		}
+		c.labels = labels
+	}
+
 	return true
 }
 
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, scopeClient *scopes.Client, _ uint32, opts []scopes.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "set-labels":
+		return scopeClient.SetLabels(c.Context, c.FlagId, c.labels, opts...)
+	}
+	return origResult, origError
+}
+
 func (c *Command) printListTable(items []*scopes.Scope) string {
 	if len(items) == 0 {
 		return "No child scopes found"
@@ -169,6 +223,14 @@ func printItemTable(result api.GenericResult) string {
 		)
 	}
 
+	if len(item.Labels) > 0 {
+		ret = append(ret,
+			"",
+			"  Labels:",
+			common.LabelsForOutput(item.Labels, maxLength),
+		)
+	}
+
 	if len(item.AuthorizedActions) > 0 {
 		ret = append(ret,
 			"",
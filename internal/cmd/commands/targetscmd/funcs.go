@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/boundary/api/targets"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
 	"github.com/hashicorp/boundary/sdk/strutil"
 	"github.com/mitchellh/go-wordwrap"
 	"github.com/posener/complete"
@@ -27,15 +28,20 @@ func init() {
 type extraCmdVars struct {
 	flagHostSets []string
 	flagHostId   string
+	flagLabels   []string
+	labels       map[string]string
 	sar          *targets.SessionAuthorizationResult
+	flagOwner    string
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"authorize-session": {"id", "host-id"},
-		"add-host-sets":     {"id", "host-set", "version"},
-		"remove-host-sets":  {"id", "host-set", "version"},
-		"set-host-sets":     {"id", "host-set", "version"},
+		"authorize-session":  {"id", "host-id"},
+		"add-host-sets":      {"id", "host-set", "version"},
+		"remove-host-sets":   {"id", "host-set", "version"},
+		"set-host-sets":      {"id", "host-set", "version"},
+		"set-labels":         {"id", "label"},
+		"transfer-ownership": {"id", "owner"},
 	}
 }
 
@@ -56,6 +62,12 @@ func extraSynopsisFuncImpl(c *Command) string {
 	case "authorize-session":
 		return "Request session authorization against the target"
 
+	case "set-labels":
+		return "Set the labels on the specified target"
+
+	case "transfer-ownership":
+		return "Transfer ownership of the specified target to another user"
+
 	default:
 		return ""
 	}
@@ -86,6 +98,10 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			`      $ boundary targets create tcp -name prodops -description "For ProdOps usage"`,
 			"",
+			"    Create a udp-type target:",
+			"",
+			`      $ boundary targets create udp -name dns -default-port 53`,
+			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
 	case "update":
@@ -152,6 +168,26 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"",
 		})
+	case "set-labels":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary targets set-labels [options] [args]",
+			"",
+			`  This command sets the complete set of labels on a target, which grants can match on. Any labels not given are removed. Setting "null" removes all labels. The "label" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary targets set-labels -id ttcp_1234567890 -label env=prod -label team=db`,
+			"",
+			"",
+		})
+	case "transfer-ownership":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary targets transfer-ownership [options] [args]",
+			"",
+			`  This command makes another user the owner of a target. Grants using the "{{owner}}" template match the resources a user owns. Example:`,
+			"",
+			`    $ boundary targets transfer-ownership -id ttcp_1234567890 -owner u_1234567890`,
+			"",
+			"",
+		})
 	}
 	return helpStr + c.Flags().Help()
 }
@@ -171,6 +207,10 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagHostId,
 				Usage:  "The ID of a specific host to connect to out of the hosts from the target's host sets. If not specified, one is chosen at random.",
 			})
+		case "label":
+			common.PopulateLabelFlag(f, &c.flagLabels, "target")
+		case "owner":
+			common.PopulateOwnerFlag(f, &c.flagOwner, "target")
 		}
 	}
 
@@ -256,6 +296,20 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]targets.Op
 		if len(c.flagHostId) != 0 {
 			*opts = append(*opts, targets.WithHostId(c.flagHostId))
 		}
+
+	case "set-labels":
+		labels, err := common.ParseLabels(c.flagLabels)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return false
+		}
+		c.labels = labels
+
+	case "transfer-ownership":
+		if c.flagOwner == "" {
+			c.UI.Error("No owner supplied via -owner")
+			return false
+		}
 	}
 
 	return true
@@ -274,6 +328,10 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		c.plural = "a session against target"
 		c.sar, err = targetClient.AuthorizeSession(c.Context, c.FlagId, opts...)
 		return nil, err
+	case "set-labels":
+		return targetClient.SetLabels(c.Context, c.FlagId, c.labels, opts...)
+	case "transfer-ownership":
+		return targetClient.TransferOwnership(c.Context, c.FlagId, c.flagOwner, opts...)
 	}
 	return origResult, origError
 }
@@ -360,6 +418,9 @@ func printItemTable(result api.GenericResult) string {
 	if item.Description != "" {
 		nonAttributeMap["Description"] = item.Description
 	}
+	if item.OwnerId != "" {
+		nonAttributeMap["Owner ID"] = item.OwnerId
+	}
 	if item.WorkerFilter != "" {
 		nonAttributeMap["Worker Filter"] = item.WorkerFilter
 	}
@@ -410,6 +471,14 @@ func printItemTable(result api.GenericResult) string {
 		)
 	}
 
+	if len(item.Labels) > 0 {
+		ret = append(ret,
+			"",
+			"  Labels:",
+			common.LabelsForOutput(item.Labels, maxLength),
+		)
+	}
+
 	ret = append(ret,
 		"",
 	)
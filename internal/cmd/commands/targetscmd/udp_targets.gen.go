@@ -0,0 +1,244 @@
+// Code generated by "make api"; DO NOT EDIT.
+package targetscmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+func initUdpFlags() {
+	flagsOnce.Do(func() {
+		extraFlags := extraUdpActionsFlagsMapFunc()
+		for k, v := range extraFlags {
+			flagsUdpMap[k] = append(flagsUdpMap[k], v...)
+		}
+	})
+}
+
+var (
+	_ cli.Command             = (*UdpCommand)(nil)
+	_ cli.CommandAutocomplete = (*UdpCommand)(nil)
+)
+
+type UdpCommand struct {
+	*base.Command
+
+	Func string
+
+	plural string
+
+	extraUdpCmdVars
+}
+
+func (c *UdpCommand) AutocompleteArgs() complete.Predictor {
+	initUdpFlags()
+	return complete.PredictAnything
+}
+
+func (c *UdpCommand) AutocompleteFlags() complete.Flags {
+	initUdpFlags()
+	return c.Flags().Completions()
+}
+
+func (c *UdpCommand) Synopsis() string {
+	if extra := extraUdpSynopsisFunc(c); extra != "" {
+		return extra
+	}
+
+	synopsisStr := "target"
+
+	synopsisStr = fmt.Sprintf("%s %s", "udp-type", synopsisStr)
+
+	return common.SynopsisFunc(c.Func, synopsisStr)
+}
+
+func (c *UdpCommand) Help() string {
+	initUdpFlags()
+
+	var helpStr string
+	helpMap := common.HelpMap("target")
+
+	switch c.Func {
+	default:
+
+		helpStr = c.extraUdpHelpFunc(helpMap)
+	}
+
+	// Keep linter from complaining if we don't actually generate code using it
+	_ = helpMap
+	return helpStr
+}
+
+var flagsUdpMap = map[string][]string{
+
+	"create": {"scope-id", "name", "description"},
+
+	"update": {"id", "name", "description", "version"},
+}
+
+func (c *UdpCommand) Flags() *base.FlagSets {
+	if len(flagsUdpMap[c.Func]) == 0 {
+		return c.FlagSet(base.FlagSetNone)
+	}
+
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "udp-type target", flagsUdpMap[c.Func])
+
+	extraUdpFlagsFunc(c, set, f)
+
+	return set
+}
+
+func (c *UdpCommand) Run(args []string) int {
+	initUdpFlags()
+
+	switch c.Func {
+	case "":
+		return cli.RunResultHelp
+	}
+
+	c.plural = "udp-type target"
+	switch c.Func {
+	case "list":
+		c.plural = "udp-type targets"
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	if strutil.StrListContains(flagsUdpMap[c.Func], "id") && c.FlagId == "" {
+		c.PrintCliError(errors.New("ID is required but not passed in via -id"))
+		return base.CommandUserError
+	}
+
+	var opts []targets.Option
+
+	if strutil.StrListContains(flagsUdpMap[c.Func], "scope-id") {
+		switch c.Func {
+		case "create":
+			if c.FlagScopeId == "" {
+				c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+				return base.CommandUserError
+			}
+		}
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %s", err.Error()))
+		return base.CommandCliError
+	}
+	targetsClient := targets.NewClient(client)
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultName())
+	default:
+		opts = append(opts, targets.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, targets.DefaultDescription())
+	default:
+		opts = append(opts, targets.WithDescription(c.FlagDescription))
+	}
+
+	switch c.FlagRecursive {
+	case true:
+		opts = append(opts, targets.WithRecursive(true))
+	}
+
+	if c.FlagFilter != "" {
+		opts = append(opts, targets.WithFilter(c.FlagFilter))
+	}
+
+	var version uint32
+
+	switch c.Func {
+	case "update":
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, targets.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	if ok := extraUdpFlagsHandlingFunc(c, f, &opts); !ok {
+		return base.CommandUserError
+	}
+
+	var result api.GenericResult
+
+	switch c.Func {
+
+	case "create":
+		result, err = targetsClient.Create(c.Context, "udp", c.FlagScopeId, opts...)
+
+	case "update":
+		result, err = targetsClient.Update(c.Context, c.FlagId, version, opts...)
+
+	}
+
+	result, err = executeExtraUdpActions(c, result, err, targetsClient, version, opts)
+
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, fmt.Sprintf("Error from controller when performing %s on %s", c.Func, c.plural))
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to %s %s: %s", c.Func, c.plural, err.Error()))
+		return base.CommandCliError
+	}
+
+	output, err := printCustomUdpActionOutput(c)
+	if err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if output {
+		return base.CommandSuccess
+	}
+
+	switch c.Func {
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(printItemTable(result))
+
+	case "json":
+		if ok := c.PrintJsonItem(result); !ok {
+			return base.CommandCliError
+		}
+	}
+
+	return base.CommandSuccess
+}
+
+var (
+	extraUdpActionsFlagsMapFunc = func() map[string][]string { return nil }
+	extraUdpSynopsisFunc        = func(*UdpCommand) string { return "" }
+	extraUdpFlagsFunc           = func(*UdpCommand, *base.FlagSets, *base.FlagSet) {}
+	extraUdpFlagsHandlingFunc   = func(*UdpCommand, *base.FlagSets, *[]targets.Option) bool { return true }
+	executeExtraUdpActions      = func(_ *UdpCommand, inResult api.GenericResult, inErr error, _ *targets.Client, _ uint32, _ []targets.Option) (api.GenericResult, error) {
+		return inResult, inErr
+	}
+	printCustomUdpActionOutput = func(*UdpCommand) (bool, error) { return false, nil }
+)
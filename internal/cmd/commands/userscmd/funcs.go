@@ -1,7 +1,10 @@
 package userscmd
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,10 +20,12 @@ func init() {
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 type extraCmdVars struct {
 	flagAccounts []string
+	epr          *users.EffectivePermissionsResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
@@ -28,6 +33,8 @@ func extraActionsFlagsMapFuncImpl() map[string][]string {
 		"add-accounts":    {"id", "account", "version"},
 		"set-accounts":    {"id", "account", "version"},
 		"remove-accounts": {"id", "account", "version"},
+
+		"read-effective-permissions": {"id"},
 	}
 }
 
@@ -44,6 +51,9 @@ func extraSynopsisFuncImpl(c *Command) string {
 			in = "Remove accounts from"
 		}
 		return wordwrap.WrapString(fmt.Sprintf("%s a user within Boundary", in), base.TermWidth)
+
+	case "read-effective-permissions":
+		return wordwrap.WrapString("Read the effective permissions of a user within Boundary", base.TermWidth)
 	}
 
 	return ""
@@ -85,6 +95,17 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "read-effective-permissions":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary users read-effective-permissions [options] [args]",
+			"",
+			`  Reads the permissions the grants of a user amount to across all scopes, given its ID. Grants the user receives through groups, and those given to all users through u_anon and u_auth, are included. Each permission lists the actions allowed and denied on a resource type and ID within a scope, and the roles the grants come from. Besides "table" and "json", the "format" flag accepts "csv" for use in access reviews. Example:`,
+			"",
+			`    $ boundary users read-effective-permissions -id u_1234567890 -format csv`,
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -135,6 +156,11 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		return userClient.SetAccounts(c.Context, c.FlagId, version, c.flagAccounts, opts...)
 	case "remove-accounts":
 		return userClient.RemoveAccounts(c.Context, c.FlagId, version, c.flagAccounts, opts...)
+	case "read-effective-permissions":
+		var err error
+		c.plural = "effective permissions of user"
+		c.epr, err = userClient.ReadEffectivePermissions(c.Context, c.FlagId, opts...)
+		return nil, err
 	}
 	return origResult, origError
 }
@@ -302,3 +328,121 @@ func printItemTable(result api.GenericResult) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "read-effective-permissions":
+		item := c.epr.GetItem().(*users.EffectivePermissions)
+
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printEffectivePermissionsTable(item))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.epr); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+
+		case "csv":
+			out, err := effectivePermissionsCsv(item)
+			if err != nil {
+				return false, fmt.Errorf("Error formatting as CSV: %w", err)
+			}
+			c.UI.Output(out)
+			return true, nil
+
+		default:
+			return false, fmt.Errorf("Unknown format %q; valid formats are \"table\", \"json\" and \"csv\"", base.Format(c.UI))
+		}
+	}
+
+	return false, nil
+}
+
+func printEffectivePermissionsTable(item *users.EffectivePermissions) string {
+	if len(item.Permissions) == 0 {
+		return fmt.Sprintf("No permissions found for user %s", item.UserId)
+	}
+
+	ret := []string{
+		"",
+		"Effective permissions:",
+		fmt.Sprintf("  User ID:                %s", item.UserId),
+	}
+	for _, p := range item.Permissions {
+		ret = append(ret,
+			"",
+			fmt.Sprintf("  Scope ID:               %s", p.ScopeId),
+		)
+		if p.ResourceType != "" {
+			ret = append(ret,
+				fmt.Sprintf("    Resource Type:        %s", p.ResourceType),
+			)
+		}
+		if p.ResourceId != "" {
+			ret = append(ret,
+				fmt.Sprintf("    Resource ID:          %s", p.ResourceId),
+			)
+		}
+		if len(p.Labels) > 0 {
+			ret = append(ret,
+				fmt.Sprintf("    Labels:               %s", strings.Join(labelPairs(p.Labels), ", ")),
+			)
+		}
+		if len(p.Actions) > 0 {
+			ret = append(ret,
+				fmt.Sprintf("    Actions:              %s", strings.Join(p.Actions, ", ")),
+			)
+		}
+		if len(p.DeniedActions) > 0 {
+			ret = append(ret,
+				fmt.Sprintf("    Denied Actions:       %s", strings.Join(p.DeniedActions, ", ")),
+			)
+		}
+		if len(p.RoleIds) > 0 {
+			ret = append(ret,
+				fmt.Sprintf("    Role IDs:             %s", strings.Join(p.RoleIds, ", ")),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(ret)
+}
+
+// effectivePermissionsCsv writes one row per permission, with the lists of
+// labels, actions and roles space separated
+func effectivePermissionsCsv(item *users.EffectivePermissions) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	rows := [][]string{
+		{"user_id", "scope_id", "resource_type", "resource_id", "labels", "actions", "denied_actions", "role_ids"},
+	}
+	for _, p := range item.Permissions {
+		rows = append(rows, []string{
+			item.UserId,
+			p.ScopeId,
+			p.ResourceType,
+			p.ResourceId,
+			strings.Join(labelPairs(p.Labels), " "),
+			strings.Join(p.Actions, " "),
+			strings.Join(p.DeniedActions, " "),
+			strings.Join(p.RoleIds, " "),
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// labelPairs returns the labels as "key=value" strings sorted by key
+func labelPairs(labels map[string]string) []string {
+	ret := make([]string, 0, len(labels))
+	for k, v := range labels {
+		ret = append(ret, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -26,18 +26,19 @@ func SynopsisFunc(inFunc, resType string) string {
 
 func HelpMap(resType string) map[string]func() string {
 	prefixMap := map[string]string{
-		resource.Scope.String():       "o",
-		resource.AuthToken.String():   "at",
-		resource.AuthMethod.String():  "am",
-		resource.Account.String():     "a",
-		resource.Role.String():        "r",
-		resource.Group.String():       "g",
-		resource.User.String():        "u",
-		resource.HostCatalog.String(): "hc",
-		resource.HostSet.String():     "hs",
-		resource.Host.String():        "h",
-		resource.Session.String():     "s",
-		resource.Target.String():      "t",
+		resource.Scope.String():        "o",
+		resource.AuthToken.String():    "at",
+		resource.AuthMethod.String():   "am",
+		resource.Account.String():      "a",
+		resource.Role.String():         "r",
+		resource.RoleTemplate.String(): "rt",
+		resource.Group.String():        "g",
+		resource.User.String():         "u",
+		resource.HostCatalog.String():  "hc",
+		resource.HostSet.String():      "hs",
+		resource.Host.String():         "h",
+		resource.Session.String():      "s",
+		resource.Target.String():       "t",
 	}
 	return map[string]func() string{
 		"base": func() string {
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/posener/complete"
+)
+
+// PopulateLabelFlag adds the repeatable label flag used to set labels on a
+// resource
+func PopulateLabelFlag(f *base.FlagSet, target *[]string, resourceType string) {
+	f.StringSliceVar(&base.StringSliceVar{
+		Name:       "label",
+		Target:     target,
+		Completion: complete.PredictAnything,
+		Usage:      fmt.Sprintf(`A label to set on the %s, as "key=value". May be specified multiple times. Set to "null" to remove all labels.`, resourceType),
+	})
+}
+
+// ParseLabels parses labels given as "key=value" with the label flag. A
+// single "null" value returns an empty, non-nil map, which removes all labels
+// when set.
+func ParseLabels(in []string) (map[string]string, error) {
+	switch {
+	case len(in) == 0:
+		return nil, fmt.Errorf(`No labels supplied via -label, use "null" to remove all labels`)
+	case len(in) == 1 && in[0] == "null":
+		return map[string]string{}, nil
+	}
+	ret := make(map[string]string, len(in))
+	for _, label := range in {
+		kv := strings.SplitN(label, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("Label %q not formatted correctly, must be key=value", label)
+		}
+		if _, ok := ret[kv[0]]; ok {
+			return nil, fmt.Errorf("Label key %q given more than once", kv[0])
+		}
+		ret[kv[0]] = kv[1]
+	}
+	return ret, nil
+}
+
+// LabelsForOutput returns the labels formatted for table output
+func LabelsForOutput(labels map[string]string, maxLength int) string {
+	vals := make(map[string]interface{}, len(labels))
+	for k, v := range labels {
+		vals[k] = v
+	}
+	return base.WrapMap(4, maxLength, vals)
+}
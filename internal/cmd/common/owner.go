@@ -0,0 +1,19 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/posener/complete"
+)
+
+// PopulateOwnerFlag adds the owner flag used to transfer ownership of a
+// resource
+func PopulateOwnerFlag(f *base.FlagSet, target *string, resourceType string) {
+	f.StringVar(&base.StringVar{
+		Name:       "owner",
+		Target:     target,
+		Completion: complete.PredictAnything,
+		Usage:      fmt.Sprintf("The ID of the user to make the owner of the %s.", resourceType),
+	})
+}
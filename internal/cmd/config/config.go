@@ -117,6 +117,58 @@ type Controller struct {
 	// denoted by time.Duration
 	AuthTokenTimeToStale         interface{} `hcl:"auth_token_time_to_stale"`
 	AuthTokenTimeToStaleDuration time.Duration
+
+	// OidcRevalidationInterval is how often users who authenticated via an
+	// oidc auth method are re-validated with their provider denoted by
+	// time.Duration.  Re-validation is disabled when it's not set.
+	OidcRevalidationInterval         interface{} `hcl:"oidc_revalidation_interval"`
+	OidcRevalidationIntervalDuration time.Duration
+
+	// Scim configures the bearer tokens accepted by the SCIM provisioning
+	// endpoint.  The endpoint is disabled when there are none.
+	Scim []*Scim `hcl:"scim"`
+
+	// ApiRateLimits are the token bucket limits applied to requests to the
+	// controller's API.  Requests are not limited when there are none.
+	ApiRateLimits []*ApiRateLimit `hcl:"api_rate_limit"`
+}
+
+// Scim is a bearer token accepted by the SCIM provisioning endpoint.  The
+// token can only provision the users and groups of its scope, which is the
+// block's label, e.g. scim "o_1234567890" { token = "env://SCIM_TOKEN" }
+type Scim struct {
+	ScopeId string `hcl:",key"`
+	Token   string `hcl:"token"`
+}
+
+// The values an ApiRateLimit can count requests per
+const (
+	RateLimitPerIp        = "ip"
+	RateLimitPerAuthToken = "auth_token"
+	RateLimitPerEndpoint  = "endpoint"
+)
+
+// The classes of endpoints an ApiRateLimit can apply to
+const (
+	RateLimitEndpointAuthenticate = "authenticate"
+	RateLimitEndpointList         = "list"
+	RateLimitEndpointRead         = "read"
+	RateLimitEndpointWrite        = "write"
+)
+
+// ApiRateLimit is a token bucket allowing Limit requests to the API every
+// Period, e.g. api_rate_limit "ip" { endpoints = ["authenticate"] limit = 10
+// period = "1m" }.  The block's label is what requests are counted per: the
+// client's IP address, the auth token they use (or their IP address when they
+// use none, or one the controller didn't issue) or the endpoint class alone.
+// Endpoints limits the classes of endpoints the limit applies to, with each
+// class counted separately; it applies to all of them when empty.
+type ApiRateLimit struct {
+	Per            string        `hcl:",key"`
+	Endpoints      []string      `hcl:"endpoints"`
+	Limit          int           `hcl:"limit"`
+	Period         interface{}   `hcl:"period"`
+	PeriodDuration time.Duration `hcl:"-"`
 }
 
 type Worker struct {
@@ -263,6 +315,61 @@ func Parse(d string) (*Config, error) {
 			}
 			result.Controller.AuthTokenTimeToStaleDuration = t
 		}
+
+		if result.Controller.OidcRevalidationInterval != "" {
+			t, err := parseutil.ParseDurationSecond(result.Controller.OidcRevalidationInterval)
+			if err != nil {
+				return result, err
+			}
+			result.Controller.OidcRevalidationIntervalDuration = t
+		}
+
+		tokens := make(map[string]bool, len(result.Controller.Scim))
+		for _, s := range result.Controller.Scim {
+			if s.ScopeId == "" {
+				return nil, errors.New("SCIM scope id must be set")
+			}
+			s.Token, err = ParseAddress(s.Token)
+			if err != nil && err != ErrNotAUrl {
+				return nil, fmt.Errorf("Error parsing SCIM token for scope %s: %w", s.ScopeId, err)
+			}
+			if s.Token == "" {
+				return nil, fmt.Errorf("SCIM token for scope %s must be set", s.ScopeId)
+			}
+			if tokens[s.Token] {
+				return nil, fmt.Errorf("SCIM token for scope %s is not unique", s.ScopeId)
+			}
+			tokens[s.Token] = true
+		}
+
+		for _, l := range result.Controller.ApiRateLimits {
+			switch l.Per {
+			case RateLimitPerIp, RateLimitPerAuthToken, RateLimitPerEndpoint:
+			default:
+				return nil, fmt.Errorf("Unknown API rate limit type %q", l.Per)
+			}
+			for _, e := range l.Endpoints {
+				switch e {
+				case RateLimitEndpointAuthenticate, RateLimitEndpointList, RateLimitEndpointRead, RateLimitEndpointWrite:
+				default:
+					return nil, fmt.Errorf("Unknown endpoint class %q in %s API rate limit", e, l.Per)
+				}
+			}
+			if l.Limit <= 0 {
+				return nil, fmt.Errorf("Limit of %s API rate limit must be greater than zero", l.Per)
+			}
+			if l.Period == nil {
+				return nil, fmt.Errorf("Period of %s API rate limit must be set", l.Per)
+			}
+			t, err := parseutil.ParseDurationSecond(l.Period)
+			if err != nil {
+				return nil, fmt.Errorf("Error parsing period of %s API rate limit: %w", l.Per, err)
+			}
+			if t <= 0 {
+				return nil, fmt.Errorf("Period of %s API rate limit must be greater than zero", l.Per)
+			}
+			l.PeriodDuration = t
+		}
 	}
 
 	// Parse worker tags
@@ -331,3 +331,120 @@ func TestParsingName(t *testing.T) {
 		})
 	}
 }
+
+func TestParsingScim(t *testing.T) {
+	t.Parallel()
+	os.Setenv("SCIMTOKENENV", "token-from-env")
+	out, err := Parse(`
+	controller {
+		name = "foobar"
+		scim "o_1234567890" {
+			token = "env://SCIMTOKENENV"
+		}
+		scim "o_0987654321" {
+			token = "another-token"
+		}
+	}
+	`)
+	require.NoError(t, err)
+	require.Len(t, out.Controller.Scim, 2)
+	assert.Equal(t, &Scim{ScopeId: "o_1234567890", Token: "token-from-env"}, out.Controller.Scim[0])
+	assert.Equal(t, &Scim{ScopeId: "o_0987654321", Token: "another-token"}, out.Controller.Scim[1])
+
+	for name, config := range map[string]string{
+		"missing-scope": `controller {
+			scim "" {
+				token = "a-token"
+			}
+		}`,
+		"missing-token": `controller {
+			scim "o_1234567890" {
+			}
+		}`,
+		"duplicate-token": `controller {
+			scim "o_1234567890" {
+				token = "a-token"
+			}
+			scim "o_0987654321" {
+				token = "a-token"
+			}
+		}`,
+	} {
+		_, err := Parse(config)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestParsingApiRateLimits(t *testing.T) {
+	t.Parallel()
+	out, err := Parse(`
+	controller {
+		name = "foobar"
+		api_rate_limit "ip" {
+			endpoints = ["authenticate"]
+			limit = 10
+			period = "1m"
+		}
+		api_rate_limit "ip" {
+			limit = 100
+			period = 1
+		}
+		api_rate_limit "endpoint" {
+			endpoints = ["list", "read"]
+			limit = 1000
+			period = "10s"
+		}
+	}
+	`)
+	require.NoError(t, err)
+	require.Len(t, out.Controller.ApiRateLimits, 3)
+	l := out.Controller.ApiRateLimits[0]
+	assert.Equal(t, "ip", l.Per)
+	assert.Equal(t, []string{"authenticate"}, l.Endpoints)
+	assert.Equal(t, 10, l.Limit)
+	assert.Equal(t, time.Minute, l.PeriodDuration)
+	l = out.Controller.ApiRateLimits[1]
+	assert.Equal(t, "ip", l.Per)
+	assert.Empty(t, l.Endpoints)
+	assert.Equal(t, 100, l.Limit)
+	assert.Equal(t, time.Second, l.PeriodDuration)
+	l = out.Controller.ApiRateLimits[2]
+	assert.Equal(t, "endpoint", l.Per)
+	assert.Equal(t, []string{"list", "read"}, l.Endpoints)
+	assert.Equal(t, 10*time.Second, l.PeriodDuration)
+
+	for name, config := range map[string]string{
+		"unknown-per": `controller {
+			api_rate_limit "user" {
+				limit = 10
+				period = "1m"
+			}
+		}`,
+		"unknown-endpoint": `controller {
+			api_rate_limit "ip" {
+				endpoints = ["delete"]
+				limit = 10
+				period = "1m"
+			}
+		}`,
+		"missing-limit": `controller {
+			api_rate_limit "ip" {
+				period = "1m"
+			}
+		}`,
+		"missing-period": `controller {
+			api_rate_limit "ip" {
+				limit = 10
+			}
+		}`,
+		"bad-period": `controller {
+			api_rate_limit "auth_token" {
+				limit = 10
+				period = "soon"
+			}
+		}`,
+	} {
+		_, err := Parse(config)
+		assert.Error(t, err, name)
+	}
+}
@@ -173,13 +173,14 @@ var inputStructs = map[string][]*cmdInfo{
 	},
 	"hostcatalogs": {
 		{
-			ResourceType:     resource.HostCatalog.String(),
-			Pkg:              "hostcatalogs",
-			StdActions:       []string{"read", "delete", "list"},
-			IsAbstractType:   true,
-			HasExtraHelpFunc: true,
-			Container:        "Scope",
-			HasId:            true,
+			ResourceType:        resource.HostCatalog.String(),
+			Pkg:                 "hostcatalogs",
+			StdActions:          []string{"read", "delete", "list"},
+			HasExtraCommandVars: true,
+			IsAbstractType:      true,
+			HasExtraHelpFunc:    true,
+			Container:           "Scope",
+			HasId:               true,
 		},
 		{
 			ResourceType:         resource.HostCatalog.String(),
@@ -226,15 +227,16 @@ var inputStructs = map[string][]*cmdInfo{
 	},
 	"hosts": {
 		{
-			ResourceType:     resource.Host.String(),
-			Pkg:              "hosts",
-			StdActions:       []string{"read", "delete", "list"},
-			HasExtraHelpFunc: true,
-			IsAbstractType:   true,
-			Container:        "HostCatalog",
-			HasId:            true,
-			HasName:          true,
-			HasDescription:   true,
+			ResourceType:        resource.Host.String(),
+			Pkg:                 "hosts",
+			StdActions:          []string{"read", "delete", "list"},
+			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
+			IsAbstractType:      true,
+			Container:           "HostCatalog",
+			HasId:               true,
+			HasName:             true,
+			HasDescription:      true,
 		},
 		{
 			ResourceType:        resource.Host.String(),
@@ -262,6 +264,20 @@ var inputStructs = map[string][]*cmdInfo{
 			Container:           "Scope",
 			HasName:             true,
 			HasDescription:      true,
+			VersionedActions:    []string{"update", "add-grants", "remove-grants", "set-grants", "add-grant-scopes", "remove-grant-scopes", "set-grant-scopes", "add-principals", "remove-principals", "set-principals"},
+		},
+	},
+	"roletemplates": {
+		{
+			ResourceType:        resource.RoleTemplate.String(),
+			Pkg:                 "roletemplates",
+			StdActions:          []string{"create", "read", "update", "delete", "list"},
+			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
+			HasId:               true,
+			Container:           "Scope",
+			HasName:             true,
+			HasDescription:      true,
 			VersionedActions:    []string{"update", "add-grants", "remove-grants", "set-grants", "add-principals", "remove-principals", "set-principals"},
 		},
 	},
@@ -271,6 +287,7 @@ var inputStructs = map[string][]*cmdInfo{
 			Pkg:                 "scopes",
 			StdActions:          []string{"create", "read", "update", "delete", "list"},
 			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
 			HasId:               true,
 			Container:           "Scope",
 			HasName:             true,
@@ -318,6 +335,21 @@ var inputStructs = map[string][]*cmdInfo{
 			VersionedActions:     []string{"update"},
 			NeedsSubTypeInCreate: true,
 		},
+		{
+			ResourceType:         resource.Target.String(),
+			Pkg:                  "targets",
+			StdActions:           []string{"create", "update"},
+			SubActionPrefix:      "udp",
+			HasExtraCommandVars:  true,
+			SkipNormalHelp:       true,
+			HasExtraHelpFunc:     true,
+			HasId:                true,
+			HasName:              true,
+			Container:            "Scope",
+			HasDescription:       true,
+			VersionedActions:     []string{"update"},
+			NeedsSubTypeInCreate: true,
+		},
 	},
 	"users": {
 		{
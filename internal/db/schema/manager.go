@@ -176,6 +176,31 @@ func (b *Manager) RollForward(ctx context.Context) error {
 	return nil
 }
 
+// Migration is an embedded migration.
+type Migration struct {
+	// Version is the schema version the migration migrates to.
+	Version int
+	// Statements are the migration's SQL statements.
+	Statements string
+}
+
+// PendingMigrations returns the migrations which RollForward would run, in
+// the order it would run them.  An empty slice is returned if the database is
+// already at the most recent version.
+func (b *Manager) PendingMigrations(ctx context.Context) ([]Migration, error) {
+	const op = "schema.(Manager).PendingMigrations"
+	curVersion, _, _, err := b.driver.CurrentState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	var pending []Migration
+	qp := newStatementProvider(b.dialect, curVersion, WithMigrationStates(b.migrationStates))
+	for qp.Next() {
+		pending = append(pending, Migration{Version: qp.Version(), Statements: string(qp.ReadUp())})
+	}
+	return pending, nil
+}
+
 type rollbacker interface {
 	Rollback() error
 }
@@ -141,6 +141,47 @@ func TestRollForward_NotFromFresh(t *testing.T) {
 	assert.False(t, state.Dirty)
 }
 
+func TestPendingMigrations(t *testing.T) {
+	dialect := "postgres"
+	oState := migrationStates[dialect]
+	nState := createPartialMigrationState(oState, 8)
+
+	c, u, _, err := docker.StartDbInDocker(dialect)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c())
+	})
+	d, err := sql.Open(dialect, u)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	m, err := NewManager(ctx, dialect, d, WithMigrationStates(map[string]migrationState{dialect: nState}))
+	require.NoError(t, err)
+
+	// All the migrations are pending for a fresh database.
+	pending, err := m.PendingMigrations(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, len(nState.upMigrations))
+	assert.True(t, sort.SliceIsSorted(pending, func(i, j int) bool { return pending[i].Version < pending[j].Version }))
+	for _, p := range pending {
+		assert.Equal(t, string(nState.upMigrations[p.Version]), p.Statements)
+	}
+	require.NoError(t, m.RollForward(ctx))
+	pending, err = m.PendingMigrations(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// The rest of the migrations are pending for the full migration state.
+	newM, err := NewManager(ctx, dialect, d)
+	require.NoError(t, err)
+	pending, err = newM.PendingMigrations(ctx)
+	require.NoError(t, err)
+	assert.Len(t, pending, len(oState.upMigrations)-len(nState.upMigrations))
+	for _, p := range pending {
+		assert.Greater(t, p.Version, nState.binarySchemaVersion)
+	}
+}
+
 func TestRunMigration_canceledContext(t *testing.T) {
 	dialect := "postgres"
 
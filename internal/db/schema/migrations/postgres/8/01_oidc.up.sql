@@ -0,0 +1,72 @@
+begin;
+
+-- auth_oidc_provider_token entries are the tokens returned by an oidc provider
+-- for a successful authentication.  There is at most one for each auth token
+-- issued by an oidc auth method and it's deleted along with its auth token.
+-- The tokens are used to periodically re-validate the user with the provider
+-- and the provider_session_id is used to find the auth tokens to revoke when
+-- the provider sends a back-channel logout request.
+create table auth_oidc_provider_token (
+  auth_token_id wt_public_id primary key
+    constraint auth_token_fkey
+      references auth_token(public_id)
+      on delete cascade
+      on update cascade,
+  oidc_method_id wt_public_id not null
+    constraint auth_oidc_method_fkey
+      references auth_oidc_method(public_id)
+      on delete cascade
+      on update cascade,
+  account_id wt_public_id not null,
+  provider_session_id text -- maps to an id_token's optional sid claim
+    constraint provider_session_id_must_not_be_empty
+      check(length(trim(provider_session_id)) > 0),
+  refresh_token bytea not null -- encrypted refresh_token, which may be an encrypted empty value.
+    constraint refresh_token_must_not_be_empty
+      check(length(refresh_token) > 0),
+  access_token bytea not null -- encrypted access_token, which may be an encrypted empty value.
+    constraint access_token_must_not_be_empty
+      check(length(access_token) > 0),
+  access_token_expiration_time timestamp with time zone,
+  last_validation_time wt_timestamp,
+  key_id wt_private_id not null -- key used to encrypt entries via wrapping wrapper.
+    constraint kms_database_key_version_fkey
+      references kms_database_key_version(private_id)
+      on delete restrict
+      on update cascade,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  constraint auth_oidc_account_fkey
+    foreign key (oidc_method_id, account_id)
+      references auth_oidc_account (auth_method_id, public_id)
+      on delete cascade
+      on update cascade
+);
+comment on table auth_oidc_provider_token is
+'auth_oidc_provider_token entries are the tokens returned by an oidc provider for a successful authentication.  There is at most one for each auth token issued by an oidc auth method.';
+
+create index auth_oidc_provider_token_account_id_ix
+  on auth_oidc_provider_token (account_id);
+
+create index auth_oidc_provider_token_provider_session_id_ix
+  on auth_oidc_provider_token (oidc_method_id, provider_session_id);
+
+create trigger
+  update_time_column
+before
+update on auth_oidc_provider_token
+  for each row execute procedure update_time_column();
+
+create trigger
+  immutable_columns
+before
+update on auth_oidc_provider_token
+  for each row execute procedure immutable_columns('auth_token_id', 'oidc_method_id', 'account_id', 'provider_session_id', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on auth_oidc_provider_token
+  for each row execute procedure default_create_time();
+
+commit;
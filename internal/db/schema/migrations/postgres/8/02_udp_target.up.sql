@@ -0,0 +1,232 @@
+begin;
+
+create table target_udp (
+  public_id wt_public_id primary key
+    references target(public_id)
+    on delete cascade
+    on update cascade,
+  scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  name text not null, -- name is not optional for a target subtype
+  description text,
+  default_port int, -- default_port can be null
+   -- max duration of the session in seconds.
+   -- default is 8 hours
+  session_max_seconds int not null default 28800
+    constraint session_max_seconds_must_be_greater_than_0
+    check(session_max_seconds > 0),
+  -- limit on number of session connections allowed. -1 equals no limit
+  session_connection_limit int not null default 1
+    constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+    check(session_connection_limit > 0 or session_connection_limit = -1),
+  worker_filter wt_bexprfilter,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  unique(scope_id, name) -- name must be unique within a scope
+);
+
+create trigger
+  insert_target_subtype
+before insert on target_udp
+  for each row execute procedure insert_target_subtype();
+
+create trigger
+  delete_target_subtype
+after delete on target_udp
+  for each row execute procedure delete_target_subtype();
+
+create trigger
+  immutable_columns
+before
+update on target_udp
+  for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+create trigger
+  update_version_column
+after update on target_udp
+  for each row execute procedure update_version_column();
+
+create trigger
+  update_time_column
+before update on target_udp
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on target_udp
+  for each row execute procedure default_create_time();
+
+create trigger
+  target_scope_valid
+before insert on target_udp
+  for each row execute procedure target_scope_valid();
+
+-- Replaces the view created in 1/01 to include udp targets
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  version,
+  create_time,
+  update_time,
+  worker_filter,
+  'tcp' as type
+from target_tcp
+union all
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  version,
+  create_time,
+  update_time,
+  worker_filter,
+  'udp' as type
+from target_udp;
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('target_udp', 1);
+
+-- Connections to udp targets count the datagrams proxied in each direction as
+-- well as their bytes.
+alter table session_connection
+  add column datagrams_up bigint -- can be null
+    constraint datagrams_up_must_be_null_or_a_non_negative_number
+    check (
+      datagrams_up is null
+      or
+      datagrams_up >= 0
+    ),
+  add column datagrams_down bigint -- can be null
+    constraint datagrams_down_must_be_null_or_a_non_negative_number
+    check (
+      datagrams_down is null
+      or
+      datagrams_down >= 0
+    );
+
+alter table wh_session_connection_accumulating_fact
+  add column datagrams_up bigint -- can be null
+    constraint datagrams_up_must_be_null_or_a_non_negative_number
+    check (
+      datagrams_up is null
+      or
+      datagrams_up >= 0
+    ),
+  add column datagrams_down bigint -- can be null
+    constraint datagrams_down_must_be_null_or_a_non_negative_number
+    check (
+      datagrams_down is null
+      or
+      datagrams_down >= 0
+    );
+
+comment on column wh_session_connection_accumulating_fact.datagrams_up is
+  'Datagrams Up is the total number of datagrams received by the worker from '
+  'the client and sent to the endpoint for a connection to a udp target. '
+  'Datagrams Up is a fully additive measurement.';
+comment on column wh_session_connection_accumulating_fact.datagrams_down is
+  'Datagrams Down is the total number of datagrams received by the worker from '
+  'the endpoint and sent to the client for a connection to a udp target. '
+  'Datagrams Down is a fully additive measurement.';
+
+-- Replaces the function created in 0/69 to include datagrams
+create or replace function wh_update_session_connection()
+  returns trigger
+as $$
+declare
+  updated_row wh_session_connection_accumulating_fact%rowtype;
+begin
+      update wh_session_connection_accumulating_fact
+         set client_tcp_address       = new.client_tcp_address,
+             client_tcp_port_number   = new.client_tcp_port,
+             endpoint_tcp_address     = new.endpoint_tcp_address,
+             endpoint_tcp_port_number = new.endpoint_tcp_port,
+             bytes_up                 = new.bytes_up,
+             bytes_down               = new.bytes_down,
+             datagrams_up             = new.datagrams_up,
+             datagrams_down           = new.datagrams_down
+       where connection_id = new.public_id
+   returning * into strict updated_row;
+  perform wh_rollup_connections(new.session_id);
+  return null;
+end;
+$$ language plpgsql;
+
+-- Replaces the view created in 0/65 to include udp targets
+create or replace view whx_host_dimension_source as
+with target_all as (
+  select public_id, scope_id, name, description, default_port,
+         session_max_seconds, session_connection_limit,
+         'tcp target' as target_type
+    from target_tcp
+  union all
+  select public_id, scope_id, name, description, default_port,
+         session_max_seconds, session_connection_limit,
+         'udp target' as target_type
+    from target_udp
+)
+select -- id is the first column in the target view
+       h.public_id                     as host_id,
+       'static host'                   as host_type,
+       coalesce(h.name, 'None')        as host_name,
+       coalesce(h.description, 'None') as host_description,
+       coalesce(h.address, 'Unknown')  as host_address,
+       s.public_id                     as host_set_id,
+       'static host set'               as host_set_type,
+       coalesce(s.name, 'None')        as host_set_name,
+       coalesce(s.description, 'None') as host_set_description,
+       c.public_id                     as host_catalog_id,
+       'static host catalog'           as host_catalog_type,
+       coalesce(c.name, 'None')        as host_catalog_name,
+       coalesce(c.description, 'None') as host_catalog_description,
+       t.public_id                     as target_id,
+       t.target_type                   as target_type,
+       coalesce(t.name, 'None')        as target_name,
+       coalesce(t.description, 'None') as target_description,
+       coalesce(t.default_port, 0)     as target_default_port_number,
+       t.session_max_seconds           as target_session_max_seconds,
+       t.session_connection_limit      as target_session_connection_limit,
+       p.public_id                     as project_id,
+       coalesce(p.name, 'None')        as project_name,
+       coalesce(p.description, 'None') as project_description,
+       o.public_id                     as host_organization_id,
+       coalesce(o.name, 'None')        as host_organization_name,
+       coalesce(o.description, 'None') as host_organization_description
+  from static_host as h,
+       static_host_catalog as c,
+       static_host_set_member as m,
+       static_host_set as s,
+       target_host_set as ts,
+       target_all as t,
+       iam_scope as p,
+       iam_scope as o
+ where h.catalog_id = c.public_id
+   and h.public_id = m.host_id
+   and s.public_id = m.set_id
+   and t.public_id = ts.target_id
+   and s.public_id = ts.host_set_id
+   and p.public_id = t.scope_id
+   and p.type = 'project'
+   and o.public_id = p.parent_id
+   and o.type = 'org'
+;
+
+commit;
@@ -0,0 +1,52 @@
+begin;
+
+-- static_host_ssh_host_key holds the SSH host key pinned for a static host.
+-- The key is pinned the first time a worker reports the key the host
+-- presents. A different key reported later is recorded as pending until it
+-- is trusted, which replaces the pinned key.
+create table static_host_ssh_host_key (
+  host_id wt_public_id primary key
+    references static_host (public_id)
+    on delete cascade
+    on update cascade,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  host_key bytea not null
+    constraint host_key_must_not_be_empty
+    check(length(host_key) > 0),
+  fingerprint text not null
+    constraint fingerprint_must_not_be_empty
+    check(length(trim(fingerprint)) > 0),
+  pending_host_key bytea
+    constraint pending_host_key_must_not_be_empty
+    check(length(pending_host_key) > 0),
+  pending_fingerprint text
+    constraint pending_fingerprint_must_not_be_empty
+    check(length(trim(pending_fingerprint)) > 0),
+  constraint pending_host_key_and_fingerprint_set_together
+    check((pending_host_key is null) = (pending_fingerprint is null))
+);
+
+create trigger
+  immutable_columns
+before
+update on static_host_ssh_host_key
+  for each row execute procedure immutable_columns('host_id', 'create_time');
+
+create trigger
+  update_time_column
+before update on static_host_ssh_host_key
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on static_host_ssh_host_key
+  for each row execute procedure default_create_time();
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('static_host_ssh_host_key', 1);
+
+commit;
@@ -0,0 +1,136 @@
+begin;
+
+-- iam_role_grant_scope holds the scopes a role's grants apply to. A role
+-- without any rows here keeps using its grant_scope_id. Besides scope IDs the
+-- special values 'this' (the role's own scope), 'children' (the direct child
+-- scopes of the role's scope) and 'descendants' (every scope below global) are
+-- allowed; the special values are resolved whenever grants are looked up, so
+-- scopes created later are covered as well.
+create table iam_role_grant_scope (
+  create_time wt_timestamp,
+  role_id wt_role_id -- pk
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  scope_id_or_special text -- pk
+    constraint scope_id_or_special_must_not_be_empty
+    check(
+      length(trim(scope_id_or_special)) > 0
+    ),
+  primary key(role_id, scope_id_or_special)
+);
+
+-- iam_role_grant_scope_valid ensures a grant scope can be used by the role:
+-- 'descendants' only by global roles, 'children' by global and org roles, and
+-- a scope ID only if it's the role's own scope or a scope below it. The role's
+-- own scope ID is stored as 'this'.
+create or replace function
+  iam_role_grant_scope_valid()
+  returns trigger
+as $$
+declare role_scope_id text;
+declare role_scope_type text;
+declare grant_scope_parent_id text;
+begin
+  select r.scope_id, s.type
+    from iam_role r
+    join iam_scope s
+      on s.public_id = r.scope_id
+   where r.public_id = new.role_id
+    into role_scope_id, role_scope_type;
+  if new.scope_id_or_special = role_scope_id then
+    new.scope_id_or_special = 'this';
+  end if;
+  case new.scope_id_or_special
+  when 'this' then
+    return new;
+  when 'children' then
+    if role_scope_type = 'project' then
+      raise exception 'children grant scope is invalid for a project role';
+    end if;
+    perform 1 from iam_role_grant_scope
+     where role_id = new.role_id
+       and scope_id_or_special = 'descendants';
+    if found then
+      raise exception 'children grant scope conflicts with descendants';
+    end if;
+    return new;
+  when 'descendants' then
+    if role_scope_type != 'global' then
+      raise exception 'descendants grant scope is only valid for a global role';
+    end if;
+    perform 1 from iam_role_grant_scope
+     where role_id = new.role_id
+       and scope_id_or_special = 'children';
+    if found then
+      raise exception 'descendants grant scope conflicts with children';
+    end if;
+    return new;
+  else
+    select parent_id
+      from iam_scope
+     where public_id = new.scope_id_or_special
+      into grant_scope_parent_id;
+    if not found then
+      raise exception 'grant scope % does not exist', new.scope_id_or_special;
+    end if;
+    if role_scope_type = 'global' then
+      return new;
+    end if;
+    if role_scope_type = 'org' and grant_scope_parent_id = role_scope_id then
+      return new;
+    end if;
+    raise exception 'grant scope % is not below the role scope', new.scope_id_or_special;
+  end case;
+end;
+$$ language plpgsql;
+
+create trigger
+  ensure_grant_scope_valid
+before
+insert on iam_role_grant_scope
+  for each row execute procedure iam_role_grant_scope_valid();
+
+-- iam_immutable_role_grant_scope() ensures that grant scopes assigned to roles
+-- are immutable.
+create or replace function
+  iam_immutable_role_grant_scope()
+  returns trigger
+as $$
+begin
+  raise exception 'role grant scopes are immutable';
+end;
+$$ language plpgsql;
+
+create trigger
+  immutable_role_grant_scope
+before
+update on iam_role_grant_scope
+  for each row execute procedure iam_immutable_role_grant_scope();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_grant_scope
+  for each row execute procedure default_create_time();
+
+-- iam_role_grant_scope_scope_deleted removes the grant scopes naming a scope
+-- when that scope is deleted.
+create or replace function
+  iam_role_grant_scope_scope_deleted()
+  returns trigger
+as $$
+begin
+  delete from iam_role_grant_scope
+   where scope_id_or_special = old.public_id;
+  return old;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_role_grant_scope_scope_deleted
+after
+delete on iam_scope
+  for each row execute procedure iam_role_grant_scope_scope_deleted();
+
+commit;
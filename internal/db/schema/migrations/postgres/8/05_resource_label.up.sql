@@ -0,0 +1,107 @@
+begin;
+
+-- resource_label holds the key/value labels on scopes, targets, host catalogs,
+-- host sets and hosts, which grants can match on. A resource has at most one
+-- value per key. Keys and values can't contain the characters used to
+-- separate them in grant strings.
+create table resource_label (
+  create_time wt_timestamp,
+  resource_id wt_public_id not null, -- pk
+  key text not null -- pk
+    constraint key_must_be_valid
+    check(
+      key ~ '^[a-zA-Z0-9_./-]+$'
+    ),
+  value text not null
+    constraint value_must_be_valid
+    check(
+      value ~ '^[a-zA-Z0-9_./-]+$'
+    ),
+  primary key(resource_id, key)
+);
+
+-- resource_label_resource_exists ensures labels are only added to a resource
+-- that exists and can have labels.
+create or replace function
+  resource_label_resource_exists()
+  returns trigger
+as $$
+begin
+  perform 1 from iam_scope where public_id = new.resource_id
+   union all
+  select 1 from target where public_id = new.resource_id
+   union all
+  select 1 from host_catalog where public_id = new.resource_id
+   union all
+  select 1 from host_set where public_id = new.resource_id
+   union all
+  select 1 from host where public_id = new.resource_id;
+  if not found then
+    raise exception 'resource % does not exist or cannot have labels', new.resource_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  ensure_resource_label_resource_exists
+before
+insert on resource_label
+  for each row execute procedure resource_label_resource_exists();
+
+create trigger
+  immutable_columns
+before
+update on resource_label
+  for each row execute procedure immutable_columns('resource_id', 'key', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on resource_label
+  for each row execute procedure default_create_time();
+
+-- resource_label_resource_deleted removes the labels of a resource when it's
+-- deleted.
+create or replace function
+  resource_label_resource_deleted()
+  returns trigger
+as $$
+begin
+  delete from resource_label
+   where resource_id = old.public_id;
+  return old;
+end;
+$$ language plpgsql;
+
+create trigger
+  resource_label_resource_deleted
+after
+delete on iam_scope
+  for each row execute procedure resource_label_resource_deleted();
+
+create trigger
+  resource_label_resource_deleted
+after
+delete on target
+  for each row execute procedure resource_label_resource_deleted();
+
+create trigger
+  resource_label_resource_deleted
+after
+delete on host_catalog
+  for each row execute procedure resource_label_resource_deleted();
+
+create trigger
+  resource_label_resource_deleted
+after
+delete on host_set
+  for each row execute procedure resource_label_resource_deleted();
+
+create trigger
+  resource_label_resource_deleted
+after
+delete on host
+  for each row execute procedure resource_label_resource_deleted();
+
+commit;
@@ -0,0 +1,106 @@
+begin;
+
+-- iam_group_member_group is an association table that represents groups with
+-- associated child groups. The members of a child group are members of every
+-- group it's a member of, transitively.
+create table iam_group_member_group (
+  create_time wt_timestamp,
+  group_id wt_public_id -- pk
+    references iam_group(public_id)
+    on delete cascade
+    on update cascade,
+  member_id wt_public_id -- pk
+    references iam_group(public_id)
+    on delete cascade
+    on update cascade,
+  primary key (group_id, member_id),
+  constraint group_cannot_be_member_of_itself
+    check(
+      group_id != member_id
+    )
+);
+
+-- iam_group_member_group_no_cycle ensures a group is never, transitively, a
+-- member of itself.
+create or replace function
+  iam_group_member_group_no_cycle()
+  returns trigger
+as $$
+begin
+  if exists (
+    with recursive
+    nested_groups (group_id) as (
+      select new.member_id
+       union
+      select iam_group_member_group.member_id
+        from iam_group_member_group
+       inner
+        join nested_groups
+          on iam_group_member_group.group_id = nested_groups.group_id
+    )
+    select 1
+      from nested_groups
+     where group_id = new.group_id
+  ) then
+    raise exception 'group % cannot be a member of group %: it would create a cycle', new.member_id, new.group_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  ensure_no_group_member_cycle
+before
+insert on iam_group_member_group
+  for each row execute procedure iam_group_member_group_no_cycle();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_group_member_group
+  for each row execute procedure default_create_time();
+
+create trigger
+  iam_immutable_group_member
+before
+update on iam_group_member_group
+  for each row execute procedure iam_immutable_group_member();
+
+-- iam_group_member is recreated to include child groups, with a type of
+-- 'group'.
+drop view iam_group_member;
+
+create view iam_group_member as
+select
+  gm.create_time,
+  gm.group_id,
+  gm.member_id,
+  u.scope_id as member_scope_id,
+  g.scope_id as group_scope_id,
+  get_scoped_member_id(g.scope_id, u.scope_id, gm.member_id) as scoped_member_id,
+  'user' as type
+from
+  iam_group_member_user gm,
+  iam_user u,
+  iam_group g
+where
+  gm.member_id = u.public_id and
+  gm.group_id = g.public_id
+union
+select
+  gm.create_time,
+  gm.group_id,
+  gm.member_id,
+  mg.scope_id as member_scope_id,
+  g.scope_id as group_scope_id,
+  get_scoped_member_id(g.scope_id, mg.scope_id, gm.member_id) as scoped_member_id,
+  'group' as type
+from
+  iam_group_member_group gm,
+  iam_group mg,
+  iam_group g
+where
+  gm.member_id = mg.public_id and
+  gm.group_id = g.public_id;
+
+commit;
@@ -0,0 +1,228 @@
+begin;
+
+-- iam_role_template is a named set of grants and principals defined in the
+-- global scope or an org. A role is instantiated from the template in every
+-- project below its scope, and the instantiated roles are kept in sync with
+-- the template.
+create table iam_role_template (
+  public_id wt_public_id primary key,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  name text,
+  description text,
+  scope_id wt_scope_id
+    not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  version wt_version,
+  unique(name, scope_id)
+);
+
+-- role_template_scope_id_valid ensures role templates are only created in the
+-- global scope or an org, the scopes that have projects below them.
+create or replace function
+  role_template_scope_id_valid()
+  returns trigger
+as $$
+begin
+  perform from iam_scope where public_id = new.scope_id and type in ('global', 'org');
+  if not found then
+    raise exception 'invalid scope type for role template creation';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  ensure_role_template_scope_id_valid
+before
+insert on iam_role_template
+  for each row execute procedure role_template_scope_id_valid();
+
+create trigger
+  update_version_column
+after update on iam_role_template
+  for each row execute procedure update_version_column();
+
+create trigger
+  update_time_column
+before update on iam_role_template
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_template
+  for each row execute procedure default_create_time();
+
+create trigger
+  a_immutable_columns
+before
+update on iam_role_template
+  for each row execute procedure immutable_columns('public_id', 'create_time', 'scope_id');
+
+-- iam_role_template_grant holds the grants of a role template. The grants may
+-- reference the parameters {{scope.id}} and {{scope.parent_id}}, which are
+-- replaced with the project's values in the instantiated roles.
+create table iam_role_template_grant (
+  create_time wt_timestamp,
+  role_template_id wt_public_id -- pk
+    references iam_role_template(public_id)
+    on delete cascade
+    on update cascade,
+  canonical_grant text -- pk
+    constraint canonical_grant_must_not_be_empty
+    check(
+      length(trim(canonical_grant)) > 0
+    ),
+  raw_grant text not null
+    constraint raw_grant_must_not_be_empty
+    check(
+      length(trim(raw_grant)) > 0
+    ),
+  primary key(role_template_id, canonical_grant)
+);
+
+-- iam_role_template_user_principal and iam_role_template_group_principal hold
+-- the users and groups assigned to the roles instantiated from a role
+-- template.
+create table iam_role_template_user_principal (
+  create_time wt_timestamp,
+  role_template_id wt_public_id
+    references iam_role_template(public_id)
+    on delete cascade
+    on update cascade,
+  principal_id wt_user_id
+    references iam_user(public_id)
+    on delete cascade
+    on update cascade,
+  primary key (role_template_id, principal_id)
+);
+
+create table iam_role_template_group_principal (
+  create_time wt_timestamp,
+  role_template_id wt_public_id
+    references iam_role_template(public_id)
+    on delete cascade
+    on update cascade,
+  principal_id wt_public_id
+    references iam_group(public_id)
+    on delete cascade
+    on update cascade,
+  primary key (role_template_id, principal_id)
+);
+
+-- iam_role_template_principal provides a consolidated view of the users and
+-- groups assigned to role templates.
+create view iam_role_template_principal as
+select
+  tu.create_time,
+  tu.role_template_id,
+  tu.principal_id,
+  u.scope_id as principal_scope_id,
+  'user' as type
+from
+  iam_role_template_user_principal tu,
+  iam_user u
+where
+  tu.principal_id = u.public_id
+union
+select
+  tg.create_time,
+  tg.role_template_id,
+  tg.principal_id,
+  g.scope_id as principal_scope_id,
+  'group' as type
+from
+  iam_role_template_group_principal tg,
+  iam_group g
+where
+  tg.principal_id = g.public_id;
+
+-- iam_role_template_role records the roles instantiated from a role template.
+-- A role is instantiated from at most one template.
+create table iam_role_template_role (
+  create_time wt_timestamp,
+  role_template_id wt_public_id
+    references iam_role_template(public_id)
+    on delete cascade
+    on update cascade,
+  role_id wt_role_id
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  primary key (role_template_id, role_id),
+  unique(role_id)
+);
+
+-- iam_immutable_role_template_association() ensures the grants, principals
+-- and roles of role templates are immutable.
+create or replace function
+  iam_immutable_role_template_association()
+  returns trigger
+as $$
+begin
+  raise exception 'role template associations are immutable';
+end;
+$$ language plpgsql;
+
+create trigger
+  immutable_role_template_grant
+before
+update on iam_role_template_grant
+  for each row execute procedure iam_immutable_role_template_association();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_template_grant
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_role_template_user_principal
+before
+update on iam_role_template_user_principal
+  for each row execute procedure iam_immutable_role_template_association();
+
+create trigger
+  recovery_user_not_allowed_role_template_user_principal
+before
+insert on iam_role_template_user_principal
+  for each row execute procedure recovery_user_not_allowed('principal_id');
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_template_user_principal
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_role_template_group_principal
+before
+update on iam_role_template_group_principal
+  for each row execute procedure iam_immutable_role_template_association();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_template_group_principal
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_role_template_role
+before
+update on iam_role_template_role
+  for each row execute procedure iam_immutable_role_template_association();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_template_role
+  for each row execute procedure default_create_time();
+
+insert into oplog_ticket (name, version)
+values
+  ('iam_role_template', 1);
+
+commit;
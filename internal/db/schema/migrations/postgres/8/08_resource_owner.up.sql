@@ -0,0 +1,103 @@
+begin;
+
+-- resource_owner holds the user owning a target, host set, host or group,
+-- which grants can match on. The owner is the user that created the resource
+-- until ownership is transferred. When the owning user is deleted the
+-- resource is left without an owner.
+create table resource_owner (
+  resource_id wt_public_id primary key,
+  owner_id wt_user_id not null
+    references iam_user(public_id)
+    on delete cascade
+    on update cascade,
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+-- resource_owner_resource_exists ensures an owner is only recorded for a
+-- resource that exists and can be owned.
+create or replace function
+  resource_owner_resource_exists()
+  returns trigger
+as $$
+begin
+  perform 1 from target where public_id = new.resource_id
+   union all
+  select 1 from host_set where public_id = new.resource_id
+   union all
+  select 1 from host where public_id = new.resource_id
+   union all
+  select 1 from iam_group where public_id = new.resource_id;
+  if not found then
+    raise exception 'resource % does not exist or cannot be owned', new.resource_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  ensure_resource_owner_resource_exists
+before
+insert on resource_owner
+  for each row execute procedure resource_owner_resource_exists();
+
+create trigger
+  immutable_columns
+before
+update on resource_owner
+  for each row execute procedure immutable_columns('resource_id', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on resource_owner
+  for each row execute procedure default_create_time();
+
+create trigger
+  update_time_column
+before
+update on resource_owner
+  for each row execute procedure update_time_column();
+
+-- resource_owner_resource_deleted removes the owner of a resource when it's
+-- deleted.
+create or replace function
+  resource_owner_resource_deleted()
+  returns trigger
+as $$
+begin
+  delete from resource_owner
+   where resource_id = old.public_id;
+  return old;
+end;
+$$ language plpgsql;
+
+create trigger
+  resource_owner_resource_deleted
+after
+delete on target
+  for each row execute procedure resource_owner_resource_deleted();
+
+create trigger
+  resource_owner_resource_deleted
+after
+delete on host_set
+  for each row execute procedure resource_owner_resource_deleted();
+
+create trigger
+  resource_owner_resource_deleted
+after
+delete on host
+  for each row execute procedure resource_owner_resource_deleted();
+
+create trigger
+  resource_owner_resource_deleted
+after
+delete on iam_group
+  for each row execute procedure resource_owner_resource_deleted();
+
+insert into oplog_ticket (name, version)
+values
+  ('resource_owner', 1);
+
+commit;
@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/lib/pq"
+)
+
+// verifySchema is the name of the schema the embedded migrations are
+// replayed into by MigratedCatalog.  It only exists within a transaction
+// which is always rolled back.
+const verifySchema = "boundary_schema_verify"
+
+// catalogExcludedTables are tables which are created by the driver rather
+// than by migrations, so they're not part of a catalog.
+var catalogExcludedTables = map[string]bool{
+	defaultMigrationsTable: true,
+	"schema_migrations":    true,
+}
+
+// catalogQueries return the objects in the schema named by $1.  Each row is a
+// kind, the table the object belongs to (or "" for objects which don't belong
+// to a table) and a description of the object.  Objects which belong to an
+// extension, e.g. pgcrypto's functions, are excluded.
+var catalogQueries = []string{
+	// tables, views and sequences
+	`select case c.relkind when 'v' then 'view' when 'm' then 'materialized view' when 'S' then 'sequence' else 'table' end,
+	        c.relname, c.relname
+	   from pg_class c
+	   join pg_namespace n on n.oid = c.relnamespace
+	  where n.nspname = $1 and c.relkind in ('r', 'p', 'v', 'm', 'S')`,
+	// columns
+	`select 'column', c.relname,
+	        c.relname || '.' || a.attname || ' ' || format_type(a.atttypid, a.atttypmod) || case when a.attnotnull then ' not null' else '' end
+	   from pg_attribute a
+	   join pg_class c on c.oid = a.attrelid
+	   join pg_namespace n on n.oid = c.relnamespace
+	  where n.nspname = $1 and c.relkind in ('r', 'p', 'v', 'm') and a.attnum > 0 and not a.attisdropped`,
+	// table constraints
+	`select 'constraint', c.relname, c.relname || '.' || con.conname || ' (' || con.contype || ')'
+	   from pg_constraint con
+	   join pg_class c on c.oid = con.conrelid
+	   join pg_namespace n on n.oid = c.relnamespace
+	  where n.nspname = $1`,
+	// indexes
+	`select 'index', c.relname, c.relname || '.' || i.relname
+	   from pg_index x
+	   join pg_class i on i.oid = x.indexrelid
+	   join pg_class c on c.oid = x.indrelid
+	   join pg_namespace n on n.oid = c.relnamespace
+	  where n.nspname = $1`,
+	// triggers
+	`select 'trigger', c.relname, c.relname || '.' || t.tgname
+	   from pg_trigger t
+	   join pg_class c on c.oid = t.tgrelid
+	   join pg_namespace n on n.oid = c.relnamespace
+	  where n.nspname = $1 and not t.tgisinternal`,
+	// functions
+	`select 'function', '', p.proname || '(' || pg_get_function_identity_arguments(p.oid) || ')'
+	   from pg_proc p
+	   join pg_namespace n on n.oid = p.pronamespace
+	  where n.nspname = $1
+	    and not exists (select 1 from pg_depend d where d.objid = p.oid and d.deptype = 'e')`,
+	// domains
+	`select 'domain', '', t.typname
+	   from pg_type t
+	   join pg_namespace n on n.oid = t.typnamespace
+	  where n.nspname = $1 and t.typtype = 'd'
+	    and not exists (select 1 from pg_depend d where d.objid = t.oid and d.deptype = 'e')`,
+}
+
+type queryContexter interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Catalog returns a sorted description of the tables, views, sequences,
+// columns, constraints, indexes, triggers, functions and domains in the
+// current schema.  Each entry is prefixed by its kind, e.g.
+// "trigger iam_scope.immutable_columns".
+func (p *Postgres) Catalog(ctx context.Context) ([]string, error) {
+	const op = "postgres.(Postgres).Catalog"
+	var schemaName string
+	if err := p.conn.QueryRowContext(ctx, "select current_schema()").Scan(&schemaName); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	c, err := catalog(ctx, p.conn, schemaName)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return c, nil
+}
+
+// MigratedCatalog returns the Catalog of a schema created by running the
+// migrations, in order.  The migrations are run in a temporary schema within
+// a transaction which is rolled back, so the database is not modified.
+func (p *Postgres) MigratedCatalog(ctx context.Context, migrations [][]byte) (c []string, retErr error) {
+	const op = "postgres.(Postgres).MigratedCatalog"
+	var current string
+	if err := p.conn.QueryRowContext(ctx, "select current_schema()").Scan(&current); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	tx, err := p.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			retErr = errors.Wrap(multierror.Append(retErr, err), op)
+		}
+	}()
+
+	// Objects are created in the first schema in the search path, and the
+	// current schema stays in the path so migrations can still find the
+	// version table and extensions.
+	setup := fmt.Sprintf("create schema %[1]s; set local search_path to %[1]s, %[2]s",
+		pq.QuoteIdentifier(verifySchema), pq.QuoteIdentifier(current))
+	if _, err := tx.ExecContext(ctx, setup); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to create verification schema"))
+	}
+	for i, m := range migrations {
+		if _, err := tx.ExecContext(ctx, string(m)); err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to run migration %d of %d", i+1, len(migrations))))
+		}
+	}
+	if c, err = catalog(ctx, tx, verifySchema); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return c, nil
+}
+
+func catalog(ctx context.Context, q queryContexter, schemaName string) ([]string, error) {
+	const op = "postgres.catalog"
+	var c []string
+	for _, query := range catalogQueries {
+		rows, err := q.QueryContext(ctx, query, schemaName)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		for rows.Next() {
+			var kind, table, desc string
+			if err := rows.Scan(&kind, &table, &desc); err != nil {
+				rows.Close()
+				return nil, errors.Wrap(err, op)
+			}
+			if catalogExcludedTables[table] {
+				continue
+			}
+			c = append(c, kind+" "+desc)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, op)
+		}
+		rows.Close()
+	}
+	sort.Strings(c)
+	return c, nil
+}
@@ -4,7 +4,7 @@ package schema
 
 func init() {
 	migrationStates["postgres"] = migrationState{
-		binarySchemaVersion: 7003,
+		binarySchemaVersion: 8008,
 		upMigrations: map[int][]byte{
 			1: []byte(`
 create domain wt_public_id as text
@@ -6346,6 +6346,1026 @@ create table job (
 		       )
 	  )
 	  select job_plugin_id, job_name, next_scheduled_run from final;
+`),
+			8001: []byte(`
+-- auth_oidc_provider_token entries are the tokens returned by an oidc provider
+-- for a successful authentication.  There is at most one for each auth token
+-- issued by an oidc auth method and it's deleted along with its auth token.
+-- The tokens are used to periodically re-validate the user with the provider
+-- and the provider_session_id is used to find the auth tokens to revoke when
+-- the provider sends a back-channel logout request.
+create table auth_oidc_provider_token (
+  auth_token_id wt_public_id primary key
+    constraint auth_token_fkey
+      references auth_token(public_id)
+      on delete cascade
+      on update cascade,
+  oidc_method_id wt_public_id not null
+    constraint auth_oidc_method_fkey
+      references auth_oidc_method(public_id)
+      on delete cascade
+      on update cascade,
+  account_id wt_public_id not null,
+  provider_session_id text -- maps to an id_token's optional sid claim
+    constraint provider_session_id_must_not_be_empty
+      check(length(trim(provider_session_id)) > 0),
+  refresh_token bytea not null -- encrypted refresh_token, which may be an encrypted empty value.
+    constraint refresh_token_must_not_be_empty
+      check(length(refresh_token) > 0),
+  access_token bytea not null -- encrypted access_token, which may be an encrypted empty value.
+    constraint access_token_must_not_be_empty
+      check(length(access_token) > 0),
+  access_token_expiration_time timestamp with time zone,
+  last_validation_time wt_timestamp,
+  key_id wt_private_id not null -- key used to encrypt entries via wrapping wrapper.
+    constraint kms_database_key_version_fkey
+      references kms_database_key_version(private_id)
+      on delete restrict
+      on update cascade,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  constraint auth_oidc_account_fkey
+    foreign key (oidc_method_id, account_id)
+      references auth_oidc_account (auth_method_id, public_id)
+      on delete cascade
+      on update cascade
+);
+comment on table auth_oidc_provider_token is
+'auth_oidc_provider_token entries are the tokens returned by an oidc provider for a successful authentication.  There is at most one for each auth token issued by an oidc auth method.';
+
+create index auth_oidc_provider_token_account_id_ix
+  on auth_oidc_provider_token (account_id);
+
+create index auth_oidc_provider_token_provider_session_id_ix
+  on auth_oidc_provider_token (oidc_method_id, provider_session_id);
+
+create trigger
+  update_time_column
+before
+update on auth_oidc_provider_token
+  for each row execute procedure update_time_column();
+
+create trigger
+  immutable_columns
+before
+update on auth_oidc_provider_token
+  for each row execute procedure immutable_columns('auth_token_id', 'oidc_method_id', 'account_id', 'provider_session_id', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on auth_oidc_provider_token
+  for each row execute procedure default_create_time();
+`),
+			8002: []byte(`
+create table target_udp (
+  public_id wt_public_id primary key
+    references target(public_id)
+    on delete cascade
+    on update cascade,
+  scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  name text not null, -- name is not optional for a target subtype
+  description text,
+  default_port int, -- default_port can be null
+   -- max duration of the session in seconds.
+   -- default is 8 hours
+  session_max_seconds int not null default 28800
+    constraint session_max_seconds_must_be_greater_than_0
+    check(session_max_seconds > 0),
+  -- limit on number of session connections allowed. -1 equals no limit
+  session_connection_limit int not null default 1
+    constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+    check(session_connection_limit > 0 or session_connection_limit = -1),
+  worker_filter wt_bexprfilter,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  unique(scope_id, name) -- name must be unique within a scope
+);
+
+create trigger
+  insert_target_subtype
+before insert on target_udp
+  for each row execute procedure insert_target_subtype();
+
+create trigger
+  delete_target_subtype
+after delete on target_udp
+  for each row execute procedure delete_target_subtype();
+
+create trigger
+  immutable_columns
+before
+update on target_udp
+  for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+create trigger
+  update_version_column
+after update on target_udp
+  for each row execute procedure update_version_column();
+
+create trigger
+  update_time_column
+before update on target_udp
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on target_udp
+  for each row execute procedure default_create_time();
+
+create trigger
+  target_scope_valid
+before insert on target_udp
+  for each row execute procedure target_scope_valid();
+
+-- Replaces the view created in 1/01 to include udp targets
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  version,
+  create_time,
+  update_time,
+  worker_filter,
+  'tcp' as type
+from target_tcp
+union all
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  version,
+  create_time,
+  update_time,
+  worker_filter,
+  'udp' as type
+from target_udp;
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('target_udp', 1);
+
+-- Connections to udp targets count the datagrams proxied in each direction as
+-- well as their bytes.
+alter table session_connection
+  add column datagrams_up bigint -- can be null
+    constraint datagrams_up_must_be_null_or_a_non_negative_number
+    check (
+      datagrams_up is null
+      or
+      datagrams_up >= 0
+    ),
+  add column datagrams_down bigint -- can be null
+    constraint datagrams_down_must_be_null_or_a_non_negative_number
+    check (
+      datagrams_down is null
+      or
+      datagrams_down >= 0
+    );
+
+alter table wh_session_connection_accumulating_fact
+  add column datagrams_up bigint -- can be null
+    constraint datagrams_up_must_be_null_or_a_non_negative_number
+    check (
+      datagrams_up is null
+      or
+      datagrams_up >= 0
+    ),
+  add column datagrams_down bigint -- can be null
+    constraint datagrams_down_must_be_null_or_a_non_negative_number
+    check (
+      datagrams_down is null
+      or
+      datagrams_down >= 0
+    );
+
+comment on column wh_session_connection_accumulating_fact.datagrams_up is
+  'Datagrams Up is the total number of datagrams received by the worker from '
+  'the client and sent to the endpoint for a connection to a udp target. '
+  'Datagrams Up is a fully additive measurement.';
+comment on column wh_session_connection_accumulating_fact.datagrams_down is
+  'Datagrams Down is the total number of datagrams received by the worker from '
+  'the endpoint and sent to the client for a connection to a udp target. '
+  'Datagrams Down is a fully additive measurement.';
+
+-- Replaces the function created in 0/69 to include datagrams
+create or replace function wh_update_session_connection()
+  returns trigger
+as $$
+declare
+  updated_row wh_session_connection_accumulating_fact%rowtype;
+begin
+      update wh_session_connection_accumulating_fact
+         set client_tcp_address       = new.client_tcp_address,
+             client_tcp_port_number   = new.client_tcp_port,
+             endpoint_tcp_address     = new.endpoint_tcp_address,
+             endpoint_tcp_port_number = new.endpoint_tcp_port,
+             bytes_up                 = new.bytes_up,
+             bytes_down               = new.bytes_down,
+             datagrams_up             = new.datagrams_up,
+             datagrams_down           = new.datagrams_down
+       where connection_id = new.public_id
+   returning * into strict updated_row;
+  perform wh_rollup_connections(new.session_id);
+  return null;
+end;
+$$ language plpgsql;
+
+-- Replaces the view created in 0/65 to include udp targets
+create or replace view whx_host_dimension_source as
+with target_all as (
+  select public_id, scope_id, name, description, default_port,
+         session_max_seconds, session_connection_limit,
+         'tcp target' as target_type
+    from target_tcp
+  union all
+  select public_id, scope_id, name, description, default_port,
+         session_max_seconds, session_connection_limit,
+         'udp target' as target_type
+    from target_udp
+)
+select -- id is the first column in the target view
+       h.public_id                     as host_id,
+       'static host'                   as host_type,
+       coalesce(h.name, 'None')        as host_name,
+       coalesce(h.description, 'None') as host_description,
+       coalesce(h.address, 'Unknown')  as host_address,
+       s.public_id                     as host_set_id,
+       'static host set'               as host_set_type,
+       coalesce(s.name, 'None')        as host_set_name,
+       coalesce(s.description, 'None') as host_set_description,
+       c.public_id                     as host_catalog_id,
+       'static host catalog'           as host_catalog_type,
+       coalesce(c.name, 'None')        as host_catalog_name,
+       coalesce(c.description, 'None') as host_catalog_description,
+       t.public_id                     as target_id,
+       t.target_type                   as target_type,
+       coalesce(t.name, 'None')        as target_name,
+       coalesce(t.description, 'None') as target_description,
+       coalesce(t.default_port, 0)     as target_default_port_number,
+       t.session_max_seconds           as target_session_max_seconds,
+       t.session_connection_limit      as target_session_connection_limit,
+       p.public_id                     as project_id,
+       coalesce(p.name, 'None')        as project_name,
+       coalesce(p.description, 'None') as project_description,
+       o.public_id                     as host_organization_id,
+       coalesce(o.name, 'None')        as host_organization_name,
+       coalesce(o.description, 'None') as host_organization_description
+  from static_host as h,
+       static_host_catalog as c,
+       static_host_set_member as m,
+       static_host_set as s,
+       target_host_set as ts,
+       target_all as t,
+       iam_scope as p,
+       iam_scope as o
+ where h.catalog_id = c.public_id
+   and h.public_id = m.host_id
+   and s.public_id = m.set_id
+   and t.public_id = ts.target_id
+   and s.public_id = ts.host_set_id
+   and p.public_id = t.scope_id
+   and p.type = 'project'
+   and o.public_id = p.parent_id
+   and o.type = 'org'
+;
+`),
+			8003: []byte(`
+-- static_host_ssh_host_key holds the SSH host key pinned for a static host.
+-- The key is pinned the first time a worker reports the key the host
+-- presents. A different key reported later is recorded as pending until it
+-- is trusted, which replaces the pinned key.
+create table static_host_ssh_host_key (
+  host_id wt_public_id primary key
+    references static_host (public_id)
+    on delete cascade
+    on update cascade,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  host_key bytea not null
+    constraint host_key_must_not_be_empty
+    check(length(host_key) > 0),
+  fingerprint text not null
+    constraint fingerprint_must_not_be_empty
+    check(length(trim(fingerprint)) > 0),
+  pending_host_key bytea
+    constraint pending_host_key_must_not_be_empty
+    check(length(pending_host_key) > 0),
+  pending_fingerprint text
+    constraint pending_fingerprint_must_not_be_empty
+    check(length(trim(pending_fingerprint)) > 0),
+  constraint pending_host_key_and_fingerprint_set_together
+    check((pending_host_key is null) = (pending_fingerprint is null))
+);
+
+create trigger
+  immutable_columns
+before
+update on static_host_ssh_host_key
+  for each row execute procedure immutable_columns('host_id', 'create_time');
+
+create trigger
+  update_time_column
+before update on static_host_ssh_host_key
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on static_host_ssh_host_key
+  for each row execute procedure default_create_time();
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('static_host_ssh_host_key', 1);
+`),
+			8004: []byte(`
+-- iam_role_grant_scope holds the scopes a role's grants apply to. A role
+-- without any rows here keeps using its grant_scope_id. Besides scope IDs the
+-- special values 'this' (the role's own scope), 'children' (the direct child
+-- scopes of the role's scope) and 'descendants' (every scope below global) are
+-- allowed; the special values are resolved whenever grants are looked up, so
+-- scopes created later are covered as well.
+create table iam_role_grant_scope (
+  create_time wt_timestamp,
+  role_id wt_role_id -- pk
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  scope_id_or_special text -- pk
+    constraint scope_id_or_special_must_not_be_empty
+    check(
+      length(trim(scope_id_or_special)) > 0
+    ),
+  primary key(role_id, scope_id_or_special)
+);
+
+-- iam_role_grant_scope_valid ensures a grant scope can be used by the role:
+-- 'descendants' only by global roles, 'children' by global and org roles, and
+-- a scope ID only if it's the role's own scope or a scope below it. The role's
+-- own scope ID is stored as 'this'.
+create or replace function
+  iam_role_grant_scope_valid()
+  returns trigger
+as $$
+declare role_scope_id text;
+declare role_scope_type text;
+declare grant_scope_parent_id text;
+begin
+  select r.scope_id, s.type
+    from iam_role r
+    join iam_scope s
+      on s.public_id = r.scope_id
+   where r.public_id = new.role_id
+    into role_scope_id, role_scope_type;
+  if new.scope_id_or_special = role_scope_id then
+    new.scope_id_or_special = 'this';
+  end if;
+  case new.scope_id_or_special
+  when 'this' then
+    return new;
+  when 'children' then
+    if role_scope_type = 'project' then
+      raise exception 'children grant scope is invalid for a project role';
+    end if;
+    perform 1 from iam_role_grant_scope
+     where role_id = new.role_id
+       and scope_id_or_special = 'descendants';
+    if found then
+      raise exception 'children grant scope conflicts with descendants';
+    end if;
+    return new;
+  when 'descendants' then
+    if role_scope_type != 'global' then
+      raise exception 'descendants grant scope is only valid for a global role';
+    end if;
+    perform 1 from iam_role_grant_scope
+     where role_id = new.role_id
+       and scope_id_or_special = 'children';
+    if found then
+      raise exception 'descendants grant scope conflicts with children';
+    end if;
+    return new;
+  else
+    select parent_id
+      from iam_scope
+     where public_id = new.scope_id_or_special
+      into grant_scope_parent_id;
+    if not found then
+      raise exception 'grant scope % does not exist', new.scope_id_or_special;
+    end if;
+    if role_scope_type = 'global' then
+      return new;
+    end if;
+    if role_scope_type = 'org' and grant_scope_parent_id = role_scope_id then
+      return new;
+    end if;
+    raise exception 'grant scope % is not below the role scope', new.scope_id_or_special;
+  end case;
+end;
+$$ language plpgsql;
+
+create trigger
+  ensure_grant_scope_valid
+before
+insert on iam_role_grant_scope
+  for each row execute procedure iam_role_grant_scope_valid();
+
+-- iam_immutable_role_grant_scope() ensures that grant scopes assigned to roles
+-- are immutable.
+create or replace function
+  iam_immutable_role_grant_scope()
+  returns trigger
+as $$
+begin
+  raise exception 'role grant scopes are immutable';
+end;
+$$ language plpgsql;
+
+create trigger
+  immutable_role_grant_scope
+before
+update on iam_role_grant_scope
+  for each row execute procedure iam_immutable_role_grant_scope();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_grant_scope
+  for each row execute procedure default_create_time();
+
+-- iam_role_grant_scope_scope_deleted removes the grant scopes naming a scope
+-- when that scope is deleted.
+create or replace function
+  iam_role_grant_scope_scope_deleted()
+  returns trigger
+as $$
+begin
+  delete from iam_role_grant_scope
+   where scope_id_or_special = old.public_id;
+  return old;
+end;
+$$ language plpgsql;
+
+create trigger
+  iam_role_grant_scope_scope_deleted
+after
+delete on iam_scope
+  for each row execute procedure iam_role_grant_scope_scope_deleted();
+`),
+			8005: []byte(`
+-- resource_label holds the key/value labels on scopes, targets, host catalogs,
+-- host sets and hosts, which grants can match on. A resource has at most one
+-- value per key. Keys and values can't contain the characters used to
+-- separate them in grant strings.
+create table resource_label (
+  create_time wt_timestamp,
+  resource_id wt_public_id not null, -- pk
+  key text not null -- pk
+    constraint key_must_be_valid
+    check(
+      key ~ '^[a-zA-Z0-9_./-]+$'
+    ),
+  value text not null
+    constraint value_must_be_valid
+    check(
+      value ~ '^[a-zA-Z0-9_./-]+$'
+    ),
+  primary key(resource_id, key)
+);
+
+-- resource_label_resource_exists ensures labels are only added to a resource
+-- that exists and can have labels.
+create or replace function
+  resource_label_resource_exists()
+  returns trigger
+as $$
+begin
+  perform 1 from iam_scope where public_id = new.resource_id
+   union all
+  select 1 from target where public_id = new.resource_id
+   union all
+  select 1 from host_catalog where public_id = new.resource_id
+   union all
+  select 1 from host_set where public_id = new.resource_id
+   union all
+  select 1 from host where public_id = new.resource_id;
+  if not found then
+    raise exception 'resource % does not exist or cannot have labels', new.resource_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  ensure_resource_label_resource_exists
+before
+insert on resource_label
+  for each row execute procedure resource_label_resource_exists();
+
+create trigger
+  immutable_columns
+before
+update on resource_label
+  for each row execute procedure immutable_columns('resource_id', 'key', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on resource_label
+  for each row execute procedure default_create_time();
+
+-- resource_label_resource_deleted removes the labels of a resource when it's
+-- deleted.
+create or replace function
+  resource_label_resource_deleted()
+  returns trigger
+as $$
+begin
+  delete from resource_label
+   where resource_id = old.public_id;
+  return old;
+end;
+$$ language plpgsql;
+
+create trigger
+  resource_label_resource_deleted
+after
+delete on iam_scope
+  for each row execute procedure resource_label_resource_deleted();
+
+create trigger
+  resource_label_resource_deleted
+after
+delete on target
+  for each row execute procedure resource_label_resource_deleted();
+
+create trigger
+  resource_label_resource_deleted
+after
+delete on host_catalog
+  for each row execute procedure resource_label_resource_deleted();
+
+create trigger
+  resource_label_resource_deleted
+after
+delete on host_set
+  for each row execute procedure resource_label_resource_deleted();
+
+create trigger
+  resource_label_resource_deleted
+after
+delete on host
+  for each row execute procedure resource_label_resource_deleted();
+`),
+			8006: []byte(`
+-- iam_group_member_group is an association table that represents groups with
+-- associated child groups. The members of a child group are members of every
+-- group it's a member of, transitively.
+create table iam_group_member_group (
+  create_time wt_timestamp,
+  group_id wt_public_id -- pk
+    references iam_group(public_id)
+    on delete cascade
+    on update cascade,
+  member_id wt_public_id -- pk
+    references iam_group(public_id)
+    on delete cascade
+    on update cascade,
+  primary key (group_id, member_id),
+  constraint group_cannot_be_member_of_itself
+    check(
+      group_id != member_id
+    )
+);
+
+-- iam_group_member_group_no_cycle ensures a group is never, transitively, a
+-- member of itself.
+create or replace function
+  iam_group_member_group_no_cycle()
+  returns trigger
+as $$
+begin
+  if exists (
+    with recursive
+    nested_groups (group_id) as (
+      select new.member_id
+       union
+      select iam_group_member_group.member_id
+        from iam_group_member_group
+       inner
+        join nested_groups
+          on iam_group_member_group.group_id = nested_groups.group_id
+    )
+    select 1
+      from nested_groups
+     where group_id = new.group_id
+  ) then
+    raise exception 'group % cannot be a member of group %: it would create a cycle', new.member_id, new.group_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  ensure_no_group_member_cycle
+before
+insert on iam_group_member_group
+  for each row execute procedure iam_group_member_group_no_cycle();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_group_member_group
+  for each row execute procedure default_create_time();
+
+create trigger
+  iam_immutable_group_member
+before
+update on iam_group_member_group
+  for each row execute procedure iam_immutable_group_member();
+
+-- iam_group_member is recreated to include child groups, with a type of
+-- 'group'.
+drop view iam_group_member;
+
+create view iam_group_member as
+select
+  gm.create_time,
+  gm.group_id,
+  gm.member_id,
+  u.scope_id as member_scope_id,
+  g.scope_id as group_scope_id,
+  get_scoped_member_id(g.scope_id, u.scope_id, gm.member_id) as scoped_member_id,
+  'user' as type
+from
+  iam_group_member_user gm,
+  iam_user u,
+  iam_group g
+where
+  gm.member_id = u.public_id and
+  gm.group_id = g.public_id
+union
+select
+  gm.create_time,
+  gm.group_id,
+  gm.member_id,
+  mg.scope_id as member_scope_id,
+  g.scope_id as group_scope_id,
+  get_scoped_member_id(g.scope_id, mg.scope_id, gm.member_id) as scoped_member_id,
+  'group' as type
+from
+  iam_group_member_group gm,
+  iam_group mg,
+  iam_group g
+where
+  gm.member_id = mg.public_id and
+  gm.group_id = g.public_id;
+`),
+			8007: []byte(`
+-- iam_role_template is a named set of grants and principals defined in the
+-- global scope or an org. A role is instantiated from the template in every
+-- project below its scope, and the instantiated roles are kept in sync with
+-- the template.
+create table iam_role_template (
+  public_id wt_public_id primary key,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  name text,
+  description text,
+  scope_id wt_scope_id
+    not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  version wt_version,
+  unique(name, scope_id)
+);
+
+-- role_template_scope_id_valid ensures role templates are only created in the
+-- global scope or an org, the scopes that have projects below them.
+create or replace function
+  role_template_scope_id_valid()
+  returns trigger
+as $$
+begin
+  perform from iam_scope where public_id = new.scope_id and type in ('global', 'org');
+  if not found then
+    raise exception 'invalid scope type for role template creation';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  ensure_role_template_scope_id_valid
+before
+insert on iam_role_template
+  for each row execute procedure role_template_scope_id_valid();
+
+create trigger
+  update_version_column
+after update on iam_role_template
+  for each row execute procedure update_version_column();
+
+create trigger
+  update_time_column
+before update on iam_role_template
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_template
+  for each row execute procedure default_create_time();
+
+create trigger
+  a_immutable_columns
+before
+update on iam_role_template
+  for each row execute procedure immutable_columns('public_id', 'create_time', 'scope_id');
+
+-- iam_role_template_grant holds the grants of a role template. The grants may
+-- reference the parameters {{scope.id}} and {{scope.parent_id}}, which are
+-- replaced with the project's values in the instantiated roles.
+create table iam_role_template_grant (
+  create_time wt_timestamp,
+  role_template_id wt_public_id -- pk
+    references iam_role_template(public_id)
+    on delete cascade
+    on update cascade,
+  canonical_grant text -- pk
+    constraint canonical_grant_must_not_be_empty
+    check(
+      length(trim(canonical_grant)) > 0
+    ),
+  raw_grant text not null
+    constraint raw_grant_must_not_be_empty
+    check(
+      length(trim(raw_grant)) > 0
+    ),
+  primary key(role_template_id, canonical_grant)
+);
+
+-- iam_role_template_user_principal and iam_role_template_group_principal hold
+-- the users and groups assigned to the roles instantiated from a role
+-- template.
+create table iam_role_template_user_principal (
+  create_time wt_timestamp,
+  role_template_id wt_public_id
+    references iam_role_template(public_id)
+    on delete cascade
+    on update cascade,
+  principal_id wt_user_id
+    references iam_user(public_id)
+    on delete cascade
+    on update cascade,
+  primary key (role_template_id, principal_id)
+);
+
+create table iam_role_template_group_principal (
+  create_time wt_timestamp,
+  role_template_id wt_public_id
+    references iam_role_template(public_id)
+    on delete cascade
+    on update cascade,
+  principal_id wt_public_id
+    references iam_group(public_id)
+    on delete cascade
+    on update cascade,
+  primary key (role_template_id, principal_id)
+);
+
+-- iam_role_template_principal provides a consolidated view of the users and
+-- groups assigned to role templates.
+create view iam_role_template_principal as
+select
+  tu.create_time,
+  tu.role_template_id,
+  tu.principal_id,
+  u.scope_id as principal_scope_id,
+  'user' as type
+from
+  iam_role_template_user_principal tu,
+  iam_user u
+where
+  tu.principal_id = u.public_id
+union
+select
+  tg.create_time,
+  tg.role_template_id,
+  tg.principal_id,
+  g.scope_id as principal_scope_id,
+  'group' as type
+from
+  iam_role_template_group_principal tg,
+  iam_group g
+where
+  tg.principal_id = g.public_id;
+
+-- iam_role_template_role records the roles instantiated from a role template.
+-- A role is instantiated from at most one template.
+create table iam_role_template_role (
+  create_time wt_timestamp,
+  role_template_id wt_public_id
+    references iam_role_template(public_id)
+    on delete cascade
+    on update cascade,
+  role_id wt_role_id
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  primary key (role_template_id, role_id),
+  unique(role_id)
+);
+
+-- iam_immutable_role_template_association() ensures the grants, principals
+-- and roles of role templates are immutable.
+create or replace function
+  iam_immutable_role_template_association()
+  returns trigger
+as $$
+begin
+  raise exception 'role template associations are immutable';
+end;
+$$ language plpgsql;
+
+create trigger
+  immutable_role_template_grant
+before
+update on iam_role_template_grant
+  for each row execute procedure iam_immutable_role_template_association();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_template_grant
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_role_template_user_principal
+before
+update on iam_role_template_user_principal
+  for each row execute procedure iam_immutable_role_template_association();
+
+create trigger
+  recovery_user_not_allowed_role_template_user_principal
+before
+insert on iam_role_template_user_principal
+  for each row execute procedure recovery_user_not_allowed('principal_id');
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_template_user_principal
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_role_template_group_principal
+before
+update on iam_role_template_group_principal
+  for each row execute procedure iam_immutable_role_template_association();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_template_group_principal
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_role_template_role
+before
+update on iam_role_template_role
+  for each row execute procedure iam_immutable_role_template_association();
+
+create trigger
+  default_create_time_column
+before
+insert on iam_role_template_role
+  for each row execute procedure default_create_time();
+
+insert into oplog_ticket (name, version)
+values
+  ('iam_role_template', 1);
+`),
+			8008: []byte(`
+-- resource_owner holds the user owning a target, host set, host or group,
+-- which grants can match on. The owner is the user that created the resource
+-- until ownership is transferred. When the owning user is deleted the
+-- resource is left without an owner.
+create table resource_owner (
+  resource_id wt_public_id primary key,
+  owner_id wt_user_id not null
+    references iam_user(public_id)
+    on delete cascade
+    on update cascade,
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+-- resource_owner_resource_exists ensures an owner is only recorded for a
+-- resource that exists and can be owned.
+create or replace function
+  resource_owner_resource_exists()
+  returns trigger
+as $$
+begin
+  perform 1 from target where public_id = new.resource_id
+   union all
+  select 1 from host_set where public_id = new.resource_id
+   union all
+  select 1 from host where public_id = new.resource_id
+   union all
+  select 1 from iam_group where public_id = new.resource_id;
+  if not found then
+    raise exception 'resource % does not exist or cannot be owned', new.resource_id;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  ensure_resource_owner_resource_exists
+before
+insert on resource_owner
+  for each row execute procedure resource_owner_resource_exists();
+
+create trigger
+  immutable_columns
+before
+update on resource_owner
+  for each row execute procedure immutable_columns('resource_id', 'create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on resource_owner
+  for each row execute procedure default_create_time();
+
+create trigger
+  update_time_column
+before
+update on resource_owner
+  for each row execute procedure update_time_column();
+
+-- resource_owner_resource_deleted removes the owner of a resource when it's
+-- deleted.
+create or replace function
+  resource_owner_resource_deleted()
+  returns trigger
+as $$
+begin
+  delete from resource_owner
+   where resource_id = old.public_id;
+  return old;
+end;
+$$ language plpgsql;
+
+create trigger
+  resource_owner_resource_deleted
+after
+delete on target
+  for each row execute procedure resource_owner_resource_deleted();
+
+create trigger
+  resource_owner_resource_deleted
+after
+delete on host_set
+  for each row execute procedure resource_owner_resource_deleted();
+
+create trigger
+  resource_owner_resource_deleted
+after
+delete on host
+  for each row execute procedure resource_owner_resource_deleted();
+
+create trigger
+  resource_owner_resource_deleted
+after
+delete on iam_group
+  for each row execute procedure resource_owner_resource_deleted();
+
+insert into oplog_ticket (name, version)
+values
+  ('resource_owner', 1);
 `),
 		},
 	}
@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// cataloger is implemented by drivers which can describe the objects in a
+// schema, which is needed to verify it.
+type cataloger interface {
+	// Catalog returns a sorted description of the objects in the current
+	// schema.
+	Catalog(context.Context) ([]string, error)
+	// MigratedCatalog returns the Catalog of a schema created by running the
+	// migrations, without modifying the database.
+	MigratedCatalog(context.Context, [][]byte) ([]string, error)
+}
+
+// Drift is the difference between a database's schema and the schema defined
+// by the migrations embedded in the binary.  Each entry describes an object,
+// e.g. "trigger iam_scope.immutable_columns".
+type Drift struct {
+	// Missing are objects defined by the migrations which are not in the
+	// database.
+	Missing []string
+	// Unexpected are objects in the database which are not defined by the
+	// migrations.
+	Unexpected []string
+}
+
+// Empty reports whether there is no drift.
+func (d *Drift) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Unexpected) == 0
+}
+
+// Verify compares the database's schema to the schema defined by the
+// embedded migrations up to the database's current version, and returns the
+// Drift between them.  The tables, views, sequences, columns, constraints,
+// indexes, triggers, functions and domains of the schemas are compared.
+//
+// The expected schema is built by running the migrations in a temporary
+// schema within a transaction which is rolled back, so the database is not
+// modified.  A database with no schema version can't be verified.
+func (b *Manager) Verify(ctx context.Context) (*Drift, error) {
+	const op = "schema.(Manager).Verify"
+	c, ok := b.driver.(cataloger)
+	if !ok {
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("verification is not supported for dialect %q", b.dialect))
+	}
+	curVersion, _, dirty, err := b.driver.CurrentState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	switch {
+	case curVersion == nilVersion:
+		return nil, errors.New(errors.MigrationIntegrity, op, "database has not been initialized")
+	case dirty:
+		return nil, errors.New(errors.NotSpecificIntegrity, op, fmt.Sprintf("schema is dirty with version %d", curVersion))
+	}
+
+	up := getUpMigration(b.dialect, WithMigrationStates(b.migrationStates))
+	versions := make([]int, 0, len(up))
+	for v := range up {
+		if v <= curVersion {
+			versions = append(versions, v)
+		}
+	}
+	sort.Ints(versions)
+	migrations := make([][]byte, 0, len(versions))
+	for _, v := range versions {
+		migrations = append(migrations, up[v])
+	}
+
+	want, err := c.MigratedCatalog(ctx, migrations)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	got, err := c.Catalog(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return diffCatalogs(want, got), nil
+}
+
+// diffCatalogs returns the Drift from the want catalog to the got catalog.
+// Both must be sorted.
+func diffCatalogs(want, got []string) *Drift {
+	d := &Drift{}
+	i, j := 0, 0
+	for i < len(want) && j < len(got) {
+		switch {
+		case want[i] == got[j]:
+			i++
+			j++
+		case want[i] < got[j]:
+			d.Missing = append(d.Missing, want[i])
+			i++
+		default:
+			d.Unexpected = append(d.Unexpected, got[j])
+			j++
+		}
+	}
+	d.Missing = append(d.Missing, want[i:]...)
+	d.Unexpected = append(d.Unexpected, got[j:]...)
+	return d
+}
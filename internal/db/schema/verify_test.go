@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/docker"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	dialect := "postgres"
+
+	c, u, _, err := docker.StartDbInDocker(dialect)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c())
+	})
+	d, err := sql.Open(dialect, u)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	m, err := NewManager(ctx, dialect, d)
+	require.NoError(t, err)
+
+	_, err = m.Verify(ctx)
+	assert.True(t, errors.Match(errors.T(errors.MigrationIntegrity), err))
+
+	require.NoError(t, m.RollForward(ctx))
+	drift, err := m.Verify(ctx)
+	require.NoError(t, err)
+	assert.True(t, drift.Empty(), "unexpected drift: %+v", drift)
+
+	_, err = d.ExecContext(ctx, `
+alter table iam_scope add column drifted text;
+drop trigger immutable_columns on iam_scope;
+`)
+	require.NoError(t, err)
+	drift, err = m.Verify(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"trigger iam_scope.immutable_columns"}, drift.Missing)
+	assert.Equal(t, []string{"column iam_scope.drifted text"}, drift.Unexpected)
+
+	// Verifying doesn't modify the database.
+	var exists bool
+	require.NoError(t, d.QueryRowContext(ctx, "select exists (select 1 from pg_namespace where nspname = 'boundary_schema_verify')").Scan(&exists))
+	assert.False(t, exists)
+}
+
+func Test_diffCatalogs(t *testing.T) {
+	tests := []struct {
+		name  string
+		want  []string
+		got   []string
+		drift *Drift
+	}{
+		{
+			name:  "empty",
+			drift: &Drift{},
+		},
+		{
+			name:  "equal",
+			want:  []string{"table a", "table b"},
+			got:   []string{"table a", "table b"},
+			drift: &Drift{},
+		},
+		{
+			name:  "missing",
+			want:  []string{"table a", "table b", "table c"},
+			got:   []string{"table b"},
+			drift: &Drift{Missing: []string{"table a", "table c"}},
+		},
+		{
+			name:  "unexpected",
+			want:  []string{"table b"},
+			got:   []string{"table a", "table b", "table c"},
+			drift: &Drift{Unexpected: []string{"table a", "table c"}},
+		},
+		{
+			name: "both",
+			want: []string{"column a.x text", "table a", "trigger a.t"},
+			got:  []string{"column a.x integer", "table a"},
+			drift: &Drift{
+				Missing:    []string{"column a.x text", "trigger a.t"},
+				Unexpected: []string{"column a.x integer"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffCatalogs(tt.want, tt.got)
+			assert.Equal(t, tt.drift, got)
+			assert.Equal(t, len(tt.want) == len(tt.got) && got.Empty(), got.Empty())
+		})
+	}
+}
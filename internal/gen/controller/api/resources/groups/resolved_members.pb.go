@@ -0,0 +1,256 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v3.15.8
+// source: controller/api/resources/groups/v1/resolved_members.proto
+
+package groups
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ResolvedMember is a User that is a member of a Group, either directly or
+// through Groups nested in it.
+type ResolvedMember struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Output only. The ID of the User.
+	Id string `protobuf:"bytes,10,opt,name=id,proto3" json:"id,omitempty"`
+	// Output only. The Scope ID of the User.
+	ScopeId string `protobuf:"bytes,20,opt,name=scope_id,proto3" json:"scope_id,omitempty"`
+	// Output only. The IDs of the Groups the User is a direct member of, which are the Group itself or Groups nested in it.
+	GroupIds []string `protobuf:"bytes,30,rep,name=group_ids,proto3" json:"group_ids,omitempty"`
+}
+
+func (x *ResolvedMember) Reset() {
+	*x = ResolvedMember{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_api_resources_groups_v1_resolved_members_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolvedMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedMember) ProtoMessage() {}
+
+func (x *ResolvedMember) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_api_resources_groups_v1_resolved_members_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvedMember.ProtoReflect.Descriptor instead.
+func (*ResolvedMember) Descriptor() ([]byte, []int) {
+	return file_controller_api_resources_groups_v1_resolved_members_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ResolvedMember) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResolvedMember) GetScopeId() string {
+	if x != nil {
+		return x.ScopeId
+	}
+	return ""
+}
+
+func (x *ResolvedMember) GetGroupIds() []string {
+	if x != nil {
+		return x.GroupIds
+	}
+	return nil
+}
+
+// ResolvedMembers contains the Users that are members of a Group after
+// expanding the Groups nested in it.
+type ResolvedMembers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Output only. The ID of the Group.
+	GroupId string `protobuf:"bytes,10,opt,name=group_id,proto3" json:"group_id,omitempty"`
+	// Output only. The Users, sorted by ID.
+	Members []*ResolvedMember `protobuf:"bytes,20,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+func (x *ResolvedMembers) Reset() {
+	*x = ResolvedMembers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_api_resources_groups_v1_resolved_members_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolvedMembers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedMembers) ProtoMessage() {}
+
+func (x *ResolvedMembers) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_api_resources_groups_v1_resolved_members_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvedMembers.ProtoReflect.Descriptor instead.
+func (*ResolvedMembers) Descriptor() ([]byte, []int) {
+	return file_controller_api_resources_groups_v1_resolved_members_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ResolvedMembers) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ResolvedMembers) GetMembers() []*ResolvedMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+var File_controller_api_resources_groups_v1_resolved_members_proto protoreflect.FileDescriptor
+
+var file_controller_api_resources_groups_v1_resolved_members_proto_rawDesc = []byte{
+	0x0a, 0x39, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x5f, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x22, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e, 0x76, 0x31, 0x22,
+	0x5a, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x4d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x1e, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x73, 0x22, 0x7b, 0x0a, 0x0f, 0x52,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x12, 0x4c, 0x0a, 0x07, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52,
+	0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x42, 0x53, 0x5a, 0x51, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70,
+	0x2f, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x3b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_controller_api_resources_groups_v1_resolved_members_proto_rawDescOnce sync.Once
+	file_controller_api_resources_groups_v1_resolved_members_proto_rawDescData = file_controller_api_resources_groups_v1_resolved_members_proto_rawDesc
+)
+
+func file_controller_api_resources_groups_v1_resolved_members_proto_rawDescGZIP() []byte {
+	file_controller_api_resources_groups_v1_resolved_members_proto_rawDescOnce.Do(func() {
+		file_controller_api_resources_groups_v1_resolved_members_proto_rawDescData = protoimpl.X.CompressGZIP(file_controller_api_resources_groups_v1_resolved_members_proto_rawDescData)
+	})
+	return file_controller_api_resources_groups_v1_resolved_members_proto_rawDescData
+}
+
+var file_controller_api_resources_groups_v1_resolved_members_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_controller_api_resources_groups_v1_resolved_members_proto_goTypes = []interface{}{
+	(*ResolvedMember)(nil),  // 0: controller.api.resources.groups.v1.ResolvedMember
+	(*ResolvedMembers)(nil), // 1: controller.api.resources.groups.v1.ResolvedMembers
+}
+var file_controller_api_resources_groups_v1_resolved_members_proto_depIdxs = []int32{
+	0, // 0: controller.api.resources.groups.v1.ResolvedMembers.members:type_name -> controller.api.resources.groups.v1.ResolvedMember
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_controller_api_resources_groups_v1_resolved_members_proto_init() }
+func file_controller_api_resources_groups_v1_resolved_members_proto_init() {
+	if File_controller_api_resources_groups_v1_resolved_members_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_controller_api_resources_groups_v1_resolved_members_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolvedMember); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_api_resources_groups_v1_resolved_members_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolvedMembers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_controller_api_resources_groups_v1_resolved_members_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_controller_api_resources_groups_v1_resolved_members_proto_goTypes,
+		DependencyIndexes: file_controller_api_resources_groups_v1_resolved_members_proto_depIdxs,
+		MessageInfos:      file_controller_api_resources_groups_v1_resolved_members_proto_msgTypes,
+	}.Build()
+	File_controller_api_resources_groups_v1_resolved_members_proto = out.File
+	file_controller_api_resources_groups_v1_resolved_members_proto_rawDesc = nil
+	file_controller_api_resources_groups_v1_resolved_members_proto_goTypes = nil
+	file_controller_api_resources_groups_v1_resolved_members_proto_depIdxs = nil
+}
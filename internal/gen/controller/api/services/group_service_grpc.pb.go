@@ -61,6 +61,16 @@ type GroupServiceClient interface {
 	// An error is returned if any provided id is missing, malformed or
 	// references a non-existing resource.
 	RemoveGroupMembers(ctx context.Context, in *RemoveGroupMembersRequest, opts ...grpc.CallOption) (*RemoveGroupMembersResponse, error)
+	// GetGroupResolvedMembers returns the Users that are members of a Group,
+	// either directly or through the Groups nested in it. The provided request
+	// must include the Group ID. If that ID is missing, malformed or references
+	// a non existing resource, an error is returned.
+	GetGroupResolvedMembers(ctx context.Context, in *GetGroupResolvedMembersRequest, opts ...grpc.CallOption) (*GetGroupResolvedMembersResponse, error)
+	// TransferGroupOwnership makes the provided user the owner of the Group, which
+	// grants can match on. The Group is owned by the user that created it until
+	// its ownership is transferred. If the ID is missing, malformed or references
+	// a non existing resource, an error is returned.
+	TransferGroupOwnership(ctx context.Context, in *TransferGroupOwnershipRequest, opts ...grpc.CallOption) (*TransferGroupOwnershipResponse, error)
 }
 
 type groupServiceClient struct {
@@ -143,6 +153,24 @@ func (c *groupServiceClient) RemoveGroupMembers(ctx context.Context, in *RemoveG
 	return out, nil
 }
 
+func (c *groupServiceClient) GetGroupResolvedMembers(ctx context.Context, in *GetGroupResolvedMembersRequest, opts ...grpc.CallOption) (*GetGroupResolvedMembersResponse, error) {
+	out := new(GetGroupResolvedMembersResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.GroupService/GetGroupResolvedMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *groupServiceClient) TransferGroupOwnership(ctx context.Context, in *TransferGroupOwnershipRequest, opts ...grpc.CallOption) (*TransferGroupOwnershipResponse, error) {
+	out := new(TransferGroupOwnershipResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.GroupService/TransferGroupOwnership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GroupServiceServer is the server API for GroupService service.
 // All implementations must embed UnimplementedGroupServiceServer
 // for forward compatibility
@@ -190,6 +218,16 @@ type GroupServiceServer interface {
 	// An error is returned if any provided id is missing, malformed or
 	// references a non-existing resource.
 	RemoveGroupMembers(context.Context, *RemoveGroupMembersRequest) (*RemoveGroupMembersResponse, error)
+	// GetGroupResolvedMembers returns the Users that are members of a Group,
+	// either directly or through the Groups nested in it. The provided request
+	// must include the Group ID. If that ID is missing, malformed or references
+	// a non existing resource, an error is returned.
+	GetGroupResolvedMembers(context.Context, *GetGroupResolvedMembersRequest) (*GetGroupResolvedMembersResponse, error)
+	// TransferGroupOwnership makes the provided user the owner of the Group, which
+	// grants can match on. The Group is owned by the user that created it until
+	// its ownership is transferred. If the ID is missing, malformed or references
+	// a non existing resource, an error is returned.
+	TransferGroupOwnership(context.Context, *TransferGroupOwnershipRequest) (*TransferGroupOwnershipResponse, error)
 	mustEmbedUnimplementedGroupServiceServer()
 }
 
@@ -221,6 +259,12 @@ func (UnimplementedGroupServiceServer) SetGroupMembers(context.Context, *SetGrou
 func (UnimplementedGroupServiceServer) RemoveGroupMembers(context.Context, *RemoveGroupMembersRequest) (*RemoveGroupMembersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveGroupMembers not implemented")
 }
+func (UnimplementedGroupServiceServer) GetGroupResolvedMembers(context.Context, *GetGroupResolvedMembersRequest) (*GetGroupResolvedMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroupResolvedMembers not implemented")
+}
+func (UnimplementedGroupServiceServer) TransferGroupOwnership(context.Context, *TransferGroupOwnershipRequest) (*TransferGroupOwnershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferGroupOwnership not implemented")
+}
 func (UnimplementedGroupServiceServer) mustEmbedUnimplementedGroupServiceServer() {}
 
 // UnsafeGroupServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -378,6 +422,42 @@ func _GroupService_RemoveGroupMembers_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GroupService_GetGroupResolvedMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupResolvedMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).GetGroupResolvedMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.GroupService/GetGroupResolvedMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).GetGroupResolvedMembers(ctx, req.(*GetGroupResolvedMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GroupService_TransferGroupOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferGroupOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GroupServiceServer).TransferGroupOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.GroupService/TransferGroupOwnership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GroupServiceServer).TransferGroupOwnership(ctx, req.(*TransferGroupOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GroupService_ServiceDesc is the grpc.ServiceDesc for GroupService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -417,6 +497,14 @@ var GroupService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveGroupMembers",
 			Handler:    _GroupService_RemoveGroupMembers_Handler,
 		},
+		{
+			MethodName: "GetGroupResolvedMembers",
+			Handler:    _GroupService_GetGroupResolvedMembers_Handler,
+		},
+		{
+			MethodName: "TransferGroupOwnership",
+			Handler:    _GroupService_TransferGroupOwnership_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/group_service.proto",
@@ -44,6 +44,12 @@ type HostCatalogServiceClient interface {
 	// sets from Boundary. If the provided Host Catalog IDs is malformed or not
 	// provided DeleteHostCatalog returns an error.
 	DeleteHostCatalog(ctx context.Context, in *DeleteHostCatalogRequest, opts ...grpc.CallOption) (*DeleteHostCatalogResponse, error)
+	// SetHostCatalogLabels sets the labels on the Host Catalog, which grants can match on.
+	// Any existing labels on the Host Catalog are removed if they are not included in
+	// this request. An empty set of labels removes all labels. If the ID is
+	// missing, malformed or references a non existing resource, an error is
+	// returned.
+	SetHostCatalogLabels(ctx context.Context, in *SetHostCatalogLabelsRequest, opts ...grpc.CallOption) (*SetHostCatalogLabelsResponse, error)
 }
 
 type hostCatalogServiceClient struct {
@@ -99,6 +105,15 @@ func (c *hostCatalogServiceClient) DeleteHostCatalog(ctx context.Context, in *De
 	return out, nil
 }
 
+func (c *hostCatalogServiceClient) SetHostCatalogLabels(ctx context.Context, in *SetHostCatalogLabelsRequest, opts ...grpc.CallOption) (*SetHostCatalogLabelsResponse, error) {
+	out := new(SetHostCatalogLabelsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.HostCatalogService/SetHostCatalogLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HostCatalogServiceServer is the server API for HostCatalogService service.
 // All implementations must embed UnimplementedHostCatalogServiceServer
 // for forward compatibility
@@ -129,6 +144,12 @@ type HostCatalogServiceServer interface {
 	// sets from Boundary. If the provided Host Catalog IDs is malformed or not
 	// provided DeleteHostCatalog returns an error.
 	DeleteHostCatalog(context.Context, *DeleteHostCatalogRequest) (*DeleteHostCatalogResponse, error)
+	// SetHostCatalogLabels sets the labels on the Host Catalog, which grants can match on.
+	// Any existing labels on the Host Catalog are removed if they are not included in
+	// this request. An empty set of labels removes all labels. If the ID is
+	// missing, malformed or references a non existing resource, an error is
+	// returned.
+	SetHostCatalogLabels(context.Context, *SetHostCatalogLabelsRequest) (*SetHostCatalogLabelsResponse, error)
 	mustEmbedUnimplementedHostCatalogServiceServer()
 }
 
@@ -151,6 +172,9 @@ func (UnimplementedHostCatalogServiceServer) UpdateHostCatalog(context.Context,
 func (UnimplementedHostCatalogServiceServer) DeleteHostCatalog(context.Context, *DeleteHostCatalogRequest) (*DeleteHostCatalogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteHostCatalog not implemented")
 }
+func (UnimplementedHostCatalogServiceServer) SetHostCatalogLabels(context.Context, *SetHostCatalogLabelsRequest) (*SetHostCatalogLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHostCatalogLabels not implemented")
+}
 func (UnimplementedHostCatalogServiceServer) mustEmbedUnimplementedHostCatalogServiceServer() {}
 
 // UnsafeHostCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -254,6 +278,24 @@ func _HostCatalogService_DeleteHostCatalog_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HostCatalogService_SetHostCatalogLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHostCatalogLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostCatalogServiceServer).SetHostCatalogLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.HostCatalogService/SetHostCatalogLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostCatalogServiceServer).SetHostCatalogLabels(ctx, req.(*SetHostCatalogLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HostCatalogService_ServiceDesc is the grpc.ServiceDesc for HostCatalogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,6 +323,10 @@ var HostCatalogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteHostCatalog",
 			Handler:    _HostCatalogService_DeleteHostCatalog_Handler,
 		},
+		{
+			MethodName: "SetHostCatalogLabels",
+			Handler:    _HostCatalogService_SetHostCatalogLabels_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/host_catalog_service.proto",
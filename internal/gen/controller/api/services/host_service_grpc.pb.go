@@ -46,6 +46,23 @@ type HostServiceClient interface {
 	// DeleteHost removes a Host from Boundary. If the provided Host ID
 	// is malformed or not provided an error is returned.
 	DeleteHost(ctx context.Context, in *DeleteHostRequest, opts ...grpc.CallOption) (*DeleteHostResponse, error)
+	// RotateSshHostKey replaces the SSH host key pinned for a Host with the
+	// pending key the Host presented in its place. The pending key's
+	// fingerprint must be provided, so a key presented after the caller
+	// inspected the Host isn't trusted unseen. An error is returned if the
+	// Host has no pending key.
+	RotateSshHostKey(ctx context.Context, in *RotateSshHostKeyRequest, opts ...grpc.CallOption) (*RotateSshHostKeyResponse, error)
+	// SetHostLabels sets the labels on the Host, which grants can match on.
+	// Any existing labels on the Host are removed if they are not included in
+	// this request. An empty set of labels removes all labels. If the ID is
+	// missing, malformed or references a non existing resource, an error is
+	// returned.
+	SetHostLabels(ctx context.Context, in *SetHostLabelsRequest, opts ...grpc.CallOption) (*SetHostLabelsResponse, error)
+	// TransferHostOwnership makes the provided user the owner of the Host, which
+	// grants can match on. The Host is owned by the user that created it until its
+	// ownership is transferred. If the ID is missing, malformed or references a
+	// non existing resource, an error is returned.
+	TransferHostOwnership(ctx context.Context, in *TransferHostOwnershipRequest, opts ...grpc.CallOption) (*TransferHostOwnershipResponse, error)
 }
 
 type hostServiceClient struct {
@@ -101,6 +118,33 @@ func (c *hostServiceClient) DeleteHost(ctx context.Context, in *DeleteHostReques
 	return out, nil
 }
 
+func (c *hostServiceClient) RotateSshHostKey(ctx context.Context, in *RotateSshHostKeyRequest, opts ...grpc.CallOption) (*RotateSshHostKeyResponse, error) {
+	out := new(RotateSshHostKeyResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.HostService/RotateSshHostKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) SetHostLabels(ctx context.Context, in *SetHostLabelsRequest, opts ...grpc.CallOption) (*SetHostLabelsResponse, error) {
+	out := new(SetHostLabelsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.HostService/SetHostLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostServiceClient) TransferHostOwnership(ctx context.Context, in *TransferHostOwnershipRequest, opts ...grpc.CallOption) (*TransferHostOwnershipResponse, error) {
+	out := new(TransferHostOwnershipResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.HostService/TransferHostOwnership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HostServiceServer is the server API for HostService service.
 // All implementations must embed UnimplementedHostServiceServer
 // for forward compatibility
@@ -133,6 +177,23 @@ type HostServiceServer interface {
 	// DeleteHost removes a Host from Boundary. If the provided Host ID
 	// is malformed or not provided an error is returned.
 	DeleteHost(context.Context, *DeleteHostRequest) (*DeleteHostResponse, error)
+	// RotateSshHostKey replaces the SSH host key pinned for a Host with the
+	// pending key the Host presented in its place. The pending key's
+	// fingerprint must be provided, so a key presented after the caller
+	// inspected the Host isn't trusted unseen. An error is returned if the
+	// Host has no pending key.
+	RotateSshHostKey(context.Context, *RotateSshHostKeyRequest) (*RotateSshHostKeyResponse, error)
+	// SetHostLabels sets the labels on the Host, which grants can match on.
+	// Any existing labels on the Host are removed if they are not included in
+	// this request. An empty set of labels removes all labels. If the ID is
+	// missing, malformed or references a non existing resource, an error is
+	// returned.
+	SetHostLabels(context.Context, *SetHostLabelsRequest) (*SetHostLabelsResponse, error)
+	// TransferHostOwnership makes the provided user the owner of the Host, which
+	// grants can match on. The Host is owned by the user that created it until its
+	// ownership is transferred. If the ID is missing, malformed or references a
+	// non existing resource, an error is returned.
+	TransferHostOwnership(context.Context, *TransferHostOwnershipRequest) (*TransferHostOwnershipResponse, error)
 	mustEmbedUnimplementedHostServiceServer()
 }
 
@@ -155,6 +216,15 @@ func (UnimplementedHostServiceServer) UpdateHost(context.Context, *UpdateHostReq
 func (UnimplementedHostServiceServer) DeleteHost(context.Context, *DeleteHostRequest) (*DeleteHostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteHost not implemented")
 }
+func (UnimplementedHostServiceServer) RotateSshHostKey(context.Context, *RotateSshHostKeyRequest) (*RotateSshHostKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateSshHostKey not implemented")
+}
+func (UnimplementedHostServiceServer) SetHostLabels(context.Context, *SetHostLabelsRequest) (*SetHostLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHostLabels not implemented")
+}
+func (UnimplementedHostServiceServer) TransferHostOwnership(context.Context, *TransferHostOwnershipRequest) (*TransferHostOwnershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferHostOwnership not implemented")
+}
 func (UnimplementedHostServiceServer) mustEmbedUnimplementedHostServiceServer() {}
 
 // UnsafeHostServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -258,6 +328,60 @@ func _HostService_DeleteHost_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HostService_RotateSshHostKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateSshHostKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).RotateSshHostKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.HostService/RotateSshHostKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).RotateSshHostKey(ctx, req.(*RotateSshHostKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_SetHostLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHostLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).SetHostLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.HostService/SetHostLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).SetHostLabels(ctx, req.(*SetHostLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostService_TransferHostOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferHostOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).TransferHostOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.HostService/TransferHostOwnership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).TransferHostOwnership(ctx, req.(*TransferHostOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HostService_ServiceDesc is the grpc.ServiceDesc for HostService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -285,6 +409,18 @@ var HostService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteHost",
 			Handler:    _HostService_DeleteHost_Handler,
 		},
+		{
+			MethodName: "RotateSshHostKey",
+			Handler:    _HostService_RotateSshHostKey_Handler,
+		},
+		{
+			MethodName: "SetHostLabels",
+			Handler:    _HostService_SetHostLabels_Handler,
+		},
+		{
+			MethodName: "TransferHostOwnership",
+			Handler:    _HostService_TransferHostOwnership_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/host_service.proto",
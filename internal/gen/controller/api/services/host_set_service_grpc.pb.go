@@ -66,6 +66,17 @@ type HostSetServiceClient interface {
 	// or references a non-existing scope or catalog, or if a Host id is included
 	// which is not in the provided Host Set.
 	RemoveHostSetHosts(ctx context.Context, in *RemoveHostSetHostsRequest, opts ...grpc.CallOption) (*RemoveHostSetHostsResponse, error)
+	// SetHostSetLabels sets the labels on the Host Set, which grants can match on.
+	// Any existing labels on the Host Set are removed if they are not included in
+	// this request. An empty set of labels removes all labels. If the ID is
+	// missing, malformed or references a non existing resource, an error is
+	// returned.
+	SetHostSetLabels(ctx context.Context, in *SetHostSetLabelsRequest, opts ...grpc.CallOption) (*SetHostSetLabelsResponse, error)
+	// TransferHostSetOwnership makes the provided user the owner of the Host Set,
+	// which grants can match on. The Host Set is owned by the user that created it
+	// until its ownership is transferred. If the ID is missing, malformed or
+	// references a non existing resource, an error is returned.
+	TransferHostSetOwnership(ctx context.Context, in *TransferHostSetOwnershipRequest, opts ...grpc.CallOption) (*TransferHostSetOwnershipResponse, error)
 }
 
 type hostSetServiceClient struct {
@@ -148,6 +159,24 @@ func (c *hostSetServiceClient) RemoveHostSetHosts(ctx context.Context, in *Remov
 	return out, nil
 }
 
+func (c *hostSetServiceClient) SetHostSetLabels(ctx context.Context, in *SetHostSetLabelsRequest, opts ...grpc.CallOption) (*SetHostSetLabelsResponse, error) {
+	out := new(SetHostSetLabelsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.HostSetService/SetHostSetLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostSetServiceClient) TransferHostSetOwnership(ctx context.Context, in *TransferHostSetOwnershipRequest, opts ...grpc.CallOption) (*TransferHostSetOwnershipResponse, error) {
+	out := new(TransferHostSetOwnershipResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.HostSetService/TransferHostSetOwnership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HostSetServiceServer is the server API for HostSetService service.
 // All implementations must embed UnimplementedHostSetServiceServer
 // for forward compatibility
@@ -200,6 +229,17 @@ type HostSetServiceServer interface {
 	// or references a non-existing scope or catalog, or if a Host id is included
 	// which is not in the provided Host Set.
 	RemoveHostSetHosts(context.Context, *RemoveHostSetHostsRequest) (*RemoveHostSetHostsResponse, error)
+	// SetHostSetLabels sets the labels on the Host Set, which grants can match on.
+	// Any existing labels on the Host Set are removed if they are not included in
+	// this request. An empty set of labels removes all labels. If the ID is
+	// missing, malformed or references a non existing resource, an error is
+	// returned.
+	SetHostSetLabels(context.Context, *SetHostSetLabelsRequest) (*SetHostSetLabelsResponse, error)
+	// TransferHostSetOwnership makes the provided user the owner of the Host Set,
+	// which grants can match on. The Host Set is owned by the user that created it
+	// until its ownership is transferred. If the ID is missing, malformed or
+	// references a non existing resource, an error is returned.
+	TransferHostSetOwnership(context.Context, *TransferHostSetOwnershipRequest) (*TransferHostSetOwnershipResponse, error)
 	mustEmbedUnimplementedHostSetServiceServer()
 }
 
@@ -231,6 +271,12 @@ func (UnimplementedHostSetServiceServer) SetHostSetHosts(context.Context, *SetHo
 func (UnimplementedHostSetServiceServer) RemoveHostSetHosts(context.Context, *RemoveHostSetHostsRequest) (*RemoveHostSetHostsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveHostSetHosts not implemented")
 }
+func (UnimplementedHostSetServiceServer) SetHostSetLabels(context.Context, *SetHostSetLabelsRequest) (*SetHostSetLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHostSetLabels not implemented")
+}
+func (UnimplementedHostSetServiceServer) TransferHostSetOwnership(context.Context, *TransferHostSetOwnershipRequest) (*TransferHostSetOwnershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferHostSetOwnership not implemented")
+}
 func (UnimplementedHostSetServiceServer) mustEmbedUnimplementedHostSetServiceServer() {}
 
 // UnsafeHostSetServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -388,6 +434,42 @@ func _HostSetService_RemoveHostSetHosts_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HostSetService_SetHostSetLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHostSetLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostSetServiceServer).SetHostSetLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.HostSetService/SetHostSetLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostSetServiceServer).SetHostSetLabels(ctx, req.(*SetHostSetLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostSetService_TransferHostSetOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferHostSetOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostSetServiceServer).TransferHostSetOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.HostSetService/TransferHostSetOwnership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostSetServiceServer).TransferHostSetOwnership(ctx, req.(*TransferHostSetOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HostSetService_ServiceDesc is the grpc.ServiceDesc for HostSetService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -427,6 +509,14 @@ var HostSetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveHostSetHosts",
 			Handler:    _HostSetService_RemoveHostSetHosts_Handler,
 		},
+		{
+			MethodName: "SetHostSetLabels",
+			Handler:    _HostSetService_SetHostSetLabels_Handler,
+		},
+		{
+			MethodName: "TransferHostSetOwnership",
+			Handler:    _HostSetService_TransferHostSetOwnership_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/host_set_service.proto",
@@ -0,0 +1,575 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package services
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// RoleTemplateServiceClient is the client API for RoleTemplateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RoleTemplateServiceClient interface {
+	// GetRoleTemplate returns a stored Role Template if present. The provided
+	// request must include the Role Template ID for the Role Template being
+	// retrieved. If the ID is missing, malformed or reference a non-existing
+	// resource an error is returned.
+	GetRoleTemplate(ctx context.Context, in *GetRoleTemplateRequest, opts ...grpc.CallOption) (*GetRoleTemplateResponse, error)
+	// ListRoleTemplates returns a list of stored Role Templates which exist inside
+	// the scope referenced inside the request. The request must include the scope
+	// ID for the Role Templates being retrieved. If the scope ID is missing,
+	// malformed, or reference a non-existing scope, an error is returned.
+	ListRoleTemplates(ctx context.Context, in *ListRoleTemplatesRequest, opts ...grpc.CallOption) (*ListRoleTemplatesResponse, error)
+	// CreateRoleTemplate creates and stores a Role Template. The provided request
+	// must include the scope ID in which the Role Template will be created. If the
+	// scope ID is missing, malformed, or references a non-existing scope, an error
+	// is returned. If a name is provided that is in use in another Role Template
+	// in the same scope, an error is returned.
+	// A Role is instantiated from the Role Template in every project below its
+	// scope, and in every project created below it later on.
+	CreateRoleTemplate(ctx context.Context, in *CreateRoleTemplateRequest, opts ...grpc.CallOption) (*CreateRoleTemplateResponse, error)
+	// UpdateRoleTemplate updates an existing Role Template in boundary.  The
+	// provided Role Template must not have any read-only fields set. The update
+	// mask must be included in the request and contain at least 1 mutable field.
+	// To unset a field's value, include the field in the update mask and don't set
+	// it in the provided Role Template. An error is returned if either the scope
+	// or Role Template IDs are missing or reference a non-existing resource. An
+	// error is also returned if the request attempts to update the name to one
+	// that is already in use in the same scope.
+	UpdateRoleTemplate(ctx context.Context, in *UpdateRoleTemplateRequest, opts ...grpc.CallOption) (*UpdateRoleTemplateResponse, error)
+	// DeleteRoleTemplate removes a Role Template from Boundary. If the provided
+	// scope or Role Template IDs are malformed, or not provided an error is
+	// returned.
+	DeleteRoleTemplate(ctx context.Context, in *DeleteRoleTemplateRequest, opts ...grpc.CallOption) (*DeleteRoleTemplateResponse, error)
+	// AddRoleTemplatePrincipals adds users and/or groups to a Role Template. The
+	// provided request must include the Role Template ID to which the users and/or
+	// groups will be added. If the Role Template ID is missing, malformed, or
+	// reference non-existing resources, an error is returned.  An error is also
+	// returned if the request attempts to add a principal that is already part of
+	// the role template.
+	AddRoleTemplatePrincipals(ctx context.Context, in *AddRoleTemplatePrincipalsRequest, opts ...grpc.CallOption) (*AddRoleTemplatePrincipalsResponse, error)
+	// SetRoleTemplatePrincipals sets the Role Template's users and/or groups. Any
+	// existing principals on the Role Template are deleted if they are not
+	// included in this request. The provided request must include the Role
+	// Template ID on which the users and/or groups will be set. If the Role
+	// Template ID is missing, malformed, or referencing a non-existing resource,
+	// an error is returned.
+	SetRoleTemplatePrincipals(ctx context.Context, in *SetRoleTemplatePrincipalsRequest, opts ...grpc.CallOption) (*SetRoleTemplatePrincipalsResponse, error)
+	// RemoveRoleTemplatePrincipals removes the users and/or groups from the
+	// specified Role Template. The provided request must include the Role Template
+	// ID from which the users and/or groups will be removed. If that ID is
+	// missing, malformed, or reference a non-existing resource, an error is
+	// returned. An error is returned if a remove is called for a principal that is
+	// not part of the role template.
+	RemoveRoleTemplatePrincipals(ctx context.Context, in *RemoveRoleTemplatePrincipalsRequest, opts ...grpc.CallOption) (*RemoveRoleTemplatePrincipalsResponse, error)
+	// AddRoleTemplateGrants adds grants to a Role Template. The provided request
+	// must include the Role Template id which the grants will be added to. An
+	// error is returned if the provided id is malformed or references a non-
+	// existing resource.
+	AddRoleTemplateGrants(ctx context.Context, in *AddRoleTemplateGrantsRequest, opts ...grpc.CallOption) (*AddRoleTemplateGrantsResponse, error)
+	// SetRoleTemplateGrants sets the Role Template's grants. Any existing grants
+	// on the Role Template are deleted if they are not included in this request.
+	// The provided request must include the Role Template ID on which the grants
+	// will be set. If missing, malformed, or referencing a non-existing resource,
+	// an error is returned.
+	SetRoleTemplateGrants(ctx context.Context, in *SetRoleTemplateGrantsRequest, opts ...grpc.CallOption) (*SetRoleTemplateGrantsResponse, error)
+	// RemoveRoleTemplateGrants removes the grants from the specified Role
+	// Template. The provided request must include the Role Template IDs from which
+	// the grants will be removed. If missing, malformed, or references a non-
+	// existing resource, an error is returned.
+	RemoveRoleTemplateGrants(ctx context.Context, in *RemoveRoleTemplateGrantsRequest, opts ...grpc.CallOption) (*RemoveRoleTemplateGrantsResponse, error)
+}
+
+type roleTemplateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRoleTemplateServiceClient(cc grpc.ClientConnInterface) RoleTemplateServiceClient {
+	return &roleTemplateServiceClient{cc}
+}
+
+func (c *roleTemplateServiceClient) GetRoleTemplate(ctx context.Context, in *GetRoleTemplateRequest, opts ...grpc.CallOption) (*GetRoleTemplateResponse, error) {
+	out := new(GetRoleTemplateResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleTemplateService/GetRoleTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) ListRoleTemplates(ctx context.Context, in *ListRoleTemplatesRequest, opts ...grpc.CallOption) (*ListRoleTemplatesResponse, error) {
+	out := new(ListRoleTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleTemplateService/ListRoleTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) CreateRoleTemplate(ctx context.Context, in *CreateRoleTemplateRequest, opts ...grpc.CallOption) (*CreateRoleTemplateResponse, error) {
+	out := new(CreateRoleTemplateResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleTemplateService/CreateRoleTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) UpdateRoleTemplate(ctx context.Context, in *UpdateRoleTemplateRequest, opts ...grpc.CallOption) (*UpdateRoleTemplateResponse, error) {
+	out := new(UpdateRoleTemplateResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleTemplateService/UpdateRoleTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) DeleteRoleTemplate(ctx context.Context, in *DeleteRoleTemplateRequest, opts ...grpc.CallOption) (*DeleteRoleTemplateResponse, error) {
+	out := new(DeleteRoleTemplateResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleTemplateService/DeleteRoleTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) AddRoleTemplatePrincipals(ctx context.Context, in *AddRoleTemplatePrincipalsRequest, opts ...grpc.CallOption) (*AddRoleTemplatePrincipalsResponse, error) {
+	out := new(AddRoleTemplatePrincipalsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleTemplateService/AddRoleTemplatePrincipals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) SetRoleTemplatePrincipals(ctx context.Context, in *SetRoleTemplatePrincipalsRequest, opts ...grpc.CallOption) (*SetRoleTemplatePrincipalsResponse, error) {
+	out := new(SetRoleTemplatePrincipalsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleTemplateService/SetRoleTemplatePrincipals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) RemoveRoleTemplatePrincipals(ctx context.Context, in *RemoveRoleTemplatePrincipalsRequest, opts ...grpc.CallOption) (*RemoveRoleTemplatePrincipalsResponse, error) {
+	out := new(RemoveRoleTemplatePrincipalsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleTemplateService/RemoveRoleTemplatePrincipals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) AddRoleTemplateGrants(ctx context.Context, in *AddRoleTemplateGrantsRequest, opts ...grpc.CallOption) (*AddRoleTemplateGrantsResponse, error) {
+	out := new(AddRoleTemplateGrantsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleTemplateService/AddRoleTemplateGrants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) SetRoleTemplateGrants(ctx context.Context, in *SetRoleTemplateGrantsRequest, opts ...grpc.CallOption) (*SetRoleTemplateGrantsResponse, error) {
+	out := new(SetRoleTemplateGrantsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleTemplateService/SetRoleTemplateGrants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleTemplateServiceClient) RemoveRoleTemplateGrants(ctx context.Context, in *RemoveRoleTemplateGrantsRequest, opts ...grpc.CallOption) (*RemoveRoleTemplateGrantsResponse, error) {
+	out := new(RemoveRoleTemplateGrantsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.RoleTemplateService/RemoveRoleTemplateGrants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RoleTemplateServiceServer is the server API for RoleTemplateService service.
+// All implementations must embed UnimplementedRoleTemplateServiceServer
+// for forward compatibility
+type RoleTemplateServiceServer interface {
+	// GetRoleTemplate returns a stored Role Template if present. The provided
+	// request must include the Role Template ID for the Role Template being
+	// retrieved. If the ID is missing, malformed or reference a non-existing
+	// resource an error is returned.
+	GetRoleTemplate(context.Context, *GetRoleTemplateRequest) (*GetRoleTemplateResponse, error)
+	// ListRoleTemplates returns a list of stored Role Templates which exist inside
+	// the scope referenced inside the request. The request must include the scope
+	// ID for the Role Templates being retrieved. If the scope ID is missing,
+	// malformed, or reference a non-existing scope, an error is returned.
+	ListRoleTemplates(context.Context, *ListRoleTemplatesRequest) (*ListRoleTemplatesResponse, error)
+	// CreateRoleTemplate creates and stores a Role Template. The provided request
+	// must include the scope ID in which the Role Template will be created. If the
+	// scope ID is missing, malformed, or references a non-existing scope, an error
+	// is returned. If a name is provided that is in use in another Role Template
+	// in the same scope, an error is returned.
+	// A Role is instantiated from the Role Template in every project below its
+	// scope, and in every project created below it later on.
+	CreateRoleTemplate(context.Context, *CreateRoleTemplateRequest) (*CreateRoleTemplateResponse, error)
+	// UpdateRoleTemplate updates an existing Role Template in boundary.  The
+	// provided Role Template must not have any read-only fields set. The update
+	// mask must be included in the request and contain at least 1 mutable field.
+	// To unset a field's value, include the field in the update mask and don't set
+	// it in the provided Role Template. An error is returned if either the scope
+	// or Role Template IDs are missing or reference a non-existing resource. An
+	// error is also returned if the request attempts to update the name to one
+	// that is already in use in the same scope.
+	UpdateRoleTemplate(context.Context, *UpdateRoleTemplateRequest) (*UpdateRoleTemplateResponse, error)
+	// DeleteRoleTemplate removes a Role Template from Boundary. If the provided
+	// scope or Role Template IDs are malformed, or not provided an error is
+	// returned.
+	DeleteRoleTemplate(context.Context, *DeleteRoleTemplateRequest) (*DeleteRoleTemplateResponse, error)
+	// AddRoleTemplatePrincipals adds users and/or groups to a Role Template. The
+	// provided request must include the Role Template ID to which the users and/or
+	// groups will be added. If the Role Template ID is missing, malformed, or
+	// reference non-existing resources, an error is returned.  An error is also
+	// returned if the request attempts to add a principal that is already part of
+	// the role template.
+	AddRoleTemplatePrincipals(context.Context, *AddRoleTemplatePrincipalsRequest) (*AddRoleTemplatePrincipalsResponse, error)
+	// SetRoleTemplatePrincipals sets the Role Template's users and/or groups. Any
+	// existing principals on the Role Template are deleted if they are not
+	// included in this request. The provided request must include the Role
+	// Template ID on which the users and/or groups will be set. If the Role
+	// Template ID is missing, malformed, or referencing a non-existing resource,
+	// an error is returned.
+	SetRoleTemplatePrincipals(context.Context, *SetRoleTemplatePrincipalsRequest) (*SetRoleTemplatePrincipalsResponse, error)
+	// RemoveRoleTemplatePrincipals removes the users and/or groups from the
+	// specified Role Template. The provided request must include the Role Template
+	// ID from which the users and/or groups will be removed. If that ID is
+	// missing, malformed, or reference a non-existing resource, an error is
+	// returned. An error is returned if a remove is called for a principal that is
+	// not part of the role template.
+	RemoveRoleTemplatePrincipals(context.Context, *RemoveRoleTemplatePrincipalsRequest) (*RemoveRoleTemplatePrincipalsResponse, error)
+	// AddRoleTemplateGrants adds grants to a Role Template. The provided request
+	// must include the Role Template id which the grants will be added to. An
+	// error is returned if the provided id is malformed or references a non-
+	// existing resource.
+	AddRoleTemplateGrants(context.Context, *AddRoleTemplateGrantsRequest) (*AddRoleTemplateGrantsResponse, error)
+	// SetRoleTemplateGrants sets the Role Template's grants. Any existing grants
+	// on the Role Template are deleted if they are not included in this request.
+	// The provided request must include the Role Template ID on which the grants
+	// will be set. If missing, malformed, or referencing a non-existing resource,
+	// an error is returned.
+	SetRoleTemplateGrants(context.Context, *SetRoleTemplateGrantsRequest) (*SetRoleTemplateGrantsResponse, error)
+	// RemoveRoleTemplateGrants removes the grants from the specified Role
+	// Template. The provided request must include the Role Template IDs from which
+	// the grants will be removed. If missing, malformed, or references a non-
+	// existing resource, an error is returned.
+	RemoveRoleTemplateGrants(context.Context, *RemoveRoleTemplateGrantsRequest) (*RemoveRoleTemplateGrantsResponse, error)
+	mustEmbedUnimplementedRoleTemplateServiceServer()
+}
+
+// UnimplementedRoleTemplateServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRoleTemplateServiceServer struct {
+}
+
+func (UnimplementedRoleTemplateServiceServer) GetRoleTemplate(context.Context, *GetRoleTemplateRequest) (*GetRoleTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoleTemplate not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) ListRoleTemplates(context.Context, *ListRoleTemplatesRequest) (*ListRoleTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleTemplates not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) CreateRoleTemplate(context.Context, *CreateRoleTemplateRequest) (*CreateRoleTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoleTemplate not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) UpdateRoleTemplate(context.Context, *UpdateRoleTemplateRequest) (*UpdateRoleTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoleTemplate not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) DeleteRoleTemplate(context.Context, *DeleteRoleTemplateRequest) (*DeleteRoleTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoleTemplate not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) AddRoleTemplatePrincipals(context.Context, *AddRoleTemplatePrincipalsRequest) (*AddRoleTemplatePrincipalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRoleTemplatePrincipals not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) SetRoleTemplatePrincipals(context.Context, *SetRoleTemplatePrincipalsRequest) (*SetRoleTemplatePrincipalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRoleTemplatePrincipals not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) RemoveRoleTemplatePrincipals(context.Context, *RemoveRoleTemplatePrincipalsRequest) (*RemoveRoleTemplatePrincipalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleTemplatePrincipals not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) AddRoleTemplateGrants(context.Context, *AddRoleTemplateGrantsRequest) (*AddRoleTemplateGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRoleTemplateGrants not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) SetRoleTemplateGrants(context.Context, *SetRoleTemplateGrantsRequest) (*SetRoleTemplateGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRoleTemplateGrants not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) RemoveRoleTemplateGrants(context.Context, *RemoveRoleTemplateGrantsRequest) (*RemoveRoleTemplateGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleTemplateGrants not implemented")
+}
+func (UnimplementedRoleTemplateServiceServer) mustEmbedUnimplementedRoleTemplateServiceServer() {}
+
+// UnsafeRoleTemplateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RoleTemplateServiceServer will
+// result in compilation errors.
+type UnsafeRoleTemplateServiceServer interface {
+	mustEmbedUnimplementedRoleTemplateServiceServer()
+}
+
+func RegisterRoleTemplateServiceServer(s grpc.ServiceRegistrar, srv RoleTemplateServiceServer) {
+	s.RegisterService(&RoleTemplateService_ServiceDesc, srv)
+}
+
+func _RoleTemplateService_GetRoleTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoleTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).GetRoleTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleTemplateService/GetRoleTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).GetRoleTemplate(ctx, req.(*GetRoleTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_ListRoleTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).ListRoleTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleTemplateService/ListRoleTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).ListRoleTemplates(ctx, req.(*ListRoleTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_CreateRoleTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoleTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).CreateRoleTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleTemplateService/CreateRoleTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).CreateRoleTemplate(ctx, req.(*CreateRoleTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_UpdateRoleTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoleTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).UpdateRoleTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleTemplateService/UpdateRoleTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).UpdateRoleTemplate(ctx, req.(*UpdateRoleTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_DeleteRoleTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoleTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).DeleteRoleTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleTemplateService/DeleteRoleTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).DeleteRoleTemplate(ctx, req.(*DeleteRoleTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_AddRoleTemplatePrincipals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRoleTemplatePrincipalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).AddRoleTemplatePrincipals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleTemplateService/AddRoleTemplatePrincipals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).AddRoleTemplatePrincipals(ctx, req.(*AddRoleTemplatePrincipalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_SetRoleTemplatePrincipals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRoleTemplatePrincipalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).SetRoleTemplatePrincipals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleTemplateService/SetRoleTemplatePrincipals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).SetRoleTemplatePrincipals(ctx, req.(*SetRoleTemplatePrincipalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_RemoveRoleTemplatePrincipals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRoleTemplatePrincipalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).RemoveRoleTemplatePrincipals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleTemplateService/RemoveRoleTemplatePrincipals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).RemoveRoleTemplatePrincipals(ctx, req.(*RemoveRoleTemplatePrincipalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_AddRoleTemplateGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRoleTemplateGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).AddRoleTemplateGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleTemplateService/AddRoleTemplateGrants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).AddRoleTemplateGrants(ctx, req.(*AddRoleTemplateGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_SetRoleTemplateGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRoleTemplateGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).SetRoleTemplateGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleTemplateService/SetRoleTemplateGrants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).SetRoleTemplateGrants(ctx, req.(*SetRoleTemplateGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleTemplateService_RemoveRoleTemplateGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRoleTemplateGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleTemplateServiceServer).RemoveRoleTemplateGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.RoleTemplateService/RemoveRoleTemplateGrants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleTemplateServiceServer).RemoveRoleTemplateGrants(ctx, req.(*RemoveRoleTemplateGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RoleTemplateService_ServiceDesc is the grpc.ServiceDesc for RoleTemplateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RoleTemplateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "controller.api.services.v1.RoleTemplateService",
+	HandlerType: (*RoleTemplateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRoleTemplate",
+			Handler:    _RoleTemplateService_GetRoleTemplate_Handler,
+		},
+		{
+			MethodName: "ListRoleTemplates",
+			Handler:    _RoleTemplateService_ListRoleTemplates_Handler,
+		},
+		{
+			MethodName: "CreateRoleTemplate",
+			Handler:    _RoleTemplateService_CreateRoleTemplate_Handler,
+		},
+		{
+			MethodName: "UpdateRoleTemplate",
+			Handler:    _RoleTemplateService_UpdateRoleTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteRoleTemplate",
+			Handler:    _RoleTemplateService_DeleteRoleTemplate_Handler,
+		},
+		{
+			MethodName: "AddRoleTemplatePrincipals",
+			Handler:    _RoleTemplateService_AddRoleTemplatePrincipals_Handler,
+		},
+		{
+			MethodName: "SetRoleTemplatePrincipals",
+			Handler:    _RoleTemplateService_SetRoleTemplatePrincipals_Handler,
+		},
+		{
+			MethodName: "RemoveRoleTemplatePrincipals",
+			Handler:    _RoleTemplateService_RemoveRoleTemplatePrincipals_Handler,
+		},
+		{
+			MethodName: "AddRoleTemplateGrants",
+			Handler:    _RoleTemplateService_AddRoleTemplateGrants_Handler,
+		},
+		{
+			MethodName: "SetRoleTemplateGrants",
+			Handler:    _RoleTemplateService_SetRoleTemplateGrants_Handler,
+		},
+		{
+			MethodName: "RemoveRoleTemplateGrants",
+			Handler:    _RoleTemplateService_RemoveRoleTemplateGrants_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "controller/api/services/v1/role_template_service.proto",
+}
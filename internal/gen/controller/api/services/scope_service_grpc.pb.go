@@ -44,6 +44,12 @@ type ScopeServiceClient interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(ctx context.Context, in *DeleteScopeRequest, opts ...grpc.CallOption) (*DeleteScopeResponse, error)
+	// SetScopeLabels sets the labels on the Scope, which grants can match on.
+	// Any existing labels on the Scope are removed if they are not included in
+	// this request. An empty set of labels removes all labels. If the ID is
+	// missing, malformed or references a non existing resource, an error is
+	// returned.
+	SetScopeLabels(ctx context.Context, in *SetScopeLabelsRequest, opts ...grpc.CallOption) (*SetScopeLabelsResponse, error)
 }
 
 type scopeServiceClient struct {
@@ -99,6 +105,15 @@ func (c *scopeServiceClient) DeleteScope(ctx context.Context, in *DeleteScopeReq
 	return out, nil
 }
 
+func (c *scopeServiceClient) SetScopeLabels(ctx context.Context, in *SetScopeLabelsRequest, opts ...grpc.CallOption) (*SetScopeLabelsResponse, error) {
+	out := new(SetScopeLabelsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/SetScopeLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ScopeServiceServer is the server API for ScopeService service.
 // All implementations must embed UnimplementedScopeServiceServer
 // for forward compatibility
@@ -129,6 +144,12 @@ type ScopeServiceServer interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error)
+	// SetScopeLabels sets the labels on the Scope, which grants can match on.
+	// Any existing labels on the Scope are removed if they are not included in
+	// this request. An empty set of labels removes all labels. If the ID is
+	// missing, malformed or references a non existing resource, an error is
+	// returned.
+	SetScopeLabels(context.Context, *SetScopeLabelsRequest) (*SetScopeLabelsResponse, error)
 	mustEmbedUnimplementedScopeServiceServer()
 }
 
@@ -151,6 +172,9 @@ func (UnimplementedScopeServiceServer) UpdateScope(context.Context, *UpdateScope
 func (UnimplementedScopeServiceServer) DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteScope not implemented")
 }
+func (UnimplementedScopeServiceServer) SetScopeLabels(context.Context, *SetScopeLabelsRequest) (*SetScopeLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetScopeLabels not implemented")
+}
 func (UnimplementedScopeServiceServer) mustEmbedUnimplementedScopeServiceServer() {}
 
 // UnsafeScopeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -254,6 +278,24 @@ func _ScopeService_DeleteScope_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScopeService_SetScopeLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetScopeLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).SetScopeLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/SetScopeLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).SetScopeLabels(ctx, req.(*SetScopeLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ScopeService_ServiceDesc is the grpc.ServiceDesc for ScopeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,6 +323,10 @@ var ScopeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteScope",
 			Handler:    _ScopeService_DeleteScope_Handler,
 		},
+		{
+			MethodName: "SetScopeLabels",
+			Handler:    _ScopeService_SetScopeLabels_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/scope_service.proto",
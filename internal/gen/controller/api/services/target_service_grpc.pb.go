@@ -71,6 +71,17 @@ type TargetServiceClient interface {
 	// returned.  An error is returned if a Host Set is attempted to be
 	// removed from the Target when the Target does not have the Host Set.
 	RemoveTargetHostSets(ctx context.Context, in *RemoveTargetHostSetsRequest, opts ...grpc.CallOption) (*RemoveTargetHostSetsResponse, error)
+	// SetTargetLabels sets the labels on the Target, which grants can match on.
+	// Any existing labels on the Target are removed if they are not included in
+	// this request. An empty set of labels removes all labels. If the ID is
+	// missing, malformed or references a non existing resource, an error is
+	// returned.
+	SetTargetLabels(ctx context.Context, in *SetTargetLabelsRequest, opts ...grpc.CallOption) (*SetTargetLabelsResponse, error)
+	// TransferTargetOwnership makes the provided user the owner of the Target,
+	// which grants can match on. The Target is owned by the user that created it
+	// until its ownership is transferred. If the ID is missing, malformed or
+	// references a non existing resource, an error is returned.
+	TransferTargetOwnership(ctx context.Context, in *TransferTargetOwnershipRequest, opts ...grpc.CallOption) (*TransferTargetOwnershipResponse, error)
 }
 
 type targetServiceClient struct {
@@ -162,6 +173,24 @@ func (c *targetServiceClient) RemoveTargetHostSets(ctx context.Context, in *Remo
 	return out, nil
 }
 
+func (c *targetServiceClient) SetTargetLabels(ctx context.Context, in *SetTargetLabelsRequest, opts ...grpc.CallOption) (*SetTargetLabelsResponse, error) {
+	out := new(SetTargetLabelsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/SetTargetLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *targetServiceClient) TransferTargetOwnership(ctx context.Context, in *TransferTargetOwnershipRequest, opts ...grpc.CallOption) (*TransferTargetOwnershipResponse, error) {
+	out := new(TransferTargetOwnershipResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.TargetService/TransferTargetOwnership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TargetServiceServer is the server API for TargetService service.
 // All implementations must embed UnimplementedTargetServiceServer
 // for forward compatibility
@@ -219,6 +248,17 @@ type TargetServiceServer interface {
 	// returned.  An error is returned if a Host Set is attempted to be
 	// removed from the Target when the Target does not have the Host Set.
 	RemoveTargetHostSets(context.Context, *RemoveTargetHostSetsRequest) (*RemoveTargetHostSetsResponse, error)
+	// SetTargetLabels sets the labels on the Target, which grants can match on.
+	// Any existing labels on the Target are removed if they are not included in
+	// this request. An empty set of labels removes all labels. If the ID is
+	// missing, malformed or references a non existing resource, an error is
+	// returned.
+	SetTargetLabels(context.Context, *SetTargetLabelsRequest) (*SetTargetLabelsResponse, error)
+	// TransferTargetOwnership makes the provided user the owner of the Target,
+	// which grants can match on. The Target is owned by the user that created it
+	// until its ownership is transferred. If the ID is missing, malformed or
+	// references a non existing resource, an error is returned.
+	TransferTargetOwnership(context.Context, *TransferTargetOwnershipRequest) (*TransferTargetOwnershipResponse, error)
 	mustEmbedUnimplementedTargetServiceServer()
 }
 
@@ -253,6 +293,12 @@ func (UnimplementedTargetServiceServer) SetTargetHostSets(context.Context, *SetT
 func (UnimplementedTargetServiceServer) RemoveTargetHostSets(context.Context, *RemoveTargetHostSetsRequest) (*RemoveTargetHostSetsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveTargetHostSets not implemented")
 }
+func (UnimplementedTargetServiceServer) SetTargetLabels(context.Context, *SetTargetLabelsRequest) (*SetTargetLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTargetLabels not implemented")
+}
+func (UnimplementedTargetServiceServer) TransferTargetOwnership(context.Context, *TransferTargetOwnershipRequest) (*TransferTargetOwnershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferTargetOwnership not implemented")
+}
 func (UnimplementedTargetServiceServer) mustEmbedUnimplementedTargetServiceServer() {}
 
 // UnsafeTargetServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -428,6 +474,42 @@ func _TargetService_RemoveTargetHostSets_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TargetService_SetTargetLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTargetLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).SetTargetLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/SetTargetLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).SetTargetLabels(ctx, req.(*SetTargetLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TargetService_TransferTargetOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferTargetOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TargetServiceServer).TransferTargetOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.TargetService/TransferTargetOwnership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TargetServiceServer).TransferTargetOwnership(ctx, req.(*TransferTargetOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TargetService_ServiceDesc is the grpc.ServiceDesc for TargetService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -471,6 +553,14 @@ var TargetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveTargetHostSets",
 			Handler:    _TargetService_RemoveTargetHostSets_Handler,
 		},
+		{
+			MethodName: "SetTargetLabels",
+			Handler:    _TargetService_SetTargetLabels_Handler,
+		},
+		{
+			MethodName: "TransferTargetOwnership",
+			Handler:    _TargetService_TransferTargetOwnership_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/target_service.proto",
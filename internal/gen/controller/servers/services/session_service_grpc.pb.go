@@ -23,6 +23,9 @@ type SessionServiceClient interface {
 	LookupSession(ctx context.Context, in *LookupSessionRequest, opts ...grpc.CallOption) (*LookupSessionResponse, error)
 	// ActivateSession allows a worker to activate a session on a controller.
 	ActivateSession(ctx context.Context, in *ActivateSessionRequest, opts ...grpc.CallOption) (*ActivateSessionResponse, error)
+	// ResumeSession allows a worker to take over an active session activated
+	// by another worker, e.g. one which has been restarted.
+	ResumeSession(ctx context.Context, in *ResumeSessionRequest, opts ...grpc.CallOption) (*ResumeSessionResponse, error)
 	// CancelSession allows a worker to request that the controller cancel a session.
 	CancelSession(ctx context.Context, in *CancelSessionRequest, opts ...grpc.CallOption) (*CancelSessionResponse, error)
 	// AuthorizeConnection allows a worker to activate a session on a controller.
@@ -31,6 +34,10 @@ type SessionServiceClient interface {
 	ConnectConnection(ctx context.Context, in *ConnectConnectionRequest, opts ...grpc.CallOption) (*ConnectConnectionResponse, error)
 	// CloseConnections updates a connection to set it to closed
 	CloseConnection(ctx context.Context, in *CloseConnectionRequest, opts ...grpc.CallOption) (*CloseConnectionResponse, error)
+	// ReportSshHostKey reports the SSH host key presented by a session's
+	// host, pinning it if no key is pinned for the host yet, and returns
+	// the pinned key.
+	ReportSshHostKey(ctx context.Context, in *ReportSshHostKeyRequest, opts ...grpc.CallOption) (*ReportSshHostKeyResponse, error)
 }
 
 type sessionServiceClient struct {
@@ -59,6 +66,15 @@ func (c *sessionServiceClient) ActivateSession(ctx context.Context, in *Activate
 	return out, nil
 }
 
+func (c *sessionServiceClient) ResumeSession(ctx context.Context, in *ResumeSessionRequest, opts ...grpc.CallOption) (*ResumeSessionResponse, error) {
+	out := new(ResumeSessionResponse)
+	err := c.cc.Invoke(ctx, "/controller.servers.services.v1.SessionService/ResumeSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *sessionServiceClient) CancelSession(ctx context.Context, in *CancelSessionRequest, opts ...grpc.CallOption) (*CancelSessionResponse, error) {
 	out := new(CancelSessionResponse)
 	err := c.cc.Invoke(ctx, "/controller.servers.services.v1.SessionService/CancelSession", in, out, opts...)
@@ -95,6 +111,15 @@ func (c *sessionServiceClient) CloseConnection(ctx context.Context, in *CloseCon
 	return out, nil
 }
 
+func (c *sessionServiceClient) ReportSshHostKey(ctx context.Context, in *ReportSshHostKeyRequest, opts ...grpc.CallOption) (*ReportSshHostKeyResponse, error) {
+	out := new(ReportSshHostKeyResponse)
+	err := c.cc.Invoke(ctx, "/controller.servers.services.v1.SessionService/ReportSshHostKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionServiceServer is the server API for SessionService service.
 // All implementations must embed UnimplementedSessionServiceServer
 // for forward compatibility
@@ -104,6 +129,9 @@ type SessionServiceServer interface {
 	LookupSession(context.Context, *LookupSessionRequest) (*LookupSessionResponse, error)
 	// ActivateSession allows a worker to activate a session on a controller.
 	ActivateSession(context.Context, *ActivateSessionRequest) (*ActivateSessionResponse, error)
+	// ResumeSession allows a worker to take over an active session activated
+	// by another worker, e.g. one which has been restarted.
+	ResumeSession(context.Context, *ResumeSessionRequest) (*ResumeSessionResponse, error)
 	// CancelSession allows a worker to request that the controller cancel a session.
 	CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error)
 	// AuthorizeConnection allows a worker to activate a session on a controller.
@@ -112,6 +140,10 @@ type SessionServiceServer interface {
 	ConnectConnection(context.Context, *ConnectConnectionRequest) (*ConnectConnectionResponse, error)
 	// CloseConnections updates a connection to set it to closed
 	CloseConnection(context.Context, *CloseConnectionRequest) (*CloseConnectionResponse, error)
+	// ReportSshHostKey reports the SSH host key presented by a session's
+	// host, pinning it if no key is pinned for the host yet, and returns
+	// the pinned key.
+	ReportSshHostKey(context.Context, *ReportSshHostKeyRequest) (*ReportSshHostKeyResponse, error)
 	mustEmbedUnimplementedSessionServiceServer()
 }
 
@@ -125,6 +157,9 @@ func (UnimplementedSessionServiceServer) LookupSession(context.Context, *LookupS
 func (UnimplementedSessionServiceServer) ActivateSession(context.Context, *ActivateSessionRequest) (*ActivateSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ActivateSession not implemented")
 }
+func (UnimplementedSessionServiceServer) ResumeSession(context.Context, *ResumeSessionRequest) (*ResumeSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSession not implemented")
+}
 func (UnimplementedSessionServiceServer) CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelSession not implemented")
 }
@@ -137,6 +172,9 @@ func (UnimplementedSessionServiceServer) ConnectConnection(context.Context, *Con
 func (UnimplementedSessionServiceServer) CloseConnection(context.Context, *CloseConnectionRequest) (*CloseConnectionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CloseConnection not implemented")
 }
+func (UnimplementedSessionServiceServer) ReportSshHostKey(context.Context, *ReportSshHostKeyRequest) (*ReportSshHostKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportSshHostKey not implemented")
+}
 func (UnimplementedSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {}
 
 // UnsafeSessionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -186,6 +224,24 @@ func _SessionService_ActivateSession_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_ResumeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).ResumeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.servers.services.v1.SessionService/ResumeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).ResumeSession(ctx, req.(*ResumeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SessionService_CancelSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CancelSessionRequest)
 	if err := dec(in); err != nil {
@@ -258,6 +314,24 @@ func _SessionService_CloseConnection_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_ReportSshHostKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportSshHostKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).ReportSshHostKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.servers.services.v1.SessionService/ReportSshHostKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).ReportSshHostKey(ctx, req.(*ReportSshHostKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SessionService_ServiceDesc is the grpc.ServiceDesc for SessionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -273,6 +347,10 @@ var SessionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ActivateSession",
 			Handler:    _SessionService_ActivateSession_Handler,
 		},
+		{
+			MethodName: "ResumeSession",
+			Handler:    _SessionService_ResumeSession_Handler,
+		},
 		{
 			MethodName: "CancelSession",
 			Handler:    _SessionService_CancelSession_Handler,
@@ -289,6 +367,10 @@ var SessionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CloseConnection",
 			Handler:    _SessionService_CloseConnection_Handler,
 		},
+		{
+			MethodName: "ReportSshHostKey",
+			Handler:    _SessionService_ReportSshHostKey_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/servers/services/v1/session_service.proto",
@@ -19,6 +19,7 @@ type options struct {
 	withLimit       int
 	withAddress     string
 	withPublicId    string
+	withOwnerId     string
 }
 
 func getDefaultOptions() options {
@@ -65,3 +66,11 @@ func WithPublicId(id string) Option {
 		o.withPublicId = id
 	}
 }
+
+// WithOwnerId provides an optional user to own a host or host set being
+// created.
+func WithOwnerId(id string) Option {
+	return func(o *options) {
+		o.withOwnerId = id
+	}
+}
@@ -38,4 +38,10 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withPublicId = "test"
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithOwnerId", func(t *testing.T) {
+		opts := getOpts(WithOwnerId("u_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withOwnerId = "u_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	dbcommon "github.com/hashicorp/boundary/internal/db/common"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
 )
@@ -15,7 +16,8 @@ import (
 // CreateHost inserts h into the repository and returns a new Host
 // containing the host's PublicId. h is not changed. h must contain a valid
 // CatalogId. h must not contain a PublicId. The PublicId is generated and
-// assigned by this method. opt is ignored.
+// assigned by this method. WithPublicId and WithOwnerId are the only
+// supported options.
 //
 // h must contain a valid Address.
 //
@@ -76,6 +78,11 @@ func (r *Repository) CreateHost(ctx context.Context, scopeId string, h *Host, op
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
+			if opts.withOwnerId != "" {
+				if _, err := iam.CreateResourceOwnerTx(ctx, w, oplogWrapper, scopeId, newHost.PublicId, opts.withOwnerId); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
 			return nil
 		},
 	)
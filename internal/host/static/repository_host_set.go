@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	dbcommon "github.com/hashicorp/boundary/internal/db/common"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
 )
@@ -15,7 +16,8 @@ import (
 // CreateSet inserts s into the repository and returns a new HostSet
 // containing the host set's PublicId. s is not changed. s must contain a
 // valid CatalogId. s must not contain a PublicId. The PublicId is
-// generated and assigned by this method. opt is ignored.
+// generated and assigned by this method. WithPublicId and WithOwnerId are
+// the only supported options.
 //
 // Both s.Name and s.Description are optional. If s.Name is set, it must be
 // unique within s.CatalogId.
@@ -70,6 +72,11 @@ func (r *Repository) CreateSet(ctx context.Context, scopeId string, s *HostSet,
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
+			if opts.withOwnerId != "" {
+				if _, err := iam.CreateResourceOwnerTx(ctx, w, oplogWrapper, scopeId, newHostSet.PublicId, opts.withOwnerId); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
 			return nil
 		},
 	)
@@ -0,0 +1,154 @@
+package static
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// LookupSshHostKey will look up the SSH host key pinned for hostId in the
+// repository. If no key is pinned, it will return nil, nil. All options are
+// ignored.
+func (r *Repository) LookupSshHostKey(ctx context.Context, hostId string, opt ...Option) (*HostSshHostKey, error) {
+	const op = "static.(Repository).LookupSshHostKey"
+	if hostId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "no host id")
+	}
+	k := allocHostSshHostKey()
+	if err := r.reader.LookupWhere(ctx, k, "host_id = ?", hostId); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", hostId)))
+	}
+	return k, nil
+}
+
+// ReportSshHostKey checks hostKey, the SSH host key in the SSH wire format
+// presented by hostId, against the key pinned for the host. If no key is
+// pinned, hostKey is pinned. If hostKey doesn't match the pinned key, it's
+// recorded as the host's pending key. It returns the host's key, which is
+// not changed on a mismatch, and the result of the check. All options are
+// ignored.
+func (r *Repository) ReportSshHostKey(ctx context.Context, scopeId string, hostId string, hostKey []byte, opt ...Option) (*HostSshHostKey, SshHostKeyStatus, error) {
+	const op = "static.(Repository).ReportSshHostKey"
+	if scopeId == "" {
+		return nil, "", errors.New(errors.InvalidParameter, op, "no scope id")
+	}
+	presented, err := NewHostSshHostKey(hostId, hostKey)
+	if err != nil {
+		return nil, "", errors.Wrap(err, op)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, "", errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var returnedKey *HostSshHostKey
+	var status SshHostKeyStatus
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			returnedKey = allocHostSshHostKey()
+			err := reader.LookupWhere(ctx, returnedKey, "host_id = ?", hostId)
+			switch {
+			case errors.IsNotFoundError(err):
+				returnedKey = presented.clone()
+				status = SshHostKeyPinned
+				if err := w.Create(ctx, returnedKey, db.WithOplog(oplogWrapper, presented.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+					return errors.Wrap(err, op)
+				}
+				return nil
+			case err != nil:
+				return errors.Wrap(err, op)
+			}
+
+			if bytes.Equal(returnedKey.HostKey, presented.HostKey) {
+				status = SshHostKeyVerified
+				return nil
+			}
+			status = SshHostKeyMismatch
+			if bytes.Equal(returnedKey.PendingHostKey, presented.HostKey) {
+				return nil
+			}
+			returnedKey.PendingHostKey = presented.HostKey
+			returnedKey.PendingFingerprint = presented.Fingerprint
+			rowsUpdated, err := w.Update(ctx, returnedKey, []string{"PendingHostKey", "PendingFingerprint"}, nil,
+				db.WithOplog(oplogWrapper, returnedKey.oplog(oplog.OpType_OP_TYPE_UPDATE)))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, "", errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("for %s", hostId)))
+	}
+	return returnedKey, status, nil
+}
+
+// RotateSshHostKey pins the pending SSH host key of hostId in place of its
+// pinned key, and returns the host's key. fingerprint must match the
+// fingerprint of the pending key. All options are ignored.
+func (r *Repository) RotateSshHostKey(ctx context.Context, scopeId string, hostId string, fingerprint string, opt ...Option) (*HostSshHostKey, error) {
+	const op = "static.(Repository).RotateSshHostKey"
+	if scopeId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "no scope id")
+	}
+	if hostId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "no host id")
+	}
+	if fingerprint == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "no fingerprint")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var returnedKey *HostSshHostKey
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			returnedKey = allocHostSshHostKey()
+			if err := reader.LookupWhere(ctx, returnedKey, "host_id = ?", hostId); err != nil {
+				if errors.IsNotFoundError(err) {
+					return errors.New(errors.RecordNotFound, op, "no ssh host key pinned")
+				}
+				return errors.Wrap(err, op)
+			}
+			switch returnedKey.PendingFingerprint {
+			case "":
+				return errors.New(errors.InvalidParameter, op, "no pending ssh host key")
+			case fingerprint:
+			default:
+				return errors.New(errors.InvalidParameter, op, "fingerprint does not match the pending ssh host key")
+			}
+			returnedKey.HostKey = returnedKey.PendingHostKey
+			returnedKey.Fingerprint = returnedKey.PendingFingerprint
+			returnedKey.PendingHostKey = nil
+			returnedKey.PendingFingerprint = ""
+			rowsUpdated, err := w.Update(ctx, returnedKey, []string{"HostKey", "Fingerprint"}, []string{"PendingHostKey", "PendingFingerprint"},
+				db.WithOplog(oplogWrapper, returnedKey.oplog(oplog.OpType_OP_TYPE_UPDATE)))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("for %s", hostId)))
+	}
+	return returnedKey, nil
+}
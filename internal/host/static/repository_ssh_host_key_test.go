@@ -0,0 +1,129 @@
+package static
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func testSshHostKey(t *testing.T) []byte {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pk, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	return pk.Marshal()
+}
+
+func TestRepository_ReportSshHostKey(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	catalog := TestCatalogs(t, conn, prj.PublicId, 1)[0]
+	host := TestHosts(t, conn, catalog.PublicId, 1)[0]
+
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+
+	_, _, err = repo.ReportSshHostKey(ctx, prj.PublicId, host.PublicId, []byte("not a key"))
+	assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+
+	got, err := repo.LookupSshHostKey(ctx, host.PublicId)
+	require.NoError(err)
+	assert.Nil(got)
+
+	first := testSshHostKey(t)
+	got, status, err := repo.ReportSshHostKey(ctx, prj.PublicId, host.PublicId, first)
+	require.NoError(err)
+	assert.Equal(SshHostKeyPinned, status)
+	assert.Equal(first, got.HostKey)
+	firstFingerprint, err := SshHostKeyFingerprint(first)
+	require.NoError(err)
+	assert.Equal(firstFingerprint, got.Fingerprint)
+
+	got, status, err = repo.ReportSshHostKey(ctx, prj.PublicId, host.PublicId, first)
+	require.NoError(err)
+	assert.Equal(SshHostKeyVerified, status)
+	assert.Equal(first, got.HostKey)
+	assert.Empty(got.PendingHostKey)
+
+	second := testSshHostKey(t)
+	secondFingerprint, err := SshHostKeyFingerprint(second)
+	require.NoError(err)
+	got, status, err = repo.ReportSshHostKey(ctx, prj.PublicId, host.PublicId, second)
+	require.NoError(err)
+	assert.Equal(SshHostKeyMismatch, status)
+	assert.Equal(first, got.HostKey)
+	assert.Equal(second, got.PendingHostKey)
+	assert.Equal(secondFingerprint, got.PendingFingerprint)
+
+	got, err = repo.LookupSshHostKey(ctx, host.PublicId)
+	require.NoError(err)
+	assert.Equal(first, got.HostKey)
+	assert.Equal(secondFingerprint, got.PendingFingerprint)
+}
+
+func TestRepository_RotateSshHostKey(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	catalog := TestCatalogs(t, conn, prj.PublicId, 1)[0]
+	host := TestHosts(t, conn, catalog.PublicId, 1)[0]
+
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+
+	_, err = repo.RotateSshHostKey(ctx, prj.PublicId, host.PublicId, "SHA256:abc")
+	assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "want err: %q got: %q", errors.RecordNotFound, err)
+
+	first := testSshHostKey(t)
+	_, _, err = repo.ReportSshHostKey(ctx, prj.PublicId, host.PublicId, first)
+	require.NoError(err)
+	firstFingerprint, err := SshHostKeyFingerprint(first)
+	require.NoError(err)
+
+	_, err = repo.RotateSshHostKey(ctx, prj.PublicId, host.PublicId, firstFingerprint)
+	assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+
+	second := testSshHostKey(t)
+	_, _, err = repo.ReportSshHostKey(ctx, prj.PublicId, host.PublicId, second)
+	require.NoError(err)
+	secondFingerprint, err := SshHostKeyFingerprint(second)
+	require.NoError(err)
+
+	_, err = repo.RotateSshHostKey(ctx, prj.PublicId, host.PublicId, firstFingerprint)
+	assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+
+	got, err := repo.RotateSshHostKey(ctx, prj.PublicId, host.PublicId, secondFingerprint)
+	require.NoError(err)
+	assert.Equal(second, got.HostKey)
+	assert.Equal(secondFingerprint, got.Fingerprint)
+	assert.Empty(got.PendingHostKey)
+	assert.Empty(got.PendingFingerprint)
+
+	got, status, err := repo.ReportSshHostKey(ctx, prj.PublicId, host.PublicId, second)
+	require.NoError(err)
+	assert.Equal(SshHostKeyVerified, status)
+	assert.Empty(got.PendingFingerprint)
+}
@@ -0,0 +1,101 @@
+package static
+
+import (
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/proto"
+)
+
+// SshHostKeyStatus is the result of checking the SSH host key presented by a
+// host against the key pinned for it.
+type SshHostKeyStatus string
+
+const (
+	// SshHostKeyPinned means the host had no pinned key and the presented
+	// key has been pinned.
+	SshHostKeyPinned SshHostKeyStatus = "pinned"
+	// SshHostKeyVerified means the presented key matches the pinned key.
+	SshHostKeyVerified SshHostKeyStatus = "verified"
+	// SshHostKeyMismatch means the presented key doesn't match the pinned
+	// key. It's recorded as the host's pending key until it's rotated in.
+	SshHostKeyMismatch SshHostKeyStatus = "mismatch"
+)
+
+// A HostSshHostKey is the SSH host key pinned for a host, and the key the
+// host presented in its place, if any.
+type HostSshHostKey struct {
+	*store.HostSshHostKey
+	tableName string `gorm:"-"`
+}
+
+// NewHostSshHostKey creates a new in memory HostSshHostKey pinning hostKey,
+// in the SSH wire format, for hostId. All options are ignored.
+func NewHostSshHostKey(hostId string, hostKey []byte, opt ...Option) (*HostSshHostKey, error) {
+	const op = "static.NewHostSshHostKey"
+	if hostId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "no host id")
+	}
+	fingerprint, err := SshHostKeyFingerprint(hostKey)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	k := &HostSshHostKey{
+		HostSshHostKey: &store.HostSshHostKey{
+			HostId:      hostId,
+			HostKey:     hostKey,
+			Fingerprint: fingerprint,
+		},
+	}
+	return k, nil
+}
+
+// SshHostKeyFingerprint returns the SHA256 fingerprint of hostKey, which is
+// in the SSH wire format.
+func SshHostKeyFingerprint(hostKey []byte) (string, error) {
+	const op = "static.SshHostKeyFingerprint"
+	if len(hostKey) == 0 {
+		return "", errors.New(errors.InvalidParameter, op, "no host key")
+	}
+	pk, err := ssh.ParsePublicKey(hostKey)
+	if err != nil {
+		return "", errors.New(errors.InvalidParameter, op, "unable to parse host key", errors.WithWrap(err))
+	}
+	return ssh.FingerprintSHA256(pk), nil
+}
+
+// TableName returns the table name for the host key.
+func (k *HostSshHostKey) TableName() string {
+	if k.tableName != "" {
+		return k.tableName
+	}
+	return "static_host_ssh_host_key"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (k *HostSshHostKey) SetTableName(n string) {
+	k.tableName = n
+}
+
+func allocHostSshHostKey() *HostSshHostKey {
+	return &HostSshHostKey{
+		HostSshHostKey: &store.HostSshHostKey{},
+	}
+}
+
+func (k *HostSshHostKey) clone() *HostSshHostKey {
+	cp := proto.Clone(k.HostSshHostKey)
+	return &HostSshHostKey{
+		HostSshHostKey: cp.(*store.HostSshHostKey),
+	}
+}
+
+func (k *HostSshHostKey) oplog(op oplog.OpType) oplog.Metadata {
+	return oplog.Metadata{
+		"resource-public-id": []string{k.HostId},
+		"resource-type":      []string{"static-host-ssh-host-key"},
+		"op-type":            []string{op.String()},
+	}
+}
@@ -2,6 +2,7 @@ package iam
 
 import (
 	"context"
+	"strings"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -9,26 +10,28 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-// MemberType defines the possible membership types for groups. We don't surface
-// this in the API as of yet as it's always user and we don't have plans for
-// others currently.
+// MemberType defines the possible membership types for groups: users and
+// child groups.
 type MemberType uint32
 
 const (
 	UnknownMemberType MemberType = 0
 	UserMemberType    MemberType = 1
+	GroupMemberType   MemberType = 2
 )
 
 func (m MemberType) String() string {
 	return [...]string{
 		"unknown",
 		"user",
+		"group",
 	}[m]
 }
 
 const (
 	groupMemberViewDefaultTableName = "iam_group_member"
 	groupMemberUserDefaultTable     = "iam_group_member_user"
+	groupMemberGroupDefaultTable    = "iam_group_member_group"
 )
 
 // GroupMember provides a common way to return members.
@@ -129,3 +132,100 @@ func (m *GroupMemberUser) SetTableName(n string) {
 		m.tableName = n
 	}
 }
+
+// GroupMemberGroup is a group member that's a Group. The members of the child
+// group are members of the group as well.
+type GroupMemberGroup struct {
+	*store.GroupMemberGroup
+	tableName string `gorm:"-"`
+}
+
+// ensure that GroupMemberGroup implements the interfaces of: Cloneable,
+// db.VetForWriter
+var (
+	_ Cloneable       = (*GroupMemberGroup)(nil)
+	_ db.VetForWriter = (*GroupMemberGroup)(nil)
+)
+
+// NewGroupMemberGroup creates a new in memory child group member of the group.
+// No options are currently supported.
+func NewGroupMemberGroup(groupId, memberGroupId string, _ ...Option) (*GroupMemberGroup, error) {
+	const op = "iam.NewGroupMemberGroup"
+	if groupId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing group id")
+	}
+	if memberGroupId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing member group id")
+	}
+	if groupId == memberGroupId {
+		return nil, errors.New(errors.InvalidParameter, op, "group cannot be a member of itself")
+	}
+	return &GroupMemberGroup{
+		GroupMemberGroup: &store.GroupMemberGroup{
+			MemberId: memberGroupId,
+			GroupId:  groupId,
+		},
+	}, nil
+}
+
+// Clone creates a clone of the GroupMemberGroup
+func (m *GroupMemberGroup) Clone() interface{} {
+	cp := proto.Clone(m.GroupMemberGroup)
+	return &GroupMemberGroup{
+		GroupMemberGroup: cp.(*store.GroupMemberGroup),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface for group members.
+func (m *GroupMemberGroup) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	const op = "iam.(GroupMemberGroup).VetForWrite"
+	if m.GroupId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing group id")
+	}
+	if m.MemberId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing member id")
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (m *GroupMemberGroup) TableName() string {
+	if m.tableName != "" {
+		return m.tableName
+	}
+	return groupMemberGroupDefaultTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage interface
+func (m *GroupMemberGroup) SetTableName(n string) {
+	switch n {
+	case "":
+		m.tableName = groupMemberGroupDefaultTable
+	default:
+		m.tableName = n
+	}
+}
+
+// newGroupMembers creates in memory members of the group for the member IDs,
+// which are user or group IDs. Users and child groups are returned separately
+// since they're written to different tables.
+func newGroupMembers(groupId string, memberIds []string) (users []interface{}, groups []interface{}, err error) {
+	const op = "iam.newGroupMembers"
+	for _, id := range memberIds {
+		switch {
+		case strings.HasPrefix(id, GroupPrefix+"_"):
+			gm, err := NewGroupMemberGroup(groupId, id)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, op)
+			}
+			groups = append(groups, gm)
+		default:
+			gm, err := NewGroupMemberUser(groupId, id)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, op)
+			}
+			users = append(users, gm)
+		}
+	}
+	return users, groups, nil
+}
@@ -434,3 +434,101 @@ func TestGroupMemberUser_SetTableName(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupMemberGroup_SetTableName(t *testing.T) {
+	defaultTableName := groupMemberGroupDefaultTable
+	tests := []struct {
+		name        string
+		initialName string
+		setNameTo   string
+		want        string
+	}{
+		{
+			name:        "new-name",
+			initialName: "",
+			setNameTo:   "new-name",
+			want:        "new-name",
+		},
+		{
+			name:        "reset to default",
+			initialName: "initial",
+			setNameTo:   "",
+			want:        defaultTableName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			def := &GroupMemberGroup{
+				GroupMemberGroup: &store.GroupMemberGroup{},
+			}
+			require.Equal(defaultTableName, def.TableName())
+			s := &GroupMemberGroup{
+				GroupMemberGroup: &store.GroupMemberGroup{},
+				tableName:        tt.initialName,
+			}
+			s.SetTableName(tt.setNameTo)
+			assert.Equal(tt.want, s.TableName())
+		})
+	}
+}
+
+func Test_NewGroupMemberGroup(t *testing.T) {
+	tests := []struct {
+		name          string
+		groupId       string
+		memberGroupId string
+		wantErr       bool
+	}{
+		{
+			name:          "valid",
+			groupId:       "g_1234567890",
+			memberGroupId: "g_0987654321",
+		},
+		{
+			name:          "missing-group",
+			memberGroupId: "g_0987654321",
+			wantErr:       true,
+		},
+		{
+			name:    "missing-member-group",
+			groupId: "g_1234567890",
+			wantErr: true,
+		},
+		{
+			name:          "member-of-itself",
+			groupId:       "g_1234567890",
+			memberGroupId: "g_1234567890",
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewGroupMemberGroup(tt.groupId, tt.memberGroupId)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.groupId, got.GetGroupId())
+			assert.Equal(tt.memberGroupId, got.GetMemberId())
+		})
+	}
+}
+
+func Test_newGroupMembers(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	users, groups, err := newGroupMembers("g_1234567890", []string{"u_1234567890", "g_0987654321", "u_0987654321"})
+	require.NoError(err)
+	require.Len(users, 2)
+	require.Len(groups, 1)
+	assert.Equal("u_1234567890", users[0].(*GroupMemberUser).GetMemberId())
+	assert.Equal("u_0987654321", users[1].(*GroupMemberUser).GetMemberId())
+	assert.Equal("g_0987654321", groups[0].(*GroupMemberGroup).GetMemberId())
+
+	_, _, err = newGroupMembers("g_1234567890", []string{"g_1234567890"})
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+}
@@ -9,10 +9,11 @@ import (
 )
 
 const (
-	UserPrefix      = "u"
-	GroupPrefix     = "g"
-	RolePrefix      = "r"
-	RoleGrantPrefix = "rg"
+	UserPrefix         = "u"
+	GroupPrefix        = "g"
+	RolePrefix         = "r"
+	RoleGrantPrefix    = "rg"
+	RoleTemplatePrefix = "rt"
 )
 
 func newRoleId() (string, error) {
@@ -23,6 +24,14 @@ func newRoleId() (string, error) {
 	return id, nil
 }
 
+func newRoleTemplateId() (string, error) {
+	id, err := db.NewPublicId(RoleTemplatePrefix)
+	if err != nil {
+		return "", errors.Wrap(err, "iam.newRoleTemplateId")
+	}
+	return id, nil
+}
+
 func newUserId() (string, error) {
 	id, err := db.NewPublicId(UserPrefix)
 	if err != nil {
@@ -16,6 +16,11 @@ func Test_PublicIds(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, strings.HasPrefix(id, RolePrefix+"_"))
 	})
+	t.Run("role template", func(t *testing.T) {
+		id, err := newRoleTemplateId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, RoleTemplatePrefix+"_"))
+	})
 	t.Run("user", func(t *testing.T) {
 		id, err := newUserId()
 		require.NoError(t, err)
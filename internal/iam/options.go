@@ -29,6 +29,7 @@ type options struct {
 	withRandomReader            io.Reader
 	withAccountIds              []string
 	withPrimaryAuthMethodId     string
+	withOwnerId                 string
 }
 
 func getDefaultOptions() options {
@@ -141,3 +142,11 @@ func WithPrimaryAuthMethodId(id string) Option {
 		o.withPrimaryAuthMethodId = id
 	}
 }
+
+// WithOwnerId provides an option to specify the user owning a group when
+// creating it.
+func WithOwnerId(id string) Option {
+	return func(o *options) {
+		o.withOwnerId = id
+	}
+}
@@ -72,7 +72,12 @@ const (
 	  select public_id
 		from iam_user
 	   where
-	   	public_id in (%s)
+	   	public_id in (%[1]s)
+	   union
+	  select public_id
+		from iam_group
+	   where
+	   	public_id in (%[1]s)
 	),
 	current_members (member_id) as (
 	  -- returns the current list
@@ -108,4 +113,43 @@ const (
 	select * from final
 	order by action, member_id;
 	`
+
+	grpMemberCycleQuery = `
+	with recursive
+	nested_groups (group_id) as (
+	  -- returns the groups being added and every group nested in them
+	  select public_id
+		from iam_group
+	   where
+	   	public_id in (%s)
+	   union
+	  select iam_group_member_group.member_id
+		from iam_group_member_group
+	   inner
+		join nested_groups
+		  on iam_group_member_group.group_id = nested_groups.group_id
+	)
+	select count(*)
+	  from nested_groups
+	 where group_id = $1;
+	`
+
+	grpResolvedMembersQuery = `
+	with recursive
+	nested_groups (group_id) as (
+	  -- returns the group and every group nested in it
+	  select $1::text
+	   union
+	  select iam_group_member_group.member_id
+		from iam_group_member_group
+	   inner
+		join nested_groups
+		  on iam_group_member_group.group_id = nested_groups.group_id
+	)
+	select member_id, member_scope_id, group_id
+	  from iam_group_member
+	 where type = 'user'
+	   and group_id in (select group_id from nested_groups)
+	 order by member_id, group_id;
+	`
 )
@@ -62,9 +62,11 @@ func (r *Repository) list(ctx context.Context, resources interface{}, where stri
 	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit))
 }
 
-// create will create a new iam resource in the db repository with an oplog entry
-func (r *Repository) create(ctx context.Context, resource Resource, _ ...Option) (Resource, error) {
+// create will create a new iam resource in the db repository with an oplog
+// entry.  WithOwnerId is the only supported option.
+func (r *Repository) create(ctx context.Context, resource Resource, opt ...Option) (Resource, error) {
 	const op = "iam.(Repository).create"
+	opts := getOpts(opt...)
 	if resource == nil {
 		return nil, errors.New(errors.InvalidParameter, op, "missing resource")
 	}
@@ -102,6 +104,11 @@ func (r *Repository) create(ctx context.Context, resource Resource, _ ...Option)
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
+			if opts.withOwnerId != "" {
+				if _, err := CreateResourceOwnerTx(ctx, w, oplogWrapper, scope.GetPublicId(), resource.GetPublicId(), opts.withOwnerId); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
 			return nil
 		},
 	)
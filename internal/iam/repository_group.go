@@ -13,8 +13,8 @@ import (
 )
 
 // CreateGroup will create a group in the repository and return the written
-// group.  No options are currently supported.
-func (r *Repository) CreateGroup(ctx context.Context, group *Group, _ ...Option) (*Group, error) {
+// group.  WithOwnerId is the only supported option.
+func (r *Repository) CreateGroup(ctx context.Context, group *Group, opt ...Option) (*Group, error) {
 	const op = "iam.(Repository).CreateGroup"
 	if group == nil {
 		return nil, errors.New(errors.InvalidParameter, op, "missing group")
@@ -34,7 +34,7 @@ func (r *Repository) CreateGroup(ctx context.Context, group *Group, _ ...Option)
 	}
 	g := group.Clone().(*Group)
 	g.PublicId = id
-	resource, err := r.create(ctx, g)
+	resource, err := r.create(ctx, g, opt...)
 	if err != nil {
 		if errors.IsUniqueError(err) {
 			return nil, errors.New(errors.NotUnique, op, fmt.Sprintf("group %s already exists in scope %s", group.Name, group.ScopeId))
@@ -201,13 +201,13 @@ func (r *Repository) ListGroupMembers(ctx context.Context, withGroupId string, o
 // (groupId).  The group's current db version must match the groupVersion or an
 // error will be returned.  Zero is not a valid value for the WithVersion option
 // and will return an error.
-func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupVersion uint32, userIds []string, _ ...Option) ([]*GroupMember, error) {
+func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupVersion uint32, memberIds []string, _ ...Option) ([]*GroupMember, error) {
 	const op = "iam.(Repository).AddGroupMembers"
 	if groupId == "" {
 		return nil, errors.New(errors.InvalidParameter, op, "missing group id")
 	}
-	if len(userIds) == 0 {
-		return nil, errors.New(errors.InvalidParameter, op, "missing user ids")
+	if len(memberIds) == 0 {
+		return nil, errors.New(errors.InvalidParameter, op, "missing member ids")
 	}
 	if groupVersion == 0 {
 		return nil, errors.New(errors.InvalidParameter, op, "missing version")
@@ -219,13 +219,9 @@ func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupV
 		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to get group members %s scope", groupId)))
 	}
 
-	newGroupMembers := make([]interface{}, 0, len(userIds))
-	for _, id := range userIds {
-		gm, err := NewGroupMemberUser(groupId, id)
-		if err != nil {
-			return nil, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group member"))
-		}
-		newGroupMembers = append(newGroupMembers, gm)
+	newUserMembers, newGroupMembers, err := newGroupMembers(groupId, memberIds)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group member"))
 	}
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
@@ -256,11 +252,19 @@ func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupV
 				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("updated group and %d rows updated", rowsUpdated))
 			}
 			msgs = append(msgs, &groupOplogMsg)
-			memberOplogMsgs := make([]*oplog.Message, 0, len(newGroupMembers))
-			if err := w.CreateItems(ctx, newGroupMembers, db.NewOplogMsgs(&memberOplogMsgs)); err != nil {
-				return errors.Wrap(err, op, errors.WithMsg("unable to add users"))
+			if err := groupMemberCycle(ctx, reader, groupId, newGroupMembers); err != nil {
+				return errors.Wrap(err, op)
+			}
+			for _, members := range [][]interface{}{newUserMembers, newGroupMembers} {
+				if len(members) == 0 {
+					continue
+				}
+				memberOplogMsgs := make([]*oplog.Message, 0, len(members))
+				if err := w.CreateItems(ctx, members, db.NewOplogMsgs(&memberOplogMsgs)); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to add members"))
+				}
+				msgs = append(msgs, memberOplogMsgs...)
 			}
-			msgs = append(msgs, memberOplogMsgs...)
 			metadata := oplog.Metadata{
 				"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
 				"scope-id":           []string{scope.PublicId},
@@ -291,15 +295,16 @@ func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupV
 	return currentMembers, nil
 }
 
-// DeleteGroupMembers (userIds) from a group (groupId). The group's current db version
-// must match the groupVersion or an error will be returned. Zero is not a valid
-// value for the WithVersion option and will return an error.
-func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, groupVersion uint32, userIds []string, _ ...Option) (int, error) {
+// DeleteGroupMembers (memberIds, which are user or group ids) from a group
+// (groupId). The group's current db version must match the groupVersion or an
+// error will be returned. Zero is not a valid value for the WithVersion option
+// and will return an error.
+func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, groupVersion uint32, memberIds []string, _ ...Option) (int, error) {
 	const op = "iam.(Repository).DeleteGroupMembers"
 	if groupId == "" {
 		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing group id")
 	}
-	if len(userIds) == 0 {
+	if len(memberIds) == 0 {
 		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing either user or groups to delete")
 	}
 	if groupVersion == 0 {
@@ -312,13 +317,9 @@ func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, gro
 		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to get group members %s scope", groupId)))
 	}
 
-	deleteMembers := make([]interface{}, 0, len(userIds))
-	for _, id := range userIds {
-		member, err := NewGroupMemberUser(groupId, id)
-		if err != nil {
-			return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group member"))
-		}
-		deleteMembers = append(deleteMembers, member)
+	deleteUserMembers, deleteGroupMembers, err := newGroupMembers(groupId, memberIds)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group member"))
 	}
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
@@ -349,16 +350,21 @@ func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, gro
 				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("updated group and %d rows updated", rowsUpdated))
 			}
 			msgs = append(msgs, &groupOplogMsg)
-			userOplogMsgs := make([]*oplog.Message, 0, len(deleteMembers))
-			rowsDeleted, err := w.DeleteItems(ctx, deleteMembers, db.NewOplogMsgs(&userOplogMsgs))
-			if err != nil {
-				return errors.Wrap(err, op, errors.WithMsg("unable to delete group members"))
-			}
-			if rowsDeleted != len(deleteMembers) {
-				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("group members deleted %d did not match request for %d", rowsDeleted, len(deleteMembers)))
+			for _, deleteMembers := range [][]interface{}{deleteUserMembers, deleteGroupMembers} {
+				if len(deleteMembers) == 0 {
+					continue
+				}
+				memberOplogMsgs := make([]*oplog.Message, 0, len(deleteMembers))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteMembers, db.NewOplogMsgs(&memberOplogMsgs))
+				if err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to delete group members"))
+				}
+				if rowsDeleted != len(deleteMembers) {
+					return errors.New(errors.MultipleRecords, op, fmt.Sprintf("group members deleted %d did not match request for %d", rowsDeleted, len(deleteMembers)))
+				}
+				totalRowsDeleted += rowsDeleted
+				msgs = append(msgs, memberOplogMsgs...)
 			}
-			totalRowsDeleted += rowsDeleted
-			msgs = append(msgs, userOplogMsgs...)
 			metadata := oplog.Metadata{
 				"op-type":            []string{oplog.OpType_OP_TYPE_DELETE.String()},
 				"scope-id":           []string{scope.PublicId},
@@ -377,10 +383,10 @@ func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, gro
 	return totalRowsDeleted, nil
 }
 
-// SetGroupMembers will set the group's members.  If userIds is empty, the
-// members will be cleared. Zero is not a valid value for the WithVersion option
-// and will return an error.
-func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupVersion uint32, userIds []string, _ ...Option) ([]*GroupMember, int, error) {
+// SetGroupMembers will set the group's members, which are user or group ids.
+// If memberIds is empty, the members will be cleared. Zero is not a valid value
+// for the WithVersion option and will return an error.
+func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupVersion uint32, memberIds []string, _ ...Option) ([]*GroupMember, int, error) {
 	const op = "iam.(Repository).SetGroupMembers"
 	if groupId == "" {
 		return nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing group id")
@@ -415,7 +421,7 @@ func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupV
 				// intentionally not setting the defaultLimit, so we'll get all
 				// the members without a limit
 			}
-			addMembers, deleteMembers, err := groupMemberChanges(ctx, reader, groupId, userIds)
+			addMembers, deleteMembers, err := groupMemberChanges(ctx, reader, groupId, memberIds)
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
@@ -454,27 +460,41 @@ func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupV
 				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("updated group and %d rows updated", rowsUpdated))
 			}
 			if len(deleteMembers) > 0 {
-				userOplogMsgs := make([]*oplog.Message, 0, len(deleteMembers))
-				rowsDeleted, err := w.DeleteItems(ctx, deleteMembers, db.NewOplogMsgs(&userOplogMsgs))
-				if err != nil {
-					return errors.Wrap(err, op, errors.WithMsg("unable to delete group member"))
+				deleteUserMembers, deleteGroupMembers := splitGroupMembers(deleteMembers)
+				for _, members := range [][]interface{}{deleteUserMembers, deleteGroupMembers} {
+					if len(members) == 0 {
+						continue
+					}
+					memberOplogMsgs := make([]*oplog.Message, 0, len(members))
+					rowsDeleted, err := w.DeleteItems(ctx, members, db.NewOplogMsgs(&memberOplogMsgs))
+					if err != nil {
+						return errors.Wrap(err, op, errors.WithMsg("unable to delete group member"))
+					}
+					if rowsDeleted != len(members) {
+						return errors.New(errors.MultipleRecords, op, fmt.Sprintf("members deleted %d did not match request for %d", rowsDeleted, len(members)))
+					}
+					totalRowsAffected += rowsDeleted
+					msgs = append(msgs, memberOplogMsgs...)
 				}
-				if rowsDeleted != len(deleteMembers) {
-					return errors.New(errors.MultipleRecords, op, fmt.Sprintf("members deleted %d did not match request for %d", rowsDeleted, len(deleteMembers)))
-				}
-				totalRowsAffected += rowsDeleted
-				msgs = append(msgs, userOplogMsgs...)
 				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
 			}
 			if len(addMembers) > 0 {
-				userOplogMsgs := make([]*oplog.Message, 0, len(addMembers))
-				if err := w.CreateItems(ctx, addMembers, db.NewOplogMsgs(&userOplogMsgs)); err != nil {
-					return errors.Wrap(err, op, errors.WithMsg("unable to add users"))
+				addUserMembers, addGroupMembers := splitGroupMembers(addMembers)
+				if err := groupMemberCycle(ctx, reader, groupId, addGroupMembers); err != nil {
+					return errors.Wrap(err, op)
+				}
+				for _, members := range [][]interface{}{addUserMembers, addGroupMembers} {
+					if len(members) == 0 {
+						continue
+					}
+					memberOplogMsgs := make([]*oplog.Message, 0, len(members))
+					if err := w.CreateItems(ctx, members, db.NewOplogMsgs(&memberOplogMsgs)); err != nil {
+						return errors.Wrap(err, op, errors.WithMsg("unable to add members"))
+					}
+					totalRowsAffected += len(members)
+					msgs = append(msgs, memberOplogMsgs...)
 				}
-				totalRowsAffected += len(addMembers)
-				msgs = append(msgs, userOplogMsgs...)
 				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
-
 			}
 			// we're done with all the membership writes, so let's write the
 			// group's update oplog message
@@ -494,11 +514,11 @@ func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupV
 }
 
 // groupMemberChanges returns two slices: members to add and delete
-func groupMemberChanges(ctx context.Context, reader db.Reader, groupId string, userIds []string) ([]interface{}, []interface{}, error) {
+func groupMemberChanges(ctx context.Context, reader db.Reader, groupId string, memberIds []string) ([]interface{}, []interface{}, error) {
 	const op = "iam.groupMemberChanges"
 	var inClauseSpots []string
 	// starts at 2 because there is already a $1 in the query
-	for i := 2; i < len(userIds)+2; i++ {
+	for i := 2; i < len(memberIds)+2; i++ {
 		inClauseSpots = append(inClauseSpots, fmt.Sprintf("$%d", i))
 	}
 	inClause := strings.Join(inClauseSpots, ",")
@@ -509,7 +529,7 @@ func groupMemberChanges(ctx context.Context, reader db.Reader, groupId string, u
 
 	var params []interface{}
 	params = append(params, groupId)
-	for _, v := range userIds {
+	for _, v := range memberIds {
 		params = append(params, v)
 	}
 	rows, err := reader.Query(ctx, query, params)
@@ -534,21 +554,21 @@ func groupMemberChanges(ctx context.Context, reader db.Reader, groupId string, u
 	deleteMembers := []interface{}{}
 	for _, c := range changes {
 		if c.MemberId == "" {
-			return nil, nil, errors.New(errors.InvalidParameter, op, "missing user id in change result")
+			return nil, nil, errors.New(errors.InvalidParameter, op, "missing member id in change result")
 		}
 		switch c.Action {
 		case "add":
-			gm, err := NewGroupMemberUser(groupId, c.MemberId)
+			users, groups, err := newGroupMembers(groupId, []string{c.MemberId})
 			if err != nil {
 				return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group member for add"))
 			}
-			addMembers = append(addMembers, gm)
+			addMembers = append(append(addMembers, users...), groups...)
 		case "delete":
-			gm, err := NewGroupMemberUser(groupId, c.MemberId)
+			users, groups, err := newGroupMembers(groupId, []string{c.MemberId})
 			if err != nil {
 				return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group member for delete"))
 			}
-			deleteMembers = append(deleteMembers, gm)
+			deleteMembers = append(append(deleteMembers, users...), groups...)
 		default:
 			return nil, nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("unknown action %s for %s", c.Action, c.MemberId))
 		}
@@ -556,3 +576,100 @@ func groupMemberChanges(ctx context.Context, reader db.Reader, groupId string, u
 	}
 	return addMembers, deleteMembers, nil
 }
+
+// splitGroupMembers splits in memory group members into user and child group
+// members, since they're written to different tables
+func splitGroupMembers(members []interface{}) (users []interface{}, groups []interface{}) {
+	for _, m := range members {
+		switch m.(type) {
+		case *GroupMemberGroup:
+			groups = append(groups, m)
+		default:
+			users = append(users, m)
+		}
+	}
+	return users, groups
+}
+
+// groupMemberCycle returns an error if adding the child groups as members of
+// the group would make the group, transitively, a member of itself
+func groupMemberCycle(ctx context.Context, reader db.Reader, groupId string, groups []interface{}) error {
+	const op = "iam.groupMemberCycle"
+	if len(groups) == 0 {
+		return nil
+	}
+	inClauseSpots := make([]string, 0, len(groups))
+	params := make([]interface{}, 0, len(groups)+1)
+	params = append(params, groupId)
+	for i, g := range groups {
+		// starts at 2 because there is already a $1 in the query
+		inClauseSpots = append(inClauseSpots, fmt.Sprintf("$%d", i+2))
+		params = append(params, g.(*GroupMemberGroup).GetMemberId())
+	}
+	query := fmt.Sprintf(grpMemberCycleQuery, strings.Join(inClauseSpots, ","))
+	rows, err := reader.Query(ctx, query, params)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	var count int
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+	if count > 0 {
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("adding the groups as members of group %s would make it a member of itself", groupId))
+	}
+	return nil
+}
+
+// ResolvedGroupMember is a user that's a member of a group, either directly or
+// through the groups nested in it.
+type ResolvedGroupMember struct {
+	// MemberId is the id of the user
+	MemberId string
+	// MemberScopeId is the scope id of the user
+	MemberScopeId string
+	// GroupIds are the ids of the groups the user is a direct member of, which
+	// are the group itself or groups nested in it
+	GroupIds []string
+}
+
+// ListResolvedGroupMembers returns the users that are members of the group
+// (withGroupId), either directly or through the groups nested in it, sorted by
+// their id.
+func (r *Repository) ListResolvedGroupMembers(ctx context.Context, withGroupId string, _ ...Option) ([]*ResolvedGroupMember, error) {
+	const op = "iam.(Repository).ListResolvedGroupMembers"
+	if withGroupId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing group id")
+	}
+	rows, err := r.reader.Query(ctx, grpResolvedMembersQuery, []interface{}{withGroupId})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+
+	type member struct {
+		MemberId      string
+		MemberScopeId string
+		GroupId       string
+	}
+	var members []*ResolvedGroupMember
+	for rows.Next() {
+		var m member
+		if err := r.reader.ScanRows(rows, &m); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		// Rows are sorted by member id, so each member's groups are together
+		if len(members) == 0 || members[len(members)-1].MemberId != m.MemberId {
+			members = append(members, &ResolvedGroupMember{
+				MemberId:      m.MemberId,
+				MemberScopeId: m.MemberScopeId,
+			})
+		}
+		last := members[len(members)-1]
+		last.GroupIds = append(last.GroupIds, m.GroupId)
+	}
+	return members, nil
+}
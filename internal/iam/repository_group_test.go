@@ -1157,3 +1157,95 @@ func TestRepository_SetGroupMembers(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_AddGroupMembers_NestedGroups(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	user := TestUser(t, repo, org.PublicId)
+	parent := TestGroup(t, conn, org.PublicId)
+	child := TestGroup(t, conn, proj.PublicId)
+	grandchild := TestGroup(t, conn, proj.PublicId)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.AddGroupMembers(context.Background(), parent.PublicId, parent.Version, []string{user.PublicId, child.PublicId})
+		require.NoError(err)
+		require.Len(got, 2)
+		gotTypes := map[string]string{}
+		for _, m := range got {
+			gotTypes[m.MemberId] = m.Type
+		}
+		assert.Equal(UserMemberType.String(), gotTypes[user.PublicId])
+		assert.Equal(GroupMemberType.String(), gotTypes[child.PublicId])
+
+		_, err = repo.AddGroupMembers(context.Background(), child.PublicId, child.Version, []string{grandchild.PublicId})
+		require.NoError(err)
+	})
+	t.Run("itself", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		g, _, err := repo.LookupGroup(context.Background(), grandchild.PublicId)
+		require.NoError(err)
+		_, err = repo.AddGroupMembers(context.Background(), g.PublicId, g.Version, []string{g.PublicId})
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("cycle", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		g, _, err := repo.LookupGroup(context.Background(), grandchild.PublicId)
+		require.NoError(err)
+		_, err = repo.AddGroupMembers(context.Background(), g.PublicId, g.Version, []string{parent.PublicId})
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+		_, _, err = repo.SetGroupMembers(context.Background(), g.PublicId, g.Version, []string{parent.PublicId})
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestRepository_ListResolvedGroupMembers(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	parent := TestGroup(t, conn, org.PublicId)
+	child := TestGroup(t, conn, proj.PublicId)
+	grandchild := TestGroup(t, conn, proj.PublicId)
+	u1 := TestUser(t, repo, org.PublicId)
+	u2 := TestUser(t, repo, org.PublicId)
+	TestGroupMember(t, conn, parent.PublicId, u1.PublicId)
+	TestGroupMember(t, conn, grandchild.PublicId, u1.PublicId)
+	TestGroupMember(t, conn, grandchild.PublicId, u2.PublicId)
+	TestGroupMemberGroup(t, conn, parent.PublicId, child.PublicId)
+	TestGroupMemberGroup(t, conn, child.PublicId, grandchild.PublicId)
+
+	t.Run("nested", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListResolvedGroupMembers(context.Background(), parent.PublicId)
+		require.NoError(err)
+		gotMembers := map[string][]string{}
+		for _, m := range got {
+			assert.Equal(org.PublicId, m.MemberScopeId)
+			gotMembers[m.MemberId] = m.GroupIds
+		}
+		require.Len(gotMembers, 2)
+		assert.ElementsMatch([]string{parent.PublicId, grandchild.PublicId}, gotMembers[u1.PublicId])
+		assert.ElementsMatch([]string{grandchild.PublicId}, gotMembers[u2.PublicId])
+	})
+	t.Run("leaf", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.ListResolvedGroupMembers(context.Background(), grandchild.PublicId)
+		require.NoError(err)
+		assert.Len(got, 2)
+	})
+	t.Run("missing-group-id", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := repo.ListResolvedGroupMembers(context.Background(), "")
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
@@ -0,0 +1,98 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/perms"
+)
+
+const defaultResourceLabelTable = "resource_label"
+
+// resourceLabel is a key/value label on a scope, target, host catalog, host
+// set or host.
+type resourceLabel struct {
+	ResourceId string `gorm:"primary_key"`
+	Key        string `gorm:"primary_key"`
+	Value      string
+}
+
+// TableName returns the table name for resource labels.
+func (l *resourceLabel) TableName() string {
+	return defaultResourceLabelTable
+}
+
+// SetResourceLabels sets the labels on the resource to exactly the given
+// labels, removing any labels not given; an empty map removes all labels. The
+// resource must be a scope, target, host catalog, host set or host. The labels
+// on the resource are returned.
+func (r *Repository) SetResourceLabels(ctx context.Context, resourceId string, labels map[string]string, _ ...Option) (map[string]string, error) {
+	const op = "iam.(Repository).SetResourceLabels"
+	if resourceId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing resource id")
+	}
+	// Explicitly set to empty clears, but treat nil as a mistake
+	if labels == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing labels")
+	}
+	newLabels := make([]interface{}, 0, len(labels))
+	for k, v := range labels {
+		if err := perms.ValidateLabel(k, v); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		newLabels = append(newLabels, &resourceLabel{
+			ResourceId: resourceId,
+			Key:        k,
+			Value:      v,
+		})
+	}
+
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if _, err := w.Exec(ctx, "delete from resource_label where resource_id = $1", []interface{}{resourceId}); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to delete labels"))
+			}
+			if len(newLabels) == 0 {
+				return nil
+			}
+			if err := w.CreateItems(ctx, newLabels); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to add labels"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to set labels on %s", resourceId)))
+	}
+	ret := make(map[string]string, len(labels))
+	for k, v := range labels {
+		ret[k] = v
+	}
+	return ret, nil
+}
+
+// ListResourceLabels returns the labels on each of the resources, keyed by
+// resource ID. Resources without labels are left out.
+func (r *Repository) ListResourceLabels(ctx context.Context, resourceIds []string, _ ...Option) (map[string]map[string]string, error) {
+	const op = "iam.(Repository).ListResourceLabels"
+	ret := make(map[string]map[string]string)
+	if len(resourceIds) == 0 {
+		return ret, nil
+	}
+	var labels []*resourceLabel
+	if err := r.reader.SearchWhere(ctx, &labels, "resource_id in (?)", []interface{}{resourceIds}); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to lookup labels"))
+	}
+	for _, l := range labels {
+		if ret[l.ResourceId] == nil {
+			ret[l.ResourceId] = make(map[string]string)
+		}
+		ret[l.ResourceId][l.Key] = l.Value
+	}
+	return ret, nil
+}
@@ -0,0 +1,130 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_SetResourceLabels(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+
+	tests := []struct {
+		name        string
+		resourceId  string
+		labels      map[string]string
+		want        map[string]string
+		wantErr     bool
+		wantErrCode errors.Code
+	}{
+		{
+			name:       "valid",
+			resourceId: org.PublicId,
+			labels:     map[string]string{"env": "prod", "team": "db"},
+			want:       map[string]string{"env": "prod", "team": "db"},
+		},
+		{
+			name:       "replace",
+			resourceId: org.PublicId,
+			labels:     map[string]string{"env": "dev"},
+			want:       map[string]string{"env": "dev"},
+		},
+		{
+			name:       "clear",
+			resourceId: org.PublicId,
+			labels:     map[string]string{},
+		},
+		{
+			name:        "missing-resource-id",
+			labels:      map[string]string{"env": "prod"},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "nil-labels",
+			resourceId:  org.PublicId,
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "invalid-label",
+			resourceId:  org.PublicId,
+			labels:      map[string]string{"env": "prod:eu"},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:       "unknown-resource",
+			resourceId: "ttcp_1234567890",
+			labels:     map[string]string{"env": "prod"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.SetResourceLabels(context.Background(), tt.resourceId, tt.labels)
+			if tt.wantErr {
+				require.Error(err)
+				if tt.wantErrCode != 0 {
+					assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "unexpected error %s", err.Error())
+				}
+				return
+			}
+			require.NoError(err)
+			assert.Len(got, len(tt.want))
+			for k, v := range tt.want {
+				assert.Equal(v, got[k])
+			}
+
+			found, err := repo.ListResourceLabels(context.Background(), []string{tt.resourceId})
+			require.NoError(err)
+			if len(tt.want) == 0 {
+				assert.Empty(found)
+				return
+			}
+			assert.Equal(tt.want, found[tt.resourceId])
+		})
+	}
+}
+
+func TestRepository_ListResourceLabels(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	ctx := context.Background()
+
+	_, err := repo.SetResourceLabels(ctx, org.PublicId, map[string]string{"env": "prod"})
+	require.NoError(err)
+	_, err = repo.SetResourceLabels(ctx, proj.PublicId, map[string]string{"env": "dev", "team": "db"})
+	require.NoError(err)
+
+	found, err := repo.ListResourceLabels(ctx, []string{org.PublicId, proj.PublicId, "o_1234567890"})
+	require.NoError(err)
+	assert.Equal(map[string]map[string]string{
+		org.PublicId:  {"env": "prod"},
+		proj.PublicId: {"env": "dev", "team": "db"},
+	}, found)
+
+	found, err = repo.ListResourceLabels(ctx, nil)
+	require.NoError(err)
+	assert.Empty(found)
+
+	// Deleting the resource removes its labels
+	_, err = repo.DeleteScope(ctx, proj.PublicId)
+	require.NoError(err)
+	found, err = repo.ListResourceLabels(ctx, []string{proj.PublicId})
+	require.NoError(err)
+	assert.Empty(found)
+}
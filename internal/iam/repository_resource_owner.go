@@ -0,0 +1,110 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// SetResourceOwner makes the user the owner of the resource, recording the
+// change in the oplog of the resource's scope. The resource must be a target,
+// host set, host or group. No options are currently supported.
+func (r *Repository) SetResourceOwner(ctx context.Context, scopeId, resourceId, ownerId string, _ ...Option) (*ResourceOwner, error) {
+	const op = "iam.(Repository).SetResourceOwner"
+	if scopeId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	o, err := NewResourceOwner(resourceId, ownerId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var returnedOwner *ResourceOwner
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			returnedOwner = o.Clone().(*ResourceOwner)
+			current := allocResourceOwner()
+			err := reader.LookupWhere(ctx, &current, "resource_id = ?", resourceId)
+			switch {
+			case errors.IsNotFoundError(err):
+				returnedOwner, err = CreateResourceOwnerTx(ctx, w, oplogWrapper, scopeId, resourceId, ownerId)
+				if err != nil {
+					return errors.Wrap(err, op)
+				}
+				return nil
+			case err != nil:
+				return errors.Wrap(err, op, errors.WithMsg("unable to lookup current owner"))
+			case current.OwnerId == ownerId:
+				returnedOwner = &current
+				return nil
+			}
+			rowsUpdated, err := w.Update(ctx, returnedOwner, []string{"OwnerId"}, nil, db.WithOplog(oplogWrapper, returnedOwner.oplog(oplog.OpType_OP_TYPE_UPDATE, scopeId)))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsUpdated != 1 {
+				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("%d owners would have been updated", rowsUpdated))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to set owner of %s", resourceId)))
+	}
+	return returnedOwner, nil
+}
+
+// CreateResourceOwnerTx makes the user the owner of the resource, which must
+// not have an owner yet, recording the change in the oplog of the resource's
+// scope. This function encapsulates the work required within a db.TxHandler
+// so the resource can be created along with its owner by other repositories.
+func CreateResourceOwnerTx(ctx context.Context, w db.Writer, oplogWrapper wrapping.Wrapper, scopeId, resourceId, ownerId string) (*ResourceOwner, error) {
+	const op = "iam.CreateResourceOwnerTx"
+	if w == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing db writer")
+	}
+	if oplogWrapper == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing oplog wrapper")
+	}
+	if scopeId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	o, err := NewResourceOwner(resourceId, ownerId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if err := w.Create(ctx, o, db.WithOplog(oplogWrapper, o.oplog(oplog.OpType_OP_TYPE_CREATE, scopeId))); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to create owner"))
+	}
+	return o, nil
+}
+
+// ListResourceOwners returns the owner of each of the resources, keyed by
+// resource ID. Resources without an owner are left out.
+func (r *Repository) ListResourceOwners(ctx context.Context, resourceIds []string, _ ...Option) (map[string]string, error) {
+	const op = "iam.(Repository).ListResourceOwners"
+	ret := make(map[string]string)
+	if len(resourceIds) == 0 {
+		return ret, nil
+	}
+	var owners []*ResourceOwner
+	if err := r.reader.SearchWhere(ctx, &owners, "resource_id in (?)", []interface{}{resourceIds}, db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to lookup owners"))
+	}
+	for _, o := range owners {
+		ret[o.ResourceId] = o.OwnerId
+	}
+	return ret, nil
+}
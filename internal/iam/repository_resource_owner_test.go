@@ -0,0 +1,185 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_SetResourceOwner(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	grp := TestGroup(t, conn, org.PublicId)
+	u1 := TestUser(t, repo, org.PublicId)
+	u2 := TestUser(t, repo, org.PublicId)
+
+	tests := []struct {
+		name        string
+		scopeId     string
+		resourceId  string
+		ownerId     string
+		wantErr     bool
+		wantErrCode errors.Code
+	}{
+		{
+			name:       "valid",
+			scopeId:    org.PublicId,
+			resourceId: grp.PublicId,
+			ownerId:    u1.PublicId,
+		},
+		{
+			name:       "same-owner",
+			scopeId:    org.PublicId,
+			resourceId: grp.PublicId,
+			ownerId:    u1.PublicId,
+		},
+		{
+			name:       "transfer",
+			scopeId:    org.PublicId,
+			resourceId: grp.PublicId,
+			ownerId:    u2.PublicId,
+		},
+		{
+			name:        "missing-scope-id",
+			resourceId:  grp.PublicId,
+			ownerId:     u1.PublicId,
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "missing-resource-id",
+			scopeId:     org.PublicId,
+			ownerId:     u1.PublicId,
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "missing-owner-id",
+			scopeId:     org.PublicId,
+			resourceId:  grp.PublicId,
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:       "unknown-owner",
+			scopeId:    org.PublicId,
+			resourceId: grp.PublicId,
+			ownerId:    "u_1234567890",
+			wantErr:    true,
+		},
+		{
+			name:       "unownable-resource",
+			scopeId:    org.PublicId,
+			resourceId: org.PublicId,
+			ownerId:    u1.PublicId,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.SetResourceOwner(context.Background(), tt.scopeId, tt.resourceId, tt.ownerId)
+			if tt.wantErr {
+				require.Error(err)
+				if tt.wantErrCode != 0 {
+					assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "unexpected error %s", err.Error())
+				}
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.resourceId, got.ResourceId)
+			assert.Equal(tt.ownerId, got.OwnerId)
+
+			found, err := repo.ListResourceOwners(context.Background(), []string{tt.resourceId})
+			require.NoError(err)
+			assert.Equal(tt.ownerId, found[tt.resourceId])
+		})
+	}
+}
+
+func TestRepository_ListResourceOwners(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	ctx := context.Background()
+
+	orgGrp := TestGroup(t, conn, org.PublicId)
+	projGrp := TestGroup(t, conn, proj.PublicId)
+	u1 := TestUser(t, repo, org.PublicId)
+	u2 := TestUser(t, repo, org.PublicId)
+
+	_, err := repo.SetResourceOwner(ctx, org.PublicId, orgGrp.PublicId, u1.PublicId)
+	require.NoError(err)
+	_, err = repo.SetResourceOwner(ctx, proj.PublicId, projGrp.PublicId, u2.PublicId)
+	require.NoError(err)
+
+	found, err := repo.ListResourceOwners(ctx, []string{orgGrp.PublicId, projGrp.PublicId, "g_1234567890"})
+	require.NoError(err)
+	assert.Equal(map[string]string{
+		orgGrp.PublicId:  u1.PublicId,
+		projGrp.PublicId: u2.PublicId,
+	}, found)
+
+	found, err = repo.ListResourceOwners(ctx, nil)
+	require.NoError(err)
+	assert.Empty(found)
+
+	// Deleting the resource removes its owner
+	_, err = repo.DeleteGroup(ctx, projGrp.PublicId)
+	require.NoError(err)
+	found, err = repo.ListResourceOwners(ctx, []string{projGrp.PublicId})
+	require.NoError(err)
+	assert.Empty(found)
+
+	// Deleting the owner removes it from the resources it owned
+	_, err = repo.DeleteUser(ctx, u1.PublicId)
+	require.NoError(err)
+	found, err = repo.ListResourceOwners(ctx, []string{orgGrp.PublicId})
+	require.NoError(err)
+	assert.Empty(found)
+}
+
+func TestRepository_CreateGroup_WithOwnerId(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	u := TestUser(t, repo, org.PublicId)
+	ctx := context.Background()
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		grp, err := NewGroup(org.PublicId, WithName("valid"))
+		require.NoError(err)
+		got, err := repo.CreateGroup(ctx, grp, WithOwnerId(u.PublicId))
+		require.NoError(err)
+		found, err := repo.ListResourceOwners(ctx, []string{got.PublicId})
+		require.NoError(err)
+		assert.Equal(u.PublicId, found[got.PublicId])
+	})
+	t.Run("unknown-owner", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		grp, err := NewGroup(org.PublicId, WithName("unknown-owner"))
+		require.NoError(err)
+		_, err = repo.CreateGroup(ctx, grp, WithOwnerId("u_1234567890"))
+		require.Error(err)
+
+		// The group isn't created without its owner
+		grps, err := repo.ListGroups(ctx, []string{org.PublicId})
+		require.NoError(err)
+		for _, g := range grps {
+			assert.NotEqual("unknown-owner", g.Name)
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	dbcommon "github.com/hashicorp/boundary/internal/db/common"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/sdk/strutil"
 )
 
 // CreateRole will create a role in the repository and return the written
@@ -47,7 +48,8 @@ func (r *Repository) CreateRole(ctx context.Context, role *Role, _ ...Option) (*
 // updated.  Fields will be set to NULL if the field is a zero value and
 // included in fieldMask. Name, Description, and GrantScopeId are the only
 // updatable fields, If no updatable fields are included in the fieldMaskPaths,
-// then an error is returned.
+// then an error is returned. GrantScopeId can't be updated while the role has
+// grant scopes.
 func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32, fieldMaskPaths []string, _ ...Option) (*Role, []PrincipalRole, []*RoleGrant, int, error) {
 	const op = "iam.(Repository).UpdateRole"
 	if role == nil {
@@ -90,13 +92,23 @@ func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(read db.Reader, w db.Writer) error {
-			var err error
-			c := role.Clone().(*Role)
-			resource, rowsUpdated, err = r.update(ctx, c, version, dbMask, nullFields)
+			repo, err := NewRepository(read, w, r.kms)
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
-			repo, err := NewRepository(read, w, r.kms)
+			if strutil.StrListContains(dbMask, "GrantScopeId") || strutil.StrListContains(nullFields, "GrantScopeId") {
+				// The grant scope ID isn't used while the role has grant
+				// scopes, so changing it would have no effect
+				gs, err := repo.ListRoleGrantScopes(ctx, role.PublicId)
+				if err != nil {
+					return errors.Wrap(err, op)
+				}
+				if len(gs) > 0 {
+					return errors.New(errors.InvalidParameter, op, "grant scope id can't be changed while the role has grant scopes")
+				}
+			}
+			c := role.Clone().(*Role)
+			resource, rowsUpdated, err = r.update(ctx, c, version, dbMask, nullFields)
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
@@ -385,6 +385,11 @@ func (r *Repository) ListRoleGrants(ctx context.Context, roleId string, opt ...O
 	return roleGrants, nil
 }
 
+// GrantsForUser returns the grants of the roles the user is a principal of,
+// directly or through a group, paired with each scope the grants apply to and
+// the role they come from. The
+// special grant scopes of the roles are resolved to the scopes existing when
+// this is called.
 func (r *Repository) GrantsForUser(ctx context.Context, userId string, _ ...Option) ([]perms.GrantPair, error) {
 	const op = "iam.(Repository).GrantsForUser"
 	if userId == "" {
@@ -395,7 +400,7 @@ func (r *Repository) GrantsForUser(ctx context.Context, userId string, _ ...Opti
 		anonUser    = `where public_id in ($1)`
 		authUser    = `where public_id in ('u_anon', 'u_auth', $1)`
 		grantsQuery = `
-with
+with recursive
 users (id) as (
   select public_id
     from iam_user
@@ -406,6 +411,12 @@ user_groups (id) as (
     from iam_group_member_user,
          users
    where member_id in (users.id)
+   union
+  -- groups are transitively members of the groups they're nested in
+  select iam_group_member_group.group_id
+    from iam_group_member_group,
+         user_groups
+   where member_id = user_groups.id
 ),
 group_roles (role_id) as (
   select role_id
@@ -426,22 +437,68 @@ user_group_roles (role_id) as (
   select role_id
     from user_roles
 ),
-roles (role_id, grant_scope_id) as (
+roles (role_id, role_scope_id, grant_scope_id) as (
   select iam_role.public_id,
+         iam_role.scope_id,
          iam_role.grant_scope_id
     from iam_role,
          user_group_roles
    where public_id in (user_group_roles.role_id)
 ),
-final (role_scope, role_grant) as (
-  select roles.grant_scope_id,
-         iam_role_grant.canonical_grant
+role_grant_scopes (role_id, grant_scope_id) as (
+  -- roles without grant scopes apply their grants to their grant_scope_id
+  select roles.role_id,
+         roles.grant_scope_id
+    from roles
+   where not exists (
+           select 1
+             from iam_role_grant_scope
+            where iam_role_grant_scope.role_id = roles.role_id
+         )
+   union
+  select roles.role_id,
+         case iam_role_grant_scope.scope_id_or_special
+           when 'this' then roles.role_scope_id
+           else iam_role_grant_scope.scope_id_or_special
+         end
+    from roles
+   inner
+    join iam_role_grant_scope
+      on roles.role_id = iam_role_grant_scope.role_id
+   where iam_role_grant_scope.scope_id_or_special not in ('children', 'descendants')
+   union
+  select roles.role_id,
+         iam_scope.public_id
     from roles
+   inner
+    join iam_role_grant_scope
+      on roles.role_id = iam_role_grant_scope.role_id
+   inner
+    join iam_scope
+      on iam_scope.parent_id = roles.role_scope_id
+   where iam_role_grant_scope.scope_id_or_special = 'children'
+   union
+  select roles.role_id,
+         iam_scope.public_id
+    from roles
+   inner
+    join iam_role_grant_scope
+      on roles.role_id = iam_role_grant_scope.role_id
+   inner
+    join iam_scope
+      on iam_scope.public_id != 'global'
+   where iam_role_grant_scope.scope_id_or_special = 'descendants'
+),
+final (role_id, role_scope, role_grant) as (
+  select role_grant_scopes.role_id,
+         role_grant_scopes.grant_scope_id,
+         iam_role_grant.canonical_grant
+    from role_grant_scopes
    inner
     join iam_role_grant
-      on roles.role_id = iam_role_grant.role_id
+      on role_grant_scopes.role_id = iam_role_grant.role_id
 )
-select role_scope as scope_id, role_grant as grant from final;
+select role_id, role_scope as scope_id, role_grant as grant from final;
 	`
 	)
 
@@ -0,0 +1,298 @@
+package iam
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_AddRoleGrantScopes(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	role := TestRole(t, conn, org.PublicId)
+
+	type args struct {
+		roleVersion uint32
+		grantScopes []string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        []string
+		wantErr     bool
+		wantErrCode errors.Code
+	}{
+		{
+			name: "valid",
+			args: args{
+				roleVersion: 1,
+				grantScopes: []string{org.PublicId, GrantScopeChildren, GrantScopeThis},
+			},
+			want: []string{GrantScopeChildren, GrantScopeThis},
+		},
+		{
+			name: "project",
+			args: args{
+				roleVersion: 2,
+				grantScopes: []string{proj.PublicId},
+			},
+			want: []string{proj.PublicId},
+		},
+		{
+			name: "no-grant-scopes",
+			args: args{
+				roleVersion: 3,
+			},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name: "invalid-grant-scope",
+			args: args{
+				roleVersion: 3,
+				grantScopes: []string{"everything"},
+			},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name: "zero-version",
+			args: args{
+				grantScopes: []string{GrantScopeChildren},
+			},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name: "bad-version",
+			args: args{
+				roleVersion: 1000,
+				grantScopes: []string{GrantScopeChildren},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			require.NoError(conn.Where("1=1").Delete(allocRoleGrantScope()).Error)
+			got, err := repo.AddRoleGrantScopes(context.Background(), role.PublicId, tt.args.roleVersion, tt.args.grantScopes)
+			if tt.wantErr {
+				require.Error(err)
+				if tt.wantErrCode != 0 {
+					assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "unexpected error %s", err.Error())
+				}
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, grantScopeValues(got))
+
+			err = db.TestVerifyOplog(t, rw, role.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second))
+			assert.NoError(err)
+
+			found, err := repo.ListRoleGrantScopes(context.Background(), role.PublicId)
+			require.NoError(err)
+			assert.Equal(tt.want, grantScopeValues(found))
+		})
+	}
+}
+
+func TestRepository_DeleteRoleGrantScopes(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	role := TestRole(t, conn, org.PublicId)
+	ctx := context.Background()
+
+	_, err := repo.AddRoleGrantScopes(ctx, role.PublicId, role.Version, []string{GrantScopeThis, proj.PublicId})
+	require.NoError(err)
+
+	_, err = repo.DeleteRoleGrantScopes(ctx, "", 2, []string{GrantScopeThis})
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = repo.DeleteRoleGrantScopes(ctx, role.PublicId, 0, []string{GrantScopeThis})
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = repo.DeleteRoleGrantScopes(ctx, role.PublicId, 2, nil)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+	// The role's own scope ID deletes "this" and unknown grant scopes are
+	// ignored
+	deleted, err := repo.DeleteRoleGrantScopes(ctx, role.PublicId, 2, []string{org.PublicId, GrantScopeChildren})
+	require.NoError(err)
+	assert.Equal(1, deleted)
+	found, err := repo.ListRoleGrantScopes(ctx, role.PublicId)
+	require.NoError(err)
+	assert.Equal([]string{proj.PublicId}, grantScopeValues(found))
+}
+
+func TestRepository_SetRoleGrantScopes(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	role := TestRole(t, conn, "global")
+	ctx := context.Background()
+
+	_, _, err := repo.SetRoleGrantScopes(ctx, role.PublicId, role.Version, nil)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+	got, deleted, err := repo.SetRoleGrantScopes(ctx, role.PublicId, 1, []string{GrantScopeThis, GrantScopeChildren})
+	require.NoError(err)
+	assert.Equal(0, deleted)
+	assert.Equal([]string{GrantScopeChildren, GrantScopeThis}, grantScopeValues(got))
+
+	// Replacing children with descendants doesn't conflict
+	got, deleted, err = repo.SetRoleGrantScopes(ctx, role.PublicId, 2, []string{"global", GrantScopeDescendants})
+	require.NoError(err)
+	assert.Equal(1, deleted)
+	assert.Equal([]string{GrantScopeDescendants, GrantScopeThis}, grantScopeValues(got))
+
+	// Nothing to change keeps the version
+	_, deleted, err = repo.SetRoleGrantScopes(ctx, role.PublicId, 3, []string{GrantScopeDescendants, GrantScopeThis})
+	require.NoError(err)
+	assert.Equal(0, deleted)
+
+	got, deleted, err = repo.SetRoleGrantScopes(ctx, role.PublicId, 3, []string{org.PublicId})
+	require.NoError(err)
+	assert.Equal(2, deleted)
+	assert.Equal([]string{org.PublicId}, grantScopeValues(got))
+
+	got, deleted, err = repo.SetRoleGrantScopes(ctx, role.PublicId, 4, []string{})
+	require.NoError(err)
+	assert.Equal(1, deleted)
+	assert.Empty(got)
+
+	// The grant scope ID can only be changed without grant scopes
+	role.GrantScopeId = org.PublicId
+	_, _, _, _, err = repo.UpdateRole(ctx, role, 5, []string{"GrantScopeId"})
+	require.NoError(err)
+	_, err = repo.AddRoleGrantScopes(ctx, role.PublicId, 6, []string{GrantScopeThis})
+	require.NoError(err)
+	role.GrantScopeId = "global"
+	_, _, _, _, err = repo.UpdateRole(ctx, role, 7, []string{"GrantScopeId"})
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+}
+
+func TestRepository_GrantsForUser_GrantScopes(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	otherOrg, otherProj := TestScopes(t, repo)
+	user := TestUser(t, repo, org.PublicId)
+	ctx := context.Background()
+
+	// Only look at this grant, as the default roles of the scopes created by
+	// other tests grant to every user as well
+	const grant = "id=*;type=*;actions=read"
+	newRole := func(scopeId string, grantScopes ...string) *Role {
+		role := TestRole(t, conn, scopeId)
+		TestRoleGrant(t, conn, role.PublicId, grant)
+		TestUserRole(t, conn, role.PublicId, user.PublicId)
+		for _, gs := range grantScopes {
+			TestRoleGrantScope(t, conn, role.PublicId, gs)
+		}
+		return role
+	}
+	grantScopes := func() []string {
+		pairs, err := repo.GrantsForUser(ctx, user.PublicId)
+		require.NoError(err)
+		var ret []string
+		for _, p := range pairs {
+			if p.Grant == grant {
+				ret = append(ret, p.ScopeId)
+			}
+		}
+		sort.Strings(ret)
+		return ret
+	}
+	sorted := func(s ...string) []string {
+		sort.Strings(s)
+		return s
+	}
+
+	// Without grant scopes the grant scope ID is used
+	orgRole := newRole(org.PublicId)
+	require.Equal([]string{org.PublicId}, grantScopes())
+
+	// Grant scopes replace the grant scope ID
+	TestRoleGrantScope(t, conn, orgRole.PublicId, GrantScopeChildren)
+	require.Equal([]string{proj.PublicId}, grantScopes())
+
+	// Children are resolved when looking up grants
+	newProj := testProject(t, repo, org.PublicId)
+	require.Equal(sorted(proj.PublicId, newProj.PublicId), grantScopes())
+
+	TestRoleGrantScope(t, conn, orgRole.PublicId, GrantScopeThis)
+	require.Equal(sorted(org.PublicId, proj.PublicId, newProj.PublicId), grantScopes())
+
+	// Explicit scopes and descendants of a global role
+	globalRole := newRole("global", otherProj.PublicId)
+	require.Equal(sorted(org.PublicId, proj.PublicId, newProj.PublicId, otherProj.PublicId), grantScopes())
+	_, _, err := repo.SetRoleGrantScopes(ctx, globalRole.PublicId, globalRole.Version, []string{GrantScopeDescendants})
+	require.NoError(err)
+	require.Equal(sorted(
+		org.PublicId, proj.PublicId, newProj.PublicId,
+		org.PublicId, proj.PublicId, newProj.PublicId, otherOrg.PublicId, otherProj.PublicId,
+	), removeScopesNotIn(grantScopes(), org.PublicId, proj.PublicId, newProj.PublicId, otherOrg.PublicId, otherProj.PublicId))
+
+	// The pairs parse into an ACL allowing reads in the resolved scopes
+	pairs, err := repo.GrantsForUser(ctx, user.PublicId)
+	require.NoError(err)
+	var grants []perms.Grant
+	for _, p := range pairs {
+		if p.Grant != grant {
+			continue
+		}
+		g, err := perms.Parse(p.ScopeId, p.Grant)
+		require.NoError(err)
+		grants = append(grants, g)
+	}
+	acl := perms.NewACL(grants...)
+	res := perms.Resource{ScopeId: otherProj.PublicId, Id: "ttcp_1234567890", Type: resource.Target}
+	require.True(acl.Allowed(res, action.Read).Authorized)
+}
+
+// removeScopesNotIn drops the scopes created by other tests sharing the
+// database.
+func removeScopesNotIn(scopeIds []string, keep ...string) []string {
+	var ret []string
+	for _, id := range scopeIds {
+		for _, k := range keep {
+			if id == k {
+				ret = append(ret, id)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+func grantScopeValues(gs []*RoleGrantScope) []string {
+	var ret []string
+	for _, g := range gs {
+		ret = append(ret, g.ScopeIdOrSpecial)
+	}
+	sort.Strings(ret)
+	return ret
+}
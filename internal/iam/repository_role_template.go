@@ -0,0 +1,910 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// CreateRoleTemplate will create a role template in the repository and return
+// the written role template. A role is instantiated from the template in every
+// existing project below the template's scope. No options are currently
+// supported.
+func (r *Repository) CreateRoleTemplate(ctx context.Context, t *RoleTemplate, _ ...Option) (*RoleTemplate, error) {
+	const op = "iam.(Repository).CreateRoleTemplate"
+	if t == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing role template")
+	}
+	if t.RoleTemplate == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing role template store")
+	}
+	if t.PublicId != "" {
+		return nil, errors.New(errors.InvalidParameter, op, "public id not empty")
+	}
+	if t.ScopeId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	id, err := newRoleTemplateId()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	c := t.Clone().(*RoleTemplate)
+	c.PublicId = id
+
+	metadata, err := r.stdMetadata(ctx, c)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("error getting metadata"))
+	}
+	metadata["op-type"] = []string{oplog.OpType_OP_TYPE_CREATE.String()}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, c.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var returnedTemplate *RoleTemplate
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			returnedTemplate = c.Clone().(*RoleTemplate)
+			if err := w.Create(ctx, returnedTemplate, db.WithOplog(oplogWrapper, metadata)); err != nil {
+				return errors.Wrap(err, op)
+			}
+			projects, err := roleTemplateProjects(ctx, reader, returnedTemplate.ScopeId)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			for _, p := range projects {
+				projectOplogWrapper, err := r.kms.GetWrapper(ctx, p.PublicId, kms.KeyPurposeOplog)
+				if err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to get project oplog wrapper"))
+				}
+				if err := instantiateRoleTemplate(ctx, reader, w, projectOplogWrapper, returnedTemplate, nil, nil, p); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to instantiate role in project %s", p.PublicId)))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.New(errors.NotUnique, op, fmt.Sprintf("role template %s already exists in scope %s", t.Name, t.ScopeId))
+		}
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("for %s", c.PublicId)))
+	}
+	return returnedTemplate, nil
+}
+
+// UpdateRoleTemplate will update a role template in the repository and return
+// the written role template. fieldMaskPaths provides field_mask.proto paths
+// for fields that should be updated. Fields will be set to NULL if the field
+// is a zero value and included in fieldMask. Name and Description are the only
+// updatable fields, If no updatable fields are included in the fieldMaskPaths,
+// then an error is returned. The roles instantiated from the template are
+// updated to match.
+func (r *Repository) UpdateRoleTemplate(ctx context.Context, t *RoleTemplate, version uint32, fieldMaskPaths []string, _ ...Option) (*RoleTemplate, []*RoleTemplatePrincipal, []*RoleTemplateGrant, int, error) {
+	const op = "iam.(Repository).UpdateRoleTemplate"
+	if t == nil {
+		return nil, nil, nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing role template")
+	}
+	if t.RoleTemplate == nil {
+		return nil, nil, nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing role template store")
+	}
+	if t.PublicId == "" {
+		return nil, nil, nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing public id")
+	}
+	if version == 0 {
+		return nil, nil, nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing version")
+	}
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f):
+		case strings.EqualFold("description", f):
+		default:
+			return nil, nil, nil, db.NoRowsAffected, errors.New(errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"name":        t.Name,
+			"description": t.Description,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, nil, nil, db.NoRowsAffected, errors.E(errors.WithCode(errors.EmptyFieldMask), errors.WithOp(op))
+	}
+
+	metadata, err := r.stdMetadata(ctx, t)
+	if err != nil {
+		return nil, nil, nil, db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("error getting metadata"))
+	}
+	metadata["op-type"] = []string{oplog.OpType_OP_TYPE_UPDATE.String()}
+	scope, err := t.GetScope(ctx, r.reader)
+	if err != nil {
+		return nil, nil, nil, db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("unable to get scope"))
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, nil, nil, db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var returnedTemplate *RoleTemplate
+	var rowsUpdated int
+	var principals []*RoleTemplatePrincipal
+	var grants []*RoleTemplateGrant
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			returnedTemplate = t.Clone().(*RoleTemplate)
+			rowsUpdated, err = w.Update(ctx, returnedTemplate, dbMask, nullFields, db.WithOplog(oplogWrapper, metadata), db.WithVersion(&version))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			if rowsUpdated == 0 {
+				return nil
+			}
+			if err := reader.LookupByPublicId(ctx, returnedTemplate); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to lookup role template after update"))
+			}
+			principals, grants, err = r.syncRoleTemplate(ctx, reader, w, returnedTemplate)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, nil, nil, db.NoRowsAffected, errors.New(errors.NotUnique, op, fmt.Sprintf("role template %s already exists in scope %s", t.Name, t.ScopeId))
+		}
+		return nil, nil, nil, db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("for %s", t.PublicId)))
+	}
+	return returnedTemplate, principals, grants, rowsUpdated, nil
+}
+
+// LookupRoleTemplate will look up a role template in the repository. If the
+// role template is not found, it will return nil, nil.
+func (r *Repository) LookupRoleTemplate(ctx context.Context, withPublicId string, _ ...Option) (*RoleTemplate, []*RoleTemplatePrincipal, []*RoleTemplateGrant, error) {
+	const op = "iam.(Repository).LookupRoleTemplate"
+	if withPublicId == "" {
+		return nil, nil, nil, errors.New(errors.InvalidParameter, op, "missing public id")
+	}
+	t := allocRoleTemplate()
+	t.PublicId = withPublicId
+	var principals []*RoleTemplatePrincipal
+	var grants []*RoleTemplateGrant
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			if err := read.LookupByPublicId(ctx, &t); err != nil {
+				return errors.Wrap(err, op)
+			}
+			repo, err := NewRepository(read, w, r.kms)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			principals, err = repo.ListRoleTemplatePrincipals(ctx, withPublicId)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			grants, err = repo.ListRoleTemplateGrants(ctx, withPublicId)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil, nil, nil
+		}
+		return nil, nil, nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("for %s", withPublicId)))
+	}
+	return &t, principals, grants, nil
+}
+
+// DeleteRoleTemplate will delete a role template and the roles instantiated
+// from it from the repository.
+func (r *Repository) DeleteRoleTemplate(ctx context.Context, withPublicId string, _ ...Option) (int, error) {
+	const op = "iam.(Repository).DeleteRoleTemplate"
+	if withPublicId == "" {
+		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing public id")
+	}
+	t := allocRoleTemplate()
+	t.PublicId = withPublicId
+	if err := r.reader.LookupByPublicId(ctx, &t); err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", withPublicId)))
+	}
+	metadata, err := r.stdMetadata(ctx, &t)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("error getting metadata"))
+	}
+	metadata["op-type"] = []string{oplog.OpType_OP_TYPE_DELETE.String()}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, t.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var templateRoles []*RoleTemplateRole
+			if err := reader.SearchWhere(ctx, &templateRoles, "role_template_id = ?", []interface{}{withPublicId}, db.WithLimit(-1)); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to search for instantiated roles"))
+			}
+			for _, tr := range templateRoles {
+				role := allocRole()
+				role.PublicId = tr.RoleId
+				if err := reader.LookupByPublicId(ctx, &role); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to lookup instantiated role %s", tr.RoleId)))
+				}
+				project, err := role.GetScope(ctx, reader)
+				if err != nil {
+					return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to get role %s scope", tr.RoleId)))
+				}
+				projectOplogWrapper, err := r.kms.GetWrapper(ctx, project.PublicId, kms.KeyPurposeOplog)
+				if err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to get project oplog wrapper"))
+				}
+				roleMetadata := oplog.Metadata{
+					"resource-public-id": []string{role.PublicId},
+					"scope-id":           []string{project.PublicId},
+					"scope-type":         []string{project.Type},
+					"resource-type":      []string{resource.Role.String()},
+					"op-type":            []string{oplog.OpType_OP_TYPE_DELETE.String()},
+				}
+				if _, err := w.Delete(ctx, &role, db.WithOplog(projectOplogWrapper, roleMetadata)); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to delete instantiated role %s", role.PublicId)))
+				}
+			}
+			deleteTemplate := t.Clone().(*RoleTemplate)
+			rowsDeleted, err = w.Delete(ctx, deleteTemplate, db.WithOplog(oplogWrapper, metadata))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", withPublicId)))
+	}
+	return rowsDeleted, nil
+}
+
+// ListRoleTemplates lists role templates in the given scopes and supports
+// WithLimit option.
+func (r *Repository) ListRoleTemplates(ctx context.Context, withScopeIds []string, opt ...Option) ([]*RoleTemplate, error) {
+	const op = "iam.(Repository).ListRoleTemplates"
+	if len(withScopeIds) == 0 {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope ids")
+	}
+	var templates []*RoleTemplate
+	err := r.list(ctx, &templates, "scope_id in (?)", []interface{}{withScopeIds}, opt...)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return templates, nil
+}
+
+// ListRoleTemplateGrants returns the grants for the roleTemplateId and
+// supports the WithLimit option.
+func (r *Repository) ListRoleTemplateGrants(ctx context.Context, roleTemplateId string, opt ...Option) ([]*RoleTemplateGrant, error) {
+	const op = "iam.(Repository).ListRoleTemplateGrants"
+	if roleTemplateId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing role template id")
+	}
+	var grants []*RoleTemplateGrant
+	if err := r.list(ctx, &grants, "role_template_id = ?", []interface{}{roleTemplateId}, opt...); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to lookup role template grants"))
+	}
+	return grants, nil
+}
+
+// ListRoleTemplatePrincipals returns the principals for the roleTemplateId
+// and supports the WithLimit option.
+func (r *Repository) ListRoleTemplatePrincipals(ctx context.Context, roleTemplateId string, opt ...Option) ([]*RoleTemplatePrincipal, error) {
+	const op = "iam.(Repository).ListRoleTemplatePrincipals"
+	if roleTemplateId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing role template id")
+	}
+	var principals []*RoleTemplatePrincipal
+	if err := r.list(ctx, &principals, "role_template_id = ?", []interface{}{roleTemplateId}, opt...); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to lookup role template principals"))
+	}
+	return principals, nil
+}
+
+// ListRoleTemplateRoles returns the roles instantiated from the
+// roleTemplateId and supports the WithLimit option.
+func (r *Repository) ListRoleTemplateRoles(ctx context.Context, roleTemplateId string, opt ...Option) ([]*RoleTemplateRole, error) {
+	const op = "iam.(Repository).ListRoleTemplateRoles"
+	if roleTemplateId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing role template id")
+	}
+	var roles []*RoleTemplateRole
+	if err := r.list(ctx, &roles, "role_template_id = ?", []interface{}{roleTemplateId}, opt...); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to lookup role template roles"))
+	}
+	return roles, nil
+}
+
+// SetRoleTemplateGrants sets grants on a role template (roleTemplateId) and
+// on the roles instantiated from it. The role template's current db version
+// must match the roleTemplateVersion or an error will be returned. Zero is not
+// a valid value for the WithVersion option and will return an error.
+func (r *Repository) SetRoleTemplateGrants(ctx context.Context, roleTemplateId string, roleTemplateVersion uint32, grants []string, _ ...Option) ([]*RoleTemplateGrant, int, error) {
+	const op = "iam.(Repository).SetRoleTemplateGrants"
+	if roleTemplateId == "" {
+		return nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing role template id")
+	}
+	if roleTemplateVersion == 0 {
+		return nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing version")
+	}
+	// Explicitly set to zero clears, but treat nil as a mistake
+	if grants == nil {
+		return nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing grants")
+	}
+
+	// NOTE: Set calculation can safely take place out of the transaction since
+	// we are using roleTemplateVersion to ensure that we end up operating on
+	// the same set of data from this query to the final set in the
+	// transaction function
+	existing, err := r.ListRoleTemplateGrants(ctx, roleTemplateId, WithLimit(-1))
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(err, op)
+	}
+	found := map[string]*RoleTemplateGrant{}
+	for _, g := range existing {
+		found[g.CanonicalGrant] = g
+	}
+	addGrants := make([]interface{}, 0, len(grants))
+	deleteGrants := make([]interface{}, 0, len(existing))
+	for _, grant := range grants {
+		g, err := NewRoleTemplateGrant(roleTemplateId, grant)
+		if err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("unable to create in memory role template grant"))
+		}
+		if _, ok := found[g.CanonicalGrant]; ok {
+			delete(found, g.CanonicalGrant)
+			continue
+		}
+		addGrants = append(addGrants, g)
+	}
+	for _, g := range found {
+		deleteGrants = append(deleteGrants, g)
+	}
+	if len(addGrants) == 0 && len(deleteGrants) == 0 {
+		return existing, db.NoRowsAffected, nil
+	}
+
+	var currentGrants []*RoleTemplateGrant
+	var totalRowsDeleted int
+	err = r.updateRoleTemplateAssociations(ctx, roleTemplateId, roleTemplateVersion, addGrants, deleteGrants, func(reader db.Reader, w db.Writer, t *RoleTemplate, rowsDeleted int) error {
+		totalRowsDeleted = rowsDeleted
+		var err error
+		_, currentGrants, err = r.syncRoleTemplate(ctx, reader, w, t)
+		return err
+	})
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(err, op)
+	}
+	return currentGrants, totalRowsDeleted, nil
+}
+
+// SetRoleTemplatePrincipals sets the principals (user and group ids) of a role
+// template (roleTemplateId) and of the roles instantiated from it. If
+// principalIds is empty, the principals will be cleared. The role template's
+// current db version must match the roleTemplateVersion or an error will be
+// returned. Zero is not a valid value for the WithVersion option and will
+// return an error.
+func (r *Repository) SetRoleTemplatePrincipals(ctx context.Context, roleTemplateId string, roleTemplateVersion uint32, principalIds []string, _ ...Option) ([]*RoleTemplatePrincipal, int, error) {
+	const op = "iam.(Repository).SetRoleTemplatePrincipals"
+	if roleTemplateId == "" {
+		return nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing role template id")
+	}
+	if roleTemplateVersion == 0 {
+		return nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing version")
+	}
+	userIds, groupIds, err := splitPrincipals(principalIds)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(err, op)
+	}
+
+	// it's "safe" to do this lookup outside the DoTx transaction because we
+	// have a roleTemplateVersion so the principals can’t change without the
+	// version changing.
+	existing, err := r.ListRoleTemplatePrincipals(ctx, roleTemplateId, WithLimit(-1))
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(err, op)
+	}
+	found := map[string]*RoleTemplatePrincipal{}
+	for _, p := range existing {
+		found[p.PrincipalId] = p
+	}
+	var addPrincipals, deletePrincipals []interface{}
+	for _, id := range userIds {
+		if _, ok := found[id]; ok {
+			delete(found, id)
+			continue
+		}
+		p, err := NewRoleTemplateUserPrincipal(roleTemplateId, id)
+		if err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("unable to create in memory role template user principal"))
+		}
+		addPrincipals = append(addPrincipals, p)
+	}
+	for _, id := range groupIds {
+		if _, ok := found[id]; ok {
+			delete(found, id)
+			continue
+		}
+		p, err := NewRoleTemplateGroupPrincipal(roleTemplateId, id)
+		if err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("unable to create in memory role template group principal"))
+		}
+		addPrincipals = append(addPrincipals, p)
+	}
+	for _, p := range found {
+		switch p.GetType() {
+		case UserRoleType.String():
+			up, err := NewRoleTemplateUserPrincipal(roleTemplateId, p.PrincipalId)
+			if err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("unable to create in memory role template user principal"))
+			}
+			deletePrincipals = append(deletePrincipals, up)
+		case GroupRoleType.String():
+			gp, err := NewRoleTemplateGroupPrincipal(roleTemplateId, p.PrincipalId)
+			if err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("unable to create in memory role template group principal"))
+			}
+			deletePrincipals = append(deletePrincipals, gp)
+		default:
+			return nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is unknown principal type %s", p.PrincipalId, p.GetType()))
+		}
+	}
+	if len(addPrincipals) == 0 && len(deletePrincipals) == 0 {
+		return existing, db.NoRowsAffected, nil
+	}
+
+	var currentPrincipals []*RoleTemplatePrincipal
+	var totalRowsAffected int
+	err = r.updateRoleTemplateAssociations(ctx, roleTemplateId, roleTemplateVersion, addPrincipals, deletePrincipals, func(reader db.Reader, w db.Writer, t *RoleTemplate, rowsDeleted int) error {
+		totalRowsAffected = rowsDeleted + len(addPrincipals)
+		var err error
+		currentPrincipals, _, err = r.syncRoleTemplate(ctx, reader, w, t)
+		return err
+	})
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(err, op)
+	}
+	return currentPrincipals, totalRowsAffected, nil
+}
+
+// updateRoleTemplateAssociations bumps the version of the role template, as
+// it's the aggregate, adds and deletes the associated items, then calls after
+// with the updated role template within the same transaction.
+func (r *Repository) updateRoleTemplateAssociations(ctx context.Context, roleTemplateId string, roleTemplateVersion uint32, addItems, deleteItems []interface{}, after func(db.Reader, db.Writer, *RoleTemplate, int) error) error {
+	const op = "iam.(Repository).updateRoleTemplateAssociations"
+	t := allocRoleTemplate()
+	t.PublicId = roleTemplateId
+	scope, err := t.GetScope(ctx, r.reader)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to get role template %s scope", roleTemplateId)))
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			templateTicket, err := w.GetTicket(&t)
+			if err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to get ticket"))
+			}
+			updatedTemplate := allocRoleTemplate()
+			updatedTemplate.PublicId = roleTemplateId
+			updatedTemplate.Version = roleTemplateVersion + 1
+			var templateOplogMsg oplog.Message
+			rowsUpdated, err := w.Update(ctx, &updatedTemplate, []string{"Version"}, nil, db.NewOplogMsg(&templateOplogMsg), db.WithVersion(&roleTemplateVersion))
+			if err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to update role template version"))
+			}
+			if rowsUpdated != 1 {
+				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("updated role template and %d rows updated", rowsUpdated))
+			}
+			msgs := make([]*oplog.Message, 0, 1+len(addItems)+len(deleteItems))
+			msgs = append(msgs, &templateOplogMsg)
+			metadata := oplog.Metadata{
+				"op-type":            []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+				"scope-id":           []string{scope.PublicId},
+				"scope-type":         []string{scope.Type},
+				"resource-public-id": []string{roleTemplateId},
+			}
+
+			var rowsDeleted int
+			if len(deleteItems) > 0 {
+				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+				deleteOplogMsgs := make([]*oplog.Message, 0, len(deleteItems))
+				rowsDeleted, err = w.DeleteItems(ctx, deleteItems, db.NewOplogMsgs(&deleteOplogMsgs))
+				if err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to delete role template associations"))
+				}
+				if rowsDeleted != len(deleteItems) {
+					return errors.New(errors.MultipleRecords, op, fmt.Sprintf("role template associations deleted %d did not match request for %d", rowsDeleted, len(deleteItems)))
+				}
+				msgs = append(msgs, deleteOplogMsgs...)
+			}
+			if len(addItems) > 0 {
+				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+				addOplogMsgs := make([]*oplog.Message, 0, len(addItems))
+				if err := w.CreateItems(ctx, addItems, db.NewOplogMsgs(&addOplogMsgs)); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to add role template associations"))
+				}
+				msgs = append(msgs, addOplogMsgs...)
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, templateTicket, metadata, msgs); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to write oplog"))
+			}
+
+			if err := reader.LookupByPublicId(ctx, &updatedTemplate); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to lookup role template after update"))
+			}
+			if err := after(reader, w, &updatedTemplate, rowsDeleted); err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
+
+// syncRoleTemplate brings the roles instantiated from the role template in
+// line with it, and returns the principals and grants of the role template.
+// It must be called within a transaction.
+func (r *Repository) syncRoleTemplate(ctx context.Context, reader db.Reader, w db.Writer, t *RoleTemplate) ([]*RoleTemplatePrincipal, []*RoleTemplateGrant, error) {
+	const op = "iam.(Repository).syncRoleTemplate"
+	var grants []*RoleTemplateGrant
+	if err := reader.SearchWhere(ctx, &grants, "role_template_id = ?", []interface{}{t.PublicId}, db.WithLimit(-1)); err != nil {
+		return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to search for role template grants"))
+	}
+	var principals []*RoleTemplatePrincipal
+	if err := reader.SearchWhere(ctx, &principals, "role_template_id = ?", []interface{}{t.PublicId}, db.WithLimit(-1)); err != nil {
+		return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to search for role template principals"))
+	}
+	var templateRoles []*RoleTemplateRole
+	if err := reader.SearchWhere(ctx, &templateRoles, "role_template_id = ?", []interface{}{t.PublicId}, db.WithLimit(-1)); err != nil {
+		return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to search for instantiated roles"))
+	}
+	for _, tr := range templateRoles {
+		role := allocRole()
+		role.PublicId = tr.RoleId
+		if err := reader.LookupByPublicId(ctx, &role); err != nil {
+			return nil, nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to lookup instantiated role %s", tr.RoleId)))
+		}
+		project, err := role.GetScope(ctx, reader)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to get role %s scope", tr.RoleId)))
+		}
+		projectOplogWrapper, err := r.kms.GetWrapper(ctx, project.PublicId, kms.KeyPurposeOplog)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to get project oplog wrapper"))
+		}
+		if err := reconcileRoleTemplateRole(ctx, reader, w, projectOplogWrapper, t, grants, principals, &role, project); err != nil {
+			return nil, nil, errors.Wrap(err, op)
+		}
+	}
+	return principals, grants, nil
+}
+
+// instantiateRoleTemplates instantiates a role in the new project from each of
+// the role templates of the scopes above it. It must be called within a
+// transaction.
+func instantiateRoleTemplates(ctx context.Context, reader db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, project *Scope) error {
+	const op = "iam.instantiateRoleTemplates"
+	var templates []*RoleTemplate
+	if err := reader.SearchWhere(ctx, &templates, "scope_id in (?)", []interface{}{[]string{scope.Global.String(), project.ParentId}}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to search for role templates"))
+	}
+	for _, t := range templates {
+		var grants []*RoleTemplateGrant
+		if err := reader.SearchWhere(ctx, &grants, "role_template_id = ?", []interface{}{t.PublicId}, db.WithLimit(-1)); err != nil {
+			return errors.Wrap(err, op, errors.WithMsg("unable to search for role template grants"))
+		}
+		var principals []*RoleTemplatePrincipal
+		if err := reader.SearchWhere(ctx, &principals, "role_template_id = ?", []interface{}{t.PublicId}, db.WithLimit(-1)); err != nil {
+			return errors.Wrap(err, op, errors.WithMsg("unable to search for role template principals"))
+		}
+		if err := instantiateRoleTemplate(ctx, reader, w, oplogWrapper, t, grants, principals, project); err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to instantiate role template %s", t.PublicId)))
+		}
+	}
+	return nil
+}
+
+// instantiateRoleTemplate creates a role from the role template in the
+// project and records it as instantiated from the template, then sets the
+// template's grants and principals on it.
+func instantiateRoleTemplate(ctx context.Context, reader db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, t *RoleTemplate, grants []*RoleTemplateGrant, principals []*RoleTemplatePrincipal, project *Scope) error {
+	const op = "iam.instantiateRoleTemplate"
+	role, err := NewRole(project.PublicId)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("error instantiating new role"))
+	}
+	role.PublicId, err = newRoleId()
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("error generating public id for new role"))
+	}
+	role.Name = roleTemplateRoleName(t)
+	role.Description = roleTemplateRoleDescription(t)
+
+	roleTicket, err := w.GetTicket(role)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to get ticket"))
+	}
+	msgs := make([]*oplog.Message, 0, 2)
+	var roleOplogMsg oplog.Message
+	if err := w.Create(ctx, role, db.NewOplogMsg(&roleOplogMsg)); err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("error creating role"))
+	}
+	msgs = append(msgs, &roleOplogMsg)
+	templateRole, err := NewRoleTemplateRole(t.PublicId, role.PublicId)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to create in memory role template role"))
+	}
+	var templateRoleOplogMsg oplog.Message
+	if err := w.Create(ctx, templateRole, db.NewOplogMsg(&templateRoleOplogMsg)); err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("error recording role template role"))
+	}
+	msgs = append(msgs, &templateRoleOplogMsg)
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{role.PublicId},
+		"scope-id":           []string{project.PublicId},
+		"scope-type":         []string{project.Type},
+		"resource-type":      []string{resource.Role.String()},
+		"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+	}
+	if err := w.WriteOplogEntryWith(ctx, oplogWrapper, roleTicket, metadata, msgs); err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to write oplog"))
+	}
+
+	if err := reconcileRoleTemplateRole(ctx, reader, w, oplogWrapper, t, grants, principals, role, project); err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
+
+// reconcileRoleTemplateRole brings the name, description, grants and
+// principals of a role instantiated from the role template in line with the
+// template. The grants are instantiated with the values of the project the
+// role is in.
+func reconcileRoleTemplateRole(ctx context.Context, reader db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, t *RoleTemplate, grants []*RoleTemplateGrant, principals []*RoleTemplatePrincipal, role *Role, project *Scope) error {
+	const op = "iam.reconcileRoleTemplateRole"
+
+	// Grants
+	var existingGrants []*RoleGrant
+	if err := reader.SearchWhere(ctx, &existingGrants, "role_id = ?", []interface{}{role.PublicId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to search for role grants"))
+	}
+	foundGrants := map[string]*RoleGrant{}
+	for _, rg := range existingGrants {
+		foundGrants[rg.CanonicalGrant] = rg
+	}
+	var addGrants, deleteGrants []interface{}
+	for _, g := range grants {
+		rg, err := NewRoleGrant(role.PublicId, instantiateRoleTemplateGrant(g.RawGrant, project.PublicId, project.ParentId))
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg("unable to create in memory role grant"))
+		}
+		if _, ok := foundGrants[rg.CanonicalGrant]; ok {
+			delete(foundGrants, rg.CanonicalGrant)
+			continue
+		}
+		addGrants = append(addGrants, rg)
+	}
+	for _, rg := range foundGrants {
+		deleteGrants = append(deleteGrants, rg)
+	}
+
+	// Principals
+	var existingPrincipals []PrincipalRole
+	if err := reader.SearchWhere(ctx, &existingPrincipals, "role_id = ?", []interface{}{role.PublicId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to search for role principals"))
+	}
+	foundPrincipals := map[string]PrincipalRole{}
+	for _, p := range existingPrincipals {
+		foundPrincipals[p.PrincipalId] = p
+	}
+	var addPrincipals, deletePrincipals []interface{}
+	for _, p := range principals {
+		if _, ok := foundPrincipals[p.PrincipalId]; ok {
+			delete(foundPrincipals, p.PrincipalId)
+			continue
+		}
+		pr, err := newRoleTemplatePrincipalRole(role.PublicId, p.PrincipalId, p.GetType())
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		addPrincipals = append(addPrincipals, pr)
+	}
+	for _, p := range foundPrincipals {
+		pr, err := newRoleTemplatePrincipalRole(role.PublicId, p.PrincipalId, p.GetType())
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		deletePrincipals = append(deletePrincipals, pr)
+	}
+
+	// Name and description
+	var dbMask, nullFields []string
+	name := roleTemplateRoleName(t)
+	if role.Name != name {
+		if name == "" {
+			nullFields = append(nullFields, "Name")
+		} else {
+			dbMask = append(dbMask, "Name")
+		}
+	}
+	description := roleTemplateRoleDescription(t)
+	if role.Description != description {
+		dbMask = append(dbMask, "Description")
+	}
+
+	if len(dbMask) == 0 && len(nullFields) == 0 && len(addGrants) == 0 && len(deleteGrants) == 0 && len(addPrincipals) == 0 && len(deletePrincipals) == 0 {
+		return nil
+	}
+
+	roleTicket, err := w.GetTicket(role)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to get ticket"))
+	}
+	// We need to update the role version as that's the aggregate
+	updatedRole := allocRole()
+	updatedRole.PublicId = role.PublicId
+	updatedRole.Version = role.Version + 1
+	updatedRole.Name = name
+	updatedRole.Description = description
+	dbMask = append(dbMask, "Version")
+	var roleOplogMsg oplog.Message
+	rowsUpdated, err := w.Update(ctx, &updatedRole, dbMask, nullFields, db.NewOplogMsg(&roleOplogMsg), db.WithVersion(&role.Version))
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to update role"))
+	}
+	if rowsUpdated != 1 {
+		return errors.New(errors.MultipleRecords, op, fmt.Sprintf("updated role and %d rows updated", rowsUpdated))
+	}
+	msgs := make([]*oplog.Message, 0, 1+len(addGrants)+len(deleteGrants)+len(addPrincipals)+len(deletePrincipals))
+	msgs = append(msgs, &roleOplogMsg)
+	metadata := oplog.Metadata{
+		"op-type":            []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+		"scope-id":           []string{project.PublicId},
+		"scope-type":         []string{project.Type},
+		"resource-public-id": []string{role.PublicId},
+	}
+	for _, items := range [][]interface{}{deleteGrants, deletePrincipals} {
+		if len(items) == 0 {
+			continue
+		}
+		deleteOplogMsgs := make([]*oplog.Message, 0, len(items))
+		rowsDeleted, err := w.DeleteItems(ctx, items, db.NewOplogMsgs(&deleteOplogMsgs))
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg("unable to delete role associations"))
+		}
+		if rowsDeleted != len(items) {
+			return errors.New(errors.MultipleRecords, op, fmt.Sprintf("role associations deleted %d did not match request for %d", rowsDeleted, len(items)))
+		}
+		msgs = append(msgs, deleteOplogMsgs...)
+	}
+	for _, items := range [][]interface{}{addGrants, addPrincipals} {
+		if len(items) == 0 {
+			continue
+		}
+		addOplogMsgs := make([]*oplog.Message, 0, len(items))
+		if err := w.CreateItems(ctx, items, db.NewOplogMsgs(&addOplogMsgs)); err != nil {
+			return errors.Wrap(err, op, errors.WithMsg("unable to add role associations"))
+		}
+		msgs = append(msgs, addOplogMsgs...)
+	}
+	if len(deleteGrants) > 0 || len(deletePrincipals) > 0 {
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+	}
+	if len(addGrants) > 0 || len(addPrincipals) > 0 {
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+	}
+	if err := w.WriteOplogEntryWith(ctx, oplogWrapper, roleTicket, metadata, msgs); err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to write oplog"))
+	}
+	return nil
+}
+
+// newRoleTemplatePrincipalRole returns the in memory user or group role of
+// the principal type.
+func newRoleTemplatePrincipalRole(roleId, principalId, principalType string) (interface{}, error) {
+	const op = "iam.newRoleTemplatePrincipalRole"
+	switch principalType {
+	case UserRoleType.String():
+		ur, err := NewUserRole(roleId, principalId)
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg("unable to create in memory user role"))
+		}
+		return ur, nil
+	case GroupRoleType.String():
+		gr, err := NewGroupRole(roleId, principalId)
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group role"))
+		}
+		return gr, nil
+	default:
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is unknown principal type %s", principalId, principalType))
+	}
+}
+
+// roleTemplateProjects returns the projects below the scope of a role
+// template.
+func roleTemplateProjects(ctx context.Context, reader db.Reader, scopeId string) ([]*Scope, error) {
+	const op = "iam.roleTemplateProjects"
+	var projects []*Scope
+	var err error
+	switch {
+	case scopeId == scope.Global.String():
+		err = reader.SearchWhere(ctx, &projects, "type = ?", []interface{}{scope.Project.String()}, db.WithLimit(-1))
+	default:
+		err = reader.SearchWhere(ctx, &projects, "type = ? and parent_id = ?", []interface{}{scope.Project.String(), scopeId}, db.WithLimit(-1))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to search for projects"))
+	}
+	return projects, nil
+}
+
+// roleTemplateRoleName returns the name of the roles instantiated from the
+// role template.  The template's id is part of the name so it can't conflict
+// with the default roles of a new project, or with the roles of another
+// template with the same name.
+func roleTemplateRoleName(t *RoleTemplate) string {
+	if t.Name == "" {
+		return ""
+	}
+	return fmt.Sprintf(roleTemplateInstantiatedNameFormat, t.Name, t.PublicId)
+}
+
+// roleTemplateRoleDescription returns the description of the roles
+// instantiated from the role template.
+func roleTemplateRoleDescription(t *RoleTemplate) string {
+	if t.Description != "" {
+		return t.Description
+	}
+	return fmt.Sprintf(roleTemplateInstantiatedDescriptionFormat, t.PublicId)
+}
@@ -0,0 +1,213 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateRoleTemplate(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+	org, proj := TestScopes(t, repo)
+
+	tests := []struct {
+		name      string
+		scopeId   string
+		opt       []Option
+		wantRoles int
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "global",
+			scopeId:   "global",
+			opt:       []Option{WithName("create-global")},
+			wantRoles: 1,
+		},
+		{
+			name:      "org",
+			scopeId:   org.PublicId,
+			opt:       []Option{WithName("create-org"), WithDescription("create-org")},
+			wantRoles: 1,
+		},
+		{
+			name:      "project",
+			scopeId:   proj.PublicId,
+			wantIsErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			rt, err := NewRoleTemplate(tt.scopeId, tt.opt...)
+			require.NoError(err)
+			got, err := repo.CreateRoleTemplate(ctx, rt)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(tt.wantIsErr), err))
+				return
+			}
+			require.NoError(err)
+			assert.NotEmpty(got.PublicId)
+			assert.Equal(tt.scopeId, got.ScopeId)
+
+			roles, err := repo.ListRoleTemplateRoles(ctx, got.PublicId)
+			require.NoError(err)
+			require.Len(roles, tt.wantRoles)
+			role, _, _, err := repo.LookupRole(ctx, roles[0].RoleId)
+			require.NoError(err)
+			assert.Equal(proj.PublicId, role.ScopeId)
+			assert.Equal(got.Name+" ("+got.PublicId+")", role.Name)
+		})
+	}
+	t.Run("duplicate-name", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		rt, err := NewRoleTemplate(org.PublicId, WithName("create-org"))
+		require.NoError(err)
+		_, err = repo.CreateRoleTemplate(ctx, rt)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.NotUnique), err))
+	})
+}
+
+func TestRepository_RoleTemplateRoleNames(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+	org, _ := TestScopes(t, repo)
+
+	// Templates named like a default project role, and templates with the
+	// same name in the global scope and an org, don't conflict
+	var templates []*RoleTemplate
+	for _, tt := range []struct {
+		scopeId string
+		name    string
+	}{
+		{scopeId: "global", name: "Administration"},
+		{scopeId: "global", name: "shared"},
+		{scopeId: org.PublicId, name: "shared"},
+	} {
+		rt, err := NewRoleTemplate(tt.scopeId, WithName(tt.name))
+		require.NoError(err)
+		rt, err = repo.CreateRoleTemplate(ctx, rt)
+		require.NoError(err)
+		templates = append(templates, rt)
+	}
+
+	newProj, err := NewProject(org.PublicId)
+	require.NoError(err)
+	newProj, err = repo.CreateScope(ctx, newProj, "")
+	require.NoError(err)
+	for _, rt := range templates {
+		roles, err := repo.ListRoleTemplateRoles(ctx, rt.PublicId)
+		require.NoError(err)
+		require.Len(roles, 2)
+		for _, r := range roles {
+			role, _, _, err := repo.LookupRole(ctx, r.RoleId)
+			require.NoError(err)
+			assert.Equal(rt.Name+" ("+rt.PublicId+")", role.Name)
+		}
+	}
+
+	// Renaming a template to the name of a default role doesn't conflict
+	// either
+	rt := templates[1]
+	rt.Name = "Default Grants"
+	_, _, _, rowsUpdated, err := repo.UpdateRoleTemplate(ctx, rt, rt.Version, []string{"Name"})
+	require.NoError(err)
+	assert.Equal(1, rowsUpdated)
+
+	// Another project can still be created
+	newProj, err = NewProject(org.PublicId)
+	require.NoError(err)
+	_, err = repo.CreateScope(ctx, newProj, "")
+	require.NoError(err)
+}
+
+func TestRepository_RoleTemplateLifecycle(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+	org, proj := TestScopes(t, repo)
+	user := TestUser(t, repo, org.PublicId)
+
+	rt, err := NewRoleTemplate(org.PublicId, WithName("lifecycle"))
+	require.NoError(err)
+	rt, err = repo.CreateRoleTemplate(ctx, rt)
+	require.NoError(err)
+
+	grants, _, err := repo.SetRoleTemplateGrants(ctx, rt.PublicId, rt.Version, []string{"id={{scope.id}};actions=read"})
+	require.NoError(err)
+	require.Len(grants, 1)
+	assert.Equal("id={{scope.id}};actions=read", grants[0].CanonicalGrant)
+
+	principals, _, err := repo.SetRoleTemplatePrincipals(ctx, rt.PublicId, rt.Version+1, []string{user.PublicId})
+	require.NoError(err)
+	require.Len(principals, 1)
+	assert.Equal(user.PublicId, principals[0].PrincipalId)
+
+	// The role in the existing project follows the template
+	roles, err := repo.ListRoleTemplateRoles(ctx, rt.PublicId)
+	require.NoError(err)
+	require.Len(roles, 1)
+	role, rolePrincipals, roleGrants, err := repo.LookupRole(ctx, roles[0].RoleId)
+	require.NoError(err)
+	assert.Equal(proj.PublicId, role.ScopeId)
+	require.Len(roleGrants, 1)
+	assert.Equal("id="+proj.PublicId+";actions=read", roleGrants[0].CanonicalGrant)
+	require.Len(rolePrincipals, 1)
+	assert.Equal(user.PublicId, rolePrincipals[0].PrincipalId)
+
+	// A new project gets its own role
+	newProj, err := NewProject(org.PublicId)
+	require.NoError(err)
+	newProj, err = repo.CreateScope(ctx, newProj, "")
+	require.NoError(err)
+	roles, err = repo.ListRoleTemplateRoles(ctx, rt.PublicId)
+	require.NoError(err)
+	require.Len(roles, 2)
+	var newRoleId string
+	for _, r := range roles {
+		if r.RoleId != role.PublicId {
+			newRoleId = r.RoleId
+		}
+	}
+	newRole, _, newRoleGrants, err := repo.LookupRole(ctx, newRoleId)
+	require.NoError(err)
+	assert.Equal(newProj.PublicId, newRole.ScopeId)
+	require.Len(newRoleGrants, 1)
+	assert.Equal("id="+newProj.PublicId+";actions=read", newRoleGrants[0].CanonicalGrant)
+
+	// Renaming the template renames the roles
+	rt.Name = "lifecycle-renamed"
+	rt, _, _, rowsUpdated, err := repo.UpdateRoleTemplate(ctx, rt, rt.Version+2, []string{"Name"})
+	require.NoError(err)
+	assert.Equal(1, rowsUpdated)
+	role, _, _, err = repo.LookupRole(ctx, role.PublicId)
+	require.NoError(err)
+	assert.Equal("lifecycle-renamed ("+rt.PublicId+")", role.Name)
+
+	// Deleting the template deletes its roles
+	rowsDeleted, err := repo.DeleteRoleTemplate(ctx, rt.PublicId)
+	require.NoError(err)
+	assert.Equal(1, rowsDeleted)
+	role, _, _, err = repo.LookupRole(ctx, role.PublicId)
+	require.NoError(err)
+	assert.Nil(role)
+	newRole, _, _, err = repo.LookupRole(ctx, newRoleId)
+	require.NoError(err)
+	assert.Nil(newRole)
+}
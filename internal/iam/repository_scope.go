@@ -359,6 +359,14 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 				}
 			}
 
+			// Instantiate the role templates of the scopes above a new
+			// project
+			if s.Type == scope.Project.String() {
+				if err := instantiateRoleTemplates(ctx, dbr, w, childOplogWrapper, s); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to instantiate role templates"))
+				}
+			}
+
 			return nil
 		},
 	)
@@ -0,0 +1,95 @@
+package iam
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultResourceOwnerTable = "resource_owner"
+
+// ResourceOwner is the user owning a target, host set, host or group, which
+// grants can match on.
+type ResourceOwner struct {
+	*store.ResourceOwner
+	tableName string `gorm:"-"`
+}
+
+// ensure that ResourceOwner implements the interfaces of: Cloneable and
+// db.VetForWriter
+var (
+	_ Cloneable       = (*ResourceOwner)(nil)
+	_ db.VetForWriter = (*ResourceOwner)(nil)
+)
+
+// NewResourceOwner creates a new in memory resource owner. No options are
+// currently supported.
+func NewResourceOwner(resourceId, ownerId string, _ ...Option) (*ResourceOwner, error) {
+	const op = "iam.NewResourceOwner"
+	if resourceId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing resource id")
+	}
+	if ownerId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing owner id")
+	}
+	return &ResourceOwner{
+		ResourceOwner: &store.ResourceOwner{
+			ResourceId: resourceId,
+			OwnerId:    ownerId,
+		},
+	}, nil
+}
+
+func allocResourceOwner() ResourceOwner {
+	return ResourceOwner{
+		ResourceOwner: &store.ResourceOwner{},
+	}
+}
+
+// Clone creates a clone of the ResourceOwner
+func (o *ResourceOwner) Clone() interface{} {
+	cp := proto.Clone(o.ResourceOwner)
+	return &ResourceOwner{
+		ResourceOwner: cp.(*store.ResourceOwner),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface
+func (o *ResourceOwner) VetForWrite(_ context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "iam.(ResourceOwner).VetForWrite"
+	if o.ResourceId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing resource id")
+	}
+	if o.OwnerId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing owner id")
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (o *ResourceOwner) TableName() string {
+	if o.tableName != "" {
+		return o.tableName
+	}
+	return defaultResourceOwnerTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (o *ResourceOwner) SetTableName(n string) {
+	o.tableName = n
+}
+
+func (o *ResourceOwner) oplog(op oplog.OpType, scopeId string) oplog.Metadata {
+	return oplog.Metadata{
+		"resource-public-id": []string{o.ResourceId},
+		"resource-type":      []string{"resource-owner"},
+		"scope-id":           []string{scopeId},
+		"op-type":            []string{op.String()},
+	}
+}
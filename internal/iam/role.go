@@ -95,6 +95,9 @@ func (*Role) Actions() map[string]action.Type {
 	ret[action.AddPrincipals.String()] = action.AddPrincipals
 	ret[action.RemovePrincipals.String()] = action.RemovePrincipals
 	ret[action.SetPrincipals.String()] = action.SetPrincipals
+	ret[action.AddGrantScopes.String()] = action.AddGrantScopes
+	ret[action.RemoveGrantScopes.String()] = action.RemoveGrantScopes
+	ret[action.SetGrantScopes.String()] = action.SetGrantScopes
 	return ret
 }
 
@@ -0,0 +1,124 @@
+package iam
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultRoleGrantScopeTable = "iam_role_grant_scope"
+
+// The special grant scopes a role's grants can apply to in addition to scope
+// IDs. They are resolved whenever grants are looked up, so scopes created
+// after the grant scope was added are covered as well.
+const (
+	// GrantScopeThis applies the grants to the role's own scope.
+	GrantScopeThis = "this"
+
+	// GrantScopeChildren applies the grants to the direct child scopes of the
+	// role's scope. It's valid for global and org roles.
+	GrantScopeChildren = "children"
+
+	// GrantScopeDescendants applies the grants to every scope below global.
+	// It's only valid for global roles.
+	GrantScopeDescendants = "descendants"
+)
+
+// RoleGrantScope defines a scope a role's grants apply to. A role without
+// grant scopes applies its grants to its GrantScopeId.
+type RoleGrantScope struct {
+	*store.RoleGrantScope
+	tableName string `gorm:"-"`
+}
+
+// ensure that RoleGrantScope implements the interfaces of: Cloneable and db.VetForWriter
+var (
+	_ Cloneable       = (*RoleGrantScope)(nil)
+	_ db.VetForWriter = (*RoleGrantScope)(nil)
+)
+
+// NewRoleGrantScope creates a new in memory role grant scope. The grant scope
+// must be a scope ID or one of GrantScopeThis, GrantScopeChildren and
+// GrantScopeDescendants.
+func NewRoleGrantScope(roleId string, grantScope string, _ ...Option) (*RoleGrantScope, error) {
+	const op = "iam.NewRoleGrantScope"
+	if roleId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing role id")
+	}
+	if err := validGrantScope(grantScope); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	rgs := &RoleGrantScope{
+		RoleGrantScope: &store.RoleGrantScope{
+			RoleId:           roleId,
+			ScopeIdOrSpecial: grantScope,
+		},
+	}
+	return rgs, nil
+}
+
+func allocRoleGrantScope() RoleGrantScope {
+	return RoleGrantScope{
+		RoleGrantScope: &store.RoleGrantScope{},
+	}
+}
+
+// Clone creates a clone of the RoleGrantScope
+func (g *RoleGrantScope) Clone() interface{} {
+	cp := proto.Clone(g.RoleGrantScope)
+	return &RoleGrantScope{
+		RoleGrantScope: cp.(*store.RoleGrantScope),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface
+func (g *RoleGrantScope) VetForWrite(_ context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "iam.(RoleGrantScope).VetForWrite"
+	if g.RoleId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing role id")
+	}
+	if err := validGrantScope(g.ScopeIdOrSpecial); err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (g *RoleGrantScope) TableName() string {
+	if g.tableName != "" {
+		return g.tableName
+	}
+	return defaultRoleGrantScopeTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (g *RoleGrantScope) SetTableName(n string) {
+	g.tableName = n
+}
+
+// validGrantScope checks that the grant scope is a special grant scope or
+// looks like a scope ID. Whether the role can use it is checked by the
+// database.
+func validGrantScope(grantScope string) error {
+	const op = "iam.validGrantScope"
+	switch {
+	case grantScope == "":
+		return errors.New(errors.InvalidParameter, op, "missing grant scope")
+	case grantScope == GrantScopeThis,
+		grantScope == GrantScopeChildren,
+		grantScope == GrantScopeDescendants,
+		grantScope == scope.Global.String(),
+		strings.HasPrefix(grantScope, scope.Org.Prefix()+"_"),
+		strings.HasPrefix(grantScope, scope.Project.Prefix()+"_"):
+		return nil
+	default:
+		return errors.New(errors.InvalidParameter, op, "grant scope must be a scope id, this, children or descendants")
+	}
+}
@@ -0,0 +1,164 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewRoleGrantScope(t *testing.T) {
+	tests := []struct {
+		name       string
+		roleId     string
+		grantScope string
+		wantErr    bool
+	}{
+		{name: "missing-role", grantScope: GrantScopeThis, wantErr: true},
+		{name: "missing-grant-scope", roleId: "r_1234567890", wantErr: true},
+		{name: "invalid-grant-scope", roleId: "r_1234567890", grantScope: "everything", wantErr: true},
+		{name: "this", roleId: "r_1234567890", grantScope: GrantScopeThis},
+		{name: "children", roleId: "r_1234567890", grantScope: GrantScopeChildren},
+		{name: "descendants", roleId: "r_1234567890", grantScope: GrantScopeDescendants},
+		{name: "global", roleId: "r_1234567890", grantScope: "global"},
+		{name: "org", roleId: "r_1234567890", grantScope: "o_1234567890"},
+		{name: "project", roleId: "r_1234567890", grantScope: "p_1234567890"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewRoleGrantScope(tt.roleId, tt.grantScope)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.roleId, got.RoleId)
+			assert.Equal(tt.grantScope, got.ScopeIdOrSpecial)
+		})
+	}
+}
+
+func TestRoleGrantScope_Create(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	otherOrg, otherProj := TestScopes(t, repo)
+	globalRole := TestRole(t, conn, "global")
+	orgRole := TestRole(t, conn, org.PublicId)
+	projRole := TestRole(t, conn, proj.PublicId)
+
+	tests := []struct {
+		name       string
+		roleId     string
+		grantScope string
+		want       string
+		wantErr    bool
+	}{
+		{name: "global-this", roleId: globalRole.PublicId, grantScope: GrantScopeThis, want: GrantScopeThis},
+		{name: "global-own-id-duplicates-this", roleId: globalRole.PublicId, grantScope: "global", wantErr: true},
+		{name: "global-descendants", roleId: globalRole.PublicId, grantScope: GrantScopeDescendants, want: GrantScopeDescendants},
+		{name: "global-children-conflict", roleId: globalRole.PublicId, grantScope: GrantScopeChildren, wantErr: true},
+		{name: "global-org", roleId: globalRole.PublicId, grantScope: otherOrg.PublicId, want: otherOrg.PublicId},
+		{name: "global-project", roleId: globalRole.PublicId, grantScope: otherProj.PublicId, want: otherProj.PublicId},
+		{name: "global-missing-scope", roleId: globalRole.PublicId, grantScope: "p_1234567890", wantErr: true},
+		{name: "org-own-id", roleId: orgRole.PublicId, grantScope: org.PublicId, want: GrantScopeThis},
+		{name: "org-children", roleId: orgRole.PublicId, grantScope: GrantScopeChildren, want: GrantScopeChildren},
+		{name: "org-descendants", roleId: orgRole.PublicId, grantScope: GrantScopeDescendants, wantErr: true},
+		{name: "org-child-project", roleId: orgRole.PublicId, grantScope: proj.PublicId, want: proj.PublicId},
+		{name: "org-other-project", roleId: orgRole.PublicId, grantScope: otherProj.PublicId, wantErr: true},
+		{name: "project-this", roleId: projRole.PublicId, grantScope: GrantScopeThis, want: GrantScopeThis},
+		{name: "project-children", roleId: projRole.PublicId, grantScope: GrantScopeChildren, wantErr: true},
+		{name: "project-other-project", roleId: projRole.PublicId, grantScope: otherProj.PublicId, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			rw := db.New(conn)
+			gs, err := NewRoleGrantScope(tt.roleId, tt.grantScope)
+			require.NoError(err)
+			err = rw.Create(context.Background(), gs)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			found := allocRoleGrantScope()
+			err = rw.LookupWhere(context.Background(), &found, "role_id = ? and scope_id_or_special = ?", tt.roleId, tt.want)
+			require.NoError(err)
+			assert.NotNil(found.CreateTime)
+		})
+	}
+}
+
+func TestRoleGrantScope_Immutable(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	role := TestRole(t, conn, org.PublicId)
+	gs := TestRoleGrantScope(t, conn, role.PublicId, GrantScopeThis)
+
+	rw := db.New(conn)
+	updated := gs.Clone().(*RoleGrantScope)
+	updated.ScopeIdOrSpecial = proj.PublicId
+	_, err := rw.Update(context.Background(), updated, []string{"ScopeIdOrSpecial"}, nil, db.WithWhere("role_id = ? and scope_id_or_special = ?", role.PublicId, GrantScopeThis))
+	assert.Error(err)
+
+	// Deleting a scope removes the grant scopes naming it
+	TestRoleGrantScope(t, conn, role.PublicId, proj.PublicId)
+	_, err = repo.DeleteScope(context.Background(), proj.PublicId)
+	require.NoError(err)
+	found, err := repo.ListRoleGrantScopes(context.Background(), role.PublicId)
+	require.NoError(err)
+	require.Len(found, 1)
+	assert.Equal(GrantScopeThis, found[0].ScopeIdOrSpecial)
+}
+
+func TestRoleGrantScope_Clone(t *testing.T) {
+	assert := assert.New(t)
+	gs, err := NewRoleGrantScope("r_1234567890", GrantScopeChildren)
+	require.NoError(t, err)
+	cp := gs.Clone()
+	assert.True(proto.Equal(cp.(*RoleGrantScope).RoleGrantScope, gs.RoleGrantScope))
+
+	gs2, err := NewRoleGrantScope("r_1234567890", GrantScopeThis)
+	require.NoError(t, err)
+	assert.True(!proto.Equal(cp.(*RoleGrantScope).RoleGrantScope, gs2.RoleGrantScope))
+}
+
+func TestRoleGrantScope_SetTableName(t *testing.T) {
+	defaultTableName := defaultRoleGrantScopeTable
+	tests := []struct {
+		name        string
+		initialName string
+		setNameTo   string
+		want        string
+	}{
+		{name: "new-name", initialName: "", setNameTo: "new-name", want: "new-name"},
+		{name: "reset to default", initialName: "initial", setNameTo: "", want: defaultTableName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			def := allocRoleGrantScope()
+			require.Equal(t, defaultTableName, def.TableName())
+			s := &RoleGrantScope{
+				RoleGrantScope: &store.RoleGrantScope{},
+				tableName:      tt.initialName,
+			}
+			s.SetTableName(tt.setNameTo)
+			assert.Equal(tt.want, s.TableName())
+		})
+	}
+}
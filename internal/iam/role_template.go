@@ -0,0 +1,481 @@
+package iam
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultRoleTemplateTableName              = "iam_role_template"
+	defaultRoleTemplateGrantTable             = "iam_role_template_grant"
+	roleTemplatePrincipalViewDefaultTable     = "iam_role_template_principal"
+	roleTemplateUserPrincipalDefaultTable     = "iam_role_template_user_principal"
+	roleTemplateGroupPrincipalDefaultTable    = "iam_role_template_group_principal"
+	defaultRoleTemplateRoleTable              = "iam_role_template_role"
+	roleTemplateScopeIdParameter              = "{{scope.id}}"
+	roleTemplateScopeParentIdParameter        = "{{scope.parent_id}}"
+	roleTemplateScopeIdPlaceholder            = "p_roletemplatescope"
+	roleTemplateScopeParentIdPlaceholder      = "o_roletemplateparent"
+	roleTemplateInstantiatedNameFormat        = "%s (%s)"
+	roleTemplateInstantiatedDescriptionFormat = "Role instantiated from role template %s"
+)
+
+// RoleTemplates are named sets of grants and principals defined in the global
+// scope or an org. A Role is instantiated from the template in every project
+// below its scope, and kept in sync with the template.
+type RoleTemplate struct {
+	*store.RoleTemplate
+	tableName string `gorm:"-"`
+}
+
+// ensure that RoleTemplate implements the interfaces of: Resource, Cloneable,
+// and db.VetForWriter.
+var (
+	_ Resource        = (*RoleTemplate)(nil)
+	_ Cloneable       = (*RoleTemplate)(nil)
+	_ db.VetForWriter = (*RoleTemplate)(nil)
+)
+
+// NewRoleTemplate creates a new in memory role template with a scope
+// (global/org). Allowed options include: WithDescription, WithName.
+func NewRoleTemplate(scopeId string, opt ...Option) (*RoleTemplate, error) {
+	const op = "iam.NewRoleTemplate"
+	if scopeId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	t := &RoleTemplate{
+		RoleTemplate: &store.RoleTemplate{
+			ScopeId:     scopeId,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+		},
+	}
+	return t, nil
+}
+
+func allocRoleTemplate() RoleTemplate {
+	return RoleTemplate{
+		RoleTemplate: &store.RoleTemplate{},
+	}
+}
+
+// Clone creates a clone of the RoleTemplate.
+func (t *RoleTemplate) Clone() interface{} {
+	cp := proto.Clone(t.RoleTemplate)
+	return &RoleTemplate{
+		RoleTemplate: cp.(*store.RoleTemplate),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface.
+func (t *RoleTemplate) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	const op = "iam.(RoleTemplate).VetForWrite"
+	if t.PublicId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing public id")
+	}
+	if err := validateScopeForWrite(ctx, r, t, opType, opt...); err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
+
+func (t *RoleTemplate) validScopeTypes() []scope.Type {
+	return []scope.Type{scope.Global, scope.Org}
+}
+
+// GetScope returns the scope for the RoleTemplate.
+func (t *RoleTemplate) GetScope(ctx context.Context, r db.Reader) (*Scope, error) {
+	return LookupScope(ctx, r, t)
+}
+
+// ResourceType returns the type of the RoleTemplate.
+func (*RoleTemplate) ResourceType() resource.Type { return resource.RoleTemplate }
+
+// Actions returns the available actions for RoleTemplate.
+func (*RoleTemplate) Actions() map[string]action.Type {
+	ret := CrudlActions()
+	ret[action.AddGrants.String()] = action.AddGrants
+	ret[action.RemoveGrants.String()] = action.RemoveGrants
+	ret[action.SetGrants.String()] = action.SetGrants
+	ret[action.AddPrincipals.String()] = action.AddPrincipals
+	ret[action.RemovePrincipals.String()] = action.RemovePrincipals
+	ret[action.SetPrincipals.String()] = action.SetPrincipals
+	return ret
+}
+
+// TableName returns the tablename to override the default gorm table name.
+func (t *RoleTemplate) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return defaultRoleTemplateTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (t *RoleTemplate) SetTableName(n string) {
+	t.tableName = n
+}
+
+// RoleTemplateGrant defines the grants of a role template. The grants may
+// reference the {{scope.id}} and {{scope.parent_id}} parameters, which are
+// replaced with the project's values in the instantiated roles.
+type RoleTemplateGrant struct {
+	*store.RoleTemplateGrant
+	tableName string `gorm:"-"`
+}
+
+// ensure that RoleTemplateGrant implements the interfaces of: Cloneable and
+// db.VetForWriter
+var (
+	_ Cloneable       = (*RoleTemplateGrant)(nil)
+	_ db.VetForWriter = (*RoleTemplateGrant)(nil)
+)
+
+// NewRoleTemplateGrant creates a new in memory role template grant. No options
+// are currently supported.
+func NewRoleTemplateGrant(roleTemplateId string, grant string, _ ...Option) (*RoleTemplateGrant, error) {
+	const op = "iam.NewRoleTemplateGrant"
+	if roleTemplateId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing role template id")
+	}
+	if grant == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing grant")
+	}
+	canonical, err := canonicalRoleTemplateGrant(grant)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return &RoleTemplateGrant{
+		RoleTemplateGrant: &store.RoleTemplateGrant{
+			RoleTemplateId: roleTemplateId,
+			RawGrant:       grant,
+			CanonicalGrant: canonical,
+		},
+	}, nil
+}
+
+func allocRoleTemplateGrant() RoleTemplateGrant {
+	return RoleTemplateGrant{
+		RoleTemplateGrant: &store.RoleTemplateGrant{},
+	}
+}
+
+// Clone creates a clone of the RoleTemplateGrant
+func (g *RoleTemplateGrant) Clone() interface{} {
+	cp := proto.Clone(g.RoleTemplateGrant)
+	return &RoleTemplateGrant{
+		RoleTemplateGrant: cp.(*store.RoleTemplateGrant),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface
+func (g *RoleTemplateGrant) VetForWrite(_ context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "iam.(RoleTemplateGrant).VetForWrite"
+	if g.RawGrant == "" {
+		return errors.New(errors.InvalidParameter, op, "missing grant")
+	}
+	canonical, err := canonicalRoleTemplateGrant(g.RawGrant)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if g.CanonicalGrant != "" && g.CanonicalGrant != canonical {
+		return errors.New(errors.InvalidParameter, op, "existing canonical grant and derived one do not match")
+	}
+	g.CanonicalGrant = canonical
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (g *RoleTemplateGrant) TableName() string {
+	if g.tableName != "" {
+		return g.tableName
+	}
+	return defaultRoleTemplateGrantTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (g *RoleTemplateGrant) SetTableName(n string) {
+	g.tableName = n
+}
+
+// canonicalRoleTemplateGrant validates the grant by parsing it as it would be
+// instantiated in a project, and returns its canonical string with the
+// parameters left in place.
+func canonicalRoleTemplateGrant(grant string) (string, error) {
+	const op = "iam.canonicalRoleTemplateGrant"
+	perm, _, err := ParseRoleTemplateGrant(grant)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+	return restoreRoleTemplateParameters(perm.CanonicalString()), nil
+}
+
+// ParseRoleTemplateGrant parses a role template grant as it would be
+// instantiated in a project. As the returned grant refers to placeholder
+// scopes, the ID of the grant is also returned with the parameters left in
+// place.
+func ParseRoleTemplateGrant(grant string) (perms.Grant, string, error) {
+	const op = "iam.ParseRoleTemplateGrant"
+	perm, err := perms.Parse(roleTemplateScopeIdPlaceholder, instantiateRoleTemplateGrant(grant, roleTemplateScopeIdPlaceholder, roleTemplateScopeParentIdPlaceholder))
+	if err != nil {
+		return perms.Grant{}, "", errors.Wrap(err, op, errors.WithMsg("parsing grant string"))
+	}
+	return perm, restoreRoleTemplateParameters(perm.Id()), nil
+}
+
+func restoreRoleTemplateParameters(s string) string {
+	return strings.NewReplacer(
+		roleTemplateScopeIdPlaceholder, roleTemplateScopeIdParameter,
+		roleTemplateScopeParentIdPlaceholder, roleTemplateScopeParentIdParameter,
+	).Replace(s)
+}
+
+// instantiateRoleTemplateGrant replaces the parameters of a role template
+// grant with the values of the project the role is instantiated in.
+func instantiateRoleTemplateGrant(grant, scopeId, parentScopeId string) string {
+	return strings.NewReplacer(
+		roleTemplateScopeIdParameter, scopeId,
+		roleTemplateScopeParentIdParameter, parentScopeId,
+	).Replace(grant)
+}
+
+// RoleTemplatePrincipal provides a common way to return the principals of
+// role templates regardless of their underlying type.
+type RoleTemplatePrincipal struct {
+	*store.RoleTemplatePrincipalView
+	tableName string `gorm:"-"`
+}
+
+// TableName provides an overridden gorm table name for role template
+// principals.
+func (v *RoleTemplatePrincipal) TableName() string {
+	if v.tableName != "" {
+		return v.tableName
+	}
+	return roleTemplatePrincipalViewDefaultTable
+}
+
+// SetTableName sets the table name for the resource.  If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (v *RoleTemplatePrincipal) SetTableName(n string) {
+	switch n {
+	case "":
+		v.tableName = roleTemplatePrincipalViewDefaultTable
+	default:
+		v.tableName = n
+	}
+}
+
+// RoleTemplateUserPrincipal is a user assigned to the roles instantiated from
+// a role template
+type RoleTemplateUserPrincipal struct {
+	*store.RoleTemplatePrincipal
+	tableName string `gorm:"-"`
+}
+
+// ensure that RoleTemplateUserPrincipal implements the interfaces of:
+// Cloneable and db.VetForWriter
+var (
+	_ Cloneable       = (*RoleTemplateUserPrincipal)(nil)
+	_ db.VetForWriter = (*RoleTemplateUserPrincipal)(nil)
+)
+
+// NewRoleTemplateUserPrincipal creates a new in memory user principal of a
+// role template. No options are currently supported.
+func NewRoleTemplateUserPrincipal(roleTemplateId, userId string, _ ...Option) (*RoleTemplateUserPrincipal, error) {
+	const op = "iam.NewRoleTemplateUserPrincipal"
+	if roleTemplateId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing role template id")
+	}
+	if userId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing user id")
+	}
+	return &RoleTemplateUserPrincipal{
+		RoleTemplatePrincipal: &store.RoleTemplatePrincipal{
+			RoleTemplateId: roleTemplateId,
+			PrincipalId:    userId,
+		},
+	}, nil
+}
+
+// Clone creates a clone of the RoleTemplateUserPrincipal
+func (p *RoleTemplateUserPrincipal) Clone() interface{} {
+	cp := proto.Clone(p.RoleTemplatePrincipal)
+	return &RoleTemplateUserPrincipal{
+		RoleTemplatePrincipal: cp.(*store.RoleTemplatePrincipal),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface
+func (p *RoleTemplateUserPrincipal) VetForWrite(_ context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "iam.(RoleTemplateUserPrincipal).VetForWrite"
+	if p.RoleTemplateId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing role template id")
+	}
+	if p.PrincipalId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing user id")
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (p *RoleTemplateUserPrincipal) TableName() string {
+	if p.tableName != "" {
+		return p.tableName
+	}
+	return roleTemplateUserPrincipalDefaultTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (p *RoleTemplateUserPrincipal) SetTableName(n string) {
+	p.tableName = n
+}
+
+// RoleTemplateGroupPrincipal is a group assigned to the roles instantiated
+// from a role template
+type RoleTemplateGroupPrincipal struct {
+	*store.RoleTemplatePrincipal
+	tableName string `gorm:"-"`
+}
+
+// ensure that RoleTemplateGroupPrincipal implements the interfaces of:
+// Cloneable and db.VetForWriter
+var (
+	_ Cloneable       = (*RoleTemplateGroupPrincipal)(nil)
+	_ db.VetForWriter = (*RoleTemplateGroupPrincipal)(nil)
+)
+
+// NewRoleTemplateGroupPrincipal creates a new in memory group principal of a
+// role template. No options are currently supported.
+func NewRoleTemplateGroupPrincipal(roleTemplateId, groupId string, _ ...Option) (*RoleTemplateGroupPrincipal, error) {
+	const op = "iam.NewRoleTemplateGroupPrincipal"
+	if roleTemplateId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing role template id")
+	}
+	if groupId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing group id")
+	}
+	return &RoleTemplateGroupPrincipal{
+		RoleTemplatePrincipal: &store.RoleTemplatePrincipal{
+			RoleTemplateId: roleTemplateId,
+			PrincipalId:    groupId,
+		},
+	}, nil
+}
+
+// Clone creates a clone of the RoleTemplateGroupPrincipal
+func (p *RoleTemplateGroupPrincipal) Clone() interface{} {
+	cp := proto.Clone(p.RoleTemplatePrincipal)
+	return &RoleTemplateGroupPrincipal{
+		RoleTemplatePrincipal: cp.(*store.RoleTemplatePrincipal),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface
+func (p *RoleTemplateGroupPrincipal) VetForWrite(_ context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "iam.(RoleTemplateGroupPrincipal).VetForWrite"
+	if p.RoleTemplateId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing role template id")
+	}
+	if p.PrincipalId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing group id")
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (p *RoleTemplateGroupPrincipal) TableName() string {
+	if p.tableName != "" {
+		return p.tableName
+	}
+	return roleTemplateGroupPrincipalDefaultTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (p *RoleTemplateGroupPrincipal) SetTableName(n string) {
+	p.tableName = n
+}
+
+// RoleTemplateRole records a role instantiated from a role template
+type RoleTemplateRole struct {
+	*store.RoleTemplateRole
+	tableName string `gorm:"-"`
+}
+
+// ensure that RoleTemplateRole implements the interfaces of: Cloneable and
+// db.VetForWriter
+var (
+	_ Cloneable       = (*RoleTemplateRole)(nil)
+	_ db.VetForWriter = (*RoleTemplateRole)(nil)
+)
+
+// NewRoleTemplateRole creates a new in memory record of a role instantiated
+// from a role template. No options are currently supported.
+func NewRoleTemplateRole(roleTemplateId, roleId string, _ ...Option) (*RoleTemplateRole, error) {
+	const op = "iam.NewRoleTemplateRole"
+	if roleTemplateId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing role template id")
+	}
+	if roleId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing role id")
+	}
+	return &RoleTemplateRole{
+		RoleTemplateRole: &store.RoleTemplateRole{
+			RoleTemplateId: roleTemplateId,
+			RoleId:         roleId,
+		},
+	}, nil
+}
+
+// Clone creates a clone of the RoleTemplateRole
+func (r *RoleTemplateRole) Clone() interface{} {
+	cp := proto.Clone(r.RoleTemplateRole)
+	return &RoleTemplateRole{
+		RoleTemplateRole: cp.(*store.RoleTemplateRole),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface
+func (r *RoleTemplateRole) VetForWrite(_ context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "iam.(RoleTemplateRole).VetForWrite"
+	if r.RoleTemplateId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing role template id")
+	}
+	if r.RoleId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing role id")
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (r *RoleTemplateRole) TableName() string {
+	if r.tableName != "" {
+		return r.tableName
+	}
+	return defaultRoleTemplateRoleTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (r *RoleTemplateRole) SetTableName(n string) {
+	r.tableName = n
+}
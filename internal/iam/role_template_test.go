@@ -0,0 +1,100 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRoleTemplateGrant(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		id            string
+		grant         string
+		wantCanonical string
+		wantIsErr     errors.Code
+	}{
+		{
+			name:      "missing-id",
+			grant:     "id=*;type=*;actions=read",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-grant",
+			id:        "rt_1234567890",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "bad-grant",
+			id:        "rt_1234567890",
+			grant:     "id=*;type=*",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:          "valid",
+			id:            "rt_1234567890",
+			grant:         "type=target;actions=list,read;id=*",
+			wantCanonical: "id=*;type=target;actions=list,read",
+		},
+		{
+			name:          "valid-scope-parameter",
+			id:            "rt_1234567890",
+			grant:         "id={{scope.id}};actions=read",
+			wantCanonical: "id={{scope.id}};actions=read",
+		},
+		{
+			name:          "valid-parent-parameter",
+			id:            "rt_1234567890",
+			grant:         "id={{scope.parent_id}};actions=read",
+			wantCanonical: "id={{scope.parent_id}};actions=read",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewRoleTemplateGrant(tt.id, tt.grant)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(tt.wantIsErr), err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.id, got.GetRoleTemplateId())
+			assert.Equal(tt.grant, got.GetRawGrant())
+			assert.Equal(tt.wantCanonical, got.GetCanonicalGrant())
+		})
+	}
+}
+
+func TestParseRoleTemplateGrant(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+
+	g, id, err := ParseRoleTemplateGrant("id={{scope.id}};actions=read")
+	require.NoError(err)
+	assert.Equal("{{scope.id}}", id)
+	assert.Equal(roleTemplateScopeIdPlaceholder, g.Id())
+
+	_, _, err = ParseRoleTemplateGrant("id={{scope.id}}")
+	assert.Error(err)
+}
+
+func TestInstantiateRoleTemplateGrant(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	assert.Equal(
+		"id=p_1234567890;actions=read",
+		instantiateRoleTemplateGrant("id={{scope.id}};actions=read", "p_1234567890", "o_1234567890"),
+	)
+	assert.Equal(
+		"id=o_1234567890;actions=read",
+		instantiateRoleTemplateGrant("id={{scope.parent_id}};actions=read", "p_1234567890", "o_1234567890"),
+	)
+	assert.Equal(
+		"id=*;type=target;actions=read",
+		instantiateRoleTemplateGrant("id=*;type=target;actions=read", "p_1234567890", "o_1234567890"),
+	)
+}
@@ -188,6 +188,19 @@ func TestRoleGrant(t *testing.T, conn *gorm.DB, roleId, grant string, opt ...Opt
 	return g
 }
 
+// TestRoleGrantScope adds a grant scope to a role for testing.
+func TestRoleGrantScope(t *testing.T, conn *gorm.DB, roleId, grantScope string, opt ...Option) *RoleGrantScope {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+
+	gs, err := NewRoleGrantScope(roleId, grantScope, opt...)
+	require.NoError(err)
+	err = rw.Create(context.Background(), gs)
+	require.NoError(err)
+	return gs
+}
+
 // TestGroup creates a group suitable for testing.
 func TestGroup(t *testing.T, conn *gorm.DB, scopeId string, opt ...Option) *Group {
 	t.Helper()
@@ -218,6 +231,19 @@ func TestGroupMember(t *testing.T, conn *gorm.DB, groupId, userId string, opt ..
 	return gm
 }
 
+func TestGroupMemberGroup(t *testing.T, conn *gorm.DB, groupId, memberGroupId string, opt ...Option) *GroupMemberGroup {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	gm, err := NewGroupMemberGroup(groupId, memberGroupId)
+	require.NoError(err)
+	require.NotNil(gm)
+	err = rw.Create(context.Background(), gm)
+	require.NoError(err)
+	require.NotEmpty(gm.CreateTime)
+	return gm
+}
+
 func TestUserRole(t *testing.T, conn *gorm.DB, roleId, userId string, opt ...Option) *UserRole {
 	t.Helper()
 	require := require.New(t)
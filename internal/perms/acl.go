@@ -55,6 +55,14 @@ type Resource struct {
 	// Pin if defined would constrain the resource within the collection of the
 	// pin id.
 	Pin string
+
+	// Labels are the labels on the resource, which grants can match on. They
+	// only apply to a resource with an ID.
+	Labels map[string]string
+
+	// OwnerId is the user owning the resource, which grants can match on. It
+	// only applies to a resource with an ID.
+	OwnerId string
 }
 
 // NewACL creates an ACL from the grants provided.
@@ -75,36 +83,24 @@ func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
 	// First, get the grants within the specified scope
 	grants := a.scopeMap[r.ScopeId]
 	results.scopeMap = a.scopeMap
+	parentAction := parentActionOf(aType)
 
-	var parentAction action.Type
-	split := strings.Split(aType.String(), ":")
-	if len(split) == 2 {
-		parentAction = action.Map[split[0]]
+	// Deny grants take precedence over any grant allowing the action, so if
+	// one matches we're done. Output fields aren't returned for a denied
+	// action.
+	for _, grant := range grants {
+		if grant.deny && grant.denies(r, aType) {
+			return
+		}
 	}
+
 	// Now, go through and check the cases indicated above
 	for _, grant := range grants {
-		var outputFieldsOnly bool
-		switch {
-		case len(grant.actions) == 0:
-			// Continue with the next grant, unless we have output fields
-			// specified in which case we continue to be able to apply the
-			// output fields depending on ID and type.
-			if len(grant.OutputFields) > 0 {
-				outputFieldsOnly = true
-			} else {
-				continue
-			}
-		case grant.actions[aType]:
-			// We have this action
-		case grant.actions[parentAction]:
-			// We don't have this action, but it's a subaction and we have the
-			// parent action. As an example, if we are looking for "read:self"
-			// and have "read", this is sufficient.
-		case grant.actions[action.All]:
-			// All actions are allowed
-		default:
-			// No actions in the grant match what we're looking for, so continue
-			// with the next grant
+		if grant.deny {
+			continue
+		}
+		matched, outputFieldsOnly := grant.matchesAction(aType, parentAction)
+		if !matched {
 			continue
 		}
 
@@ -114,57 +110,7 @@ func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
 		// If the action was not found above but we did find output fields in
 		// patterns that match, we do not authorize the request, but we do build
 		// up the output fields patterns.
-		var found bool
-		switch {
-		// id=<resource.id>;actions=<action> where ID cannot be a wildcard; or
-		// id=<resource.id>;output_fields=<fields> where fields cannot be a
-		// wildcard.
-		case grant.id == r.Id &&
-			grant.id != "" &&
-			grant.id != "*" &&
-			grant.typ == resource.Unknown &&
-			aType != action.List &&
-			aType != action.Create:
-
-			found = true
-
-		// type=<resource.type>;actions=<action> when action is list or create.
-		// Must be a top level collection, otherwise must be one of the two
-		// formats specified below. Or,
-		// type=resource.type;output_fields=<fields> and no action.
-		case grant.id == "" &&
-			r.Id == "" &&
-			grant.typ == r.Type &&
-			grant.typ != resource.Unknown &&
-			topLevelType(r.Type) &&
-			(aType == action.List ||
-				aType == action.Create):
-
-			found = true
-
-		// id=*;type=<resource.type>;actions=<action> where type cannot be
-		// unknown but can be a wildcard to allow any resource at all; or
-		// id=*;type=<resource.type>;output_fields=<fields> with no action.
-		case grant.id == "*" &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type ||
-				grant.typ == resource.All):
-
-			found = true
-
-		// id=<pin>;type=<resource.type>;actions=<action> where type can be a
-		// wildcard and this this is operating on a non-top-level type. Same for
-		// output fields only.
-		case grant.id != "" &&
-			grant.id == r.Pin &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type || grant.typ == resource.All) &&
-			!topLevelType(r.Type):
-
-			found = true
-		}
-
-		if found {
+		if grant.matchesResource(r, aType) {
 			if !outputFieldsOnly {
 				results.Authorized = true
 			}
@@ -176,6 +122,160 @@ func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
 	return
 }
 
+// matchesAction reports whether an allow grant applies to the action. A grant
+// without actions but with output fields applies to any action, but only to
+// contribute its output fields.
+func (g Grant) matchesAction(aType, parentAction action.Type) (matched bool, outputFieldsOnly bool) {
+	switch {
+	case len(g.actions) == 0:
+		// Continue with the next grant, unless we have output fields
+		// specified in which case we continue to be able to apply the output
+		// fields depending on ID and type.
+		if len(g.OutputFields) > 0 {
+			return true, true
+		}
+		return false, false
+	case g.actions[aType]:
+		// We have this action
+	case g.actions[parentAction]:
+		// We don't have this action, but it's a subaction and we have the
+		// parent action. As an example, if we are looking for "read:self" and
+		// have "read", this is sufficient.
+	case g.actions[action.All]:
+		// All actions are allowed
+	default:
+		// No actions in the grant match what we're looking for, so continue
+		// with the next grant
+		return false, false
+	}
+	return true, false
+}
+
+// Matches reports whether the grant applies to the action on the resource: a
+// deny grant if it denies the action, any other grant if it allows the action
+// or contributes output fields for it. It's meant to explain the results of
+// Allowed, which should be used for authorization decisions.
+func (g Grant) Matches(r Resource, aType action.Type) bool {
+	if g.scope.Id != r.ScopeId {
+		return false
+	}
+	if g.deny {
+		return g.denies(r, aType)
+	}
+	if matched, _ := g.matchesAction(aType, parentActionOf(aType)); !matched {
+		return false
+	}
+	return g.matchesResource(r, aType)
+}
+
+// parentActionOf returns the parent action of a subaction, e.g. "read" for
+// "read:self", or action.Unknown if the action isn't a subaction.
+func parentActionOf(aType action.Type) action.Type {
+	split := strings.Split(aType.String(), ":")
+	if len(split) == 2 {
+		return action.Map[split[0]]
+	}
+	return action.Unknown
+}
+
+// matchesResource reports whether the ID, type, pin, labels and owner of the
+// grant match the resource for the given action.
+func (g Grant) matchesResource(r Resource, aType action.Type) bool {
+	if len(g.labels) > 0 {
+		switch {
+		case r.Id != "":
+			if !g.labelsMatch(r) {
+				return false
+			}
+		case aType == action.List && !g.deny:
+			// The listed items are each checked against the labels. A deny
+			// grant must not match the collection, as that would deny
+			// listing the items without the labels too.
+		default:
+			// Labels can't match a collection, so e.g. creating is never
+			// allowed
+			return false
+		}
+	}
+
+	switch {
+	// id={{owner}};type=<resource.type>;actions=<action> where type can be a
+	// wildcard; the resource must be owned by the user the grant is for. The
+	// listed items are each checked against their owner, so listing is
+	// allowed on the collection, except by a deny grant as that would deny
+	// listing the items owned by others too. Nothing is owned before it's
+	// created, so creating is never allowed.
+	case g.id == OwnerTemplate:
+		switch {
+		case g.typ == resource.Unknown,
+			g.typ != r.Type && g.typ != resource.All,
+			aType == action.Create:
+			return false
+		case r.Id == "":
+			return aType == action.List && !g.deny
+		}
+		return g.ownerId != "" && g.ownerId == r.OwnerId
+
+	// id=<resource.id>;actions=<action> where ID cannot be a wildcard; or
+	// id=<resource.id>;output_fields=<fields> where fields cannot be a
+	// wildcard.
+	case g.id == r.Id &&
+		g.id != "" &&
+		g.id != "*" &&
+		g.typ == resource.Unknown &&
+		aType != action.List &&
+		aType != action.Create:
+
+		return true
+
+	// type=<resource.type>;actions=<action> when action is list or create.
+	// Must be a top level collection, otherwise must be one of the two
+	// formats specified below. Or,
+	// type=resource.type;output_fields=<fields> and no action.
+	case g.id == "" &&
+		r.Id == "" &&
+		g.typ == r.Type &&
+		g.typ != resource.Unknown &&
+		topLevelType(r.Type) &&
+		(aType == action.List ||
+			aType == action.Create):
+
+		return true
+
+	// id=*;type=<resource.type>;actions=<action> where type cannot be
+	// unknown but can be a wildcard to allow any resource at all; or
+	// id=*;type=<resource.type>;output_fields=<fields> with no action.
+	case g.id == "*" &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type ||
+			g.typ == resource.All):
+
+		return true
+
+	// id=<pin>;type=<resource.type>;actions=<action> where type can be a
+	// wildcard and this this is operating on a non-top-level type. Same for
+	// output fields only.
+	case g.id != "" &&
+		g.id == r.Pin &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type || g.typ == resource.All) &&
+		!topLevelType(r.Type):
+
+		return true
+	}
+	return false
+}
+
+// denies reports whether a deny grant matches the action on the resource. A
+// deny grant only matches the actions it lists, or any action if it lists *,
+// so denying "cancel" still allows "cancel:self".
+func (g Grant) denies(r Resource, aType action.Type) bool {
+	if !g.actions[aType] && !g.actions[action.All] {
+		return false
+	}
+	return g.matchesResource(r, aType)
+}
+
 func topLevelType(typ resource.Type) bool {
 	switch typ {
 	case resource.AuthMethod,
@@ -183,6 +283,7 @@ func topLevelType(typ resource.Type) bool {
 		resource.Group,
 		resource.HostCatalog,
 		resource.Role,
+		resource.RoleTemplate,
 		resource.Scope,
 		resource.Session,
 		resource.Target,
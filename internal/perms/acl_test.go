@@ -66,6 +66,39 @@ func Test_ACLAllowed(t *testing.T) {
 				"id=*;type=account;actions=update;output_fields=id,version",
 			},
 		},
+		{
+			scope: "o_e",
+			grants: []string{
+				"id=*;type=*;actions=*",
+				"id=*;type=target;actions=delete;effect=deny",
+				"id=*;type=session;actions=cancel;effect=deny",
+				"id=ttcp_denied;actions=*;effect=deny",
+				"type=user;actions=create;effect=deny",
+			},
+		},
+		{
+			scope: "o_f",
+			grants: []string{
+				"type=target;labels=env:prod,team:db;actions=list,read,authorize-session",
+				"type=*;labels=env:prod;actions=update",
+				"type=*;labels=locked:true;actions=update;effect=deny",
+				"id=hcst_1;type=host;labels=env:dev;actions=read",
+			},
+		},
+		{
+			scope: "o_g",
+			grants: []string{
+				"id=*;type=target;actions=list,read",
+				"id=*;type=target;labels=env:prod;actions=list,read;effect=deny",
+			},
+		},
+		{
+			scope: "o_h",
+			grants: []string{
+				"id=*;type=*;actions=list,read",
+				"id={{owner}};type=*;actions=list,read;effect=deny",
+			},
+		},
 	}
 
 	// See acl.go for expected allowed formats. The goal here is to basically
@@ -282,6 +315,164 @@ func Test_ACLAllowed(t *testing.T) {
 				{action: action.ReadSelf, authorized: true},
 			},
 		},
+		{
+			name:        "deny takes precedence over wildcard allow",
+			resource:    Resource{ScopeId: "o_e", Id: "ttcp_1234", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true},
+				{action: action.Update, authorized: true},
+				{action: action.Delete},
+			},
+		},
+		{
+			name:        "deny parent action keeps subaction",
+			resource:    Resource{ScopeId: "o_e", Id: "s_1234", Type: resource.Session},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true},
+				{action: action.Cancel},
+				{action: action.CancelSelf, authorized: true},
+			},
+		},
+		{
+			name:        "deny all actions on id",
+			resource:    Resource{ScopeId: "o_e", Id: "ttcp_denied", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read},
+				{action: action.Delete},
+				{action: action.AuthorizeSession},
+			},
+		},
+		{
+			name:        "deny collection action",
+			resource:    Resource{ScopeId: "o_e", Type: resource.User},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Create},
+				{action: action.List, authorized: true},
+			},
+		},
+		{
+			name: "labels match",
+			resource: Resource{ScopeId: "o_f", Id: "ttcp_1234", Type: resource.Target, Labels: map[string]string{
+				"env":  "prod",
+				"team": "db",
+				"tier": "1",
+			}},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true},
+				{action: action.AuthorizeSession, authorized: true},
+				{action: action.Update, authorized: true},
+				{action: action.Delete},
+			},
+		},
+		{
+			name:        "labels partially match",
+			resource:    Resource{ScopeId: "o_f", Id: "ttcp_1234", Type: resource.Target, Labels: map[string]string{"env": "prod", "team": "web"}},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read},
+				{action: action.AuthorizeSession},
+				{action: action.Update, authorized: true},
+			},
+		},
+		{
+			name:        "labels other type",
+			resource:    Resource{ScopeId: "o_f", Id: "hsst_1234", Type: resource.HostSet, Labels: map[string]string{"env": "prod", "team": "db"}},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read},
+				{action: action.Update, authorized: true},
+			},
+		},
+		{
+			name:        "labels deny",
+			resource:    Resource{ScopeId: "o_f", Id: "hsst_1234", Type: resource.HostSet, Labels: map[string]string{"env": "prod", "locked": "true"}},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Update},
+			},
+		},
+		{
+			name:        "labels without resource labels",
+			resource:    Resource{ScopeId: "o_f", Id: "ttcp_1234", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read},
+				{action: action.Update},
+			},
+		},
+		{
+			name:        "labels allow listing",
+			resource:    Resource{ScopeId: "o_f", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.List, authorized: true},
+				{action: action.Create},
+			},
+		},
+		{
+			name:        "labels with pin",
+			resource:    Resource{ScopeId: "o_f", Id: "hst_1234", Pin: "hcst_1", Type: resource.Host, Labels: map[string]string{"env": "dev"}},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true},
+			},
+		},
+		{
+			name:        "labels deny doesn't deny listing",
+			resource:    Resource{ScopeId: "o_g", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.List, authorized: true},
+			},
+		},
+		{
+			name:        "labels deny unlabeled item",
+			resource:    Resource{ScopeId: "o_g", Id: "ttcp_1234", Type: resource.Target},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true},
+			},
+		},
+		{
+			name:        "labels deny labeled item",
+			resource:    Resource{ScopeId: "o_g", Id: "ttcp_1234", Type: resource.Target, Labels: map[string]string{"env": "prod"}},
+			scopeGrants: commonGrants,
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read},
+			},
+		},
+		{
+			name:        "owner deny doesn't deny listing",
+			resource:    Resource{ScopeId: "o_h", Type: resource.Target},
+			scopeGrants: commonGrants,
+			userId:      "u_1",
+			actionsAuthorized: []actionAuthorized{
+				{action: action.List, authorized: true},
+			},
+		},
+		{
+			name:        "owner deny other owner",
+			resource:    Resource{ScopeId: "o_h", Id: "ttcp_1234", Type: resource.Target, OwnerId: "u_2"},
+			scopeGrants: commonGrants,
+			userId:      "u_1",
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true},
+			},
+		},
+		{
+			name:        "owner deny owned",
+			resource:    Resource{ScopeId: "o_h", Id: "ttcp_1234", Type: resource.Target, OwnerId: "u_1"},
+			scopeGrants: commonGrants,
+			userId:      "u_1",
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read},
+			},
+		},
 		{
 			name:        "read self only",
 			resource:    Resource{ScopeId: "o_a", Id: "a_baz"},
@@ -312,3 +503,149 @@ func Test_ACLAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestGrant_Matches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		scope    string
+		grant    string
+		userId   string
+		resource Resource
+		action   action.Type
+		want     bool
+	}{
+		{
+			name:     "allow matches",
+			scope:    "o_a",
+			grant:    "id=*;type=target;actions=read",
+			resource: Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target},
+			action:   action.Read,
+			want:     true,
+		},
+		{
+			name:     "other scope",
+			scope:    "o_a",
+			grant:    "id=*;type=target;actions=read",
+			resource: Resource{ScopeId: "o_b", Id: "ttcp_1", Type: resource.Target},
+			action:   action.Read,
+		},
+		{
+			name:     "other action",
+			scope:    "o_a",
+			grant:    "id=*;type=target;actions=read",
+			resource: Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target},
+			action:   action.Delete,
+		},
+		{
+			name:     "parent action",
+			scope:    "o_a",
+			grant:    "id=*;type=session;actions=cancel",
+			resource: Resource{ScopeId: "o_a", Id: "s_1", Type: resource.Session},
+			action:   action.CancelSelf,
+			want:     true,
+		},
+		{
+			name:     "output fields only",
+			scope:    "o_a",
+			grant:    "id=*;type=*;output_fields=id",
+			resource: Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target},
+			action:   action.Read,
+			want:     true,
+		},
+		{
+			name:     "deny matches",
+			scope:    "o_a",
+			grant:    "id=*;type=session;actions=cancel;effect=deny",
+			resource: Resource{ScopeId: "o_a", Id: "s_1", Type: resource.Session},
+			action:   action.Cancel,
+			want:     true,
+		},
+		{
+			name:     "labels match",
+			scope:    "o_a",
+			grant:    "type=target;labels=env:prod;actions=read",
+			resource: Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target, Labels: map[string]string{"env": "prod"}},
+			action:   action.Read,
+			want:     true,
+		},
+		{
+			name:     "labels don't match",
+			scope:    "o_a",
+			grant:    "type=target;labels=env:prod;actions=read",
+			resource: Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target, Labels: map[string]string{"env": "dev"}},
+			action:   action.Read,
+		},
+		{
+			name:     "labels deny on collection",
+			scope:    "o_a",
+			grant:    "id=*;type=target;labels=env:prod;actions=list,read;effect=deny",
+			resource: Resource{ScopeId: "o_a", Type: resource.Target},
+			action:   action.List,
+		},
+		{
+			name:     "owner matches",
+			scope:    "o_a",
+			grant:    "id={{owner}};type=target;actions=update",
+			userId:   "u_1",
+			resource: Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target, OwnerId: "u_1"},
+			action:   action.Update,
+			want:     true,
+		},
+		{
+			name:     "owner doesn't match",
+			scope:    "o_a",
+			grant:    "id={{owner}};type=target;actions=update",
+			userId:   "u_1",
+			resource: Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target, OwnerId: "u_2"},
+			action:   action.Update,
+		},
+		{
+			name:     "owner unset doesn't match",
+			scope:    "o_a",
+			grant:    "id={{owner}};type=*;actions=update",
+			userId:   "u_1",
+			resource: Resource{ScopeId: "o_a", Id: "hsst_1", Type: resource.HostSet, Pin: "hcst_1"},
+			action:   action.Update,
+		},
+		{
+			name:     "owner list on collection",
+			scope:    "o_a",
+			grant:    "id={{owner}};type=host;actions=list,read",
+			resource: Resource{ScopeId: "o_a", Type: resource.Host, Pin: "hcst_1"},
+			action:   action.List,
+			want:     true,
+		},
+		{
+			name:     "owner deny list on collection",
+			scope:    "o_a",
+			grant:    "id={{owner}};type=*;actions=list;effect=deny",
+			userId:   "u_1",
+			resource: Resource{ScopeId: "o_a", Type: resource.Target},
+			action:   action.List,
+		},
+		{
+			name:     "owner other type",
+			scope:    "o_a",
+			grant:    "id={{owner}};type=group;actions=read",
+			userId:   "u_1",
+			resource: Resource{ScopeId: "o_a", Id: "ttcp_1", Type: resource.Target, OwnerId: "u_1"},
+			action:   action.Read,
+		},
+		{
+			name:     "deny doesn't match subaction",
+			scope:    "o_a",
+			grant:    "id=*;type=session;actions=cancel;effect=deny",
+			resource: Resource{ScopeId: "o_a", Id: "s_1", Type: resource.Session},
+			action:   action.CancelSelf,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Parse(tt.scope, tt.grant, WithUserId(tt.userId))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, g.Matches(tt.resource, tt.action))
+		})
+	}
+}
@@ -12,11 +12,24 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 )
 
+const (
+	// GrantEffectAllow is the default effect of a grant, authorizing the
+	// actions it lists.
+	GrantEffectAllow = "allow"
+
+	// GrantEffectDeny makes a grant deny the actions it lists, taking
+	// precedence over any grant allowing them.
+	GrantEffectDeny = "deny"
+)
+
 // GrantPair is simply a struct that can be reference from other code to return
 // a set of scopes and grants to parse
 type GrantPair struct {
 	ScopeId string
 	Grant   string
+
+	// RoleId is the role the grant comes from, if known
+	RoleId string
 }
 
 // Scope provides an in-memory representation of iam.Scope without the
@@ -40,9 +53,19 @@ type Grant struct {
 	// The type, if provided
 	typ resource.Type
 
+	// The labels a resource must have for the grant to match it, if provided
+	labels map[string]string
+
+	// The user a resource must be owned by for the grant to match it, when
+	// the ID is the owner template
+	ownerId string
+
 	// The set of actions being granted
 	actions map[action.Type]bool
 
+	// Whether the grant denies its actions instead of allowing them
+	deny bool
+
 	// The set of output fields granted
 	OutputFields OutputFieldsMap
 
@@ -59,6 +82,16 @@ func (g Grant) Type() resource.Type {
 	return g.typ
 }
 
+// Labels returns the labels a resource must have for the grant to match it
+func (g Grant) Labels() map[string]string {
+	return g.labels
+}
+
+// Deny returns whether the grant denies its actions instead of allowing them.
+func (g Grant) Deny() bool {
+	return g.deny
+}
+
 func (g Grant) Actions() (typs []action.Type, strs []string) {
 	typs = make([]action.Type, 0, len(g.actions))
 	strs = make([]string, 0, len(g.actions))
@@ -75,9 +108,17 @@ func (g Grant) Actions() (typs []action.Type, strs []string) {
 
 func (g Grant) clone() *Grant {
 	ret := &Grant{
-		scope: g.scope,
-		id:    g.id,
-		typ:   g.typ,
+		scope:   g.scope,
+		id:      g.id,
+		typ:     g.typ,
+		ownerId: g.ownerId,
+		deny:    g.deny,
+	}
+	if g.labels != nil {
+		ret.labels = make(map[string]string, len(g.labels))
+		for k, v := range g.labels {
+			ret.labels[k] = v
+		}
 	}
 	if g.actionsBeingParsed != nil {
 		ret.actionsBeingParsed = append(ret.actionsBeingParsed, g.actionsBeingParsed...)
@@ -109,6 +150,10 @@ func (g Grant) CanonicalString() string {
 		builder = append(builder, fmt.Sprintf("type=%s", g.typ.String()))
 	}
 
+	if len(g.labels) > 0 {
+		builder = append(builder, fmt.Sprintf("labels=%s", labelsString(g.labels)))
+	}
+
 	if len(g.actions) > 0 {
 		actions := make([]string, 0, len(g.actions))
 		for action := range g.actions {
@@ -118,6 +163,10 @@ func (g Grant) CanonicalString() string {
 		builder = append(builder, fmt.Sprintf("actions=%s", strings.Join(actions, ",")))
 	}
 
+	if g.deny {
+		builder = append(builder, fmt.Sprintf("effect=%s", GrantEffectDeny))
+	}
+
 	if len(g.OutputFields) > 0 {
 		builder = append(builder, fmt.Sprintf("output_fields=%s", strings.Join(g.OutputFields.Fields(), ",")))
 	}
@@ -128,13 +177,16 @@ func (g Grant) CanonicalString() string {
 // MarshalJSON provides a custom marshaller for grants
 func (g Grant) MarshalJSON() ([]byte, error) {
 	const op = "perms.(Grant).MarshalJSON"
-	res := make(map[string]interface{}, 4)
+	res := make(map[string]interface{}, 5)
 	if g.id != "" {
 		res["id"] = g.id
 	}
 	if g.typ != resource.Unknown {
 		res["type"] = g.typ.String()
 	}
+	if len(g.labels) > 0 {
+		res["labels"] = g.labels
+	}
 	if len(g.actions) > 0 {
 		actions := make([]string, 0, len(g.actions))
 		for action := range g.actions {
@@ -143,6 +195,9 @@ func (g Grant) MarshalJSON() ([]byte, error) {
 		sort.Strings(actions)
 		res["actions"] = actions
 	}
+	if g.deny {
+		res["effect"] = GrantEffectDeny
+	}
 	if len(g.OutputFields) > 0 {
 		res["output_fields"] = g.OutputFields.Fields()
 	}
@@ -179,6 +234,22 @@ func (g *Grant) unmarshalJSON(data []byte) error {
 			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unknown type specifier %q", typ))
 		}
 	}
+	if rawLabels, ok := raw["labels"]; ok {
+		interfaceLabels, ok := rawLabels.(map[string]interface{})
+		if !ok {
+			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as object", "labels"))
+		}
+		g.labels = make(map[string]string, len(interfaceLabels))
+		for k, v := range interfaceLabels {
+			value, ok := v.(string)
+			if !ok {
+				return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret value of label %q as string", k))
+			}
+			if err := setLabel(g.labels, k, value); err != nil {
+				return errors.Wrap(err, op)
+			}
+		}
+	}
 	if rawActions, ok := raw["actions"]; ok {
 		interfaceActions, ok := rawActions.([]interface{})
 		if !ok {
@@ -199,6 +270,15 @@ func (g *Grant) unmarshalJSON(data []byte) error {
 			}
 		}
 	}
+	if rawEffect, ok := raw["effect"]; ok {
+		effect, ok := rawEffect.(string)
+		if !ok {
+			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as string", "effect"))
+		}
+		if err := g.setEffect(effect); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
 	if rawOutputFields, ok := raw["output_fields"]; ok {
 		interfaceOutputFields, ok := rawOutputFields.([]interface{})
 		if !ok {
@@ -249,6 +329,13 @@ func (g *Grant) unmarshalText(grantString string) error {
 				return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unknown type specifier %q", typeString))
 			}
 
+		case "labels":
+			labels, err := parseLabels(kv[1])
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			g.labels = labels
+
 		case "actions":
 			actions := strings.Split(kv[1], ",")
 			if len(actions) > 0 {
@@ -261,6 +348,11 @@ func (g *Grant) unmarshalText(grantString string) error {
 				}
 			}
 
+		case "effect":
+			if err := g.setEffect(kv[1]); err != nil {
+				return errors.Wrap(err, op)
+			}
+
 		case "output_fields":
 			g.OutputFields = g.OutputFields.AddFields(strings.Split(kv[1], ","))
 		}
@@ -269,6 +361,21 @@ func (g *Grant) unmarshalText(grantString string) error {
 	return nil
 }
 
+// setEffect sets whether the grant denies its actions from the value of its
+// effect field.
+func (g *Grant) setEffect(effect string) error {
+	const op = "perms.(Grant).setEffect"
+	switch strings.ToLower(effect) {
+	case GrantEffectAllow:
+		g.deny = false
+	case GrantEffectDeny:
+		g.deny = true
+	default:
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unknown effect %q", effect))
+	}
+	return nil
+}
+
 // Parse parses a grant string. Note that this does not do checking
 // of the validity of IDs and such; that's left for other parts of the system.
 // We may not check at all (e.g. let it be an authz-time failure) or could check
@@ -311,6 +418,23 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 		}
 	}
 
+	// Labels narrow down the resources of a type the grant matches. Without an
+	// ID they match any resource of the type that has the labels, the same as
+	// with a wildcard ID.
+	if grant.labels != nil {
+		switch {
+		case len(grant.labels) == 0:
+			return Grant{}, errors.New(errors.InvalidParameter, op, "parsed grant string has labels set but empty")
+		case grant.typ == resource.Unknown:
+			return Grant{}, errors.New(errors.InvalidParameter, op, "parsed grant string has labels set without a type")
+		case grant.typ != resource.All && !LabeledType(grant.typ):
+			return Grant{}, errors.New(errors.InvalidParameter, op, fmt.Sprintf("parsed grant string has labels set on type %q, which can't have labels", grant.typ.String()))
+		}
+		if grant.id == "" {
+			grant.id = "*"
+		}
+	}
+
 	opts := getOpts(opt...)
 
 	// Check for templated values ID, and substitute in with the authenticated values
@@ -327,6 +451,11 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 			if opts.withAccountId != "" {
 				grant.id = opts.withAccountId
 			}
+		case "owner":
+			// The ID stays templated, as it matches each resource owned by
+			// the user rather than a single resource
+			grant.id = OwnerTemplate
+			grant.ownerId = opts.withUserId
 		default:
 			return Grant{}, errors.New(errors.InvalidParameter, op, fmt.Sprintf("unknown template %q in grant %q value", grant.id, "id"))
 		}
@@ -336,10 +465,33 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 		return Grant{}, errors.Wrap(err, op)
 	}
 
+	// The owner template matches on the owner of resources of a type, so the
+	// type must be given and have owners
+	if grant.id == OwnerTemplate {
+		switch {
+		case grant.typ == resource.Unknown:
+			return Grant{}, errors.New(errors.InvalidParameter, op, "parsed grant string uses the owner template without a type")
+		case grant.typ != resource.All && !OwnedType(grant.typ):
+			return Grant{}, errors.New(errors.InvalidParameter, op, fmt.Sprintf("parsed grant string uses the owner template on type %q, which can't be owned", grant.typ.String()))
+		}
+	}
+
 	if err := grant.parseAndValidateActions(); err != nil {
 		return Grant{}, errors.Wrap(err, op)
 	}
 
+	// A deny grant only takes away actions, so output fields make no sense on
+	// it
+	if grant.deny && len(grant.OutputFields) > 0 {
+		return Grant{}, errors.New(errors.InvalidParameter, op, "parsed grant string has output_fields set on a deny grant")
+	}
+
+	for field := range grant.OutputFields {
+		if err := validateOutputField(field); err != nil {
+			return Grant{}, errors.Wrap(err, op)
+		}
+	}
+
 	if !opts.withSkipFinalValidation {
 		// Filter out some forms that don't make sense
 
@@ -394,12 +546,21 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 		// This might be zero if output fields is populated
 		if len(grant.actions) > 0 {
 			// Create a dummy resource and pass it through Allowed and ensure that
-			// we get allowed.
-			acl := NewACL(grant)
+			// we get allowed. A deny grant is checked as if it allowed its
+			// actions, to make sure it would match them.
+			allowGrant := grant
+			allowGrant.deny = false
+			if allowGrant.id == OwnerTemplate && allowGrant.ownerId == "" {
+				// The grants of any user can be checked this way
+				allowGrant.ownerId = AnonymousUserId
+			}
+			acl := NewACL(allowGrant)
 			r := Resource{
 				ScopeId: scopeId,
 				Id:      grant.id,
 				Type:    grant.typ,
+				Labels:  grant.labels,
+				OwnerId: allowGrant.ownerId,
 			}
 			if !topLevelType(grant.typ) {
 				r.Pin = grant.id
@@ -429,6 +590,7 @@ func (g Grant) validateType() error {
 		resource.User,
 		resource.Group,
 		resource.Role,
+		resource.RoleTemplate,
 		resource.AuthMethod,
 		resource.Account,
 		resource.AuthToken,
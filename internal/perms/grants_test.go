@@ -209,6 +209,41 @@ func Test_MarshalingAndCloning(t *testing.T) {
 			jsonOutput:      `{"actions":["create","read"],"id":"baz","output_fields":["id","name","version"],"type":"group"}`,
 			canonicalString: `id=baz;type=group;actions=create,read;output_fields=id,name,version`,
 		},
+		{
+			name: "deny",
+			input: Grant{
+				id: "baz",
+				scope: Scope{
+					Type: scope.Project,
+				},
+				typ: resource.Group,
+				actions: map[action.Type]bool{
+					action.Delete: true,
+				},
+				deny: true,
+			},
+			jsonOutput:      `{"actions":["delete"],"effect":"deny","id":"baz","type":"group"}`,
+			canonicalString: `id=baz;type=group;actions=delete;effect=deny`,
+		},
+		{
+			name: "labels",
+			input: Grant{
+				id: "*",
+				scope: Scope{
+					Type: scope.Project,
+				},
+				typ: resource.Target,
+				labels: map[string]string{
+					"team": "db",
+					"env":  "prod",
+				},
+				actions: map[action.Type]bool{
+					action.AuthorizeSession: true,
+				},
+			},
+			jsonOutput:      `{"actions":["authorize-session"],"id":"*","labels":{"env":"prod","team":"db"},"type":"target"}`,
+			canonicalString: `id=*;type=target;labels=env:prod,team:db;actions=authorize-session`,
+		},
 	}
 
 	for _, test := range tests {
@@ -325,6 +360,62 @@ func Test_Unmarshaling(t *testing.T) {
 			textInput: `actions=,`,
 			textErr:   `perms.(Grant).unmarshalText: empty action found: parameter violation: error #100`,
 		},
+		{
+			name: "deny effect",
+			expected: Grant{
+				deny: true,
+			},
+			jsonInput: `{"effect":"deny"}`,
+			textInput: `effect=DENY`,
+		},
+		{
+			name: "labels",
+			expected: Grant{
+				labels: map[string]string{
+					"env":  "prod",
+					"team": "db",
+				},
+			},
+			jsonInput: `{"labels":{"env":"prod","team":"db"}}`,
+			textInput: `labels=env:prod,team:db`,
+		},
+		{
+			name:      "bad label format",
+			jsonInput: `{"labels":{"env":1}}`,
+			jsonErr:   `perms.(Grant).unmarshalJSON: unable to interpret value of label "env" as string: parameter violation: error #100`,
+			textInput: `labels=env`,
+			textErr:   `perms.(Grant).unmarshalText: perms.parseLabels: label "env" not formatted correctly, must be key:value: parameter violation: error #100`,
+		},
+		{
+			name:      "bad label key",
+			jsonInput: `{"labels":{"e nv":"prod"}}`,
+			jsonErr:   `perms.(Grant).unmarshalJSON: perms.setLabel: perms.ValidateLabel: invalid label key "e nv": parameter violation: error #100`,
+			textInput: `labels=e nv:prod`,
+			textErr:   `perms.(Grant).unmarshalText: perms.parseLabels: perms.setLabel: perms.ValidateLabel: invalid label key "e nv": parameter violation: error #100`,
+		},
+		{
+			name:      "duplicate label key",
+			textInput: `labels=env:prod,env:dev`,
+			textErr:   `perms.(Grant).unmarshalText: perms.parseLabels: perms.setLabel: label key "env" given more than once: parameter violation: error #100`,
+		},
+		{
+			name:      "allow effect",
+			expected:  Grant{},
+			jsonInput: `{"effect":"allow"}`,
+			textInput: `effect=allow`,
+		},
+		{
+			name:      "bad effect",
+			jsonInput: `{"effect":"maybe"}`,
+			jsonErr:   `perms.(Grant).unmarshalJSON: perms.(Grant).setEffect: unknown effect "maybe": parameter violation: error #100`,
+			textInput: `effect=maybe`,
+			textErr:   `perms.(Grant).unmarshalText: perms.(Grant).setEffect: unknown effect "maybe": parameter violation: error #100`,
+		},
+		{
+			name:      "bad json effect",
+			jsonInput: `{"effect":true}`,
+			jsonErr:   `perms.(Grant).unmarshalJSON: unable to interpret "effect" as string: parameter violation: error #100`,
+		},
 		{
 			name:      "bad json action",
 			jsonInput: `{"actions":[1, true]}`,
@@ -450,6 +541,160 @@ func Test_Parse(t *testing.T) {
 			input: `{"id": "*", "type": "*", "actions": ["read", "list"], "output_fields": []}`,
 			err:   "perms.Parse: parsed grant string has output_fields set but empty: parameter violation: error #100",
 		},
+		{
+			name:  "nested output field with empty segment",
+			input: "id=*;type=*;actions=read;output_fields=id,scope..name",
+			err:   `perms.Parse: perms.validateOutputField: output field "scope..name" has an empty path segment: parameter violation: error #100`,
+		},
+		{
+			name:  "deny with output fields",
+			input: "id=*;type=*;actions=read;effect=deny;output_fields=id",
+			err:   `perms.Parse: parsed grant string has output_fields set on a deny grant: parameter violation: error #100`,
+		},
+		{
+			name:  "deny without actions",
+			input: "id=*;type=*;effect=deny",
+			err:   `perms.Parse: perms.(Grant).parseAndValidateActions: missing actions: parameter violation: error #100`,
+		},
+		{
+			name:  "deny in format that would not match",
+			input: "id=*;actions=delete;effect=deny",
+			err:   `perms.Parse: parsed grant string would not result in any action being authorized: parameter violation: error #100`,
+		},
+		{
+			name:  "labels without type",
+			input: "id=*;labels=env:prod;actions=read",
+			err:   `perms.Parse: parsed grant string has labels set without a type: parameter violation: error #100`,
+		},
+		{
+			name:  "labels on type without labels",
+			input: "type=user;labels=env:prod;actions=read",
+			err:   `perms.Parse: parsed grant string has labels set on type "user", which can't have labels: parameter violation: error #100`,
+		},
+		{
+			name:  "empty labels json",
+			input: `{"type":"target","labels":{},"actions":["read"]}`,
+			err:   `perms.Parse: parsed grant string has labels set but empty: parameter violation: error #100`,
+		},
+		{
+			name:  "good text labels",
+			input: "type=target;labels=env:prod,team:db;actions=authorize-session",
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id:  "*",
+				typ: resource.Target,
+				labels: map[string]string{
+					"env":  "prod",
+					"team": "db",
+				},
+				actions: map[action.Type]bool{
+					action.AuthorizeSession: true,
+				},
+			},
+		},
+		{
+			name:  "good json labels deny",
+			input: `{"type":"*","labels":{"env":"prod"},"actions":["delete"],"effect":"deny"}`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id:  "*",
+				typ: resource.All,
+				labels: map[string]string{
+					"env": "prod",
+				},
+				actions: map[action.Type]bool{
+					action.Delete: true,
+				},
+				deny: true,
+			},
+		},
+		{
+			name:  "owner template without type",
+			input: "id={{owner}};actions=read",
+			err:   `perms.Parse: parsed grant string uses the owner template without a type: parameter violation: error #100`,
+		},
+		{
+			name:  "owner template on type without owners",
+			input: "id={{owner}};type=role;actions=read",
+			err:   `perms.Parse: parsed grant string uses the owner template on type "role", which can't be owned: parameter violation: error #100`,
+		},
+		{
+			name:  "owner template with only create",
+			input: "id={{owner}};type=target;actions=create",
+			err:   `perms.Parse: parsed grant string would not result in any action being authorized: parameter violation: error #100`,
+		},
+		{
+			name:   "good owner template",
+			input:  "id={{ owner }};type=target;actions=read,update,transfer-ownership",
+			userId: "u_abcd1234",
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id:      "{{owner}}",
+				typ:     resource.Target,
+				ownerId: "u_abcd1234",
+				actions: map[action.Type]bool{
+					action.Read:              true,
+					action.Update:            true,
+					action.TransferOwnership: true,
+				},
+			},
+		},
+		{
+			name:  "good json owner template without user",
+			input: `{"id":"{{owner}}","type":"*","actions":["delete"]}`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id:  "{{owner}}",
+				typ: resource.All,
+				actions: map[action.Type]bool{
+					action.Delete: true,
+				},
+			},
+		},
+		{
+			name:  "good text deny",
+			input: "id=*;type=target;actions=delete;effect=deny",
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id:  "*",
+				typ: resource.Target,
+				actions: map[action.Type]bool{
+					action.Delete: true,
+				},
+				deny: true,
+			},
+		},
+		{
+			name:  "good json deny",
+			input: `{"id":"*","type":"session","actions":["cancel"],"effect":"deny"}`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id:  "*",
+				typ: resource.Session,
+				actions: map[action.Type]bool{
+					action.Cancel: true,
+				},
+				deny: true,
+			},
+		},
 		{
 			name:  "wildcard id and type and actions with list",
 			input: "id=*;type=*;actions=read,list",
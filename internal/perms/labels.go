@@ -0,0 +1,96 @@
+package perms
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+// Label keys and values can't contain the characters used to separate them in
+// grant strings
+var labelRegexp = regexp.MustCompile(`^[a-zA-Z0-9_./-]+$`)
+
+// LabeledType reports whether resources of the type can have labels, and so
+// be matched by grants on their labels.
+func LabeledType(typ resource.Type) bool {
+	switch typ {
+	case resource.Scope,
+		resource.Target,
+		resource.HostCatalog,
+		resource.HostSet,
+		resource.Host:
+		return true
+	}
+	return false
+}
+
+// ValidateLabel returns an error if the key or value can't be used for a
+// label. Both must be non-empty and consist of letters, digits and the
+// characters "_", ".", "/" and "-".
+func ValidateLabel(key, value string) error {
+	const op = "perms.ValidateLabel"
+	switch {
+	case !labelRegexp.MatchString(key):
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("invalid label key %q", key))
+	case !labelRegexp.MatchString(value):
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("invalid label value %q for key %q", value, key))
+	}
+	return nil
+}
+
+// parseLabels parses labels in the grant string format, e.g.
+// "env:prod,team:db"
+func parseLabels(in string) (map[string]string, error) {
+	const op = "perms.parseLabels"
+	ret := make(map[string]string)
+	for _, label := range strings.Split(in, ",") {
+		kv := strings.Split(label, ":")
+		if len(kv) != 2 {
+			return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("label %q not formatted correctly, must be key:value", label))
+		}
+		if err := setLabel(ret, kv[0], kv[1]); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+	return ret, nil
+}
+
+func setLabel(labels map[string]string, key, value string) error {
+	const op = "perms.setLabel"
+	if err := ValidateLabel(key, value); err != nil {
+		return errors.Wrap(err, op)
+	}
+	if _, ok := labels[key]; ok {
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("label key %q given more than once", key))
+	}
+	labels[key] = value
+	return nil
+}
+
+// labelsString returns the labels in the grant string format, sorted by key
+func labelsString(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	ret := make([]string, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, fmt.Sprintf("%s:%s", k, labels[k]))
+	}
+	return strings.Join(ret, ",")
+}
+
+// labelsMatch reports whether the resource has every label of the grant
+func (g Grant) labelsMatch(r Resource) bool {
+	for k, v := range g.labels {
+		if rv, ok := r.Labels[k]; !ok || rv != v {
+			return false
+		}
+	}
+	return true
+}
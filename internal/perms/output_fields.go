@@ -1,15 +1,35 @@
 package perms
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/errors"
 )
 
 // OutputFieldsMap is used to store information about allowed output fields in
-// grants
+// grants. Fields of nested objects can be given as dotted paths, e.g.
+// "scope.name", in which case only those nested fields are output. A "*"
+// segment in a path matches any field at that level.
 type OutputFieldsMap map[string]bool
 
+// outputFieldSeparator separates the segments of a nested output field path
+const outputFieldSeparator = "."
+
+// validateOutputField returns an error if the output field is a nested path
+// with an empty segment, e.g. "scope." or "scope..name"
+func validateOutputField(field string) error {
+	const op = "perms.validateOutputField"
+	for _, segment := range strings.Split(field, outputFieldSeparator) {
+		if segment == "" {
+			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("output field %q has an empty path segment", field))
+		}
+	}
+	return nil
+}
+
 // AddFields adds the given fields and returns the map.
 func (o OutputFieldsMap) AddFields(input []string) (ret OutputFieldsMap) {
 	switch {
@@ -85,12 +105,29 @@ func (o OutputFieldsMap) SelfOrDefaults(userId string) OutputFieldsMap {
 	}
 }
 
-// Has returns true if the value exists; that is, it is directly in the map, or
-// the map contains *
+// Has returns true if the value exists; that is, it is directly in the map, the
+// map contains *, or the map contains a path nested in the value
 func (o OutputFieldsMap) Has(in string) bool {
 	// Handle nil or empty case
 	if len(o) == 0 {
 		return false
 	}
-	return o.HasAll() || o[in]
+	return len(o.Nested(in)) > 0
+}
+
+// Nested returns the output fields for the fields nested in the given field.
+// It contains * if the whole field is output and is nil if none of it is.
+func (o OutputFieldsMap) Nested(in string) OutputFieldsMap {
+	if o.HasAll() || o[in] {
+		return OutputFieldsMap{"*": true}
+	}
+	var ret OutputFieldsMap
+	for f := range o {
+		parts := strings.SplitN(f, outputFieldSeparator, 2)
+		if len(parts) != 2 || (parts[0] != in && parts[0] != "*") {
+			continue
+		}
+		ret = ret.AddFields([]string{parts[1]})
+	}
+	return ret
 }
@@ -72,6 +72,76 @@ func Test_OutputFields(t *testing.T) {
 	}
 }
 
+func Test_OutputFieldsNested(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		fields  OutputFieldsMap
+		field   string
+		nested  OutputFieldsMap
+		hasAll  bool
+		present bool
+	}{
+		{
+			name:  "nil map",
+			field: "scope",
+		},
+		{
+			name:    "star",
+			fields:  OutputFieldsMap{"*": true},
+			field:   "scope",
+			nested:  OutputFieldsMap{"*": true},
+			hasAll:  true,
+			present: true,
+		},
+		{
+			name:    "top level",
+			fields:  OutputFieldsMap{"id": true, "scope": true},
+			field:   "scope",
+			nested:  OutputFieldsMap{"*": true},
+			hasAll:  true,
+			present: true,
+		},
+		{
+			name:   "missing",
+			fields: OutputFieldsMap{"id": true, "scope.name": true},
+			field:  "name",
+		},
+		{
+			name:    "nested paths",
+			fields:  OutputFieldsMap{"id": true, "scope.id": true, "scope.parent.name": true},
+			field:   "scope",
+			nested:  OutputFieldsMap{"id": true, "parent.name": true},
+			present: true,
+		},
+		{
+			name:    "nested star",
+			fields:  OutputFieldsMap{"id": true, "scope.*": true},
+			field:   "scope",
+			nested:  OutputFieldsMap{"*": true},
+			hasAll:  true,
+			present: true,
+		},
+		{
+			name:    "wildcard segment",
+			fields:  OutputFieldsMap{"*.id": true, "host_sets.host_catalog_id": true},
+			field:   "host_sets",
+			nested:  OutputFieldsMap{"id": true, "host_catalog_id": true},
+			present: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			nested := tt.fields.Nested(tt.field)
+			assert.Equal(tt.nested, nested)
+			assert.Equal(tt.hasAll, nested.HasAll())
+			assert.Equal(tt.present, tt.fields.Has(tt.field))
+		})
+	}
+}
+
 func Test_ACLOutputFields(t *testing.T) {
 	t.Parallel()
 
@@ -181,6 +251,17 @@ func Test_ACLOutputFields(t *testing.T) {
 			fields:     []string{"id"},
 			authorized: true,
 		},
+		{
+			name:     "nested",
+			resource: Resource{ScopeId: "o_myorg", Id: "bar", Type: resource.Role},
+			grants: []string{
+				"id=bar;actions=read;output_fields=id,scope.id,scope.name",
+				"id=*;type=role;output_fields=grants.raw",
+			},
+			action:     action.Read,
+			fields:     []string{"grants.raw", "id", "scope.id", "scope.name"},
+			authorized: true,
+		},
 		{
 			name:     "initial grant unauthorized with star",
 			resource: Resource{ScopeId: "o_myorg", Id: "bar", Type: resource.Role},
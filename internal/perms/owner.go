@@ -0,0 +1,30 @@
+package perms
+
+import "github.com/hashicorp/boundary/internal/types/resource"
+
+// OwnerTemplate is the templated grant ID matching the resources owned by the
+// user the grants are for
+const OwnerTemplate = "{{owner}}"
+
+// OwnedType reports whether resources of the type have an owner, and so can
+// be matched by grants using the owner template.
+func OwnedType(typ resource.Type) bool {
+	switch typ {
+	case resource.Target,
+		resource.HostSet,
+		resource.Host,
+		resource.Group:
+		return true
+	}
+	return false
+}
+
+// CanOwn reports whether resources can be owned by the user. The special
+// anonymous, authenticated and recovery users never own anything.
+func CanOwn(userId string) bool {
+	switch userId {
+	case "", AnonymousUserId, "u_auth", "u_recovery":
+		return false
+	}
+	return true
+}
@@ -28,6 +28,7 @@ const (
 	// our purposes it simply means a normal connection.
 	HANDSHAKECOMMAND_HANDSHAKECOMMAND_UNSPECIFIED    HANDSHAKECOMMAND = 0
 	HANDSHAKECOMMAND_HANDSHAKECOMMAND_SESSION_CANCEL HANDSHAKECOMMAND = 1
+	HANDSHAKECOMMAND_HANDSHAKECOMMAND_SSH_HOST_KEY   HANDSHAKECOMMAND = 2
 )
 
 // Enum value maps for HANDSHAKECOMMAND.
@@ -35,10 +36,12 @@ var (
 	HANDSHAKECOMMAND_name = map[int32]string{
 		0: "HANDSHAKECOMMAND_UNSPECIFIED",
 		1: "HANDSHAKECOMMAND_SESSION_CANCEL",
+		2: "HANDSHAKECOMMAND_SSH_HOST_KEY",
 	}
 	HANDSHAKECOMMAND_value = map[string]int32{
 		"HANDSHAKECOMMAND_UNSPECIFIED":    0,
 		"HANDSHAKECOMMAND_SESSION_CANCEL": 1,
+		"HANDSHAKECOMMAND_SSH_HOST_KEY":   2,
 	}
 )
 
@@ -187,6 +190,82 @@ func (x *HandshakeResult) GetConnectionsLeft() int32 {
 	return 0
 }
 
+// SshHostKeyResult is sent in response to a HANDSHAKECOMMAND_SSH_HOST_KEY
+// handshake. Keys are in the SSH wire format.
+type SshHostKeyResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The key pinned for the session's host, which clients should trust.
+	HostKey     []byte `protobuf:"bytes,10,opt,name=host_key,json=hostKey,proto3" json:"host_key,omitempty"`
+	Fingerprint string `protobuf:"bytes,20,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	// The key the host presented to the worker. It differs from host_key if
+	// the host's key has changed since it was pinned.
+	PresentedHostKey     []byte `protobuf:"bytes,30,opt,name=presented_host_key,json=presentedHostKey,proto3" json:"presented_host_key,omitempty"`
+	PresentedFingerprint string `protobuf:"bytes,40,opt,name=presented_fingerprint,json=presentedFingerprint,proto3" json:"presented_fingerprint,omitempty"`
+}
+
+func (x *SshHostKeyResult) Reset() {
+	*x = SshHostKeyResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_worker_proxy_v1_proxy_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SshHostKeyResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SshHostKeyResult) ProtoMessage() {}
+
+func (x *SshHostKeyResult) ProtoReflect() protoreflect.Message {
+	mi := &file_worker_proxy_v1_proxy_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SshHostKeyResult.ProtoReflect.Descriptor instead.
+func (*SshHostKeyResult) Descriptor() ([]byte, []int) {
+	return file_worker_proxy_v1_proxy_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SshHostKeyResult) GetHostKey() []byte {
+	if x != nil {
+		return x.HostKey
+	}
+	return nil
+}
+
+func (x *SshHostKeyResult) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *SshHostKeyResult) GetPresentedHostKey() []byte {
+	if x != nil {
+		return x.PresentedHostKey
+	}
+	return nil
+}
+
+func (x *SshHostKeyResult) GetPresentedFingerprint() string {
+	if x != nil {
+		return x.PresentedFingerprint
+	}
+	return ""
+}
+
 var File_worker_proxy_v1_proxy_proto protoreflect.FileDescriptor
 
 var file_worker_proxy_v1_proxy_proto_rawDesc = []byte{
@@ -212,16 +291,30 @@ var file_worker_proxy_v1_proxy_proto_rawDesc = []byte{
 	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6e,
 	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x1e, 0x20,
 	0x01, 0x28, 0x05, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x4c, 0x65, 0x66, 0x74, 0x2a, 0x59, 0x0a, 0x10, 0x48, 0x41, 0x4e, 0x44, 0x53, 0x48, 0x41, 0x4b,
-	0x45, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x12, 0x20, 0x0a, 0x1c, 0x48, 0x41, 0x4e, 0x44,
-	0x53, 0x48, 0x41, 0x4b, 0x45, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53,
-	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x48, 0x41,
-	0x4e, 0x44, 0x53, 0x48, 0x41, 0x4b, 0x45, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x53,
-	0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x10, 0x01, 0x42,
-	0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x61,
-	0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2f, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79,
-	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x3b,
-	0x70, 0x72, 0x6f, 0x78, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4c, 0x65, 0x66, 0x74, 0x22, 0xb2, 0x01, 0x0a, 0x10, 0x53, 0x73, 0x68, 0x48, 0x6f, 0x73, 0x74,
+	0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x6f, 0x73,
+	0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x68, 0x6f, 0x73,
+	0x74, 0x4b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72,
+	0x69, 0x6e, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69, 0x6e, 0x67, 0x65,
+	0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e,
+	0x74, 0x65, 0x64, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x1e, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x10, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x48, 0x6f, 0x73,
+	0x74, 0x4b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x15, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x65,
+	0x64, 0x5f, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x28, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x14, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x46, 0x69,
+	0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x2a, 0x7c, 0x0a, 0x10, 0x48, 0x41, 0x4e,
+	0x44, 0x53, 0x48, 0x41, 0x4b, 0x45, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x12, 0x20, 0x0a,
+	0x1c, 0x48, 0x41, 0x4e, 0x44, 0x53, 0x48, 0x41, 0x4b, 0x45, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e,
+	0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x23, 0x0a, 0x1f, 0x48, 0x41, 0x4e, 0x44, 0x53, 0x48, 0x41, 0x4b, 0x45, 0x43, 0x4f, 0x4d, 0x4d,
+	0x41, 0x4e, 0x44, 0x5f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x41, 0x4e, 0x43,
+	0x45, 0x4c, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x48, 0x41, 0x4e, 0x44, 0x53, 0x48, 0x41, 0x4b,
+	0x45, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x53, 0x53, 0x48, 0x5f, 0x48, 0x4f, 0x53,
+	0x54, 0x5f, 0x4b, 0x45, 0x59, 0x10, 0x02, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70, 0x2f,
+	0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x3b, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -237,16 +330,17 @@ func file_worker_proxy_v1_proxy_proto_rawDescGZIP() []byte {
 }
 
 var file_worker_proxy_v1_proxy_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_worker_proxy_v1_proxy_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_worker_proxy_v1_proxy_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_worker_proxy_v1_proxy_proto_goTypes = []interface{}{
 	(HANDSHAKECOMMAND)(0),         // 0: worker.proxy.v1.HANDSHAKECOMMAND
 	(*ClientHandshake)(nil),       // 1: worker.proxy.v1.ClientHandshake
 	(*HandshakeResult)(nil),       // 2: worker.proxy.v1.HandshakeResult
-	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*SshHostKeyResult)(nil),      // 3: worker.proxy.v1.SshHostKeyResult
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
 }
 var file_worker_proxy_v1_proxy_proto_depIdxs = []int32{
 	0, // 0: worker.proxy.v1.ClientHandshake.command:type_name -> worker.proxy.v1.HANDSHAKECOMMAND
-	3, // 1: worker.proxy.v1.HandshakeResult.expiration:type_name -> google.protobuf.Timestamp
+	4, // 1: worker.proxy.v1.HandshakeResult.expiration:type_name -> google.protobuf.Timestamp
 	2, // [2:2] is the sub-list for method output_type
 	2, // [2:2] is the sub-list for method input_type
 	2, // [2:2] is the sub-list for extension type_name
@@ -284,6 +378,18 @@ func file_worker_proxy_v1_proxy_proto_init() {
 				return nil
 			}
 		}
+		file_worker_proxy_v1_proxy_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SshHostKeyResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -291,7 +397,7 @@ func file_worker_proxy_v1_proxy_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_worker_proxy_v1_proxy_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
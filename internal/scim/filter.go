@@ -0,0 +1,385 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// filter is a parsed SCIM filter which can be matched against the attributes
+// of a resource.  Attribute names are matched case-insensitively and string
+// values are compared case-insensitively.
+//
+// See: https://tools.ietf.org/html/rfc7644#section-3.4.2.2
+type filter interface {
+	match(attrs map[string]interface{}) bool
+}
+
+type logicalFilter struct {
+	and         bool
+	left, right filter
+}
+
+func (f *logicalFilter) match(attrs map[string]interface{}) bool {
+	if f.and {
+		return f.left.match(attrs) && f.right.match(attrs)
+	}
+	return f.left.match(attrs) || f.right.match(attrs)
+}
+
+type notFilter struct {
+	f filter
+}
+
+func (f *notFilter) match(attrs map[string]interface{}) bool {
+	return !f.f.match(attrs)
+}
+
+type compareFilter struct {
+	path  []string
+	op    string
+	value interface{}
+}
+
+func (f *compareFilter) match(attrs map[string]interface{}) bool {
+	values := resolve(attrs, f.path)
+	if f.op == "pr" {
+		for _, v := range values {
+			if v != nil && v != "" {
+				return true
+			}
+		}
+		return false
+	}
+	if f.op == "ne" {
+		for _, v := range values {
+			if compare(v, "eq", f.value) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range values {
+		if compare(v, f.op, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// valuePathFilter matches when any value of the multi-valued attribute at path
+// matches the filter, e.g. members[value eq "u_1234567890"]
+type valuePathFilter struct {
+	path []string
+	f    filter
+}
+
+func (f *valuePathFilter) match(attrs map[string]interface{}) bool {
+	for _, v := range resolve(attrs, f.path) {
+		if m, ok := v.(map[string]interface{}); ok && f.f.match(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the values at path.  Multi-valued attributes contribute one
+// value per element.
+func resolve(attrs map[string]interface{}, path []string) []interface{} {
+	if len(path) == 0 {
+		return nil
+	}
+	v, ok := attrs[path[0]]
+	if !ok {
+		return nil
+	}
+	var values []interface{}
+	switch tv := v.(type) {
+	case []interface{}:
+		values = tv
+	default:
+		values = []interface{}{tv}
+	}
+	if len(path) == 1 {
+		return values
+	}
+	var out []interface{}
+	for _, v := range values {
+		if m, ok := v.(map[string]interface{}); ok {
+			out = append(out, resolve(m, path[1:])...)
+		}
+	}
+	return out
+}
+
+func compare(attr interface{}, op string, value interface{}) bool {
+	switch a := attr.(type) {
+	case string:
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		a, s = strings.ToLower(a), strings.ToLower(s)
+		switch op {
+		case "eq":
+			return a == s
+		case "co":
+			return strings.Contains(a, s)
+		case "sw":
+			return strings.HasPrefix(a, s)
+		case "ew":
+			return strings.HasSuffix(a, s)
+		case "gt":
+			return a > s
+		case "ge":
+			return a >= s
+		case "lt":
+			return a < s
+		case "le":
+			return a <= s
+		}
+	case bool:
+		b, ok := value.(bool)
+		return ok && op == "eq" && a == b
+	case nil:
+		return op == "eq" && value == nil
+	}
+	return false
+}
+
+// parseFilter parses the SCIM filter expression, f.
+func parseFilter(f string) (filter, error) {
+	const op = "scim.parseFilter"
+	tokens, err := tokenizeFilter(f)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	p := &filterParser{tokens: tokens}
+	out, err := p.parseOr()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if p.pos != len(p.tokens) {
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("unexpected %q", p.tokens[p.pos].text))
+	}
+	return out, nil
+}
+
+type filterTokenKind int
+
+const (
+	wordToken filterTokenKind = iota
+	stringToken
+	punctToken
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+}
+
+func tokenizeFilter(f string) ([]filterToken, error) {
+	const op = "scim.tokenizeFilter"
+	var tokens []filterToken
+	for i := 0; i < len(f); {
+		c := f[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			tokens = append(tokens, filterToken{kind: punctToken, text: string(c)})
+			i++
+		case c == '"':
+			// find the closing quote, honoring escapes, and let the json
+			// decoder unescape the string.
+			j := i + 1
+			for ; j < len(f) && f[j] != '"'; j++ {
+				if f[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(f) {
+				return nil, errors.New(errors.InvalidParameter, op, "unterminated string")
+			}
+			var s string
+			if err := json.Unmarshal([]byte(f[i:j+1]), &s); err != nil {
+				return nil, errors.New(errors.InvalidParameter, op, "invalid string", errors.WithWrap(err))
+			}
+			tokens = append(tokens, filterToken{kind: stringToken, text: s})
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(f); j++ {
+				r := rune(f[j])
+				if unicode.IsSpace(r) || strings.ContainsRune("()[]\"", r) {
+					break
+				}
+			}
+			tokens = append(tokens, filterToken{kind: wordToken, text: f[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() *filterToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *filterParser) peekWord(w string) bool {
+	t := p.peek()
+	return t != nil && t.kind == wordToken && strings.EqualFold(t.text, w)
+}
+
+func (p *filterParser) expectPunct(punct string) error {
+	const op = "scim.(filterParser).expectPunct"
+	t := p.peek()
+	if t == nil || t.kind != punctToken || t.text != punct {
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("expected %q", punct))
+	}
+	p.pos++
+	return nil
+}
+
+func (p *filterParser) parseOr() (filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekWord("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filter, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekWord("and") {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseNot() (filter, error) {
+	if !p.peekWord("not") {
+		return p.parseAtom()
+	}
+	p.pos++
+	if err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectPunct(")"); err != nil {
+		return nil, err
+	}
+	return &notFilter{f: f}, nil
+}
+
+func (p *filterParser) parseAtom() (filter, error) {
+	const op = "scim.(filterParser).parseAtom"
+	t := p.peek()
+	switch {
+	case t == nil:
+		return nil, errors.New(errors.InvalidParameter, op, "unexpected end of filter")
+	case t.kind == punctToken && t.text == "(":
+		p.pos++
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return f, nil
+	case t.kind != wordToken:
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("expected an attribute, got %q", t.text))
+	}
+	p.pos++
+	path := parseAttrPath(t.text)
+
+	if next := p.peek(); next != nil && next.kind == punctToken && next.text == "[" {
+		p.pos++
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+		return &valuePathFilter{path: path, f: f}, nil
+	}
+
+	opToken := p.peek()
+	if opToken == nil || opToken.kind != wordToken {
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("missing operator for %q", t.text))
+	}
+	p.pos++
+	compareOp := strings.ToLower(opToken.text)
+	switch compareOp {
+	case "pr":
+		return &compareFilter{path: path, op: compareOp}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("unsupported operator %q", opToken.text))
+	}
+
+	valueToken := p.peek()
+	if valueToken == nil || valueToken.kind == punctToken {
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("missing value for %q", t.text))
+	}
+	p.pos++
+	var value interface{}
+	switch {
+	case valueToken.kind == stringToken:
+		value = valueToken.text
+	case strings.EqualFold(valueToken.text, "true"):
+		value = true
+	case strings.EqualFold(valueToken.text, "false"):
+		value = false
+	case strings.EqualFold(valueToken.text, "null"):
+		value = nil
+	default:
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("unsupported value %q", valueToken.text))
+	}
+	return &compareFilter{path: path, op: compareOp, value: value}, nil
+}
+
+// parseAttrPath splits an attribute path into lower case names, removing any
+// schema URN prefix, e.g.
+// "urn:ietf:params:scim:schemas:core:2.0:User:meta.created" becomes
+// ["meta", "created"]
+func parseAttrPath(a string) []string {
+	if strings.HasPrefix(strings.ToLower(a), "urn:") {
+		if i := strings.LastIndex(a, ":"); i >= 0 {
+			a = a[i+1:]
+		}
+	}
+	return strings.Split(strings.ToLower(a), ".")
+}
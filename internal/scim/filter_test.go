@@ -0,0 +1,88 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseFilter(t *testing.T) {
+	t.Parallel()
+	user := (&User{
+		Id:          "u_1234567890",
+		UserName:    "Alice@example.com",
+		DisplayName: "Alice Smith",
+		Meta:        &Meta{ResourceType: "User", Created: "2021-03-01T00:00:00Z"},
+	}).attributes()
+	group := (&Group{
+		Id:          "g_1234567890",
+		DisplayName: "Engineering",
+		Members:     []*Member{{Value: "u_1234567890", Type: "User"}, {Value: "u_0987654321", Type: "User"}},
+	}).attributes()
+
+	tests := []struct {
+		name   string
+		filter string
+		attrs  map[string]interface{}
+		want   bool
+	}{
+		{name: "eq", filter: `userName eq "alice@example.com"`, attrs: user, want: true},
+		{name: "eq-no-match", filter: `userName eq "bob@example.com"`, attrs: user, want: false},
+		{name: "case-insensitive-attr-op", filter: `USERNAME EQ "Alice@Example.com"`, attrs: user, want: true},
+		{name: "urn-prefix", filter: `urn:ietf:params:scim:schemas:core:2.0:User:userName eq "alice@example.com"`, attrs: user, want: true},
+		{name: "ne", filter: `userName ne "bob@example.com"`, attrs: user, want: true},
+		{name: "co", filter: `displayName co "smi"`, attrs: user, want: true},
+		{name: "sw", filter: `displayName sw "alice"`, attrs: user, want: true},
+		{name: "ew", filter: `userName ew "@example.com"`, attrs: user, want: true},
+		{name: "pr", filter: `displayName pr`, attrs: user, want: true},
+		{name: "pr-missing", filter: `externalId pr`, attrs: user, want: false},
+		{name: "bool", filter: `active eq true`, attrs: user, want: true},
+		{name: "sub-attr-gt", filter: `meta.created gt "2021-01-01T00:00:00Z"`, attrs: user, want: true},
+		{name: "sub-attr-lt", filter: `meta.created lt "2021-01-01T00:00:00Z"`, attrs: user, want: false},
+		{name: "and", filter: `userName sw "alice" and displayName ew "smith"`, attrs: user, want: true},
+		{name: "and-false", filter: `userName sw "alice" and displayName ew "jones"`, attrs: user, want: false},
+		{name: "or", filter: `userName eq "bob" or displayName ew "smith"`, attrs: user, want: true},
+		{name: "not", filter: `not (userName eq "bob")`, attrs: user, want: true},
+		{name: "parens", filter: `(userName eq "bob" or userName eq "carol") and active eq true`, attrs: user, want: false},
+		{name: "escaped-string", filter: `displayName eq "Alice \"Al\" Smith"`, attrs: user, want: false},
+		{name: "multi-valued", filter: `members.value eq "u_0987654321"`, attrs: group, want: true},
+		{name: "value-path", filter: `members[value eq "u_1234567890"]`, attrs: group, want: true},
+		{name: "value-path-no-match", filter: `members[value eq "u_1111111111"]`, attrs: group, want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := parseFilter(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, f.match(tt.attrs))
+		})
+	}
+}
+
+func Test_parseFilterErrors(t *testing.T) {
+	t.Parallel()
+	tests := []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName xx "alice"`,
+		`userName eq alice`,
+		`userName eq "alice`,
+		`(userName eq "alice"`,
+		`members[value eq "u_1234567890"`,
+		`not userName eq "alice"`,
+		`userName eq "alice" bob`,
+	}
+	for _, f := range tests {
+		f := f
+		t.Run(f, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseFilter(f)
+			require.Error(t, err)
+			assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package scim
+
+import "github.com/hashicorp/go-hclog"
+
+// defaultMaxResults is the maximum number of resources returned in a single
+// list response when the client doesn't request fewer.
+const defaultMaxResults = 100
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withLogger     hclog.Logger
+	withMaxResults int
+}
+
+func getDefaultOptions() options {
+	return options{
+		withLogger:     hclog.NewNullLogger(),
+		withMaxResults: defaultMaxResults,
+	}
+}
+
+// WithLogger provides an optional logger which the Server uses to log
+// internal errors.
+func WithLogger(l hclog.Logger) Option {
+	return func(o *options) {
+		if l != nil {
+			o.withLogger = l
+		}
+	}
+}
+
+// WithMaxResults provides an optional maximum number of resources returned in
+// a single list response.  Values less than 1 are ignored.
+func WithMaxResults(max int) Option {
+	return func(o *options) {
+		if max > 0 {
+			o.withMaxResults = max
+		}
+	}
+}
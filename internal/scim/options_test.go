@@ -0,0 +1,39 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test_GetOpts provides unit tests for GetOpts and all the options
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithLogger", func(t *testing.T) {
+		assert := assert.New(t)
+		l := hclog.New(&hclog.LoggerOptions{Name: "scim"})
+		opts := getOpts(WithLogger(l))
+		testOpts := getDefaultOptions()
+		testOpts.withLogger = l
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithLogger(nil))
+		assert.NotNil(opts.withLogger)
+	})
+	t.Run("WithMaxResults", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		testOpts := getDefaultOptions()
+		testOpts.withMaxResults = defaultMaxResults
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithMaxResults(10))
+		testOpts.withMaxResults = 10
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithMaxResults(-1))
+		testOpts.withMaxResults = defaultMaxResults
+		assert.Equal(opts, testOpts)
+	})
+}
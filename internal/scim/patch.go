@@ -0,0 +1,294 @@
+package scim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// applyUserPatch applies the PATCH operations, ops, to the User, u.
+//
+// See: https://tools.ietf.org/html/rfc7644#section-3.5.2
+func applyUserPatch(u *User, ops []*PatchOperation) error {
+	const op = "scim.applyUserPatch"
+	for _, o := range ops {
+		if o == nil {
+			return errors.New(errors.InvalidParameter, op, "missing operation")
+		}
+		switch strings.ToLower(o.Op) {
+		case "add", "replace":
+			values, err := patchValues(o)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			for attr, v := range values {
+				if err := setUserAttribute(u, attr, v); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
+		case "remove":
+			if o.Path == "" {
+				return errors.New(errors.InvalidParameter, op, "remove operation is missing a path")
+			}
+			if err := setUserAttribute(u, attrName(o.Path), nil); err != nil {
+				return errors.Wrap(err, op)
+			}
+		default:
+			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unsupported operation %q", o.Op))
+		}
+	}
+	return nil
+}
+
+func setUserAttribute(u *User, attr string, v interface{}) error {
+	const op = "scim.setUserAttribute"
+	switch attr {
+	case "username":
+		s, err := stringValue(attr, v)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if s == "" {
+			return errors.New(errors.InvalidParameter, op, "userName is required")
+		}
+		u.UserName = s
+	case "displayname":
+		s, err := stringValue(attr, v)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		u.DisplayName = s
+	case "active":
+		b, err := boolValue(attr, v)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		u.Active = &b
+	case "externalid":
+		// external ids are not stored.
+	default:
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unsupported attribute %q", attr))
+	}
+	return nil
+}
+
+// applyGroupPatch applies the PATCH operations, ops, to the Group, g.
+//
+// See: https://tools.ietf.org/html/rfc7644#section-3.5.2
+func applyGroupPatch(g *Group, ops []*PatchOperation) error {
+	const op = "scim.applyGroupPatch"
+	for _, o := range ops {
+		if o == nil {
+			return errors.New(errors.InvalidParameter, op, "missing operation")
+		}
+		switch strings.ToLower(o.Op) {
+		case "add", "replace":
+			replace := strings.EqualFold(o.Op, "replace")
+			values, err := patchValues(o)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			for attr, v := range values {
+				if err := setGroupAttribute(g, attr, v, replace); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
+		case "remove":
+			if err := removeGroupAttribute(g, o); err != nil {
+				return errors.Wrap(err, op)
+			}
+		default:
+			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unsupported operation %q", o.Op))
+		}
+	}
+	return nil
+}
+
+func setGroupAttribute(g *Group, attr string, v interface{}, replace bool) error {
+	const op = "scim.setGroupAttribute"
+	switch attr {
+	case "displayname":
+		s, err := stringValue(attr, v)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if s == "" {
+			return errors.New(errors.InvalidParameter, op, "displayName is required")
+		}
+		g.DisplayName = s
+	case "members":
+		members, err := memberValues(v)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if replace {
+			g.Members = nil
+		}
+		for _, m := range members {
+			if !g.hasMember(m.Value) {
+				g.Members = append(g.Members, m)
+			}
+		}
+	case "externalid":
+		// external ids are not stored.
+	default:
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unsupported attribute %q", attr))
+	}
+	return nil
+}
+
+// removeGroupAttribute applies a remove operation to the Group, g.  Members
+// can be removed with a value filter (members[value eq "u_1234567890"]) or a
+// list of values, and all members are removed when neither is given.
+func removeGroupAttribute(g *Group, o *PatchOperation) error {
+	const op = "scim.removeGroupAttribute"
+	if o.Path == "" {
+		return errors.New(errors.InvalidParameter, op, "remove operation is missing a path")
+	}
+	path := o.Path
+	var f filter
+	if i := strings.Index(path, "["); i >= 0 {
+		var err error
+		if f, err = parseFilter(path); err != nil {
+			return errors.Wrap(err, op)
+		}
+		path = path[:i]
+	}
+	switch attrName(path) {
+	case "members":
+	case "displayname":
+		return errors.New(errors.InvalidParameter, op, "displayName is required")
+	case "externalid":
+		return nil
+	default:
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("unsupported attribute %q", o.Path))
+	}
+
+	var remove func(m *Member) bool
+	switch {
+	case f != nil:
+		remove = func(m *Member) bool {
+			return f.match(map[string]interface{}{"members": []interface{}{
+				map[string]interface{}{"value": m.Value, "type": m.Type},
+			}})
+		}
+	case o.Value != nil:
+		values, err := memberValues(o.Value)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		remove = func(m *Member) bool {
+			for _, v := range values {
+				if m.Value == v.Value {
+					return true
+				}
+			}
+			return false
+		}
+	default:
+		remove = func(*Member) bool { return true }
+	}
+	members := g.Members[:0]
+	for _, m := range g.Members {
+		if !remove(m) {
+			members = append(members, m)
+		}
+	}
+	g.Members = members
+	return nil
+}
+
+func (g *Group) hasMember(id string) bool {
+	for _, m := range g.Members {
+		if m.Value == id {
+			return true
+		}
+	}
+	return false
+}
+
+// patchValues returns the attribute values set by an add or replace
+// operation, keyed by lower case attribute name.  Without a path, the
+// operation's value must be an object of attribute values.
+func patchValues(o *PatchOperation) (map[string]interface{}, error) {
+	const op = "scim.patchValues"
+	if o.Path != "" {
+		return map[string]interface{}{attrName(o.Path): o.Value}, nil
+	}
+	m, ok := o.Value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s operation without a path requires an object value", o.Op))
+	}
+	values := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		values[attrName(k)] = v
+	}
+	return values, nil
+}
+
+// attrName returns the lower case name of the top level attribute of path.
+func attrName(path string) string {
+	return strings.Join(parseAttrPath(path), ".")
+}
+
+func stringValue(attr string, v interface{}) (string, error) {
+	const op = "scim.stringValue"
+	switch s := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return s, nil
+	default:
+		return "", errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s must be a string", attr))
+	}
+}
+
+// boolValue returns the boolean value of v.  Some clients send booleans as
+// strings, e.g. "False", so they're accepted too.
+func boolValue(attr string, v interface{}) (bool, error) {
+	const op = "scim.boolValue"
+	switch b := v.(type) {
+	case bool:
+		return b, nil
+	case string:
+		switch strings.ToLower(b) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s must be a boolean", attr))
+}
+
+// memberValues returns the members of a members value, which is either a
+// single member object or a list of them.
+func memberValues(v interface{}) ([]*Member, error) {
+	const op = "scim.memberValues"
+	var values []interface{}
+	switch tv := v.(type) {
+	case []interface{}:
+		values = tv
+	case map[string]interface{}:
+		values = []interface{}{tv}
+	case nil:
+		return nil, nil
+	default:
+		return nil, errors.New(errors.InvalidParameter, op, "members must be a list of objects")
+	}
+	members := make([]*Member, 0, len(values))
+	for _, value := range values {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.New(errors.InvalidParameter, op, "members must be a list of objects")
+		}
+		id, ok := m["value"].(string)
+		if !ok || id == "" {
+			return nil, errors.New(errors.InvalidParameter, op, "member is missing a value")
+		}
+		memberType, _ := m["type"].(string)
+		members = append(members, &Member{Value: id, Type: memberType})
+	}
+	return members, nil
+}
@@ -0,0 +1,149 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applyUserPatch(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		ops     string
+		want    *User
+		wantErr bool
+	}{
+		{
+			name: "replace-with-path",
+			ops:  `[{"op":"replace","path":"displayName","value":"Bob"}]`,
+			want: &User{UserName: "alice", DisplayName: "Bob"},
+		},
+		{
+			name: "replace-without-path",
+			ops:  `[{"op":"Replace","value":{"userName":"bob","urn:ietf:params:scim:schemas:core:2.0:User:displayName":"Bob"}}]`,
+			want: &User{UserName: "bob", DisplayName: "Bob"},
+		},
+		{
+			name: "deactivate-string",
+			ops:  `[{"op":"Replace","path":"active","value":"False"}]`,
+			want: &User{UserName: "alice", DisplayName: "Alice", Active: boolPtr(false)},
+		},
+		{
+			name: "deactivate-object",
+			ops:  `[{"op":"replace","value":{"active":false}}]`,
+			want: &User{UserName: "alice", DisplayName: "Alice", Active: boolPtr(false)},
+		},
+		{
+			name: "remove",
+			ops:  `[{"op":"remove","path":"displayName"}]`,
+			want: &User{UserName: "alice"},
+		},
+		{name: "remove-required", ops: `[{"op":"remove","path":"userName"}]`, wantErr: true},
+		{name: "remove-without-path", ops: `[{"op":"remove"}]`, wantErr: true},
+		{name: "unsupported-op", ops: `[{"op":"move","path":"userName","value":"bob"}]`, wantErr: true},
+		{name: "unsupported-attribute", ops: `[{"op":"add","path":"nickName","value":"al"}]`, wantErr: true},
+		{name: "invalid-active", ops: `[{"op":"replace","path":"active","value":"nope"}]`, wantErr: true},
+		{name: "invalid-value", ops: `[{"op":"replace","value":"bob"}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var ops []*PatchOperation
+			require.NoError(t, json.Unmarshal([]byte(tt.ops), &ops))
+			u := &User{UserName: "alice", DisplayName: "Alice"}
+			err := applyUserPatch(u, ops)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, u)
+		})
+	}
+}
+
+func Test_applyGroupPatch(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		ops         string
+		wantName    string
+		wantMembers []string
+		wantErr     bool
+	}{
+		{
+			name:        "add-members",
+			ops:         `[{"op":"add","path":"members","value":[{"value":"u_2"},{"value":"u_3"}]}]`,
+			wantName:    "eng",
+			wantMembers: []string{"u_1", "u_2", "u_3"},
+		},
+		{
+			name:        "replace-members",
+			ops:         `[{"op":"replace","path":"members","value":[{"value":"u_3"}]}]`,
+			wantName:    "eng",
+			wantMembers: []string{"u_3"},
+		},
+		{
+			name:        "remove-member-filter",
+			ops:         `[{"op":"remove","path":"members[value eq \"u_1\"]"}]`,
+			wantName:    "eng",
+			wantMembers: []string{"u_2"},
+		},
+		{
+			name:        "remove-member-values",
+			ops:         `[{"op":"remove","path":"members","value":[{"value":"u_2"}]}]`,
+			wantName:    "eng",
+			wantMembers: []string{"u_1"},
+		},
+		{
+			name:     "remove-all-members",
+			ops:      `[{"op":"remove","path":"members"}]`,
+			wantName: "eng",
+		},
+		{
+			name:        "rename",
+			ops:         `[{"op":"replace","value":{"displayName":"Engineering"}}]`,
+			wantName:    "Engineering",
+			wantMembers: []string{"u_1", "u_2"},
+		},
+		{
+			name:        "rename-with-path",
+			ops:         `[{"op":"replace","path":"displayName","value":"Engineering"}]`,
+			wantName:    "Engineering",
+			wantMembers: []string{"u_1", "u_2"},
+		},
+		{name: "read-only-attribute", ops: `[{"op":"replace","value":{"id":"g_1"}}]`, wantErr: true},
+		{name: "remove-display-name", ops: `[{"op":"remove","path":"displayName"}]`, wantErr: true},
+		{name: "invalid-members", ops: `[{"op":"add","path":"members","value":"u_2"}]`, wantErr: true},
+		{name: "invalid-filter", ops: `[{"op":"remove","path":"members[value eq]"}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var ops []*PatchOperation
+			require.NoError(t, json.Unmarshal([]byte(tt.ops), &ops))
+			g := &Group{DisplayName: "eng", Members: []*Member{{Value: "u_1"}, {Value: "u_2"}}}
+			err := applyGroupPatch(g, ops)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, g.DisplayName)
+			var members []string
+			for _, m := range g.Members {
+				members = append(members, m.Value)
+			}
+			assert.Equal(t, tt.wantMembers, members)
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,250 @@
+// Package scim provides a SCIM 2.0 provisioning server which maps SCIM Users
+// and Groups onto the iam users, groups and group members of a single scope.
+// A Group's members may be Users or other Groups of the scope.
+//
+// Requests are authenticated with a bearer token which is dedicated to the
+// server (it is not a Boundary auth token) and each token only allows access to
+// the users and groups of its scope.
+//
+// Boundary users don't have a disabled state, so deactivating a SCIM User
+// (setting active to false) deletes the iam user.
+//
+// See: https://tools.ietf.org/html/rfc7643 and
+// https://tools.ietf.org/html/rfc7644
+package scim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/iam"
+)
+
+const (
+	// UserSchema is the core schema for SCIM Users
+	UserSchema = "urn:ietf:params:scim:schemas:core:2.0:User"
+
+	// GroupSchema is the core schema for SCIM Groups
+	GroupSchema = "urn:ietf:params:scim:schemas:core:2.0:Group"
+
+	// ListResponseSchema is the schema for SCIM list responses
+	ListResponseSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+
+	// PatchOpSchema is the schema for SCIM PATCH requests
+	PatchOpSchema = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+
+	// ErrorSchema is the schema for SCIM error responses
+	ErrorSchema = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// ServiceProviderConfigSchema is the schema for the SCIM service provider
+	// config
+	ServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	// ResourceTypeSchema is the schema for SCIM resource types
+	ResourceTypeSchema = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+
+	// ContentType is the media type of SCIM requests and responses
+	ContentType = "application/scim+json"
+
+	// PathPrefix is the prefix of the paths served by the Server
+	PathPrefix = "/scim/v2/"
+)
+
+// The types of the members of a SCIM Group
+const (
+	memberTypeUser  = "User"
+	memberTypeGroup = "Group"
+)
+
+// Repository is the subset of iam.(Repository) used by the Server.
+type Repository interface {
+	CreateUser(ctx context.Context, user *iam.User, opt ...iam.Option) (*iam.User, error)
+	LookupUser(ctx context.Context, userId string, opt ...iam.Option) (*iam.User, []string, error)
+	UpdateUser(ctx context.Context, user *iam.User, version uint32, fieldMaskPaths []string, opt ...iam.Option) (*iam.User, []string, int, error)
+	DeleteUser(ctx context.Context, withPublicId string, opt ...iam.Option) (int, error)
+	ListUsers(ctx context.Context, withScopeIds []string, opt ...iam.Option) ([]*iam.User, error)
+
+	CreateGroup(ctx context.Context, group *iam.Group, opt ...iam.Option) (*iam.Group, error)
+	LookupGroup(ctx context.Context, withPublicId string, opt ...iam.Option) (*iam.Group, []*iam.GroupMember, error)
+	UpdateGroup(ctx context.Context, group *iam.Group, version uint32, fieldMaskPaths []string, opt ...iam.Option) (*iam.Group, []*iam.GroupMember, int, error)
+	DeleteGroup(ctx context.Context, withPublicId string, opt ...iam.Option) (int, error)
+	ListGroups(ctx context.Context, withScopeIds []string, opt ...iam.Option) ([]*iam.Group, error)
+	ListGroupMembers(ctx context.Context, withGroupId string, opt ...iam.Option) ([]*iam.GroupMember, error)
+	SetGroupMembers(ctx context.Context, groupId string, groupVersion uint32, memberIds []string, opt ...iam.Option) ([]*iam.GroupMember, int, error)
+}
+
+// RepoFactory is used by the Server to create a new Repository
+type RepoFactory func() (Repository, error)
+
+// Meta is the SCIM meta attribute of a resource
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+// User is a SCIM User.  UserName is mapped to the iam user's name and
+// DisplayName is mapped to its description.
+type User struct {
+	Schemas     []string `json:"schemas"`
+	Id          string   `json:"id,omitempty"`
+	ExternalId  string   `json:"externalId,omitempty"`
+	UserName    string   `json:"userName"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+	Meta        *Meta    `json:"meta,omitempty"`
+}
+
+// Member is a member of a SCIM Group.  Value is the member's user or group
+// id, and Type is memberTypeUser or memberTypeGroup accordingly.
+type Member struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+// Group is a SCIM Group.  DisplayName is mapped to the iam group's name.
+type Group struct {
+	Schemas     []string  `json:"schemas"`
+	Id          string    `json:"id,omitempty"`
+	ExternalId  string    `json:"externalId,omitempty"`
+	DisplayName string    `json:"displayName"`
+	Members     []*Member `json:"members,omitempty"`
+	Meta        *Meta     `json:"meta,omitempty"`
+}
+
+// ListResponse is a SCIM list response
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// PatchOperation is a single operation of a SCIM PATCH request
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// PatchRequest is a SCIM PATCH request
+type PatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*PatchOperation `json:"Operations"`
+}
+
+// Error is a SCIM error response
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// toUser converts an iam user to a SCIM User
+func toUser(u *iam.User, baseUrl string) *User {
+	active := true
+	return &User{
+		Schemas:     []string{UserSchema},
+		Id:          u.GetPublicId(),
+		UserName:    u.GetName(),
+		DisplayName: u.GetDescription(),
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: "User",
+			Created:      formatTime(u.GetCreateTime().GetTimestamp().AsTime()),
+			LastModified: formatTime(u.GetUpdateTime().GetTimestamp().AsTime()),
+			Location:     fmt.Sprintf("%s/Users/%s", baseUrl, u.GetPublicId()),
+			Version:      formatVersion(u.GetVersion()),
+		},
+	}
+}
+
+// toGroup converts an iam group and its members to a SCIM Group
+func toGroup(g *iam.Group, members []*iam.GroupMember, baseUrl string) *Group {
+	out := &Group{
+		Schemas:     []string{GroupSchema},
+		Id:          g.GetPublicId(),
+		DisplayName: g.GetName(),
+		Meta: &Meta{
+			ResourceType: "Group",
+			Created:      formatTime(g.GetCreateTime().GetTimestamp().AsTime()),
+			LastModified: formatTime(g.GetUpdateTime().GetTimestamp().AsTime()),
+			Location:     fmt.Sprintf("%s/Groups/%s", baseUrl, g.GetPublicId()),
+			Version:      formatVersion(g.GetVersion()),
+		},
+	}
+	for _, m := range members {
+		member := &Member{
+			Value: m.GetMemberId(),
+			Ref:   fmt.Sprintf("%s/Users/%s", baseUrl, m.GetMemberId()),
+			Type:  memberTypeUser,
+		}
+		if m.GetType() == iam.GroupMemberType.String() {
+			member.Ref = fmt.Sprintf("%s/Groups/%s", baseUrl, m.GetMemberId())
+			member.Type = memberTypeGroup
+		}
+		out.Members = append(out.Members, member)
+	}
+	return out
+}
+
+// attributes returns the SCIM attributes of the User which can be used in a
+// filter.
+func (u *User) attributes() map[string]interface{} {
+	attrs := map[string]interface{}{
+		"id":          u.Id,
+		"username":    u.UserName,
+		"displayname": u.DisplayName,
+		"active":      u.Active == nil || *u.Active,
+	}
+	if u.Meta != nil {
+		attrs["meta"] = u.Meta.attributes()
+	}
+	return attrs
+}
+
+// attributes returns the SCIM attributes of the Group which can be used in a
+// filter.
+func (g *Group) attributes() map[string]interface{} {
+	members := make([]interface{}, 0, len(g.Members))
+	for _, m := range g.Members {
+		members = append(members, map[string]interface{}{
+			"value": m.Value,
+			"type":  m.Type,
+		})
+	}
+	attrs := map[string]interface{}{
+		"id":          g.Id,
+		"displayname": g.DisplayName,
+		"members":     members,
+	}
+	if g.Meta != nil {
+		attrs["meta"] = g.Meta.attributes()
+	}
+	return attrs
+}
+
+func (m *Meta) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"resourcetype": m.ResourceType,
+		"created":      m.Created,
+		"lastmodified": m.LastModified,
+		"location":     m.Location,
+		"version":      m.Version,
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatVersion(v uint32) string {
+	return fmt.Sprintf(`W/"%d"`, v)
+}
@@ -0,0 +1,739 @@
+package scim
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Server is an http.Handler which serves the SCIM 2.0 Users, Groups,
+// ServiceProviderConfig and ResourceTypes endpoints under PathPrefix.
+type Server struct {
+	repoFn     RepoFactory
+	tokens     map[string]string
+	logger     hclog.Logger
+	maxResults int
+}
+
+// NewServer creates a new Server.  tokens maps each bearer token accepted by
+// the Server to the id of the scope whose users and groups it can provision.
+// The options WithLogger and WithMaxResults are supported.
+func NewServer(repoFn RepoFactory, tokens map[string]string, opt ...Option) (*Server, error) {
+	const op = "scim.NewServer"
+	if repoFn == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing repository function")
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New(errors.InvalidParameter, op, "missing tokens")
+	}
+	t := make(map[string]string, len(tokens))
+	for token, scopeId := range tokens {
+		if token == "" {
+			return nil, errors.New(errors.InvalidParameter, op, "missing token")
+		}
+		if scopeId == "" {
+			return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+		}
+		t[token] = scopeId
+	}
+	opts := getOpts(opt...)
+	return &Server{
+		repoFn:     repoFn,
+		tokens:     t,
+		logger:     opts.withLogger,
+		maxResults: opts.withMaxResults,
+	}, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	scopeId, ok := s.authenticate(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+		writeError(w, http.StatusUnauthorized, "", "missing or invalid bearer token")
+		return
+	}
+	if size, ok := r.Context().Value(globals.ContextMaxRequestSizeTypeKey).(int64); ok && size > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, size)
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, PathPrefix), "/"), "/")
+	name, id := parts[0], ""
+	if len(parts) == 2 {
+		id = parts[1]
+	}
+	if len(parts) > 2 || (len(parts) == 2 && id == "") {
+		writeError(w, http.StatusNotFound, "", "not found")
+		return
+	}
+
+	h := &request{Server: s, w: w, r: r, ctx: r.Context(), scopeId: scopeId, baseUrl: baseUrl(r)}
+	switch {
+	case name == "Users" && id == "":
+		h.route(map[string]func(){http.MethodGet: h.listUsers, http.MethodPost: h.createUser})
+	case name == "Users":
+		h.route(map[string]func(){
+			http.MethodGet:    func() { h.getUser(id) },
+			http.MethodPut:    func() { h.putUser(id) },
+			http.MethodPatch:  func() { h.patchUser(id) },
+			http.MethodDelete: func() { h.deleteUser(id) },
+		})
+	case name == "Groups" && id == "":
+		h.route(map[string]func(){http.MethodGet: h.listGroups, http.MethodPost: h.createGroup})
+	case name == "Groups":
+		h.route(map[string]func(){
+			http.MethodGet:    func() { h.getGroup(id) },
+			http.MethodPut:    func() { h.putGroup(id) },
+			http.MethodPatch:  func() { h.patchGroup(id) },
+			http.MethodDelete: func() { h.deleteGroup(id) },
+		})
+	case name == "ServiceProviderConfig" && id == "":
+		h.route(map[string]func(){http.MethodGet: h.serviceProviderConfig})
+	case name == "ResourceTypes" && id == "":
+		h.route(map[string]func(){http.MethodGet: h.resourceTypes})
+	default:
+		writeError(w, http.StatusNotFound, "", "not found")
+	}
+}
+
+// authenticate returns the scope id of the request's bearer token.  Every
+// token is compared in constant time so the response time doesn't reveal
+// which tokens exist.
+func (s *Server) authenticate(r *http.Request) (string, bool) {
+	auth := r.Header.Get("Authorization")
+	if len(auth) < len("Bearer ") || !strings.EqualFold(auth[:len("Bearer ")], "Bearer ") {
+		return "", false
+	}
+	bearer := []byte(strings.TrimSpace(auth[len("Bearer "):]))
+	var scopeId string
+	for token, id := range s.tokens {
+		if subtle.ConstantTimeCompare(bearer, []byte(token)) == 1 {
+			scopeId = id
+		}
+	}
+	return scopeId, scopeId != ""
+}
+
+// request is the state of a single SCIM request.
+type request struct {
+	*Server
+	w       http.ResponseWriter
+	r       *http.Request
+	ctx     context.Context
+	scopeId string
+	baseUrl string
+}
+
+func (h *request) route(methods map[string]func()) {
+	fn, ok := methods[h.r.Method]
+	if !ok {
+		allowed := make([]string, 0, len(methods))
+		for m := range methods {
+			allowed = append(allowed, m)
+		}
+		h.w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeError(h.w, http.StatusMethodNotAllowed, "", fmt.Sprintf("method %s is not allowed", h.r.Method))
+		return
+	}
+	fn()
+}
+
+func (h *request) repo() (Repository, bool) {
+	repo, err := h.repoFn()
+	if err != nil {
+		h.writeErr(err)
+		return nil, false
+	}
+	return repo, true
+}
+
+func (h *request) listUsers() {
+	repo, ok := h.repo()
+	if !ok {
+		return
+	}
+	users, err := repo.ListUsers(h.ctx, []string{h.scopeId}, iam.WithLimit(-1))
+	if err != nil {
+		h.writeErr(err)
+		return
+	}
+	resources := make([]resource, 0, len(users))
+	for _, u := range users {
+		resources = append(resources, toUser(u, h.baseUrl))
+	}
+	h.writeList(resources)
+}
+
+func (h *request) createUser() {
+	const op = "scim.(request).createUser"
+	var in User
+	if !h.decode(&in) {
+		return
+	}
+	if in.UserName == "" {
+		h.writeErr(errors.New(errors.InvalidParameter, op, "userName is required"))
+		return
+	}
+	if in.Active != nil && !*in.Active {
+		h.writeErr(errors.New(errors.InvalidParameter, op, "inactive users are not supported"))
+		return
+	}
+	repo, ok := h.repo()
+	if !ok {
+		return
+	}
+	u, err := iam.NewUser(h.scopeId, iam.WithName(in.UserName), iam.WithDescription(in.DisplayName))
+	if err != nil {
+		h.writeErr(err)
+		return
+	}
+	u, err = repo.CreateUser(h.ctx, u)
+	if err != nil {
+		h.writeErr(err)
+		return
+	}
+	h.writeResource(http.StatusCreated, toUser(u, h.baseUrl))
+}
+
+func (h *request) getUser(id string) {
+	repo, ok := h.repo()
+	if !ok {
+		return
+	}
+	u, ok := h.lookupUser(repo, id)
+	if !ok {
+		return
+	}
+	h.writeResource(http.StatusOK, toUser(u, h.baseUrl))
+}
+
+func (h *request) putUser(id string) {
+	const op = "scim.(request).putUser"
+	var in User
+	if !h.decode(&in) {
+		return
+	}
+	if in.UserName == "" {
+		h.writeErr(errors.New(errors.InvalidParameter, op, "userName is required"))
+		return
+	}
+	repo, ok := h.repo()
+	if !ok {
+		return
+	}
+	u, ok := h.lookupUser(repo, id)
+	if !ok {
+		return
+	}
+	h.saveUser(repo, u, &in)
+}
+
+func (h *request) patchUser(id string) {
+	var in PatchRequest
+	if !h.decode(&in) {
+		return
+	}
+	repo, ok := h.repo()
+	if !ok {
+		return
+	}
+	u, ok := h.lookupUser(repo, id)
+	if !ok {
+		return
+	}
+	patched := toUser(u, h.baseUrl)
+	if err := applyUserPatch(patched, in.Operations); err != nil {
+		h.writeErr(err)
+		return
+	}
+	h.saveUser(repo, u, patched)
+}
+
+// saveUser updates the iam user, u, to match the SCIM User, in.  The iam user
+// is deleted when in is not active.
+func (h *request) saveUser(repo Repository, u *iam.User, in *User) {
+	if in.Active != nil && !*in.Active {
+		if _, err := repo.DeleteUser(h.ctx, u.GetPublicId()); err != nil {
+			h.writeErr(err)
+			return
+		}
+		out := toUser(u, h.baseUrl)
+		out.Active = in.Active
+		h.writeResource(http.StatusOK, out)
+		return
+	}
+	if in.UserName != u.GetName() || in.DisplayName != u.GetDescription() {
+		updated := u.Clone().(*iam.User)
+		updated.Name = in.UserName
+		updated.Description = in.DisplayName
+		var err error
+		if u, _, _, err = repo.UpdateUser(h.ctx, updated, u.GetVersion(), []string{"Name", "Description"}); err != nil {
+			h.writeErr(err)
+			return
+		}
+	}
+	h.writeResource(http.StatusOK, toUser(u, h.baseUrl))
+}
+
+func (h *request) deleteUser(id string) {
+	repo, ok := h.repo()
+	if !ok {
+		return
+	}
+	if _, ok := h.lookupUser(repo, id); !ok {
+		return
+	}
+	if _, err := repo.DeleteUser(h.ctx, id); err != nil {
+		h.writeErr(err)
+		return
+	}
+	h.w.WriteHeader(http.StatusNoContent)
+}
+
+// lookupUser returns the user with the id.  Users outside of the request's
+// scope are not found.
+func (h *request) lookupUser(repo Repository, id string) (*iam.User, bool) {
+	u, _, err := repo.LookupUser(h.ctx, id)
+	if err != nil {
+		h.writeErr(err)
+		return nil, false
+	}
+	if u == nil || u.GetScopeId() != h.scopeId {
+		writeError(h.w, http.StatusNotFound, "", fmt.Sprintf("user %s not found", id))
+		return nil, false
+	}
+	return u, true
+}
+
+func (h *request) listGroups() {
+	repo, ok := h.repo()
+	if !ok {
+		return
+	}
+	groups, err := repo.ListGroups(h.ctx, []string{h.scopeId}, iam.WithLimit(-1))
+	if err != nil {
+		h.writeErr(err)
+		return
+	}
+	resources := make([]resource, 0, len(groups))
+	for _, g := range groups {
+		members, err := repo.ListGroupMembers(h.ctx, g.GetPublicId())
+		if err != nil {
+			h.writeErr(err)
+			return
+		}
+		resources = append(resources, toGroup(g, members, h.baseUrl))
+	}
+	h.writeList(resources)
+}
+
+func (h *request) createGroup() {
+	const op = "scim.(request).createGroup"
+	var in Group
+	if !h.decode(&in) {
+		return
+	}
+	if in.DisplayName == "" {
+		h.writeErr(errors.New(errors.InvalidParameter, op, "displayName is required"))
+		return
+	}
+	repo, ok := h.repo()
+	if !ok {
+		return
+	}
+	memberIds, ok := h.memberIds(repo, in.Members)
+	if !ok {
+		return
+	}
+	g, err := iam.NewGroup(h.scopeId, iam.WithName(in.DisplayName))
+	if err != nil {
+		h.writeErr(err)
+		return
+	}
+	if g, err = repo.CreateGroup(h.ctx, g); err != nil {
+		h.writeErr(err)
+		return
+	}
+	var members []*iam.GroupMember
+	if len(memberIds) > 0 {
+		if members, _, err = repo.SetGroupMembers(h.ctx, g.GetPublicId(), g.GetVersion(), memberIds); err != nil {
+			h.writeErr(err)
+			return
+		}
+		// setting the members incremented the group's version.
+		if g, members, err = repo.LookupGroup(h.ctx, g.GetPublicId()); err != nil {
+			h.writeErr(err)
+			return
+		}
+	}
+	h.writeResource(http.StatusCreated, toGroup(g, members, h.baseUrl))
+}
+
+func (h *request) getGroup(id string) {
+	repo, ok := h.repo()
+	if !ok {
+		return
+	}
+	g, members, ok := h.lookupGroup(repo, id)
+	if !ok {
+		return
+	}
+	h.writeResource(http.StatusOK, toGroup(g, members, h.baseUrl))
+}
+
+func (h *request) putGroup(id string) {
+	const op = "scim.(request).putGroup"
+	var in Group
+	if !h.decode(&in) {
+		return
+	}
+	if in.DisplayName == "" {
+		h.writeErr(errors.New(errors.InvalidParameter, op, "displayName is required"))
+		return
+	}
+	repo, ok := h.repo()
+	if !ok {
+		return
+	}
+	g, members, ok := h.lookupGroup(repo, id)
+	if !ok {
+		return
+	}
+	h.saveGroup(repo, g, members, &in)
+}
+
+func (h *request) patchGroup(id string) {
+	var in PatchRequest
+	if !h.decode(&in) {
+		return
+	}
+	repo, ok := h.repo()
+	if !ok {
+		return
+	}
+	g, members, ok := h.lookupGroup(repo, id)
+	if !ok {
+		return
+	}
+	patched := toGroup(g, members, h.baseUrl)
+	if err := applyGroupPatch(patched, in.Operations); err != nil {
+		h.writeErr(err)
+		return
+	}
+	h.saveGroup(repo, g, members, patched)
+}
+
+// saveGroup updates the iam group, g, and its members to match the SCIM
+// Group, in.
+func (h *request) saveGroup(repo Repository, g *iam.Group, members []*iam.GroupMember, in *Group) {
+	memberIds, ok := h.memberIds(repo, in.Members)
+	if !ok {
+		return
+	}
+	var err error
+	if in.DisplayName != g.GetName() {
+		updated := g.Clone().(*iam.Group)
+		updated.Name = in.DisplayName
+		if g, _, _, err = repo.UpdateGroup(h.ctx, updated, g.GetVersion(), []string{"Name"}); err != nil {
+			h.writeErr(err)
+			return
+		}
+	}
+	if !sameMembers(members, memberIds) {
+		if _, _, err = repo.SetGroupMembers(h.ctx, g.GetPublicId(), g.GetVersion(), memberIds); err != nil {
+			h.writeErr(err)
+			return
+		}
+		if g, members, err = repo.LookupGroup(h.ctx, g.GetPublicId()); err != nil {
+			h.writeErr(err)
+			return
+		}
+	}
+	h.writeResource(http.StatusOK, toGroup(g, members, h.baseUrl))
+}
+
+func (h *request) deleteGroup(id string) {
+	repo, ok := h.repo()
+	if !ok {
+		return
+	}
+	if _, _, ok := h.lookupGroup(repo, id); !ok {
+		return
+	}
+	if _, err := repo.DeleteGroup(h.ctx, id); err != nil {
+		h.writeErr(err)
+		return
+	}
+	h.w.WriteHeader(http.StatusNoContent)
+}
+
+// lookupGroup returns the group with the id and its members.  Groups outside
+// of the request's scope are not found.
+func (h *request) lookupGroup(repo Repository, id string) (*iam.Group, []*iam.GroupMember, bool) {
+	g, members, err := repo.LookupGroup(h.ctx, id)
+	if err != nil {
+		h.writeErr(err)
+		return nil, nil, false
+	}
+	if g == nil || g.GetScopeId() != h.scopeId {
+		writeError(h.w, http.StatusNotFound, "", fmt.Sprintf("group %s not found", id))
+		return nil, nil, false
+	}
+	return g, members, true
+}
+
+// memberIds returns the ids of the members.  Every member must be a user or a
+// group in the request's scope, and its type, when given, must match.
+func (h *request) memberIds(repo Repository, members []*Member) ([]string, bool) {
+	const op = "scim.(request).memberIds"
+	ids := make([]string, 0, len(members))
+	seen := make(map[string]bool, len(members))
+	for _, m := range members {
+		if m == nil || m.Value == "" {
+			h.writeErr(errors.New(errors.InvalidParameter, op, "member is missing a value"))
+			return nil, false
+		}
+		if seen[m.Value] {
+			continue
+		}
+		seen[m.Value] = true
+		memberType := memberTypeUser
+		if strings.HasPrefix(m.Value, iam.GroupPrefix+"_") {
+			memberType = memberTypeGroup
+		}
+		if m.Type != "" && !strings.EqualFold(m.Type, memberType) {
+			h.writeErr(errors.New(errors.InvalidParameter, op, fmt.Sprintf("member %s is not a %s", m.Value, m.Type)))
+			return nil, false
+		}
+		var scopeId string
+		switch memberType {
+		case memberTypeGroup:
+			g, _, err := repo.LookupGroup(h.ctx, m.Value)
+			if err != nil {
+				h.writeErr(err)
+				return nil, false
+			}
+			if g != nil {
+				scopeId = g.GetScopeId()
+			}
+		default:
+			u, _, err := repo.LookupUser(h.ctx, m.Value)
+			if err != nil {
+				h.writeErr(err)
+				return nil, false
+			}
+			if u != nil {
+				scopeId = u.GetScopeId()
+			}
+		}
+		if scopeId != h.scopeId {
+			h.writeErr(errors.New(errors.InvalidParameter, op, fmt.Sprintf("member %s is not a user or group", m.Value)))
+			return nil, false
+		}
+		ids = append(ids, m.Value)
+	}
+	return ids, true
+}
+
+func sameMembers(members []*iam.GroupMember, ids []string) bool {
+	if len(members) != len(ids) {
+		return false
+	}
+	current := make(map[string]bool, len(members))
+	for _, m := range members {
+		current[m.GetMemberId()] = true
+	}
+	for _, id := range ids {
+		if !current[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *request) serviceProviderConfig() {
+	supported := func(b bool) map[string]interface{} { return map[string]interface{}{"supported": b} }
+	h.writeResource(http.StatusOK, map[string]interface{}{
+		"schemas":        []string{ServiceProviderConfigSchema},
+		"patch":          supported(true),
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": h.maxResults},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication using a bearer token configured for the scope",
+			"primary":     true,
+		}},
+		"meta": &Meta{ResourceType: "ServiceProviderConfig", Location: h.baseUrl + "/ServiceProviderConfig"},
+	})
+}
+
+func (h *request) resourceTypes() {
+	resourceType := func(name, endpoint, schema string) interface{} {
+		return map[string]interface{}{
+			"schemas":  []string{ResourceTypeSchema},
+			"id":       name,
+			"name":     name,
+			"endpoint": endpoint,
+			"schema":   schema,
+			"meta":     &Meta{ResourceType: "ResourceType", Location: h.baseUrl + "/ResourceTypes/" + name},
+		}
+	}
+	types := []interface{}{
+		resourceType("User", "/Users", UserSchema),
+		resourceType("Group", "/Groups", GroupSchema),
+	}
+	h.writeResource(http.StatusOK, &ListResponse{
+		Schemas:      []string{ListResponseSchema},
+		TotalResults: len(types),
+		StartIndex:   1,
+		ItemsPerPage: len(types),
+		Resources:    types,
+	})
+}
+
+// resource is a SCIM resource which can be filtered.
+type resource interface {
+	attributes() map[string]interface{}
+}
+
+// writeList writes the resources which match the request's filter, paginated
+// with the request's startIndex and count.
+//
+// See: https://tools.ietf.org/html/rfc7644#section-3.4.2
+func (h *request) writeList(resources []resource) {
+	const op = "scim.(request).writeList"
+	q := h.r.URL.Query()
+	if f := q.Get("filter"); f != "" {
+		parsed, err := parseFilter(f)
+		if err != nil {
+			writeError(h.w, http.StatusBadRequest, "invalidFilter", err.Error())
+			return
+		}
+		matched := resources[:0]
+		for _, r := range resources {
+			if parsed.match(r.attributes()) {
+				matched = append(matched, r)
+			}
+		}
+		resources = matched
+	}
+
+	startIndex, count := 1, h.maxResults
+	if v := q.Get("startIndex"); v != "" {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			h.writeErr(errors.New(errors.InvalidParameter, op, "startIndex must be an integer"))
+			return
+		}
+		if i > 1 {
+			startIndex = i
+		}
+	}
+	if v := q.Get("count"); v != "" {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			h.writeErr(errors.New(errors.InvalidParameter, op, "count must be an integer"))
+			return
+		}
+		if i < 0 {
+			i = 0
+		}
+		if i < count {
+			count = i
+		}
+	}
+
+	out := &ListResponse{
+		Schemas:      []string{ListResponseSchema},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		Resources:    []interface{}{},
+	}
+	for i := startIndex - 1; i < len(resources) && len(out.Resources) < count; i++ {
+		out.Resources = append(out.Resources, resources[i])
+	}
+	out.ItemsPerPage = len(out.Resources)
+	h.writeResource(http.StatusOK, out)
+}
+
+// decode decodes the request's body into v.  It writes an error response and
+// returns false when the body is invalid.
+func (h *request) decode(v interface{}) bool {
+	body, err := ioutil.ReadAll(h.r.Body)
+	if err != nil {
+		writeError(h.w, http.StatusRequestEntityTooLarge, "", "unable to read request body")
+		return false
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		writeError(h.w, http.StatusBadRequest, "invalidSyntax", fmt.Sprintf("unable to parse request body: %v", err))
+		return false
+	}
+	return true
+}
+
+func (h *request) writeResource(status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		h.writeErr(errors.New(errors.Internal, "scim.(request).writeResource", "unable to marshal response", errors.WithWrap(err)))
+		return
+	}
+	h.w.Header().Set("Content-Type", ContentType)
+	h.w.WriteHeader(status)
+	if _, err := h.w.Write(body); err != nil {
+		h.logger.Error("unable to write scim response", "error", err)
+	}
+}
+
+// writeErr writes the SCIM error response for a domain error.
+func (h *request) writeErr(err error) {
+	switch {
+	case errors.IsNotFoundError(err):
+		writeError(h.w, http.StatusNotFound, "", err.Error())
+	case errors.IsUniqueError(err):
+		writeError(h.w, http.StatusConflict, "uniqueness", err.Error())
+	case errors.Match(errors.T(errors.InvalidParameter), err),
+		errors.Match(errors.T(errors.InvalidFieldMask), err),
+		errors.Match(errors.T(errors.EmptyFieldMask), err),
+		errors.IsCheckConstraintError(err),
+		errors.IsNotNullError(err):
+		writeError(h.w, http.StatusBadRequest, "invalidValue", err.Error())
+	default:
+		h.logger.Error("scim request failed", "method", h.r.Method, "path", h.r.URL.Path, "error", err)
+		writeError(h.w, http.StatusInternalServerError, "", "internal error")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, scimType, detail string) {
+	body, _ := json.Marshal(&Error{
+		Schemas:  []string{ErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// baseUrl returns the url of the Server's endpoints for the request.
+func baseUrl(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, r.Host, strings.TrimSuffix(PathPrefix, "/"))
+}
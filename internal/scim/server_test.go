@@ -0,0 +1,570 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	testScopeId = "o_1234567890"
+	testToken   = "scim-token"
+)
+
+func TestNewServer(t *testing.T) {
+	t.Parallel()
+	repoFn := func() (Repository, error) { return newTestRepo(), nil }
+	tests := []struct {
+		name    string
+		repoFn  RepoFactory
+		tokens  map[string]string
+		wantErr bool
+	}{
+		{name: "valid", repoFn: repoFn, tokens: map[string]string{testToken: testScopeId}},
+		{name: "missing-repo", tokens: map[string]string{testToken: testScopeId}, wantErr: true},
+		{name: "missing-tokens", repoFn: repoFn, wantErr: true},
+		{name: "empty-token", repoFn: repoFn, tokens: map[string]string{"": testScopeId}, wantErr: true},
+		{name: "empty-scope", repoFn: repoFn, tokens: map[string]string{testToken: ""}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := NewServer(tt.repoFn, tt.tokens)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, s)
+		})
+	}
+}
+
+func TestServer_Authentication(t *testing.T) {
+	t.Parallel()
+	ts := newTestServer(t, newTestRepo())
+	for _, auth := range []string{"", "Basic " + testToken, "Bearer wrong", "Bearer"} {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+PathPrefix+"Users", nil)
+		require.NoError(t, err)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, auth)
+		assert.NotEmpty(t, resp.Header.Get("WWW-Authenticate"), auth)
+	}
+}
+
+func TestServer_Users(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	repo := newTestRepo()
+	ts := newTestServer(t, repo)
+
+	// create
+	var alice User
+	resp := do(t, ts, http.MethodPost, "Users", `{"schemas":["`+UserSchema+`"],"userName":"alice@example.com","displayName":"Alice","active":true}`, &alice)
+	require.Equal(http.StatusCreated, resp.StatusCode)
+	assert.Equal(ContentType, resp.Header.Get("Content-Type"))
+	assert.NotEmpty(alice.Id)
+	assert.Equal("alice@example.com", alice.UserName)
+	assert.Equal("Alice", alice.DisplayName)
+	assert.Equal(ts.URL+"/scim/v2/Users/"+alice.Id, alice.Meta.Location)
+
+	// uniqueness
+	var scimErr Error
+	resp = do(t, ts, http.MethodPost, "Users", `{"userName":"alice@example.com"}`, &scimErr)
+	assert.Equal(http.StatusConflict, resp.StatusCode)
+	assert.Equal("uniqueness", scimErr.ScimType)
+
+	resp = do(t, ts, http.MethodPost, "Users", `{"displayName":"Nameless"}`, nil)
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	var bob User
+	resp = do(t, ts, http.MethodPost, "Users", `{"userName":"bob@example.com"}`, &bob)
+	require.Equal(http.StatusCreated, resp.StatusCode)
+
+	// get
+	var got User
+	resp = do(t, ts, http.MethodGet, "Users/"+alice.Id, "", &got)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal(alice, got)
+
+	resp = do(t, ts, http.MethodGet, "Users/u_doesnotexist", "", nil)
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+
+	// users in other scopes are not found
+	other := repo.addUser("o_other", "carol@example.com")
+	resp = do(t, ts, http.MethodGet, "Users/"+other.PublicId, "", nil)
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+
+	// list and filter
+	var list ListResponse
+	resp = do(t, ts, http.MethodGet, "Users", "", &list)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal(2, list.TotalResults)
+	assert.Len(list.Resources, 2)
+
+	resp = do(t, ts, http.MethodGet, `Users?filter=userName+eq+%22ALICE@example.com%22`, "", &list)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	require.Equal(1, list.TotalResults)
+	assert.Equal(alice.Id, list.Resources[0].(map[string]interface{})["id"])
+
+	resp = do(t, ts, http.MethodGet, `Users?filter=userName+eq+%22nobody%22`, "", &list)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal(0, list.TotalResults)
+	assert.Len(list.Resources, 0)
+
+	resp = do(t, ts, http.MethodGet, `Users?startIndex=2&count=1`, "", &list)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal(2, list.TotalResults)
+	assert.Equal(2, list.StartIndex)
+	assert.Equal(1, list.ItemsPerPage)
+
+	resp = do(t, ts, http.MethodGet, `Users?filter=userName+xx+%22a%22`, "", &scimErr)
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+	assert.Equal("invalidFilter", scimErr.ScimType)
+
+	// put
+	var put User
+	resp = do(t, ts, http.MethodPut, "Users/"+alice.Id, `{"userName":"alice@example.com","displayName":"Alice Smith"}`, &put)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("Alice Smith", put.DisplayName)
+	assert.NotEqual(alice.Meta.Version, put.Meta.Version)
+
+	// patch
+	var patched User
+	resp = do(t, ts, http.MethodPatch, "Users/"+alice.Id, `{"schemas":["`+PatchOpSchema+`"],"Operations":[{"op":"Replace","path":"userName","value":"asmith@example.com"}]}`, &patched)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("asmith@example.com", patched.UserName)
+	assert.Equal("Alice Smith", patched.DisplayName)
+
+	// deactivate deletes the user
+	resp = do(t, ts, http.MethodPatch, "Users/"+alice.Id, `{"schemas":["`+PatchOpSchema+`"],"Operations":[{"op":"Replace","path":"active","value":"False"}]}`, &patched)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	require.NotNil(patched.Active)
+	assert.False(*patched.Active)
+	resp = do(t, ts, http.MethodGet, "Users/"+alice.Id, "", nil)
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+
+	// delete
+	resp = do(t, ts, http.MethodDelete, "Users/"+bob.Id, "", nil)
+	assert.Equal(http.StatusNoContent, resp.StatusCode)
+	resp = do(t, ts, http.MethodDelete, "Users/"+bob.Id, "", nil)
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+	resp = do(t, ts, http.MethodDelete, "Users/"+other.PublicId, "", nil)
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func TestServer_Groups(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	repo := newTestRepo()
+	ts := newTestServer(t, repo)
+	u1 := repo.addUser(testScopeId, "alice@example.com")
+	u2 := repo.addUser(testScopeId, "bob@example.com")
+	other := repo.addUser("o_other", "carol@example.com")
+
+	// create
+	var g Group
+	resp := do(t, ts, http.MethodPost, "Groups", fmt.Sprintf(`{"schemas":["%s"],"displayName":"eng","members":[{"value":%q}]}`, GroupSchema, u1.PublicId), &g)
+	require.Equal(http.StatusCreated, resp.StatusCode)
+	assert.Equal("eng", g.DisplayName)
+	assert.Equal([]string{u1.PublicId}, memberIds(&g))
+
+	resp = do(t, ts, http.MethodPost, "Groups", fmt.Sprintf(`{"displayName":"ops","members":[{"value":%q}]}`, other.PublicId), nil)
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	// filter by member
+	var list ListResponse
+	resp = do(t, ts, http.MethodGet, "Groups?filter=members%5Bvalue+eq+%22"+u1.PublicId+"%22%5D", "", &list)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal(1, list.TotalResults)
+	resp = do(t, ts, http.MethodGet, "Groups?filter=displayName+eq+%22ops%22", "", &list)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal(0, list.TotalResults)
+
+	// patch members
+	resp = do(t, ts, http.MethodPatch, "Groups/"+g.Id, fmt.Sprintf(`{"schemas":["%s"],"Operations":[{"op":"add","path":"members","value":[{"value":%q}]}]}`, PatchOpSchema, u2.PublicId), &g)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.ElementsMatch([]string{u1.PublicId, u2.PublicId}, memberIds(&g))
+
+	resp = do(t, ts, http.MethodPatch, "Groups/"+g.Id, fmt.Sprintf(`{"schemas":["%s"],"Operations":[{"op":"remove","path":"members[value eq \"%s\"]"}]}`, PatchOpSchema, u1.PublicId), &g)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal([]string{u2.PublicId}, memberIds(&g))
+
+	resp = do(t, ts, http.MethodPatch, "Groups/"+g.Id, fmt.Sprintf(`{"schemas":["%s"],"Operations":[{"op":"replace","value":{"displayName":"Engineering"}}]}`, PatchOpSchema), &g)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("Engineering", g.DisplayName)
+	assert.Equal([]string{u2.PublicId}, memberIds(&g))
+
+	// put
+	var put Group
+	resp = do(t, ts, http.MethodPut, "Groups/"+g.Id, `{"displayName":"Engineering","members":[]}`, &put)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Empty(put.Members)
+
+	var got Group
+	resp = do(t, ts, http.MethodGet, "Groups/"+g.Id, "", &got)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal(put, got)
+
+	// delete
+	resp = do(t, ts, http.MethodDelete, "Groups/"+g.Id, "", nil)
+	assert.Equal(http.StatusNoContent, resp.StatusCode)
+	resp = do(t, ts, http.MethodGet, "Groups/"+g.Id, "", nil)
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func TestServer_NestedGroups(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	repo := newTestRepo()
+	ts := newTestServer(t, repo)
+	u1 := repo.addUser(testScopeId, "alice@example.com")
+	u2 := repo.addUser(testScopeId, "bob@example.com")
+
+	var child Group
+	resp := do(t, ts, http.MethodPost, "Groups", fmt.Sprintf(`{"displayName":"ops","members":[{"value":%q}]}`, u1.PublicId), &child)
+	require.Equal(http.StatusCreated, resp.StatusCode)
+
+	// groups are members of type Group
+	var g Group
+	resp = do(t, ts, http.MethodPost, "Groups", fmt.Sprintf(`{"displayName":"eng","members":[{"value":%q},{"value":%q,"type":"Group"}]}`, u2.PublicId, child.Id), &g)
+	require.Equal(http.StatusCreated, resp.StatusCode)
+	require.Len(g.Members, 2)
+	for _, m := range g.Members {
+		switch m.Value {
+		case u2.PublicId:
+			assert.Equal("User", m.Type)
+			assert.True(strings.HasSuffix(m.Ref, "/Users/"+u2.PublicId))
+		case child.Id:
+			assert.Equal("Group", m.Type)
+			assert.True(strings.HasSuffix(m.Ref, "/Groups/"+child.Id))
+		default:
+			assert.Fail("unexpected member", m.Value)
+		}
+	}
+
+	// a GET then PUT round-trip keeps the members
+	var got Group
+	resp = do(t, ts, http.MethodGet, "Groups/"+g.Id, "", &got)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	body, err := json.Marshal(got)
+	require.NoError(err)
+	var put Group
+	resp = do(t, ts, http.MethodPut, "Groups/"+g.Id, string(body), &put)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.ElementsMatch([]string{u2.PublicId, child.Id}, memberIds(&put))
+
+	// groups can be removed by a patch like users
+	resp = do(t, ts, http.MethodPatch, "Groups/"+g.Id, fmt.Sprintf(`{"schemas":["%s"],"Operations":[{"op":"remove","path":"members[type eq \"Group\"]"}]}`, PatchOpSchema), &g)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal([]string{u2.PublicId}, memberIds(&g))
+
+	// the member's type must match its id
+	resp = do(t, ts, http.MethodPatch, "Groups/"+g.Id, fmt.Sprintf(`{"schemas":["%s"],"Operations":[{"op":"add","path":"members","value":[{"value":%q,"type":"User"}]}]}`, PatchOpSchema, child.Id), nil)
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	// groups of other scopes can't be members
+	otherGroup, err := repo.CreateGroup(context.Background(), &iam.Group{Group: &store.Group{ScopeId: "o_other", Name: "other"}})
+	require.NoError(err)
+	resp = do(t, ts, http.MethodPatch, "Groups/"+g.Id, fmt.Sprintf(`{"schemas":["%s"],"Operations":[{"op":"add","path":"members","value":[{"value":%q}]}]}`, PatchOpSchema, otherGroup.PublicId), nil)
+	assert.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	// deleting a group removes it from its parents
+	resp = do(t, ts, http.MethodPatch, "Groups/"+g.Id, fmt.Sprintf(`{"schemas":["%s"],"Operations":[{"op":"add","path":"members","value":[{"value":%q}]}]}`, PatchOpSchema, child.Id), &g)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	resp = do(t, ts, http.MethodDelete, "Groups/"+child.Id, "", nil)
+	require.Equal(http.StatusNoContent, resp.StatusCode)
+	resp = do(t, ts, http.MethodGet, "Groups/"+g.Id, "", &got)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal([]string{u2.PublicId}, memberIds(&got))
+}
+
+func TestServer_Discovery(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ts := newTestServer(t, newTestRepo())
+
+	var config map[string]interface{}
+	resp := do(t, ts, http.MethodGet, "ServiceProviderConfig", "", &config)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal([]interface{}{ServiceProviderConfigSchema}, config["schemas"])
+	assert.Equal(true, config["patch"].(map[string]interface{})["supported"])
+
+	var types ListResponse
+	resp = do(t, ts, http.MethodGet, "ResourceTypes", "", &types)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal(2, types.TotalResults)
+
+	resp = do(t, ts, http.MethodDelete, "ServiceProviderConfig", "", nil)
+	assert.Equal(http.StatusMethodNotAllowed, resp.StatusCode)
+	resp = do(t, ts, http.MethodGet, "Schemas/unknown/path", "", nil)
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func newTestServer(t *testing.T, repo *testRepo) *httptest.Server {
+	t.Helper()
+	s, err := NewServer(func() (Repository, error) { return repo, nil }, map[string]string{testToken: testScopeId})
+	require.NoError(t, err)
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// do sends a request to the test server and decodes the response body into
+// out, when it's not nil.
+func do(t *testing.T, ts *httptest.Server, method, path, body string, out interface{}) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, ts.URL+PathPrefix+path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	req.Header.Set("Content-Type", ContentType)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp
+}
+
+func memberIds(g *Group) []string {
+	var ids []string
+	for _, m := range g.Members {
+		ids = append(ids, m.Value)
+	}
+	return ids
+}
+
+// testRepo is an in-memory Repository.
+type testRepo struct {
+	mu      sync.Mutex
+	nextId  int
+	users   map[string]*iam.User
+	groups  map[string]*iam.Group
+	members map[string][]string
+}
+
+func newTestRepo() *testRepo {
+	return &testRepo{
+		users:   map[string]*iam.User{},
+		groups:  map[string]*iam.Group{},
+		members: map[string][]string{},
+	}
+}
+
+func (r *testRepo) id(prefix string) string {
+	r.nextId++
+	return fmt.Sprintf("%s_%010d", prefix, r.nextId)
+}
+
+func (r *testRepo) addUser(scopeId, name string) *iam.User {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u := &iam.User{User: &store.User{ScopeId: scopeId, Name: name}}
+	u.PublicId = r.id("u")
+	u.Version = 1
+	u.CreateTime, u.UpdateTime = now(), now()
+	r.users[u.PublicId] = u
+	return u.Clone().(*iam.User)
+}
+
+func now() *timestamp.Timestamp {
+	return &timestamp.Timestamp{Timestamp: timestamppb.Now()}
+}
+
+func (r *testRepo) CreateUser(_ context.Context, user *iam.User, _ ...iam.Option) (*iam.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.ScopeId == user.ScopeId && u.Name == user.Name {
+			return nil, errors.New(errors.NotUnique, "testRepo.CreateUser", "name is not unique")
+		}
+	}
+	u := user.Clone().(*iam.User)
+	u.PublicId = r.id("u")
+	u.Version = 1
+	u.CreateTime, u.UpdateTime = now(), now()
+	r.users[u.PublicId] = u
+	return u.Clone().(*iam.User), nil
+}
+
+func (r *testRepo) LookupUser(_ context.Context, userId string, _ ...iam.Option) (*iam.User, []string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.users[userId]
+	if !ok {
+		return nil, nil, nil
+	}
+	return u.Clone().(*iam.User), nil, nil
+}
+
+func (r *testRepo) UpdateUser(_ context.Context, user *iam.User, version uint32, _ []string, _ ...iam.Option) (*iam.User, []string, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.users[user.PublicId]
+	if !ok || u.Version != version {
+		return nil, nil, 0, errors.New(errors.RecordNotFound, "testRepo.UpdateUser", "user not found")
+	}
+	u.Name, u.Description = user.Name, user.Description
+	u.Version++
+	u.UpdateTime = now()
+	return u.Clone().(*iam.User), nil, 1, nil
+}
+
+func (r *testRepo) DeleteUser(_ context.Context, withPublicId string, _ ...iam.Option) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.users[withPublicId]; !ok {
+		return 0, errors.New(errors.RecordNotFound, "testRepo.DeleteUser", "user not found")
+	}
+	delete(r.users, withPublicId)
+	for gId, ids := range r.members {
+		r.members[gId] = remove(ids, withPublicId)
+	}
+	return 1, nil
+}
+
+func (r *testRepo) ListUsers(_ context.Context, withScopeIds []string, _ ...iam.Option) ([]*iam.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var users []*iam.User
+	for _, u := range r.users {
+		if u.ScopeId == withScopeIds[0] {
+			users = append(users, u.Clone().(*iam.User))
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].PublicId < users[j].PublicId })
+	return users, nil
+}
+
+func (r *testRepo) CreateGroup(_ context.Context, group *iam.Group, _ ...iam.Option) (*iam.Group, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	g := group.Clone().(*iam.Group)
+	g.PublicId = r.id("g")
+	g.Version = 1
+	g.CreateTime, g.UpdateTime = now(), now()
+	r.groups[g.PublicId] = g
+	return g.Clone().(*iam.Group), nil
+}
+
+func (r *testRepo) LookupGroup(ctx context.Context, withPublicId string, _ ...iam.Option) (*iam.Group, []*iam.GroupMember, error) {
+	r.mu.Lock()
+	g, ok := r.groups[withPublicId]
+	r.mu.Unlock()
+	if !ok {
+		return nil, nil, nil
+	}
+	members, err := r.ListGroupMembers(ctx, withPublicId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g.Clone().(*iam.Group), members, nil
+}
+
+func (r *testRepo) UpdateGroup(ctx context.Context, group *iam.Group, version uint32, _ []string, _ ...iam.Option) (*iam.Group, []*iam.GroupMember, int, error) {
+	r.mu.Lock()
+	g, ok := r.groups[group.PublicId]
+	if !ok || g.Version != version {
+		r.mu.Unlock()
+		return nil, nil, 0, errors.New(errors.RecordNotFound, "testRepo.UpdateGroup", "group not found")
+	}
+	g.Name, g.Description = group.Name, group.Description
+	g.Version++
+	g.UpdateTime = now()
+	r.mu.Unlock()
+	members, err := r.ListGroupMembers(ctx, group.PublicId)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return g.Clone().(*iam.Group), members, 1, nil
+}
+
+func (r *testRepo) DeleteGroup(_ context.Context, withPublicId string, _ ...iam.Option) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.groups[withPublicId]; !ok {
+		return 0, errors.New(errors.RecordNotFound, "testRepo.DeleteGroup", "group not found")
+	}
+	delete(r.groups, withPublicId)
+	delete(r.members, withPublicId)
+	for gId, ids := range r.members {
+		r.members[gId] = remove(ids, withPublicId)
+	}
+	return 1, nil
+}
+
+func (r *testRepo) ListGroups(_ context.Context, withScopeIds []string, _ ...iam.Option) ([]*iam.Group, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var groups []*iam.Group
+	for _, g := range r.groups {
+		if g.ScopeId == withScopeIds[0] {
+			groups = append(groups, g.Clone().(*iam.Group))
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].PublicId < groups[j].PublicId })
+	return groups, nil
+}
+
+func (r *testRepo) ListGroupMembers(_ context.Context, withGroupId string, _ ...iam.Option) ([]*iam.GroupMember, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var members []*iam.GroupMember
+	for _, id := range r.members[withGroupId] {
+		memberType := iam.UserMemberType
+		if strings.HasPrefix(id, iam.GroupPrefix+"_") {
+			memberType = iam.GroupMemberType
+		}
+		members = append(members, &iam.GroupMember{GroupMemberView: &store.GroupMemberView{GroupId: withGroupId, MemberId: id, Type: memberType.String()}})
+	}
+	return members, nil
+}
+
+func (r *testRepo) SetGroupMembers(ctx context.Context, groupId string, groupVersion uint32, memberIds []string, _ ...iam.Option) ([]*iam.GroupMember, int, error) {
+	r.mu.Lock()
+	g, ok := r.groups[groupId]
+	if !ok || g.Version != groupVersion {
+		r.mu.Unlock()
+		return nil, 0, errors.New(errors.RecordNotFound, "testRepo.SetGroupMembers", "group not found")
+	}
+	g.Version++
+	r.members[groupId] = append([]string(nil), memberIds...)
+	r.mu.Unlock()
+	members, err := r.ListGroupMembers(ctx, groupId)
+	if err != nil {
+		return nil, 0, err
+	}
+	return members, len(members), nil
+}
+
+func remove(ids []string, id string) []string {
+	out := ids[:0]
+	for _, i := range ids {
+		if i != id {
+			out = append(out, i)
+		}
+	}
+	return out
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/servers"
-	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
 )
 
@@ -17,6 +16,6 @@ type (
 	PasswordAuthRepoFactory func() (*password.Repository, error)
 	ServersRepoFactory      func() (*servers.Repository, error)
 	StaticRepoFactory       func() (*static.Repository, error)
-	SessionRepoFactory      func() (*session.Repository, error)
+	SessionRepoFactory      = oidc.SessionRepoFactory
 	TargetRepoFactory       func() (*target.Repository, error)
 )
@@ -53,6 +53,10 @@ type Controller struct {
 	scheduler *scheduler.Scheduler
 
 	kms *kms.Kms
+
+	// apiRateLimiter is shared by all API listeners; nil when no limits are
+	// configured
+	apiRateLimiter *rateLimiter
 }
 
 func New(conf *Config) (*Controller, error) {
@@ -79,6 +83,8 @@ func New(conf *Config) (*Controller, error) {
 		}
 	}
 
+	c.apiRateLimiter = newRateLimiter(conf.RawConfig.Controller.ApiRateLimits)
+
 	if !conf.RawConfig.DisableMlock {
 		// Ensure our memory usage is locked into physical RAM
 		if err := mlock.LockMemory(); err != nil {
@@ -170,6 +176,7 @@ func (c *Controller) Start() error {
 	c.startRecoveryNonceCleanupTicking(c.baseContext)
 	c.startTerminateCompletedSessionsTicking(c.baseContext)
 	c.startCloseExpiredPendingTokens(c.baseContext)
+	c.startOidcRevalidationTicking(c.baseContext)
 	c.started.Store(true)
 
 	return nil
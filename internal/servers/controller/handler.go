@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/textproto"
 	"os"
@@ -18,8 +19,10 @@ import (
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/scim"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/accounts"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authorization"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_sets"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/targets"
@@ -35,6 +38,7 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_catalogs"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/hosts"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roles"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roletemplates"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/scopes"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/users"
 )
@@ -55,16 +59,39 @@ func (c *Controller) handler(props HandlerProperties) (http.Handler, error) {
 		return nil, err
 	}
 	mux.Handle("/v1/", h)
+	if len(c.conf.RawConfig.Controller.Scim) > 0 {
+		s, err := handleScim(c)
+		if err != nil {
+			return nil, err
+		}
+		mux.Handle(scim.PathPrefix, s)
+	}
 	mux.Handle("/", handleUi(c))
 
 	corsWrappedHandler := wrapHandlerWithCors(mux, props)
 	commonWrappedHandler := wrapHandlerWithCommonFuncs(corsWrappedHandler, c, props)
 	callbackInterceptingHandler := wrapHandlerWithCallbackInterceptor(commonWrappedHandler, c)
-	printablePathCheckHandler := cleanhttp.PrintablePathCheckHandler(callbackInterceptingHandler, nil)
+	rateLimitedHandler := wrapHandlerWithRateLimiter(callbackInterceptingHandler, c)
+	printablePathCheckHandler := cleanhttp.PrintablePathCheckHandler(rateLimitedHandler, nil)
 
 	return printablePathCheckHandler, nil
 }
 
+func handleScim(c *Controller) (http.Handler, error) {
+	tokens := make(map[string]string, len(c.conf.RawConfig.Controller.Scim))
+	for _, s := range c.conf.RawConfig.Controller.Scim {
+		tokens[s.Token] = s.ScopeId
+	}
+	repoFn := func() (scim.Repository, error) {
+		return c.IamRepoFn()
+	}
+	s, err := scim.NewServer(repoFn, tokens, scim.WithLogger(c.logger.Named("scim")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scim server: %w", err)
+	}
+	return s, nil
+}
+
 func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, error) {
 	// Register*ServiceHandlerServer methods ignore the passed in ctx. Using it
 	// now however in case this changes in the future.
@@ -83,14 +110,14 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterHostCatalogServiceHandlerServer(ctx, mux, hcs); err != nil {
 		return nil, fmt.Errorf("failed to register host catalog service handler: %w", err)
 	}
-	hss, err := host_sets.NewService(c.StaticHostRepoFn)
+	hss, err := host_sets.NewService(c.StaticHostRepoFn, c.IamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create host set handler service: %w", err)
 	}
 	if err := services.RegisterHostSetServiceHandlerServer(ctx, mux, hss); err != nil {
 		return nil, fmt.Errorf("failed to register host set service handler: %w", err)
 	}
-	hs, err := hosts.NewService(c.StaticHostRepoFn)
+	hs, err := hosts.NewService(c.StaticHostRepoFn, c.IamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create host handler service: %w", err)
 	}
@@ -104,7 +131,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterAccountServiceHandlerServer(ctx, mux, accts); err != nil {
 		return nil, fmt.Errorf("failed to register account service handler: %w", err)
 	}
-	authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.OidcRepoFn, c.IamRepoFn, c.AuthTokenRepoFn, handlers.WithLogger(c.logger.Named("authmethod_service")))
+	authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.OidcRepoFn, c.IamRepoFn, c.AuthTokenRepoFn, c.SessionRepoFn, handlers.WithLogger(c.logger.Named("authmethod_service")))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth method handler service: %w", err)
 	}
@@ -159,6 +186,13 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterRoleServiceHandlerServer(ctx, mux, rs); err != nil {
 		return nil, fmt.Errorf("failed to register role service handler: %w", err)
 	}
+	rts, err := roletemplates.NewService(c.IamRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create role template handler service: %w", err)
+	}
+	if err := services.RegisterRoleTemplateServiceHandlerServer(ctx, mux, rts); err != nil {
+		return nil, fmt.Errorf("failed to register role template service handler: %w", err)
+	}
 	ss, err := sessions.NewService(c.SessionRepoFn, c.IamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create session handler service: %w", err)
@@ -166,6 +200,13 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterSessionServiceHandlerServer(ctx, mux, ss); err != nil {
 		return nil, fmt.Errorf("failed to register session service handler: %w", err)
 	}
+	azs, err := authorization.NewService(c.IamRepoFn, c.AuthTokenRepoFn, c.PasswordAuthRepoFn, c.OidcRepoFn, c.StaticHostRepoFn, c.TargetRepoFn, c.SessionRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authorization handler service: %w", err)
+	}
+	if err := services.RegisterAuthorizationServiceHandlerServer(ctx, mux, azs); err != nil {
+		return nil, fmt.Errorf("failed to register authorization service handler: %w", err)
+	}
 
 	return mux, nil
 }
@@ -334,24 +375,36 @@ func wrapHandlerWithCallbackInterceptor(h http.Handler, c *Controller) http.Hand
 	logCallbackErrors := os.Getenv("BOUNDARY_LOG_CALLBACK_ERRORS") != ""
 
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		// If this doesn't have a callback suffix on a supported action, serve
-		// normally
-		if !strings.HasSuffix(req.URL.Path, ":authenticate:callback") {
+		// If this doesn't have a callback or logout suffix on a supported
+		// action, serve normally
+		var command string
+		switch {
+		case strings.HasSuffix(req.URL.Path, ":authenticate:callback"):
+			command = "callback"
+		case strings.HasSuffix(req.URL.Path, ":authenticate:logout"):
+			command = "logout"
+		default:
 			h.ServeHTTP(w, req)
 			return
 		}
 
-		req.URL.Path = strings.TrimSuffix(req.URL.Path, ":callback")
+		req.URL.Path = strings.TrimSuffix(req.URL.Path, ":"+command)
 
-		// How we get the parameters changes based on the method. Right now only
-		// GET is supported with query args, but this can support POST with JSON
-		// or URL-encoded args. In those cases, the MIME type would have to be
-		// checked; for URL-encoded it'd use ParseForm like Get, and for JSON
-		// you'd use a json.RawMessage for Attributes consisting of the body. Or
-		// something very similar to that.
+		// How we get the parameters changes based on the method. GET is
+		// supported with query args and POST is supported with URL-encoded
+		// args (which is how an OIDC provider sends a back-channel logout).
+		// POST with JSON could be supported by using a json.RawMessage for
+		// Attributes consisting of the body. Or something very similar to
+		// that.
 		var useForm bool
 		switch req.Method {
 		case http.MethodGet:
+			useForm = true
+		case http.MethodPost:
+			mediaType, _, _ := mime.ParseMediaType(req.Header.Get("content-type"))
+			useForm = mediaType == "application/x-www-form-urlencoded"
+		}
+		if useForm {
 			if err := req.ParseForm(); err != nil {
 				if logCallbackErrors && c != nil {
 					c.logger.Trace("callback error", "method", req.Method, "url", req.URL.RequestURI(), "error", err)
@@ -359,11 +412,10 @@ func wrapHandlerWithCallbackInterceptor(h http.Handler, c *Controller) http.Hand
 				w.WriteHeader(http.StatusBadRequest)
 				return
 			}
-			useForm = true
 		}
 
 		attrs := &cmdAttrs{
-			Command: "callback",
+			Command: command,
 		}
 
 		switch {
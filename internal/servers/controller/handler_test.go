@@ -108,6 +108,7 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/auth-tokens/someid",
 			"v1/groups",
 			"v1/groups/someid",
+			"v1/groups/someid:read-resolved-members",
 			"v1/host-catalogs",
 			"v1/host-catalogs/someid",
 			"v1/host-sets",
@@ -116,6 +117,8 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/hosts/someid",
 			"v1/roles",
 			"v1/roles/someid",
+			"v1/role-templates",
+			"v1/role-templates/someid",
 			"400_v1/sc\u200Bopes",
 			"200_v1/scopes",
 			"v1/scopes/someid",
@@ -125,6 +128,7 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/targets/someid",
 			"v1/users",
 			"v1/users/someid",
+			"v1/users/someid:read-effective-permissions",
 		},
 		"POST": {
 			// Creation end points
@@ -135,6 +139,7 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/host-sets",
 			"v1/hosts",
 			"v1/roles",
+			"v1/role-templates",
 			"v1/scopes",
 			"v1/targets",
 			"v1/users",
@@ -144,23 +149,42 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/accounts/someid:change-password",
 			"v1/auth-methods/someid:authenticate",
 			"v1/auth-methods/someid:authenticate:login",
+			"v1/authorization:explain",
 			"v1/groups/someid:add-members",
 			"v1/groups/someid:set-members",
 			"v1/groups/someid:remove-members",
+			"v1/groups/someid:transfer-ownership",
+			"v1/host-catalogs/someid:set-labels",
 			"v1/host-sets/someid:add-hosts",
 			"v1/host-sets/someid:remove-hosts",
 			"v1/host-sets/someid:set-hosts",
+			"v1/host-sets/someid:set-labels",
+			"v1/host-sets/someid:transfer-ownership",
+			"v1/hosts/someid:set-labels",
+			"v1/hosts/someid:transfer-ownership",
 			"v1/roles/someid:add-grants",
 			"v1/roles/someid:set-grants",
 			"v1/roles/someid:remove-grants",
+			"v1/roles/someid:add-grant-scopes",
+			"v1/roles/someid:set-grant-scopes",
+			"v1/roles/someid:remove-grant-scopes",
 			"v1/roles/someid:add-principals",
 			"v1/roles/someid:set-principals",
 			"v1/roles/someid:remove-principals",
+			"v1/role-templates/someid:add-grants",
+			"v1/role-templates/someid:set-grants",
+			"v1/role-templates/someid:remove-grants",
+			"v1/role-templates/someid:add-principals",
+			"v1/role-templates/someid:set-principals",
+			"v1/role-templates/someid:remove-principals",
+			"v1/scopes/someid:set-labels",
 			"v1/sessions/someid:cancel",
 			"v1/targets/someid:authorize-session",
 			"v1/targets/someid:add-host-sets",
 			"v1/targets/someid:set-host-sets",
 			"v1/targets/someid:remove-host-sets",
+			"v1/targets/someid:set-labels",
+			"v1/targets/someid:transfer-ownership",
 			"v1/users/someid:add-accounts",
 			"v1/users/someid:set-accounts",
 			"v1/users/someid:remove-accounts",
@@ -174,6 +198,7 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/host-sets/someid",
 			"v1/hosts/someid",
 			"v1/roles/someid",
+			"v1/role-templates/someid",
 			"v1/scopes/someid",
 			"v1/targets/someid",
 			"v1/users/someid",
@@ -186,6 +211,7 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/host-sets/someid",
 			"v1/hosts/someid",
 			"v1/roles/someid",
+			"v1/role-templates/someid",
 			"v1/scopes/someid",
 			"v1/targets/someid",
 			"v1/users/someid",
@@ -256,6 +282,7 @@ func TestCallbackInterceptor(t *testing.T) {
 
 	testCases := []struct {
 		name     string
+		method   string
 		path     string
 		args     url.Values
 		wantJson *cmdAttrs
@@ -296,19 +323,49 @@ func TestCallbackInterceptor(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "logout, with form args",
+			method: http.MethodPost,
+			path:   "v1/auth-methods/amoidc_1234567890:authenticate:logout",
+			args: url.Values{
+				"logout_token": []string{"fooBar"},
+			},
+			wantJson: &cmdAttrs{
+				Command: "logout",
+				Attributes: map[string]interface{}{
+					"logout_token": "fooBar",
+				},
+			},
+		},
+		{
+			name:     "logout, no args",
+			method:   http.MethodPost,
+			path:     "v1/auth-methods/amoidc_1234567890:authenticate:logout",
+			wantJson: &cmdAttrs{Command: "logout"},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			require := require.New(t)
 
-			req, err := http.NewRequest(http.MethodGet,
-				fmt.Sprintf("http://%s/%s", listener.Addr().String(), tc.path),
-				nil)
-			require.NoError(err)
+			var req *http.Request
+			switch tc.method {
+			case http.MethodPost:
+				req, err = http.NewRequest(http.MethodPost,
+					fmt.Sprintf("http://%s/%s", listener.Addr().String(), tc.path),
+					strings.NewReader(tc.args.Encode()))
+				require.NoError(err)
+				req.Header.Set("content-type", "application/x-www-form-urlencoded")
+			default:
+				req, err = http.NewRequest(http.MethodGet,
+					fmt.Sprintf("http://%s/%s", listener.Addr().String(), tc.path),
+					nil)
+				require.NoError(err)
 
-			if tc.args != nil {
-				req.URL.RawQuery = tc.args.Encode()
+				if tc.args != nil {
+					req.URL.RawQuery = tc.args.Encode()
+				}
 			}
 
 			resp, err := http.DefaultClient.Do(req)
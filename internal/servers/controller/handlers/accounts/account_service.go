@@ -868,6 +868,7 @@ func toProto(ctx context.Context, in auth.Account, opt ...handlers.Option) (*pb.
 		}
 		out.Attributes = st
 	}
+	handlers.FilterOutputFields(&out, outputFields)
 	return &out, nil
 }
 
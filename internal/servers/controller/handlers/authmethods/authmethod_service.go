@@ -67,17 +67,18 @@ var (
 type Service struct {
 	pbs.UnimplementedAuthMethodServiceServer
 
-	kms        *kms.Kms
-	pwRepoFn   common.PasswordAuthRepoFactory
-	oidcRepoFn common.OidcAuthRepoFactory
-	iamRepoFn  common.IamRepoFactory
-	atRepoFn   common.AuthTokenRepoFactory
+	kms           *kms.Kms
+	pwRepoFn      common.PasswordAuthRepoFactory
+	oidcRepoFn    common.OidcAuthRepoFactory
+	iamRepoFn     common.IamRepoFactory
+	atRepoFn      common.AuthTokenRepoFactory
+	sessionRepoFn common.SessionRepoFactory
 
 	oidcLogger hclog.Logger
 }
 
 // NewService returns a auth method service which handles auth method related requests to boundary.
-func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoFn common.OidcAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory, opt ...handlers.Option) (Service, error) {
+func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoFn common.OidcAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory, sessionRepoFn common.SessionRepoFactory, opt ...handlers.Option) (Service, error) {
 	const op = "authmethods.NewService"
 	if kms == nil {
 		return Service{}, errors.New(errors.InvalidParameter, op, "missing kms")
@@ -94,7 +95,10 @@ func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoF
 	if atRepoFn == nil {
 		return Service{}, fmt.Errorf("nil auth token repository provided")
 	}
-	s := Service{kms: kms, pwRepoFn: pwRepoFn, oidcRepoFn: oidcRepoFn, iamRepoFn: iamRepoFn, atRepoFn: atRepoFn}
+	if sessionRepoFn == nil {
+		return Service{}, errors.New(errors.InvalidParameter, op, "missing session repository")
+	}
+	s := Service{kms: kms, pwRepoFn: pwRepoFn, oidcRepoFn: oidcRepoFn, iamRepoFn: iamRepoFn, atRepoFn: atRepoFn, sessionRepoFn: sessionRepoFn}
 	opts := handlers.GetOpts(opt...)
 	if opts.WithLogger != nil {
 		s.oidcLogger = opts.WithLogger.Named("oidc")
@@ -801,6 +805,7 @@ func toAuthMethodProto(ctx context.Context, in auth.AuthMethod, opt ...handlers.
 		if len(i.GetApiUrl()) > 0 {
 			attrs.ApiUrlPrefix = wrapperspb.String(i.GetApiUrl())
 			attrs.CallbackUrl = fmt.Sprintf("%s/v1/auth-methods/oidc:authenticate:callback", i.GetApiUrl())
+			attrs.BackchannelLogoutUrl = fmt.Sprintf(oidc.LogoutEndpoint, i.GetApiUrl(), i.GetPublicId())
 		}
 		switch i.GetMaxAge() {
 		case 0:
@@ -816,6 +821,7 @@ func toAuthMethodProto(ctx context.Context, in auth.AuthMethod, opt ...handlers.
 		}
 		out.Attributes = st
 	}
+	handlers.FilterOutputFields(&out, outputFields)
 	return &out, nil
 }
 
@@ -898,6 +904,9 @@ func validateCreateRequest(req *pbs.CreateAuthMethodRequest) error {
 				if attrs.GetCallbackUrl() != "" {
 					badFields[callbackUrlField] = "Field is read only."
 				}
+				if attrs.GetBackchannelLogoutUrl() != "" {
+					badFields[backchannelLogoutUrlField] = "Field is read only."
+				}
 				if len(attrs.GetSigningAlgorithms()) > 0 {
 					for _, sa := range attrs.GetSigningAlgorithms() {
 						if !oidc.SupportedAlgorithm(oidc.Alg(sa)) {
@@ -1026,6 +1035,9 @@ func validateUpdateRequest(req *pbs.UpdateAuthMethodRequest) error {
 			if attrs.GetCallbackUrl() != "" {
 				badFields[callbackUrlField] = "Field is read only."
 			}
+			if attrs.GetBackchannelLogoutUrl() != "" {
+				badFields[backchannelLogoutUrlField] = "Field is read only."
+			}
 
 			if len(attrs.GetSigningAlgorithms()) > 0 {
 				for _, sa := range attrs.GetSigningAlgorithms() {
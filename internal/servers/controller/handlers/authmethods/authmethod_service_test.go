@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"google.golang.org/grpc/codes"
@@ -82,6 +83,9 @@ func TestGet(t *testing.T) {
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kmsCache)
 	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -121,12 +125,13 @@ func TestGet(t *testing.T) {
 		UpdatedTime: oidcam.UpdateTime.GetTimestamp(),
 		Type:        auth.OidcSubtype.String(),
 		Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-			"issuer":             structpb.NewStringValue("https://alice.com"),
-			"client_id":          structpb.NewStringValue("alice_rp"),
-			"client_secret_hmac": structpb.NewStringValue("<hmac>"),
-			"state":              structpb.NewStringValue(string(oidc.InactiveState)),
-			"api_url_prefix":     structpb.NewStringValue("https://api.com"),
-			"callback_url":       structpb.NewStringValue(fmt.Sprintf(oidc.CallbackEndpoint, "https://api.com")),
+			"issuer":                 structpb.NewStringValue("https://alice.com"),
+			"client_id":              structpb.NewStringValue("alice_rp"),
+			"client_secret_hmac":     structpb.NewStringValue("<hmac>"),
+			"state":                  structpb.NewStringValue(string(oidc.InactiveState)),
+			"api_url_prefix":         structpb.NewStringValue("https://api.com"),
+			"callback_url":           structpb.NewStringValue(fmt.Sprintf(oidc.CallbackEndpoint, "https://api.com")),
+			"backchannel_logout_url": structpb.NewStringValue(fmt.Sprintf(oidc.LogoutEndpoint, "https://api.com", oidcam.GetPublicId())),
 		}},
 		Version: 1,
 		Scope: &scopepb.ScopeInfo{
@@ -183,7 +188,7 @@ func TestGet(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn)
+			s, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, sessionRepoFn)
 			require.NoError(err, "Couldn't create new auth_method service.")
 
 			got, gErr := s.GetAuthMethod(auth.DisabledAuthTestContext(iamRepoFn, tc.scopeId), tc.req)
@@ -219,6 +224,9 @@ func TestList(t *testing.T) {
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kmsCache)
 	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	oNoAuthMethods, _ := iam.TestScopes(t, iamRepo)
@@ -241,12 +249,13 @@ func TestList(t *testing.T) {
 		Version:     2,
 		Type:        auth.OidcSubtype.String(),
 		Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-			"issuer":             structpb.NewStringValue("https://alice.com"),
-			"client_id":          structpb.NewStringValue("alice_rp"),
-			"client_secret_hmac": structpb.NewStringValue("<hmac>"),
-			"state":              structpb.NewStringValue(string(oidc.ActivePublicState)),
-			"api_url_prefix":     structpb.NewStringValue("https://api.com"),
-			"callback_url":       structpb.NewStringValue(fmt.Sprintf(oidc.CallbackEndpoint, "https://api.com")),
+			"issuer":                 structpb.NewStringValue("https://alice.com"),
+			"client_id":              structpb.NewStringValue("alice_rp"),
+			"client_secret_hmac":     structpb.NewStringValue("<hmac>"),
+			"state":                  structpb.NewStringValue(string(oidc.ActivePublicState)),
+			"api_url_prefix":         structpb.NewStringValue("https://api.com"),
+			"callback_url":           structpb.NewStringValue(fmt.Sprintf(oidc.CallbackEndpoint, "https://api.com")),
+			"backchannel_logout_url": structpb.NewStringValue(fmt.Sprintf(oidc.LogoutEndpoint, "https://api.com", oidcam.GetPublicId())),
 			"signing_algorithms": func() *structpb.Value {
 				lv, _ := structpb.NewList([]interface{}{string(oidc.EdDSA)})
 				return structpb.NewListValue(lv)
@@ -349,7 +358,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn)
+			s, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, sessionRepoFn)
 			require.NoError(err, "Couldn't create new auth_method service.")
 
 			// First check with non-anonymous user
@@ -400,6 +409,9 @@ func TestDelete(t *testing.T) {
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kmsCache)
 	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -410,7 +422,7 @@ func TestDelete(t *testing.T) {
 	oidcam := oidc.TestAuthMethod(t, conn, databaseWrapper, o.GetPublicId(), oidc.InactiveState, "alice_rp", "my-dogs-name",
 		oidc.WithIssuer(oidc.TestConvertToUrls(t, "https://alice.com")[0]), oidc.WithApiUrl(oidc.TestConvertToUrls(t, "https://api.com")[0]))
 
-	s, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn)
+	s, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, sessionRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	cases := []struct {
@@ -477,12 +489,15 @@ func TestDelete_twice(t *testing.T) {
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	o, _ := iam.TestScopes(t, iamRepo)
 	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
 
-	s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn)
+	s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, sessionRepoFn)
 	require.NoError(err, "Error when getting new auth_method service.")
 
 	req := &pbs.DeleteAuthMethodRequest{
@@ -512,6 +527,9 @@ func TestCreate(t *testing.T) {
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -957,7 +975,7 @@ func TestCreate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn)
+			s, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, sessionRepoFn)
 			require.NoError(err, "Error when getting new auth_method service.")
 
 			got, gErr := s.CreateAuthMethod(auth.DisabledAuthTestContext(iamRepoFn, tc.req.GetItem().GetScopeId()), tc.req)
@@ -997,6 +1015,12 @@ func TestCreate(t *testing.T) {
 					delete(got.Item.Attributes.Fields, "callback_url")
 					delete(tc.res.Item.Attributes.Fields, "callback_url")
 				}
+				if _, ok := got.Item.Attributes.Fields["backchannel_logout_url"]; ok {
+					gVal := got.Item.Attributes.Fields["backchannel_logout_url"].GetStringValue()
+					assert.True(strings.HasSuffix(gVal, ":authenticate:logout"), "%q isn't a logout url", gVal)
+					delete(got.Item.Attributes.Fields, "backchannel_logout_url")
+					delete(tc.res.Item.Attributes.Fields, "backchannel_logout_url")
+				}
 			}
 			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform(), protocmp.SortRepeatedFields(got)), "CreateAuthMethod(%q) got response %q, wanted %q", tc.req, got, tc.res)
 		})
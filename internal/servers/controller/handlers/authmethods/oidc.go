@@ -24,6 +24,7 @@ const (
 	startCommand    = "start"
 	callbackCommand = "callback"
 	tokenCommand    = "token"
+	logoutCommand   = "logout"
 
 	// token request/response fields
 	statusField = "status"
@@ -35,12 +36,14 @@ const (
 	clientSecretHmacField                  = "attributes.client_secret_hmac"
 	stateField                             = "attributes.state"
 	callbackUrlField                       = "attributes.callback_url"
+	backchannelLogoutUrlField              = "attributes.backchannel_logout_url"
 	apiUrlPrefixField                      = "attributes.api_url_prefix"
 	caCertsField                           = "attributes.ca_certs"
 	signingAlgorithmField                  = "attributes.signing_algorithms"
 	disableDiscoveredConfigValidationField = "attributes.disable_discovered_config_validation"
 	roundtripPayloadAttributesField        = "attributes.roundtrip_payload"
 	codeField                              = "attributes.code"
+	logoutTokenField                       = "attributes.logout_token"
 	claimsScopesField                      = "attributes.claims_scopes"
 	accountClaimMapsField                  = "attributes.account_claim_maps"
 )
@@ -156,6 +159,8 @@ func (s Service) authenticateOidc(ctx context.Context, req *pbs.AuthenticateRequ
 		return s.authenticateOidcCallback(ctx, req)
 	case tokenCommand:
 		return s.authenticateOidcToken(ctx, req, authResults)
+	case logoutCommand:
+		return s.authenticateOidcLogout(ctx, req)
 	}
 
 	return &pbs.AuthenticateResponse{Command: req.GetCommand(), Attributes: nil}, nil
@@ -334,6 +339,49 @@ func (s Service) authenticateOidcToken(ctx context.Context, req *pbs.Authenticat
 	return s.convertToAuthenticateResponse(ctx, req, authResults, responseToken)
 }
 
+// authenticateOidcLogout handles an OIDC back-channel logout request from the
+// provider.  The provider only looks at the response's status code, so the
+// response has no attributes.
+func (s Service) authenticateOidcLogout(ctx context.Context, req *pbs.AuthenticateRequest) (*pbs.AuthenticateResponse, error) {
+	const op = "authmethod_service.(Service).authenticateOidcLogout"
+	if req == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "Nil request.")
+	}
+
+	attrs := new(pb.OidcAuthMethodAuthenticateLogoutRequest)
+	// Note that this conversion has already happened in the validate call so we don't expect errors here.
+	if err := handlers.StructToProto(req.GetAttributes(), attrs, handlers.WithDiscardUnknownFields(true)); err != nil {
+		return nil, errors.New(errors.InvalidParameter, op, "Error parsing request attributes.", errors.WithWrap(err))
+	}
+
+	repo, err := s.oidcRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	am, err := repo.LookupAuthMethod(ctx, req.GetAuthMethodId())
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if am == nil {
+		return nil, errors.New(errors.RecordNotFound, op, fmt.Sprintf("Auth method %s not found.", req.GetAuthMethodId()))
+	}
+
+	revoked, err := oidc.BackChannelLogout(ctx, s.oidcRepoFn, s.atRepoFn, s.sessionRepoFn, am, attrs.GetLogoutToken())
+	if err != nil {
+		if s.oidcLogger != nil {
+			s.oidcLogger.Error("error processing the oidc back-channel logout", "op", op, "error", err)
+		}
+		if errors.Match(errors.T(errors.InvalidParameter), err) {
+			return nil, errors.New(errors.InvalidParameter, op, "Invalid logout token.")
+		}
+		return nil, errors.New(errors.Internal, op, "Error processing the back-channel logout. See the controller's log for more information.")
+	}
+	if s.oidcLogger != nil {
+		s.oidcLogger.Info("oidc back-channel logout successful", "op", op, "auth_method_id", am.GetPublicId(), "auth_tokens_revoked", revoked)
+	}
+	return &pbs.AuthenticateResponse{Command: req.GetCommand()}, nil
+}
+
 func validateAuthenticateOidcRequest(req *pbs.AuthenticateRequest) error {
 	badFields := make(map[string]string)
 
@@ -393,6 +441,22 @@ func validateAuthenticateOidcRequest(req *pbs.AuthenticateRequest) error {
 			badFields[tokenTypeField] = `The only accepted types are "token" and "cookie".`
 		}
 
+	case logoutCommand:
+		if req.GetAttributes() == nil {
+			badFields[attributesField] = "No logout attributes provided."
+			break
+		}
+
+		attrs := new(pb.OidcAuthMethodAuthenticateLogoutRequest)
+		if err := handlers.StructToProto(req.GetAttributes(), attrs, handlers.WithDiscardUnknownFields(true)); err != nil {
+			badFields[attributesField] = "Unable to parse logout request attributes."
+			break
+		}
+
+		if attrs.GetLogoutToken() == "" {
+			badFields[logoutTokenField] = "Logout token field not supplied in logout request."
+		}
+
 	default:
 		badFields[commandField] = "Invalid command for this auth method type."
 	}
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"regexp"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	capoidc "github.com/hashicorp/cap/oidc"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
@@ -55,6 +57,7 @@ type setup struct {
 	oidcRepoFn                  common.OidcAuthRepoFactory
 	pwRepoFn                    common.PasswordAuthRepoFactory
 	atRepoFn                    common.AuthTokenRepoFactory
+	sessionRepoFn               common.SessionRepoFactory
 	org                         *iam.Scope
 	proj                        *iam.Scope
 	databaseWrapper             wrapping.Wrapper
@@ -92,12 +95,15 @@ func getSetup(t *testing.T) setup {
 	ret.atRepoFn = func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(ret.rw, ret.rw, ret.kmsCache)
 	}
+	ret.sessionRepoFn = func() (*session.Repository, error) {
+		return session.NewRepository(ret.rw, ret.rw, ret.kmsCache)
+	}
 
 	ret.org, ret.proj = iam.TestScopes(t, ret.iamRepo)
 	ret.databaseWrapper, err = ret.kmsCache.GetWrapper(ret.ctx, ret.org.PublicId, kms.KeyPurposeDatabase)
 	require.NoError(err)
 
-	ret.authMethodService, err = authmethods.NewService(ret.kmsCache, ret.pwRepoFn, ret.oidcRepoFn, ret.iamRepoFn, ret.atRepoFn)
+	ret.authMethodService, err = authmethods.NewService(ret.kmsCache, ret.pwRepoFn, ret.oidcRepoFn, ret.iamRepoFn, ret.atRepoFn, ret.sessionRepoFn)
 	require.NoError(err)
 
 	ret.testProvider = capoidc.StartTestProvider(t)
@@ -148,6 +154,9 @@ func TestList_FilterNonPublic(t *testing.T) {
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kmsCache)
 	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kmsCache)
+	}
 	authTokenRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kmsCache)
 	}
@@ -181,7 +190,7 @@ func TestList_FilterNonPublic(t *testing.T) {
 			oidc.WithIssuer(oidc.TestConvertToUrls(t, fmt.Sprintf("https://alice%d.com", i))[0]), oidc.WithApiUrl(oidc.TestConvertToUrls(t, "https://api.com")[0]))
 	}
 
-	s, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn)
+	s, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, sessionRepoFn)
 	require.NoError(t, err, "Couldn't create new auth_method service.")
 
 	req := &pbs.ListAuthMethodsRequest{
@@ -253,10 +262,13 @@ func TestUpdate_OIDC(t *testing.T) {
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	o, _ := iam.TestScopes(t, iamRepo)
-	tested, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn)
+	tested, err := authmethods.NewService(kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, sessionRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	defaultScopeInfo := &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: o.GetType(), ParentScopeId: scope.Global.String()}
@@ -1092,6 +1104,12 @@ func TestUpdate_OIDC(t *testing.T) {
 					delete(got.Item.Attributes.Fields, "callback_url")
 					delete(tc.res.Item.Attributes.Fields, "callback_url")
 				}
+				if _, ok := got.Item.Attributes.Fields["backchannel_logout_url"]; ok {
+					gVal := got.Item.Attributes.Fields["backchannel_logout_url"].GetStringValue()
+					assert.True(strings.HasSuffix(gVal, ":authenticate:logout"), "%q isn't a logout url", gVal)
+					delete(got.Item.Attributes.Fields, "backchannel_logout_url")
+					delete(tc.res.Item.Attributes.Fields, "backchannel_logout_url")
+				}
 
 				got.Item.UpdatedTime, tc.res.Item.UpdatedTime = nil, nil
 
@@ -1120,6 +1138,9 @@ func TestUpdate_OIDCDryRun(t *testing.T) {
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kmsCache)
 	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -1160,12 +1181,13 @@ func TestUpdate_OIDCDryRun(t *testing.T) {
 		AuthorizedActions:           oidcAuthorizedActions,
 		AuthorizedCollectionActions: authorizedCollectionActions,
 		Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-			"api_url_prefix":     structpb.NewStringValue(am.GetApiUrl()),
-			"callback_url":       structpb.NewStringValue(fmt.Sprintf("%s/v1/auth-methods/oidc:authenticate:callback", am.GetApiUrl())),
-			"client_id":          structpb.NewStringValue(am.GetClientId()),
-			"client_secret_hmac": structpb.NewStringValue(am.GetClientSecretHmac()),
-			"issuer":             structpb.NewStringValue(am.GetIssuer()),
-			"state":              structpb.NewStringValue(am.GetOperationalState()),
+			"api_url_prefix":         structpb.NewStringValue(am.GetApiUrl()),
+			"callback_url":           structpb.NewStringValue(fmt.Sprintf("%s/v1/auth-methods/oidc:authenticate:callback", am.GetApiUrl())),
+			"backchannel_logout_url": structpb.NewStringValue(fmt.Sprintf(oidc.LogoutEndpoint, am.GetApiUrl(), am.GetPublicId())),
+			"client_id":              structpb.NewStringValue(am.GetClientId()),
+			"client_secret_hmac":     structpb.NewStringValue(am.GetClientSecretHmac()),
+			"issuer":                 structpb.NewStringValue(am.GetIssuer()),
+			"state":                  structpb.NewStringValue(am.GetOperationalState()),
 			"idp_ca_certs": func() *structpb.Value {
 				lv, _ := structpb.NewList([]interface{}{tp.CACert()})
 				return structpb.NewListValue(lv)
@@ -1177,7 +1199,7 @@ func TestUpdate_OIDCDryRun(t *testing.T) {
 		}},
 	}
 
-	tested, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn)
+	tested, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, sessionRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 	cases := []struct {
 		name    string
@@ -1294,6 +1316,9 @@ func TestChangeState_OIDC(t *testing.T) {
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(rw, rw, kmsCache)
 	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -1317,7 +1342,7 @@ func TestChangeState_OIDC(t *testing.T) {
 	mismatchedAM := oidc.TestAuthMethod(t, conn, databaseWrapper, o.PublicId, "inactive", "different_client_id", oidc.ClientSecret(tpClientSecret),
 		oidc.WithIssuer(oidc.TestConvertToUrls(t, tp.Addr())[0]), oidc.WithSigningAlgs(oidc.EdDSA), oidc.WithApiUrl(oidc.TestConvertToUrls(t, "https://example.callback:58")[0]), oidc.WithCertificates(tpCert...))
 
-	s, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn)
+	s, err := authmethods.NewService(kmsCache, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, sessionRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	signingAlg := func() *structpb.Value {
@@ -1339,14 +1364,15 @@ func TestChangeState_OIDC(t *testing.T) {
 		UpdatedTime: oidcam.UpdateTime.GetTimestamp(),
 		Type:        auth.OidcSubtype.String(),
 		Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
-			"issuer":             structpb.NewStringValue(oidcam.GetIssuer()),
-			"client_id":          structpb.NewStringValue(tpClientId),
-			"client_secret_hmac": structpb.NewStringValue("<hmac>"),
-			"state":              structpb.NewStringValue(string(oidc.InactiveState)),
-			"callback_url":       structpb.NewStringValue("https://example.callback:58/v1/auth-methods/oidc:authenticate:callback"),
-			"api_url_prefix":     structpb.NewStringValue("https://example.callback:58"),
-			"signing_algorithms": signingAlg,
-			"idp_ca_certs":       certs,
+			"issuer":                 structpb.NewStringValue(oidcam.GetIssuer()),
+			"client_id":              structpb.NewStringValue(tpClientId),
+			"client_secret_hmac":     structpb.NewStringValue("<hmac>"),
+			"state":                  structpb.NewStringValue(string(oidc.InactiveState)),
+			"callback_url":           structpb.NewStringValue("https://example.callback:58/v1/auth-methods/oidc:authenticate:callback"),
+			"backchannel_logout_url": structpb.NewStringValue(fmt.Sprintf(oidc.LogoutEndpoint, "https://example.callback:58", oidcam.GetPublicId())),
+			"api_url_prefix":         structpb.NewStringValue("https://example.callback:58"),
+			"signing_algorithms":     signingAlg,
+			"idp_ca_certs":           certs,
 		}},
 		Version: 1,
 		Scope: &scopepb.ScopeInfo{
@@ -1485,6 +1511,12 @@ func TestChangeState_OIDC(t *testing.T) {
 				delete(got.Item.Attributes.Fields, "callback_url")
 				delete(tc.res.Item.Attributes.Fields, "callback_url")
 			}
+			if _, ok := got.Item.Attributes.Fields["backchannel_logout_url"]; ok {
+				gVal := got.Item.Attributes.Fields["backchannel_logout_url"].GetStringValue()
+				assert.True(strings.HasSuffix(gVal, ":authenticate:logout"), "%q isn't a logout url", gVal)
+				delete(got.Item.Attributes.Fields, "backchannel_logout_url")
+				delete(tc.res.Item.Attributes.Fields, "backchannel_logout_url")
+			}
 			got.Item.UpdatedTime, tc.res.Item.UpdatedTime = nil, nil
 
 			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "ChangeState() got response %q, wanted %q", got, tc.res)
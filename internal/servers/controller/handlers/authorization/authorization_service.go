@@ -0,0 +1,567 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/errors"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/authorization"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// Service handles request as described by the pbs.AuthorizationServiceServer interface.
+type Service struct {
+	pbs.UnimplementedAuthorizationServiceServer
+
+	iamRepoFn       common.IamRepoFactory
+	authTokenRepoFn common.AuthTokenRepoFactory
+	pwRepoFn        common.PasswordAuthRepoFactory
+	oidcRepoFn      common.OidcAuthRepoFactory
+	staticRepoFn    common.StaticRepoFactory
+	targetRepoFn    common.TargetRepoFactory
+	sessionRepoFn   common.SessionRepoFactory
+}
+
+// NewService returns an authorization service which explains authorization
+// decisions. The repositories other than the iam one are used to look up the
+// scope of the resources it explains.
+func NewService(iamRepoFn common.IamRepoFactory,
+	authTokenRepoFn common.AuthTokenRepoFactory,
+	pwRepoFn common.PasswordAuthRepoFactory,
+	oidcRepoFn common.OidcAuthRepoFactory,
+	staticRepoFn common.StaticRepoFactory,
+	targetRepoFn common.TargetRepoFactory,
+	sessionRepoFn common.SessionRepoFactory) (Service, error) {
+	const op = "authorization.NewService"
+	if iamRepoFn == nil {
+		return Service{}, errors.New(errors.InvalidParameter, op, "missing iam repository")
+	}
+	if authTokenRepoFn == nil {
+		return Service{}, errors.New(errors.InvalidParameter, op, "missing auth token repository")
+	}
+	if pwRepoFn == nil {
+		return Service{}, errors.New(errors.InvalidParameter, op, "missing password repository")
+	}
+	if oidcRepoFn == nil {
+		return Service{}, errors.New(errors.InvalidParameter, op, "missing oidc repository")
+	}
+	if staticRepoFn == nil {
+		return Service{}, errors.New(errors.InvalidParameter, op, "missing static host repository")
+	}
+	if targetRepoFn == nil {
+		return Service{}, errors.New(errors.InvalidParameter, op, "missing target repository")
+	}
+	if sessionRepoFn == nil {
+		return Service{}, errors.New(errors.InvalidParameter, op, "missing session repository")
+	}
+	return Service{
+		iamRepoFn:       iamRepoFn,
+		authTokenRepoFn: authTokenRepoFn,
+		pwRepoFn:        pwRepoFn,
+		oidcRepoFn:      oidcRepoFn,
+		staticRepoFn:    staticRepoFn,
+		targetRepoFn:    targetRepoFn,
+		sessionRepoFn:   sessionRepoFn,
+	}, nil
+}
+
+var _ pbs.AuthorizationServiceServer = Service{}
+
+// ExplainAuthorization implements the interface pbs.AuthorizationServiceServer.
+func (s Service) ExplainAuthorization(ctx context.Context, req *pbs.ExplainAuthorizationRequest) (*pbs.ExplainAuthorizationResponse, error) {
+	if err := validateExplainRequest(req); err != nil {
+		return nil, err
+	}
+	// Authorize before looking anything up, so callers can't learn about
+	// resources outside the scopes they can explain in.
+	authResults := s.authResult(ctx, req.GetScopeId())
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	res, err := s.resource(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	userId, accountId, err := s.principal(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	act := action.Map[req.GetAction()]
+	results, err := auth.Explain(ctx, iamRepo, userId, accountId, res, act)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ExplainAuthorizationResponse{Item: toProto(req, res, userId, results)}, nil
+}
+
+// pinTypes are the types of the collections resources of a type are pinned to.
+var pinTypes = map[resource.Type]resource.Type{
+	resource.Account: resource.AuthMethod,
+	resource.HostSet: resource.HostCatalog,
+	resource.Host:    resource.HostCatalog,
+}
+
+// resource returns the resource the check is performed on. Like real
+// authorization, its type, scope and pin are found by looking up the
+// resource, or for collection actions the pin, the same way its handler does.
+// A resource outside the request's scope is reported as not found, the same
+// as one that doesn't exist, and the type and pin in the request must match
+// the ones found.
+func (s Service) resource(ctx context.Context, req *pbs.ExplainAuthorizationRequest) (perms.Resource, error) {
+	res := perms.Resource{
+		ScopeId: req.GetScopeId(),
+		Id:      req.GetResourceId(),
+		Type:    resource.Map[req.GetResourceType()],
+		Pin:     req.GetPin(),
+	}
+	badFields := map[string]string{}
+	switch {
+	case req.GetResourceId() != "":
+		typ, scopeId, pin, err := s.lookupResource(ctx, req.GetResourceId())
+		if err != nil {
+			return perms.Resource{}, err
+		}
+		if scopeId != req.GetScopeId() {
+			return perms.Resource{}, handlers.NotFoundError()
+		}
+		if req.GetResourceType() != "" && res.Type != typ {
+			badFields["resource_type"] = fmt.Sprintf("The resource is a %s.", typ)
+		}
+		if req.GetPin() != "" && req.GetPin() != pin {
+			badFields["pin"] = fmt.Sprintf("The resource isn't in %q.", req.GetPin())
+		}
+		res.Type, res.Pin = typ, pin
+	case req.GetPin() != "":
+		typ, scopeId, _, err := s.lookupResource(ctx, req.GetPin())
+		if err != nil {
+			return perms.Resource{}, err
+		}
+		if scopeId != req.GetScopeId() {
+			return perms.Resource{}, handlers.NotFoundError()
+		}
+		if pinTypes[res.Type] != typ {
+			badFields["pin"] = fmt.Sprintf("A %s can't be in a %s.", res.Type, typ)
+		}
+	}
+	if len(badFields) > 0 {
+		return perms.Resource{}, handlers.InvalidArgumentErrorf("Conflicting fields provided in request.", badFields)
+	}
+	return res, nil
+}
+
+// lookupResource returns the type of the resource with the id, along with the
+// scope and pin its handler verifies actions on it in.
+func (s Service) lookupResource(ctx context.Context, id string) (typ resource.Type, scopeId, pin string, retErr error) {
+	hasPrefix := func(prefix string) bool {
+		return strings.HasPrefix(id, prefix+"_")
+	}
+	switch {
+	case id == scope.Global.String():
+		return resource.Scope, scope.Global.String(), "", nil
+
+	case hasPrefix(scope.Org.Prefix()), hasPrefix(scope.Project.Prefix()):
+		repo, err := s.iamRepoFn()
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		scp, err := repo.LookupScope(ctx, id)
+		if err != nil {
+			return resource.Unknown, "", "", lookupError(err)
+		}
+		if scp == nil {
+			return resource.Unknown, "", "", handlers.NotFoundError()
+		}
+		return resource.Scope, scp.GetParentId(), "", nil
+
+	case hasPrefix(iam.UserPrefix):
+		repo, err := s.iamRepoFn()
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		u, _, err := repo.LookupUser(ctx, id)
+		if err != nil {
+			return resource.Unknown, "", "", lookupError(err)
+		}
+		if u == nil {
+			return resource.Unknown, "", "", handlers.NotFoundError()
+		}
+		return resource.User, u.GetScopeId(), "", nil
+
+	case hasPrefix(iam.GroupPrefix):
+		repo, err := s.iamRepoFn()
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		g, _, err := repo.LookupGroup(ctx, id)
+		if err != nil {
+			return resource.Unknown, "", "", lookupError(err)
+		}
+		if g == nil {
+			return resource.Unknown, "", "", handlers.NotFoundError()
+		}
+		return resource.Group, g.GetScopeId(), "", nil
+
+	case hasPrefix(iam.RolePrefix):
+		repo, err := s.iamRepoFn()
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		r, _, _, err := repo.LookupRole(ctx, id)
+		if err != nil {
+			return resource.Unknown, "", "", lookupError(err)
+		}
+		if r == nil {
+			return resource.Unknown, "", "", handlers.NotFoundError()
+		}
+		return resource.Role, r.GetScopeId(), "", nil
+
+	case hasPrefix(iam.RoleTemplatePrefix):
+		repo, err := s.iamRepoFn()
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		rt, _, _, err := repo.LookupRoleTemplate(ctx, id)
+		if err != nil {
+			return resource.Unknown, "", "", lookupError(err)
+		}
+		if rt == nil {
+			return resource.Unknown, "", "", handlers.NotFoundError()
+		}
+		return resource.RoleTemplate, rt.GetScopeId(), "", nil
+
+	case hasPrefix(password.AuthMethodPrefix), hasPrefix(oidc.AuthMethodPrefix):
+		scopeId, err := s.authMethodScope(ctx, id)
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		return resource.AuthMethod, scopeId, "", nil
+
+	case hasPrefix(password.AccountPrefix), hasPrefix(oidc.AccountPrefix):
+		var authMethodId string
+		switch auth.SubtypeFromId(id) {
+		case auth.PasswordSubtype:
+			repo, err := s.pwRepoFn()
+			if err != nil {
+				return resource.Unknown, "", "", err
+			}
+			acct, err := repo.LookupAccount(ctx, id)
+			if err != nil {
+				return resource.Unknown, "", "", lookupError(err)
+			}
+			if acct == nil {
+				return resource.Unknown, "", "", handlers.NotFoundError()
+			}
+			authMethodId = acct.GetAuthMethodId()
+		case auth.OidcSubtype:
+			repo, err := s.oidcRepoFn()
+			if err != nil {
+				return resource.Unknown, "", "", err
+			}
+			acct, err := repo.LookupAccount(ctx, id)
+			if err != nil {
+				return resource.Unknown, "", "", lookupError(err)
+			}
+			if acct == nil {
+				return resource.Unknown, "", "", handlers.NotFoundError()
+			}
+			authMethodId = acct.GetAuthMethodId()
+		}
+		scopeId, err := s.authMethodScope(ctx, authMethodId)
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		return resource.Account, scopeId, authMethodId, nil
+
+	case hasPrefix(authtoken.AuthTokenPrefix):
+		repo, err := s.authTokenRepoFn()
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		at, err := repo.LookupAuthToken(ctx, id)
+		if err != nil {
+			return resource.Unknown, "", "", lookupError(err)
+		}
+		if at == nil {
+			return resource.Unknown, "", "", handlers.NotFoundError()
+		}
+		return resource.AuthToken, at.GetScopeId(), "", nil
+
+	case hasPrefix(static.HostCatalogPrefix):
+		scopeId, err := s.catalogScope(ctx, id)
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		return resource.HostCatalog, scopeId, "", nil
+
+	case hasPrefix(static.HostSetPrefix):
+		repo, err := s.staticRepoFn()
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		hs, _, err := repo.LookupSet(ctx, id)
+		if err != nil {
+			return resource.Unknown, "", "", lookupError(err)
+		}
+		if hs == nil {
+			return resource.Unknown, "", "", handlers.NotFoundError()
+		}
+		scopeId, err := s.catalogScope(ctx, hs.GetCatalogId())
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		return resource.HostSet, scopeId, hs.GetCatalogId(), nil
+
+	case hasPrefix(static.HostPrefix):
+		repo, err := s.staticRepoFn()
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		h, err := repo.LookupHost(ctx, id)
+		if err != nil {
+			return resource.Unknown, "", "", lookupError(err)
+		}
+		if h == nil {
+			return resource.Unknown, "", "", handlers.NotFoundError()
+		}
+		scopeId, err := s.catalogScope(ctx, h.GetCatalogId())
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		return resource.Host, scopeId, h.GetCatalogId(), nil
+
+	case hasPrefix(target.TcpTargetPrefix), hasPrefix(target.UdpTargetPrefix):
+		repo, err := s.targetRepoFn()
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		t, _, err := repo.LookupTarget(ctx, id)
+		if err != nil {
+			return resource.Unknown, "", "", lookupError(err)
+		}
+		if t == nil {
+			return resource.Unknown, "", "", handlers.NotFoundError()
+		}
+		return resource.Target, t.GetScopeId(), "", nil
+
+	case hasPrefix(session.SessionPrefix):
+		repo, err := s.sessionRepoFn()
+		if err != nil {
+			return resource.Unknown, "", "", err
+		}
+		sess, _, err := repo.LookupSession(ctx, id)
+		if err != nil {
+			return resource.Unknown, "", "", lookupError(err)
+		}
+		if sess == nil {
+			return resource.Unknown, "", "", handlers.NotFoundError()
+		}
+		return resource.Session, sess.ScopeId, "", nil
+	}
+	return resource.Unknown, "", "", handlers.InvalidArgumentErrorf("Invalid fields provided in request.", map[string]string{
+		"resource_id": "Unrecognized resource id.",
+	})
+}
+
+// authMethodScope returns the scope of the auth method.
+func (s Service) authMethodScope(ctx context.Context, id string) (string, error) {
+	switch auth.SubtypeFromId(id) {
+	case auth.PasswordSubtype:
+		repo, err := s.pwRepoFn()
+		if err != nil {
+			return "", err
+		}
+		am, err := repo.LookupAuthMethod(ctx, id)
+		if err != nil {
+			return "", lookupError(err)
+		}
+		if am == nil {
+			return "", handlers.NotFoundError()
+		}
+		return am.GetScopeId(), nil
+	case auth.OidcSubtype:
+		repo, err := s.oidcRepoFn()
+		if err != nil {
+			return "", err
+		}
+		am, err := repo.LookupAuthMethod(ctx, id)
+		if err != nil {
+			return "", lookupError(err)
+		}
+		if am == nil {
+			return "", handlers.NotFoundError()
+		}
+		return am.GetScopeId(), nil
+	}
+	return "", handlers.NotFoundError()
+}
+
+// catalogScope returns the scope of the host catalog.
+func (s Service) catalogScope(ctx context.Context, id string) (string, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return "", err
+	}
+	cat, err := repo.LookupCatalog(ctx, id)
+	if err != nil {
+		return "", lookupError(err)
+	}
+	if cat == nil {
+		return "", handlers.NotFoundError()
+	}
+	return cat.GetScopeId(), nil
+}
+
+// lookupError converts a not found error from a repository into the API's
+// not found error.
+func lookupError(err error) error {
+	if errors.IsNotFoundError(err) {
+		return handlers.NotFoundError()
+	}
+	return err
+}
+
+// principal returns the user and account the check is performed for. An auth
+// token maps to its user and account the same way it does when verifying a
+// request, falling back to the anonymous user if it no longer has a user.
+func (s Service) principal(ctx context.Context, req *pbs.ExplainAuthorizationRequest) (userId, accountId string, retErr error) {
+	if req.GetAuthTokenId() != "" {
+		repo, err := s.authTokenRepoFn()
+		if err != nil {
+			return "", "", err
+		}
+		at, err := repo.LookupAuthToken(ctx, req.GetAuthTokenId())
+		if err != nil {
+			return "", "", err
+		}
+		if at == nil {
+			return "", "", handlers.NotFoundErrorf("Auth Token %q doesn't exist.", req.GetAuthTokenId())
+		}
+		if at.GetIamUserId() == "" {
+			return auth.AnonymousUserId, "", nil
+		}
+		return at.GetIamUserId(), at.GetAuthAccountId(), nil
+	}
+
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return "", "", err
+	}
+	u, _, err := repo.LookupUser(ctx, req.GetUserId())
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return "", "", handlers.NotFoundErrorf("User %q doesn't exist.", req.GetUserId())
+		}
+		return "", "", err
+	}
+	if u == nil {
+		return "", "", handlers.NotFoundErrorf("User %q doesn't exist.", req.GetUserId())
+	}
+	return u.GetPublicId(), "", nil
+}
+
+// authResult verifies the caller has the explain-authorization action on the
+// scope, which like other actions on a scope is granted in its parent scope.
+func (s Service) authResult(ctx context.Context, scopeId string) auth.VerifyResults {
+	res := auth.VerifyResults{}
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		res.Error = err
+		return res
+	}
+	scp, err := repo.LookupScope(ctx, scopeId)
+	if err != nil {
+		res.Error = err
+		return res
+	}
+	if scp == nil {
+		res.Error = handlers.NotFoundError()
+		return res
+	}
+	opts := []auth.Option{
+		auth.WithType(resource.Scope),
+		auth.WithAction(action.ExplainAuthorization),
+		auth.WithId(scopeId),
+		auth.WithScopeId(scp.GetParentId()),
+	}
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(req *pbs.ExplainAuthorizationRequest, res perms.Resource, userId string, in *auth.ExplainResults) *pb.Explanation {
+	out := &pb.Explanation{
+		UserId:       userId,
+		ScopeId:      res.ScopeId,
+		ResourceId:   res.Id,
+		ResourceType: res.Type.String(),
+		Pin:          res.Pin,
+		Action:       req.GetAction(),
+		Authorized:   in.Authorized,
+		OutputFields: in.OutputFields.Fields(),
+	}
+	for _, mg := range in.MatchingGrants {
+		effect := perms.GrantEffectAllow
+		if mg.Grant.Deny() {
+			effect = perms.GrantEffectDeny
+		}
+		out.MatchingGrants = append(out.MatchingGrants, &pb.MatchingGrant{
+			RoleId:  mg.RoleId,
+			ScopeId: mg.ScopeId,
+			Grant:   mg.Grant.CanonicalString(),
+			Effect:  effect,
+		})
+	}
+	return out
+}
+
+func validateExplainRequest(req *pbs.ExplainAuthorizationRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix(), scope.Project.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "This field must be 'global' or a valid org or project scope id."
+	}
+	switch {
+	case req.GetUserId() == "" && req.GetAuthTokenId() == "":
+		badFields["user_id"] = "One of this field or auth_token_id must be set."
+	case req.GetUserId() != "" && req.GetAuthTokenId() != "":
+		badFields["user_id"] = "Only one of this field or auth_token_id can be set."
+	case req.GetUserId() != "" && !handlers.ValidId(handlers.Id(req.GetUserId()), iam.UserPrefix):
+		badFields["user_id"] = "Improperly formatted identifier."
+	case req.GetAuthTokenId() != "" && !handlers.ValidId(handlers.Id(req.GetAuthTokenId()), authtoken.AuthTokenPrefix):
+		badFields["auth_token_id"] = "Improperly formatted identifier."
+	}
+	if req.GetResourceId() == "" && req.GetResourceType() == "" {
+		badFields["resource_id"] = "One of this field or resource_type must be set."
+	}
+	if req.GetResourceType() != "" {
+		switch resource.Map[req.GetResourceType()] {
+		case resource.Unknown, resource.All:
+			badFields["resource_type"] = fmt.Sprintf("Unknown resource type %q.", req.GetResourceType())
+		}
+	}
+	switch action.Map[req.GetAction()] {
+	case action.Unknown, action.All:
+		badFields["action"] = fmt.Sprintf("Unknown action %q.", req.GetAction())
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,400 @@
+package authorization_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/authorization"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authorization"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestExplainAuthorization(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	staticRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	targetRepoFn := func() (*target.Repository, error) {
+		return target.NewRepository(rw, rw, kms)
+	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
+
+	s, err := authorization.NewService(iamRepoFn, tokenRepoFn, pwRepoFn, oidcRepoFn, staticRepoFn, targetRepoFn, sessionRepoFn)
+	require.NoError(t, err)
+
+	org, proj := iam.TestScopes(t, iamRepo, iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	role := iam.TestRole(t, conn, proj.GetPublicId())
+	iam.TestUserRole(t, conn, role.GetPublicId(), at.GetIamUserId())
+	iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=*;actions=*")
+	iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=target;actions=delete;effect=deny")
+
+	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test target")
+	cat := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, cat.GetPublicId(), 1)[0]
+	am := password.TestAuthMethod(t, conn, org.GetPublicId())
+
+	cases := []struct {
+		name string
+		req  *pbs.ExplainAuthorizationRequest
+		res  *pb.Explanation
+		err  error
+	}{
+		{
+			name: "allowed",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:      proj.GetPublicId(),
+				UserId:       at.GetIamUserId(),
+				ResourceId:   tar.GetPublicId(),
+				ResourceType: "target",
+				Action:       "read",
+			},
+			res: &pb.Explanation{
+				UserId:       at.GetIamUserId(),
+				ScopeId:      proj.GetPublicId(),
+				ResourceId:   tar.GetPublicId(),
+				ResourceType: "target",
+				Action:       "read",
+				Authorized:   true,
+				MatchingGrants: []*pb.MatchingGrant{
+					{RoleId: role.GetPublicId(), ScopeId: proj.GetPublicId(), Grant: "id=*;type=*;actions=*", Effect: "allow"},
+				},
+				OutputFields: []string{"*"},
+			},
+		},
+		{
+			name: "denied through token",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:      proj.GetPublicId(),
+				AuthTokenId:  at.GetPublicId(),
+				ResourceId:   tar.GetPublicId(),
+				ResourceType: "target",
+				Action:       "delete",
+			},
+			res: &pb.Explanation{
+				UserId:       at.GetIamUserId(),
+				ScopeId:      proj.GetPublicId(),
+				ResourceId:   tar.GetPublicId(),
+				ResourceType: "target",
+				Action:       "delete",
+				MatchingGrants: []*pb.MatchingGrant{
+					{RoleId: role.GetPublicId(), ScopeId: proj.GetPublicId(), Grant: "id=*;type=*;actions=*", Effect: "allow"},
+					{RoleId: role.GetPublicId(), ScopeId: proj.GetPublicId(), Grant: "id=*;type=target;actions=delete;effect=deny", Effect: "deny"},
+				},
+			},
+		},
+		{
+			name: "no grants in scope",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:      org.GetPublicId(),
+				UserId:       at.GetIamUserId(),
+				ResourceType: "user",
+				Action:       "list",
+			},
+			res: &pb.Explanation{
+				UserId:       at.GetIamUserId(),
+				ScopeId:      org.GetPublicId(),
+				ResourceType: "user",
+				Action:       "list",
+			},
+		},
+		{
+			name: "type and pin looked up",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:    proj.GetPublicId(),
+				UserId:     at.GetIamUserId(),
+				ResourceId: h.GetPublicId(),
+				Action:     "read",
+			},
+			res: &pb.Explanation{
+				UserId:       at.GetIamUserId(),
+				ScopeId:      proj.GetPublicId(),
+				ResourceId:   h.GetPublicId(),
+				ResourceType: "host",
+				Pin:          cat.GetPublicId(),
+				Action:       "read",
+				Authorized:   true,
+				MatchingGrants: []*pb.MatchingGrant{
+					{RoleId: role.GetPublicId(), ScopeId: proj.GetPublicId(), Grant: "id=*;type=*;actions=*", Effect: "allow"},
+				},
+				OutputFields: []string{"*"},
+			},
+		},
+		{
+			name: "create in pin",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:      proj.GetPublicId(),
+				UserId:       at.GetIamUserId(),
+				ResourceType: "host",
+				Pin:          cat.GetPublicId(),
+				Action:       "create",
+			},
+			res: &pb.Explanation{
+				UserId:       at.GetIamUserId(),
+				ScopeId:      proj.GetPublicId(),
+				ResourceType: "host",
+				Pin:          cat.GetPublicId(),
+				Action:       "create",
+				Authorized:   true,
+				MatchingGrants: []*pb.MatchingGrant{
+					{RoleId: role.GetPublicId(), ScopeId: proj.GetPublicId(), Grant: "id=*;type=*;actions=*", Effect: "allow"},
+				},
+				OutputFields: []string{"*"},
+			},
+		},
+		{
+			name: "resource in other scope",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:    org.GetPublicId(),
+				UserId:     at.GetIamUserId(),
+				ResourceId: tar.GetPublicId(),
+				Action:     "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "resource not in pin",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:    proj.GetPublicId(),
+				UserId:     at.GetIamUserId(),
+				ResourceId: h.GetPublicId(),
+				Pin:        "hcst_1234567890",
+				Action:     "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "resource of other type",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:      proj.GetPublicId(),
+				UserId:       at.GetIamUserId(),
+				ResourceId:   h.GetPublicId(),
+				ResourceType: "target",
+				Action:       "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "pin of other type",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:      org.GetPublicId(),
+				UserId:       at.GetIamUserId(),
+				ResourceType: "host",
+				Pin:          am.GetPublicId(),
+				Action:       "create",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "pin in other scope",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:      org.GetPublicId(),
+				UserId:       at.GetIamUserId(),
+				ResourceType: "host",
+				Pin:          cat.GetPublicId(),
+				Action:       "create",
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "unknown resource",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:    proj.GetPublicId(),
+				UserId:     at.GetIamUserId(),
+				ResourceId: "ttcp_1234567890",
+				Action:     "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "unrecognized resource",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:    proj.GetPublicId(),
+				UserId:     at.GetIamUserId(),
+				ResourceId: "x_1234567890",
+				Action:     "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "unknown user",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:    proj.GetPublicId(),
+				UserId:     "u_1234567890",
+				ResourceId: tar.GetPublicId(),
+				Action:     "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "user and token",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:     proj.GetPublicId(),
+				UserId:      at.GetIamUserId(),
+				AuthTokenId: at.GetPublicId(),
+				ResourceId:  tar.GetPublicId(),
+				Action:      "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "unknown action",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:    proj.GetPublicId(),
+				UserId:     at.GetIamUserId(),
+				ResourceId: tar.GetPublicId(),
+				Action:     "fly",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "wildcard type",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId:      proj.GetPublicId(),
+				UserId:       at.GetIamUserId(),
+				ResourceType: "*",
+				Action:       "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "no resource",
+			req: &pbs.ExplainAuthorizationRequest{
+				ScopeId: proj.GetPublicId(),
+				UserId:  at.GetIamUserId(),
+				Action:  "read",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := s.ExplainAuthorization(auth.DisabledAuthTestContext(iamRepoFn, tc.req.GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(err)
+				assert.True(errors.Is(err, tc.err), "ExplainAuthorization(%+v) got error %v, wanted %v", tc.req, err, tc.err)
+				return
+			}
+			require.NoError(err)
+			assert.Empty(cmp.Diff(tc.res, got.GetItem(), protocmp.Transform(), protocmp.SortRepeated(func(x, y *pb.MatchingGrant) bool {
+				return x.GetGrant() < y.GetGrant()
+			})))
+		})
+	}
+}
+
+func TestExplainAuthorization_HidesResources(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(rw, rw, kms)
+	}
+	staticRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	targetRepoFn := func() (*target.Repository, error) {
+		return target.NewRepository(rw, rw, kms)
+	}
+	sessionRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
+	s, err := authorization.NewService(iamRepoFn, tokenRepoFn, pwRepoFn, oidcRepoFn, staticRepoFn, targetRepoFn, sessionRepoFn)
+	require.NoError(t, err)
+
+	org, proj := iam.TestScopes(t, iamRepo, iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+	_, otherProj := iam.TestScopes(t, iamRepo, iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	otherTarget := target.TestTcpTarget(t, conn, otherProj.GetPublicId(), "other target")
+
+	// The request for a resource which exists outside the scope and one which
+	// doesn't exist at all
+	existing := &pbs.ExplainAuthorizationRequest{
+		ScopeId:    proj.GetPublicId(),
+		UserId:     at.GetIamUserId(),
+		ResourceId: otherTarget.GetPublicId(),
+		Action:     "read",
+	}
+	missing := &pbs.ExplainAuthorizationRequest{
+		ScopeId:    proj.GetPublicId(),
+		UserId:     at.GetIamUserId(),
+		ResourceId: "ttcp_1234567890",
+		Action:     "read",
+	}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		assert := assert.New(t)
+		ctx := auth.NewVerifierContext(context.Background(), hclog.NewNullLogger(), iamRepoFn, tokenRepoFn, serversRepoFn, kms, auth.RequestInfo{
+			Path:        "/v1/authorization:explain",
+			Method:      "POST",
+			TokenFormat: auth.AuthTokenTypeBearer,
+			PublicId:    at.GetPublicId(),
+			Token:       at.GetToken(),
+		})
+		_, existingErr := s.ExplainAuthorization(ctx, existing)
+		_, missingErr := s.ExplainAuthorization(ctx, missing)
+		assert.True(errors.Is(existingErr, handlers.ForbiddenError()), "got error %v", existingErr)
+		assert.Equal(missingErr, existingErr, "the caller can't tell whether the resource exists")
+	})
+
+	t.Run("other scope", func(t *testing.T) {
+		assert := assert.New(t)
+		ctx := auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId())
+		_, existingErr := s.ExplainAuthorization(ctx, existing)
+		_, missingErr := s.ExplainAuthorization(ctx, missing)
+		assert.True(errors.Is(existingErr, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", existingErr)
+		assert.Equal(missingErr, existingErr, "the resource's scope isn't revealed")
+	})
+}
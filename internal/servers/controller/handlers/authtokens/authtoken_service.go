@@ -356,6 +356,7 @@ func toProto(ctx context.Context, in *authtoken.AuthToken, opt ...handlers.Optio
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
 
+	handlers.FilterOutputFields(&out, outputFields)
 	return &out, nil
 }
 
@@ -37,6 +37,8 @@ var (
 		action.AddMembers,
 		action.SetMembers,
 		action.RemoveMembers,
+		action.ReadResolvedMembers,
+		action.TransferOwnership,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -108,6 +110,15 @@ func (s Service) ListGroups(ctx context.Context, req *pbs.ListGroupsRequest) (*p
 		return &pbs.ListGroupsResponse{}, nil
 	}
 
+	ids := make([]string, 0, len(gl))
+	for _, item := range gl {
+		ids = append(ids, item.GetPublicId())
+	}
+	owners, err := s.listOwnersFromRepo(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
@@ -119,6 +130,7 @@ func (s Service) ListGroups(ctx context.Context, req *pbs.ListGroupsRequest) (*p
 	for _, item := range gl {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetScopeId()
+		res.OwnerId = owners[item.GetPublicId()]
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
 			continue
@@ -133,6 +145,9 @@ func (s Service) ListGroups(ctx context.Context, req *pbs.ListGroupsRequest) (*p
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
+		if outputFields.Has(globals.OwnerIdField) {
+			outputOpts = append(outputOpts, handlers.WithOwnerId(res.OwnerId))
+		}
 
 		item, err := toProto(ctx, item, nil, outputOpts...)
 		if err != nil {
@@ -175,6 +190,13 @@ func (s Service) GetGroup(ctx context.Context, req *pbs.GetGroupRequest) (*pbs.G
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, u.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{u.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[u.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, u, members, outputOpts...)
 	if err != nil {
@@ -195,7 +217,12 @@ func (s Service) CreateGroup(ctx context.Context, req *pbs.CreateGroupRequest) (
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	u, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	// The user creating the group becomes its owner
+	var ownerId string
+	if perms.CanOwn(authResults.UserId) {
+		ownerId = authResults.UserId
+	}
+	u, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem(), ownerId)
 	if err != nil {
 		return nil, err
 	}
@@ -211,7 +238,16 @@ func (s Service) CreateGroup(ctx context.Context, req *pbs.CreateGroupRequest) (
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
 	}
 	if outputFields.Has(globals.AuthorizedActionsField) {
-		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, u.GetPublicId(), IdActions).Strings()))
+		res := perms.Resource{
+			Id:      u.GetPublicId(),
+			ScopeId: authResults.Scope.GetId(),
+			Type:    resource.Group,
+			OwnerId: ownerId,
+		}
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, u.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		outputOpts = append(outputOpts, handlers.WithOwnerId(ownerId))
 	}
 
 	item, err := toProto(ctx, u, nil, outputOpts...)
@@ -251,6 +287,13 @@ func (s Service) UpdateGroup(ctx context.Context, req *pbs.UpdateGroupRequest) (
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, u.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{u.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[u.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, u, members, outputOpts...)
 	if err != nil {
@@ -305,6 +348,13 @@ func (s Service) AddGroupMembers(ctx context.Context, req *pbs.AddGroupMembersRe
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, g.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{g.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[g.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, g, members, outputOpts...)
 	if err != nil {
@@ -343,6 +393,13 @@ func (s Service) SetGroupMembers(ctx context.Context, req *pbs.SetGroupMembersRe
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, g.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{g.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[g.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, g, members, outputOpts...)
 	if err != nil {
@@ -381,6 +438,13 @@ func (s Service) RemoveGroupMembers(ctx context.Context, req *pbs.RemoveGroupMem
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, g.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{g.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[g.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, g, members, outputOpts...)
 	if err != nil {
@@ -390,6 +454,86 @@ func (s Service) RemoveGroupMembers(ctx context.Context, req *pbs.RemoveGroupMem
 	return &pbs.RemoveGroupMembersResponse{Item: item}, nil
 }
 
+// TransferGroupOwnership implements the interface pbs.GroupServiceServer.
+func (s Service) TransferGroupOwnership(ctx context.Context, req *pbs.TransferGroupOwnershipRequest) (*pbs.TransferGroupOwnershipResponse, error) {
+	const op = "groups.(Service).TransferGroupOwnership"
+
+	if err := validateTransferOwnershipRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.TransferOwnership)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	g, members, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	owner, err := handlers.SetResourceOwner(ctx, s.repoFn, authResults.Scope.GetId(), req.GetId(), req.GetOwnerId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, g.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owner))
+	}
+
+	item, err := toProto(ctx, g, members, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.TransferGroupOwnershipResponse{Item: item}, nil
+}
+
+// GetGroupResolvedMembers implements the interface pbs.GroupServiceServer.
+func (s Service) GetGroupResolvedMembers(ctx context.Context, req *pbs.GetGroupResolvedMembersRequest) (*pbs.GetGroupResolvedMembersResponse, error) {
+	const op = "groups.(Service).GetGroupResolvedMembers"
+
+	if err := validateGetResolvedMembersRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ReadResolvedMembers)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	g, _, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	members, err := repo.ListResolvedGroupMembers(ctx, g.GetPublicId())
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	item := &pb.ResolvedMembers{GroupId: g.GetPublicId()}
+	for _, m := range members {
+		item.Members = append(item.Members, &pb.ResolvedMember{
+			Id:       m.MemberId,
+			ScopeId:  m.MemberScopeId,
+			GroupIds: m.GroupIds,
+		})
+	}
+	return &pbs.GetGroupResolvedMembersResponse{Item: item}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Group, []*iam.GroupMember, error) {
 	const op = "groups.(Service).getFromRepo"
 	repo, err := s.repoFn()
@@ -406,7 +550,7 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Group, []*iam
 	return g, m, err
 }
 
-func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Group) (*iam.Group, error) {
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Group, ownerId string) (*iam.Group, error) {
 	const op = "groups.(Service).createInRepo"
 	var opts []iam.Option
 	if item.GetName() != nil {
@@ -423,7 +567,11 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Grou
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
-	out, err := repo.CreateGroup(ctx, u)
+	var createOpts []iam.Option
+	if ownerId != "" {
+		createOpts = append(createOpts, iam.WithOwnerId(ownerId))
+	}
+	out, err := repo.CreateGroup(ctx, u, createOpts...)
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
@@ -495,13 +643,26 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.Gr
 	return gl, nil
 }
 
-func (s Service) addMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32) (*iam.Group, []*iam.GroupMember, error) {
+func (s Service) listOwnersFromRepo(ctx context.Context, ids []string) (map[string]string, error) {
+	const op = "groups.(Service).listOwnersFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	owners, err := repo.ListResourceOwners(ctx, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return owners, nil
+}
+
+func (s Service) addMembersInRepo(ctx context.Context, groupId string, memberIds []string, version uint32) (*iam.Group, []*iam.GroupMember, error) {
 	const op = "groups.(Service).addMembersInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, nil, errors.Wrap(err, op)
 	}
-	_, err = repo.AddGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(userIds, false))
+	_, err = repo.AddGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(memberIds, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add members to group: %v.", err)
@@ -516,13 +677,13 @@ func (s Service) addMembersInRepo(ctx context.Context, groupId string, userIds [
 	return out, m, nil
 }
 
-func (s Service) setMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32) (*iam.Group, []*iam.GroupMember, error) {
+func (s Service) setMembersInRepo(ctx context.Context, groupId string, memberIds []string, version uint32) (*iam.Group, []*iam.GroupMember, error) {
 	const op = "groups.(Service).setMembersInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, nil, errors.Wrap(err, op)
 	}
-	_, _, err = repo.SetGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(userIds, false))
+	_, _, err = repo.SetGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(memberIds, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set members on group: %v.", err)
@@ -537,13 +698,13 @@ func (s Service) setMembersInRepo(ctx context.Context, groupId string, userIds [
 	return out, m, nil
 }
 
-func (s Service) removeMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32) (*iam.Group, []*iam.GroupMember, error) {
+func (s Service) removeMembersInRepo(ctx context.Context, groupId string, memberIds []string, version uint32) (*iam.Group, []*iam.GroupMember, error) {
 	const op = "groups.(Service).removeMembersInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, nil, errors.Wrap(err, op)
 	}
-	_, err = repo.DeleteGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(userIds, false))
+	_, err = repo.DeleteGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(memberIds, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove members from group: %v.", err)
@@ -632,6 +793,9 @@ func toProto(ctx context.Context, in *iam.Group, members []*iam.GroupMember, opt
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
+	if outputFields.Has(globals.OwnerIdField) && opts.WithOwnerId != "" {
+		out.OwnerId = opts.WithOwnerId
+	}
 	if outputFields.Has(globals.MemberIdsField) {
 		for _, m := range members {
 			out.MemberIds = append(out.MemberIds, m.GetMemberId())
@@ -642,9 +806,11 @@ func toProto(ctx context.Context, in *iam.Group, members []*iam.GroupMember, opt
 			out.Members = append(out.Members, &pb.Member{
 				Id:      m.GetMemberId(),
 				ScopeId: m.GetMemberScopeId(),
+				Type:    m.GetType(),
 			})
 		}
 	}
+	handlers.FilterOutputFields(&out, outputFields)
 	return &out, nil
 }
 
@@ -657,6 +823,23 @@ func validateGetRequest(req *pbs.GetGroupRequest) error {
 	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, iam.GroupPrefix)
 }
 
+func validateGetResolvedMembersRequest(req *pbs.GetGroupResolvedMembersRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, iam.GroupPrefix)
+}
+
+func validateTransferOwnershipRequest(req *pbs.TransferGroupOwnershipRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		badFields := map[string]string{}
+		switch {
+		case !handlers.ValidId(handlers.Id(req.GetOwnerId()), iam.UserPrefix):
+			badFields[globals.OwnerIdField] = "Incorrectly formatted identifier."
+		case !perms.CanOwn(req.GetOwnerId()):
+			badFields[globals.OwnerIdField] = "Ownership can't be transferred to a special user."
+		}
+		return badFields
+	}, req, iam.GroupPrefix)
+}
+
 func validateCreateRequest(req *pbs.CreateGroupRequest) error {
 	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
@@ -705,8 +888,12 @@ func validateAddGroupMembersRequest(req *pbs.AddGroupMembersRequest) error {
 		badFields["member_ids"] = "Must be non-empty."
 	}
 	for _, id := range req.GetMemberIds() {
-		if !handlers.ValidId(handlers.Id(id), iam.UserPrefix) {
-			badFields["member_ids"] = fmt.Sprintf("Must only contain valid user ids but found %q.", id)
+		if !handlers.ValidId(handlers.Id(id), iam.UserPrefix) && !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) {
+			badFields["member_ids"] = fmt.Sprintf("Must only contain valid user or group ids but found %q.", id)
+			break
+		}
+		if id == req.GetId() {
+			badFields["member_ids"] = "A group cannot be a member of itself."
 			break
 		}
 		if id == "u_recovery" {
@@ -729,8 +916,12 @@ func validateSetGroupMembersRequest(req *pbs.SetGroupMembersRequest) error {
 		badFields["version"] = "Required field."
 	}
 	for _, id := range req.GetMemberIds() {
-		if !handlers.ValidId(handlers.Id(id), iam.UserPrefix) {
-			badFields["member_ids"] = fmt.Sprintf("Must only contain valid user ids but found %q.", id)
+		if !handlers.ValidId(handlers.Id(id), iam.UserPrefix) && !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) {
+			badFields["member_ids"] = fmt.Sprintf("Must only contain valid user or group ids but found %q.", id)
+			break
+		}
+		if id == req.GetId() {
+			badFields["member_ids"] = "A group cannot be a member of itself."
 			break
 		}
 		if id == "u_recovery" {
@@ -756,8 +947,12 @@ func validateRemoveGroupMembersRequest(req *pbs.RemoveGroupMembersRequest) error
 		badFields["member_ids"] = "Must be non-empty."
 	}
 	for _, id := range req.GetMemberIds() {
-		if !handlers.ValidId(handlers.Id(id), iam.UserPrefix) {
-			badFields["member_ids"] = fmt.Sprintf("Must only contain valid user ids but found %q.", id)
+		if !handlers.ValidId(handlers.Id(id), iam.UserPrefix) && !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) {
+			badFields["member_ids"] = fmt.Sprintf("Must only contain valid user or group ids but found %q.", id)
+			break
+		}
+		if id == req.GetId() {
+			badFields["member_ids"] = "A group cannot be a member of itself."
 			break
 		}
 	}
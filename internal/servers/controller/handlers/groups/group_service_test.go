@@ -29,7 +29,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-members", "set-members", "remove-members"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-members", "set-members", "remove-members", "read-resolved-members", "transfer-ownership"}
 
 // Creates an org scoped group and a project scoped group.
 func createDefaultGroupsAndRepo(t *testing.T) (*iam.Group, *iam.Group, func() (*iam.Repository, error)) {
@@ -987,6 +987,7 @@ func TestAddMember(t *testing.T) {
 		iam.TestUser(t, iamRepo, o.GetPublicId()),
 		iam.TestUser(t, iamRepo, o.GetPublicId()),
 	}
+	childGroup := iam.TestGroup(t, conn, o.GetPublicId())
 
 	addCases := []struct {
 		name         string
@@ -1027,6 +1028,14 @@ func TestAddMember(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:         "Add user and group on empty group",
+			setup:        func(g *iam.Group) {},
+			addUsers:     []string{users[1].GetPublicId()},
+			addGroups:    []string{childGroup.GetPublicId()},
+			resultUsers:  []string{users[1].GetPublicId()},
+			resultGroups: []string{childGroup.GetPublicId()},
+		},
 		{
 			name:     "Add invalid u_recovery to group",
 			setup:    func(g *iam.Group) {},
@@ -1043,7 +1052,7 @@ func TestAddMember(t *testing.T) {
 				req := &pbs.AddGroupMembersRequest{
 					Id:        grp.GetPublicId(),
 					Version:   grp.GetVersion(),
-					MemberIds: tc.addUsers,
+					MemberIds: append(tc.addUsers, tc.addGroups...),
 				}
 
 				got, err := s.AddGroupMembers(auth.DisabledAuthTestContext(repoFn, scp.GetPublicId()), req)
@@ -1055,7 +1064,7 @@ func TestAddMember(t *testing.T) {
 				require.True(t, ok)
 				require.NoError(t, err, "Got error: %v", s)
 
-				assert.True(t, equalMembers(got.GetItem(), tc.resultUsers))
+				assert.True(t, equalMembers(got.GetItem(), append(tc.resultUsers, tc.resultGroups...)))
 			})
 		}
 	}
@@ -1093,6 +1102,15 @@ func TestAddMember(t *testing.T) {
 			},
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Group is member of itself",
+			req: &pbs.AddGroupMembersRequest{
+				Id:        grp.GetPublicId(),
+				Version:   grp.GetVersion(),
+				MemberIds: []string{grp.GetPublicId()},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 	}
 	for _, tc := range failCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1365,3 +1383,132 @@ func TestRemoveMember(t *testing.T) {
 		})
 	}
 }
+
+func TestGetResolvedMembers(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := groups.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new group service.")
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	parent := iam.TestGroup(t, conn, o.GetPublicId())
+	child := iam.TestGroup(t, conn, o.GetPublicId())
+	u1 := iam.TestUser(t, iamRepo, o.GetPublicId())
+	u2 := iam.TestUser(t, iamRepo, o.GetPublicId())
+	iam.TestGroupMember(t, conn, parent.GetPublicId(), u1.GetPublicId())
+	iam.TestGroupMember(t, conn, child.GetPublicId(), u2.GetPublicId())
+	iam.TestGroupMemberGroup(t, conn, parent.GetPublicId(), child.GetPublicId())
+
+	cases := []struct {
+		name string
+		req  *pbs.GetGroupResolvedMembersRequest
+		res  []string
+		err  error
+	}{
+		{
+			name: "Parent group",
+			req:  &pbs.GetGroupResolvedMembersRequest{Id: parent.GetPublicId()},
+			res:  []string{u1.GetPublicId(), u2.GetPublicId()},
+		},
+		{
+			name: "Child group",
+			req:  &pbs.GetGroupResolvedMembersRequest{Id: child.GetPublicId()},
+			res:  []string{u2.GetPublicId()},
+		},
+		{
+			name: "Bad Group Id",
+			req:  &pbs.GetGroupResolvedMembersRequest{Id: "bad id"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.GetGroupResolvedMembers(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetGroupResolvedMembers(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(tc.req.GetId(), got.GetItem().GetGroupId())
+			var gotIds []string
+			for _, m := range got.GetItem().GetMembers() {
+				gotIds = append(gotIds, m.GetId())
+			}
+			assert.ElementsMatch(tc.res, gotIds)
+		})
+	}
+}
+
+func TestTransferGroupOwnership(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := groups.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new group service.")
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	g := iam.TestGroup(t, conn, o.GetPublicId())
+	u1 := iam.TestUser(t, iamRepo, o.GetPublicId())
+	u2 := iam.TestUser(t, iamRepo, o.GetPublicId())
+
+	cases := []struct {
+		name  string
+		req   *pbs.TransferGroupOwnershipRequest
+		owner string
+		err   error
+	}{
+		{
+			name:  "Transfer ownership",
+			req:   &pbs.TransferGroupOwnershipRequest{Id: g.GetPublicId(), OwnerId: u1.GetPublicId()},
+			owner: u1.GetPublicId(),
+		},
+		{
+			name:  "Transfer to another user",
+			req:   &pbs.TransferGroupOwnershipRequest{Id: g.GetPublicId(), OwnerId: u2.GetPublicId()},
+			owner: u2.GetPublicId(),
+		},
+		{
+			name: "Missing owner",
+			req:  &pbs.TransferGroupOwnershipRequest{Id: g.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Special user",
+			req:  &pbs.TransferGroupOwnershipRequest{Id: g.GetPublicId(), OwnerId: "u_anon"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad group id formatting",
+			req:  &pbs.TransferGroupOwnershipRequest{Id: iam.GroupPrefix + "_bad_format", OwnerId: u1.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := auth.DisabledAuthTestContext(repoFn, o.GetPublicId())
+			got, gErr := s.TransferGroupOwnership(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "TransferGroupOwnership(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(tc.owner, got.GetItem().GetOwnerId())
+
+			// The owner is returned when reading the group
+			read, err := s.GetGroup(ctx, &pbs.GetGroupRequest{Id: tc.req.GetId()})
+			require.NoError(err)
+			assert.Equal(tc.owner, read.GetItem().GetOwnerId())
+		})
+	}
+}
@@ -36,6 +36,7 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.SetLabels,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -114,6 +115,14 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 	if len(ul) == 0 {
 		return &pbs.ListHostCatalogsResponse{}, nil
 	}
+	ids := make([]string, 0, len(ul))
+	for _, item := range ul {
+		ids = append(ids, item.GetPublicId())
+	}
+	labels, err := s.listLabelsFromRepo(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
@@ -126,6 +135,7 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 	for _, item := range ul {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetScopeId()
+		res.Labels = labels[item.GetPublicId()]
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
 			continue
@@ -140,6 +150,9 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
+		if outputFields.Has(globals.LabelsField) {
+			outputOpts = append(outputOpts, handlers.WithLabels(res.Labels))
+		}
 		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 			collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, authResults.Scope.Id, item.GetPublicId())
 			if err != nil {
@@ -189,6 +202,13 @@ func (s Service) GetHostCatalog(ctx context.Context, req *pbs.GetHostCatalogRequ
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hc.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{hc.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hc.GetPublicId()]))
+	}
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, authResults.Scope.Id, hc.GetPublicId())
 		if err != nil {
@@ -282,6 +302,13 @@ func (s Service) UpdateHostCatalog(ctx context.Context, req *pbs.UpdateHostCatal
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hc.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{hc.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hc.GetPublicId()]))
+	}
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, authResults.Scope.Id, hc.GetPublicId())
 		if err != nil {
@@ -314,6 +341,58 @@ func (s Service) DeleteHostCatalog(ctx context.Context, req *pbs.DeleteHostCatal
 	return nil, nil
 }
 
+// SetHostCatalogLabels implements the interface pbs.HostCatalogServiceServer.
+func (s Service) SetHostCatalogLabels(ctx context.Context, req *pbs.SetHostCatalogLabelsRequest) (*pbs.SetHostCatalogLabelsResponse, error) {
+	const op = "host_catalogs.(Service).SetHostCatalogLabels"
+
+	if err := validateSetLabelsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetLabels)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	hc, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.setLabelsInRepo(ctx, req.GetId(), req.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hc.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.LabelsField) {
+		outputOpts = append(outputOpts, handlers.WithLabels(labels))
+	}
+	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
+		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, authResults.Scope.Id, hc.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
+	}
+
+	item, err := toProto(ctx, hc, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetHostCatalogLabelsResponse{Item: item}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*static.HostCatalog, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
@@ -341,6 +420,31 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*static
 	return ul, nil
 }
 
+func (s Service) listLabelsFromRepo(ctx context.Context, ids []string) (map[string]map[string]string, error) {
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListResourceLabels(ctx, ids)
+}
+
+func (s Service) setLabelsInRepo(ctx context.Context, id string, labels map[string]string) (map[string]string, error) {
+	const op = "host_catalogs.(Service).setLabelsInRepo"
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	// No labels in the request means removing all of them
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	out, err := repo.SetResourceLabels(ctx, id, labels)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to set labels on host catalog"))
+	}
+	return out, nil
+}
+
 func (s Service) createInRepo(ctx context.Context, projId string, item *pb.HostCatalog) (*static.HostCatalog, error) {
 	const op = "host_catalogs.(Servivce).createInRepo"
 	var opts []static.Option
@@ -499,6 +603,10 @@ func toProto(ctx context.Context, in *static.HostCatalog, opt ...handlers.Option
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 		out.AuthorizedCollectionActions = opts.WithAuthorizedCollectionActions
 	}
+	if outputFields.Has(globals.LabelsField) && len(opts.WithLabels) > 0 {
+		out.Labels = opts.WithLabels
+	}
+	handlers.FilterOutputFields(&out, outputFields)
 	return &out, nil
 }
 
@@ -545,6 +653,16 @@ func validateDeleteRequest(req *pbs.DeleteHostCatalogRequest) error {
 	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, static.HostCatalogPrefix)
 }
 
+func validateSetLabelsRequest(req *pbs.SetHostCatalogLabelsRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		badFields := map[string]string{}
+		if !handlers.ValidLabels(req.GetLabels()) {
+			badFields[globals.LabelsField] = "Label keys and values must be non-empty and consist of letters, digits and the characters _ . / -."
+		}
+		return badFields
+	}, req, static.HostCatalogPrefix)
+}
+
 func validateListRequest(req *pbs.ListHostCatalogsRequest) error {
 	badFields := map[string]string{}
 	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) &&
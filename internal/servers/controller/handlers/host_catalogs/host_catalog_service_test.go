@@ -47,7 +47,7 @@ var authorizedCollectionActions = map[string]*structpb.ListValue{
 	},
 }
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "set-labels"}
 
 func createDefaultHostCatalogAndRepo(t *testing.T) (*static.HostCatalog, *iam.Scope, common.StaticRepoFactory, common.IamRepoFactory) {
 	t.Helper()
@@ -823,3 +823,70 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestSetLabels(t *testing.T) {
+	t.Parallel()
+	hc, proj, repo, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+
+	s, err := host_catalogs.NewService(repo, iamRepoFn)
+	require.NoError(t, err, "Couldn't create a new host catalog service.")
+
+	cases := []struct {
+		name   string
+		req    *pbs.SetHostCatalogLabelsRequest
+		labels map[string]string
+		err    error
+	}{
+		{
+			name:   "Set labels",
+			req:    &pbs.SetHostCatalogLabelsRequest{Id: hc.GetPublicId(), Labels: map[string]string{"env": "prod", "team": "db"}},
+			labels: map[string]string{"env": "prod", "team": "db"},
+		},
+		{
+			name:   "Replace labels",
+			req:    &pbs.SetHostCatalogLabelsRequest{Id: hc.GetPublicId(), Labels: map[string]string{"env": "dev"}},
+			labels: map[string]string{"env": "dev"},
+		},
+		{
+			name: "Remove labels",
+			req:  &pbs.SetHostCatalogLabelsRequest{Id: hc.GetPublicId()},
+		},
+		{
+			name: "Invalid label",
+			req:  &pbs.SetHostCatalogLabelsRequest{Id: hc.GetPublicId(), Labels: map[string]string{"e nv": "prod"}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Not existing HostCatalog",
+			req:  &pbs.SetHostCatalogLabelsRequest{Id: static.HostCatalogPrefix + "_doesntexis", Labels: map[string]string{"env": "prod"}},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad HostCatalog Id formatting",
+			req:  &pbs.SetHostCatalogLabelsRequest{Id: static.HostCatalogPrefix + "_bad_format", Labels: map[string]string{"env": "prod"}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId())
+			got, gErr := s.SetHostCatalogLabels(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "SetHostCatalogLabels(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(len(tc.labels), len(got.GetItem().GetLabels()))
+			for k, v := range tc.labels {
+				assert.Equal(v, got.GetItem().GetLabels()[k])
+			}
+
+			// The labels are returned when reading the host catalog
+			read, err := s.GetHostCatalog(ctx, &pbs.GetHostCatalogRequest{Id: tc.req.GetId()})
+			require.NoError(err)
+			assert.Empty(cmp.Diff(got.GetItem().GetLabels(), read.GetItem().GetLabels()))
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/boundary/internal/host"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
@@ -36,6 +37,8 @@ var (
 		action.AddHosts,
 		action.SetHosts,
 		action.RemoveHosts,
+		action.SetLabels,
+		action.TransferOwnership,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -57,18 +60,22 @@ type Service struct {
 	pbs.UnimplementedHostSetServiceServer
 
 	staticRepoFn common.StaticRepoFactory
+	iamRepoFn    common.IamRepoFactory
 }
 
 var _ pbs.HostSetServiceServer = Service{}
 
 // NewService returns a host set Service which handles host set related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(repoFn common.StaticRepoFactory) (Service, error) {
+func NewService(repoFn common.StaticRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
 	const op = "host_sets.NewService"
 	if repoFn == nil {
 		return Service{}, errors.New(errors.InvalidParameter, op, "missing static repository")
 	}
-	return Service{staticRepoFn: repoFn}, nil
+	if iamRepoFn == nil {
+		return Service{}, errors.New(errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{staticRepoFn: repoFn, iamRepoFn: iamRepoFn}, nil
 }
 
 func (s Service) ListHostSets(ctx context.Context, req *pbs.ListHostSetsRequest) (*pbs.ListHostSetsResponse, error) {
@@ -86,6 +93,18 @@ func (s Service) ListHostSets(ctx context.Context, req *pbs.ListHostSetsRequest)
 	if len(hl) == 0 {
 		return &pbs.ListHostSetsResponse{}, nil
 	}
+	ids := make([]string, 0, len(hl))
+	for _, item := range hl {
+		ids = append(ids, item.GetPublicId())
+	}
+	labels, err := s.listLabelsFromRepo(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	owners, err := s.listOwnersFromRepo(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
@@ -100,6 +119,8 @@ func (s Service) ListHostSets(ctx context.Context, req *pbs.ListHostSetsRequest)
 	}
 	for _, item := range hl {
 		res.Id = item.GetPublicId()
+		res.Labels = labels[item.GetPublicId()]
+		res.OwnerId = owners[item.GetPublicId()]
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
 			continue
@@ -114,6 +135,12 @@ func (s Service) ListHostSets(ctx context.Context, req *pbs.ListHostSetsRequest)
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
+		if outputFields.Has(globals.LabelsField) {
+			outputOpts = append(outputOpts, handlers.WithLabels(res.Labels))
+		}
+		if outputFields.Has(globals.OwnerIdField) {
+			outputOpts = append(outputOpts, handlers.WithOwnerId(res.OwnerId))
+		}
 
 		item, err := toProto(ctx, item, nil, outputOpts...)
 		if err != nil {
@@ -156,6 +183,20 @@ func (s Service) GetHostSet(ctx context.Context, req *pbs.GetHostSetRequest) (*p
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{hs.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hs.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{hs.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[hs.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, hs, hosts, outputOpts...)
 	if err != nil {
@@ -176,7 +217,12 @@ func (s Service) CreateHostSet(ctx context.Context, req *pbs.CreateHostSetReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hs, err := s.createInRepo(ctx, authResults.Scope.GetId(), cat.GetPublicId(), req.GetItem())
+	// The user creating the host set becomes its owner
+	var ownerId string
+	if perms.CanOwn(authResults.UserId) {
+		ownerId = authResults.UserId
+	}
+	hs, err := s.createInRepo(ctx, authResults.Scope.GetId(), cat.GetPublicId(), req.GetItem(), ownerId)
 	if err != nil {
 		return nil, err
 	}
@@ -192,7 +238,17 @@ func (s Service) CreateHostSet(ctx context.Context, req *pbs.CreateHostSetReques
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
 	}
 	if outputFields.Has(globals.AuthorizedActionsField) {
-		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), IdActions).Strings()))
+		res := perms.Resource{
+			Id:      hs.GetPublicId(),
+			ScopeId: authResults.Scope.GetId(),
+			Type:    resource.HostSet,
+			Pin:     cat.GetPublicId(),
+			OwnerId: ownerId,
+		}
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		outputOpts = append(outputOpts, handlers.WithOwnerId(ownerId))
 	}
 
 	item, err := toProto(ctx, hs, nil, outputOpts...)
@@ -235,6 +291,20 @@ func (s Service) UpdateHostSet(ctx context.Context, req *pbs.UpdateHostSetReques
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{hs.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hs.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{hs.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[hs.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, hs, hosts, outputOpts...)
 	if err != nil {
@@ -289,6 +359,20 @@ func (s Service) AddHostSetHosts(ctx context.Context, req *pbs.AddHostSetHostsRe
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{hs.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hs.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{hs.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[hs.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, hs, hosts, outputOpts...)
 	if err != nil {
@@ -326,6 +410,20 @@ func (s Service) SetHostSetHosts(ctx context.Context, req *pbs.SetHostSetHostsRe
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{hs.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hs.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{hs.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[hs.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, hs, hosts, outputOpts...)
 	if err != nil {
@@ -364,6 +462,20 @@ func (s Service) RemoveHostSetHosts(ctx context.Context, req *pbs.RemoveHostSetH
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{hs.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hs.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{hs.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[hs.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, hs, hosts, outputOpts...)
 	if err != nil {
@@ -373,6 +485,110 @@ func (s Service) RemoveHostSetHosts(ctx context.Context, req *pbs.RemoveHostSetH
 	return &pbs.RemoveHostSetHostsResponse{Item: item}, nil
 }
 
+// SetHostSetLabels implements the interface pbs.HostSetServiceServer.
+func (s Service) SetHostSetLabels(ctx context.Context, req *pbs.SetHostSetLabelsRequest) (*pbs.SetHostSetLabelsResponse, error) {
+	const op = "host_sets.(Service).SetHostSetLabels"
+
+	if err := validateSetLabelsRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.SetLabels)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	hs, hosts, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.setLabelsInRepo(ctx, req.GetId(), req.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.LabelsField) {
+		outputOpts = append(outputOpts, handlers.WithLabels(labels))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{hs.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[hs.GetPublicId()]))
+	}
+
+	item, err := toProto(ctx, hs, hosts, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetHostSetLabelsResponse{Item: item}, nil
+}
+
+// TransferHostSetOwnership implements the interface pbs.HostSetServiceServer.
+func (s Service) TransferHostSetOwnership(ctx context.Context, req *pbs.TransferHostSetOwnershipRequest) (*pbs.TransferHostSetOwnershipResponse, error) {
+	const op = "host_sets.(Service).TransferHostSetOwnership"
+
+	if err := validateTransferOwnershipRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.TransferOwnership)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	hs, hosts, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	owner, err := handlers.SetResourceOwner(ctx, s.iamRepoFn, authResults.Scope.GetId(), req.GetId(), req.GetOwnerId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, hs.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{hs.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[hs.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owner))
+	}
+
+	item, err := toProto(ctx, hs, hosts, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.TransferHostSetOwnershipResponse{Item: item}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*static.HostSet, []*static.Host, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
@@ -388,7 +604,40 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*static.HostSet, [
 	return h, m, nil
 }
 
-func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, item *pb.HostSet) (*static.HostSet, error) {
+func (s Service) listLabelsFromRepo(ctx context.Context, ids []string) (map[string]map[string]string, error) {
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListResourceLabels(ctx, ids)
+}
+
+func (s Service) listOwnersFromRepo(ctx context.Context, ids []string) (map[string]string, error) {
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListResourceOwners(ctx, ids)
+}
+
+func (s Service) setLabelsInRepo(ctx context.Context, id string, labels map[string]string) (map[string]string, error) {
+	const op = "host_sets.(Service).setLabelsInRepo"
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	// No labels in the request means removing all of them
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	out, err := repo.SetResourceLabels(ctx, id, labels)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to set labels on host set"))
+	}
+	return out, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, item *pb.HostSet, ownerId string) (*static.HostSet, error) {
 	const op = "host_sets.(Service).createInRepo"
 	var opts []static.Option
 	if item.GetName() != nil {
@@ -405,7 +654,11 @@ func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, it
 	if err != nil {
 		return nil, err
 	}
-	out, err := repo.CreateSet(ctx, scopeId, h)
+	var createOpts []static.Option
+	if ownerId != "" {
+		createOpts = append(createOpts, static.WithOwnerId(ownerId))
+	}
+	out, err := repo.CreateSet(ctx, scopeId, h, createOpts...)
 	if err != nil {
 		return nil, errors.Wrap(err, op, errors.WithMsg("unable to create host set"))
 	}
@@ -611,11 +864,18 @@ func toProto(ctx context.Context, in *static.HostSet, hosts []*static.Host, opt
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
+	if outputFields.Has(globals.LabelsField) && len(opts.WithLabels) > 0 {
+		out.Labels = opts.WithLabels
+	}
+	if outputFields.Has(globals.OwnerIdField) && opts.WithOwnerId != "" {
+		out.OwnerId = opts.WithOwnerId
+	}
 	if outputFields.Has(globals.HostIdsField) {
 		for _, h := range hosts {
 			out.HostIds = append(out.HostIds, h.GetPublicId())
 		}
 	}
+	handlers.FilterOutputFields(&out, outputFields)
 	return &out, nil
 }
 
@@ -663,6 +923,29 @@ func validateDeleteRequest(req *pbs.DeleteHostSetRequest) error {
 	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, static.HostSetPrefix)
 }
 
+func validateSetLabelsRequest(req *pbs.SetHostSetLabelsRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		badFields := map[string]string{}
+		if !handlers.ValidLabels(req.GetLabels()) {
+			badFields[globals.LabelsField] = "Label keys and values must be non-empty and consist of letters, digits and the characters _ . / -."
+		}
+		return badFields
+	}, req, static.HostSetPrefix)
+}
+
+func validateTransferOwnershipRequest(req *pbs.TransferHostSetOwnershipRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		badFields := map[string]string{}
+		switch {
+		case !handlers.ValidId(handlers.Id(req.GetOwnerId()), iam.UserPrefix):
+			badFields[globals.OwnerIdField] = "Incorrectly formatted identifier."
+		case !perms.CanOwn(req.GetOwnerId()):
+			badFields[globals.OwnerIdField] = "Ownership can't be transferred to a special user."
+		}
+		return badFields
+	}, req, static.HostSetPrefix)
+}
+
 func validateListRequest(req *pbs.ListHostSetsRequest) error {
 	badFields := map[string]string{}
 	if !handlers.ValidId(handlers.Id(req.GetHostCatalogId()), static.HostCatalogPrefix) {
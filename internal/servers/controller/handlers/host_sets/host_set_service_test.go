@@ -31,7 +31,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-hosts", "set-hosts", "remove-hosts"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-hosts", "set-hosts", "remove-hosts", "set-labels", "transfer-ownership"}
 
 func TestGet(t *testing.T) {
 	t.Parallel()
@@ -106,7 +106,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetHostSetRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := host_sets.NewService(repoFn)
+			s, err := host_sets.NewService(repoFn, iamRepoFn)
 			require.NoError(err, "Couldn't create a new host set service.")
 
 			got, gErr := s.GetHostSet(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), req)
@@ -191,7 +191,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := host_sets.NewService(repoFn)
+			s, err := host_sets.NewService(repoFn, iamRepoFn)
 			require.NoError(err, "Couldn't create new host set service.")
 
 			// Test with non-anon user
@@ -238,7 +238,7 @@ func TestDelete(t *testing.T) {
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := host_sets.NewService(repoFn)
+	s, err := host_sets.NewService(repoFn, iamRepoFn)
 	require.NoError(t, err, "Couldn't create a new host set service.")
 
 	cases := []struct {
@@ -314,7 +314,7 @@ func TestDelete_twice(t *testing.T) {
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := host_sets.NewService(repoFn)
+	s, err := host_sets.NewService(repoFn, iamRepoFn)
 	require.NoError(err, "Couldn't create a new host set service.")
 	req := &pbs.DeleteHostSetRequest{
 		Id: h.GetPublicId(),
@@ -435,7 +435,7 @@ func TestCreate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
 
-			s, err := host_sets.NewService(repoFn)
+			s, err := host_sets.NewService(repoFn, iamRepoFn)
 			require.NoError(err, "Failed to create a new host set service.")
 
 			got, gErr := s.CreateHostSet(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), tc.req)
@@ -513,7 +513,7 @@ func TestUpdate(t *testing.T) {
 		Id: hs.GetPublicId(),
 	}
 
-	tested, err := host_sets.NewService(repoFn)
+	tested, err := host_sets.NewService(repoFn, iamRepoFn)
 	require.NoError(t, err, "Failed to create a new host set service.")
 
 	cases := []struct {
@@ -849,7 +849,7 @@ func TestAddHostSetHosts(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
-	s, err := host_sets.NewService(repoFn)
+	s, err := host_sets.NewService(repoFn, iamRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -966,7 +966,7 @@ func TestSetHostSetHosts(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
-	s, err := host_sets.NewService(repoFn)
+	s, err := host_sets.NewService(repoFn, iamRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -1079,7 +1079,7 @@ func TestRemoveHostSetHosts(t *testing.T) {
 	repoFn := func() (*static.Repository, error) {
 		return static.NewRepository(rw, rw, kms)
 	}
-	s, err := host_sets.NewService(repoFn)
+	s, err := host_sets.NewService(repoFn, iamRepoFn)
 	require.NoError(t, err, "Error when getting new host set service.")
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -1193,3 +1193,169 @@ func TestRemoveHostSetHosts(t *testing.T) {
 		})
 	}
 }
+
+func TestSetHostSetLabels(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+
+	_, proj := iam.TestScopes(t, iamRepo)
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+
+	s, err := host_sets.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create a new host set service.")
+
+	cases := []struct {
+		name   string
+		req    *pbs.SetHostSetLabelsRequest
+		labels map[string]string
+		err    error
+	}{
+		{
+			name:   "Set labels",
+			req:    &pbs.SetHostSetLabelsRequest{Id: h.GetPublicId(), Labels: map[string]string{"env": "prod", "team": "db"}},
+			labels: map[string]string{"env": "prod", "team": "db"},
+		},
+		{
+			name:   "Replace labels",
+			req:    &pbs.SetHostSetLabelsRequest{Id: h.GetPublicId(), Labels: map[string]string{"env": "dev"}},
+			labels: map[string]string{"env": "dev"},
+		},
+		{
+			name: "Remove labels",
+			req:  &pbs.SetHostSetLabelsRequest{Id: h.GetPublicId()},
+		},
+		{
+			name: "Invalid label",
+			req:  &pbs.SetHostSetLabelsRequest{Id: h.GetPublicId(), Labels: map[string]string{"env": ""}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Not existing host set",
+			req:  &pbs.SetHostSetLabelsRequest{Id: static.HostSetPrefix + "_doesntexis", Labels: map[string]string{"env": "prod"}},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad host set id formatting",
+			req:  &pbs.SetHostSetLabelsRequest{Id: static.HostSetPrefix + "_bad_format", Labels: map[string]string{"env": "prod"}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId())
+			got, gErr := s.SetHostSetLabels(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "SetHostSetLabels(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(len(tc.labels), len(got.GetItem().GetLabels()))
+			for k, v := range tc.labels {
+				assert.Equal(v, got.GetItem().GetLabels()[k])
+			}
+
+			// The labels are returned when reading the host set
+			read, err := s.GetHostSet(ctx, &pbs.GetHostSetRequest{Id: tc.req.GetId()})
+			require.NoError(err)
+			assert.Empty(cmp.Diff(got.GetItem().GetLabels(), read.GetItem().GetLabels()))
+		})
+	}
+}
+
+func TestTransferHostSetOwnership(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+
+	s, err := host_sets.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create a new host set service.")
+	u1 := iam.TestUser(t, iamRepo, org.GetPublicId())
+	u2 := iam.TestUser(t, iamRepo, org.GetPublicId())
+
+	cases := []struct {
+		name  string
+		req   *pbs.TransferHostSetOwnershipRequest
+		owner string
+		err   error
+	}{
+		{
+			name:  "Transfer ownership",
+			req:   &pbs.TransferHostSetOwnershipRequest{Id: h.GetPublicId(), OwnerId: u1.GetPublicId()},
+			owner: u1.GetPublicId(),
+		},
+		{
+			name:  "Transfer to another user",
+			req:   &pbs.TransferHostSetOwnershipRequest{Id: h.GetPublicId(), OwnerId: u2.GetPublicId()},
+			owner: u2.GetPublicId(),
+		},
+		{
+			name: "Missing owner",
+			req:  &pbs.TransferHostSetOwnershipRequest{Id: h.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Special user",
+			req:  &pbs.TransferHostSetOwnershipRequest{Id: h.GetPublicId(), OwnerId: "u_anon"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Not existing host set",
+			req:  &pbs.TransferHostSetOwnershipRequest{Id: static.HostSetPrefix + "_doesntexis", OwnerId: u1.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad host set id formatting",
+			req:  &pbs.TransferHostSetOwnershipRequest{Id: static.HostSetPrefix + "_bad_format", OwnerId: u1.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId())
+			got, gErr := s.TransferHostSetOwnership(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "TransferHostSetOwnership(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(tc.owner, got.GetItem().GetOwnerId())
+
+			// The owner is returned when reading the host set
+			read, err := s.GetHostSet(ctx, &pbs.GetHostSetRequest{Id: tc.req.GetId()})
+			require.NoError(err)
+			assert.Equal(tc.owner, read.GetItem().GetOwnerId())
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/boundary/internal/host"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
@@ -34,6 +35,9 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.RotateSshHostKey,
+		action.SetLabels,
+		action.TransferOwnership,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -55,18 +59,22 @@ type Service struct {
 	pbs.UnimplementedHostServiceServer
 
 	staticRepoFn common.StaticRepoFactory
+	iamRepoFn    common.IamRepoFactory
 }
 
 var _ pbs.HostServiceServer = Service{}
 
 // NewService returns a host Service which handles host related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(repoFn common.StaticRepoFactory) (Service, error) {
+func NewService(repoFn common.StaticRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
 	const op = "hosts.NewService"
 	if repoFn == nil {
 		return Service{}, errors.New(errors.InvalidParameter, op, "missing static repository")
 	}
-	return Service{staticRepoFn: repoFn}, nil
+	if iamRepoFn == nil {
+		return Service{}, errors.New(errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{staticRepoFn: repoFn, iamRepoFn: iamRepoFn}, nil
 }
 
 func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs.ListHostsResponse, error) {
@@ -84,6 +92,18 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 	if len(hl) == 0 {
 		return &pbs.ListHostsResponse{}, nil
 	}
+	ids := make([]string, 0, len(hl))
+	for _, item := range hl {
+		ids = append(ids, item.GetPublicId())
+	}
+	labels, err := s.listLabelsFromRepo(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	owners, err := s.listOwnersFromRepo(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
@@ -98,6 +118,8 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 	}
 	for _, item := range hl {
 		res.Id = item.GetPublicId()
+		res.Labels = labels[item.GetPublicId()]
+		res.OwnerId = owners[item.GetPublicId()]
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
 			continue
@@ -112,8 +134,14 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
+		if outputFields.Has(globals.LabelsField) {
+			outputOpts = append(outputOpts, handlers.WithLabels(res.Labels))
+		}
+		if outputFields.Has(globals.OwnerIdField) {
+			outputOpts = append(outputOpts, handlers.WithOwnerId(res.OwnerId))
+		}
 
-		item, err := toProto(ctx, item, nil, outputOpts...)
+		item, err := toProto(ctx, item, nil, nil, outputOpts...)
 		if err != nil {
 			return nil, err
 		}
@@ -140,6 +168,10 @@ func (s Service) GetHost(ctx context.Context, req *pbs.GetHostRequest) (*pbs.Get
 	if err != nil {
 		return nil, err
 	}
+	hk, err := s.getSshHostKeyFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
 
 	outputFields, ok := requests.OutputFields(ctx)
 	if !ok {
@@ -154,8 +186,22 @@ func (s Service) GetHost(ctx context.Context, req *pbs.GetHostRequest) (*pbs.Get
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, h.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{h.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[h.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{h.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[h.GetPublicId()]))
+	}
 
-	item, err := toProto(ctx, h, nil, outputOpts...)
+	item, err := toProto(ctx, h, nil, hk, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +220,12 @@ func (s Service) CreateHost(ctx context.Context, req *pbs.CreateHostRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	h, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem().GetHostCatalogId(), req.GetItem())
+	// The user creating the host becomes its owner
+	var ownerId string
+	if perms.CanOwn(authResults.UserId) {
+		ownerId = authResults.UserId
+	}
+	h, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem().GetHostCatalogId(), req.GetItem(), ownerId)
 	if err != nil {
 		return nil, err
 	}
@@ -190,10 +241,20 @@ func (s Service) CreateHost(ctx context.Context, req *pbs.CreateHostRequest) (*p
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
 	}
 	if outputFields.Has(globals.AuthorizedActionsField) {
-		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, h.GetPublicId(), IdActions).Strings()))
+		res := perms.Resource{
+			Id:      h.GetPublicId(),
+			ScopeId: authResults.Scope.GetId(),
+			Type:    resource.Host,
+			Pin:     req.GetItem().GetHostCatalogId(),
+			OwnerId: ownerId,
+		}
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, h.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		outputOpts = append(outputOpts, handlers.WithOwnerId(ownerId))
 	}
 
-	item, err := toProto(ctx, h, nil, outputOpts...)
+	item, err := toProto(ctx, h, nil, nil, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -233,8 +294,22 @@ func (s Service) UpdateHost(ctx context.Context, req *pbs.UpdateHostRequest) (*p
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, h.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{h.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[h.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{h.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[h.GetPublicId()]))
+	}
 
-	item, err := toProto(ctx, h, nil, outputOpts...)
+	item, err := toProto(ctx, h, nil, nil, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -258,6 +333,174 @@ func (s Service) DeleteHost(ctx context.Context, req *pbs.DeleteHostRequest) (*p
 	return nil, nil
 }
 
+// RotateSshHostKey implements the interface pbs.HostServiceServer.
+func (s Service) RotateSshHostKey(ctx context.Context, req *pbs.RotateSshHostKeyRequest) (*pbs.RotateSshHostKeyResponse, error) {
+	const op = "hosts.(Service).RotateSshHostKey"
+
+	if err := validateRotateSshHostKeyRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.RotateSshHostKey)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	hk, err := s.rotateSshHostKeyInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetFingerprint())
+	if err != nil {
+		return nil, err
+	}
+	h, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, h.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{h.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[h.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{h.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[h.GetPublicId()]))
+	}
+
+	item, err := toProto(ctx, h, nil, hk, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RotateSshHostKeyResponse{Item: item}, nil
+}
+
+// SetHostLabels implements the interface pbs.HostServiceServer.
+func (s Service) SetHostLabels(ctx context.Context, req *pbs.SetHostLabelsRequest) (*pbs.SetHostLabelsResponse, error) {
+	const op = "hosts.(Service).SetHostLabels"
+
+	if err := validateSetLabelsRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.SetLabels)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	h, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	hk, err := s.getSshHostKeyFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.setLabelsInRepo(ctx, req.GetId(), req.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, h.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.LabelsField) {
+		outputOpts = append(outputOpts, handlers.WithLabels(labels))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{h.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[h.GetPublicId()]))
+	}
+
+	item, err := toProto(ctx, h, nil, hk, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetHostLabelsResponse{Item: item}, nil
+}
+
+// TransferHostOwnership implements the interface pbs.HostServiceServer.
+func (s Service) TransferHostOwnership(ctx context.Context, req *pbs.TransferHostOwnershipRequest) (*pbs.TransferHostOwnershipResponse, error) {
+	const op = "hosts.(Service).TransferHostOwnership"
+
+	if err := validateTransferOwnershipRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.TransferOwnership)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	h, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	hk, err := s.getSshHostKeyFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	owner, err := handlers.SetResourceOwner(ctx, s.iamRepoFn, authResults.Scope.GetId(), req.GetId(), req.GetOwnerId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, h.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{h.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[h.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owner))
+	}
+
+	item, err := toProto(ctx, h, nil, hk, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.TransferHostOwnershipResponse{Item: item}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*static.Host, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
@@ -273,7 +516,61 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*static.Host, erro
 	return h, nil
 }
 
-func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, item *pb.Host) (*static.Host, error) {
+func (s Service) getSshHostKeyFromRepo(ctx context.Context, id string) (*static.HostSshHostKey, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	return repo.LookupSshHostKey(ctx, id)
+}
+
+func (s Service) rotateSshHostKeyInRepo(ctx context.Context, scopeId, id, fingerprint string) (*static.HostSshHostKey, error) {
+	const op = "hosts.(Service).rotateSshHostKeyInRepo"
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.RotateSshHostKey(ctx, scopeId, id, fingerprint)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to rotate ssh host key"))
+	}
+	return out, nil
+}
+
+func (s Service) listLabelsFromRepo(ctx context.Context, ids []string) (map[string]map[string]string, error) {
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListResourceLabels(ctx, ids)
+}
+
+func (s Service) listOwnersFromRepo(ctx context.Context, ids []string) (map[string]string, error) {
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListResourceOwners(ctx, ids)
+}
+
+func (s Service) setLabelsInRepo(ctx context.Context, id string, labels map[string]string) (map[string]string, error) {
+	const op = "hosts.(Service).setLabelsInRepo"
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	// No labels in the request means removing all of them
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	out, err := repo.SetResourceLabels(ctx, id, labels)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to set labels on host"))
+	}
+	return out, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, item *pb.Host, ownerId string) (*static.Host, error) {
 	const op = "hosts.(Service).createInRepo"
 	ha := &pb.StaticHostAttributes{}
 	if err := handlers.StructToProto(item.GetAttributes(), ha); err != nil {
@@ -298,7 +595,11 @@ func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, it
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
-	out, err := repo.CreateHost(ctx, scopeId, h)
+	var createOpts []static.Option
+	if ownerId != "" {
+		createOpts = append(createOpts, static.WithOwnerId(ownerId))
+	}
+	out, err := repo.CreateHost(ctx, scopeId, h, createOpts...)
 	if err != nil {
 		return nil, errors.Wrap(err, op, errors.WithMsg("Unable to create host"))
 	}
@@ -412,7 +713,7 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 	return cat, auth.Verify(ctx, opts...)
 }
 
-func toProto(ctx context.Context, in *static.Host, hostSets []*static.HostSet, opt ...handlers.Option) (*pb.Host, error) {
+func toProto(ctx context.Context, in *static.Host, hostSets []*static.HostSet, hostKey *static.HostSshHostKey, opt ...handlers.Option) (*pb.Host, error) {
 	opts := handlers.GetOpts(opt...)
 	if opts.WithOutputFields == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building host proto")
@@ -450,18 +751,29 @@ func toProto(ctx context.Context, in *static.Host, hostSets []*static.HostSet, o
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
+	if outputFields.Has(globals.LabelsField) && len(opts.WithLabels) > 0 {
+		out.Labels = opts.WithLabels
+	}
+	if outputFields.Has(globals.OwnerIdField) && opts.WithOwnerId != "" {
+		out.OwnerId = opts.WithOwnerId
+	}
 	if outputFields.Has(globals.HostSetIdsField) {
 		for _, hs := range hostSets {
 			out.HostSetIds = append(out.HostSetIds, hs.GetPublicId())
 		}
 	}
 	if outputFields.Has(globals.AttributesField) {
-		st, err := handlers.ProtoToStruct(&pb.StaticHostAttributes{Address: wrapperspb.String(in.GetAddress())})
+		st, err := handlers.ProtoToStruct(&pb.StaticHostAttributes{
+			Address:                      wrapperspb.String(in.GetAddress()),
+			SshHostKeyFingerprint:        hostKey.GetFingerprint(),
+			PendingSshHostKeyFingerprint: hostKey.GetPendingFingerprint(),
+		})
 		if err != nil {
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to convert static attribute to struct: %s", err)
 		}
 		out.Attributes = st
 	}
+	handlers.FilterOutputFields(&out, outputFields)
 	return &out, nil
 }
 
@@ -503,6 +815,12 @@ func validateCreateRequest(req *pbs.CreateHostRequest) error {
 				len(attrs.GetAddress().GetValue()) > static.MaxHostAddressLength {
 				badFields["attributes.address"] = fmt.Sprintf("Address length must be between %d and %d characters.", static.MinHostAddressLength, static.MaxHostAddressLength)
 			}
+			if attrs.GetSshHostKeyFingerprint() != "" {
+				badFields["attributes.ssh_host_key_fingerprint"] = "This is a read only field."
+			}
+			if attrs.GetPendingSshHostKeyFingerprint() != "" {
+				badFields["attributes.pending_ssh_host_key_fingerprint"] = "This is a read only field."
+			}
 			_, _, err := net.SplitHostPort(attrs.GetAddress().GetValue())
 			switch {
 			case err == nil:
@@ -549,6 +867,46 @@ func validateDeleteRequest(req *pbs.DeleteHostRequest) error {
 	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, static.HostPrefix)
 }
 
+func validateRotateSshHostKeyRequest(req *pbs.RotateSshHostKeyRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), static.HostPrefix) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if req.GetFingerprint() == "" {
+		badFields["fingerprint"] = "This field is required."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+func validateSetLabelsRequest(req *pbs.SetHostLabelsRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		badFields := map[string]string{}
+		if host.SubtypeFromId(req.GetId()) == host.UnknownSubtype {
+			badFields["id"] = "Improperly formatted identifier used."
+		}
+		if !handlers.ValidLabels(req.GetLabels()) {
+			badFields[globals.LabelsField] = "Label keys and values must be non-empty and consist of letters, digits and the characters _ . / -."
+		}
+		return badFields
+	}, req, static.HostPrefix)
+}
+
+func validateTransferOwnershipRequest(req *pbs.TransferHostOwnershipRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		badFields := map[string]string{}
+		switch {
+		case !handlers.ValidId(handlers.Id(req.GetOwnerId()), iam.UserPrefix):
+			badFields[globals.OwnerIdField] = "Incorrectly formatted identifier."
+		case !perms.CanOwn(req.GetOwnerId()):
+			badFields[globals.OwnerIdField] = "Ownership can't be transferred to a special user."
+		}
+		return badFields
+	}, req, static.HostPrefix)
+}
+
 func validateListRequest(req *pbs.ListHostsRequest) error {
 	badFields := map[string]string{}
 	if !handlers.ValidId(handlers.Id(req.GetHostCatalogId()), static.HostCatalogPrefix) {
@@ -31,7 +31,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "rotate-ssh-host-key", "set-labels", "transfer-ownership"}
 
 func TestGet(t *testing.T) {
 	t.Parallel()
@@ -99,7 +99,7 @@ func TestGet(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := hosts.NewService(repoFn)
+			s, err := hosts.NewService(repoFn, iamRepoFn)
 			require.NoError(err, "Couldn't create a new host set service.")
 
 			got, gErr := s.GetHost(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), tc.req)
@@ -186,7 +186,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := hosts.NewService(repoFn)
+			s, err := hosts.NewService(repoFn, iamRepoFn)
 			require.NoError(err, "Couldn't create new host set service.")
 
 			// Test non-anonymous listing
@@ -232,7 +232,7 @@ func TestDelete(t *testing.T) {
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := hosts.NewService(repoFn)
+	s, err := hosts.NewService(repoFn, iamRepoFn)
 	require.NoError(t, err, "Couldn't create a new host set service.")
 
 	cases := []struct {
@@ -300,7 +300,7 @@ func TestDelete_twice(t *testing.T) {
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
 	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
 
-	s, err := hosts.NewService(repoFn)
+	s, err := hosts.NewService(repoFn, iamRepoFn)
 	require.NoError(err, "Couldn't create a new host set service.")
 	req := &pbs.DeleteHostRequest{
 		Id: h.GetPublicId(),
@@ -455,7 +455,7 @@ func TestCreate(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := hosts.NewService(repoFn)
+			s, err := hosts.NewService(repoFn, iamRepoFn)
 			require.NoError(err, "Failed to create a new host set service.")
 
 			got, gErr := s.CreateHost(auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId()), tc.req)
@@ -526,7 +526,7 @@ func TestUpdate(t *testing.T) {
 		Id: h.GetPublicId(),
 	}
 
-	tested, err := hosts.NewService(repoFn)
+	tested, err := hosts.NewService(repoFn, iamRepoFn)
 	require.NoError(t, err, "Failed to create a new host set service.")
 
 	cases := []struct {
@@ -888,3 +888,169 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestSetHostLabels(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+
+	_, proj := iam.TestScopes(t, iamRepo)
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+
+	s, err := hosts.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create a new host service.")
+
+	cases := []struct {
+		name   string
+		req    *pbs.SetHostLabelsRequest
+		labels map[string]string
+		err    error
+	}{
+		{
+			name:   "Set labels",
+			req:    &pbs.SetHostLabelsRequest{Id: h.GetPublicId(), Labels: map[string]string{"env": "prod", "team": "db"}},
+			labels: map[string]string{"env": "prod", "team": "db"},
+		},
+		{
+			name:   "Replace labels",
+			req:    &pbs.SetHostLabelsRequest{Id: h.GetPublicId(), Labels: map[string]string{"env": "dev"}},
+			labels: map[string]string{"env": "dev"},
+		},
+		{
+			name: "Remove labels",
+			req:  &pbs.SetHostLabelsRequest{Id: h.GetPublicId()},
+		},
+		{
+			name: "Invalid label",
+			req:  &pbs.SetHostLabelsRequest{Id: h.GetPublicId(), Labels: map[string]string{"env": ""}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Not existing host",
+			req:  &pbs.SetHostLabelsRequest{Id: static.HostPrefix + "_doesntexis", Labels: map[string]string{"env": "prod"}},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad host id formatting",
+			req:  &pbs.SetHostLabelsRequest{Id: static.HostPrefix + "_bad_format", Labels: map[string]string{"env": "prod"}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId())
+			got, gErr := s.SetHostLabels(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "SetHostLabels(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(len(tc.labels), len(got.GetItem().GetLabels()))
+			for k, v := range tc.labels {
+				assert.Equal(v, got.GetItem().GetLabels()[k])
+			}
+
+			// The labels are returned when reading the host
+			read, err := s.GetHost(ctx, &pbs.GetHostRequest{Id: tc.req.GetId()})
+			require.NoError(err)
+			assert.Empty(cmp.Diff(got.GetItem().GetLabels(), read.GetItem().GetLabels()))
+		})
+	}
+}
+
+func TestTransferHostOwnership(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+
+	s, err := hosts.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create a new host service.")
+	u1 := iam.TestUser(t, iamRepo, org.GetPublicId())
+	u2 := iam.TestUser(t, iamRepo, org.GetPublicId())
+
+	cases := []struct {
+		name  string
+		req   *pbs.TransferHostOwnershipRequest
+		owner string
+		err   error
+	}{
+		{
+			name:  "Transfer ownership",
+			req:   &pbs.TransferHostOwnershipRequest{Id: h.GetPublicId(), OwnerId: u1.GetPublicId()},
+			owner: u1.GetPublicId(),
+		},
+		{
+			name:  "Transfer to another user",
+			req:   &pbs.TransferHostOwnershipRequest{Id: h.GetPublicId(), OwnerId: u2.GetPublicId()},
+			owner: u2.GetPublicId(),
+		},
+		{
+			name: "Missing owner",
+			req:  &pbs.TransferHostOwnershipRequest{Id: h.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Special user",
+			req:  &pbs.TransferHostOwnershipRequest{Id: h.GetPublicId(), OwnerId: "u_anon"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Not existing host",
+			req:  &pbs.TransferHostOwnershipRequest{Id: static.HostPrefix + "_doesntexis", OwnerId: u1.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad host id formatting",
+			req:  &pbs.TransferHostOwnershipRequest{Id: static.HostPrefix + "_bad_format", OwnerId: u1.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId())
+			got, gErr := s.TransferHostOwnership(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "TransferHostOwnership(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(tc.owner, got.GetItem().GetOwnerId())
+
+			// The owner is returned when reading the host
+			read, err := s.GetHost(ctx, &pbs.GetHostRequest{Id: tc.req.GetId()})
+			require.NoError(err)
+			assert.Equal(tc.owner, read.GetItem().GetOwnerId())
+		})
+	}
+}
@@ -30,6 +30,8 @@ type options struct {
 	WithScope                       *scopes.ScopeInfo
 	WithAuthorizedActions           []string
 	WithAuthorizedCollectionActions map[string]*structpb.ListValue
+	WithLabels                      map[string]string
+	WithOwnerId                     string
 }
 
 func getDefaultOptions() options {
@@ -91,3 +93,19 @@ func WithAuthorizedCollectionActions(colActs map[string]*structpb.ListValue) Opt
 		o.WithAuthorizedCollectionActions = colActs
 	}
 }
+
+// WithLabels provides an option when creating responses to include the given
+// labels if allowed
+func WithLabels(labels map[string]string) Option {
+	return func(o *options) {
+		o.WithLabels = labels
+	}
+}
+
+// WithOwnerId provides an option when creating responses to include the given
+// owner if allowed
+func WithOwnerId(ownerId string) Option {
+	return func(o *options) {
+		o.WithOwnerId = ownerId
+	}
+}
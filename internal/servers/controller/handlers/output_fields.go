@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"github.com/hashicorp/boundary/internal/perms"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// FilterOutputFields clears the fields of the item that aren't in the output
+// fields, including the fields of nested messages, lists of messages, maps and
+// structs that the output fields only give some nested paths of. Fields are
+// named by their proto names, the same as in the JSON the API returns, and
+// struct and map fields are named by their keys.
+func FilterOutputFields(item proto.Message, fields perms.OutputFieldsMap) {
+	if item == nil || fields.HasAll() {
+		return
+	}
+	filterMessage(item.ProtoReflect(), fields)
+}
+
+func filterMessage(m protoreflect.Message, fields perms.OutputFieldsMap) {
+	if !m.IsValid() || fields.HasAll() {
+		return
+	}
+	if s, ok := m.Interface().(*structpb.Struct); ok {
+		filterStruct(s, fields)
+		return
+	}
+	// Clearing fields while ranging over them is undefined, so gather them
+	// first
+	var populated []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		populated = append(populated, fd)
+		return true
+	})
+	for _, fd := range populated {
+		nested := fields.Nested(string(fd.Name()))
+		switch {
+		case len(nested) == 0:
+			m.Clear(fd)
+		case nested.HasAll():
+		case fd.IsMap():
+			filterMap(m.Mutable(fd).Map(), fd.MapValue(), nested)
+		case fd.Kind() != protoreflect.MessageKind && fd.Kind() != protoreflect.GroupKind,
+			wellKnownScalar(fd.Message()):
+			// A scalar has no nested fields to output
+			m.Clear(fd)
+		case fd.IsList():
+			l := m.Mutable(fd).List()
+			for i := 0; i < l.Len(); i++ {
+				filterMessage(l.Get(i).Message(), nested)
+			}
+		default:
+			filterMessage(m.Mutable(fd).Message(), nested)
+		}
+	}
+}
+
+func filterMap(m protoreflect.Map, valueFd protoreflect.FieldDescriptor, fields perms.OutputFieldsMap) {
+	var keys []protoreflect.MapKey
+	m.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	for _, k := range keys {
+		nested := fields.Nested(k.String())
+		switch {
+		case len(nested) == 0:
+			m.Clear(k)
+		case nested.HasAll():
+		case valueFd.Kind() != protoreflect.MessageKind, wellKnownScalar(valueFd.Message()):
+			m.Clear(k)
+		default:
+			filterMessage(m.Get(k).Message(), nested)
+		}
+	}
+}
+
+func filterStruct(s *structpb.Struct, fields perms.OutputFieldsMap) {
+	for k, v := range s.GetFields() {
+		nested := fields.Nested(k)
+		switch {
+		case len(nested) == 0:
+			delete(s.Fields, k)
+		case nested.HasAll():
+		case v.GetStructValue() != nil:
+			filterStruct(v.GetStructValue(), nested)
+		case v.GetListValue() != nil:
+			for _, elem := range v.GetListValue().GetValues() {
+				if elem.GetStructValue() != nil {
+					filterStruct(elem.GetStructValue(), nested)
+				}
+			}
+		default:
+			delete(s.Fields, k)
+		}
+	}
+}
+
+// wellKnownScalar reports whether the message is a well-known type that's
+// output as a single value in JSON, such as a wrapper or a timestamp
+func wellKnownScalar(md protoreflect.MessageDescriptor) bool {
+	if md.ParentFile().Package() != "google.protobuf" {
+		return false
+	}
+	switch md.Name() {
+	case "Struct", "Value", "ListValue":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestFilterOutputFields(t *testing.T) {
+	attrs, err := structpb.NewStruct(map[string]interface{}{
+		"default_port": 22,
+		"other":        map[string]interface{}{"a": "b", "c": "d"},
+	})
+	require.NoError(t, err)
+	full := &pb.Target{
+		Id:   "ttcp_1234567890",
+		Name: wrapperspb.String("name"),
+		Scope: &scopes.ScopeInfo{
+			Id:          "p_1234567890",
+			Type:        "project",
+			Name:        "proj",
+			Description: "secret",
+		},
+		HostSets: []*pb.HostSet{
+			{Id: "hsst_1", HostCatalogId: "hcst_1"},
+			{Id: "hsst_2", HostCatalogId: "hcst_2"},
+		},
+		Labels:     map[string]string{"env": "prod", "team": "db"},
+		Attributes: attrs,
+	}
+
+	cases := []struct {
+		name   string
+		fields perms.OutputFieldsMap
+		want   func(*pb.Target)
+	}{
+		{
+			name:   "all",
+			fields: perms.OutputFieldsMap{"*": true},
+			want:   func(*pb.Target) {},
+		},
+		{
+			name:   "top level",
+			fields: perms.OutputFieldsMap{"id": true, "scope": true},
+			want: func(t *pb.Target) {
+				t.Name = nil
+				t.HostSets = nil
+				t.Labels = nil
+				t.Attributes = nil
+			},
+		},
+		{
+			name: "nested message",
+			fields: perms.OutputFieldsMap{
+				"id": true, "name": true, "host_sets": true, "labels": true, "attributes": true,
+				"scope.id": true, "scope.type": true, "scope.name": true,
+			},
+			want: func(t *pb.Target) {
+				t.Scope.Description = ""
+			},
+		},
+		{
+			name:   "nested list and map",
+			fields: perms.OutputFieldsMap{"id": true, "host_sets.id": true, "labels.env": true},
+			want: func(t *pb.Target) {
+				t.Name = nil
+				t.Scope = nil
+				t.Attributes = nil
+				t.HostSets = []*pb.HostSet{{Id: "hsst_1"}, {Id: "hsst_2"}}
+				t.Labels = map[string]string{"env": "prod"}
+			},
+		},
+		{
+			name:   "nested struct",
+			fields: perms.OutputFieldsMap{"id": true, "attributes.other.a": true},
+			want: func(t *pb.Target) {
+				t.Name = nil
+				t.Scope = nil
+				t.HostSets = nil
+				t.Labels = nil
+				delete(t.Attributes.Fields, "default_port")
+				delete(t.Attributes.Fields["other"].GetStructValue().Fields, "c")
+			},
+		},
+		{
+			name:   "wildcard segment",
+			fields: perms.OutputFieldsMap{"*.id": true},
+			want: func(t *pb.Target) {
+				t.Id = ""
+				t.Name = nil
+				t.Labels = nil
+				t.Attributes = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+				t.Scope = &scopes.ScopeInfo{Id: "p_1234567890"}
+				t.HostSets = []*pb.HostSet{{Id: "hsst_1"}, {Id: "hsst_2"}}
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := proto.Clone(full).(*pb.Target)
+			want := proto.Clone(full).(*pb.Target)
+			tc.want(want)
+			FilterOutputFields(got, tc.fields)
+			assert.Empty(t, cmp.Diff(want, got, protocmp.Transform()))
+		})
+	}
+}
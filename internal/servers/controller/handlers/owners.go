@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+)
+
+// SetResourceOwner makes the user the owner of the target, host set, host or
+// group in the scope and returns the ID of the owner.
+func SetResourceOwner(ctx context.Context, iamRepoFn common.IamRepoFactory, scopeId, resourceId, ownerId string) (string, error) {
+	const op = "handlers.SetResourceOwner"
+	repo, err := iamRepoFn()
+	if err != nil {
+		return "", err
+	}
+	out, err := repo.SetResourceOwner(ctx, scopeId, resourceId, ownerId)
+	if err != nil {
+		return "", errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to set owner of %s", resourceId)))
+	}
+	return out.GetOwnerId(), nil
+}
@@ -40,6 +40,9 @@ var (
 		action.AddGrants,
 		action.SetGrants,
 		action.RemoveGrants,
+		action.AddGrantScopes,
+		action.SetGrantScopes,
+		action.RemoveGrantScopes,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -137,7 +140,7 @@ func (s Service) ListRoles(ctx context.Context, req *pbs.ListRolesRequest) (*pbs
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, nil, nil, outputOpts...)
+		item, err := toProto(ctx, item, nil, nil, nil, outputOpts...)
 		if err != nil {
 			return nil, err
 		}
@@ -160,7 +163,7 @@ func (s Service) GetRole(ctx context.Context, req *pbs.GetRoleRequest) (*pbs.Get
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.getFromRepo(ctx, req.GetId())
+	r, prs, rgs, rgss, err := s.getFromRepo(ctx, req.GetId())
 	if err != nil {
 		return nil, err
 	}
@@ -179,7 +182,7 @@ func (s Service) GetRole(ctx context.Context, req *pbs.GetRoleRequest) (*pbs.Get
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -217,7 +220,7 @@ func (s Service) CreateRole(ctx context.Context, req *pbs.CreateRoleRequest) (*p
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, nil, nil, outputOpts...)
+	item, err := toProto(ctx, r, nil, nil, nil, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +239,7 @@ func (s Service) UpdateRole(ctx context.Context, req *pbs.UpdateRoleRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	r, prs, rgs, rgss, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
 	if err != nil {
 		return nil, err
 	}
@@ -255,7 +258,7 @@ func (s Service) UpdateRole(ctx context.Context, req *pbs.UpdateRoleRequest) (*p
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -290,7 +293,7 @@ func (s Service) AddRolePrincipals(ctx context.Context, req *pbs.AddRolePrincipa
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.addPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	r, prs, rgs, rgss, err := s.addPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -309,7 +312,7 @@ func (s Service) AddRolePrincipals(ctx context.Context, req *pbs.AddRolePrincipa
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -328,7 +331,7 @@ func (s Service) SetRolePrincipals(ctx context.Context, req *pbs.SetRolePrincipa
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.setPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	r, prs, rgs, rgss, err := s.setPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -347,7 +350,7 @@ func (s Service) SetRolePrincipals(ctx context.Context, req *pbs.SetRolePrincipa
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -366,7 +369,7 @@ func (s Service) RemoveRolePrincipals(ctx context.Context, req *pbs.RemoveRolePr
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.removePrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	r, prs, rgs, rgss, err := s.removePrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -385,7 +388,7 @@ func (s Service) RemoveRolePrincipals(ctx context.Context, req *pbs.RemoveRolePr
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -404,7 +407,7 @@ func (s Service) AddRoleGrants(ctx context.Context, req *pbs.AddRoleGrantsReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.addGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
+	r, prs, rgs, rgss, err := s.addGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -423,7 +426,7 @@ func (s Service) AddRoleGrants(ctx context.Context, req *pbs.AddRoleGrantsReques
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -442,7 +445,7 @@ func (s Service) SetRoleGrants(ctx context.Context, req *pbs.SetRoleGrantsReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.setGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
+	r, prs, rgs, rgss, err := s.setGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -461,7 +464,7 @@ func (s Service) SetRoleGrants(ctx context.Context, req *pbs.SetRoleGrantsReques
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -480,7 +483,7 @@ func (s Service) RemoveRoleGrants(ctx context.Context, req *pbs.RemoveRoleGrants
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.removeGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
+	r, prs, rgs, rgss, err := s.removeGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -499,7 +502,7 @@ func (s Service) RemoveRoleGrants(ctx context.Context, req *pbs.RemoveRoleGrants
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -507,22 +510,140 @@ func (s Service) RemoveRoleGrants(ctx context.Context, req *pbs.RemoveRoleGrants
 	return &pbs.RemoveRoleGrantsResponse{Item: item}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, error) {
+// AddRoleGrantScopes implements the interface pbs.RoleServiceServer.
+func (s Service) AddRoleGrantScopes(ctx context.Context, req *pbs.AddRoleGrantScopesRequest) (*pbs.AddRoleGrantScopesResponse, error) {
+	const op = "roles.(Service).AddRoleGrantScopes"
+
+	if err := validateAddRoleGrantScopesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddGrantScopes)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	r, prs, rgs, rgss, err := s.addGrantScopesInRepo(ctx, req.GetId(), req.GetGrantScopeIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.AddRoleGrantScopesResponse{Item: item}, nil
+}
+
+// SetRoleGrantScopes implements the interface pbs.RoleServiceServer.
+func (s Service) SetRoleGrantScopes(ctx context.Context, req *pbs.SetRoleGrantScopesRequest) (*pbs.SetRoleGrantScopesResponse, error) {
+	const op = "roles.(Service).SetRoleGrantScopes"
+
+	if err := validateSetRoleGrantScopesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetGrantScopes)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	r, prs, rgs, rgss, err := s.setGrantScopesInRepo(ctx, req.GetId(), req.GetGrantScopeIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetRoleGrantScopesResponse{Item: item}, nil
+}
+
+// RemoveRoleGrantScopes implements the interface pbs.RoleServiceServer.
+func (s Service) RemoveRoleGrantScopes(ctx context.Context, req *pbs.RemoveRoleGrantScopesRequest) (*pbs.RemoveRoleGrantScopesResponse, error) {
+	const op = "roles.(Service).RemoveRoleGrantScopes"
+
+	if err := validateRemoveRoleGrantScopesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveGrantScopes)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	r, prs, rgs, rgss, err := s.removeGrantScopesInRepo(ctx, req.GetId(), req.GetGrantScopeIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RemoveRoleGrantScopesResponse{Item: item}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	out, pr, roleGrants, err := repo.LookupRole(ctx, id)
 	if err != nil {
 		if errors.IsNotFoundError(err) {
-			return nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist.", id)
+			return nil, nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist.", id)
 		}
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist.", id)
+		return nil, nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist.", id)
+	}
+	roleGrantScopes, err := repo.ListRoleGrantScopes(ctx, out.GetPublicId())
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
-	return out, pr, roleGrants, nil
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
 func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role) (*iam.Role, error) {
@@ -555,7 +676,7 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role
 	return out, nil
 }
 
-func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Role) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Role) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).updateInRepo"
 	var opts []iam.Option
 	if desc := item.GetDescription(); desc != nil {
@@ -571,25 +692,29 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 
 	u, err := iam.NewRole(scopeId, opts...)
 	if err != nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build role for update: %v.", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build role for update: %v.", err)
 	}
 	u.PublicId = id
 	dbMask := maskManager.Translate(mask)
 	if len(dbMask) == 0 {
-		return nil, nil, nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+		return nil, nil, nil, nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
 	}
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	out, pr, gr, rowsUpdated, err := repo.UpdateRole(ctx, u, version, dbMask)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, op)
+		return nil, nil, nil, nil, errors.Wrap(err, op)
 	}
 	if rowsUpdated == 0 {
-		return nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist or incorrect version provided.", id)
+		return nil, nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist or incorrect version provided.", id)
+	}
+	roleGrantScopes, err := repo.ListRoleGrantScopes(ctx, id)
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
-	return out, pr, gr, nil
+	return out, pr, gr, roleGrantScopes, nil
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
@@ -620,95 +745,111 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.Ro
 	return rl, nil
 }
 
-func (s Service) addPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) addPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).addPrincpleInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	_, err = repo.AddPrincipalRoles(ctx, roleId, version, strutil.RemoveDuplicates(principalIds, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add principals to role: %v.", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add principals to role: %v.", err)
 	}
 	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after adding principals"))
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after adding principals"))
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding principals to it.")
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding principals to it.")
 	}
-	return out, pr, roleGrants, nil
+	roleGrantScopes, err := repo.ListRoleGrantScopes(ctx, out.GetPublicId())
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
-func (s Service) setPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) setPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).setPrinciplesInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	_, _, err = repo.SetPrincipalRoles(ctx, roleId, version, strutil.RemoveDuplicates(principalIds, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set principals on role: %v.", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set principals on role: %v.", err)
 	}
 	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after setting principals"))
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after setting principals"))
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting principals for it.")
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting principals for it.")
+	}
+	roleGrantScopes, err := repo.ListRoleGrantScopes(ctx, out.GetPublicId())
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
-	return out, pr, roleGrants, nil
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
-func (s Service) removePrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) removePrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).removePrinciplesInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	_, err = repo.DeletePrincipalRoles(ctx, roleId, version, strutil.RemoveDuplicates(principalIds, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove principals from role: %v.", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove principals from role: %v.", err)
 	}
 	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after removing principals"))
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after removing principals"))
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing principals from it.")
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing principals from it.")
 	}
-	return out, pr, roleGrants, nil
+	roleGrantScopes, err := repo.ListRoleGrantScopes(ctx, out.GetPublicId())
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
-func (s Service) addGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) addGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "service.(Service).addGrantsInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	_, err = repo.AddRoleGrants(ctx, roleId, version, strutil.RemoveDuplicates(grants, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add grants to role: %v.", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add grants to role: %v.", err)
 	}
 	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after adding grants"))
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after adding grants"))
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding grants to it.")
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding grants to it.")
+	}
+	roleGrantScopes, err := repo.ListRoleGrantScopes(ctx, out.GetPublicId())
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
-	return out, pr, roleGrants, nil
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
-func (s Service) setGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) setGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).setGrantsInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	// If no grant was provided, we clear the grants.
 	if grants == nil {
@@ -717,37 +858,124 @@ func (s Service) setGrantsInRepo(ctx context.Context, roleId string, grants []st
 	_, _, err = repo.SetRoleGrants(ctx, roleId, version, strutil.RemoveDuplicates(grants, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set grants on role: %v.", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set grants on role: %v.", err)
 	}
 	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after setting grants"))
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after setting grants"))
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting grants on it.")
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting grants on it.")
+	}
+	roleGrantScopes, err := repo.ListRoleGrantScopes(ctx, out.GetPublicId())
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
-	return out, pr, roleGrants, nil
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
-func (s Service) removeGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) removeGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).removeGrantsInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	_, err = repo.DeleteRoleGrants(ctx, roleId, version, strutil.RemoveDuplicates(grants, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove grants from role: %v", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove grants from role: %v", err)
 	}
 	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("uable to look up role after removing grant"))
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("uable to look up role after removing grant"))
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing grants from it.")
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing grants from it.")
 	}
-	return out, pr, roleGrants, nil
+	roleGrantScopes, err := repo.ListRoleGrantScopes(ctx, out.GetPublicId())
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return out, pr, roleGrants, roleGrantScopes, nil
+}
+
+func (s Service) addGrantScopesInRepo(ctx context.Context, roleId string, grantScopes []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
+	const op = "roles.(Service).addGrantScopesInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	_, err = repo.AddRoleGrantScopes(ctx, roleId, version, strutil.RemoveDuplicates(grantScopes, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add grant scopes to role: %v.", err)
+	}
+	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after adding grant scopes"))
+	}
+	if out == nil {
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding grant scopes to it.")
+	}
+	roleGrantScopes, err := repo.ListRoleGrantScopes(ctx, roleId)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to list grant scopes after adding grant scopes"))
+	}
+	return out, pr, roleGrants, roleGrantScopes, nil
+}
+
+func (s Service) setGrantScopesInRepo(ctx context.Context, roleId string, grantScopes []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
+	const op = "roles.(Service).setGrantScopesInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	// If no grant scope was provided, we clear the grant scopes.
+	if grantScopes == nil {
+		grantScopes = []string{}
+	}
+	_, _, err = repo.SetRoleGrantScopes(ctx, roleId, version, strutil.RemoveDuplicates(grantScopes, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set grant scopes on role: %v.", err)
+	}
+	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after setting grant scopes"))
+	}
+	if out == nil {
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting grant scopes on it.")
+	}
+	roleGrantScopes, err := repo.ListRoleGrantScopes(ctx, roleId)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to list grant scopes after setting grant scopes"))
+	}
+	return out, pr, roleGrants, roleGrantScopes, nil
+}
+
+func (s Service) removeGrantScopesInRepo(ctx context.Context, roleId string, grantScopes []string, version uint32) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
+	const op = "roles.(Service).removeGrantScopesInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	_, err = repo.DeleteRoleGrantScopes(ctx, roleId, version, strutil.RemoveDuplicates(grantScopes, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove grant scopes from role: %v", err)
+	}
+	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role after removing grant scopes"))
+	}
+	if out == nil {
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing grant scopes from it.")
+	}
+	roleGrantScopes, err := repo.ListRoleGrantScopes(ctx, roleId)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to list grant scopes after removing grant scopes"))
+	}
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
@@ -789,7 +1017,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func toProto(ctx context.Context, in *iam.Role, principals []iam.PrincipalRole, grants []*iam.RoleGrant, opt ...handlers.Option) (*pb.Role, error) {
+func toProto(ctx context.Context, in *iam.Role, principals []iam.PrincipalRole, grants []*iam.RoleGrant, grantScopes []*iam.RoleGrantScope, opt ...handlers.Option) (*pb.Role, error) {
 	opts := handlers.GetOpts(opt...)
 	if opts.WithOutputFields == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building role proto")
@@ -827,6 +1055,11 @@ func toProto(ctx context.Context, in *iam.Role, principals []iam.PrincipalRole,
 	if outputFields.Has(globals.GrantScopeIdField) && in.GetGrantScopeId() != "" {
 		out.GrantScopeId = &wrapperspb.StringValue{Value: in.GetGrantScopeId()}
 	}
+	if outputFields.Has(globals.GrantScopeIdsField) {
+		for _, gs := range grantScopes {
+			out.GrantScopeIds = append(out.GrantScopeIds, gs.GetScopeIdOrSpecial())
+		}
+	}
 	if outputFields.Has(globals.PrincipalIdsField) {
 		for _, p := range principals {
 			out.PrincipalIds = append(out.PrincipalIds, p.GetPrincipalId())
@@ -873,6 +1106,7 @@ func toProto(ctx context.Context, in *iam.Role, principals []iam.PrincipalRole,
 		}
 	}
 
+	handlers.FilterOutputFields(&out, outputFields)
 	return &out, nil
 }
 
@@ -1106,3 +1340,80 @@ func validateRemoveRoleGrantsRequest(req *pbs.RemoveRoleGrantsRequest) error {
 	}
 	return nil
 }
+
+func validateAddRoleGrantScopesRequest(req *pbs.AddRoleGrantScopesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.RolePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetGrantScopeIds()) == 0 {
+		badFields["grant_scope_ids"] = "Must be non-empty."
+	}
+	for _, v := range req.GetGrantScopeIds() {
+		if !validGrantScopeId(v) {
+			badFields["grant_scope_ids"] = fmt.Sprintf("Improperly formatted grant scope %q.", v)
+			break
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateSetRoleGrantScopesRequest(req *pbs.SetRoleGrantScopesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.RolePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	for _, v := range req.GetGrantScopeIds() {
+		if !validGrantScopeId(v) {
+			badFields["grant_scope_ids"] = fmt.Sprintf("Improperly formatted grant scope %q.", v)
+			break
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveRoleGrantScopesRequest(req *pbs.RemoveRoleGrantScopesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.RolePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetGrantScopeIds()) == 0 {
+		badFields["grant_scope_ids"] = "Must be non-empty."
+	}
+	for _, v := range req.GetGrantScopeIds() {
+		if !validGrantScopeId(v) {
+			badFields["grant_scope_ids"] = fmt.Sprintf("Improperly formatted grant scope %q.", v)
+			break
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+// validGrantScopeId reports whether the value is a scope ID or one of the
+// special grant scopes.
+func validGrantScopeId(v string) bool {
+	switch v {
+	case iam.GrantScopeThis, iam.GrantScopeChildren, iam.GrantScopeDescendants, scope.Global.String():
+		return true
+	}
+	return handlers.ValidId(handlers.Id(v), scope.Org.Prefix()) ||
+		handlers.ValidId(handlers.Id(v), scope.Project.Prefix())
+}
@@ -30,7 +30,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-principals", "set-principals", "remove-principals", "add-grants", "set-grants", "remove-grants"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-principals", "set-principals", "remove-principals", "add-grants", "set-grants", "remove-grants", "add-grant-scopes", "set-grant-scopes", "remove-grant-scopes"}
 
 func createDefaultRolesAndRepo(t *testing.T) (*iam.Role, *iam.Role, func() (*iam.Repository, error)) {
 	t.Helper()
@@ -1896,3 +1896,113 @@ func TestRemoveGrants(t *testing.T) {
 		})
 	}
 }
+
+func TestGrantScopes(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := roles.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new role service.")
+
+	o, p := iam.TestScopes(t, iamRepo)
+	role := iam.TestRole(t, conn, o.GetPublicId())
+	ctx := auth.DisabledAuthTestContext(repoFn, o.GetPublicId())
+
+	t.Run("add", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.AddRoleGrantScopes(ctx, &pbs.AddRoleGrantScopesRequest{
+			Id:            role.GetPublicId(),
+			Version:       1,
+			GrantScopeIds: []string{o.GetPublicId(), "children", "children"},
+		})
+		require.NoError(err)
+		assert.ElementsMatch([]string{"this", "children"}, got.GetItem().GetGrantScopeIds())
+		assert.Equal(uint32(2), got.GetItem().GetVersion())
+	})
+	t.Run("get", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.GetRole(ctx, &pbs.GetRoleRequest{Id: role.GetPublicId()})
+		require.NoError(err)
+		assert.ElementsMatch([]string{"this", "children"}, got.GetItem().GetGrantScopeIds())
+	})
+	t.Run("remove", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.RemoveRoleGrantScopes(ctx, &pbs.RemoveRoleGrantScopesRequest{
+			Id:            role.GetPublicId(),
+			Version:       2,
+			GrantScopeIds: []string{"children"},
+		})
+		require.NoError(err)
+		assert.Equal([]string{"this"}, got.GetItem().GetGrantScopeIds())
+	})
+	t.Run("set", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.SetRoleGrantScopes(ctx, &pbs.SetRoleGrantScopesRequest{
+			Id:            role.GetPublicId(),
+			Version:       3,
+			GrantScopeIds: []string{p.GetPublicId()},
+		})
+		require.NoError(err)
+		assert.Equal([]string{p.GetPublicId()}, got.GetItem().GetGrantScopeIds())
+
+		got, err = s.SetRoleGrantScopes(ctx, &pbs.SetRoleGrantScopesRequest{
+			Id:      role.GetPublicId(),
+			Version: 4,
+		})
+		require.NoError(err)
+		assert.Empty(got.GetItem().GetGrantScopeIds())
+	})
+
+	failCases := []struct {
+		name string
+		req  *pbs.AddRoleGrantScopesRequest
+		err  error
+	}{
+		{
+			name: "Bad Version",
+			req: &pbs.AddRoleGrantScopesRequest{
+				Id:            role.GetPublicId(),
+				GrantScopeIds: []string{"this"},
+				Version:       role.GetVersion() + 10,
+			},
+			err: handlers.ApiErrorWithCode(codes.Internal),
+		},
+		{
+			name: "No Grant Scopes",
+			req: &pbs.AddRoleGrantScopesRequest{
+				Id:      role.GetPublicId(),
+				Version: 5,
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Unknown Special Grant Scope",
+			req: &pbs.AddRoleGrantScopesRequest{
+				Id:            role.GetPublicId(),
+				GrantScopeIds: []string{"everything"},
+				Version:       5,
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Descendants Of Org Role",
+			req: &pbs.AddRoleGrantScopesRequest{
+				Id:            role.GetPublicId(),
+				GrantScopeIds: []string{"descendants"},
+				Version:       5,
+			},
+			err: handlers.ApiErrorWithCode(codes.Internal),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.AddRoleGrantScopes(ctx, tc.req)
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, tc.err), "AddRoleGrantScopes(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+		})
+	}
+}
@@ -0,0 +1,1068 @@
+package roletemplates
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/errors"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/roletemplates"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.AddPrincipals,
+		action.SetPrincipals,
+		action.RemovePrincipals,
+		action.AddGrants,
+		action.SetGrants,
+		action.RemoveGrants,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.Create,
+		action.List,
+	}
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(&store.RoleTemplate{}, &pb.RoleTemplate{}); err != nil {
+		panic(err)
+	}
+}
+
+// Service handles request as described by the pbs.RoleTemplateServiceServer
+// interface.
+type Service struct {
+	pbs.UnimplementedRoleTemplateServiceServer
+
+	repoFn common.IamRepoFactory
+}
+
+// NewService returns a role template service which handles role template
+// related requests to boundary.
+func NewService(repo common.IamRepoFactory) (Service, error) {
+	const op = "roletemplates.NewService"
+	if repo == nil {
+		return Service{}, errors.New(errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{repoFn: repo}, nil
+}
+
+var _ pbs.RoleTemplateServiceServer = Service{}
+
+// ListRoleTemplates implements the interface pbs.RoleTemplateServiceServer.
+func (s Service) ListRoleTemplates(ctx context.Context, req *pbs.ListRoleTemplatesRequest) (*pbs.ListRoleTemplatesResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes.
+		if authResults.Error == handlers.ForbiddenError() &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.repoFn, authResults, req.GetScopeId(), resource.RoleTemplate, req.GetRecursive(), false)
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListRoleTemplatesResponse{}, nil
+	}
+
+	items, err := s.listFromRepo(ctx, scopeIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return &pbs.ListRoleTemplatesResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.RoleTemplate, 0, len(items))
+	res := perms.Resource{
+		Type: resource.RoleTemplate,
+	}
+	for _, item := range items {
+		res.Id = item.GetPublicId()
+		res.ScopeId = item.GetScopeId()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			continue
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		item, err := toProto(ctx, item, nil, nil, nil, outputOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListRoleTemplatesResponse{Items: finalItems}, nil
+}
+
+// GetRoleTemplate implements the interface pbs.RoleTemplateServiceServer.
+func (s Service) GetRoleTemplate(ctx context.Context, req *pbs.GetRoleTemplateRequest) (*pbs.GetRoleTemplateResponse, error) {
+	const op = "roletemplates.(Service).GetRoleTemplate"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	t, tps, tgs, trs, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, t, tps, tgs, trs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetRoleTemplateResponse{Item: item}, nil
+}
+
+// CreateRoleTemplate implements the interface pbs.RoleTemplateServiceServer.
+func (s Service) CreateRoleTemplate(ctx context.Context, req *pbs.CreateRoleTemplateRequest) (*pbs.CreateRoleTemplateResponse, error) {
+	const op = "roletemplates.(Service).CreateRoleTemplate"
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	t, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, t, nil, nil, nil, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.CreateRoleTemplateResponse{Item: item, Uri: fmt.Sprintf("role-templates/%s", item.GetId())}, nil
+}
+
+// UpdateRoleTemplate implements the interface pbs.RoleTemplateServiceServer.
+func (s Service) UpdateRoleTemplate(ctx context.Context, req *pbs.UpdateRoleTemplateRequest) (*pbs.UpdateRoleTemplateResponse, error) {
+	const op = "roletemplates.(Service).UpdateRoleTemplate"
+
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	t, tps, tgs, trs, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, t, tps, tgs, trs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.UpdateRoleTemplateResponse{Item: item}, nil
+}
+
+// DeleteRoleTemplate implements the interface pbs.RoleTemplateServiceServer.
+func (s Service) DeleteRoleTemplate(ctx context.Context, req *pbs.DeleteRoleTemplateRequest) (*pbs.DeleteRoleTemplateResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// AddRoleTemplatePrincipals implements the interface pbs.RoleTemplateServiceServer.
+func (s Service) AddRoleTemplatePrincipals(ctx context.Context, req *pbs.AddRoleTemplatePrincipalsRequest) (*pbs.AddRoleTemplatePrincipalsResponse, error) {
+	const op = "roletemplates.(Service).AddRoleTemplatePrincipals"
+
+	if err := validateAddRoleTemplatePrincipalsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddPrincipals)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	t, tps, tgs, trs, err := s.addPrincipalsInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, t, tps, tgs, trs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.AddRoleTemplatePrincipalsResponse{Item: item}, nil
+}
+
+// SetRoleTemplatePrincipals implements the interface pbs.RoleTemplateServiceServer.
+func (s Service) SetRoleTemplatePrincipals(ctx context.Context, req *pbs.SetRoleTemplatePrincipalsRequest) (*pbs.SetRoleTemplatePrincipalsResponse, error) {
+	const op = "roletemplates.(Service).SetRoleTemplatePrincipals"
+
+	if err := validateSetRoleTemplatePrincipalsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetPrincipals)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	t, tps, tgs, trs, err := s.setPrincipalsInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, t, tps, tgs, trs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetRoleTemplatePrincipalsResponse{Item: item}, nil
+}
+
+// RemoveRoleTemplatePrincipals implements the interface pbs.RoleTemplateServiceServer.
+func (s Service) RemoveRoleTemplatePrincipals(ctx context.Context, req *pbs.RemoveRoleTemplatePrincipalsRequest) (*pbs.RemoveRoleTemplatePrincipalsResponse, error) {
+	const op = "roletemplates.(Service).RemoveRoleTemplatePrincipals"
+
+	if err := validateRemoveRoleTemplatePrincipalsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemovePrincipals)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	t, tps, tgs, trs, err := s.removePrincipalsInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, t, tps, tgs, trs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RemoveRoleTemplatePrincipalsResponse{Item: item}, nil
+}
+
+// AddRoleTemplateGrants implements the interface pbs.RoleTemplateServiceServer.
+func (s Service) AddRoleTemplateGrants(ctx context.Context, req *pbs.AddRoleTemplateGrantsRequest) (*pbs.AddRoleTemplateGrantsResponse, error) {
+	const op = "roletemplates.(Service).AddRoleTemplateGrants"
+
+	if err := validateAddRoleTemplateGrantsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddGrants)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	t, tps, tgs, trs, err := s.addGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, t, tps, tgs, trs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.AddRoleTemplateGrantsResponse{Item: item}, nil
+}
+
+// SetRoleTemplateGrants implements the interface pbs.RoleTemplateServiceServer.
+func (s Service) SetRoleTemplateGrants(ctx context.Context, req *pbs.SetRoleTemplateGrantsRequest) (*pbs.SetRoleTemplateGrantsResponse, error) {
+	const op = "roletemplates.(Service).SetRoleTemplateGrants"
+
+	if err := validateSetRoleTemplateGrantsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetGrants)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	t, tps, tgs, trs, err := s.setGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, t, tps, tgs, trs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetRoleTemplateGrantsResponse{Item: item}, nil
+}
+
+// RemoveRoleTemplateGrants implements the interface pbs.RoleTemplateServiceServer.
+func (s Service) RemoveRoleTemplateGrants(ctx context.Context, req *pbs.RemoveRoleTemplateGrantsRequest) (*pbs.RemoveRoleTemplateGrantsResponse, error) {
+	const op = "roletemplates.(Service).RemoveRoleTemplateGrants"
+
+	if err := validateRemoveRoleTemplateGrantsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveGrants)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	t, tps, tgs, trs, err := s.removeGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, t, tps, tgs, trs, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RemoveRoleTemplateGrantsResponse{Item: item}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*iam.RoleTemplate, []*iam.RoleTemplatePrincipal, []*iam.RoleTemplateGrant, []*iam.RoleTemplateRole, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	out, tps, tgs, err := repo.LookupRoleTemplate(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil, nil, nil, handlers.NotFoundErrorf("Role template %q doesn't exist.", id)
+		}
+		return nil, nil, nil, nil, err
+	}
+	if out == nil {
+		return nil, nil, nil, nil, handlers.NotFoundErrorf("Role template %q doesn't exist.", id)
+	}
+	trs, err := repo.ListRoleTemplateRoles(ctx, out.GetPublicId(), iam.WithLimit(-1))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return out, tps, tgs, trs, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.RoleTemplate) (*iam.RoleTemplate, error) {
+	const op = "roletemplates.(Service).createInRepo"
+	var opts []iam.Option
+	if item.GetName() != nil {
+		opts = append(opts, iam.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
+	}
+	t, err := iam.NewRoleTemplate(scopeId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build role template for creation: %v.", err)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateRoleTemplate(ctx, t)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create role template but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.RoleTemplate) (*iam.RoleTemplate, []*iam.RoleTemplatePrincipal, []*iam.RoleTemplateGrant, []*iam.RoleTemplateRole, error) {
+	const op = "roletemplates.(Service).updateInRepo"
+	var opts []iam.Option
+	if desc := item.GetDescription(); desc != nil {
+		opts = append(opts, iam.WithDescription(desc.GetValue()))
+	}
+	if name := item.GetName(); name != nil {
+		opts = append(opts, iam.WithName(name.GetValue()))
+	}
+	version := item.GetVersion()
+
+	t, err := iam.NewRoleTemplate(scopeId, opts...)
+	if err != nil {
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build role template for update: %v.", err)
+	}
+	t.PublicId = id
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, nil, nil, nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	out, tps, tgs, rowsUpdated, err := repo.UpdateRoleTemplate(ctx, t, version, dbMask)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, op)
+	}
+	if rowsUpdated == 0 {
+		return nil, nil, nil, nil, handlers.NotFoundErrorf("Role template %q doesn't exist or incorrect version provided.", id)
+	}
+	trs, err := repo.ListRoleTemplateRoles(ctx, id, iam.WithLimit(-1))
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return out, tps, tgs, trs, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	const op = "roletemplates.(Service).deleteFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteRoleTemplate(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, op, errors.WithMsg("unable to delete role template"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.RoleTemplate, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	tl, err := repo.ListRoleTemplates(ctx, scopeIds)
+	if err != nil {
+		return nil, err
+	}
+	return tl, nil
+}
+
+// The role template's principals and grants are only ever set as a whole in
+// the repository, as the instantiated roles are brought in line with them, so
+// adding and removing computes the resulting set from the current one. The
+// version ensures the current set didn't change in the meantime.
+
+func (s Service) addPrincipalsInRepo(ctx context.Context, roleTemplateId string, principalIds []string, version uint32) (*iam.RoleTemplate, []*iam.RoleTemplatePrincipal, []*iam.RoleTemplateGrant, []*iam.RoleTemplateRole, error) {
+	_, tps, _, _, err := s.getFromRepo(ctx, roleTemplateId)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	ids := make([]string, 0, len(tps)+len(principalIds))
+	for _, p := range tps {
+		ids = append(ids, p.GetPrincipalId())
+	}
+	ids = append(ids, principalIds...)
+	return s.setPrincipalsInRepo(ctx, roleTemplateId, ids, version)
+}
+
+func (s Service) setPrincipalsInRepo(ctx context.Context, roleTemplateId string, principalIds []string, version uint32) (*iam.RoleTemplate, []*iam.RoleTemplatePrincipal, []*iam.RoleTemplateGrant, []*iam.RoleTemplateRole, error) {
+	const op = "roletemplates.(Service).setPrincipalsInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	_, _, err = repo.SetRoleTemplatePrincipals(ctx, roleTemplateId, version, strutil.RemoveDuplicates(principalIds, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set principals on role template: %v.", err)
+	}
+	out, tps, tgs, trs, err := s.getFromRepo(ctx, roleTemplateId)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role template after setting principals"))
+	}
+	return out, tps, tgs, trs, nil
+}
+
+func (s Service) removePrincipalsInRepo(ctx context.Context, roleTemplateId string, principalIds []string, version uint32) (*iam.RoleTemplate, []*iam.RoleTemplatePrincipal, []*iam.RoleTemplateGrant, []*iam.RoleTemplateRole, error) {
+	_, tps, _, _, err := s.getFromRepo(ctx, roleTemplateId)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	ids := make([]string, 0, len(tps))
+	for _, p := range tps {
+		if !strutil.StrListContains(principalIds, p.GetPrincipalId()) {
+			ids = append(ids, p.GetPrincipalId())
+		}
+	}
+	return s.setPrincipalsInRepo(ctx, roleTemplateId, ids, version)
+}
+
+func (s Service) addGrantsInRepo(ctx context.Context, roleTemplateId string, grants []string, version uint32) (*iam.RoleTemplate, []*iam.RoleTemplatePrincipal, []*iam.RoleTemplateGrant, []*iam.RoleTemplateRole, error) {
+	_, _, tgs, _, err := s.getFromRepo(ctx, roleTemplateId)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	current := make([]string, 0, len(tgs)+len(grants))
+	for _, g := range tgs {
+		current = append(current, g.GetRawGrant())
+	}
+	current = append(current, grants...)
+	return s.setGrantsInRepo(ctx, roleTemplateId, current, version)
+}
+
+func (s Service) setGrantsInRepo(ctx context.Context, roleTemplateId string, grants []string, version uint32) (*iam.RoleTemplate, []*iam.RoleTemplatePrincipal, []*iam.RoleTemplateGrant, []*iam.RoleTemplateRole, error) {
+	const op = "roletemplates.(Service).setGrantsInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	// If no grant was provided, we clear the grants.
+	if grants == nil {
+		grants = []string{}
+	}
+	_, _, err = repo.SetRoleTemplateGrants(ctx, roleTemplateId, version, strutil.RemoveDuplicates(grants, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set grants on role template: %v.", err)
+	}
+	out, tps, tgs, trs, err := s.getFromRepo(ctx, roleTemplateId)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to look up role template after setting grants"))
+	}
+	return out, tps, tgs, trs, nil
+}
+
+func (s Service) removeGrantsInRepo(ctx context.Context, roleTemplateId string, grants []string, version uint32) (*iam.RoleTemplate, []*iam.RoleTemplatePrincipal, []*iam.RoleTemplateGrant, []*iam.RoleTemplateRole, error) {
+	_, _, tgs, _, err := s.getFromRepo(ctx, roleTemplateId)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	remove := make(map[string]bool, len(grants))
+	for _, grant := range grants {
+		g, err := iam.NewRoleTemplateGrant(roleTemplateId, grant)
+		if err != nil {
+			return nil, nil, nil, nil, handlers.InvalidArgumentErrorf("Errors in provided fields.", map[string]string{"grant_strings": fmt.Sprintf("Improperly formatted grant %q.", grant)})
+		}
+		remove[g.GetCanonicalGrant()] = true
+	}
+	current := make([]string, 0, len(tgs))
+	for _, g := range tgs {
+		if !remove[g.GetCanonicalGrant()] {
+			current = append(current, g.GetRawGrant())
+		}
+	}
+	return s.setGrantsInRepo(ctx, roleTemplateId, current, version)
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+	repo, err := s.repoFn()
+	if err != nil {
+		res.Error = err
+		return res
+	}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.RoleTemplate), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		scp, err := repo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		t, _, _, err := repo.LookupRoleTemplate(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if t == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = t.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *iam.RoleTemplate, principals []*iam.RoleTemplatePrincipal, grants []*iam.RoleTemplateGrant, roles []*iam.RoleTemplateRole, opt ...handlers.Option) (*pb.RoleTemplate, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building role template proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.RoleTemplate{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	if outputFields.Has(globals.PrincipalIdsField) {
+		for _, p := range principals {
+			out.PrincipalIds = append(out.PrincipalIds, p.GetPrincipalId())
+		}
+	}
+	if outputFields.Has(globals.PrincipalsField) {
+		for _, p := range principals {
+			principal := &pb.Principal{
+				Id:      p.GetPrincipalId(),
+				Type:    p.GetType(),
+				ScopeId: p.GetPrincipalScopeId(),
+			}
+			out.Principals = append(out.Principals, principal)
+		}
+	}
+	if outputFields.Has(globals.GrantStringsField) {
+		for _, g := range grants {
+			out.GrantStrings = append(out.GrantStrings, g.GetRawGrant())
+		}
+	}
+	if outputFields.Has(globals.GrantsField) {
+		for _, g := range grants {
+			parsed, id, err := iam.ParseRoleTemplateGrant(g.GetRawGrant())
+			if err != nil {
+				// This should never happen as we validate on the way in, but let's
+				// return what we can since we are still returning the raw grant
+				out.Grants = append(out.Grants, &pb.Grant{
+					Raw:       g.GetRawGrant(),
+					Canonical: "<parse_error>",
+					Json:      nil,
+				})
+			} else {
+				_, actions := parsed.Actions()
+				out.Grants = append(out.Grants, &pb.Grant{
+					Raw:       g.GetRawGrant(),
+					Canonical: g.GetCanonicalGrant(),
+					Json: &pb.GrantJson{
+						Id:      id,
+						Type:    parsed.Type().String(),
+						Actions: actions,
+					},
+				})
+			}
+		}
+	}
+	if outputFields.Has(globals.RoleIdsField) {
+		for _, r := range roles {
+			out.RoleIds = append(out.RoleIds, r.GetRoleId())
+		}
+	}
+	handlers.FilterOutputFields(&out, outputFields)
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetRoleTemplateRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, iam.RoleTemplatePrefix)
+}
+
+func validateCreateRequest(req *pbs.CreateRoleTemplateRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if !handlers.ValidId(handlers.Id(item.GetScopeId()), scope.Org.Prefix()) &&
+			scope.Global.String() != item.GetScopeId() {
+			badFields["scope_id"] = "This field is missing or improperly formatted. Role templates can only be created in the global scope or an org."
+		}
+		if item.GetPrincipals() != nil {
+			badFields["principals"] = "This is a read only field."
+		}
+		if item.GetGrants() != nil {
+			badFields["grant_strings"] = "This is a read only field."
+		}
+		if item.GetRoleIds() != nil {
+			badFields["role_ids"] = "This is a read only field."
+		}
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateRoleTemplateRequest) error {
+	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if req.GetItem().GetPrincipalIds() != nil {
+			badFields["principal_ids"] = "This is a read only field and cannot be specified in an update request."
+		}
+		if req.GetItem().GetPrincipals() != nil {
+			badFields["principals"] = "This is a read only field and cannot be specified in an update request."
+		}
+		if req.GetItem().GetGrants() != nil {
+			badFields["grants"] = "This is a read only field and cannot be specified in an update request."
+		}
+		if req.GetItem().GetGrantStrings() != nil {
+			badFields["grant_strings"] = "This is a read only field and cannot be specified in an update request."
+		}
+		if req.GetItem().GetRoleIds() != nil {
+			badFields["role_ids"] = "This is a read only field and cannot be specified in an update request."
+		}
+		return badFields
+	}, iam.RoleTemplatePrefix)
+}
+
+func validateDeleteRequest(req *pbs.DeleteRoleTemplateRequest) error {
+	return handlers.ValidateDeleteRequest(func() map[string]string {
+		return nil
+	}, req, iam.RoleTemplatePrefix)
+}
+
+func validateListRequest(req *pbs.ListRoleTemplatesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Improperly formatted field."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateAddRoleTemplatePrincipalsRequest(req *pbs.AddRoleTemplatePrincipalsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.RoleTemplatePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetPrincipalIds()) == 0 {
+		badFields["principal_ids"] = "Must be non-empty."
+	}
+	validatePrincipalIds(req.GetPrincipalIds(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateSetRoleTemplatePrincipalsRequest(req *pbs.SetRoleTemplatePrincipalsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.RoleTemplatePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	validatePrincipalIds(req.GetPrincipalIds(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveRoleTemplatePrincipalsRequest(req *pbs.RemoveRoleTemplatePrincipalsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.RoleTemplatePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetPrincipalIds()) == 0 {
+		badFields["principal_ids"] = "Must be non-empty."
+	}
+	for _, id := range req.GetPrincipalIds() {
+		if !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) && !handlers.ValidId(handlers.Id(id), iam.UserPrefix) {
+			badFields["principal_ids"] = "Must only have valid group and/or user ids."
+			break
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateAddRoleTemplateGrantsRequest(req *pbs.AddRoleTemplateGrantsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.RoleTemplatePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetGrantStrings()) == 0 {
+		badFields["grant_strings"] = "Must be non-empty."
+	}
+	validateGrantStrings(req.GetGrantStrings(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateSetRoleTemplateGrantsRequest(req *pbs.SetRoleTemplateGrantsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.RoleTemplatePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	validateGrantStrings(req.GetGrantStrings(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveRoleTemplateGrantsRequest(req *pbs.RemoveRoleTemplateGrantsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.RoleTemplatePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetGrantStrings()) == 0 {
+		badFields["grant_strings"] = "Must be non-empty."
+	}
+	validateGrantStrings(req.GetGrantStrings(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validatePrincipalIds(ids []string, badFields map[string]string) {
+	for _, id := range ids {
+		if !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) && !handlers.ValidId(handlers.Id(id), iam.UserPrefix) {
+			badFields["principal_ids"] = "Must only have valid group and/or user ids."
+			return
+		}
+		if id == "u_recovery" {
+			badFields["principal_ids"] = "u_recovery cannot be assigned to a role template"
+			return
+		}
+	}
+}
+
+// validateGrantStrings parses the grants as they would be instantiated in a
+// project, so they may reference the role template parameters.
+func validateGrantStrings(grants []string, badFields map[string]string) {
+	for _, v := range grants {
+		if len(v) == 0 {
+			badFields["grant_strings"] = "Grant strings must not be empty."
+			return
+		}
+		if _, _, err := iam.ParseRoleTemplateGrant(v); err != nil {
+			badFields["grant_strings"] = fmt.Sprintf("Improperly formatted grant %q.", v)
+			return
+		}
+	}
+}
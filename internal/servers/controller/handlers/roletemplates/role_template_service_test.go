@@ -0,0 +1,843 @@
+package roletemplates_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	boundaryerrors "github.com/hashicorp/boundary/internal/errors"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/roletemplates"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roletemplates"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-principals", "set-principals", "remove-principals", "add-grants", "set-grants", "remove-grants"}
+
+func testRoleTemplate(t *testing.T, repo *iam.Repository, scopeId string, opt ...iam.Option) *iam.RoleTemplate {
+	t.Helper()
+	rt, err := iam.NewRoleTemplate(scopeId, opt...)
+	require.NoError(t, err)
+	rt, err = repo.CreateRoleTemplate(context.Background(), rt)
+	require.NoError(t, err)
+	return rt
+}
+
+// instantiatedRole returns the role instantiated from the role template in
+// the project.
+func instantiatedRole(t *testing.T, repo *iam.Repository, roleTemplateId, projectId string) (*iam.Role, []iam.PrincipalRole, []*iam.RoleGrant) {
+	t.Helper()
+	ctx := context.Background()
+	trs, err := repo.ListRoleTemplateRoles(ctx, roleTemplateId)
+	require.NoError(t, err)
+	for _, tr := range trs {
+		r, principals, grants, err := repo.LookupRole(ctx, tr.GetRoleId())
+		require.NoError(t, err)
+		if r.GetScopeId() == projectId {
+			return r, principals, grants
+		}
+	}
+	require.FailNow(t, "no role instantiated in project", projectId)
+	return nil, nil, nil
+}
+
+func principalIds(principals []iam.PrincipalRole) []string {
+	var ids []string
+	for _, p := range principals {
+		ids = append(ids, p.GetPrincipalId())
+	}
+	return ids
+}
+
+func grantStrings(grants []*iam.RoleGrant) []string {
+	var gs []string
+	for _, g := range grants {
+		gs = append(gs, g.GetCanonicalGrant())
+	}
+	return gs
+}
+
+func createDefaultRoleTemplateAndRepo(t *testing.T) (*iam.RoleTemplate, *iam.Scope, *iam.Scope, func() (*iam.Repository, error)) {
+	t.Helper()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+
+	o, p := iam.TestScopes(t, iamRepo)
+	rt := testRoleTemplate(t, iamRepo, o.GetPublicId(), iam.WithName("default"), iam.WithDescription("default"))
+	return rt, o, p, repoFn
+}
+
+func TestGet(t *testing.T) {
+	rt, o, p, repoFn := createDefaultRoleTemplateAndRepo(t)
+	repo, err := repoFn()
+	require.NoError(t, err)
+	role, _, _ := instantiatedRole(t, repo, rt.GetPublicId(), p.GetPublicId())
+
+	wantTemplate := &pb.RoleTemplate{
+		Id:                rt.GetPublicId(),
+		ScopeId:           rt.GetScopeId(),
+		Scope:             &scopes.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+		Name:              &wrapperspb.StringValue{Value: rt.GetName()},
+		Description:       &wrapperspb.StringValue{Value: rt.GetDescription()},
+		CreatedTime:       rt.CreateTime.GetTimestamp(),
+		UpdatedTime:       rt.UpdateTime.GetTimestamp(),
+		Version:           rt.GetVersion(),
+		RoleIds:           []string{role.GetPublicId()},
+		AuthorizedActions: testAuthorizedActions,
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.GetRoleTemplateRequest
+		res  *pbs.GetRoleTemplateResponse
+		err  error
+	}{
+		{
+			name: "Get an Existing Role Template",
+			req:  &pbs.GetRoleTemplateRequest{Id: rt.GetPublicId()},
+			res:  &pbs.GetRoleTemplateResponse{Item: wantTemplate},
+		},
+		{
+			name: "Get a non existant Role Template",
+			req:  &pbs.GetRoleTemplateRequest{Id: iam.RoleTemplatePrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetRoleTemplateRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "space in id",
+			req:  &pbs.GetRoleTemplateRequest{Id: iam.RoleTemplatePrefix + "_1 23456789"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := roletemplates.NewService(repoFn)
+			require.NoError(err, "Couldn't create new role template service.")
+
+			got, gErr := s.GetRoleTemplate(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetRoleTemplate(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "GetRoleTemplate(%q) got response\n%q, wanted\n%q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	oNoTemplates, _ := iam.TestScopes(t, iamRepo)
+	oWithTemplates, _ := iam.TestScopes(t, iamRepo)
+	var wantTemplates []*pb.RoleTemplate
+	for i := 0; i < 5; i++ {
+		rt := testRoleTemplate(t, iamRepo, oWithTemplates.GetPublicId())
+		wantTemplates = append(wantTemplates, &pb.RoleTemplate{
+			Id:                rt.GetPublicId(),
+			ScopeId:           rt.GetScopeId(),
+			Scope:             &scopes.ScopeInfo{Id: oWithTemplates.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+			CreatedTime:       rt.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       rt.GetUpdateTime().GetTimestamp(),
+			Version:           rt.GetVersion(),
+			AuthorizedActions: testAuthorizedActions,
+		})
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.ListRoleTemplatesRequest
+		res  *pbs.ListRoleTemplatesResponse
+		err  error
+	}{
+		{
+			name: "List Many Role Templates",
+			req:  &pbs.ListRoleTemplatesRequest{ScopeId: oWithTemplates.GetPublicId()},
+			res:  &pbs.ListRoleTemplatesResponse{Items: wantTemplates},
+		},
+		{
+			name: "List No Role Templates",
+			req:  &pbs.ListRoleTemplatesRequest{ScopeId: oNoTemplates.GetPublicId()},
+			res:  &pbs.ListRoleTemplatesResponse{},
+		},
+		{
+			name: "List global Role Templates recursively",
+			req:  &pbs.ListRoleTemplatesRequest{ScopeId: scope.Global.String(), Recursive: true},
+			res:  &pbs.ListRoleTemplatesResponse{Items: wantTemplates},
+		},
+		{
+			name: "Filter to One Role Template",
+			req:  &pbs.ListRoleTemplatesRequest{ScopeId: oWithTemplates.GetPublicId(), Filter: fmt.Sprintf(`"/item/id"==%q`, wantTemplates[1].GetId())},
+			res:  &pbs.ListRoleTemplatesResponse{Items: wantTemplates[1:2]},
+		},
+		{
+			name: "Project scope",
+			req:  &pbs.ListRoleTemplatesRequest{ScopeId: "p_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Filter Bad Format",
+			req:  &pbs.ListRoleTemplatesRequest{ScopeId: oWithTemplates.GetPublicId(), Filter: `"//id/"=="bad"`},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := roletemplates.NewService(repoFn)
+			require.NoError(err, "Couldn't create new role template service.")
+
+			got, gErr := s.ListRoleTemplates(auth.DisabledAuthTestContext(repoFn, tc.req.GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ListRoleTemplates(%q) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "ListRoleTemplates(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	rt, o, p, repoFn := createDefaultRoleTemplateAndRepo(t)
+	repo, err := repoFn()
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.CreateRoleTemplateRequest
+		res  *pbs.CreateRoleTemplateResponse
+		err  error
+	}{
+		{
+			name: "Create a valid Role Template",
+			req: &pbs.CreateRoleTemplateRequest{Item: &pb.RoleTemplate{
+				ScopeId:     o.GetPublicId(),
+				Name:        &wrapperspb.StringValue{Value: "name"},
+				Description: &wrapperspb.StringValue{Value: "desc"},
+			}},
+			res: &pbs.CreateRoleTemplateResponse{
+				Uri: fmt.Sprintf("role-templates/%s_", iam.RoleTemplatePrefix),
+				Item: &pb.RoleTemplate{
+					ScopeId:           o.GetPublicId(),
+					Scope:             &scopes.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+					Name:              &wrapperspb.StringValue{Value: "name"},
+					Description:       &wrapperspb.StringValue{Value: "desc"},
+					Version:           1,
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name: "Create a valid Global Role Template",
+			req: &pbs.CreateRoleTemplateRequest{Item: &pb.RoleTemplate{
+				ScopeId:     scope.Global.String(),
+				Name:        &wrapperspb.StringValue{Value: "name"},
+				Description: &wrapperspb.StringValue{Value: "desc"},
+			}},
+			res: &pbs.CreateRoleTemplateResponse{
+				Uri: fmt.Sprintf("role-templates/%s_", iam.RoleTemplatePrefix),
+				Item: &pb.RoleTemplate{
+					ScopeId:           scope.Global.String(),
+					Scope:             &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"},
+					Name:              &wrapperspb.StringValue{Value: "name"},
+					Description:       &wrapperspb.StringValue{Value: "desc"},
+					Version:           1,
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name: "Project scope",
+			req: &pbs.CreateRoleTemplateRequest{Item: &pb.RoleTemplate{
+				ScopeId: p.GetPublicId(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify Id",
+			req: &pbs.CreateRoleTemplateRequest{Item: &pb.RoleTemplate{
+				ScopeId: o.GetPublicId(),
+				Id:      iam.RoleTemplatePrefix + "_notallowed",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify Role Ids",
+			req: &pbs.CreateRoleTemplateRequest{Item: &pb.RoleTemplate{
+				ScopeId: o.GetPublicId(),
+				RoleIds: []string{"r_1234567890"},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := roletemplates.NewService(repoFn)
+			require.NoError(err, "Error when getting new role template service.")
+
+			got, gErr := s.CreateRoleTemplate(auth.DisabledAuthTestContext(repoFn, tc.req.GetItem().GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateRoleTemplate(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.True(strings.HasPrefix(got.GetUri(), tc.res.GetUri()))
+			assert.True(strings.HasPrefix(got.GetItem().GetId(), iam.RoleTemplatePrefix+"_"))
+
+			// Clear all values which are hard to compare against.
+			got.Uri, tc.res.Uri = "", ""
+			got.Item.Id, tc.res.Item.Id = "", ""
+			got.Item.CreatedTime, got.Item.UpdatedTime, tc.res.Item.CreatedTime, tc.res.Item.UpdatedTime = nil, nil, nil, nil
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "CreateRoleTemplate(%q) got response\n%q, wanted\n%q", tc.req, got, tc.res)
+		})
+	}
+
+	t.Run("Duplicate name in scope", func(t *testing.T) {
+		s, err := roletemplates.NewService(repoFn)
+		require.NoError(t, err)
+		_, err = s.CreateRoleTemplate(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), &pbs.CreateRoleTemplateRequest{Item: &pb.RoleTemplate{
+			ScopeId: o.GetPublicId(),
+			Name:    &wrapperspb.StringValue{Value: rt.GetName()},
+		}})
+		assert.True(t, boundaryerrors.Match(boundaryerrors.T(boundaryerrors.NotUnique), err), "got error %v", err)
+	})
+	t.Run("Role instantiated in project", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, err := roletemplates.NewService(repoFn)
+		require.NoError(err)
+		got, err := s.CreateRoleTemplate(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), &pbs.CreateRoleTemplateRequest{Item: &pb.RoleTemplate{
+			ScopeId: o.GetPublicId(),
+			Name:    &wrapperspb.StringValue{Value: "Administration"},
+		}})
+		require.NoError(err)
+		role, _, _ := instantiatedRole(t, repo, got.GetItem().GetId(), p.GetPublicId())
+		assert.Equal(fmt.Sprintf("Administration (%s)", got.GetItem().GetId()), role.GetName())
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	rt, o, p, repoFn := createDefaultRoleTemplateAndRepo(t)
+	repo, err := repoFn()
+	require.NoError(t, err)
+	s, err := roletemplates.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new role template service.")
+	version := rt.GetVersion()
+
+	cases := []struct {
+		name     string
+		req      *pbs.UpdateRoleTemplateRequest
+		wantName string
+		wantDesc string
+		err      error
+	}{
+		{
+			name: "Update an Existing Role Template",
+			req: &pbs.UpdateRoleTemplateRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"name", "description"}},
+				Item: &pb.RoleTemplate{
+					Name:        &wrapperspb.StringValue{Value: "new"},
+					Description: &wrapperspb.StringValue{Value: "desc"},
+				},
+			},
+			wantName: "new",
+			wantDesc: "desc",
+		},
+		{
+			name: "Rename to a default role name",
+			req: &pbs.UpdateRoleTemplateRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+				Item: &pb.RoleTemplate{
+					Name: &wrapperspb.StringValue{Value: "Default Grants"},
+				},
+			},
+			wantName: "Default Grants",
+			wantDesc: "desc",
+		},
+		{
+			name: "Unset description",
+			req: &pbs.UpdateRoleTemplateRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"description"}},
+				Item:       &pb.RoleTemplate{},
+			},
+			wantName: "Default Grants",
+		},
+		{
+			name: "No Update Mask",
+			req: &pbs.UpdateRoleTemplateRequest{
+				Item: &pb.RoleTemplate{
+					Name: &wrapperspb.StringValue{Value: "updated name"},
+				},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Only non-existant paths in Mask",
+			req: &pbs.UpdateRoleTemplateRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"nonexistant_field"}},
+				Item: &pb.RoleTemplate{
+					Name: &wrapperspb.StringValue{Value: "updated name"},
+				},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Cant specify Grant Strings",
+			req: &pbs.UpdateRoleTemplateRequest{
+				UpdateMask: &field_mask.FieldMask{Paths: []string{"grant_strings"}},
+				Item: &pb.RoleTemplate{
+					GrantStrings: []string{"id=*;type=*;actions=read"},
+				},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			tc.req.Id = rt.GetPublicId()
+			tc.req.Item.Version = version
+
+			got, gErr := s.UpdateRoleTemplate(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "UpdateRoleTemplate(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			version = got.GetItem().GetVersion()
+			assert.Equal(tc.wantName, got.GetItem().GetName().GetValue())
+			assert.Equal(tc.wantDesc, got.GetItem().GetDescription().GetValue())
+
+			// The instantiated role follows the template
+			role, _, _ := instantiatedRole(t, repo, rt.GetPublicId(), p.GetPublicId())
+			assert.Equal(fmt.Sprintf("%s (%s)", tc.wantName, rt.GetPublicId()), role.GetName())
+			if tc.wantDesc != "" {
+				assert.Equal(tc.wantDesc, role.GetDescription())
+			} else {
+				assert.Contains(role.GetDescription(), rt.GetPublicId())
+			}
+		})
+	}
+
+	t.Run("Wrong version", func(t *testing.T) {
+		_, err := s.UpdateRoleTemplate(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), &pbs.UpdateRoleTemplateRequest{
+			Id:         rt.GetPublicId(),
+			UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+			Item: &pb.RoleTemplate{
+				Name:    &wrapperspb.StringValue{Value: "wrong version"},
+				Version: version + 1,
+			},
+		})
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	rt, o, p, repoFn := createDefaultRoleTemplateAndRepo(t)
+	repo, err := repoFn()
+	require.NoError(t, err)
+	role, _, _ := instantiatedRole(t, repo, rt.GetPublicId(), p.GetPublicId())
+
+	s, err := roletemplates.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new role template service.")
+
+	cases := []struct {
+		name string
+		req  *pbs.DeleteRoleTemplateRequest
+		err  error
+	}{
+		{
+			name: "Delete an Existing Role Template",
+			req:  &pbs.DeleteRoleTemplateRequest{Id: rt.GetPublicId()},
+		},
+		{
+			name: "Delete bad role template id",
+			req:  &pbs.DeleteRoleTemplateRequest{Id: iam.RoleTemplatePrefix + "_doesntexis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad Role Template Id formatting",
+			req:  &pbs.DeleteRoleTemplateRequest{Id: "bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.DeleteRoleTemplate(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "DeleteRoleTemplate(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+		})
+	}
+
+	// The instantiated role is deleted with the template
+	got, _, _, err := repo.LookupRole(context.Background(), role.GetPublicId())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestPrincipals(t *testing.T) {
+	rt, o, p, repoFn := createDefaultRoleTemplateAndRepo(t)
+	repo, err := repoFn()
+	require.NoError(t, err)
+	s, err := roletemplates.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new role template service.")
+
+	user := iam.TestUser(t, repo, o.GetPublicId())
+	otherUser := iam.TestUser(t, repo, o.GetPublicId())
+	group, err := iam.NewGroup(o.GetPublicId())
+	require.NoError(t, err)
+	group, err = repo.CreateGroup(context.Background(), group)
+	require.NoError(t, err)
+
+	ctx := auth.DisabledAuthTestContext(repoFn, o.GetPublicId())
+	version := rt.GetVersion()
+	check := func(t *testing.T, item *pb.RoleTemplate, want []string) {
+		t.Helper()
+		version = item.GetVersion()
+		assert.ElementsMatch(t, want, item.GetPrincipalIds())
+		assert.Len(t, item.GetPrincipals(), len(want))
+		_, principals, _ := instantiatedRole(t, repo, rt.GetPublicId(), p.GetPublicId())
+		assert.ElementsMatch(t, want, principalIds(principals))
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		got, err := s.AddRoleTemplatePrincipals(ctx, &pbs.AddRoleTemplatePrincipalsRequest{
+			Id:           rt.GetPublicId(),
+			Version:      version,
+			PrincipalIds: []string{user.GetPublicId(), group.GetPublicId(), user.GetPublicId()},
+		})
+		require.NoError(t, err)
+		check(t, got.GetItem(), []string{user.GetPublicId(), group.GetPublicId()})
+	})
+	t.Run("Set", func(t *testing.T) {
+		got, err := s.SetRoleTemplatePrincipals(ctx, &pbs.SetRoleTemplatePrincipalsRequest{
+			Id:           rt.GetPublicId(),
+			Version:      version,
+			PrincipalIds: []string{otherUser.GetPublicId(), group.GetPublicId()},
+		})
+		require.NoError(t, err)
+		check(t, got.GetItem(), []string{otherUser.GetPublicId(), group.GetPublicId()})
+	})
+	t.Run("Remove", func(t *testing.T) {
+		got, err := s.RemoveRoleTemplatePrincipals(ctx, &pbs.RemoveRoleTemplatePrincipalsRequest{
+			Id:           rt.GetPublicId(),
+			Version:      version,
+			PrincipalIds: []string{group.GetPublicId()},
+		})
+		require.NoError(t, err)
+		check(t, got.GetItem(), []string{otherUser.GetPublicId()})
+	})
+	t.Run("Clear", func(t *testing.T) {
+		got, err := s.SetRoleTemplatePrincipals(ctx, &pbs.SetRoleTemplatePrincipalsRequest{
+			Id:      rt.GetPublicId(),
+			Version: version,
+		})
+		require.NoError(t, err)
+		check(t, got.GetItem(), nil)
+	})
+
+	failCases := []struct {
+		name string
+		req  *pbs.AddRoleTemplatePrincipalsRequest
+		err  error
+	}{
+		{
+			name: "Bad Role Template Id",
+			req: &pbs.AddRoleTemplatePrincipalsRequest{
+				Id:           "bad id",
+				Version:      version,
+				PrincipalIds: []string{user.GetPublicId()},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad Principal Id",
+			req: &pbs.AddRoleTemplatePrincipalsRequest{
+				Id:           rt.GetPublicId(),
+				Version:      version,
+				PrincipalIds: []string{"invalid"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "u_recovery Id",
+			req: &pbs.AddRoleTemplatePrincipalsRequest{
+				Id:           rt.GetPublicId(),
+				Version:      version,
+				PrincipalIds: []string{"u_recovery"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Empty Principal Ids",
+			req: &pbs.AddRoleTemplatePrincipalsRequest{
+				Id:      rt.GetPublicId(),
+				Version: version,
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.AddRoleTemplatePrincipals(ctx, tc.req)
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, tc.err), "AddRoleTemplatePrincipals(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+		})
+	}
+}
+
+func TestGrants(t *testing.T) {
+	rt, o, p, repoFn := createDefaultRoleTemplateAndRepo(t)
+	repo, err := repoFn()
+	require.NoError(t, err)
+	s, err := roletemplates.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new role template service.")
+
+	ctx := auth.DisabledAuthTestContext(repoFn, o.GetPublicId())
+	version := rt.GetVersion()
+	check := func(t *testing.T, item *pb.RoleTemplate, want, wantInstantiated []string) {
+		t.Helper()
+		version = item.GetVersion()
+		assert.ElementsMatch(t, want, item.GetGrantStrings())
+		assert.Len(t, item.GetGrants(), len(want))
+		_, _, grants := instantiatedRole(t, repo, rt.GetPublicId(), p.GetPublicId())
+		assert.ElementsMatch(t, wantInstantiated, grantStrings(grants))
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		got, err := s.AddRoleTemplateGrants(ctx, &pbs.AddRoleTemplateGrantsRequest{
+			Id:           rt.GetPublicId(),
+			Version:      version,
+			GrantStrings: []string{"id={{scope.id}};actions=read", "id=*;type=target;actions=read"},
+		})
+		require.NoError(t, err)
+		check(t, got.GetItem(),
+			[]string{"id={{scope.id}};actions=read", "id=*;type=target;actions=read"},
+			[]string{"id=" + p.GetPublicId() + ";actions=read", "id=*;type=target;actions=read"})
+	})
+	t.Run("Set", func(t *testing.T) {
+		got, err := s.SetRoleTemplateGrants(ctx, &pbs.SetRoleTemplateGrantsRequest{
+			Id:           rt.GetPublicId(),
+			Version:      version,
+			GrantStrings: []string{"id={{scope.parent_id}};actions=read", "id=*;type=target;actions=read"},
+		})
+		require.NoError(t, err)
+		check(t, got.GetItem(),
+			[]string{"id={{scope.parent_id}};actions=read", "id=*;type=target;actions=read"},
+			[]string{"id=" + o.GetPublicId() + ";actions=read", "id=*;type=target;actions=read"})
+	})
+	t.Run("Remove", func(t *testing.T) {
+		got, err := s.RemoveRoleTemplateGrants(ctx, &pbs.RemoveRoleTemplateGrantsRequest{
+			Id:           rt.GetPublicId(),
+			Version:      version,
+			GrantStrings: []string{"id={{scope.parent_id}};actions=read"},
+		})
+		require.NoError(t, err)
+		check(t, got.GetItem(),
+			[]string{"id=*;type=target;actions=read"},
+			[]string{"id=*;type=target;actions=read"})
+	})
+	t.Run("Clear", func(t *testing.T) {
+		got, err := s.SetRoleTemplateGrants(ctx, &pbs.SetRoleTemplateGrantsRequest{
+			Id:      rt.GetPublicId(),
+			Version: version,
+		})
+		require.NoError(t, err)
+		check(t, got.GetItem(), nil, nil)
+	})
+
+	// A new project gets a role with the template's grants instantiated for
+	// it
+	t.Run("New project", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := s.SetRoleTemplateGrants(ctx, &pbs.SetRoleTemplateGrantsRequest{
+			Id:           rt.GetPublicId(),
+			Version:      version,
+			GrantStrings: []string{"id={{scope.id}};actions=read"},
+		})
+		require.NoError(err)
+		newProj, err := iam.NewProject(o.GetPublicId())
+		require.NoError(err)
+		newProj, err = repo.CreateScope(context.Background(), newProj, "")
+		require.NoError(err)
+		role, _, grants := instantiatedRole(t, repo, rt.GetPublicId(), newProj.GetPublicId())
+		assert.Equal(fmt.Sprintf("%s (%s)", rt.GetName(), rt.GetPublicId()), role.GetName())
+		assert.Equal([]string{"id=" + newProj.GetPublicId() + ";actions=read"}, grantStrings(grants))
+	})
+
+	failCases := []struct {
+		name string
+		req  *pbs.AddRoleTemplateGrantsRequest
+		err  error
+	}{
+		{
+			name: "Bad Role Template Id",
+			req: &pbs.AddRoleTemplateGrantsRequest{
+				Id:           "bad id",
+				Version:      version,
+				GrantStrings: []string{"id=*;type=*;actions=read"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Unparseable Grant",
+			req: &pbs.AddRoleTemplateGrantsRequest{
+				Id:           rt.GetPublicId(),
+				Version:      version,
+				GrantStrings: []string{"id=*;type=*;actions=read;foo=bar"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Empty Grant",
+			req: &pbs.AddRoleTemplateGrantsRequest{
+				Id:           rt.GetPublicId(),
+				Version:      version,
+				GrantStrings: []string{""},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "No Grants",
+			req: &pbs.AddRoleTemplateGrantsRequest{
+				Id:      rt.GetPublicId(),
+				Version: version,
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.AddRoleTemplateGrants(ctx, tc.req)
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, tc.err), "AddRoleTemplateGrants(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+		})
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	rw := db.New(conn)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+	s, err := roletemplates.NewService(iamRepoFn)
+	require.NoError(t, err)
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	otherOrg, _ := iam.TestScopes(t, iamRepo)
+	rt := testRoleTemplate(t, iamRepo, o.GetPublicId())
+	otherRt := testRoleTemplate(t, iamRepo, otherOrg.GetPublicId())
+
+	// The user may only list and read the role templates in the org
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	role := iam.TestRole(t, conn, o.GetPublicId())
+	iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=role-template;actions=list,read")
+	iam.TestUserRole(t, conn, role.GetPublicId(), at.GetIamUserId())
+
+	ctxFor := func(method, path string) context.Context {
+		req := httptest.NewRequest(method, "http://127.0.0.1"+path, nil)
+		requestInfo := auth.RequestInfo{
+			Path:        req.URL.Path,
+			Method:      req.Method,
+			TokenFormat: auth.AuthTokenTypeBearer,
+			PublicId:    at.GetPublicId(),
+			Token:       at.GetToken(),
+		}
+		return auth.NewVerifierContext(context.Background(), hclog.NewNullLogger(), iamRepoFn, tokenRepoFn, serversRepoFn, kms, requestInfo)
+	}
+
+	t.Run("Read", func(t *testing.T) {
+		got, err := s.GetRoleTemplate(ctxFor("GET", "/v1/role-templates/"+rt.GetPublicId()), &pbs.GetRoleTemplateRequest{Id: rt.GetPublicId()})
+		require.NoError(t, err)
+		assert.Equal(t, rt.GetPublicId(), got.GetItem().GetId())
+		assert.Equal(t, []string{"read"}, got.GetItem().GetAuthorizedActions())
+	})
+	t.Run("Read other org", func(t *testing.T) {
+		_, err := s.GetRoleTemplate(ctxFor("GET", "/v1/role-templates/"+otherRt.GetPublicId()), &pbs.GetRoleTemplateRequest{Id: otherRt.GetPublicId()})
+		assert.True(t, errors.Is(err, handlers.ForbiddenError()), "got error %v", err)
+	})
+	t.Run("List", func(t *testing.T) {
+		got, err := s.ListRoleTemplates(ctxFor("GET", "/v1/role-templates"), &pbs.ListRoleTemplatesRequest{ScopeId: scope.Global.String(), Recursive: true})
+		require.NoError(t, err)
+		require.Len(t, got.GetItems(), 1)
+		assert.Equal(t, rt.GetPublicId(), got.GetItems()[0].GetId())
+	})
+	t.Run("Update", func(t *testing.T) {
+		_, err := s.UpdateRoleTemplate(ctxFor("PATCH", "/v1/role-templates/"+rt.GetPublicId()), &pbs.UpdateRoleTemplateRequest{
+			Id:         rt.GetPublicId(),
+			UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+			Item: &pb.RoleTemplate{
+				Name:    &wrapperspb.StringValue{Value: "forbidden"},
+				Version: rt.GetVersion(),
+			},
+		})
+		assert.True(t, errors.Is(err, handlers.ForbiddenError()), "got error %v", err)
+	})
+	t.Run("Set grants", func(t *testing.T) {
+		_, err := s.SetRoleTemplateGrants(ctxFor("POST", "/v1/role-templates/"+rt.GetPublicId()+":set-grants"), &pbs.SetRoleTemplateGrantsRequest{
+			Id:           rt.GetPublicId(),
+			Version:      rt.GetVersion(),
+			GrantStrings: []string{"id=*;type=*;actions=*"},
+		})
+		assert.True(t, errors.Is(err, handlers.ForbiddenError()), "got error %v", err)
+	})
+	t.Run("Create", func(t *testing.T) {
+		_, err := s.CreateRoleTemplate(ctxFor("POST", "/v1/role-templates"), &pbs.CreateRoleTemplateRequest{Item: &pb.RoleTemplate{
+			ScopeId: o.GetPublicId(),
+		}})
+		assert.True(t, errors.Is(err, handlers.ForbiddenError()), "got error %v", err)
+	})
+}
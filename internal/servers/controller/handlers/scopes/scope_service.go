@@ -25,6 +25,7 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/groups"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_catalogs"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roles"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roletemplates"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/targets"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/users"
@@ -45,6 +46,8 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.ExplainAuthorization,
+		action.SetLabels,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -56,23 +59,25 @@ var (
 
 	scopeCollectionTypeMapMap = map[string]map[resource.Type]action.ActionSet{
 		scope.Global.String(): {
-			resource.AuthMethod: authmethods.CollectionActions,
-			resource.AuthToken:  authtokens.CollectionActions,
-			resource.Group:      groups.CollectionActions,
-			resource.Role:       roles.CollectionActions,
-			resource.Scope:      CollectionActions,
-			resource.Session:    sessions.CollectionActions,
-			resource.User:       users.CollectionActions,
+			resource.AuthMethod:   authmethods.CollectionActions,
+			resource.AuthToken:    authtokens.CollectionActions,
+			resource.Group:        groups.CollectionActions,
+			resource.Role:         roles.CollectionActions,
+			resource.RoleTemplate: roletemplates.CollectionActions,
+			resource.Scope:        CollectionActions,
+			resource.Session:      sessions.CollectionActions,
+			resource.User:         users.CollectionActions,
 		},
 
 		scope.Org.String(): {
-			resource.AuthMethod: authmethods.CollectionActions,
-			resource.AuthToken:  authtokens.CollectionActions,
-			resource.Group:      groups.CollectionActions,
-			resource.Role:       roles.CollectionActions,
-			resource.Scope:      CollectionActions,
-			resource.Session:    sessions.CollectionActions,
-			resource.User:       users.CollectionActions,
+			resource.AuthMethod:   authmethods.CollectionActions,
+			resource.AuthToken:    authtokens.CollectionActions,
+			resource.Group:        groups.CollectionActions,
+			resource.Role:         roles.CollectionActions,
+			resource.RoleTemplate: roletemplates.CollectionActions,
+			resource.Scope:        CollectionActions,
+			resource.Session:      sessions.CollectionActions,
+			resource.User:         users.CollectionActions,
 		},
 
 		scope.Project.String(): {
@@ -147,6 +152,14 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 	if len(pl) == 0 {
 		return &pbs.ListScopesResponse{}, nil
 	}
+	ids := make([]string, 0, len(pl))
+	for _, item := range pl {
+		ids = append(ids, item.GetPublicId())
+	}
+	labels, err := s.listLabelsFromRepo(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
@@ -159,6 +172,7 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 	for _, item := range pl {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetParentId()
+		res.Labels = labels[item.GetPublicId()]
 
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
@@ -174,6 +188,9 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
+		if outputFields.Has(globals.LabelsField) {
+			outputOpts = append(outputOpts, handlers.WithLabels(res.Labels))
+		}
 		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 			collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, scopeCollectionTypeMapMap[item.Type], item.GetPublicId(), "")
 			if err != nil {
@@ -229,6 +246,13 @@ func (s Service) GetScope(ctx context.Context, req *pbs.GetScopeRequest) (*pbs.G
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), act).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{p.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[p.GetPublicId()]))
+	}
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, scopeCollectionTypeMapMap[p.Type], p.GetPublicId(), "")
 		if err != nil {
@@ -319,6 +343,13 @@ func (s Service) UpdateScope(ctx context.Context, req *pbs.UpdateScopeRequest) (
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{p.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[p.GetPublicId()]))
+	}
 	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, scopeCollectionTypeMapMap[p.Type], p.GetPublicId(), "")
 		if err != nil {
@@ -351,6 +382,58 @@ func (s Service) DeleteScope(ctx context.Context, req *pbs.DeleteScopeRequest) (
 	return nil, nil
 }
 
+// SetScopeLabels implements the interface pbs.ScopeServiceServer.
+func (s Service) SetScopeLabels(ctx context.Context, req *pbs.SetScopeLabelsRequest) (*pbs.SetScopeLabelsResponse, error) {
+	const op = "scopes.(Service).SetScopeLabels"
+
+	if err := validateSetLabelsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetLabels)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	p, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.setLabelsInRepo(ctx, req.GetId(), req.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.LabelsField) {
+		outputOpts = append(outputOpts, handlers.WithLabels(labels))
+	}
+	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
+		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, scopeCollectionTypeMapMap[p.Type], p.GetPublicId(), "")
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
+	}
+
+	item, err := ToProto(ctx, p, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetScopeLabelsResponse{Item: item}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -478,6 +561,31 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId string) (bool, erro
 	return rows > 0, nil
 }
 
+func (s Service) listLabelsFromRepo(ctx context.Context, ids []string) (map[string]map[string]string, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListResourceLabels(ctx, ids)
+}
+
+func (s Service) setLabelsInRepo(ctx context.Context, id string, labels map[string]string) (map[string]string, error) {
+	const op = "scopes.(Service).setLabelsInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	// No labels in the request means removing all of them
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	out, err := repo.SetResourceLabels(ctx, id, labels)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to set labels on scope"))
+	}
+	return out, nil
+}
+
 func SortScopes(scps []*pb.Scope) {
 	// We stable sort here even though the database may not return things in
 	// sorted order, still nice to have them as consistent as possible.
@@ -581,15 +689,19 @@ func ToProto(ctx context.Context, in *iam.Scope, opt ...handlers.Option) (*pb.Sc
 	if outputFields.Has(globals.PrimaryAuthMethodId) && in.GetPrimaryAuthMethodId() != "" {
 		out.PrimaryAuthMethodId = &wrapperspb.StringValue{Value: in.GetPrimaryAuthMethodId()}
 	}
+	if outputFields.Has(globals.LabelsField) && len(opts.WithLabels) > 0 {
+		out.Labels = opts.WithLabels
+	}
 
+	handlers.FilterOutputFields(&out, outputFields)
 	return &out, nil
 }
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
-//  * The path passed in is correctly formatted
-//  * All required parameters are set
-//  * There are no conflicting parameters provided
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetScopeRequest) error {
 	badFields := map[string]string{}
 	id := req.GetId()
@@ -728,6 +840,20 @@ func validateDeleteRequest(req *pbs.DeleteScopeRequest) error {
 	return nil
 }
 
+func validateSetLabelsRequest(req *pbs.SetScopeLabelsRequest) error {
+	if err := validateGetRequest(&pbs.GetScopeRequest{Id: req.GetId()}); err != nil {
+		return err
+	}
+	badFields := map[string]string{}
+	if !handlers.ValidLabels(req.GetLabels()) {
+		badFields[globals.LabelsField] = "Label keys and values must be non-empty and consist of letters, digits and the characters _ . / -."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
 func validateListRequest(req *pbs.ListScopesRequest) error {
 	badFields := map[string]string{}
 	if req.GetScopeId() != scope.Global.String() && !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) {
@@ -30,7 +30,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "explain-authorization", "set-labels"}
 
 func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error)) {
 	t.Helper()
@@ -77,6 +77,12 @@ var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("list"),
 		},
 	},
+	"role-templates": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
+		},
+	},
 	"roles": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
@@ -120,6 +126,12 @@ var orgAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("list"),
 		},
 	},
+	"role-templates": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
+		},
+	},
 	"roles": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
@@ -1274,3 +1286,89 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestSetLabels(t *testing.T) {
+	org, proj, repoFn := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new scopes service.")
+
+	cases := []struct {
+		name    string
+		scopeId string
+		req     *pbs.SetScopeLabelsRequest
+		labels  map[string]string
+		err     error
+	}{
+		{
+			name:    "Set labels on an org",
+			scopeId: scope.Global.String(),
+			req:     &pbs.SetScopeLabelsRequest{Id: org.GetPublicId(), Labels: map[string]string{"env": "prod", "team": "db"}},
+			labels:  map[string]string{"env": "prod", "team": "db"},
+		},
+		{
+			name:    "Replace labels on an org",
+			scopeId: scope.Global.String(),
+			req:     &pbs.SetScopeLabelsRequest{Id: org.GetPublicId(), Labels: map[string]string{"env": "dev"}},
+			labels:  map[string]string{"env": "dev"},
+		},
+		{
+			name:    "Set labels on a project",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.SetScopeLabelsRequest{Id: proj.GetPublicId(), Labels: map[string]string{"env": "prod"}},
+			labels:  map[string]string{"env": "prod"},
+		},
+		{
+			name:    "Remove labels",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.SetScopeLabelsRequest{Id: proj.GetPublicId()},
+		},
+		{
+			name:    "Invalid label key",
+			scopeId: scope.Global.String(),
+			req:     &pbs.SetScopeLabelsRequest{Id: org.GetPublicId(), Labels: map[string]string{"en v": "prod"}},
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "Invalid label value",
+			scopeId: scope.Global.String(),
+			req:     &pbs.SetScopeLabelsRequest{Id: org.GetPublicId(), Labels: map[string]string{"env": "prod,dev"}},
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "Non existing project",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.SetScopeLabelsRequest{Id: "p_doesntexis", Labels: map[string]string{"env": "prod"}},
+			err:     handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name:    "Bad id formatting",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.SetScopeLabelsRequest{Id: "bad_format", Labels: map[string]string{"env": "prod"}},
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := auth.DisabledAuthTestContext(repoFn, tc.scopeId)
+			got, gErr := s.SetScopeLabels(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "SetScopeLabels(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(tc.req.GetId(), got.GetItem().GetId())
+			assert.Equal(len(tc.labels), len(got.GetItem().GetLabels()))
+			for k, v := range tc.labels {
+				assert.Equal(v, got.GetItem().GetLabels()[k])
+			}
+
+			// The labels are returned when reading the scope
+			read, err := s.GetScope(ctx, &pbs.GetScopeRequest{Id: tc.req.GetId()})
+			require.NoError(err)
+			assert.Empty(cmp.Diff(got.GetItem().GetLabels(), read.GetItem().GetLabels()))
+		})
+	}
+}
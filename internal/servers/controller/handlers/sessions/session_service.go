@@ -428,6 +428,7 @@ func toProto(ctx context.Context, in *session.Session, opt ...handlers.Option) (
 			}
 		}
 	}
+	handlers.FilterOutputFields(&out, outputFields)
 	return &out, nil
 }
 
@@ -17,6 +17,7 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
 	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
@@ -36,6 +37,7 @@ import (
 	"github.com/mr-tron/base58"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -54,6 +56,8 @@ var (
 		action.SetHostSets,
 		action.RemoveHostSets,
 		action.AuthorizeSession,
+		action.SetLabels,
+		action.TransferOwnership,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -154,6 +158,18 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 	if len(tl) == 0 {
 		return &pbs.ListTargetsResponse{}, nil
 	}
+	ids := make([]string, 0, len(tl))
+	for _, item := range tl {
+		ids = append(ids, item.GetPublicId())
+	}
+	labels, err := s.listLabelsFromRepo(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	owners, err := s.listOwnersFromRepo(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
@@ -166,6 +182,8 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 	for _, item := range tl {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetScopeId()
+		res.Labels = labels[item.GetPublicId()]
+		res.OwnerId = owners[item.GetPublicId()]
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
 			continue
@@ -180,6 +198,12 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
+		if outputFields.Has(globals.LabelsField) {
+			outputOpts = append(outputOpts, handlers.WithLabels(res.Labels))
+		}
+		if outputFields.Has(globals.OwnerIdField) {
+			outputOpts = append(outputOpts, handlers.WithOwnerId(res.OwnerId))
+		}
 
 		item, err := toProto(ctx, item, nil, outputOpts...)
 		if err != nil {
@@ -222,6 +246,20 @@ func (s Service) GetTarget(ctx context.Context, req *pbs.GetTargetRequest) (*pbs
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{t.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{t.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, outputOpts...)
 	if err != nil {
@@ -242,7 +280,12 @@ func (s Service) CreateTarget(ctx context.Context, req *pbs.CreateTargetRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	t, ts, err := s.createInRepo(ctx, req.GetItem())
+	// The user creating the target becomes its owner
+	var ownerId string
+	if perms.CanOwn(authResults.UserId) {
+		ownerId = authResults.UserId
+	}
+	t, ts, err := s.createInRepo(ctx, req.GetItem(), ownerId)
 	if err != nil {
 		return nil, err
 	}
@@ -258,7 +301,16 @@ func (s Service) CreateTarget(ctx context.Context, req *pbs.CreateTargetRequest)
 		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
 	}
 	if outputFields.Has(globals.AuthorizedActionsField) {
-		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+		res := perms.Resource{
+			Id:      t.GetPublicId(),
+			ScopeId: authResults.Scope.GetId(),
+			Type:    resource.Target,
+			OwnerId: ownerId,
+		}
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		outputOpts = append(outputOpts, handlers.WithOwnerId(ownerId))
 	}
 
 	item, err := toProto(ctx, t, ts, outputOpts...)
@@ -298,6 +350,20 @@ func (s Service) UpdateTarget(ctx context.Context, req *pbs.UpdateTargetRequest)
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{t.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{t.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, outputOpts...)
 	if err != nil {
@@ -352,6 +418,20 @@ func (s Service) AddTargetHostSets(ctx context.Context, req *pbs.AddTargetHostSe
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{t.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{t.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, outputOpts...)
 	if err != nil {
@@ -390,6 +470,20 @@ func (s Service) SetTargetHostSets(ctx context.Context, req *pbs.SetTargetHostSe
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{t.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{t.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, outputOpts...)
 	if err != nil {
@@ -428,6 +522,20 @@ func (s Service) RemoveTargetHostSets(ctx context.Context, req *pbs.RemoveTarget
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
 	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{t.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{t.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[t.GetPublicId()]))
+	}
 
 	item, err := toProto(ctx, t, ts, outputOpts...)
 	if err != nil {
@@ -712,9 +820,126 @@ HostSetIterationLoop:
 		HostSetId:          chosenId.hostSetId,
 		Endpoint:           endpointUrl.String(),
 	}
+
+	// The session details are always returned as they're needed to connect,
+	// but the output fields granted on the target apply to its scope
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+	scopeFields := outputFields.Nested(globals.ScopeField)
+	if len(scopeFields) == 0 {
+		ret.Scope = nil
+	}
+	handlers.FilterOutputFields(ret.Scope, scopeFields)
+
 	return &pbs.AuthorizeSessionResponse{Item: ret}, nil
 }
 
+// SetTargetLabels implements the interface pbs.TargetServiceServer.
+func (s Service) SetTargetLabels(ctx context.Context, req *pbs.SetTargetLabelsRequest) (*pbs.SetTargetLabelsResponse, error) {
+	const op = "targets.(Service).SetTargetLabels"
+
+	if err := validateSetLabelsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetLabels)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	t, ts, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.setLabelsInRepo(ctx, req.GetId(), req.GetLabels())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.LabelsField) {
+		outputOpts = append(outputOpts, handlers.WithLabels(labels))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		owners, err := s.listOwnersFromRepo(ctx, []string{t.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owners[t.GetPublicId()]))
+	}
+
+	item, err := toProto(ctx, t, ts, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetTargetLabelsResponse{Item: item}, nil
+}
+
+// TransferTargetOwnership implements the interface pbs.TargetServiceServer.
+func (s Service) TransferTargetOwnership(ctx context.Context, req *pbs.TransferTargetOwnershipRequest) (*pbs.TransferTargetOwnershipResponse, error) {
+	const op = "targets.(Service).TransferTargetOwnership"
+
+	if err := validateTransferOwnershipRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.TransferOwnership)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	t, ts, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	owner, err := handlers.SetResourceOwner(ctx, s.iamRepoFn, authResults.Scope.GetId(), req.GetId(), req.GetOwnerId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(&outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, t.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.LabelsField) {
+		labels, err := s.listLabelsFromRepo(ctx, []string{t.GetPublicId()})
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithLabels(labels[t.GetPublicId()]))
+	}
+	if outputFields.Has(globals.OwnerIdField) {
+		outputOpts = append(outputOpts, handlers.WithOwnerId(owner))
+	}
+
+	item, err := toProto(ctx, t, ts, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.TransferTargetOwnershipResponse{Item: item}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (target.Target, []*target.TargetSet, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -733,7 +958,7 @@ func (s Service) getFromRepo(ctx context.Context, id string) (target.Target, []*
 	return u, m, nil
 }
 
-func (s Service) createInRepo(ctx context.Context, item *pb.Target) (target.Target, []*target.TargetSet, error) {
+func (s Service) createInRepo(ctx context.Context, item *pb.Target, ownerId string) (target.Target, []*target.TargetSet, error) {
 	const op = "targets.(Service).createInRepo"
 	opts := []target.Option{target.WithName(item.GetName().GetValue())}
 	if item.GetDescription() != nil {
@@ -748,22 +973,38 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (target.Targ
 	if item.GetWorkerFilter() != nil {
 		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
-	tcpAttrs := &pb.TcpTargetAttributes{}
-	if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
-		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
-	}
-	if tcpAttrs.GetDefaultPort().GetValue() != 0 {
-		opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
-	}
-	u, err := target.NewTcpTarget(item.GetScopeId(), opts...)
+	subtype := target.SubtypeFromType(item.GetType())
+	defaultPort, err := defaultPortFromAttributes(subtype, item.GetAttributes())
 	if err != nil {
-		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		return nil, nil, err
+	}
+	if defaultPort != 0 {
+		opts = append(opts, target.WithDefaultPort(defaultPort))
 	}
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, nil, err
 	}
-	out, m, err := repo.CreateTcpTarget(ctx, u)
+	var createOpts []target.Option
+	if ownerId != "" {
+		createOpts = append(createOpts, target.WithOwnerId(ownerId))
+	}
+	var out target.Target
+	var m []*target.TargetSet
+	switch subtype {
+	case target.UdpSubType:
+		var u *target.UdpTarget
+		if u, err = target.NewUdpTarget(item.GetScopeId(), opts...); err != nil {
+			return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		}
+		out, m, err = repo.CreateUdpTarget(ctx, u, createOpts...)
+	default:
+		var u *target.TcpTarget
+		if u, err = target.NewTcpTarget(item.GetScopeId(), opts...); err != nil {
+			return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for creation: %v.", err)
+		}
+		out, m, err = repo.CreateTcpTarget(ctx, u, createOpts...)
+	}
 	if err != nil {
 		return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to create target"))
 	}
@@ -791,19 +1032,17 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if filter := item.GetWorkerFilter(); filter != nil {
 		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
-	tcpAttrs := &pb.TcpTargetAttributes{}
-	if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
-		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+	subtype := target.SubtypeFromId(id)
+	defaultPort, err := defaultPortFromAttributes(subtype, item.GetAttributes())
+	if err != nil {
+		return nil, nil, err
 	}
-	if tcpAttrs.GetDefaultPort().GetValue() != 0 {
-		opts = append(opts, target.WithDefaultPort(tcpAttrs.GetDefaultPort().GetValue()))
+	if defaultPort != 0 {
+		opts = append(opts, target.WithDefaultPort(defaultPort))
 	}
 	version := item.GetVersion()
-	u, err := target.NewTcpTarget(scopeId, opts...)
-	if err != nil {
-		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
-	}
-	u.PublicId = id
+	// The udp target's fields are named the same as the tcp target's, so the
+	// mask translates the same for both.
 	dbMask := maskManager.Translate(mask)
 	if len(dbMask) == 0 {
 		return nil, nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid paths provided in the update mask."})
@@ -812,7 +1051,25 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if err != nil {
 		return nil, nil, err
 	}
-	out, m, rowsUpdated, err := repo.UpdateTcpTarget(ctx, u, version, dbMask)
+	var out target.Target
+	var m []*target.TargetSet
+	var rowsUpdated int
+	switch subtype {
+	case target.UdpSubType:
+		var u *target.UdpTarget
+		if u, err = target.NewUdpTarget(scopeId, opts...); err != nil {
+			return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+		}
+		u.PublicId = id
+		out, m, rowsUpdated, err = repo.UpdateUdpTarget(ctx, u, version, dbMask)
+	default:
+		var u *target.TcpTarget
+		if u, err = target.NewTcpTarget(scopeId, opts...); err != nil {
+			return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build target for update: %v.", err)
+		}
+		u.PublicId = id
+		out, m, rowsUpdated, err = repo.UpdateTcpTarget(ctx, u, version, dbMask)
+	}
 	if err != nil {
 		return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to update target"))
 	}
@@ -822,6 +1079,25 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	return out, m, nil
 }
 
+// defaultPortFromAttributes returns the default port set in the attributes of
+// a target of the given subtype.
+func defaultPortFromAttributes(subtype target.SubType, attributes *structpb.Struct) (uint32, error) {
+	var attrs interface {
+		proto.Message
+		GetDefaultPort() *wrappers.UInt32Value
+	}
+	switch subtype {
+	case target.UdpSubType:
+		attrs = &pb.UdpTargetAttributes{}
+	default:
+		attrs = &pb.TcpTargetAttributes{}
+	}
+	if err := handlers.StructToProto(attributes, attrs); err != nil {
+		return 0, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
+	}
+	return attrs.GetDefaultPort().GetValue(), nil
+}
+
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	const op = "targets.(Service).deleteFromRepo"
 	repo, err := s.repoFn()
@@ -850,6 +1126,39 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]target.
 	return ul, nil
 }
 
+func (s Service) listLabelsFromRepo(ctx context.Context, ids []string) (map[string]map[string]string, error) {
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListResourceLabels(ctx, ids)
+}
+
+func (s Service) listOwnersFromRepo(ctx context.Context, ids []string) (map[string]string, error) {
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListResourceOwners(ctx, ids)
+}
+
+func (s Service) setLabelsInRepo(ctx context.Context, id string, labels map[string]string) (map[string]string, error) {
+	const op = "targets.(Service).setLabelsInRepo"
+	repo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	// No labels in the request means removing all of them
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	out, err := repo.SetResourceLabels(ctx, id, labels)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to set labels on target"))
+	}
+	return out, nil
+}
+
 func (s Service) addInRepo(ctx context.Context, targetId string, hostSetId []string, version uint32) (target.Target, []*target.TargetSet, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -977,7 +1286,7 @@ func toProto(ctx context.Context, in target.Target, m []*target.TargetSet, opt .
 		out.ScopeId = in.GetScopeId()
 	}
 	if outputFields.Has(globals.TypeField) {
-		out.Type = target.TcpTargetType.String()
+		out.Type = in.GetType()
 	}
 	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
 		out.Description = wrapperspb.String(in.GetDescription())
@@ -1009,6 +1318,12 @@ func toProto(ctx context.Context, in target.Target, m []*target.TargetSet, opt .
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
+	if outputFields.Has(globals.LabelsField) && len(opts.WithLabels) > 0 {
+		out.Labels = opts.WithLabels
+	}
+	if outputFields.Has(globals.OwnerIdField) && opts.WithOwnerId != "" {
+		out.OwnerId = opts.WithOwnerId
+	}
 	if outputFields.Has(globals.HostSetIdsField) {
 		for _, hs := range m {
 			out.HostSetIds = append(out.HostSetIds, hs.GetPublicId())
@@ -1023,9 +1338,16 @@ func toProto(ctx context.Context, in target.Target, m []*target.TargetSet, opt .
 		}
 	}
 	if outputFields.Has(globals.AttributesField) {
-		attrs := &pb.TcpTargetAttributes{}
+		var defaultPort *wrappers.UInt32Value
 		if in.GetDefaultPort() > 0 {
-			attrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+			defaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
+		}
+		var attrs proto.Message
+		switch in.GetType() {
+		case target.UdpTargetType.String():
+			attrs = &pb.UdpTargetAttributes{DefaultPort: defaultPort}
+		default:
+			attrs = &pb.TcpTargetAttributes{DefaultPort: defaultPort}
 		}
 		st, err := handlers.ProtoToStruct(attrs)
 		if err != nil {
@@ -1033,6 +1355,7 @@ func toProto(ctx context.Context, in target.Target, m []*target.TargetSet, opt .
 		}
 		out.Attributes = st
 	}
+	handlers.FilterOutputFields(&out, outputFields)
 	return &out, nil
 }
 
@@ -1042,7 +1365,7 @@ func toProto(ctx context.Context, in target.Target, m []*target.TargetSet, opt .
 //  * All required parameters are set
 //  * There are no conflicting parameters provided
 func validateGetRequest(req *pbs.GetTargetRequest) error {
-	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, target.TcpTargetPrefix)
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, target.TcpTargetPrefix, target.UdpTargetPrefix)
 }
 
 func validateCreateRequest(req *pbs.CreateTargetRequest) error {
@@ -1075,9 +1398,17 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 			if tcpAttrs.GetDefaultPort() != nil && tcpAttrs.GetDefaultPort().GetValue() == 0 {
 				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
 			}
+		case target.UdpSubType:
+			udpAttrs := &pb.UdpTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), udpAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+			}
+			if udpAttrs.GetDefaultPort() != nil && udpAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
 		}
 		switch req.GetItem().GetType() {
-		case target.TcpTargetType.String():
+		case target.TcpTargetType.String(), target.UdpTargetType.String():
 		case "":
 			badFields["type"] = "This is a required field."
 		default:
@@ -1122,6 +1453,17 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 			if tcpAttrs.GetDefaultPort() != nil && tcpAttrs.GetDefaultPort().GetValue() == 0 {
 				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
 			}
+		case target.UdpSubType:
+			if req.GetItem().GetType() != "" && target.SubtypeFromType(req.GetItem().GetType()) != target.UdpSubType {
+				badFields["type"] = "Cannot modify the resource type."
+			}
+			udpAttrs := &pb.UdpTargetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), udpAttrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+			}
+			if udpAttrs.GetDefaultPort() != nil && udpAttrs.GetDefaultPort().GetValue() == 0 {
+				badFields["attributes.default_port"] = "This optional field cannot be set to 0."
+			}
 		}
 		if filter := req.GetItem().GetWorkerFilter(); filter != nil {
 			if _, err := bexpr.CreateEvaluator(filter.GetValue()); err != nil {
@@ -1129,11 +1471,11 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 			}
 		}
 		return badFields
-	}, target.TcpTargetPrefix)
+	}, target.TcpTargetPrefix, target.UdpTargetPrefix)
 }
 
 func validateDeleteRequest(req *pbs.DeleteTargetRequest) error {
-	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, target.TcpTargetPrefix)
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, target.TcpTargetPrefix, target.UdpTargetPrefix)
 }
 
 func validateListRequest(req *pbs.ListTargetsRequest) error {
@@ -1153,7 +1495,7 @@ func validateListRequest(req *pbs.ListTargetsRequest) error {
 
 func validateAddRequest(req *pbs.AddTargetHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.UdpTargetPrefix) {
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -1176,7 +1518,7 @@ func validateAddRequest(req *pbs.AddTargetHostSetsRequest) error {
 
 func validateSetRequest(req *pbs.SetTargetHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.UdpTargetPrefix) {
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -1196,7 +1538,7 @@ func validateSetRequest(req *pbs.SetTargetHostSetsRequest) error {
 
 func validateRemoveRequest(req *pbs.RemoveTargetHostSetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.UdpTargetPrefix) {
 		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if req.GetVersion() == 0 {
@@ -1217,13 +1559,36 @@ func validateRemoveRequest(req *pbs.RemoveTargetHostSetsRequest) error {
 	return nil
 }
 
+func validateSetLabelsRequest(req *pbs.SetTargetLabelsRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		badFields := map[string]string{}
+		if !handlers.ValidLabels(req.GetLabels()) {
+			badFields[globals.LabelsField] = "Label keys and values must be non-empty and consist of letters, digits and the characters _ . / -."
+		}
+		return badFields
+	}, req, target.TcpTargetPrefix, target.UdpTargetPrefix)
+}
+
+func validateTransferOwnershipRequest(req *pbs.TransferTargetOwnershipRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		badFields := map[string]string{}
+		switch {
+		case !handlers.ValidId(handlers.Id(req.GetOwnerId()), iam.UserPrefix):
+			badFields[globals.OwnerIdField] = "Incorrectly formatted identifier."
+		case !perms.CanOwn(req.GetOwnerId()):
+			badFields[globals.OwnerIdField] = "Ownership can't be transferred to a special user."
+		}
+		return badFields
+	}, req, target.TcpTargetPrefix, target.UdpTargetPrefix)
+}
+
 func validateAuthorizeSessionRequest(req *pbs.AuthorizeSessionRequest) error {
 	badFields := map[string]string{}
 	nameEmpty := req.GetName() == ""
 	scopeIdEmpty := req.GetScopeId() == ""
 	scopeNameEmpty := req.GetScopeName() == ""
 	if nameEmpty {
-		if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix) {
+		if !handlers.ValidId(handlers.Id(req.GetId()), target.TcpTargetPrefix, target.UdpTargetPrefix) {
 			badFields["id"] = "Incorrectly formatted identifier."
 		}
 		if !scopeIdEmpty {
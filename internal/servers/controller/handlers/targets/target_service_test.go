@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	apiproxy "github.com/hashicorp/boundary/api/proxy"
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
@@ -24,6 +25,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
 	"github.com/jinzhu/gorm"
+	"github.com/mr-tron/base58"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/genproto/protobuf/field_mask"
@@ -36,7 +38,7 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-host-sets", "set-host-sets", "remove-host-sets", "authorize-session"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-host-sets", "set-host-sets", "remove-host-sets", "authorize-session", "set-labels", "transfer-ownership"}
 
 func testService(t *testing.T, conn *gorm.DB, kms *kms.Kms, wrapper wrapping.Wrapper) (targets.Service, error) {
 	rw := db.New(conn)
@@ -409,6 +411,32 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Create a valid udp target",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("udp name"),
+				Type:    target.UdpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"default_port": structpb.NewNumberValue(53),
+				}},
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.UdpTargetPrefix),
+				Item: &pb.Target{
+					ScopeId: proj.GetPublicId(),
+					Scope:   &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String(), ParentScopeId: org.GetPublicId()},
+					Name:    wrapperspb.String("udp name"),
+					Type:    target.UdpTargetType.String(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"default_port": structpb.NewNumberValue(53),
+					}},
+					SessionMaxSeconds:      wrapperspb.UInt32(28800),
+					SessionConnectionLimit: wrapperspb.Int32(1),
+					AuthorizedActions:      testAuthorizedActions,
+				},
+			},
+		},
 		{
 			name: "Create with default port 0",
 			req: &pbs.CreateTargetRequest{Item: &pb.Target{
@@ -488,7 +516,7 @@ func TestCreate(t *testing.T) {
 
 			if got != nil {
 				assert.Contains(got.GetUri(), tc.res.GetUri())
-				assert.True(strings.HasPrefix(got.GetItem().GetId(), target.TcpTargetPrefix), got.GetItem().GetId())
+				assert.True(strings.HasPrefix(got.GetUri(), fmt.Sprintf("targets/%s", got.GetItem().GetId())), got.GetItem().GetId())
 
 				// Clear all values which are hard to compare against.
 				got.Uri, tc.res.Uri = "", ""
@@ -1264,3 +1292,197 @@ func TestRemoveTargetHostSets(t *testing.T) {
 		})
 	}
 }
+
+// The api's proxy package decodes authorization tokens without the generated
+// code, so ensure it agrees with it.
+func TestSetTargetLabels(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+
+	_, proj := iam.TestScopes(t, iamRepo)
+	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test")
+
+	s, err := testService(t, conn, kms, wrapper)
+	require.NoError(t, err, "Couldn't create a new target service.")
+
+	cases := []struct {
+		name   string
+		req    *pbs.SetTargetLabelsRequest
+		labels map[string]string
+		err    error
+	}{
+		{
+			name:   "Set labels",
+			req:    &pbs.SetTargetLabelsRequest{Id: tar.GetPublicId(), Labels: map[string]string{"env": "prod", "team": "db"}},
+			labels: map[string]string{"env": "prod", "team": "db"},
+		},
+		{
+			name:   "Replace labels",
+			req:    &pbs.SetTargetLabelsRequest{Id: tar.GetPublicId(), Labels: map[string]string{"env": "dev"}},
+			labels: map[string]string{"env": "dev"},
+		},
+		{
+			name: "Remove labels",
+			req:  &pbs.SetTargetLabelsRequest{Id: tar.GetPublicId()},
+		},
+		{
+			name: "Invalid label",
+			req:  &pbs.SetTargetLabelsRequest{Id: tar.GetPublicId(), Labels: map[string]string{"env": "prod:dev"}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Not existing target",
+			req:  &pbs.SetTargetLabelsRequest{Id: target.TcpTargetPrefix + "_doesntexis", Labels: map[string]string{"env": "prod"}},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad target id formatting",
+			req:  &pbs.SetTargetLabelsRequest{Id: target.TcpTargetPrefix + "_bad_format", Labels: map[string]string{"env": "prod"}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId())
+			got, gErr := s.SetTargetLabels(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "SetTargetLabels(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(len(tc.labels), len(got.GetItem().GetLabels()))
+			for k, v := range tc.labels {
+				assert.Equal(v, got.GetItem().GetLabels()[k])
+			}
+
+			// The labels are returned when reading the target
+			read, err := s.GetTarget(ctx, &pbs.GetTargetRequest{Id: tc.req.GetId()})
+			require.NoError(err)
+			assert.Empty(cmp.Diff(got.GetItem().GetLabels(), read.GetItem().GetLabels()))
+		})
+	}
+}
+
+func TestSessionAuthorizationData_apiDecoding(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	created := timestamppb.Now()
+	sad := &pb.SessionAuthorizationData{
+		SessionId:   "s_1234567890",
+		TargetId:    "ttcp_1234567890",
+		Scope:       &scopes.ScopeInfo{Id: "p_1234567890", Type: scope.Project.String()},
+		CreatedTime: created,
+		Type:        "tcp",
+		Certificate: []byte("certificate"),
+		PrivateKey:  []byte("private key"),
+		HostId:      "hst_1234567890",
+		Endpoint:    "tcp://127.0.0.1:22",
+		WorkerInfo: []*pb.WorkerInfo{
+			{Address: "127.0.0.1:9202"},
+			{Address: "127.0.0.2:9202"},
+		},
+		ConnectionLimit: -1,
+	}
+	marshaled, err := proto.Marshal(sad)
+	require.NoError(err)
+
+	got, err := apiproxy.DecodeAuthorizationToken(base58.FastBase58Encoding(marshaled))
+	require.NoError(err)
+	assert.Equal(&apiproxy.SessionAuthorizationData{
+		SessionId:       sad.SessionId,
+		TargetId:        sad.TargetId,
+		CreatedTime:     created.AsTime(),
+		Type:            sad.Type,
+		ConnectionLimit: sad.ConnectionLimit,
+		Certificate:     sad.Certificate,
+		PrivateKey:      sad.PrivateKey,
+		HostId:          sad.HostId,
+		Endpoint:        sad.Endpoint,
+		WorkerAddresses: []string{"127.0.0.1:9202", "127.0.0.2:9202"},
+	}, got)
+}
+
+func TestTransferTargetOwnership(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test")
+
+	s, err := testService(t, conn, kms, wrapper)
+	require.NoError(t, err, "Couldn't create a new target service.")
+	u1 := iam.TestUser(t, iamRepo, org.GetPublicId())
+	u2 := iam.TestUser(t, iamRepo, org.GetPublicId())
+
+	cases := []struct {
+		name  string
+		req   *pbs.TransferTargetOwnershipRequest
+		owner string
+		err   error
+	}{
+		{
+			name:  "Transfer ownership",
+			req:   &pbs.TransferTargetOwnershipRequest{Id: tar.GetPublicId(), OwnerId: u1.GetPublicId()},
+			owner: u1.GetPublicId(),
+		},
+		{
+			name:  "Transfer to another user",
+			req:   &pbs.TransferTargetOwnershipRequest{Id: tar.GetPublicId(), OwnerId: u2.GetPublicId()},
+			owner: u2.GetPublicId(),
+		},
+		{
+			name: "Missing owner",
+			req:  &pbs.TransferTargetOwnershipRequest{Id: tar.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Special user",
+			req:  &pbs.TransferTargetOwnershipRequest{Id: tar.GetPublicId(), OwnerId: "u_anon"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Not existing target",
+			req:  &pbs.TransferTargetOwnershipRequest{Id: target.TcpTargetPrefix + "_doesntexis", OwnerId: u1.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad target id formatting",
+			req:  &pbs.TransferTargetOwnershipRequest{Id: target.TcpTargetPrefix + "_bad_format", OwnerId: u1.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := auth.DisabledAuthTestContext(iamRepoFn, proj.GetPublicId())
+			got, gErr := s.TransferTargetOwnership(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "TransferTargetOwnership(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(tc.owner, got.GetItem().GetOwnerId())
+
+			// The owner is returned when reading the target
+			read, err := s.GetTarget(ctx, &pbs.GetTargetRequest{Id: tc.req.GetId()})
+			require.NoError(err)
+			assert.Equal(tc.owner, read.GetItem().GetOwnerId())
+		})
+	}
+}
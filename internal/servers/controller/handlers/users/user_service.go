@@ -39,6 +39,7 @@ var (
 		action.AddAccounts,
 		action.SetAccounts,
 		action.RemoveAccounts,
+		action.ReadEffectivePermissions,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -391,6 +392,43 @@ func (s Service) RemoveUserAccounts(ctx context.Context, req *pbs.RemoveUserAcco
 	return &pbs.RemoveUserAccountsResponse{Item: item}, nil
 }
 
+// GetUserEffectivePermissions implements the interface pbs.UserServiceServer.
+func (s Service) GetUserEffectivePermissions(ctx context.Context, req *pbs.GetUserEffectivePermissionsRequest) (*pbs.GetUserEffectivePermissionsResponse, error) {
+	if err := validateGetEffectivePermissionsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ReadEffectivePermissions)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, accts, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	eps, err := auth.EffectivePermissions(ctx, repo, u.GetPublicId(), accts)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &pb.EffectivePermissions{UserId: u.GetPublicId()}
+	for _, ep := range eps {
+		item.Permissions = append(item.Permissions, &pb.EffectivePermission{
+			ScopeId:       ep.ScopeId,
+			ResourceType:  ep.ResourceType,
+			ResourceId:    ep.ResourceId,
+			Labels:        ep.Labels,
+			Actions:       ep.Actions,
+			DeniedActions: ep.DeniedActions,
+			RoleIds:       ep.RoleIds,
+		})
+	}
+	return &pbs.GetUserEffectivePermissionsResponse{Item: item}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.User, []string, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -655,6 +693,7 @@ func toProto(ctx context.Context, in *iam.User, accts []string, opt ...handlers.
 			})
 		}
 	}
+	handlers.FilterOutputFields(&out, outputFields)
 	return &out, nil
 }
 
@@ -667,6 +706,10 @@ func validateGetRequest(req *pbs.GetUserRequest) error {
 	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, iam.UserPrefix)
 }
 
+func validateGetEffectivePermissionsRequest(req *pbs.GetUserEffectivePermissionsRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, iam.UserPrefix)
+}
+
 func validateCreateRequest(req *pbs.CreateUserRequest) error {
 	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
 		badFields := map[string]string{}
@@ -32,7 +32,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "read-effective-permissions"}
 
 func createDefaultUserAndRepo(t *testing.T, withAccts bool) (*iam.User, []string, func() (*iam.Repository, error)) {
 	t.Helper()
@@ -1216,3 +1216,109 @@ func TestRemoveAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEffectivePermissions(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := users.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new user service.")
+
+	o, p := iam.TestScopes(t, iamRepo, iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+	u := iam.TestUser(t, iamRepo, o.GetPublicId())
+
+	grp := iam.TestGroup(t, conn, p.GetPublicId())
+	iam.TestGroupMember(t, conn, grp.GetPublicId(), u.GetPublicId())
+	groupRole := iam.TestRole(t, conn, p.GetPublicId())
+	iam.TestGroupRole(t, conn, groupRole.GetPublicId(), grp.GetPublicId())
+	iam.TestRoleGrant(t, conn, groupRole.GetPublicId(), "id=*;type=target;actions=read,authorize-session")
+	iam.TestRoleGrant(t, conn, groupRole.GetPublicId(), "id=*;type=target;actions=delete;effect=deny")
+
+	userRole := iam.TestRole(t, conn, o.GetPublicId())
+	iam.TestUserRole(t, conn, userRole.GetPublicId(), u.GetPublicId())
+	iam.TestRoleGrant(t, conn, userRole.GetPublicId(), "id={{user.id}};actions=read")
+	iam.TestRoleGrant(t, conn, userRole.GetPublicId(), "id=*;type=target;actions=update")
+	iam.TestRoleGrant(t, conn, userRole.GetPublicId(), "type=user;output_fields=id")
+	iam.TestRoleGrantScope(t, conn, userRole.GetPublicId(), "this")
+	iam.TestRoleGrantScope(t, conn, userRole.GetPublicId(), p.GetPublicId())
+
+	cases := []struct {
+		name    string
+		req     *pbs.GetUserEffectivePermissionsRequest
+		res     *pb.EffectivePermissions
+		errCode codes.Code
+	}{
+		{
+			name: "Get effective permissions",
+			req:  &pbs.GetUserEffectivePermissionsRequest{Id: u.GetPublicId()},
+			res: &pb.EffectivePermissions{
+				UserId: u.GetPublicId(),
+				Permissions: []*pb.EffectivePermission{
+					{
+						ScopeId:    o.GetPublicId(),
+						ResourceId: u.GetPublicId(),
+						Actions:    []string{"read"},
+						RoleIds:    []string{userRole.GetPublicId()},
+					},
+					{
+						ScopeId:      o.GetPublicId(),
+						ResourceType: "target",
+						ResourceId:   "*",
+						Actions:      []string{"update"},
+						RoleIds:      []string{userRole.GetPublicId()},
+					},
+					{
+						ScopeId:    p.GetPublicId(),
+						ResourceId: u.GetPublicId(),
+						Actions:    []string{"read"},
+						RoleIds:    []string{userRole.GetPublicId()},
+					},
+					{
+						ScopeId:       p.GetPublicId(),
+						ResourceType:  "target",
+						ResourceId:    "*",
+						Actions:       []string{"authorize-session", "read", "update"},
+						DeniedActions: []string{"delete"},
+						RoleIds:       []string{groupRole.GetPublicId(), userRole.GetPublicId()},
+					},
+				},
+			},
+		},
+		{
+			name:    "Get a non existing user",
+			req:     &pbs.GetUserEffectivePermissionsRequest{Id: iam.UserPrefix + "_DoesntExis"},
+			errCode: codes.NotFound,
+		},
+		{
+			name:    "Wrong id prefix",
+			req:     &pbs.GetUserEffectivePermissionsRequest{Id: "j_1234567890"},
+			errCode: codes.InvalidArgument,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := s.GetUserEffectivePermissions(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			if tc.errCode != codes.OK {
+				require.Error(err)
+				assert.True(errors.Is(err, handlers.ApiErrorWithCode(tc.errCode)), "GetUserEffectivePermissions(%+v) got error %v, wanted %v", tc.req, err, tc.errCode)
+				return
+			}
+			require.NoError(err)
+
+			// Leave out the grants the global scope gives u_anon and u_auth by
+			// default
+			var perms []*pb.EffectivePermission
+			for _, ep := range got.GetItem().GetPermissions() {
+				if ep.GetScopeId() != scope.Global.String() {
+					perms = append(perms, ep)
+				}
+			}
+			got.GetItem().Permissions = perms
+			assert.Empty(cmp.Diff(tc.res, got.GetItem(), protocmp.Transform()))
+		})
+	}
+}
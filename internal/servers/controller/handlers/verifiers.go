@@ -8,6 +8,7 @@ import (
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	"github.com/hashicorp/boundary/internal/perms"
 	"google.golang.org/genproto/protobuf/field_mask"
 )
 
@@ -201,3 +202,14 @@ func ValidNameDescription(in string) bool {
 
 	return true
 }
+
+// ValidLabels reports whether all of the keys and values can be used for
+// labels on a resource.
+func ValidLabels(labels map[string]string) bool {
+	for k, v := range labels {
+		if perms.ValidateLabel(k, v) != nil {
+			return false
+		}
+	}
+	return true
+}
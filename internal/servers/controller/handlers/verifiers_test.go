@@ -46,6 +46,17 @@ func TestValidNameDescription(t *testing.T) {
 	assert.False(t, ValidNameDescription("foo\u200Bbar"))
 }
 
+func TestValidLabels(t *testing.T) {
+	assert.True(t, ValidLabels(nil))
+	assert.True(t, ValidLabels(map[string]string{"env": "prod", "team/name": "db-1.x_y"}))
+
+	assert.False(t, ValidLabels(map[string]string{"": "prod"}))
+	assert.False(t, ValidLabels(map[string]string{"env": ""}))
+	assert.False(t, ValidLabels(map[string]string{"env": "prod,dev"}))
+	assert.False(t, ValidLabels(map[string]string{"env:name": "prod"}))
+	assert.False(t, ValidLabels(map[string]string{"env": "prod", "team name": "db"}))
+}
+
 func TestValidateGetRequest(t *testing.T) {
 	cases := []struct {
 		name      string
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
@@ -27,6 +28,7 @@ type workerServiceServer struct {
 	logger        hclog.Logger
 	serversRepoFn common.ServersRepoFactory
 	sessionRepoFn common.SessionRepoFactory
+	staticRepoFn  common.StaticRepoFactory
 	updateTimes   *sync.Map
 	kms           *kms.Kms
 }
@@ -35,12 +37,14 @@ func NewWorkerServiceServer(
 	logger hclog.Logger,
 	serversRepoFn common.ServersRepoFactory,
 	sessionRepoFn common.SessionRepoFactory,
+	staticRepoFn common.StaticRepoFactory,
 	updateTimes *sync.Map,
 	kms *kms.Kms) *workerServiceServer {
 	return &workerServiceServer{
 		logger:        logger,
 		serversRepoFn: serversRepoFn,
 		sessionRepoFn: sessionRepoFn,
+		staticRepoFn:  staticRepoFn,
 		updateTimes:   updateTimes,
 		kms:           kms,
 	}
@@ -214,6 +218,7 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 		HostSetId:       sessionInfo.HostSetId,
 		TargetId:        sessionInfo.TargetId,
 		UserId:          sessionInfo.UserId,
+		WorkerId:        sessionInfo.ServerId,
 	}
 	if resp.ConnectionsLeft != -1 {
 		resp.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
@@ -294,6 +299,36 @@ func (ws *workerServiceServer) ActivateSession(ctx context.Context, req *pbs.Act
 	}, nil
 }
 
+func (ws *workerServiceServer) ResumeSession(ctx context.Context, req *pbs.ResumeSessionRequest) (*pbs.ResumeSessionResponse, error) {
+	ws.logger.Trace("got resume session request from worker", "session_id", req.GetSessionId(), "worker_id", req.GetWorkerId())
+
+	sessRepo, err := ws.sessionRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting session repo: %v", err)
+	}
+
+	sessionInfo, sessionStates, err := sessRepo.ResumeSession(
+		ctx,
+		req.GetSessionId(),
+		req.GetWorkerId(),
+		resource.Worker.String(),
+		[]byte(req.GetTofuToken()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error resuming session: %v", err)
+	}
+	if len(sessionStates) == 0 {
+		return nil, status.Error(codes.Internal, "Invalid session state in resume response.")
+	}
+
+	ws.logger.Info("session resumed",
+		"session_id", sessionInfo.PublicId,
+		"worker_id", req.GetWorkerId())
+
+	return &pbs.ResumeSessionResponse{
+		Status: sessionStates[0].Status.ProtoVal(),
+	}, nil
+}
+
 func (ws *workerServiceServer) AuthorizeConnection(ctx context.Context, req *pbs.AuthorizeConnectionRequest) (*pbs.AuthorizeConnectionResponse, error) {
 	ws.logger.Trace("got authorize connection request from worker", "session_id", req.GetSessionId())
 
@@ -363,7 +398,7 @@ func (ws *workerServiceServer) ConnectConnection(ctx context.Context, req *pbs.C
 		"client_tcp_port", req.ClientTcpPort,
 	}
 	switch req.GetType() {
-	case "tcp":
+	case "tcp", "udp":
 		loggerPairs = append(loggerPairs,
 			"endpoint_tcp_address", connectionInfo.EndpointTcpAddress,
 			"endpoint_tcp_port", connectionInfo.EndpointTcpPort,
@@ -391,6 +426,9 @@ func (ws *workerServiceServer) CloseConnection(ctx context.Context, req *pbs.Clo
 			BytesUp:      v.GetBytesUp(),
 			BytesDown:    v.GetBytesDown(),
 			ClosedReason: session.ClosedReason(v.GetReason()),
+
+			DatagramsUp:   v.GetDatagramsUp(),
+			DatagramsDown: v.GetDatagramsDown(),
 		})
 	}
 	ws.logger.Trace("got connection close information from worker", "connection_ids", closeIds)
@@ -432,3 +470,62 @@ func (ws *workerServiceServer) CloseConnection(ctx context.Context, req *pbs.Clo
 
 	return ret, nil
 }
+
+func (ws *workerServiceServer) ReportSshHostKey(ctx context.Context, req *pbs.ReportSshHostKeyRequest) (*pbs.ReportSshHostKeyResponse, error) {
+	ws.logger.Trace("got report ssh host key request from worker", "session_id", req.GetSessionId())
+
+	sessRepo, err := ws.sessionRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting session repo: %v", err)
+	}
+	staticRepo, err := ws.staticRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting static host repo: %v", err)
+	}
+
+	sessionInfo, _, err := sessRepo.LookupSession(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error looking up session: %v", err)
+	}
+	if sessionInfo == nil {
+		return nil, status.Error(codes.PermissionDenied, "Unknown session ID.")
+	}
+	if len(sessionInfo.States) == 0 {
+		return nil, status.Error(codes.Internal, "Empty session states during lookup.")
+	}
+	switch sessionInfo.States[0].Status {
+	case session.StatusPending, session.StatusActive:
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "Session is %s.", sessionInfo.States[0].Status)
+	}
+
+	hostKey, keyStatus, err := staticRepo.ReportSshHostKey(ctx, sessionInfo.ScopeId, sessionInfo.HostId, req.GetHostKey())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error reporting ssh host key: %v", err)
+	}
+
+	ret := &pbs.ReportSshHostKeyResponse{
+		HostKey:     hostKey.GetHostKey(),
+		Fingerprint: hostKey.GetFingerprint(),
+	}
+	switch keyStatus {
+	case static.SshHostKeyPinned:
+		ws.logger.Info("ssh host key pinned",
+			"session_id", sessionInfo.PublicId,
+			"host_id", sessionInfo.HostId,
+			"fingerprint", hostKey.GetFingerprint())
+		ret.Status = pbs.SSHHOSTKEYSTATUS_SSHHOSTKEYSTATUS_PINNED
+	case static.SshHostKeyVerified:
+		ret.Status = pbs.SSHHOSTKEYSTATUS_SSHHOSTKEYSTATUS_VERIFIED
+	case static.SshHostKeyMismatch:
+		ws.logger.Warn("WARNING: ssh host key mismatch; the host presented a key which is not pinned for it",
+			"session_id", sessionInfo.PublicId,
+			"user_id", sessionInfo.UserId,
+			"target_id", sessionInfo.TargetId,
+			"host_id", sessionInfo.HostId,
+			"fingerprint", hostKey.GetFingerprint(),
+			"presented_fingerprint", hostKey.GetPendingFingerprint())
+		ret.Status = pbs.SSHHOSTKEYSTATUS_SSHHOSTKEYSTATUS_MISMATCH
+	}
+	return ret, nil
+}
@@ -0,0 +1,250 @@
+package controller
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+)
+
+// apiPathPrefix is the prefix of the paths of the requests that are rate
+// limited.  The UI and the SCIM endpoint are not limited.
+const apiPathPrefix = "/v1/"
+
+// rateLimitSweepInterval is how often idle buckets are removed at most.
+const rateLimitSweepInterval = time.Minute
+
+// rateLimitMaxBuckets is the number of buckets a limit keeps at most.  Once
+// it's reached, requests that would need a new bucket share a single one.
+const rateLimitMaxBuckets = 100000
+
+// rateLimitOverflowKey is the key of the bucket shared by the requests that
+// would need a new bucket after reaching rateLimitMaxBuckets.
+const rateLimitOverflowKey = "overflow"
+
+// rateLimiter enforces the controller's API rate limits.  A request is only
+// allowed when every limit applying to it has a token for it.
+type rateLimiter struct {
+	limits []*rateLimit
+}
+
+// rateLimit is the token buckets of a single configured API rate limit, keyed
+// by what the limit counts requests per and the endpoint class.
+type rateLimit struct {
+	per       string
+	endpoints map[string]bool
+	limit     rate.Limit
+	burst     int
+	period    time.Duration
+
+	// maxBuckets is rateLimitMaxBuckets other than in tests
+	maxBuckets int
+
+	l         sync.Mutex
+	buckets   map[string]*rateLimitBucket
+	lastSweep time.Time
+}
+
+type rateLimitBucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// newRateLimiter returns a rateLimiter for the given limits, or nil when
+// there are none.
+func newRateLimiter(limits []*config.ApiRateLimit) *rateLimiter {
+	if len(limits) == 0 {
+		return nil
+	}
+	rl := &rateLimiter{
+		limits: make([]*rateLimit, 0, len(limits)),
+	}
+	for _, l := range limits {
+		endpoints := make(map[string]bool, len(l.Endpoints))
+		for _, e := range l.Endpoints {
+			endpoints[e] = true
+		}
+		rl.limits = append(rl.limits, &rateLimit{
+			per:        l.Per,
+			endpoints:  endpoints,
+			limit:      rate.Limit(float64(l.Limit) / l.PeriodDuration.Seconds()),
+			burst:      l.Limit,
+			period:     l.PeriodDuration,
+			maxBuckets: rateLimitMaxBuckets,
+			buckets:    make(map[string]*rateLimitBucket),
+		})
+	}
+	return rl
+}
+
+// allow reports whether the request may be served.  When it may not, the
+// returned duration is how long the client has to wait before retrying and
+// the limits rejecting the request are returned.  Tokens are only taken when
+// the request is allowed.
+//
+// Requests are counted per IP address until their auth token is verified, so
+// authTokenId, which looks the token up, is only called once the request is
+// within every limit for its IP address.  It returns an empty string when the
+// request has no valid token, in which case the request stays counted per IP
+// address by the limits counting per auth token.
+func (rl *rateLimiter) allow(now time.Time, class, clientIp string, authTokenId func() string) (bool, time.Duration, []*rateLimit) {
+	var reservations []*rate.Reservation
+	var rejectedBy []*rateLimit
+	var wait time.Duration
+	reserve := func(l *rateLimit, key string) *rate.Reservation {
+		r := l.reserve(now, key+"|"+class, class)
+		if d := r.DelayFrom(now); d > 0 {
+			rejectedBy = append(rejectedBy, l)
+			if d > wait {
+				wait = d
+			}
+		}
+		return r
+	}
+
+	// perAuthToken is the index in reservations of the per IP reservation of
+	// each limit counting per auth token
+	perAuthToken := make(map[*rateLimit]int)
+	for _, l := range rl.limits {
+		if len(l.endpoints) > 0 && !l.endpoints[class] {
+			continue
+		}
+		if l.per == config.RateLimitPerAuthToken {
+			perAuthToken[l] = len(reservations)
+		}
+		reservations = append(reservations, reserve(l, "ip:"+clientIp))
+	}
+	if len(rejectedBy) == 0 && len(perAuthToken) > 0 {
+		if id := authTokenId(); id != "" {
+			for l, i := range perAuthToken {
+				reservations[i].CancelAt(now)
+				reservations[i] = reserve(l, "at:"+id)
+			}
+		}
+	}
+	if len(rejectedBy) == 0 {
+		return true, 0, nil
+	}
+	for _, r := range reservations {
+		r.CancelAt(now)
+	}
+	return false, wait, rejectedBy
+}
+
+// reserve takes a token from the bucket with the given key, creating the
+// bucket if needed.  When there are too many buckets the request is counted
+// in the endpoint class's overflow bucket instead.
+func (l *rateLimit) reserve(now time.Time, key, class string) *rate.Reservation {
+	l.l.Lock()
+	defer l.l.Unlock()
+	l.sweep(now, false)
+	b, ok := l.buckets[key]
+	if !ok && len(l.buckets) >= l.maxBuckets {
+		l.sweep(now, true)
+		if len(l.buckets) >= l.maxBuckets {
+			key = rateLimitOverflowKey + "|" + class
+			b, ok = l.buckets[key]
+		}
+	}
+	if !ok {
+		b = &rateLimitBucket{
+			limiter: rate.NewLimiter(l.limit, l.burst),
+		}
+		l.buckets[key] = b
+	}
+	b.lastUsed = now
+	return b.limiter.ReserveN(now, 1)
+}
+
+// sweep removes the buckets that have not been used for a full period, as
+// they have refilled and are indistinguishable from new ones.  It must be
+// called with the lock held.  Unless forced, it does nothing if it ran less
+// than rateLimitSweepInterval ago.
+func (l *rateLimit) sweep(now time.Time, force bool) {
+	if !force && now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for k, b := range l.buckets {
+		if now.Sub(b.lastUsed) >= l.period {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// endpointClass returns the class of API endpoint the request is for, or an
+// empty string when it is not an API request.
+func endpointClass(r *http.Request) string {
+	if !strings.HasPrefix(r.URL.Path, apiPathPrefix) {
+		return ""
+	}
+	path := strings.TrimPrefix(r.URL.Path, apiPathPrefix)
+	switch {
+	case strings.Contains(path, ":authenticate"):
+		return config.RateLimitEndpointAuthenticate
+	case r.Method == http.MethodOptions:
+		// CORS preflight requests are answered without reaching the API
+		return ""
+	case r.Method == http.MethodGet && !strings.ContainsAny(path, "/:"):
+		return config.RateLimitEndpointList
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		return config.RateLimitEndpointRead
+	default:
+		return config.RateLimitEndpointWrite
+	}
+}
+
+// clientIp returns the IP address of the client making the request, which
+// has already been replaced by the X-Forwarded-For address if the listener
+// is configured to trust it.
+func clientIp(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func wrapHandlerWithRateLimiter(h http.Handler, c *Controller) http.Handler {
+	if c.apiRateLimiter == nil {
+		return h
+	}
+	errHandler := handlers.ErrorHandler(c.logger)
+	mar := handlers.JSONMarshaler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		class := endpointClass(r)
+		if class == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		// Only tokens issued by the controller are counted per token;
+		// whether they have expired or are authorized is checked later.
+		authTokenId := func() string {
+			return auth.DecryptTokenFromRequest(r.Context(), c.logger, c.kms, c.AuthTokenRepoFn, r)
+		}
+		ok, wait, rejectedBy := c.apiRateLimiter.allow(time.Now(), class, clientIp(r), authTokenId)
+		if ok {
+			h.ServeHTTP(w, r)
+			return
+		}
+		for _, l := range rejectedBy {
+			metrics.IncrCounterWithLabels([]string{"controller", "api", "rate_limited"}, 1, []metrics.Label{
+				{Name: "per", Value: l.per},
+				{Name: "endpoint", Value: class},
+			})
+		}
+		retryAfter := int(math.Ceil(wait.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		errHandler(r.Context(), nil, mar, w, r, handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "Too many requests, retry after %d seconds.", retryAfter))
+	})
+}
@@ -0,0 +1,277 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointClass(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{http.MethodGet, "/", ""},
+		{http.MethodPost, "/scim/v2/Users", ""},
+		{http.MethodGet, "/v1/targets", config.RateLimitEndpointList},
+		{http.MethodGet, "/v1/targets/ttcp_1234567890", config.RateLimitEndpointRead},
+		{http.MethodGet, "/v1/scopes/global:list-keys", config.RateLimitEndpointRead},
+		{http.MethodPost, "/v1/targets", config.RateLimitEndpointWrite},
+		{http.MethodPatch, "/v1/targets/ttcp_1234567890", config.RateLimitEndpointWrite},
+		{http.MethodDelete, "/v1/targets/ttcp_1234567890", config.RateLimitEndpointWrite},
+		{http.MethodPost, "/v1/targets/ttcp_1234567890:authorize-session", config.RateLimitEndpointWrite},
+		{http.MethodPost, "/v1/auth-methods/ampw_1234567890:authenticate", config.RateLimitEndpointAuthenticate},
+		{http.MethodGet, "/v1/auth-methods/amoidc_1234567890:authenticate:callback", config.RateLimitEndpointAuthenticate},
+		{http.MethodOptions, "/v1/targets", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			assert.Equal(t, tt.want, endpointClass(r))
+		})
+	}
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+
+	assert.Nil(newRateLimiter(nil))
+
+	rl := newRateLimiter([]*config.ApiRateLimit{
+		{
+			Per:            config.RateLimitPerIp,
+			Endpoints:      []string{config.RateLimitEndpointAuthenticate},
+			Limit:          2,
+			PeriodDuration: time.Minute,
+		},
+		{
+			Per:            config.RateLimitPerAuthToken,
+			Limit:          3,
+			PeriodDuration: time.Minute,
+		},
+	})
+
+	noToken := func() string { return "" }
+	token := func() string { return "at_1234567890" }
+	// The token is only looked up once the per IP limits allow the request
+	noLookup := func() string {
+		t.Error("unexpected auth token lookup")
+		return ""
+	}
+
+	// Two authentications per IP address per minute
+	ok, _, _ := rl.allow(now, config.RateLimitEndpointAuthenticate, "127.0.0.1", noToken)
+	assert.True(ok)
+	ok, _, _ = rl.allow(now, config.RateLimitEndpointAuthenticate, "127.0.0.1", noToken)
+	assert.True(ok)
+	ok, wait, rejectedBy := rl.allow(now, config.RateLimitEndpointAuthenticate, "127.0.0.1", noLookup)
+	assert.False(ok)
+	assert.Equal(30*time.Second, wait)
+	require.Len(t, rejectedBy, 1)
+	assert.Equal(config.RateLimitPerIp, rejectedBy[0].per)
+
+	// Other addresses and endpoint classes are counted separately
+	ok, _, _ = rl.allow(now, config.RateLimitEndpointAuthenticate, "127.0.0.2", noToken)
+	assert.True(ok)
+	ok, _, _ = rl.allow(now, config.RateLimitEndpointRead, "127.0.0.1", noToken)
+	assert.True(ok)
+
+	// Anonymous requests are counted per IP address by the auth token limit
+	ok, _, _ = rl.allow(now, config.RateLimitEndpointRead, "127.0.0.1", noToken)
+	assert.True(ok)
+	ok, _, _ = rl.allow(now, config.RateLimitEndpointRead, "127.0.0.1", noToken)
+	assert.True(ok)
+	ok, wait, rejectedBy = rl.allow(now, config.RateLimitEndpointRead, "127.0.0.1", noLookup)
+	assert.False(ok)
+	assert.Equal(20*time.Second, wait)
+	require.Len(t, rejectedBy, 1)
+	assert.Equal(config.RateLimitPerAuthToken, rejectedBy[0].per)
+
+	// Requests with a token are counted per token, not per IP address
+	for i := 0; i < 3; i++ {
+		ok, _, _ = rl.allow(now, config.RateLimitEndpointRead, "127.0.0.3", token)
+		assert.True(ok)
+	}
+	ok, _, rejectedBy = rl.allow(now, config.RateLimitEndpointRead, "127.0.0.3", token)
+	assert.False(ok)
+	require.Len(t, rejectedBy, 1)
+	assert.Equal(config.RateLimitPerAuthToken, rejectedBy[0].per)
+	ok, _, _ = rl.allow(now, config.RateLimitEndpointRead, "127.0.0.3", noToken)
+	assert.True(ok)
+
+	// Buckets refill over time
+	ok, _, _ = rl.allow(now.Add(30*time.Second), config.RateLimitEndpointAuthenticate, "127.0.0.1", noToken)
+	assert.True(ok)
+}
+
+func TestWrapHandlerWithRateLimiter(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	c := &Controller{
+		logger: hclog.NewNullLogger(),
+		apiRateLimiter: newRateLimiter([]*config.ApiRateLimit{
+			{
+				Per:            config.RateLimitPerEndpoint,
+				Endpoints:      []string{config.RateLimitEndpointList},
+				Limit:          1,
+				PeriodDuration: time.Hour,
+			},
+		}),
+	}
+	h := wrapHandlerWithRateLimiter(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), c)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/targets", nil))
+	assert.Equal(http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/targets", nil))
+	assert.Equal(http.StatusTooManyRequests, rec.Code)
+	assert.Equal("3600", rec.Header().Get("Retry-After"))
+	var body map[string]interface{}
+	require.NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal("ResourceExhausted", body["kind"])
+
+	// Other endpoint classes and the UI aren't limited
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/targets/ttcp_1234567890", nil))
+	assert.Equal(http.StatusOK, rec.Code)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(http.StatusOK, rec.Code)
+}
+
+func TestRateLimit_MaxBuckets(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+	rl := newRateLimiter([]*config.ApiRateLimit{
+		{
+			Per:            config.RateLimitPerIp,
+			Limit:          1,
+			PeriodDuration: time.Minute,
+		},
+	})
+	rl.limits[0].maxBuckets = 2
+	noToken := func() string { return "" }
+
+	ok, _, _ := rl.allow(now, config.RateLimitEndpointRead, "127.0.0.1", noToken)
+	assert.True(ok)
+	ok, _, _ = rl.allow(now, config.RateLimitEndpointRead, "127.0.0.2", noToken)
+	assert.True(ok)
+
+	// New clients share the overflow bucket once the limit has too many
+	ok, _, _ = rl.allow(now, config.RateLimitEndpointRead, "127.0.0.3", noToken)
+	assert.True(ok)
+	ok, _, _ = rl.allow(now, config.RateLimitEndpointRead, "127.0.0.4", noToken)
+	assert.False(ok)
+	assert.Len(rl.limits[0].buckets, 3)
+
+	// Idle buckets are removed to make room for new clients
+	ok, _, _ = rl.allow(now.Add(time.Minute), config.RateLimitEndpointRead, "127.0.0.4", noToken)
+	assert.True(ok)
+	assert.Len(rl.limits[0].buckets, 1)
+}
+
+func TestWrapHandlerWithRateLimiter_RandomTokens(t *testing.T) {
+	assert := assert.New(t)
+	c := &Controller{
+		logger: hclog.NewNullLogger(),
+		apiRateLimiter: newRateLimiter([]*config.ApiRateLimit{
+			{
+				Per:            config.RateLimitPerAuthToken,
+				Limit:          2,
+				PeriodDuration: time.Hour,
+			},
+		}),
+	}
+	h := wrapHandlerWithRateLimiter(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), c)
+
+	// Tokens that weren't issued by the controller are counted per IP
+	// address, so a new made up token on every request doesn't help
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests} {
+		r := httptest.NewRequest(http.MethodGet, "/v1/targets", nil)
+		r.Header.Set("Authorization", fmt.Sprintf("Bearer at_%010d_s1abcdefghijklmnop", i))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		assert.Equal(want, rec.Code, "request %d", i)
+	}
+	assert.Len(c.apiRateLimiter.limits[0].buckets, 1)
+}
+
+func TestWrapHandlerWithRateLimiter_FakeTokens(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	tokenRepo, err := authtoken.NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	var lookups int
+	c := &Controller{
+		logger: hclog.NewNullLogger(),
+		kms:    kmsCache,
+		AuthTokenRepoFn: func() (*authtoken.Repository, error) {
+			lookups++
+			return tokenRepo, nil
+		},
+		apiRateLimiter: newRateLimiter([]*config.ApiRateLimit{
+			{
+				Per:            config.RateLimitPerIp,
+				Limit:          3,
+				PeriodDuration: time.Hour,
+			},
+			{
+				Per:            config.RateLimitPerAuthToken,
+				Limit:          2,
+				PeriodDuration: time.Hour,
+			},
+		}),
+	}
+	h := wrapHandlerWithRateLimiter(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), c)
+	request := func(remoteAddr, token string) int {
+		r := httptest.NewRequest(http.MethodGet, "/v1/targets", nil)
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		return rec.Code
+	}
+
+	// Made up tokens are counted per IP address, and aren't looked up once
+	// the requests from the address are over a limit
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests} {
+		assert.Equal(want, request("127.0.0.1:1234", fmt.Sprintf("at_%010d_s1abcdefghijklmnop", i)), "request %d", i)
+	}
+	assert.Equal(2, lookups)
+
+	// Tokens issued by the controller are counted per token
+	o, _ := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kmsCache, o.GetPublicId())
+	encToken, err := authtoken.EncryptToken(context.Background(), kmsCache, o.GetPublicId(), at.GetPublicId(), at.GetToken())
+	require.NoError(err)
+	token := at.GetPublicId() + "_" + encToken
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		assert.Equal(want, request(fmt.Sprintf("127.0.0.%d:1234", i+2), token), "request %d", i)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/types/resource"
 )
@@ -154,3 +155,30 @@ func (c *Controller) startCloseExpiredPendingTokens(cancelCtx context.Context) {
 		}
 	}()
 }
+
+func (c *Controller) startOidcRevalidationTicking(cancelCtx context.Context) {
+	interval := c.conf.RawConfig.Controller.OidcRevalidationIntervalDuration
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("oidc revalidation ticking shutting down")
+				return
+
+			case <-timer.C:
+				revokedCount, err := oidc.RevalidateProviderTokens(cancelCtx, c.OidcRepoFn, c.AuthTokenRepoFn, c.SessionRepoFn, interval)
+				if err != nil {
+					c.logger.Error("error performing oidc revalidation", "error", err)
+				}
+				if revokedCount > 0 {
+					c.logger.Info("oidc revalidation successful", "auth_tokens_revoked", revokedCount)
+				}
+				timer.Reset(interval)
+			}
+		}
+	}()
+}
@@ -71,14 +71,16 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 		tofuToken := si.lookupSessionResponse.GetTofuToken()
 		version := si.lookupSessionResponse.GetVersion()
 		endpoint := si.lookupSessionResponse.GetEndpoint()
+		activeWorkerId := si.lookupSessionResponse.GetWorkerId()
 		// userId := si.lookupSessionResponse.GetAuthorization()
 		sessStatus := si.status
 		si.RUnlock()
 
 		w.logger.Trace("found session in session info map")
 
+		// The multiplexed protocol is preferred by clients that offer it
 		opts := &websocket.AcceptOptions{
-			Subprotocols: []string{globals.TcpProxyV1},
+			Subprotocols: []string{globals.TcpProxyMuxV1, globals.TcpProxyV1, globals.UdpProxyV1},
 		}
 		conn, err := websocket.Accept(wr, r, opts)
 		if err != nil {
@@ -114,13 +116,28 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 				conn.Close(websocket.StatusPolicyViolation, "tofu token not allowed")
 				return
 			}
+			// The session was activated on another worker, which may have
+			// been restarted, so take it over
+			if activeWorkerId != w.conf.RawConfig.Worker.Name &&
+				handshake.Command != proxy.HANDSHAKECOMMAND_HANDSHAKECOMMAND_SESSION_CANCEL {
+				w.logger.Trace("resuming session", "previous_worker_id", activeWorkerId)
+				sessStatus, err = w.resumeSession(r.Context(), sessionId, handshake.GetTofuToken())
+				if err != nil {
+					w.logger.Error("unable to resume session", "error", err)
+					conn.Close(websocket.StatusInternalError, "unable to resume session")
+					return
+				}
+				si.Lock()
+				si.lookupSessionResponse.WorkerId = w.conf.RawConfig.Worker.Name
+				si.Unlock()
+			}
 		} else {
 			if sessStatus != pbs.SESSIONSTATUS_SESSIONSTATUS_PENDING {
 				w.logger.Error("no tofu token but not in correct session state", "error", err)
 				conn.Close(websocket.StatusInternalError, "refusing to activate session")
 				return
 			}
-			if handshake.Command == proxy.HANDSHAKECOMMAND_HANDSHAKECOMMAND_UNSPECIFIED {
+			if handshake.Command != proxy.HANDSHAKECOMMAND_HANDSHAKECOMMAND_SESSION_CANCEL {
 				w.logger.Trace("activating session")
 				sessStatus, err = w.activateSession(r.Context(), sessionId, handshake.GetTofuToken(), version)
 				if err != nil {
@@ -143,6 +160,36 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 			return
 		}
 
+		if handshake.Command == proxy.HANDSHAKECOMMAND_HANDSHAKECOMMAND_SSH_HOST_KEY {
+			si.Lock()
+			si.status = sessStatus
+			si.Unlock()
+			w.handleSshHostKey(connCtx, conn, si, endpoint)
+			return
+		}
+
+		if conn.Subprotocol() == globals.TcpProxyMuxV1 {
+			// Connections are authorized per stream, so the number left isn't
+			// known yet
+			si.Lock()
+			si.status = sessStatus
+			connectionLimit := si.lookupSessionResponse.GetConnectionLimit()
+			si.Unlock()
+
+			handshakeResult := &proxy.HandshakeResult{
+				Expiration:      expiration,
+				ConnectionLimit: connectionLimit,
+				ConnectionsLeft: -1,
+			}
+			if err := wspb.Write(connCtx, conn, handshakeResult); err != nil {
+				w.logger.Error("error sending handshake result to client", "error", err)
+				conn.Close(websocket.StatusProtocolError, "unable to send handshake result")
+				return
+			}
+			w.handleTcpProxyMuxV1(r.Context(), connCtx, clientAddr, conn, si, endpoint)
+			return
+		}
+
 		var ci *connInfo
 		var connsLeft int32
 		ci, connsLeft, err = w.authorizeConnection(r.Context(), sessionId)
@@ -185,6 +232,8 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 		switch conn.Subprotocol() {
 		case globals.TcpProxyV1:
 			w.handleTcpProxyV1(connCtx, clientAddr, conn, si, ci.id, endpoint)
+		case globals.UdpProxyV1:
+			w.handleUdpProxyV1(connCtx, clientAddr, conn, si, ci, endpoint)
 		default:
 			conn.Close(websocket.StatusProtocolError, "unsupported-protocol")
 			return
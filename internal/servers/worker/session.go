@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
@@ -20,6 +21,13 @@ const (
 )
 
 type connInfo struct {
+	// The traffic proxied for the connection, updated atomically. Datagrams
+	// are only counted for connections to udp targets.
+	bytesUp       uint64
+	bytesDown     uint64
+	datagramsUp   uint64
+	datagramsDown uint64
+
 	id         string
 	connCtx    context.Context
 	connCancel context.CancelFunc
@@ -153,6 +161,30 @@ func (w *Worker) activateSession(ctx context.Context, sessionId, tofuToken strin
 	return resp.GetStatus(), nil
 }
 
+func (w *Worker) resumeSession(ctx context.Context, sessionId, tofuToken string) (pbs.SESSIONSTATUS, error) {
+	rawConn := w.controllerSessionConn.Load()
+	if rawConn == nil {
+		return pbs.SESSIONSTATUS_SESSIONSTATUS_UNSPECIFIED, errors.New("could not get a controller client")
+	}
+	conn, ok := rawConn.(pbs.SessionServiceClient)
+	if !ok {
+		return pbs.SESSIONSTATUS_SESSIONSTATUS_UNSPECIFIED, errors.New("could not cast atomic controller client to the real thing")
+	}
+	if conn == nil {
+		return pbs.SESSIONSTATUS_SESSIONSTATUS_UNSPECIFIED, errors.New("controller client is nil")
+	}
+
+	resp, err := conn.ResumeSession(ctx, &pbs.ResumeSessionRequest{
+		SessionId: sessionId,
+		TofuToken: tofuToken,
+		WorkerId:  w.conf.RawConfig.Worker.Name,
+	})
+	if err != nil {
+		return pbs.SESSIONSTATUS_SESSIONSTATUS_UNSPECIFIED, fmt.Errorf("error resuming session: %w", err)
+	}
+	return resp.GetStatus(), nil
+}
+
 func (w *Worker) cancelSession(ctx context.Context, sessionId string) (pbs.SESSIONSTATUS, error) {
 	rawConn := w.controllerSessionConn.Load()
 	if rawConn == nil {
@@ -226,6 +258,29 @@ func (w *Worker) connectConnection(ctx context.Context, req *pbs.ConnectConnecti
 	return resp.GetStatus(), nil
 }
 
+func (w *Worker) reportSshHostKey(ctx context.Context, sessionId string, hostKey []byte) (*pbs.ReportSshHostKeyResponse, error) {
+	rawConn := w.controllerSessionConn.Load()
+	if rawConn == nil {
+		return nil, errors.New("could not get a controller client")
+	}
+	conn, ok := rawConn.(pbs.SessionServiceClient)
+	if !ok {
+		return nil, errors.New("could not cast atomic controller client to the real thing")
+	}
+	if conn == nil {
+		return nil, errors.New("controller client is nil")
+	}
+
+	resp, err := conn.ReportSshHostKey(ctx, &pbs.ReportSshHostKeyRequest{
+		SessionId: sessionId,
+		HostKey:   hostKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reporting ssh host key: %w", err)
+	}
+	return resp, nil
+}
+
 func (w *Worker) closeConnection(ctx context.Context, req *pbs.CloseConnectionRequest) (*pbs.CloseConnectionResponse, error) {
 	rawConn := w.controllerSessionConn.Load()
 	if rawConn == nil {
@@ -254,11 +309,23 @@ func (w *Worker) closeConnections(ctx context.Context, closeMap map[string]strin
 	w.logger.Trace("marking connections as closed", "session_and_connection_ids", fmt.Sprintf("%#v", closeMap))
 
 	closeData := make([]*pbs.CloseConnectionRequestData, 0, len(closeMap))
-	for connId := range closeMap {
-		closeData = append(closeData, &pbs.CloseConnectionRequestData{
+	for connId, sessionId := range closeMap {
+		data := &pbs.CloseConnectionRequestData{
 			ConnectionId: connId,
 			Reason:       session.UnknownReason.String(),
-		})
+		}
+		if siRaw, ok := w.sessionInfoMap.Load(sessionId); ok {
+			si := siRaw.(*sessionInfo)
+			si.RLock()
+			if ci := si.connInfoMap[connId]; ci != nil {
+				data.BytesUp = atomic.LoadUint64(&ci.bytesUp)
+				data.BytesDown = atomic.LoadUint64(&ci.bytesDown)
+				data.DatagramsUp = atomic.LoadUint64(&ci.datagramsUp)
+				data.DatagramsDown = atomic.LoadUint64(&ci.datagramsDown)
+			}
+			si.RUnlock()
+		}
+		closeData = append(closeData, data)
 	}
 	closeInfo := &pbs.CloseConnectionRequest{
 		CloseRequestData: closeData,
@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"time"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/proxy"
+	"golang.org/x/crypto/ssh"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wspb"
+)
+
+// sshHostKeyTimeout is how long the endpoint has to present its SSH host key.
+const sshHostKeyTimeout = 10 * time.Second
+
+// errSshHostKeyFetched aborts an SSH handshake once the host key is known.
+var errSshHostKeyFetched = errors.New("ssh host key fetched")
+
+// handleSshHostKey fetches the SSH host key presented by the session's
+// endpoint and reports it to the controller. The client is sent the key
+// pinned for the session's host along with the presented key. It doesn't
+// use one of the session's connections.
+func (w *Worker) handleSshHostKey(connCtx context.Context, conn *websocket.Conn, si *sessionInfo, endpoint string) {
+	si.RLock()
+	sessionId := si.lookupSessionResponse.GetAuthorization().GetSessionId()
+	hostId := si.lookupSessionResponse.GetHostId()
+	si.RUnlock()
+
+	hostKey, err := fetchSshHostKey(connCtx, endpoint)
+	if err != nil {
+		w.logger.Error("error fetching ssh host key", "error", err, "session_id", sessionId, "endpoint", endpoint)
+		conn.Close(websocket.StatusInternalError, "unable to fetch ssh host key")
+		return
+	}
+	resp, err := w.reportSshHostKey(connCtx, sessionId, hostKey.Marshal())
+	if err != nil {
+		w.logger.Error("unable to report ssh host key", "error", err, "session_id", sessionId)
+		conn.Close(websocket.StatusInternalError, "unable to report ssh host key")
+		return
+	}
+	if resp.GetStatus() == pbs.SSHHOSTKEYSTATUS_SSHHOSTKEYSTATUS_MISMATCH {
+		w.logger.Warn("WARNING: ssh host key mismatch",
+			"session_id", sessionId,
+			"host_id", hostId,
+			"fingerprint", resp.GetFingerprint(),
+			"presented_fingerprint", ssh.FingerprintSHA256(hostKey))
+	}
+
+	result := &proxy.SshHostKeyResult{
+		HostKey:              resp.GetHostKey(),
+		Fingerprint:          resp.GetFingerprint(),
+		PresentedHostKey:     hostKey.Marshal(),
+		PresentedFingerprint: ssh.FingerprintSHA256(hostKey),
+	}
+	if err := wspb.Write(connCtx, conn, result); err != nil {
+		w.logger.Error("error sending ssh host key result to client", "error", err)
+		conn.Close(websocket.StatusProtocolError, "unable to send ssh host key result")
+		return
+	}
+	conn.Close(websocket.StatusNormalClosure, "done")
+}
+
+// fetchSshHostKey dials endpoint and starts an SSH handshake, which is
+// abandoned once the server has presented its host key.
+func fetchSshHostKey(ctx context.Context, endpoint string) (ssh.PublicKey, error) {
+	sessionUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.New("cannot parse endpoint url")
+	}
+	if sessionUrl.Scheme != "tcp" {
+		return nil, errors.New("invalid scheme for ssh")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sshHostKeyTimeout)
+	defer cancel()
+	var dialer net.Dialer
+	remoteConn, err := dialer.DialContext(ctx, "tcp", sessionUrl.Host)
+	if err != nil {
+		return nil, err
+	}
+	defer remoteConn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := remoteConn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	var hostKey ssh.PublicKey
+	_, _, _, err = ssh.NewClientConn(remoteConn, sessionUrl.Host, &ssh.ClientConfig{
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			hostKey = key
+			return errSshHostKeyFetched
+		},
+	})
+	switch {
+	case hostKey != nil:
+		return hostKey, nil
+	case err != nil:
+		return nil, err
+	default:
+		return nil, errors.New("no ssh host key presented")
+	}
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net"
 	"net/url"
@@ -13,6 +14,22 @@ import (
 )
 
 func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn, si *sessionInfo, connectionId, endpoint string) {
+	tcpRemoteConn, err := w.connectTcpEndpoint(connCtx, clientAddr, si, connectionId, endpoint)
+	if err != nil {
+		conn.Close(websocket.StatusInternalError, err.Error())
+		return
+	}
+
+	// Get a wrapped net.Conn so we can use io.Copy
+	netConn := websocket.NetConn(connCtx, conn, websocket.MessageBinary)
+
+	w.copyTcpProxy(netConn, tcpRemoteConn)
+}
+
+// connectTcpEndpoint dials the session's endpoint and marks the connection
+// as connected. Errors are logged, and returned as a reason suitable for the
+// client.
+func (w *Worker) connectTcpEndpoint(connCtx context.Context, clientAddr *net.TCPAddr, si *sessionInfo, connectionId, endpoint string) (*net.TCPConn, error) {
 	si.RLock()
 	sessionId := si.lookupSessionResponse.GetAuthorization().GetSessionId()
 	si.RUnlock()
@@ -20,19 +37,16 @@ func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAd
 	sessionUrl, err := url.Parse(endpoint)
 	if err != nil {
 		w.logger.Error("error parsing endpoint information", "error", err, "session_id", sessionId, "endpoint", endpoint)
-		conn.Close(websocket.StatusInternalError, "cannot parse endpoint url")
-		return
+		return nil, errors.New("cannot parse endpoint url")
 	}
 	if sessionUrl.Scheme != "tcp" {
 		w.logger.Error("invalid scheme for tcp proxy", "error", err, "session_id", sessionId, "endpoint", endpoint)
-		conn.Close(websocket.StatusInternalError, "invalid scheme for type")
-		return
+		return nil, errors.New("invalid scheme for type")
 	}
 	remoteConn, err := net.Dial("tcp", sessionUrl.Host)
 	if err != nil {
 		w.logger.Error("error dialing endpoint", "error", err, "endpoint", endpoint)
-		conn.Close(websocket.StatusInternalError, "endpoint dialing failed")
-		return
+		return nil, errors.New("endpoint dialing failed")
 	}
 	// Assert this for better Go 1.11 splice support
 	tcpRemoteConn := remoteConn.(*net.TCPConn)
@@ -50,16 +64,19 @@ func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAd
 	connStatus, err := w.connectConnection(connCtx, connectionInfo)
 	if err != nil {
 		w.logger.Error("error marking connection as connected", "error", err)
-		conn.Close(websocket.StatusInternalError, "failed to mark connection as connected")
-		return
+		tcpRemoteConn.Close()
+		return nil, errors.New("failed to mark connection as connected")
 	}
 	si.Lock()
 	si.connInfoMap[connectionId].status = connStatus
 	si.Unlock()
 
-	// Get a wrapped net.Conn so we can use io.Copy
-	netConn := websocket.NetConn(connCtx, conn, websocket.MessageBinary)
+	return tcpRemoteConn, nil
+}
 
+// copyTcpProxy copies between the client's connection and the endpoint's
+// until either is closed.
+func (w *Worker) copyTcpProxy(netConn net.Conn, tcpRemoteConn *net.TCPConn) {
 	connWg := new(sync.WaitGroup)
 	connWg.Add(2)
 	go func() {
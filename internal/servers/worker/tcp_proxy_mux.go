@@ -0,0 +1,133 @@
+package worker
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/proxy"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/yamux"
+	"google.golang.org/protobuf/proto"
+	"nhooyr.io/websocket"
+)
+
+// Each stream of a multiplexed session begins with a result the worker sends
+// once it has authorized the stream's connection: a status byte, then the
+// uvarint length of a payload which is the marshaled HandshakeResult if the
+// status is streamStatusConnected, and otherwise the reason the connection
+// was refused.
+const (
+	streamStatusConnected byte = 0
+	streamStatusRefused   byte = 1
+)
+
+// handleTcpProxyMuxV1 proxies each yamux stream the client opens over conn as
+// a separate connection of the session. reqCtx is used for calls to the
+// controller which should outlive the streams.
+func (w *Worker) handleTcpProxyMuxV1(reqCtx, connCtx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn, si *sessionInfo, endpoint string) {
+	netConn := websocket.NetConn(connCtx, conn, websocket.MessageBinary)
+
+	config := yamux.DefaultConfig()
+	config.LogOutput = nil
+	config.Logger = w.logger.StandardLogger(&hclog.StandardLoggerOptions{InferLevels: true})
+	muxSession, err := yamux.Server(netConn, config)
+	if err != nil {
+		w.logger.Error("error starting multiplexed session", "error", err)
+		conn.Close(websocket.StatusInternalError, "unable to start multiplexing")
+		return
+	}
+	defer muxSession.Close()
+	go func() {
+		<-connCtx.Done()
+		muxSession.Close()
+	}()
+
+	streamWg := new(sync.WaitGroup)
+	defer streamWg.Wait()
+	for {
+		stream, err := muxSession.Accept()
+		if err != nil {
+			w.logger.Trace("multiplexed session done", "error", err)
+			return
+		}
+		streamWg.Add(1)
+		go func() {
+			defer streamWg.Done()
+			w.handleTcpProxyMuxStream(reqCtx, connCtx, clientAddr, stream, si, endpoint)
+		}()
+	}
+}
+
+// handleTcpProxyMuxStream authorizes a connection for the stream and
+// proxies it to the endpoint.
+func (w *Worker) handleTcpProxyMuxStream(reqCtx, connCtx context.Context, clientAddr *net.TCPAddr, stream net.Conn, si *sessionInfo, endpoint string) {
+	defer stream.Close()
+
+	ci, connsLeft, err := w.authorizeConnection(reqCtx, si.id)
+	if err != nil {
+		w.logger.Error("unable to authorize connection", "error", err)
+		w.writeStreamResult(stream, streamStatusRefused, []byte("unable to authorize connection"))
+		return
+	}
+
+	defer func() {
+		connectionId := ci.id
+		if err := w.closeConnections(reqCtx, map[string]string{
+			connectionId: si.id,
+		}); err != nil {
+			w.logger.Error("error marking connection closed", "error", err, "connection_id", connectionId)
+		}
+	}()
+
+	// Canceling the connection, e.g. when its session is canceled, closes
+	// only its stream
+	streamCtx, streamCancel := context.WithCancel(connCtx)
+	defer streamCancel()
+	go func() {
+		<-streamCtx.Done()
+		stream.Close()
+	}()
+
+	si.Lock()
+	ci.connCtx = streamCtx
+	ci.connCancel = streamCancel
+	si.connInfoMap[ci.id] = ci
+	expiration := si.lookupSessionResponse.GetExpiration()
+	connectionLimit := si.lookupSessionResponse.GetConnectionLimit()
+	si.Unlock()
+
+	w.logger.Trace("authorized connection", "connection_id", ci.id)
+
+	result, err := proto.Marshal(&proxy.HandshakeResult{
+		Expiration:      expiration,
+		ConnectionLimit: connectionLimit,
+		ConnectionsLeft: connsLeft,
+	})
+	if err != nil {
+		w.logger.Error("error marshaling handshake result", "error", err)
+		return
+	}
+	if !w.writeStreamResult(stream, streamStatusConnected, result) {
+		return
+	}
+
+	tcpRemoteConn, err := w.connectTcpEndpoint(streamCtx, clientAddr, si, ci.id, endpoint)
+	if err != nil {
+		return
+	}
+	w.copyTcpProxy(stream, tcpRemoteConn)
+}
+
+func (w *Worker) writeStreamResult(stream net.Conn, status byte, payload []byte) bool {
+	buf := make([]byte, 1+binary.MaxVarintLen64+len(payload))
+	buf[0] = status
+	n := 1 + binary.PutUvarint(buf[1:], uint64(len(payload)))
+	n += copy(buf[n:], payload)
+	if _, err := stream.Write(buf[:n]); err != nil {
+		w.logger.Error("error sending stream result to client", "error", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,168 @@
+package worker
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"nhooyr.io/websocket"
+
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+)
+
+const (
+	// udpIdleTimeout is how long a connection to a udp target is kept open
+	// without a datagram in either direction.
+	udpIdleTimeout = 2 * time.Minute
+
+	// maxDatagramSize is the largest datagram that can be framed.
+	maxDatagramSize = 64*1024 - 1
+)
+
+// handleUdpProxyV1 proxies datagrams between the client and the session's
+// endpoint. Over the websocket each datagram is framed by its length as a
+// big-endian uint16.
+func (w *Worker) handleUdpProxyV1(connCtx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn, si *sessionInfo, ci *connInfo, endpoint string) {
+	udpRemoteConn, err := w.connectUdpEndpoint(connCtx, clientAddr, si, ci.id, endpoint)
+	if err != nil {
+		conn.Close(websocket.StatusInternalError, err.Error())
+		return
+	}
+
+	netConn := websocket.NetConn(connCtx, conn, websocket.MessageBinary)
+
+	w.copyUdpProxy(netConn, udpRemoteConn, ci)
+}
+
+// connectUdpEndpoint associates a udp socket with the session's endpoint and
+// marks the connection as connected. Errors are logged, and returned as a
+// reason suitable for the client.
+func (w *Worker) connectUdpEndpoint(connCtx context.Context, clientAddr *net.TCPAddr, si *sessionInfo, connectionId, endpoint string) (*net.UDPConn, error) {
+	si.RLock()
+	sessionId := si.lookupSessionResponse.GetAuthorization().GetSessionId()
+	si.RUnlock()
+
+	sessionUrl, err := url.Parse(endpoint)
+	if err != nil {
+		w.logger.Error("error parsing endpoint information", "error", err, "session_id", sessionId, "endpoint", endpoint)
+		return nil, errors.New("cannot parse endpoint url")
+	}
+	if sessionUrl.Scheme != "udp" {
+		w.logger.Error("invalid scheme for udp proxy", "session_id", sessionId, "endpoint", endpoint)
+		return nil, errors.New("invalid scheme for type")
+	}
+	remoteConn, err := net.Dial("udp", sessionUrl.Host)
+	if err != nil {
+		w.logger.Error("error dialing endpoint", "error", err, "endpoint", endpoint)
+		return nil, errors.New("endpoint dialing failed")
+	}
+	udpRemoteConn := remoteConn.(*net.UDPConn)
+
+	endpointAddr := udpRemoteConn.RemoteAddr().(*net.UDPAddr)
+	connectionInfo := &pbs.ConnectConnectionRequest{
+		ConnectionId:       connectionId,
+		ClientTcpAddress:   clientAddr.IP.String(),
+		ClientTcpPort:      uint32(clientAddr.Port),
+		EndpointTcpAddress: endpointAddr.IP.String(),
+		EndpointTcpPort:    uint32(endpointAddr.Port),
+		Type:               "udp",
+	}
+
+	connStatus, err := w.connectConnection(connCtx, connectionInfo)
+	if err != nil {
+		w.logger.Error("error marking connection as connected", "error", err)
+		udpRemoteConn.Close()
+		return nil, errors.New("failed to mark connection as connected")
+	}
+	si.Lock()
+	si.connInfoMap[connectionId].status = connStatus
+	si.Unlock()
+
+	return udpRemoteConn, nil
+}
+
+// copyUdpProxy copies datagrams between the client's connection and the
+// endpoint's until either is closed or the connection is idle for
+// udpIdleTimeout, counting them in ci.
+func (w *Worker) copyUdpProxy(netConn net.Conn, udpRemoteConn *net.UDPConn, ci *connInfo) {
+	closeBoth := func() {
+		netConn.Close()
+		udpRemoteConn.Close()
+	}
+	idle := time.AfterFunc(udpIdleTimeout, closeBoth)
+	defer idle.Stop()
+
+	connWg := new(sync.WaitGroup)
+	connWg.Add(2)
+	go func() {
+		defer connWg.Done()
+		defer closeBoth()
+		buf := make([]byte, maxDatagramSize)
+		for {
+			n, err := udpRemoteConn.Read(buf)
+			if err != nil {
+				w.logger.Debug("copy from endpoint to client done", "error", err)
+				return
+			}
+			idle.Reset(udpIdleTimeout)
+			if err := writeDatagramFrame(netConn, buf[:n]); err != nil {
+				w.logger.Debug("copy from endpoint to client done", "error", err)
+				return
+			}
+			atomic.AddUint64(&ci.datagramsDown, 1)
+			atomic.AddUint64(&ci.bytesDown, uint64(n))
+		}
+	}()
+	go func() {
+		defer connWg.Done()
+		defer closeBoth()
+		buf := make([]byte, maxDatagramSize)
+		for {
+			n, err := readDatagramFrame(netConn, buf)
+			if err != nil {
+				w.logger.Debug("copy from client to endpoint done", "error", err)
+				return
+			}
+			idle.Reset(udpIdleTimeout)
+			if _, err := udpRemoteConn.Write(buf[:n]); err != nil {
+				w.logger.Debug("copy from client to endpoint done", "error", err)
+				return
+			}
+			atomic.AddUint64(&ci.datagramsUp, 1)
+			atomic.AddUint64(&ci.bytesUp, uint64(n))
+		}
+	}()
+	connWg.Wait()
+}
+
+// writeDatagramFrame writes d prefixed by its length.
+func writeDatagramFrame(w io.Writer, d []byte) error {
+	if len(d) > maxDatagramSize {
+		return errors.New("datagram too large")
+	}
+	frame := make([]byte, 2+len(d))
+	binary.BigEndian.PutUint16(frame, uint16(len(d)))
+	copy(frame[2:], d)
+	_, err := w.Write(frame)
+	return err
+}
+
+// readDatagramFrame reads a datagram written by writeDatagramFrame into buf,
+// which must be at least maxDatagramSize long, and returns its length.
+func readDatagramFrame(r io.Reader, buf []byte) (int, error) {
+	var hdr [2]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return 0, err
+	}
+	n := int(binary.BigEndian.Uint16(hdr[:]))
+	if _, err := io.ReadFull(r, buf[:n]); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
@@ -31,6 +31,10 @@ type Connection struct {
 	BytesUp uint64 `json:"bytes_up,omitempty" gorm:"default:null"`
 	// BytesDown of the connection
 	BytesDown uint64 `json:"bytes_down,omitempty" gorm:"default:null"`
+	// DatagramsUp of the connection, if it's to a udp target
+	DatagramsUp uint64 `json:"datagrams_up,omitempty" gorm:"default:null"`
+	// DatagramsDown of the connection, if it's to a udp target
+	DatagramsDown uint64 `json:"datagrams_down,omitempty" gorm:"default:null"`
 	// ClosedReason of the conneciont
 	ClosedReason string `json:"closed_reason,omitempty" gorm:"default:null"`
 	// CreateTime from the RDBMS
@@ -85,6 +89,8 @@ func (c *Connection) Clone() interface{} {
 		EndpointTcpPort:    c.EndpointTcpPort,
 		BytesUp:            c.BytesUp,
 		BytesDown:          c.BytesDown,
+		DatagramsUp:        c.DatagramsUp,
+		DatagramsDown:      c.DatagramsDown,
 		ClosedReason:       c.ClosedReason,
 		Version:            c.Version,
 	}
@@ -11,6 +11,11 @@ type CloseWith struct {
 	BytesUp      uint64
 	BytesDown    uint64
 	ClosedReason ClosedReason
+
+	// DatagramsUp and DatagramsDown are only set for connections to udp
+	// targets.
+	DatagramsUp   uint64
+	DatagramsDown uint64
 }
 
 func (c CloseWith) validate() error {
@@ -548,12 +548,19 @@ func (r *Repository) CloseConnections(ctx context.Context, closeWith []CloseWith
 				updateConnection.BytesUp = cw.BytesUp
 				updateConnection.BytesDown = cw.BytesDown
 				updateConnection.ClosedReason = cw.ClosedReason.String()
+				fieldMask := []string{"BytesUp", "BytesDown", "ClosedReason"}
+				// Only connections to udp targets count datagrams
+				if cw.DatagramsUp > 0 || cw.DatagramsDown > 0 {
+					updateConnection.DatagramsUp = cw.DatagramsUp
+					updateConnection.DatagramsDown = cw.DatagramsDown
+					fieldMask = append(fieldMask, "DatagramsUp", "DatagramsDown")
+				}
 				// updating the ClosedReason will trigger an insert into the
 				// session_connection_state with a state of closed.
 				rowsUpdated, err := w.Update(
 					ctx,
 					&updateConnection,
-					[]string{"BytesUp", "BytesDown", "ClosedReason"},
+					fieldMask,
 					nil,
 				)
 				if err != nil {
@@ -638,7 +645,7 @@ func (r *Repository) ActivateSession(ctx context.Context, sessionId string, sess
 			if err := updatedSession.encrypt(ctx, databaseWrapper); err != nil {
 				return errors.Wrap(err, op)
 			}
-			rowsUpdated, err := w.Update(ctx, &updatedSession, []string{"CtTofuToken"}, nil)
+			rowsUpdated, err := w.Update(ctx, &updatedSession, []string{"CtTofuToken", "ServerId", "ServerType"}, nil)
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
@@ -660,6 +667,83 @@ func (r *Repository) ActivateSession(ctx context.Context, sessionId string, sess
 	return &updatedSession, returnedStates, nil
 }
 
+// ResumeSession moves an active session to another worker and is called by a
+// worker asked to proxy a session activated by a different worker, e.g. when
+// that worker has been restarted. The tofu token must match the one the
+// session was activated with. Returns an InvalidSessionState error code if
+// the session isn't active, and a TokenMismatch error code if the tofu token
+// doesn't match.
+func (r *Repository) ResumeSession(ctx context.Context, sessionId, serverId, serverType string, tofuToken []byte) (*Session, []*State, error) {
+	const op = "session.(Repository).ResumeSession"
+	if sessionId == "" {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing session id")
+	}
+	if serverId == "" {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing server id")
+	}
+	if serverType == "" {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing server type")
+	}
+	if len(tofuToken) == 0 {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing tofu token")
+	}
+
+	updatedSession := AllocSession()
+	updatedSession.PublicId = sessionId
+	var returnedStates []*State
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			states, err := fetchStates(ctx, reader, sessionId, db.WithOrder("start_time desc"))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if len(states) == 0 || states[0].Status != StatusActive {
+				return errors.New(errors.InvalidSessionState, op, "session is not in an active state")
+			}
+			foundSession := AllocSession()
+			foundSession.PublicId = sessionId
+			if err := reader.LookupById(ctx, &foundSession); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", sessionId)))
+			}
+			databaseWrapper, err := r.kms.GetWrapper(ctx, foundSession.ScopeId, kms.KeyPurposeDatabase)
+			if err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to get database wrapper"))
+			}
+			if err := foundSession.decrypt(ctx, databaseWrapper); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("cannot decrypt session value"))
+			}
+			if subtle.ConstantTimeCompare(foundSession.TofuToken, tofuToken) != 1 {
+				return errors.New(errors.TokenMismatch, op, "tofu token mismatch")
+			}
+			if foundSession.ServerId == serverId && foundSession.ServerType == serverType {
+				updatedSession = foundSession
+				returnedStates = states
+				return nil
+			}
+
+			updatedSession.ServerId = serverId
+			updatedSession.ServerType = serverType
+			rowsUpdated, err := w.Update(ctx, &updatedSession, []string{"ServerId", "ServerType"}, nil, db.WithVersion(&foundSession.Version))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsUpdated != 1 {
+				// return err, which will result in a rollback of the update
+				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("%d would have been updated for session %s", rowsUpdated, sessionId))
+			}
+			returnedStates = states
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	return &updatedSession, returnedStates, nil
+}
+
 // updateState will update the session's state using the session id and its
 // version. updateState is idempotent. States are ordered by start time
 // descending. No options are currently supported.
@@ -1514,6 +1514,101 @@ func TestRepository_ActivateSession(t *testing.T) {
 	}
 }
 
+func TestRepository_ResumeSession(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	worker := TestWorker(t, conn, wrapper)
+	otherWorker := TestWorker(t, conn, wrapper)
+
+	tofu := TestTofu(t)
+	activeSession := func() *Session {
+		s := TestDefaultSession(t, conn, wrapper, iamRepo)
+		s, _, err := repo.ActivateSession(context.Background(), s.PublicId, s.Version, worker.PrivateId, worker.Type, tofu)
+		require.NoError(t, err)
+		return s
+	}
+	tests := []struct {
+		name        string
+		session     *Session
+		serverId    string
+		tofu        []byte
+		wantIsError errors.Code
+	}{
+		{
+			name:     "valid",
+			session:  activeSession(),
+			serverId: otherWorker.PrivateId,
+			tofu:     tofu,
+		},
+		{
+			name:     "same-worker",
+			session:  activeSession(),
+			serverId: worker.PrivateId,
+			tofu:     tofu,
+		},
+		{
+			name:        "pending",
+			session:     TestDefaultSession(t, conn, wrapper, iamRepo),
+			serverId:    otherWorker.PrivateId,
+			tofu:        tofu,
+			wantIsError: errors.InvalidSessionState,
+		},
+		{
+			name:        "tofu-mismatch",
+			session:     activeSession(),
+			serverId:    otherWorker.PrivateId,
+			tofu:        TestTofu(t),
+			wantIsError: errors.TokenMismatch,
+		},
+		{
+			name:        "empty-server-id",
+			session:     activeSession(),
+			tofu:        tofu,
+			wantIsError: errors.InvalidParameter,
+		},
+		{
+			name:        "empty-tofu",
+			session:     activeSession(),
+			serverId:    otherWorker.PrivateId,
+			wantIsError: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, ss, err := repo.ResumeSession(context.Background(), tt.session.PublicId, tt.serverId, worker.Type, tt.tofu)
+			if tt.wantIsError != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsError), err), "unexpected error %s", err.Error())
+				return
+			}
+			require.NoError(err)
+			require.NotNil(s)
+			require.NotEmpty(ss)
+			assert.Equal(StatusActive, ss[0].Status)
+
+			found, _, err := repo.LookupSession(context.Background(), tt.session.PublicId)
+			require.NoError(err)
+			assert.Equal(tt.serverId, found.ServerId)
+		})
+	}
+	t.Run("canceled", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := activeSession()
+		_, err := repo.CancelSession(context.Background(), s.PublicId, s.Version)
+		require.NoError(err)
+		_, _, err = repo.ResumeSession(context.Background(), s.PublicId, otherWorker.PrivateId, otherWorker.Type, tofu)
+		require.Error(err)
+		assert.Truef(errors.Match(errors.T(errors.InvalidSessionState), err), "unexpected error %s", err.Error())
+	})
+}
+
 func TestRepository_DeleteSession(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
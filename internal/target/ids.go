@@ -7,6 +7,7 @@ import (
 
 const (
 	TcpTargetPrefix = "ttcp"
+	UdpTargetPrefix = "tudp"
 )
 
 func newTcpTargetId() (string, error) {
@@ -17,3 +18,12 @@ func newTcpTargetId() (string, error) {
 	}
 	return id, nil
 }
+
+func newUdpTargetId() (string, error) {
+	const op = "target.newUdpTargetId"
+	id, err := db.NewPublicId(UdpTargetPrefix)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+	return id, nil
+}
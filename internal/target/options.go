@@ -30,6 +30,7 @@ type options struct {
 	withSessionConnectionLimit int32
 	withPublicId               string
 	withWorkerFilter           string
+	withOwnerId                string
 }
 
 func getDefaultOptions() options {
@@ -48,6 +49,7 @@ func getDefaultOptions() options {
 		withSessionConnectionLimit: 1,
 		withPublicId:               "",
 		withWorkerFilter:           "",
+		withOwnerId:                "",
 	}
 }
 
@@ -148,3 +150,10 @@ func WithWorkerFilter(filter string) Option {
 		o.withWorkerFilter = filter
 	}
 }
+
+// WithOwnerId provides an optional user to own a target being created.
+func WithOwnerId(id string) Option {
+	return func(o *options) {
+		o.withOwnerId = id
+	}
+}
@@ -104,4 +104,11 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withWorkerFilter = `"/foo" == "bar"`
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithOwnerId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithOwnerId("u_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withOwnerId = "u_1234567890"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -217,6 +217,11 @@ func (r *Repository) DeleteTarget(ctx context.Context, publicId string, _ ...Opt
 		tcpT.PublicId = publicId
 		deleteTarget = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_DELETE)
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = publicId
+		deleteTarget = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_DELETE)
 	default:
 		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is an unsupported target type %s", publicId, t.Type))
 	}
@@ -362,6 +367,12 @@ func (r *Repository) AddTargetHostSets(ctx context.Context, targetId string, tar
 		tcpT.Version = targetVersion + 1
 		target = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		target = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
 		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
 	default:
 		return nil, nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is an unsupported target type %s", t.PublicId, t.Type))
@@ -454,6 +465,12 @@ func (r *Repository) DeleteTargeHostSets(ctx context.Context, targetId string, t
 		tcpT.Version = targetVersion + 1
 		target = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		target = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
 		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
 	default:
 		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is an unsupported target type %s", t.PublicId, t.Type))
@@ -577,6 +594,12 @@ func (r *Repository) SetTargetHostSets(ctx context.Context, targetId string, tar
 		tcpT.Version = targetVersion + 1
 		target = &tcpT
 		metadata = tcpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
+	case UdpTargetType.String():
+		udpT := allocUdpTarget()
+		udpT.PublicId = t.PublicId
+		udpT.Version = targetVersion + 1
+		target = &udpT
+		metadata = udpT.oplog(oplog.OpType_OP_TYPE_UPDATE)
 	default:
 		return nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is an unsupported target type %s", t.PublicId, t.Type))
 	}
@@ -8,12 +8,14 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	dbcommon "github.com/hashicorp/boundary/internal/db/common"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
 )
 
 // CreateTcpTarget inserts into the repository and returns the new Target with
-// its list of host sets.  WithHostSets is currently the only supported option.
+// its list of host sets.  WithHostSets, WithPublicId and WithOwnerId are the
+// only supported options.
 func (r *Repository) CreateTcpTarget(ctx context.Context, target *TcpTarget, opt ...Option) (Target, []*TargetSet, error) {
 	const op = "target.(Repository).CreateTcpTarget"
 	opts := getOpts(opt...)
@@ -95,6 +97,11 @@ func (r *Repository) CreateTcpTarget(ctx context.Context, target *TcpTarget, opt
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
 				return errors.Wrap(err, op, errors.WithMsg("unable to write oplog"))
 			}
+			if opts.withOwnerId != "" {
+				if _, err := iam.CreateResourceOwnerTx(ctx, w, oplogWrapper, t.ScopeId, t.PublicId, opts.withOwnerId); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
 
 			return nil
 		},
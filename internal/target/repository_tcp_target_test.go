@@ -147,6 +147,45 @@ func TestRepository_CreateTcpTarget(t *testing.T) {
 	}
 }
 
+func TestRepository_CreateTcpTarget_WithOwnerId(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iamRepo)
+	u := iam.TestUser(t, iamRepo, org.PublicId)
+	ctx := context.Background()
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tar, err := NewTcpTarget(proj.PublicId, WithName("valid"))
+		require.NoError(err)
+		got, _, err := repo.CreateTcpTarget(ctx, tar, WithOwnerId(u.PublicId))
+		require.NoError(err)
+		found, err := iamRepo.ListResourceOwners(ctx, []string{got.GetPublicId()})
+		require.NoError(err)
+		assert.Equal(u.PublicId, found[got.GetPublicId()])
+	})
+	t.Run("unknown-owner", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tar, err := NewTcpTarget(proj.PublicId, WithName("unknown-owner"))
+		require.NoError(err)
+		_, _, err = repo.CreateTcpTarget(ctx, tar, WithOwnerId("u_1234567890"))
+		require.Error(err)
+
+		// The target isn't created without its owner
+		got, err := repo.ListTargets(ctx, WithScopeIds([]string{proj.PublicId}))
+		require.NoError(err)
+		for _, tar := range got {
+			assert.NotEqual("unknown-owner", tar.GetName())
+		}
+	})
+}
+
 func TestRepository_UpdateTcpTarget(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
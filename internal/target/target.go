@@ -32,6 +32,7 @@ type TargetType uint32
 const (
 	UnknownTargetType TargetType = 0
 	TcpTargetType     TargetType = 1
+	UdpTargetType     TargetType = 2
 )
 
 // String returns a string representation of the target type.
@@ -39,6 +40,7 @@ func (t TargetType) String() string {
 	return [...]string{
 		"unknown",
 		"tcp",
+		"udp",
 	}[t]
 }
 
@@ -97,6 +99,20 @@ func (t *targetView) targetSubType() (Target, error) {
 		tcpTarget.SessionConnectionLimit = t.SessionConnectionLimit
 		tcpTarget.WorkerFilter = t.WorkerFilter
 		return &tcpTarget, nil
+	case UdpTargetType.String():
+		udpTarget := allocUdpTarget()
+		udpTarget.PublicId = t.PublicId
+		udpTarget.ScopeId = t.ScopeId
+		udpTarget.Name = t.Name
+		udpTarget.Description = t.Description
+		udpTarget.DefaultPort = t.DefaultPort
+		udpTarget.CreateTime = t.CreateTime
+		udpTarget.UpdateTime = t.UpdateTime
+		udpTarget.Version = t.Version
+		udpTarget.SessionMaxSeconds = t.SessionMaxSeconds
+		udpTarget.SessionConnectionLimit = t.SessionConnectionLimit
+		udpTarget.WorkerFilter = t.WorkerFilter
+		return &udpTarget, nil
 	}
 	return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is an unknown target subtype of %s", t.PublicId, t.Type))
 }
@@ -8,80 +8,98 @@ type Type uint
 
 // not using iota intentionally, since the values are stored in the db as well.
 const (
-	Unknown          Type = 0
-	List             Type = 1
-	Create           Type = 2
-	Update           Type = 3
-	Read             Type = 4
-	Delete           Type = 5
-	Authenticate     Type = 6
-	All              Type = 7
-	AuthorizeSession Type = 8
-	AddGrants        Type = 9
-	RemoveGrants     Type = 10
-	SetGrants        Type = 11
-	AddPrincipals    Type = 12
-	SetPrincipals    Type = 13
-	RemovePrincipals Type = 14
-	Deauthenticate   Type = 15
-	AddMembers       Type = 16
-	SetMembers       Type = 17
-	RemoveMembers    Type = 18
-	SetPassword      Type = 19
-	ChangePassword   Type = 20
-	AddHosts         Type = 21
-	SetHosts         Type = 22
-	RemoveHosts      Type = 23
-	AddHostSets      Type = 24
-	SetHostSets      Type = 25
-	RemoveHostSets   Type = 26
-	Cancel           Type = 27
-	AddAccounts      Type = 28
-	SetAccounts      Type = 29
-	RemoveAccounts   Type = 30
-	ReadSelf         Type = 31
-	CancelSelf       Type = 32
-	ChangeState      Type = 33
-	DeleteSelf       Type = 34
-	NoOp             Type = 35
+	Unknown                  Type = 0
+	List                     Type = 1
+	Create                   Type = 2
+	Update                   Type = 3
+	Read                     Type = 4
+	Delete                   Type = 5
+	Authenticate             Type = 6
+	All                      Type = 7
+	AuthorizeSession         Type = 8
+	AddGrants                Type = 9
+	RemoveGrants             Type = 10
+	SetGrants                Type = 11
+	AddPrincipals            Type = 12
+	SetPrincipals            Type = 13
+	RemovePrincipals         Type = 14
+	Deauthenticate           Type = 15
+	AddMembers               Type = 16
+	SetMembers               Type = 17
+	RemoveMembers            Type = 18
+	SetPassword              Type = 19
+	ChangePassword           Type = 20
+	AddHosts                 Type = 21
+	SetHosts                 Type = 22
+	RemoveHosts              Type = 23
+	AddHostSets              Type = 24
+	SetHostSets              Type = 25
+	RemoveHostSets           Type = 26
+	Cancel                   Type = 27
+	AddAccounts              Type = 28
+	SetAccounts              Type = 29
+	RemoveAccounts           Type = 30
+	ReadSelf                 Type = 31
+	CancelSelf               Type = 32
+	ChangeState              Type = 33
+	DeleteSelf               Type = 34
+	NoOp                     Type = 35
+	RotateSshHostKey         Type = 36
+	AddGrantScopes           Type = 37
+	SetGrantScopes           Type = 38
+	RemoveGrantScopes        Type = 39
+	ExplainAuthorization     Type = 40
+	ReadEffectivePermissions Type = 41
+	SetLabels                Type = 42
+	ReadResolvedMembers      Type = 43
+	TransferOwnership        Type = 44
 )
 
 var Map = map[string]Type{
-	Create.String():           Create,
-	List.String():             List,
-	Update.String():           Update,
-	Read.String():             Read,
-	Delete.String():           Delete,
-	Authenticate.String():     Authenticate,
-	All.String():              All,
-	AuthorizeSession.String(): AuthorizeSession,
-	AddGrants.String():        AddGrants,
-	RemoveGrants.String():     RemoveGrants,
-	SetGrants.String():        SetGrants,
-	AddPrincipals.String():    AddPrincipals,
-	SetPrincipals.String():    SetPrincipals,
-	RemovePrincipals.String(): RemovePrincipals,
-	Deauthenticate.String():   Deauthenticate,
-	AddMembers.String():       AddMembers,
-	SetMembers.String():       SetMembers,
-	RemoveMembers.String():    RemoveMembers,
-	SetPassword.String():      SetPassword,
-	ChangePassword.String():   ChangePassword,
-	AddHosts.String():         AddHosts,
-	SetHosts.String():         SetHosts,
-	RemoveHosts.String():      RemoveHosts,
-	AddHostSets.String():      AddHostSets,
-	SetHostSets.String():      SetHostSets,
-	RemoveHostSets.String():   RemoveHostSets,
-	Cancel.String():           Cancel,
-	AddAccounts.String():      AddAccounts,
-	SetAccounts.String():      SetAccounts,
-	RemoveAccounts.String():   RemoveAccounts,
-	ReadSelf.String():         ReadSelf,
-	CancelSelf.String():       CancelSelf,
-	ChangeState.String():      ChangeState,
-	DeleteSelf.String():       DeleteSelf,
-	NoOp.String():             NoOp,
+	Create.String():                   Create,
+	List.String():                     List,
+	Update.String():                   Update,
+	Read.String():                     Read,
+	Delete.String():                   Delete,
+	Authenticate.String():             Authenticate,
+	All.String():                      All,
+	AuthorizeSession.String():         AuthorizeSession,
+	AddGrants.String():                AddGrants,
+	RemoveGrants.String():             RemoveGrants,
+	SetGrants.String():                SetGrants,
+	AddPrincipals.String():            AddPrincipals,
+	SetPrincipals.String():            SetPrincipals,
+	RemovePrincipals.String():         RemovePrincipals,
+	Deauthenticate.String():           Deauthenticate,
+	AddMembers.String():               AddMembers,
+	SetMembers.String():               SetMembers,
+	RemoveMembers.String():            RemoveMembers,
+	SetPassword.String():              SetPassword,
+	ChangePassword.String():           ChangePassword,
+	AddHosts.String():                 AddHosts,
+	SetHosts.String():                 SetHosts,
+	RemoveHosts.String():              RemoveHosts,
+	AddHostSets.String():              AddHostSets,
+	SetHostSets.String():              SetHostSets,
+	RemoveHostSets.String():           RemoveHostSets,
+	Cancel.String():                   Cancel,
+	AddAccounts.String():              AddAccounts,
+	SetAccounts.String():              SetAccounts,
+	RemoveAccounts.String():           RemoveAccounts,
+	ReadSelf.String():                 ReadSelf,
+	CancelSelf.String():               CancelSelf,
+	ChangeState.String():              ChangeState,
+	DeleteSelf.String():               DeleteSelf,
+	NoOp.String():                     NoOp,
+	RotateSshHostKey.String():         RotateSshHostKey,
+	AddGrantScopes.String():           AddGrantScopes,
+	SetGrantScopes.String():           SetGrantScopes,
+	RemoveGrantScopes.String():        RemoveGrantScopes,
+	ExplainAuthorization.String():     ExplainAuthorization,
+	ReadEffectivePermissions.String(): ReadEffectivePermissions,
+	SetLabels.String():                SetLabels,
+	ReadResolvedMembers.String():      ReadResolvedMembers,
+	TransferOwnership.String():        TransferOwnership,
 }
 
 func (a Type) String() string {
@@ -122,6 +140,15 @@ func (a Type) String() string {
 		"change-state",
 		"delete:self",
 		"no-op",
+		"rotate-ssh-host-key",
+		"add-grant-scopes",
+		"set-grant-scopes",
+		"remove-grant-scopes",
+		"explain-authorization",
+		"read-effective-permissions",
+		"set-labels",
+		"read-resolved-members",
+		"transfer-ownership",
 	}[a]
 }
 
@@ -95,6 +95,42 @@ func TestAction(t *testing.T) {
 			action: NoOp,
 			want:   "no-op",
 		},
+		{
+			action: RotateSshHostKey,
+			want:   "rotate-ssh-host-key",
+		},
+		{
+			action: AddGrantScopes,
+			want:   "add-grant-scopes",
+		},
+		{
+			action: SetGrantScopes,
+			want:   "set-grant-scopes",
+		},
+		{
+			action: RemoveGrantScopes,
+			want:   "remove-grant-scopes",
+		},
+		{
+			action: ExplainAuthorization,
+			want:   "explain-authorization",
+		},
+		{
+			action: ReadEffectivePermissions,
+			want:   "read-effective-permissions",
+		},
+		{
+			action: SetLabels,
+			want:   "set-labels",
+		},
+		{
+			action: ReadResolvedMembers,
+			want:   "read-resolved-members",
+		},
+		{
+			action: TransferOwnership,
+			want:   "transfer-ownership",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
@@ -483,7 +483,17 @@ var host = &Resource{
 				"Type": "host",
 				"Pin":  "<host-catalog-id>",
 			},
-			Actions: rudActions("a host", true),
+			Actions: append(
+				rudActions("a host", true),
+				&Action{
+					Name:        "rotate-ssh-host-key",
+					Description: "Trust the pending SSH host key of a host",
+					Examples: []string{
+						"id=<id>;actions=rotate-ssh-host-key",
+						"id=<pin>;type=<type>;actions=rotate-ssh-host-key",
+					},
+				},
+			),
 		},
 	},
 }